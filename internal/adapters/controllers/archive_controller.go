@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// ArchiveController периодически переносит давно закрытые хеджи в архив
+type ArchiveController struct {
+	archive  *usecases.HedgeArchiveUseCase
+	lease    *usecases.RoleLease // Аренда роли reporter (nil - архивация без согласования с другими экземплярами)
+	interval time.Duration
+}
+
+// NewArchiveController создает контроллер архивации
+func NewArchiveController(archive *usecases.HedgeArchiveUseCase, interval time.Duration) *ArchiveController {
+	return &ArchiveController{
+		archive:  archive,
+		interval: interval,
+	}
+}
+
+// WithLease подключает аренду роли reporter: при нескольких экземплярах архивирует только держатель
+func (a *ArchiveController) WithLease(lease *usecases.RoleLease) *ArchiveController {
+	a.lease = lease
+	return a
+}
+
+// Start архивирует хеджи сразу при запуске и затем с интервалом
+func (a *ArchiveController) Start(ctx context.Context) {
+	logger.LogWithTime("🗄️ Запуск архивации закрытых хеджей каждые %v", a.interval)
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	a.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			logger.LogWithTime("🛑 Архивация хеджей остановлена")
+			return
+		case <-ticker.C:
+			a.run(ctx)
+		}
+	}
+}
+
+// run выполняет одну архивацию
+func (a *ArchiveController) run(ctx context.Context) {
+	if a.lease != nil && !a.lease.Acquire(ctx) {
+		return
+	}
+	if _, err := a.archive.Archive(ctx, time.Now()); err != nil {
+		logger.LogWithTime("❌ Ошибка архивации хеджей: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// BalanceCheckController периодически сверяет открытые хеджи с балансами биржи и оповещает о расхождениях
+type BalanceCheckController struct {
+	balanceCheck *usecases.BalanceDivergenceUseCase
+	alerts       *usecases.AlertManager
+	lease        *usecases.RoleLease // Аренда роли reporter (nil - сверка без согласования с другими экземплярами)
+	interval     time.Duration
+}
+
+// NewBalanceCheckController создает контроллер сверки балансов
+func NewBalanceCheckController(balanceCheck *usecases.BalanceDivergenceUseCase, alerts *usecases.AlertManager, interval time.Duration) *BalanceCheckController {
+	return &BalanceCheckController{
+		balanceCheck: balanceCheck,
+		alerts:       alerts,
+		interval:     interval,
+	}
+}
+
+// WithLease подключает аренду роли reporter: при нескольких экземплярах сверку выполняет только держатель
+func (b *BalanceCheckController) WithLease(lease *usecases.RoleLease) *BalanceCheckController {
+	b.lease = lease
+	return b
+}
+
+// Start запускает периодическую сверку
+func (b *BalanceCheckController) Start(ctx context.Context) {
+	logger.LogWithTime("🧮 Запуск сверки балансов с открытыми хеджами каждые %v", b.interval)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.LogWithTime("🛑 Сверка балансов остановлена")
+			return
+		case <-ticker.C:
+			b.check(ctx)
+		}
+	}
+}
+
+// check оповещает о расхождениях (повторы группируются по активу) и об их устранении
+func (b *BalanceCheckController) check(ctx context.Context) {
+	if b.lease != nil && !b.lease.Acquire(ctx) {
+		return
+	}
+
+	divergences, err := b.balanceCheck.Check(ctx)
+	if err != nil {
+		logger.LogWithTime("❌ Ошибка сверки балансов: %v", err)
+		return
+	}
+
+	current := make(map[string]bool, len(divergences))
+	for _, divergence := range divergences {
+		key := usecases.AlertKeyBalance + divergence.Asset
+		current[key] = true
+		message := fmt.Sprintf("%s. Монеты могли быть проданы вручную или исполнение ордера пропущено - "+
+			"запустите сверку статусов (POST /api/check-status) и проверьте ордера на бирже", divergence.String())
+		b.alerts.Raise(ctx, entities.NewNotification(entities.NotificationPriorityHigh,
+			"Баланс не соответствует открытым хеджам", message).WithKey(key))
+	}
+	b.alerts.ResolveMissing(ctx, usecases.AlertKeyBalance, current)
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// criticalCheckInterval периодичность проверки критических условий
+const criticalCheckInterval = time.Minute
+
+// criticalPingTimeout таймаут проверки доступности базы данных
+const criticalPingTimeout = 5 * time.Second
+
+// CriticalConditionsController периодически проверяет критические условия, которые не видны по ошибкам
+// циклов: недоступность базы данных и хеджи, застрявшие без тейк-профита
+type CriticalConditionsController struct {
+	alerts     *usecases.AlertManager
+	database   repositories.PingableRepository    // nil - доступность базы не проверяется (хранилище в памяти)
+	intentRepo repositories.HedgeIntentRepository // nil - застрявшие хеджи не ищутся
+	stuckAfter time.Duration                      // Сколько позиция может оставаться без тейк-профита (0 - не проверяется)
+}
+
+// NewCriticalConditionsController создает контроллер критических условий
+func NewCriticalConditionsController(alerts *usecases.AlertManager) *CriticalConditionsController {
+	return &CriticalConditionsController{alerts: alerts}
+}
+
+// WithDatabase включает проверку доступности базы данных
+func (c *CriticalConditionsController) WithDatabase(database repositories.PingableRepository) *CriticalConditionsController {
+	c.database = database
+	return c
+}
+
+// WithStuckHedges включает поиск хеджей, застрявших в BUY_FILLED дольше stuckAfter
+func (c *CriticalConditionsController) WithStuckHedges(intentRepo repositories.HedgeIntentRepository, stuckAfter time.Duration) *CriticalConditionsController {
+	c.intentRepo = intentRepo
+	c.stuckAfter = stuckAfter
+	return c
+}
+
+// Start запускает периодическую проверку
+func (c *CriticalConditionsController) Start(ctx context.Context) {
+	logger.LogWithTime("🚨 Запуск проверки критических условий (база данных: %v, хеджи без тейк-профита дольше %v)",
+		c.database != nil, c.stuckAfter)
+
+	ticker := time.NewTicker(criticalCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.LogWithTime("🛑 Проверка критических условий остановлена")
+			return
+		case now := <-ticker.C:
+			c.check(ctx, now)
+		}
+	}
+}
+
+// check оповещает о критических условиях и об их устранении
+func (c *CriticalConditionsController) check(ctx context.Context, now time.Time) {
+	if c.database != nil && !c.checkDatabase(ctx) {
+		// Без базы застрявшие хеджи не найти - их группы остаются открытыми до восстановления
+		return
+	}
+	if c.intentRepo != nil && c.stuckAfter > 0 {
+		c.checkStuckHedges(ctx, now)
+	}
+}
+
+// checkDatabase проверяет доступность базы данных; повторы недоступности эскалируются AlertManager
+func (c *CriticalConditionsController) checkDatabase(ctx context.Context) bool {
+	pingCtx, cancel := context.WithTimeout(ctx, criticalPingTimeout)
+	defer cancel()
+
+	if err := c.database.Ping(pingCtx); err != nil {
+		logger.LogWithTime("❌ База данных недоступна: %v", err)
+		c.alerts.Raise(ctx, entities.NewNotification(entities.NotificationPriorityNormal,
+			"База данных недоступна", err.Error()).WithKey(usecases.AlertKeyDatabase))
+		return false
+	}
+	c.alerts.Resolve(ctx, usecases.AlertKeyDatabase)
+	return true
+}
+
+// checkStuckHedges оповещает о каждой позиции без тейк-профита сразу с высоким приоритетом:
+// порог уже означает, что восстановление не справилось, а позиция не защищена
+func (c *CriticalConditionsController) checkStuckHedges(ctx context.Context, now time.Time) {
+	stuck, err := usecases.FindStuckHedges(ctx, c.intentRepo, c.stuckAfter, now)
+	if err != nil {
+		logger.LogWithTime("⚠️ Ошибка поиска хеджей без тейк-профита: %v", err)
+		return
+	}
+
+	current := make(map[string]bool, len(stuck))
+	for _, intent := range stuck {
+		key := usecases.AlertKeyStuckHedge + intent.ClientOrderID
+		current[key] = true
+		c.alerts.Raise(ctx, entities.NewNotification(entities.NotificationPriorityHigh, "Позиция без тейк-профита",
+			fmt.Sprintf("%s (сделка Freqtrade %d, покупка %s): куплено %.8f, тейк-профит не выставлен %v",
+				intent.Pair, intent.FreqtradeTradeID, intent.BuyOrderID, intent.FilledQty, now.Sub(intent.UpdatedAt).Round(time.Minute))).
+			WithKey(key))
+	}
+	c.alerts.ResolveMissing(ctx, usecases.AlertKeyStuckHedge, current)
+}
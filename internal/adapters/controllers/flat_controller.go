@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// FlatController контроллер ежедневного закрытия хеджей в заданное время
+type FlatController struct {
+	flatCloserUseCase *usecases.FlatCloserUseCase
+	hour              int
+	minute            int
+}
+
+// NewFlatController создает новый контроллер закрытия хеджей по времени (время - локальное, ЧЧ:ММ)
+func NewFlatController(flatCloserUseCase *usecases.FlatCloserUseCase, hour, minute int) *FlatController {
+	return &FlatController{
+		flatCloserUseCase: flatCloserUseCase,
+		hour:              hour,
+		minute:            minute,
+	}
+}
+
+// Start запускает ежедневное закрытие хеджей
+func (f *FlatController) Start(ctx context.Context) {
+	logger.LogWithTime("🌙 Запуск ежедневного закрытия хеджей в %02d:%02d", f.hour, f.minute)
+
+	for {
+		next := f.nextRun(time.Now())
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			logger.LogWithTime("🛑 Закрытие хеджей по времени остановлено")
+			return
+		case <-timer.C:
+			if _, err := f.flatCloserUseCase.CloseAll(ctx); err != nil {
+				logger.LogWithTime("❌ Ошибка закрытия хеджей по времени: %v", err)
+			}
+		}
+	}
+}
+
+// nextRun возвращает ближайший момент запуска после now
+func (f *FlatController) nextRun(now time.Time) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), f.hour, f.minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
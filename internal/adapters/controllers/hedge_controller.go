@@ -20,8 +20,9 @@ func NewHedgeController(hedgeUseCase *usecases.HedgeStrategyUseCase) *HedgeContr
 	}
 }
 
-// ExecuteHedgeStrategy выполняет стратегию хеджирования с выводом результатов
-func (h *HedgeController) ExecuteHedgeStrategy(ctx context.Context) {
+// ExecuteHedgeStrategy выполняет стратегию хеджирования с выводом результатов.
+// Возвращает непредвиденную ошибку цикла (nil - цикл завершен, в том числе без действий)
+func (h *HedgeController) ExecuteHedgeStrategy(ctx context.Context) error {
 	logger.LogWithTime("🚀 Запуск стратегии хеджирования убытков")
 
 	err := h.hedgeUseCase.ExecuteHedgeStrategy(ctx)
@@ -30,13 +31,14 @@ func (h *HedgeController) ExecuteHedgeStrategy(ctx context.Context) {
 		var strategyErr *domainErrors.StrategyError
 		if errors.As(err, &strategyErr) && strategyErr.IsExpected() {
 			logger.LogWithTime("✅ %s. Действия не требуются", err.Error())
-			return
+			return nil
 		}
 		// Используем log.Printf вместо log.Fatalf чтобы не останавливать приложение
 		logger.LogWithTime("❌ Ошибка выполнения стратегии: %v", err)
-		return
+		return err
 	}
 
 	logger.LogWithTime("🎉 Хеджирование выполнено успешно!")
 	logger.LogWithTime("💾 Полная информация о сделке сохранена в базе данных")
+	return nil
 }
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// LeaseController периодически продлевает аренду ведущего экземпляра
+type LeaseController struct {
+	lease    *usecases.InstanceLease
+	interval time.Duration
+}
+
+// NewLeaseController создает контроллер аренды. interval должен быть меньше срока аренды
+func NewLeaseController(lease *usecases.InstanceLease, interval time.Duration) *LeaseController {
+	return &LeaseController{
+		lease:    lease,
+		interval: interval,
+	}
+}
+
+// Start сразу получает аренду (или запрашивает ее передачу) и затем продлевает ее каждые interval
+func (l *LeaseController) Start(ctx context.Context) {
+	logger.LogWithTime("🔑 Экземпляр %s: продление аренды каждые %v", l.lease.InstanceID(), l.interval)
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	l.lease.Renew(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.LogWithTime("🛑 Продление аренды остановлено")
+			return
+		case <-ticker.C:
+			l.lease.Renew(ctx)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// RebalancerController контроллер для периодической ребалансировки прибыли
+type RebalancerController struct {
+	rebalancerUseCase *usecases.RebalancerUseCase
+	interval          time.Duration
+}
+
+// NewRebalancerController создает новый контроллер ребалансировки
+func NewRebalancerController(rebalancerUseCase *usecases.RebalancerUseCase, interval time.Duration) *RebalancerController {
+	return &RebalancerController{
+		rebalancerUseCase: rebalancerUseCase,
+		interval:          interval,
+	}
+}
+
+// Start запускает периодическую ребалансировку
+func (r *RebalancerController) Start(ctx context.Context) {
+	logger.LogWithTime("⚖️ Запуск периодической ребалансировки каждые %v", r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.LogWithTime("🛑 Ребалансировка остановлена")
+			return
+		case <-ticker.C:
+			if err := r.rebalancerUseCase.Rebalance(ctx); err != nil {
+				logger.LogWithTime("❌ Ошибка ребалансировки: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/pkg/cron"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// reportSchedule расписание сводки одного периода
+type reportSchedule struct {
+	period   string
+	schedule *cron.Schedule
+}
+
+// ReportController отправляет сводки хеджирования за день и неделю по расписаниям cron
+type ReportController struct {
+	reports   *usecases.ReportUseCase
+	lease     *usecases.RoleLease // Аренда роли reporter (nil - сводки без согласования с другими экземплярами)
+	schedules []reportSchedule
+}
+
+// NewReportController создает контроллер сводок. Пустое расписание отключает сводку своего периода;
+// расписания проверяются при загрузке конфигурации, поэтому ошибка здесь означает ошибку вызова
+func NewReportController(reports *usecases.ReportUseCase, dailySchedule, weeklySchedule string) (*ReportController, error) {
+	controller := &ReportController{reports: reports}
+	for _, item := range []struct{ period, expr string }{
+		{entities.ReportPeriodDaily, dailySchedule},
+		{entities.ReportPeriodWeekly, weeklySchedule},
+	} {
+		if item.expr == "" {
+			continue
+		}
+		schedule, err := cron.Parse(item.expr)
+		if err != nil {
+			return nil, err
+		}
+		controller.schedules = append(controller.schedules, reportSchedule{period: item.period, schedule: schedule})
+	}
+	return controller, nil
+}
+
+// WithLease подключает аренду роли reporter: при нескольких экземплярах сводки отправляет только держатель
+func (r *ReportController) WithLease(lease *usecases.RoleLease) *ReportController {
+	r.lease = lease
+	return r
+}
+
+// Start ждет ближайшего запуска по расписаниям и отправляет сводки, чье время наступило
+func (r *ReportController) Start(ctx context.Context) {
+	if len(r.schedules) == 0 {
+		logger.LogWithTime("📊 Сводки хеджирования не запланированы: расписания не заданы")
+		return
+	}
+	for _, item := range r.schedules {
+		logger.LogWithTime("📊 Сводка хеджирования (%s) по расписанию «%s», ближайшая: %s",
+			item.period, item.schedule, item.schedule.Next(time.Now()).Format("2006-01-02 15:04"))
+	}
+
+	for {
+		now := time.Now()
+		var next time.Time
+		for _, item := range r.schedules {
+			if at := item.schedule.Next(now); !at.IsZero() && (next.IsZero() || at.Before(next)) {
+				next = at
+			}
+		}
+		if next.IsZero() {
+			logger.LogWithTime("⚠️ Расписания сводок не наступают: сводки остановлены")
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			logger.LogWithTime("🛑 Отправка сводок хеджирования остановлена")
+			return
+		case <-timer.C:
+		}
+		r.run(ctx, next)
+	}
+}
+
+// run отправляет сводки, расписание которых включает минуту at
+func (r *ReportController) run(ctx context.Context, at time.Time) {
+	if r.lease != nil && !r.lease.Acquire(ctx) {
+		return
+	}
+	for _, item := range r.schedules {
+		if !item.schedule.Matches(at) {
+			continue
+		}
+		if err := r.reports.SendReport(ctx, item.period, time.Now()); err != nil {
+			logger.LogWithTime("❌ Ошибка отправки сводки хеджирования (%s): %v", item.period, err)
+			continue
+		}
+		logger.LogWithTime("📊 Сводка хеджирования (%s) отправлена", item.period)
+	}
+}
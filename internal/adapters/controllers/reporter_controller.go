@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// ReporterController периодически рассчитывает итоги хеджирования на экземпляре с ролью reporter
+// без роли executor (в одном процессе итоги рассчитывает SchedulerController)
+type ReporterController struct {
+	outcomeUseCase *usecases.HedgeOutcomeUseCase
+	lease          *usecases.RoleLease
+	interval       time.Duration
+}
+
+// NewReporterController создает контроллер роли reporter. lease может быть nil -
+// тогда итоги рассчитываются без согласования с другими экземплярами
+func NewReporterController(outcomeUseCase *usecases.HedgeOutcomeUseCase, lease *usecases.RoleLease, interval time.Duration) *ReporterController {
+	return &ReporterController{
+		outcomeUseCase: outcomeUseCase,
+		lease:          lease,
+		interval:       interval,
+	}
+}
+
+// Start запускает периодический расчет итогов
+func (r *ReporterController) Start(ctx context.Context) {
+	logger.LogWithTime("📒 Запуск расчета итогов хеджирования каждые %v", r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.report(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.LogWithTime("🛑 Расчет итогов хеджирования остановлен")
+			return
+		case <-ticker.C:
+			r.report(ctx)
+		}
+	}
+}
+
+// report рассчитывает итоги, если роль reporter не выполняет другой экземпляр
+func (r *ReporterController) report(ctx context.Context) {
+	if r.lease != nil && !r.lease.Acquire(ctx) {
+		return
+	}
+	if _, err := r.outcomeUseCase.ReconcileOutcomes(ctx); err != nil {
+		logger.LogWithTime("❌ Ошибка расчета итогов хеджирования: %v", err)
+	}
+}
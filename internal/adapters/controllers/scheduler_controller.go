@@ -2,8 +2,13 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
 	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/pkg/tracing"
 	"trade-hedge/internal/usecases"
 )
 
@@ -11,51 +16,350 @@ import (
 type SchedulerController struct {
 	hedgeUseCase         *usecases.HedgeStrategyUseCase
 	statusCheckerUseCase *usecases.StatusCheckerUseCase
-	interval             time.Duration
+	outcomeUseCase       *usecases.HedgeOutcomeUseCase
+	watchdog             *usecases.Watchdog
+	lease                *usecases.InstanceLease
+	alerts               *usecases.AlertManager     // Оповещения об ошибках циклов (nil - только лог)
+	control              *usecases.SchedulerControl // Пауза автоматического хеджирования и состояние циклов
+	notifier             services.Notifier          // Оповещения о завершенных циклах (nil - не отправляются)
+	heartbeat            services.Heartbeat         // Отметка успешных циклов для внешнего мониторинга (nil - не отправляется)
+	authFailed           bool                       // В текущем цикле биржа отклонила ключ API
+	schedule             *usecases.CycleSchedule    // Интервал или расписание cron и тихие часы
+	cycleTimeout         time.Duration              // Ограничение длительности цикла (0 - без ограничения)
 }
 
-// NewSchedulerController создает новый scheduler контроллер
-func NewSchedulerController(hedgeUseCase *usecases.HedgeStrategyUseCase, statusCheckerUseCase *usecases.StatusCheckerUseCase, interval time.Duration) *SchedulerController {
+// NewSchedulerController создает новый scheduler контроллер с циклами каждые interval.
+// outcomeUseCase может быть nil - тогда итоги хеджирования не рассчитываются
+func NewSchedulerController(hedgeUseCase *usecases.HedgeStrategyUseCase, statusCheckerUseCase *usecases.StatusCheckerUseCase, outcomeUseCase *usecases.HedgeOutcomeUseCase, interval time.Duration) *SchedulerController {
+	schedule := usecases.NewCycleSchedule(interval)
 	return &SchedulerController{
 		hedgeUseCase:         hedgeUseCase,
 		statusCheckerUseCase: statusCheckerUseCase,
-		interval:             interval,
+		outcomeUseCase:       outcomeUseCase,
+		control:              usecases.NewSchedulerControl(schedule),
+		schedule:             schedule,
 	}
 }
 
+// WithSchedule заменяет интервал циклов расписанием: cron (strategy.schedule) и тихие часы (strategy.quiet_hours)
+func (s *SchedulerController) WithSchedule(schedule *usecases.CycleSchedule) *SchedulerController {
+	s.schedule = schedule
+	s.control.WithSchedule(schedule)
+	return s
+}
+
+// WithCycleTimeout ограничивает длительность цикла (strategy.cycle_timeout): по истечении timeout контекст
+// цикла отменяется, и зависшие запросы к бирже или ожидания исполнения не задерживают следующие циклы
+func (s *SchedulerController) WithCycleTimeout(timeout time.Duration) *SchedulerController {
+	s.cycleTimeout = timeout
+	s.control.WithCycleTimeout(timeout)
+	return s
+}
+
+// Control возвращает управляемое состояние планировщика (пауза и возобновление из веб-интерфейса)
+func (s *SchedulerController) Control() *usecases.SchedulerControl {
+	return s.control
+}
+
+// WithWatchdog подключает сторожевой таймер: успешные циклы стратегии и проверки статусов отмечаются в нем
+func (s *SchedulerController) WithWatchdog(watchdog *usecases.Watchdog) *SchedulerController {
+	s.watchdog = watchdog
+	if watchdog != nil {
+		watchdog.Track(usecases.WatchdogStrategy)
+		if s.statusCheckerUseCase != nil {
+			watchdog.Track(usecases.WatchdogStatusCheck)
+		}
+	}
+	return s
+}
+
+// WithLease подключает аренду ведущего экземпляра: без аренды циклы пропускаются,
+// в режиме завершения сопровождаются только уже размещенные ордера
+func (s *SchedulerController) WithLease(lease *usecases.InstanceLease) *SchedulerController {
+	s.lease = lease
+	return s
+}
+
+// WithAlerts подключает оповещения об ошибках циклов стратегии и проверки статусов:
+// повторы одной ошибки группируются, после успешного цикла приходит оповещение об устранении
+func (s *SchedulerController) WithAlerts(alerts *usecases.AlertManager) *SchedulerController {
+	s.alerts = alerts
+	return s
+}
+
+// WithNotifier включает оповещения о каждом завершенном цикле (cycle_completed) - для внешней автоматизации
+// через webhook; в лог о циклах и так выводится все, поэтому правила по умолчанию не направляют их в лог
+func (s *SchedulerController) WithNotifier(notifier services.Notifier) *SchedulerController {
+	s.notifier = notifier
+	return s
+}
+
+// WithHeartbeat включает отметку каждого успешного цикла для внешнего мониторинга. Экземпляр без аренды
+// циклы пропускает и не отмечает: при общем адресе пинга отметки отправляет тот, кто работает
+func (s *SchedulerController) WithHeartbeat(heartbeat services.Heartbeat) *SchedulerController {
+	s.heartbeat = heartbeat
+	return s
+}
+
 // Start запускает периодическое выполнение стратегии
 func (s *SchedulerController) Start(ctx context.Context) {
-	logger.LogWithTime("🕒 Запуск периодической проверки каждые %v", s.interval)
+	logger.LogWithTime("🕒 Запуск периодической проверки %s", s.schedule)
+	if s.cycleTimeout > 0 {
+		logger.LogWithTime("⌛ Длительность цикла ограничена %v", s.cycleTimeout)
+	}
 
-	ticker := time.NewTicker(s.interval)
-	defer ticker.Stop()
+	s.control.Started()
+	defer s.control.Stopped()
+
+	// Внеочередные проверки статусов после размещения ордеров прекращаются вместе с планировщиком
+	if s.statusCheckerUseCase != nil {
+		s.statusCheckerUseCase.WithLifetime(ctx)
+	}
 
 	// Выполняем сразу при запуске
-	s.executeStrategy(ctx)
+	next := s.runCycle(ctx, s.schedule.Next(time.Now(), time.Now()))
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	s.waitUntil(timer, next)
 
 	for {
 		select {
 		case <-ctx.Done():
 			logger.LogWithTime("🛑 Получен сигнал остановки")
 			return
-		case <-ticker.C:
-			s.executeStrategy(ctx)
+		case <-timer.C:
+			next = s.schedule.Next(next, time.Now())
+		case <-s.control.Resumed():
+			// После снятия паузы цикл выполняется сразу, следующий - по расписанию от момента снятия
+			next = s.schedule.Next(time.Now(), time.Now())
 		}
+		next = s.runCycle(ctx, next)
+		s.waitUntil(timer, next)
 	}
 }
 
+// runCycle выполняет цикл и возвращает время следующего. Если цикл выполнялся дольше периода расписания,
+// циклы, время которых прошло, пропускаются: следующий начинается по расписанию, а не сразу за долгим
+func (s *SchedulerController) runCycle(ctx context.Context, next time.Time) time.Time {
+	s.control.ScheduleNext(next)
+	start := time.Now()
+	s.executeStrategy(ctx)
+
+	rescheduled, missed := s.schedule.Overrun(next, time.Now())
+	if missed == 0 {
+		return next
+	}
+	s.control.CycleOverrun(missed)
+	s.control.ScheduleNext(rescheduled)
+	logger.LogWithTime("⚠️ Цикл выполнялся %v и не уложился в расписание %s - пропущено циклов: %d",
+		time.Since(start).Round(time.Millisecond), s.schedule, missed)
+	return rescheduled
+}
+
+// waitUntil перезапускает таймер до времени следующего цикла. Если расписание больше не наступает,
+// таймер останавливается, и циклы выполняются только после снятия паузы
+func (s *SchedulerController) waitUntil(timer *time.Timer, next time.Time) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	if next.IsZero() {
+		logger.LogWithTime("⚠️ Расписание циклов %s больше не наступает", s.schedule)
+		return
+	}
+	timer.Reset(time.Until(next))
+}
+
 // executeStrategy выполняет одну итерацию стратегии
 func (s *SchedulerController) executeStrategy(ctx context.Context) {
 	// Добавляем отступ для лучшей читаемости логов
 	logger.LogPlain("\n")
 	logger.LogWithTime("⏰ Проверка позиций...")
 
+	// Цикл не начинается, пока выполняется предыдущий (например, внеочередной запуск из веб-интерфейса)
+	if !s.control.CycleStarted() {
+		logger.LogWithTime("⏭️ Предыдущий цикл стратегии еще выполняется - цикл пропущен")
+		return
+	}
+	defer s.control.CycleFinished()
+
+	// Трасса на каждый цикл: проверка статусов, хеджирование сделок, запросы к бирже и базе - дочерние спаны
+	ctx, span := tracing.Start(ctx, "scheduler.cycle")
+	defer span.End()
+	if traceID := span.TraceID(); traceID != "" {
+		logger.LogWithTime("🔭 Трасса цикла: %s", traceID)
+	}
+
+	if s.lease != nil {
+		// Цикл отмечается до проверки состояния, чтобы аренда не была освобождена между проверкой и работой
+		s.lease.CycleStarted()
+		defer s.lease.CycleFinished()
+		if !s.lease.CanManageOrders() {
+			logger.LogWithTime("⏸️ Экземпляр %s не держит аренду (%s) - цикл пропущен", s.lease.InstanceID(), s.lease.State())
+			return
+		}
+	}
+
+	// Работа цикла ограничена strategy.cycle_timeout; оповещения о цикле отправляются в исходном контексте
+	cycleCtx, cancel := s.cycleContext(ctx)
+	defer cancel()
+
+	// Оповещаем о завершении цикла: режим (hedge, drain, paused, quiet), длительность и ошибка стратегии
+	cycleStart := time.Now()
+	mode := "hedge"
+	var cycleErr, statusErr error
+	defer func() {
+		if errors.Is(cycleCtx.Err(), context.DeadlineExceeded) {
+			logger.LogWithTime("⌛ Цикл прерван по таймауту %v (режим %s)", s.cycleTimeout, mode)
+			s.control.CycleTimedOut()
+			if cycleErr == nil {
+				cycleErr = fmt.Errorf("цикл прерван по таймауту %v", s.cycleTimeout)
+			}
+		}
+		span.SetAttributes(tracing.String("mode", mode))
+		span.RecordError(cycleErr)
+		s.notifyCycleCompleted(ctx, mode, time.Since(cycleStart), cycleErr)
+		if cycleErr == nil && statusErr == nil {
+			s.beat(ctx)
+		}
+	}()
+
+	// Отказ в аутентификации устранен, только если ни одна часть цикла с ним не столкнулась
+	s.authFailed = false
+	defer func() {
+		if s.alerts != nil && !s.authFailed {
+			s.alerts.Resolve(ctx, usecases.AlertKeyExchangeAuth)
+		}
+	}()
+
 	// 1. Сначала проверяем статусы существующих хеджированных ордеров
-	if err := s.statusCheckerUseCase.CheckAllActiveOrders(ctx); err != nil {
-		logger.LogWithTime("❌ Ошибка проверки статусов ордеров: %v", err)
+	// (проверка не подключается в режимах без доступа к бирже)
+	if s.statusCheckerUseCase != nil {
+		statusErr = s.statusCheckerUseCase.CheckAllActiveOrders(cycleCtx)
+		if statusErr != nil {
+			logger.LogWithTime("❌ Ошибка проверки статусов ордеров: %v", statusErr)
+		} else {
+			s.markCompleted(usecases.WatchdogStatusCheck)
+		}
+		s.reportCycle(ctx, usecases.AlertKeyStatusCheck, "Ошибка проверки статусов ордеров", statusErr)
 	}
 
-	// 2. Затем проверяем новые сделки для хеджирования
+	// 2. Рассчитываем итоги для сделок, закрытых в Freqtrade
+	if s.outcomeUseCase != nil {
+		if _, err := s.outcomeUseCase.ReconcileOutcomes(cycleCtx); err != nil {
+			logger.LogWithTime("❌ Ошибка расчета итогов хеджирования: %v", err)
+		}
+	}
+
+	// В режиме завершения новые хеджи не открываются - только доводим начатые до тейк-профита
+	if s.lease != nil && !s.lease.CanHedge() {
+		if err := s.hedgeUseCase.Recovery().RecoverInFlightHedges(cycleCtx); err != nil {
+			logger.LogWithTime("❌ Ошибка завершения начатых хеджей: %v", err)
+		}
+		logger.LogWithTime("🚰 Режим завершения: новые хеджи не открываются")
+		mode = "drain"
+		return
+	}
+
+	// На паузе оператора новые хеджи не открываются, открытые сопровождаются проверкой статусов
+	if s.control.Paused() {
+		logger.LogWithTime("⏸️ Автоматическое хеджирование приостановлено - новые сделки не проверяются")
+		s.markCompleted(usecases.WatchdogStrategy)
+		mode = "paused"
+		return
+	}
+
+	// В тихие часы (низкая ликвидность) так же, как на паузе: только сопровождение открытых хеджей
+	if window, quiet := s.schedule.QuietWindow(cycleStart); quiet {
+		logger.LogWithTime("🌙 Тихие часы %s (%s) - новые сделки не проверяются", window, s.schedule.Location())
+		s.markCompleted(usecases.WatchdogStrategy)
+		mode = "quiet"
+		return
+	}
+
+	// 3. Затем проверяем новые сделки для хеджирования
 	hedgeController := NewHedgeController(s.hedgeUseCase)
-	hedgeController.ExecuteHedgeStrategy(ctx)
+	err := hedgeController.ExecuteHedgeStrategy(cycleCtx)
+	if err == nil {
+		s.markCompleted(usecases.WatchdogStrategy)
+	}
+	s.reportCycle(ctx, usecases.AlertKeyStrategy, "Ошибка выполнения стратегии", err)
+	cycleErr = err
+}
+
+// cycleContext возвращает контекст работы цикла с таймаутом strategy.cycle_timeout (если задан)
+func (s *SchedulerController) cycleContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.cycleTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.cycleTimeout)
+}
+
+// notifyCycleCompleted оповещает о завершенном цикле (если оповещения о циклах подключены)
+func (s *SchedulerController) notifyCycleCompleted(ctx context.Context, mode string, duration time.Duration, cycleErr error) {
+	if s.notifier == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"mode":        mode,
+		"duration_ms": duration.Milliseconds(),
+		"success":     cycleErr == nil,
+	}
+	if traceID := tracing.FromContext(ctx).TraceID(); traceID != "" {
+		data["trace_id"] = traceID
+	}
+	priority := entities.NotificationPriorityLow
+	message := fmt.Sprintf("Цикл завершен за %s (режим %s)", duration.Round(time.Millisecond), mode)
+	if cycleErr != nil {
+		data["error"] = cycleErr.Error()
+		priority = entities.NotificationPriorityNormal
+		message += ": " + cycleErr.Error()
+	}
+
+	notification := entities.NewNotification(priority, "Цикл стратегии завершен", message).
+		WithEvent(entities.NotificationEventCycleCompleted, data)
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		logger.LogWithTime("❌ Ошибка отправки оповещения о завершении цикла: %v", err)
+	}
+}
+
+// reportCycle оповещает об ошибке цикла или об ее устранении после успешного цикла (если оповещения подключены).
+// Отказ биржи в аутентификации - критическое условие: его повторы группируются под общим ключом
+// независимо от того, какой цикл с ним столкнулся
+func (s *SchedulerController) reportCycle(ctx context.Context, key, title string, err error) {
+	if s.alerts == nil {
+		return
+	}
+	if err == nil {
+		s.alerts.Resolve(ctx, key)
+		return
+	}
+	if usecases.IsExchangeAuthError(err) {
+		s.authFailed = true
+		s.alerts.Raise(ctx, entities.NewNotification(entities.NotificationPriorityNormal,
+			"Биржа отклоняет ключ API", err.Error()).WithKey(usecases.AlertKeyExchangeAuth))
+		return
+	}
+	s.alerts.Raise(ctx, entities.NewNotification(entities.NotificationPriorityNormal, title, err.Error()).WithKey(key))
+}
+
+// beat отмечает успешный цикл для внешнего мониторинга (если отметка подключена)
+func (s *SchedulerController) beat(ctx context.Context) {
+	if s.heartbeat == nil {
+		return
+	}
+	if err := s.heartbeat.Beat(ctx); err != nil {
+		logger.LogWithTime("⚠️ Ошибка отметки цикла для мониторинга: %v", err)
+	}
+}
+
+// markCompleted отмечает завершение цикла в сторожевом таймере (если подключен)
+func (s *SchedulerController) markCompleted(component string) {
+	if s.watchdog != nil {
+		s.watchdog.MarkCompleted(component)
+	}
 }
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// StatusCheckController периодически проверяет статусы ордеров на экземпляре с ролью status-checker
+// без роли executor (в одном процессе проверку выполняет SchedulerController)
+type StatusCheckController struct {
+	statusCheckerUseCase *usecases.StatusCheckerUseCase
+	watchdog             *usecases.Watchdog
+	interval             time.Duration
+}
+
+// NewStatusCheckController создает контроллер проверки статусов
+func NewStatusCheckController(statusCheckerUseCase *usecases.StatusCheckerUseCase, interval time.Duration) *StatusCheckController {
+	return &StatusCheckController{
+		statusCheckerUseCase: statusCheckerUseCase,
+		interval:             interval,
+	}
+}
+
+// WithWatchdog подключает сторожевой таймер: успешные проверки статусов отмечаются в нем
+func (s *StatusCheckController) WithWatchdog(watchdog *usecases.Watchdog) *StatusCheckController {
+	s.watchdog = watchdog
+	if watchdog != nil {
+		watchdog.Track(usecases.WatchdogStatusCheck)
+	}
+	return s
+}
+
+// Start запускает периодическую проверку статусов
+func (s *StatusCheckController) Start(ctx context.Context) {
+	logger.LogWithTime("🔍 Запуск проверки статусов ордеров каждые %v", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.check(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.LogWithTime("🛑 Проверка статусов остановлена")
+			return
+		case <-ticker.C:
+			s.check(ctx)
+		}
+	}
+}
+
+// check выполняет одну проверку статусов
+func (s *StatusCheckController) check(ctx context.Context) {
+	if err := s.statusCheckerUseCase.CheckAllActiveOrders(ctx); err != nil {
+		logger.LogWithTime("❌ Ошибка проверки статусов ордеров: %v", err)
+		return
+	}
+	if s.watchdog != nil {
+		s.watchdog.MarkCompleted(usecases.WatchdogStatusCheck)
+	}
+}
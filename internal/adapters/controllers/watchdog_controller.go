@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// watchdogCheckInterval периодичность проверки сторожевого таймера
+const watchdogCheckInterval = time.Minute
+
+// WatchdogController периодически проверяет сторожевой таймер и оповещает о зависших циклах
+type WatchdogController struct {
+	watchdog    *usecases.Watchdog
+	alerts      *usecases.AlertManager
+	exitOnStall bool
+}
+
+// NewWatchdogController создает контроллер сторожевого таймера.
+// exitOnStall - завершить процесс после оповещения, чтобы супервизор его перезапустил
+func NewWatchdogController(watchdog *usecases.Watchdog, alerts *usecases.AlertManager, exitOnStall bool) *WatchdogController {
+	return &WatchdogController{
+		watchdog:    watchdog,
+		alerts:      alerts,
+		exitOnStall: exitOnStall,
+	}
+}
+
+// Start запускает периодическую проверку
+func (w *WatchdogController) Start(ctx context.Context) {
+	logger.LogWithTime("🐕 Запуск сторожевого таймера (порог зависания %v)", w.watchdog.Threshold())
+
+	ticker := time.NewTicker(watchdogCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.LogWithTime("🛑 Сторожевой таймер остановлен")
+			return
+		case now := <-ticker.C:
+			w.check(ctx, now)
+		}
+	}
+}
+
+// check оповещает о зависших компонентах (повторы группируются по компоненту)
+// и о восстановлении ранее зависших
+func (w *WatchdogController) check(ctx context.Context, now time.Time) {
+	stalled := w.watchdog.Stalled(now)
+
+	current := make(map[string]bool, len(stalled))
+	for _, component := range stalled {
+		key := usecases.AlertKeyWatchdog + component.Name
+		current[key] = true
+		w.alerts.Raise(ctx, entities.NewNotification(entities.NotificationPriorityHigh, "Циклы не завершаются",
+			fmt.Sprintf("%s: нет завершенных циклов %v (последний %s)",
+				component.Name, component.Idle.Round(time.Minute), component.LastCompleted.Format("2006-01-02 15:04:05"))).
+			WithKey(key))
+	}
+	w.alerts.ResolveMissing(ctx, usecases.AlertKeyWatchdog, current)
+
+	if len(stalled) > 0 && w.exitOnStall {
+		logger.LogWithTime("🛑 Сторожевой таймер завершает процесс для перезапуска супервизором")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,130 @@
+package pairs
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/infrastructure/clients"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// CommandName название подкоманды проверки пар
+const CommandName = "pairs"
+
+// Options параметры проверки пар
+type Options struct {
+	Pairs       []string // Пары для проверки (пусто - whitelist Freqtrade)
+	Instruments []string // Файлы выгрузок символов (пусто - список инструментов с Bybit)
+	SaveDump    string   // Куда сохранить полученную с биржи выгрузку (пусто - не сохранять)
+}
+
+// stringList флаг, который можно указать несколько раз
+type stringList []string
+
+// String реализует flag.Value
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set реализует flag.Value
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// ParseOptions разбирает аргументы подкоманды: --pairs SOL/USDT,BTC/USDT --instruments bybit.json --save-dump bybit.json
+func ParseOptions(args []string, output io.Writer) (*Options, error) {
+	opts := &Options{}
+	var pairs string
+	var instruments stringList
+	flags := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&pairs, "pairs", "", "пары через запятую вместо whitelist Freqtrade")
+	flags.Var(&instruments, "instruments", "файл выгрузки символов вместо запроса к бирже (можно указать несколько)")
+	flags.StringVar(&opts.SaveDump, "save-dump", "", "сохранить полученную с биржи выгрузку символов в файл")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	for _, pair := range strings.Split(pairs, ",") {
+		if pair = strings.TrimSpace(pair); pair != "" {
+			opts.Pairs = append(opts.Pairs, pair)
+		}
+	}
+	opts.Instruments = instruments
+	if opts.SaveDump != "" && len(opts.Instruments) > 0 {
+		return nil, fmt.Errorf("--save-dump сохраняет выгрузку с биржи и не сочетается с --instruments")
+	}
+	return opts, nil
+}
+
+// RunCommand выполняет подкоманду pairs: пары whitelist Freqtrade (или --pairs) сверяются со списком
+// инструментов Bybit (или выгрузками --instruments). cfg нужен для запросов к Freqtrade и Bybit
+// (nil - только с --pairs и --instruments). Возвращает ошибку, если хотя бы одна пара не хеджируется
+func RunCommand(ctx context.Context, args []string, output io.Writer, cfg *config.Config) error {
+	opts, err := ParseOptions(args, output)
+	if err != nil {
+		return err
+	}
+	if cfg == nil && (len(opts.Pairs) == 0 || len(opts.Instruments) == 0) {
+		return fmt.Errorf("без конфигурации нужны --pairs и --instruments")
+	}
+
+	var freqtradeClient *clients.FreqtradeClient
+	var bybitClient *clients.BybitClient
+	if cfg != nil {
+		httpClient := clients.NewHTTPClient(&cfg.HTTP)
+		freqtradeClient = clients.NewFreqtradeClient(&cfg.Freqtrade, httpClient)
+		bybitClient = clients.NewBybitClient(&cfg.Bybit, httpClient)
+	}
+
+	pairs := opts.Pairs
+	if len(pairs) == 0 {
+		if pairs, err = freqtradeClient.GetWhitelist(ctx); err != nil {
+			return fmt.Errorf("ошибка получения whitelist Freqtrade: %w", err)
+		}
+		logger.LogWithTime("🔤 Whitelist Freqtrade: %d пар", len(pairs))
+	}
+	if len(pairs) == 0 {
+		return fmt.Errorf("нет пар для проверки: whitelist Freqtrade пуст")
+	}
+
+	var dumps []*Dump
+	if len(opts.Instruments) == 0 {
+		dump, err := FetchDump(ctx, valueobjects.ExchangeBybit, bybitClient)
+		if err != nil {
+			return err
+		}
+		if opts.SaveDump != "" {
+			if err := dump.Save(opts.SaveDump); err != nil {
+				return err
+			}
+			logger.LogWithTime("💾 Выгрузка символов %s сохранена: %s (%d инструментов)",
+				dump.Exchange, opts.SaveDump, len(dump.Instruments))
+		}
+		dumps = append(dumps, dump)
+	}
+	for _, path := range opts.Instruments {
+		dump, err := LoadDump(path)
+		if err != nil {
+			return err
+		}
+		dumps = append(dumps, dump)
+	}
+
+	failed := 0
+	for _, dump := range dumps {
+		report := Check(dump, pairs)
+		report.Print(output)
+		failed += report.Failed()
+	}
+	if failed > 0 {
+		return fmt.Errorf("пар без однозначного символа биржи: %d", failed)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package pairs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// Instrument инструмент биржи в выгрузке символов
+type Instrument struct {
+	Symbol    string `json:"symbol"`
+	BaseCoin  string `json:"base_coin"`
+	QuoteCoin string `json:"quote_coin"`
+	Status    string `json:"status,omitempty"` // Пусто - статус неизвестен, инструмент считается торгуемым
+}
+
+// Dump выгрузка символов биржи: сохраняется с биржи (--save-dump) и позволяет повторить проверку
+// без сети, в том числе для бирж без клиента в боте (выгрузка в том же формате готовится отдельно)
+type Dump struct {
+	Exchange    string       `json:"exchange"` // valueobjects.ExchangeBybit, ExchangeBinance, ExchangeKraken
+	FetchedAt   time.Time    `json:"fetched_at"`
+	Instruments []Instrument `json:"instruments"`
+
+	Source string `json:"-"` // Откуда получены инструменты (для отчета)
+}
+
+// FetchDump получает полный список спотовых инструментов биржи
+func FetchDump(ctx context.Context, exchange string, service services.InstrumentsExchangeService) (*Dump, error) {
+	instruments, err := service.GetInstruments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения инструментов %s: %w", exchange, err)
+	}
+
+	dump := &Dump{
+		Exchange:    exchange,
+		FetchedAt:   time.Now().UTC(),
+		Instruments: make([]Instrument, 0, len(instruments)),
+		Source:      "биржа",
+	}
+	for _, instrument := range instruments {
+		dump.Instruments = append(dump.Instruments, Instrument{
+			Symbol:    instrument.Symbol,
+			BaseCoin:  instrument.BaseCoin,
+			QuoteCoin: instrument.QuoteCoin,
+			Status:    instrument.Status,
+		})
+	}
+	sort.Slice(dump.Instruments, func(i, j int) bool {
+		return dump.Instruments[i].Symbol < dump.Instruments[j].Symbol
+	})
+	return dump, nil
+}
+
+// LoadDump читает выгрузку символов из файла
+func LoadDump(path string) (*Dump, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения выгрузки %s: %w", path, err)
+	}
+
+	var dump Dump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return nil, fmt.Errorf("ошибка разбора выгрузки %s: %w", path, err)
+	}
+	switch dump.Exchange {
+	case valueobjects.ExchangeBybit, valueobjects.ExchangeBinance, valueobjects.ExchangeKraken:
+	default:
+		return nil, fmt.Errorf("выгрузка %s: неподдерживаемая биржа %q", path, dump.Exchange)
+	}
+	dump.Source = path
+	return &dump, nil
+}
+
+// Save записывает выгрузку символов в файл
+func (d *Dump) Save(path string) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ошибка кодирования выгрузки: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("ошибка записи выгрузки %s: %w", path, err)
+	}
+	return nil
+}
+
+// suggest возвращает символы инструментов с той же базовой валютой (для подсказки в отчете)
+func (d *Dump) suggest(base string) []string {
+	var symbols []string
+	for _, instrument := range d.Instruments {
+		listed, err := valueobjects.FromExchangeSymbol(d.Exchange, instrument.BaseCoin+"/"+instrument.QuoteCoin)
+		if err != nil || listed.BaseCurrency() != base {
+			continue
+		}
+		symbols = append(symbols, instrument.Symbol)
+		if len(symbols) == maxSuggestions {
+			break
+		}
+	}
+	return symbols
+}
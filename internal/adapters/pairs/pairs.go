@@ -0,0 +1,180 @@
+// Package pairs реализует проверку соответствия пар Freqtrade символам биржи: команда trade-hedge pairs
+// прогоняет каждую пару whitelist через преобразование в символ биржи и сверяет результат с полным списком
+// инструментов, показывая пары, которые не найдутся на бирже или совпадут с другой парой, до развертывания
+package pairs
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// statusTrading статус инструмента Bybit, доступного для торговли
+const statusTrading = "Trading"
+
+// maxSuggestions сколько похожих символов показывать для ненайденной пары
+const maxSuggestions = 3
+
+// IssueKind вид расхождения пары и символа биржи
+type IssueKind string
+
+const (
+	// IssueUnmappable пара не преобразуется в символ биржи или символа нет в списке инструментов
+	IssueUnmappable IssueKind = "unmappable"
+	// IssueAmbiguous символ найден, но соответствует другой паре или нескольким парам whitelist
+	IssueAmbiguous IssueKind = "ambiguous"
+	// IssueNotTrading символ найден, но инструмент сейчас не торгуется (предупреждение)
+	IssueNotTrading IssueKind = "not_trading"
+)
+
+// Finding расхождение одной пары
+type Finding struct {
+	Pair   string
+	Symbol string // Символ биржи (пусто - пара не преобразуется)
+	Kind   IssueKind
+	Reason string
+}
+
+// Report результат проверки пар на одной бирже
+type Report struct {
+	Exchange    string
+	Source      string // Откуда получены инструменты: биржа или файл выгрузки
+	Instruments int
+	Pairs       int
+	Findings    []Finding
+}
+
+// Failed возвращает количество пар, которые нельзя хеджировать (без предупреждений)
+func (r *Report) Failed() int {
+	failed := 0
+	for _, finding := range r.Findings {
+		if finding.Kind != IssueNotTrading {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Check прогоняет пары через преобразование в символ биржи и сверяет с инструментами выгрузки
+func Check(dump *Dump, pairs []string) *Report {
+	report := &Report{
+		Exchange:    dump.Exchange,
+		Source:      dump.Source,
+		Instruments: len(dump.Instruments),
+		Pairs:       len(pairs),
+	}
+
+	bySymbol := make(map[string]Instrument, len(dump.Instruments))
+	for _, instrument := range dump.Instruments {
+		bySymbol[instrument.Symbol] = instrument
+	}
+
+	symbolPairs := make(map[string][]string)
+	for _, pair := range pairs {
+		finding := checkPair(dump, bySymbol, pair)
+		if finding.Symbol != "" {
+			symbolPairs[finding.Symbol] = append(symbolPairs[finding.Symbol], pair)
+		}
+		if finding.Kind != "" {
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+
+	// Несколько пар whitelist в один символ: хеджи и ордера одной пары будут приняты за другую
+	for symbol, mapped := range symbolPairs {
+		if len(mapped) < 2 {
+			continue
+		}
+		for _, pair := range mapped {
+			report.Findings = append(report.Findings, Finding{
+				Pair:   pair,
+				Symbol: symbol,
+				Kind:   IssueAmbiguous,
+				Reason: fmt.Sprintf("в символ %s преобразуются несколько пар: %s", symbol, strings.Join(mapped, ", ")),
+			})
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Pair < report.Findings[j].Pair
+	})
+	return report
+}
+
+// checkPair проверяет одну пару; Finding без Kind - расхождений нет
+func checkPair(dump *Dump, bySymbol map[string]Instrument, pair string) Finding {
+	finding := Finding{Pair: pair}
+
+	tp, err := valueobjects.ParseTradingPair(pair)
+	if err != nil {
+		finding.Kind, finding.Reason = IssueUnmappable, err.Error()
+		return finding
+	}
+	symbol, err := tp.ToExchangeFormat(dump.Exchange)
+	if err != nil {
+		finding.Kind, finding.Reason = IssueUnmappable, err.Error()
+		return finding
+	}
+	finding.Symbol = symbol
+
+	instrument, ok := bySymbol[symbol]
+	if !ok {
+		finding.Kind = IssueUnmappable
+		finding.Reason = fmt.Sprintf("символа %s нет среди инструментов %s", symbol, dump.Exchange)
+		if suggestions := dump.suggest(tp.BaseCurrency()); len(suggestions) > 0 {
+			finding.Reason += fmt.Sprintf(" (есть: %s)", strings.Join(suggestions, ", "))
+		}
+		return finding
+	}
+
+	// Монеты инструмента должны совпадать с парой: иначе ордер уйдет на другой рынок
+	spot := tp.BaseCurrency() + "/" + tp.QuoteCurrency()
+	if instrument.BaseCoin != "" && instrument.QuoteCoin != "" {
+		listed, err := valueobjects.FromExchangeSymbol(dump.Exchange, instrument.BaseCoin+"/"+instrument.QuoteCoin)
+		if err != nil || listed.String() != spot {
+			finding.Kind = IssueAmbiguous
+			finding.Reason = fmt.Sprintf("символ %s на бирже - рынок %s/%s, а не %s",
+				symbol, instrument.BaseCoin, instrument.QuoteCoin, spot)
+			return finding
+		}
+	}
+
+	// Обратное преобразование символа должно вернуть ту же пару
+	parsed, err := valueobjects.FromExchangeSymbol(dump.Exchange, symbol)
+	if err != nil || parsed.String() != spot {
+		finding.Kind = IssueAmbiguous
+		if err != nil {
+			finding.Reason = fmt.Sprintf("символ %s не читается обратно: %v", symbol, err)
+		} else {
+			finding.Reason = fmt.Sprintf("символ %s читается обратно как %s", symbol, parsed.String())
+		}
+		return finding
+	}
+
+	if instrument.Status != "" && instrument.Status != statusTrading {
+		finding.Kind = IssueNotTrading
+		finding.Reason = fmt.Sprintf("инструмент %s в статусе %s", symbol, instrument.Status)
+	}
+	return finding
+}
+
+// Print выводит результат проверки
+func (r *Report) Print(output io.Writer) {
+	fmt.Fprintf(output, "\n🔤 Пары %s (%s, инструментов %d): проверено %d, не хеджируются %d\n",
+		r.Exchange, r.Source, r.Instruments, r.Pairs, r.Failed())
+
+	if len(r.Findings) == 0 {
+		fmt.Fprintf(output, "  ✅ Все пары преобразуются в символы биржи однозначно\n")
+		return
+	}
+	for _, finding := range r.Findings {
+		icon := "❌"
+		if finding.Kind == IssueNotTrading {
+			icon = "⚠️"
+		}
+		fmt.Fprintf(output, "  %s %-16s %-12s %s\n", icon, finding.Pair, finding.Kind, finding.Reason)
+	}
+}
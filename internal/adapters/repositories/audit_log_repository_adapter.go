@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// AuditLogRepositoryAdapter адаптер для репозитория журнала аудита
+type AuditLogRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewAuditLogRepositoryAdapter создает новый адаптер репозитория журнала аудита
+func NewAuditLogRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *AuditLogRepositoryAdapter {
+	return &AuditLogRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveAuditEntry сохраняет запись журнала аудита
+func (r *AuditLogRepositoryAdapter) SaveAuditEntry(ctx context.Context, entry *entities.AuditEntry) error {
+	return r.dbRepo.SaveAuditEntry(ctx, entry)
+}
+
+// GetAuditEntries возвращает записи в интервале [from, to)
+func (r *AuditLogRepositoryAdapter) GetAuditEntries(ctx context.Context, from, to time.Time) ([]*entities.AuditEntry, error) {
+	return r.dbRepo.GetAuditEntries(ctx, from, to)
+}
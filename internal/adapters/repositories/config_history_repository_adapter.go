@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// ConfigHistoryRepositoryAdapter адаптер для репозитория истории конфигурации
+type ConfigHistoryRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewConfigHistoryRepositoryAdapter создает новый адаптер репозитория истории конфигурации
+func NewConfigHistoryRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *ConfigHistoryRepositoryAdapter {
+	return &ConfigHistoryRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveConfigVersion сохраняет версию конфигурации
+func (r *ConfigHistoryRepositoryAdapter) SaveConfigVersion(ctx context.Context, version *entities.ConfigVersion) error {
+	return r.dbRepo.SaveConfigVersion(ctx, version)
+}
+
+// GetConfigVersions возвращает версии конфигурации
+func (r *ConfigHistoryRepositoryAdapter) GetConfigVersions(ctx context.Context) ([]*entities.ConfigVersion, error) {
+	return r.dbRepo.GetConfigVersions(ctx)
+}
+
+// GetConfigVersion возвращает версию по ID
+func (r *ConfigHistoryRepositoryAdapter) GetConfigVersion(ctx context.Context, id int) (*entities.ConfigVersion, error) {
+	return r.dbRepo.GetConfigVersion(ctx, id)
+}
+
+// GetLatestConfigVersion возвращает последнюю версию
+func (r *ConfigHistoryRepositoryAdapter) GetLatestConfigVersion(ctx context.Context) (*entities.ConfigVersion, error) {
+	return r.dbRepo.GetLatestConfigVersion(ctx)
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// EvaluationRepositoryAdapter адаптер для репозитория истории наблюдений
+type EvaluationRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewEvaluationRepositoryAdapter создает новый адаптер репозитория истории наблюдений
+func NewEvaluationRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *EvaluationRepositoryAdapter {
+	return &EvaluationRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveEvaluations сохраняет наблюдения одного цикла
+func (r *EvaluationRepositoryAdapter) SaveEvaluations(ctx context.Context, evaluations []*entities.TradeEvaluation) error {
+	return r.dbRepo.SaveEvaluations(ctx, evaluations)
+}
+
+// GetEvaluations возвращает наблюдения начиная с since
+func (r *EvaluationRepositoryAdapter) GetEvaluations(ctx context.Context, since time.Time) ([]*entities.TradeEvaluation, error) {
+	return r.dbRepo.GetEvaluations(ctx, since)
+}
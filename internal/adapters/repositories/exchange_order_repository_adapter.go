@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// ExchangeOrderRepositoryAdapter адаптер для репозитория истории ордеров аккаунта
+type ExchangeOrderRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewExchangeOrderRepositoryAdapter создает новый адаптер репозитория истории ордеров аккаунта
+func NewExchangeOrderRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *ExchangeOrderRepositoryAdapter {
+	return &ExchangeOrderRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveExchangeOrders сохраняет ордера, пропуская уже импортированные
+func (r *ExchangeOrderRepositoryAdapter) SaveExchangeOrders(ctx context.Context, orders []*entities.ExchangeOrder) (int, error) {
+	return r.dbRepo.SaveExchangeOrders(ctx, orders)
+}
+
+// HasExchangeOrders проверяет, импортировалась ли уже история пары
+func (r *ExchangeOrderRepositoryAdapter) HasExchangeOrders(ctx context.Context, pair string) (bool, error) {
+	return r.dbRepo.HasExchangeOrders(ctx, pair)
+}
+
+// GetExchangeOrders возвращает ордера, созданные начиная с since
+func (r *ExchangeOrderRepositoryAdapter) GetExchangeOrders(ctx context.Context, since time.Time) ([]*entities.ExchangeOrder, error) {
+	return r.dbRepo.GetExchangeOrders(ctx, since)
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// FeatureFlagRepositoryAdapter адаптер для репозитория флагов возможностей
+type FeatureFlagRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewFeatureFlagRepositoryAdapter создает новый адаптер репозитория флагов
+func NewFeatureFlagRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *FeatureFlagRepositoryAdapter {
+	return &FeatureFlagRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// GetFeatureFlags возвращает переключенные флаги
+func (r *FeatureFlagRepositoryAdapter) GetFeatureFlags(ctx context.Context) ([]*entities.FeatureFlag, error) {
+	return r.dbRepo.GetFeatureFlags(ctx)
+}
+
+// SaveFeatureFlag сохраняет состояние флага и запись журнала
+func (r *FeatureFlagRepositoryAdapter) SaveFeatureFlag(ctx context.Context, key string, enabled *bool, author string) (*entities.FeatureFlagChange, error) {
+	return r.dbRepo.SaveFeatureFlag(ctx, key, enabled, author)
+}
+
+// GetFeatureFlagChanges возвращает журнал переключений флагов
+func (r *FeatureFlagRepositoryAdapter) GetFeatureFlagChanges(ctx context.Context, limit int) ([]*entities.FeatureFlagChange, error) {
+	return r.dbRepo.GetFeatureFlagChanges(ctx, limit)
+}
+
+// MemoryFeatureFlagRepository хранит переключенные флаги и журнал в памяти процесса
+// (для SQLite и режима dry-run: переключения не переживают перезапуск)
+type MemoryFeatureFlagRepository struct {
+	mu      sync.RWMutex
+	flags   map[string]*entities.FeatureFlag
+	changes []*entities.FeatureFlagChange
+}
+
+// NewMemoryFeatureFlagRepository создает пустой репозиторий флагов в памяти
+func NewMemoryFeatureFlagRepository() *MemoryFeatureFlagRepository {
+	return &MemoryFeatureFlagRepository{flags: make(map[string]*entities.FeatureFlag)}
+}
+
+// GetFeatureFlags возвращает переключенные флаги
+func (r *MemoryFeatureFlagRepository) GetFeatureFlags(ctx context.Context) ([]*entities.FeatureFlag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	flags := make([]*entities.FeatureFlag, 0, len(r.flags))
+	for _, flag := range r.flags {
+		copied := *flag
+		flags = append(flags, &copied)
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Key < flags[j].Key
+	})
+	return flags, nil
+}
+
+// SaveFeatureFlag сохраняет состояние флага и запись журнала
+func (r *MemoryFeatureFlagRepository) SaveFeatureFlag(ctx context.Context, key string, enabled *bool, author string) (*entities.FeatureFlagChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if enabled != nil {
+		r.flags[key] = &entities.FeatureFlag{Key: key, Enabled: *enabled, UpdatedAt: now, UpdatedBy: author}
+	} else {
+		delete(r.flags, key)
+	}
+
+	change := &entities.FeatureFlagChange{
+		ID:        int64(len(r.changes) + 1),
+		Key:       key,
+		Enabled:   enabled,
+		ChangedBy: author,
+		ChangedAt: now,
+	}
+	r.changes = append(r.changes, change)
+	return change, nil
+}
+
+// GetFeatureFlagChanges возвращает журнал переключений (новые первыми)
+func (r *MemoryFeatureFlagRepository) GetFeatureFlagChanges(ctx context.Context, limit int) ([]*entities.FeatureFlagChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	changes := make([]*entities.FeatureFlagChange, 0, len(r.changes))
+	for i := len(r.changes) - 1; i >= 0 && (limit <= 0 || len(changes) < limit); i-- {
+		changes = append(changes, r.changes[i])
+	}
+	return changes, nil
+}
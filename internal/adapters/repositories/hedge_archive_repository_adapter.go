@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// HedgeArchiveRepositoryAdapter адаптер для архива хеджированных сделок
+type HedgeArchiveRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewHedgeArchiveRepositoryAdapter создает новый адаптер архива
+func NewHedgeArchiveRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *HedgeArchiveRepositoryAdapter {
+	return &HedgeArchiveRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// ArchiveHedgedTrades переносит в архив хеджи, закрытые раньше closedBefore
+func (r *HedgeArchiveRepositoryAdapter) ArchiveHedgedTrades(ctx context.Context, closedBefore time.Time) (int, error) {
+	return r.dbRepo.ArchiveHedgedTrades(ctx, closedBefore)
+}
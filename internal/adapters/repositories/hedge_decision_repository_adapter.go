@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// HedgeDecisionRepositoryAdapter адаптер для репозитория решений по сделкам
+type HedgeDecisionRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewHedgeDecisionRepositoryAdapter создает новый адаптер репозитория решений по сделкам
+func NewHedgeDecisionRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *HedgeDecisionRepositoryAdapter {
+	return &HedgeDecisionRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveHedgeDecisions сохраняет решения одного цикла
+func (r *HedgeDecisionRepositoryAdapter) SaveHedgeDecisions(ctx context.Context, decisions []*entities.HedgeDecision) error {
+	return r.dbRepo.SaveHedgeDecisions(ctx, decisions)
+}
+
+// GetHedgeDecisions возвращает решения в интервале [from, to)
+func (r *HedgeDecisionRepositoryAdapter) GetHedgeDecisions(ctx context.Context, from, to time.Time) ([]*entities.HedgeDecision, error) {
+	return r.dbRepo.GetHedgeDecisions(ctx, from, to)
+}
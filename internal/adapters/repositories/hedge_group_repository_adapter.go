@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// HedgeGroupRepositoryAdapter адаптер для репозитория хедж-групп
+type HedgeGroupRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewHedgeGroupRepositoryAdapter создает новый адаптер репозитория хедж-групп
+func NewHedgeGroupRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *HedgeGroupRepositoryAdapter {
+	return &HedgeGroupRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveHedgeGroup сохраняет новую группу
+func (r *HedgeGroupRepositoryAdapter) SaveHedgeGroup(ctx context.Context, group *entities.HedgeGroup) error {
+	return r.dbRepo.SaveHedgeGroup(ctx, group)
+}
+
+// UpdateHedgeGroup сохраняет прогресс и состояние группы
+func (r *HedgeGroupRepositoryAdapter) UpdateHedgeGroup(ctx context.Context, group *entities.HedgeGroup) error {
+	return r.dbRepo.UpdateHedgeGroup(ctx, group)
+}
+
+// GetActiveHedgeGroups возвращает группы, которые еще размещают ноги
+func (r *HedgeGroupRepositoryAdapter) GetActiveHedgeGroups(ctx context.Context) ([]*entities.HedgeGroup, error) {
+	return r.dbRepo.GetActiveHedgeGroups(ctx)
+}
+
+// GetHedgeGroups возвращает последние limit групп
+func (r *HedgeGroupRepositoryAdapter) GetHedgeGroups(ctx context.Context, limit int) ([]*entities.HedgeGroup, error) {
+	return r.dbRepo.GetHedgeGroups(ctx, limit)
+}
+
+// GetHedgeGroupLegs возвращает ноги группы
+func (r *HedgeGroupRepositoryAdapter) GetHedgeGroupLegs(ctx context.Context, groupID int64) ([]*entities.HedgedTrade, error) {
+	return r.dbRepo.GetHedgeGroupLegs(ctx, groupID)
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// HedgeIntentRepositoryAdapter адаптер для репозитория намерений хеджирования
+type HedgeIntentRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewHedgeIntentRepositoryAdapter создает новый адаптер репозитория намерений хеджирования
+func NewHedgeIntentRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *HedgeIntentRepositoryAdapter {
+	return &HedgeIntentRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveHedgeIntent сохраняет намерение до размещения ордера
+func (r *HedgeIntentRepositoryAdapter) SaveHedgeIntent(ctx context.Context, intent *entities.HedgeIntent) error {
+	return r.dbRepo.SaveHedgeIntent(ctx, intent)
+}
+
+// UpdateHedgeIntent сохраняет состояние намерения и данные ордеров
+func (r *HedgeIntentRepositoryAdapter) UpdateHedgeIntent(ctx context.Context, intent *entities.HedgeIntent) error {
+	return r.dbRepo.UpdateHedgeIntent(ctx, intent)
+}
+
+// GetInFlightHedgeIntents возвращает намерения, не дошедшие до тейк-профита
+func (r *HedgeIntentRepositoryAdapter) GetInFlightHedgeIntents(ctx context.Context) ([]*entities.HedgeIntent, error) {
+	return r.dbRepo.GetInFlightHedgeIntents(ctx)
+}
+
+// GetHedgeIntentByOrderID находит намерение по ID ордера на покупку или тейк-профита
+func (r *HedgeIntentRepositoryAdapter) GetHedgeIntentByOrderID(ctx context.Context, orderID string) (*entities.HedgeIntent, error) {
+	return r.dbRepo.GetHedgeIntentByOrderID(ctx, orderID)
+}
+
+// CountHedgeIntents возвращает количество намерений для сделки и номера хеджа
+func (r *HedgeIntentRepositoryAdapter) CountHedgeIntents(ctx context.Context, tradeID, tranche int) (int, error) {
+	return r.dbRepo.CountHedgeIntents(ctx, tradeID, tranche)
+}
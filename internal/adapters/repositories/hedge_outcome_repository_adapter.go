@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// HedgeOutcomeRepositoryAdapter адаптер для репозитория итогов хеджирования
+type HedgeOutcomeRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewHedgeOutcomeRepositoryAdapter создает новый адаптер репозитория итогов хеджирования
+func NewHedgeOutcomeRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *HedgeOutcomeRepositoryAdapter {
+	return &HedgeOutcomeRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveHedgeOutcome сохраняет итог хеджирования по сделке
+func (r *HedgeOutcomeRepositoryAdapter) SaveHedgeOutcome(ctx context.Context, outcome *entities.HedgeOutcome) error {
+	return r.dbRepo.SaveHedgeOutcome(ctx, outcome)
+}
+
+// GetHedgeOutcomes возвращает рассчитанные итоги хеджирования
+func (r *HedgeOutcomeRepositoryAdapter) GetHedgeOutcomes(ctx context.Context) ([]*entities.HedgeOutcome, error) {
+	return r.dbRepo.GetHedgeOutcomes(ctx)
+}
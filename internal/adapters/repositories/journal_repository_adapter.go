@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// JournalRepositoryAdapter адаптер для репозитория торгового журнала
+type JournalRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewJournalRepositoryAdapter создает новый адаптер репозитория торгового журнала
+func NewJournalRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *JournalRepositoryAdapter {
+	return &JournalRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveJournalEntry сохраняет запись журнала
+func (r *JournalRepositoryAdapter) SaveJournalEntry(ctx context.Context, entry *entities.JournalEntry) error {
+	return r.dbRepo.SaveJournalEntry(ctx, entry)
+}
+
+// GetJournalEntries возвращает все записи журнала
+func (r *JournalRepositoryAdapter) GetJournalEntries(ctx context.Context) ([]*entities.JournalEntry, error) {
+	return r.dbRepo.GetJournalEntries(ctx)
+}
+
+// DeleteJournalEntry удаляет запись журнала по ID
+func (r *JournalRepositoryAdapter) DeleteJournalEntry(ctx context.Context, id int) error {
+	return r.dbRepo.DeleteJournalEntry(ctx, id)
+}
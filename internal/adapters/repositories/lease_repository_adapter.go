@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// LeaseRepositoryAdapter адаптер для репозитория аренды ведущего экземпляра
+type LeaseRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewLeaseRepositoryAdapter создает новый адаптер репозитория аренды
+func NewLeaseRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *LeaseRepositoryAdapter {
+	return &LeaseRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// AcquireLease получает или продлевает аренду
+func (r *LeaseRepositoryAdapter) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*entities.Lease, error) {
+	return r.dbRepo.AcquireLease(ctx, name, holder, ttl)
+}
+
+// ReleaseLease освобождает аренду
+func (r *LeaseRepositoryAdapter) ReleaseLease(ctx context.Context, name, holder string) error {
+	return r.dbRepo.ReleaseLease(ctx, name, holder)
+}
+
+// RequestHandoff запрашивает передачу аренды
+func (r *LeaseRepositoryAdapter) RequestHandoff(ctx context.Context, name, requester string) error {
+	return r.dbRepo.RequestHandoff(ctx, name, requester)
+}
+
+// GetLease возвращает текущее состояние аренды
+func (r *LeaseRepositoryAdapter) GetLease(ctx context.Context, name string) (*entities.Lease, error) {
+	return r.dbRepo.GetLease(ctx, name)
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// MarketSnapshotRepositoryAdapter адаптер для репозитория снимка балансов и цен
+type MarketSnapshotRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewMarketSnapshotRepositoryAdapter создает новый адаптер репозитория снимка
+func NewMarketSnapshotRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *MarketSnapshotRepositoryAdapter {
+	return &MarketSnapshotRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveMarketSnapshot заменяет сохраненный снимок
+func (r *MarketSnapshotRepositoryAdapter) SaveMarketSnapshot(ctx context.Context, snapshot *entities.MarketSnapshot) error {
+	return r.dbRepo.SaveMarketSnapshot(ctx, snapshot)
+}
+
+// GetMarketSnapshot возвращает сохраненный снимок
+func (r *MarketSnapshotRepositoryAdapter) GetMarketSnapshot(ctx context.Context) (*entities.MarketSnapshot, error) {
+	return r.dbRepo.GetMarketSnapshot(ctx)
+}
+
+// MemoryMarketSnapshotRepository хранит снимок балансов и цен в памяти процесса
+// (для SQLite и режима dry-run: снимок не переживает перезапуск)
+type MemoryMarketSnapshotRepository struct {
+	mu       sync.RWMutex
+	snapshot *entities.MarketSnapshot
+}
+
+// NewMemoryMarketSnapshotRepository создает пустой репозиторий снимка в памяти
+func NewMemoryMarketSnapshotRepository() *MemoryMarketSnapshotRepository {
+	return &MemoryMarketSnapshotRepository{}
+}
+
+// SaveMarketSnapshot заменяет снимок
+func (r *MemoryMarketSnapshotRepository) SaveMarketSnapshot(ctx context.Context, snapshot *entities.MarketSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.snapshot = snapshot
+	return nil
+}
+
+// GetMarketSnapshot возвращает снимок (nil, если снимка еще нет)
+func (r *MemoryMarketSnapshotRepository) GetMarketSnapshot(ctx context.Context) (*entities.MarketSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.snapshot, nil
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"trade-hedge/internal/domain/entities"
+)
+
+// MemoryHedgeIntentRepository хранит намерения хеджирования в памяти процесса.
+// Используется в режиме dry-run вместе с MemoryHedgeRepository
+type MemoryHedgeIntentRepository struct {
+	mu      sync.RWMutex
+	intents []*entities.HedgeIntent
+}
+
+// NewMemoryHedgeIntentRepository создает новый репозиторий намерений в памяти
+func NewMemoryHedgeIntentRepository() *MemoryHedgeIntentRepository {
+	return &MemoryHedgeIntentRepository{}
+}
+
+// SaveHedgeIntent сохраняет копию намерения
+func (r *MemoryHedgeIntentRepository) SaveHedgeIntent(ctx context.Context, intent *entities.HedgeIntent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *intent
+	r.intents = append(r.intents, &stored)
+	return nil
+}
+
+// UpdateHedgeIntent сохраняет состояние намерения и данные ордеров
+func (r *MemoryHedgeIntentRepository) UpdateHedgeIntent(ctx context.Context, intent *entities.HedgeIntent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, stored := range r.intents {
+		if stored.ClientOrderID == intent.ClientOrderID {
+			updated := *intent
+			r.intents[i] = &updated
+			return nil
+		}
+	}
+	return fmt.Errorf("намерение хеджирования %s не найдено", intent.ClientOrderID)
+}
+
+// GetInFlightHedgeIntents возвращает копии намерений, не дошедших до тейк-профита
+func (r *MemoryHedgeIntentRepository) GetInFlightHedgeIntents(ctx context.Context) ([]*entities.HedgeIntent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entities.HedgeIntent
+	for _, intent := range r.intents {
+		if intent.State.IsInFlight() {
+			copied := *intent
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+// GetHedgeIntentByOrderID находит копию намерения по ID ордера на покупку или тейк-профита
+func (r *MemoryHedgeIntentRepository) GetHedgeIntentByOrderID(ctx context.Context, orderID string) (*entities.HedgeIntent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.intents) - 1; i >= 0; i-- {
+		intent := r.intents[i]
+		if orderID != "" && (intent.BuyOrderID == orderID || intent.TakeProfitOrderID == orderID) {
+			copied := *intent
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+// CountHedgeIntents возвращает количество намерений для сделки и номера хеджа
+func (r *MemoryHedgeIntentRepository) CountHedgeIntents(ctx context.Context, tradeID, tranche int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, intent := range r.intents {
+		if intent.FreqtradeTradeID == tradeID && intent.Tranche == tranche {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// MemoryHedgeRepository хранит хеджированные сделки в памяти процесса.
+// Используется в режиме dry-run, когда база данных не настроена; данные теряются при перезапуске
+type MemoryHedgeRepository struct {
+	mu     sync.RWMutex
+	trades []*entities.HedgedTrade
+	nextID int64 // Последний выданный ID хеджа
+}
+
+// NewMemoryHedgeRepository создает новый репозиторий в памяти
+func NewMemoryHedgeRepository() *MemoryHedgeRepository {
+	return &MemoryHedgeRepository{}
+}
+
+// IsTradeHedged проверяет, есть ли у сделки исполненный хедж
+func (r *MemoryHedgeRepository) IsTradeHedged(ctx context.Context, tradeID int) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, trade := range r.trades {
+		if trade.FreqtradeTradeID == tradeID && trade.OrderStatus == entities.OrderStatusFilled {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SaveHedgedTrade сохраняет копию хеджированной сделки и присваивает ей ID хеджа
+func (r *MemoryHedgeRepository) SaveHedgedTrade(ctx context.Context, hedgedTrade *entities.HedgedTrade) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	hedgedTrade.HedgeID = r.nextID
+	stored := *hedgedTrade
+	r.trades = append(r.trades, &stored)
+	return nil
+}
+
+// GetHedgedTrades возвращает копии сделок (опционально по статусу), новые первыми
+func (r *MemoryHedgeRepository) GetHedgedTrades(ctx context.Context, status *string) ([]*entities.HedgedTrade, error) {
+	return r.filter(func(trade *entities.HedgedTrade) bool {
+		return status == nil || trade.OrderStatus.String() == *status
+	}), nil
+}
+
+// QueryHedgedTrades возвращает страницу копий сделок с фильтрами и сортировкой.
+// Сделки в памяти не архивируются: выборка из архива пуста
+func (r *MemoryHedgeRepository) QueryHedgedTrades(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeTradePage, error) {
+	if query.Archived {
+		return query.Apply(nil), nil
+	}
+	page := query.Apply(r.filter(func(*entities.HedgedTrade) bool { return true }))
+	return page, nil
+}
+
+// GetTradeStats считает статистику сделок, подходящих под фильтры выборки
+func (r *MemoryHedgeRepository) GetTradeStats(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeStats, error) {
+	if query.Archived {
+		return entities.ComputeHedgeStats(nil), nil
+	}
+	return entities.ComputeHedgeStats(r.filter(query.Matches)), nil
+}
+
+// GetProfitTimeSeries считает ряд прибыли закрытых сделок
+func (r *MemoryHedgeRepository) GetProfitTimeSeries(ctx context.Context, bucket string, from, to time.Time) ([]*entities.ProfitBucket, error) {
+	if !entities.IsProfitBucket(bucket) {
+		return nil, fmt.Errorf("неизвестный интервал ряда прибыли: %q", bucket)
+	}
+	return entities.ComputeProfitSeries(r.filter(func(*entities.HedgedTrade) bool { return true }), bucket, from, to), nil
+}
+
+// GetProfitTotals считает итоги сделок, закрытых до before
+func (r *MemoryHedgeRepository) GetProfitTotals(ctx context.Context, before time.Time) (*entities.ProfitBucket, error) {
+	return entities.ComputeProfitTotals(r.filter(func(*entities.HedgedTrade) bool { return true }), before), nil
+}
+
+// UpdateHedgedTradeStatus обновляет статус сделки по ID ордера
+func (r *MemoryHedgeRepository) UpdateHedgedTradeStatus(ctx context.Context, orderID string, status entities.OrderStatus, closePrice *float64, closeTime *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, trade := range r.trades {
+		if trade.BybitOrderID == orderID {
+			trade.OrderStatus = status
+			trade.LastStatusCheck = &now
+			trade.ClosePrice = closePrice
+			trade.CloseTime = closeTime
+		}
+	}
+	return nil
+}
+
+// UpdateHedgedTrade обновляет данные сделки, найденной по текущему ID ордера. Как и в SQL-хранилищах,
+// обновляются только ордера, исполнение и статус: сделка Freqtrade, пара, время хеджирования,
+// версия стратегии и моменты до исполнения покупки остаются прежними
+func (r *MemoryHedgeRepository) UpdateHedgedTrade(ctx context.Context, orderID string, hedgedTrade *entities.HedgedTrade) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, trade := range r.trades {
+		if trade.BybitOrderID != orderID {
+			continue
+		}
+		trade.BybitOrderID = hedgedTrade.BybitOrderID
+		trade.BuyOrderID = hedgedTrade.BuyOrderID
+		trade.HedgeOpenPrice = hedgedTrade.HedgeOpenPrice
+		trade.HedgeAmount = hedgedTrade.HedgeAmount
+		trade.HedgeTakeProfitPrice = hedgedTrade.HedgeTakeProfitPrice
+		trade.OrderStatus = hedgedTrade.OrderStatus
+		trade.LastStatusCheck = hedgedTrade.LastStatusCheck
+		trade.ClosePrice = hedgedTrade.ClosePrice
+		trade.CloseTime = hedgedTrade.CloseTime
+		trade.BuyRequestedQty = hedgedTrade.BuyRequestedQty
+		trade.BuyFilledQty = hedgedTrade.BuyFilledQty
+		trade.StopLossPrice = hedgedTrade.StopLossPrice
+		trade.StopLossOrderID = hedgedTrade.StopLossOrderID
+		trade.EntryFee = hedgedTrade.EntryFee
+		trade.ExitFee = hedgedTrade.ExitFee
+		trade.BuyFilledAt = hedgedTrade.BuyFilledAt
+		trade.TakeProfitPlacedAt = hedgedTrade.TakeProfitPlacedAt
+		trade.CloseIntendedPrice = hedgedTrade.CloseIntendedPrice
+	}
+	return nil
+}
+
+// GetHedgeHistory возвращает историю хеджей по сделке, новые первыми
+func (r *MemoryHedgeRepository) GetHedgeHistory(ctx context.Context, tradeID int) ([]*entities.HedgedTrade, error) {
+	return r.filter(func(trade *entities.HedgedTrade) bool {
+		return trade.FreqtradeTradeID == tradeID
+	}), nil
+}
+
+// GetQuoteExposure возвращает агрегаты хеджей по котируемой валюте
+func (r *MemoryHedgeRepository) GetQuoteExposure(ctx context.Context, quote string, since time.Time) (*entities.QuoteExposure, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exposure := &entities.QuoteExposure{Quote: quote}
+	for _, trade := range r.trades {
+		if valueobjects.NewTradingPair(trade.Pair).QuoteCurrency() != quote {
+			continue
+		}
+
+		notional := trade.HedgeAmount * trade.HedgeOpenPrice
+		if trade.IsActive() && trade.OrderStatus != entities.OrderStatusUnknown {
+			exposure.OpenHedges++
+			exposure.OpenNotional += notional
+		}
+		if !trade.HedgeTime.Before(since) {
+			exposure.DailyNotional += notional
+		}
+	}
+	return exposure, nil
+}
+
+// filter возвращает копии сделок, удовлетворяющих условию, отсортированные по времени хеджирования и ID (новые первыми)
+func (r *MemoryHedgeRepository) filter(match func(trade *entities.HedgedTrade) bool) []*entities.HedgedTrade {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entities.HedgedTrade
+	for _, trade := range r.trades {
+		if match(trade) {
+			copied := *trade
+			result = append(result, &copied)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].HedgeTime.Equal(result[j].HedgeTime) {
+			return result[i].HedgeTime.After(result[j].HedgeTime)
+		}
+		return result[i].HedgeID > result[j].HedgeID
+	})
+	return result
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// OrderEventRepositoryAdapter адаптер для репозитория истории событий ордеров
+type OrderEventRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewOrderEventRepositoryAdapter создает новый адаптер репозитория истории событий ордеров
+func NewOrderEventRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *OrderEventRepositoryAdapter {
+	return &OrderEventRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveOrderEvent сохраняет событие ордера
+func (r *OrderEventRepositoryAdapter) SaveOrderEvent(ctx context.Context, event *entities.OrderEvent) error {
+	return r.dbRepo.SaveOrderEvent(ctx, event)
+}
+
+// GetOrderEvents возвращает события ордера в порядке возникновения
+func (r *OrderEventRepositoryAdapter) GetOrderEvents(ctx context.Context, orderID string) ([]*entities.OrderEvent, error) {
+	return r.dbRepo.GetOrderEvents(ctx, orderID)
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// RebalanceRepositoryAdapter адаптер для репозитория ребалансировки
+type RebalanceRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewRebalanceRepositoryAdapter создает новый адаптер репозитория ребалансировки
+func NewRebalanceRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *RebalanceRepositoryAdapter {
+	return &RebalanceRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// SaveRebalanceRecord сохраняет запись аудита ребалансировки
+func (r *RebalanceRepositoryAdapter) SaveRebalanceRecord(ctx context.Context, record *entities.RebalanceRecord) error {
+	return r.dbRepo.SaveRebalanceRecord(ctx, record)
+}
+
+// GetLastRebalanceTime возвращает конец периода последней ребалансировки
+func (r *RebalanceRepositoryAdapter) GetLastRebalanceTime(ctx context.Context) (*time.Time, error) {
+	return r.dbRepo.GetLastRebalanceTime(ctx)
+}
+
+// GetConvertedAmounts возвращает суммы успешных конвертаций по активам для периода, начатого в periodStart
+func (r *RebalanceRepositoryAdapter) GetConvertedAmounts(ctx context.Context, periodStart time.Time) (map[string]float64, error) {
+	return r.dbRepo.GetConvertedAmounts(ctx, periodStart)
+}
+
+// GetRebalanceRecords возвращает записи аудита ребалансировки
+func (r *RebalanceRepositoryAdapter) GetRebalanceRecords(ctx context.Context, limit int) ([]*entities.RebalanceRecord, error) {
+	return r.dbRepo.GetRebalanceRecords(ctx, limit)
+}
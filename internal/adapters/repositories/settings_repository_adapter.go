@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// SettingsRepositoryAdapter адаптер для репозитория параметров
+type SettingsRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewSettingsRepositoryAdapter создает новый адаптер репозитория параметров
+func NewSettingsRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *SettingsRepositoryAdapter {
+	return &SettingsRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// GetSettings возвращает сохраненные параметры
+func (r *SettingsRepositoryAdapter) GetSettings(ctx context.Context) ([]*entities.Setting, error) {
+	return r.dbRepo.GetSettings(ctx)
+}
+
+// SaveSetting сохраняет значение параметра и запись журнала
+func (r *SettingsRepositoryAdapter) SaveSetting(ctx context.Context, key string, value *float64, ttl time.Duration, author string) (*entities.SettingChange, error) {
+	return r.dbRepo.SaveSetting(ctx, key, value, ttl, author)
+}
+
+// RevertExpiredSettings возвращает постоянные значения параметров с закончившимися временными значениями
+func (r *SettingsRepositoryAdapter) RevertExpiredSettings(ctx context.Context, author string) ([]*entities.SettingChange, error) {
+	return r.dbRepo.RevertExpiredSettings(ctx, author)
+}
+
+// GetSettingChanges возвращает журнал изменений параметров
+func (r *SettingsRepositoryAdapter) GetSettingChanges(ctx context.Context, limit int) ([]*entities.SettingChange, error) {
+	return r.dbRepo.GetSettingChanges(ctx, limit)
+}
+
+// MemorySettingsRepository хранит параметры и журнал в памяти процесса
+// (для SQLite и режима dry-run: изменения не переживают перезапуск)
+type MemorySettingsRepository struct {
+	mu       sync.RWMutex
+	settings map[string]*entities.Setting
+	changes  []*entities.SettingChange
+}
+
+// NewMemorySettingsRepository создает пустой репозиторий параметров в памяти
+func NewMemorySettingsRepository() *MemorySettingsRepository {
+	return &MemorySettingsRepository{settings: make(map[string]*entities.Setting)}
+}
+
+// GetSettings возвращает сохраненные параметры
+func (r *MemorySettingsRepository) GetSettings(ctx context.Context) ([]*entities.Setting, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings := make([]*entities.Setting, 0, len(r.settings))
+	for _, setting := range r.settings {
+		copied := *setting
+		settings = append(settings, &copied)
+	}
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+	return settings, nil
+}
+
+// SaveSetting сохраняет значение параметра и запись журнала
+func (r *MemorySettingsRepository) SaveSetting(ctx context.Context, key string, value *float64, ttl time.Duration, author string) (*entities.SettingChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	change := &entities.SettingChange{
+		ID:        int64(len(r.changes) + 1),
+		Key:       key,
+		NewValue:  value,
+		ChangedBy: author,
+		ChangedAt: now,
+	}
+	current, ok := r.settings[key]
+	if ok {
+		oldValue := current.Value
+		change.OldValue = &oldValue
+	}
+
+	if value != nil {
+		setting := &entities.Setting{Key: key, Value: *value, UpdatedAt: now, UpdatedBy: author}
+		if ttl > 0 {
+			expiresAt := now.Add(ttl)
+			setting.ExpiresAt = &expiresAt
+			setting.RevertValue = current.BaseValue()
+			change.ExpiresAt = &expiresAt
+		}
+		r.settings[key] = setting
+	} else {
+		delete(r.settings, key)
+	}
+	r.changes = append(r.changes, change)
+	return change, nil
+}
+
+// RevertExpiredSettings возвращает постоянные значения параметров с закончившимися временными значениями
+func (r *MemorySettingsRepository) RevertExpiredSettings(ctx context.Context, author string) ([]*entities.SettingChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	keys := make([]string, 0, len(r.settings))
+	for key, setting := range r.settings {
+		if setting.Expired(now) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := make([]*entities.SettingChange, 0, len(keys))
+	for _, key := range keys {
+		setting := r.settings[key]
+		oldValue := setting.Value
+		change := &entities.SettingChange{
+			ID:        int64(len(r.changes) + 1),
+			Key:       key,
+			OldValue:  &oldValue,
+			NewValue:  setting.RevertValue,
+			ChangedBy: author,
+			ChangedAt: now,
+		}
+		if setting.RevertValue != nil {
+			r.settings[key] = &entities.Setting{Key: key, Value: *setting.RevertValue, UpdatedAt: now, UpdatedBy: author}
+		} else {
+			delete(r.settings, key)
+		}
+		r.changes = append(r.changes, change)
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// GetSettingChanges возвращает журнал изменений (новые первыми)
+func (r *MemorySettingsRepository) GetSettingChanges(ctx context.Context, limit int) ([]*entities.SettingChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	changes := make([]*entities.SettingChange, 0, len(r.changes))
+	for i := len(r.changes) - 1; i >= 0 && (limit <= 0 || len(changes) < limit); i-- {
+		changes = append(changes, r.changes[i])
+	}
+	return changes, nil
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// StatusClaimRepositoryAdapter адаптер для репозитория захватов хеджей на проверку статусов
+type StatusClaimRepositoryAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewStatusClaimRepositoryAdapter создает новый адаптер репозитория захватов
+func NewStatusClaimRepositoryAdapter(dbRepo *database.PostgreSQLTradeRepository) *StatusClaimRepositoryAdapter {
+	return &StatusClaimRepositoryAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// ClaimHedges захватывает хеджи для проверки статусов
+func (r *StatusClaimRepositoryAdapter) ClaimHedges(ctx context.Context, holder string, hedgeIDs []int64, limit int, ttl time.Duration) ([]int64, error) {
+	return r.dbRepo.ClaimHedges(ctx, holder, hedgeIDs, limit, ttl)
+}
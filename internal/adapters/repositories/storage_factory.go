@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// Storage хранилище, выбранное по database.driver
+type Storage struct {
+	Hedges repositories.HedgeRepository
+
+	// Архив давно закрытых хеджей (PostgreSQL и SQLite)
+	Archive repositories.HedgeArchiveRepository
+
+	// Транзакции для атомарного сохранения хеджа и событий его ордеров; nil для SQLite -
+	// события ордеров там не хранятся, и хедж сохраняется одной командой
+	Transactions repositories.UnitOfWork
+
+	// PostgreSQL для остальных репозиториев (намерения, журнал, события ордеров и т.д.);
+	// nil для SQLite - эти возможности работают в памяти или отключаются, как в dry-run
+	PostgreSQL *database.PostgreSQLTradeRepository
+
+	close func()
+}
+
+// Close закрывает соединение с хранилищем
+func (s *Storage) Close() {
+	if s.close != nil {
+		s.close()
+	}
+}
+
+// OpenStorage открывает хранилище хеджей по database.driver: PostgreSQL или файл SQLite
+func OpenStorage(cfg *config.Config) (*Storage, error) {
+	switch cfg.Database.Driver {
+	case config.DatabaseDriverSQLite:
+		sqliteRepo, err := database.NewSQLiteTradeRepository(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return &Storage{Hedges: sqliteRepo, Archive: sqliteRepo, close: sqliteRepo.Close}, nil
+	case config.DatabaseDriverPostgres, "":
+		dbRepo, err := database.NewPostgreSQLTradeRepository(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return &Storage{
+			Hedges:       NewHedgeRepositoryAdapter(dbRepo),
+			Archive:      NewHedgeArchiveRepositoryAdapter(dbRepo),
+			Transactions: NewUnitOfWorkAdapter(dbRepo),
+			PostgreSQL:   dbRepo,
+			close:        dbRepo.Close,
+		}, nil
+	default:
+		return nil, fmt.Errorf("неизвестный драйвер хранилища: %q", cfg.Database.Driver)
+	}
+}
+
+// MigrateStorage применяет миграции схемы хранилища и возвращает количество примененных миграций.
+// Используется точкой входа с флагом --migrate-only; схема SQLite создается при открытии файла
+func MigrateStorage(ctx context.Context, cfg *config.Config) (int, error) {
+	switch cfg.Database.Driver {
+	case config.DatabaseDriverSQLite:
+		sqliteRepo, err := database.NewSQLiteTradeRepository(cfg)
+		if err != nil {
+			return 0, err
+		}
+		sqliteRepo.Close()
+		return 0, nil
+	case config.DatabaseDriverPostgres, "":
+		return database.RunMigrations(ctx, cfg)
+	default:
+		return 0, fmt.Errorf("неизвестный драйвер хранилища: %q", cfg.Database.Driver)
+	}
+}
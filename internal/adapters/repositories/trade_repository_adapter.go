@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
 	"trade-hedge/internal/infrastructure/database"
 )
 
@@ -21,6 +22,16 @@ func NewHedgeRepositoryAdapter(
 	}
 }
 
+// Ping проверяет доступность базы данных
+func (r *HedgeRepositoryAdapter) Ping(ctx context.Context) error {
+	return r.dbRepo.Ping(ctx)
+}
+
+// PoolStats возвращает состояние пула соединений
+func (r *HedgeRepositoryAdapter) PoolStats() *repositories.DatabasePoolStats {
+	return r.dbRepo.PoolStats()
+}
+
 // IsTradeHedged проверяет, была ли сделка хеджирована
 func (r *HedgeRepositoryAdapter) IsTradeHedged(ctx context.Context, tradeID int) (bool, error) {
 	return r.dbRepo.IsTradeHedged(ctx, tradeID)
@@ -36,12 +47,47 @@ func (r *HedgeRepositoryAdapter) GetHedgedTrades(ctx context.Context, status *st
 	return r.dbRepo.GetHedgedTrades(ctx, status)
 }
 
+// QueryHedgedTrades возвращает страницу хеджей с фильтрами и сортировкой
+func (r *HedgeRepositoryAdapter) QueryHedgedTrades(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeTradePage, error) {
+	return r.dbRepo.QueryHedgedTrades(ctx, query)
+}
+
+// GetTradeStats возвращает агрегированную статистику хеджей
+func (r *HedgeRepositoryAdapter) GetTradeStats(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeStats, error) {
+	return r.dbRepo.GetTradeStats(ctx, query)
+}
+
+// GetProfitTimeSeries возвращает реализованную прибыль хеджей по интервалам
+func (r *HedgeRepositoryAdapter) GetProfitTimeSeries(ctx context.Context, bucket string, from, to time.Time) ([]*entities.ProfitBucket, error) {
+	return r.dbRepo.GetProfitTimeSeries(ctx, bucket, from, to)
+}
+
+// GetProfitTotals возвращает итоги хеджей, закрытых до before
+func (r *HedgeRepositoryAdapter) GetProfitTotals(ctx context.Context, before time.Time) (*entities.ProfitBucket, error) {
+	return r.dbRepo.GetProfitTotals(ctx, before)
+}
+
+// QueryScalar выполняет read-only запрос пользовательской метрики
+func (r *HedgeRepositoryAdapter) QueryScalar(ctx context.Context, query string, timeout time.Duration) (*float64, error) {
+	return r.dbRepo.QueryScalar(ctx, query, timeout)
+}
+
 // UpdateHedgedTradeStatus обновляет статус хеджированной сделки
 func (r *HedgeRepositoryAdapter) UpdateHedgedTradeStatus(ctx context.Context, orderID string, status entities.OrderStatus, closePrice *float64, closeTime *time.Time) error {
 	return r.dbRepo.UpdateHedgedTradeStatus(ctx, orderID, status, closePrice, closeTime)
 }
 
+// UpdateHedgedTrade обновляет данные хеджированной сделки
+func (r *HedgeRepositoryAdapter) UpdateHedgedTrade(ctx context.Context, orderID string, hedgedTrade *entities.HedgedTrade) error {
+	return r.dbRepo.UpdateHedgedTrade(ctx, orderID, hedgedTrade)
+}
+
 // GetHedgeHistory получает историю хедж-ордеров по конкретной сделке
 func (r *HedgeRepositoryAdapter) GetHedgeHistory(ctx context.Context, tradeID int) ([]*entities.HedgedTrade, error) {
 	return r.dbRepo.GetHedgeHistory(ctx, tradeID)
 }
+
+// GetQuoteExposure возвращает агрегаты хеджей по котируемой валюте
+func (r *HedgeRepositoryAdapter) GetQuoteExposure(ctx context.Context, quote string, since time.Time) (*entities.QuoteExposure, error) {
+	return r.dbRepo.GetQuoteExposure(ctx, quote, since)
+}
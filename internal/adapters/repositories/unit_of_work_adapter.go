@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/infrastructure/database"
+)
+
+// UnitOfWorkAdapter адаптер транзакций PostgreSQL
+type UnitOfWorkAdapter struct {
+	dbRepo *database.PostgreSQLTradeRepository
+}
+
+// NewUnitOfWorkAdapter создает новый адаптер транзакций
+func NewUnitOfWorkAdapter(dbRepo *database.PostgreSQLTradeRepository) *UnitOfWorkAdapter {
+	return &UnitOfWorkAdapter{
+		dbRepo: dbRepo,
+	}
+}
+
+// WithinTransaction выполняет fn в одной транзакции
+func (u *UnitOfWorkAdapter) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return u.dbRepo.WithinTransaction(ctx, fn)
+}
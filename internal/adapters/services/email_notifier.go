@@ -0,0 +1,200 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// EmailNotifier отправляет письма об оповещениях, которые маршрутизатор направил в канал email
+// (по умолчанию - критические условия с высоким приоритетом, см. config.DefaultNotificationRoutes).
+// Ограничение частоты защищает почтовый ящик от лавины писем: одно условие - не чаще throttle,
+// все условия - не больше max_per_hour писем в час. Письмо об устранении отправляется, только если
+// о самом условии письмо было
+type EmailNotifier struct {
+	config *config.EmailNotifierConfig
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time // Ключ условия -> время последнего письма о нем
+	sentLog  []time.Time          // Время писем за последний час
+}
+
+// NewEmailNotifier создает оповещатель по почте
+func NewEmailNotifier(cfg *config.EmailNotifierConfig) *EmailNotifier {
+	return &EmailNotifier{
+		config:   cfg,
+		lastSent: make(map[string]time.Time),
+	}
+}
+
+// Notify отправляет письмо об оповещении в фоне, если его пропускает ограничение частоты
+func (e *EmailNotifier) Notify(ctx context.Context, notification *entities.Notification) error {
+	if !e.accept(notification, time.Now()) {
+		return nil
+	}
+
+	message := e.buildMessage(notification)
+	go func() {
+		if err := e.send(message); err != nil {
+			logger.LogWithTime("❌ Ошибка отправки письма «%s»: %v", notification.Title, err)
+			return
+		}
+		logger.LogWithTime("📧 Письмо «%s» отправлено: %s", notification.Title, strings.Join(e.config.To, ", "))
+	}()
+	return nil
+}
+
+// accept решает, отправлять ли письмо, и учитывает его в ограничении частоты
+func (e *EmailNotifier) accept(notification *entities.Notification, now time.Time) bool {
+	key := throttleKey(notification)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, alerted := e.lastSent[key]
+	if notification.Resolved {
+		if !alerted {
+			return false
+		}
+		delete(e.lastSent, key)
+	} else {
+		throttle := time.Duration(e.config.Throttle) * time.Minute
+		if alerted && throttle > 0 && now.Sub(e.lastSent[key]) < throttle {
+			return false
+		}
+	}
+
+	// Общий лимит писем в час
+	recent := e.sentLog[:0]
+	for _, sent := range e.sentLog {
+		if now.Sub(sent) < time.Hour {
+			recent = append(recent, sent)
+		}
+	}
+	e.sentLog = recent
+	if e.config.MaxPerHour > 0 && len(e.sentLog) >= e.config.MaxPerHour {
+		logger.LogWithTime("⚠️ Письмо «%s» не отправлено: достигнут лимит %d писем в час", notification.Title, e.config.MaxPerHour)
+		return false
+	}
+	e.sentLog = append(e.sentLog, now)
+	if !notification.Resolved {
+		e.lastSent[key] = now
+	}
+	return true
+}
+
+// throttleKey возвращает ключ ограничения частоты: ключ группы оповещений или, для событий
+// без ключа (хедж, цикл), событие и заголовок
+func throttleKey(notification *entities.Notification) string {
+	if notification.Key != "" {
+		return notification.Key
+	}
+	return notification.EventName() + ":" + notification.Title
+}
+
+// buildMessage формирует письмо в UTF-8
+func (e *EmailNotifier) buildMessage(notification *entities.Notification) []byte {
+	var body bytes.Buffer
+	body.WriteString(notification.Message)
+	body.WriteString("\r\n\r\n")
+	fmt.Fprintf(&body, "Приоритет: %s\r\n", notification.Priority)
+	fmt.Fprintf(&body, "Время: %s\r\n", notification.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	if notification.Key != "" {
+		fmt.Fprintf(&body, "Условие: %s\r\n", notification.Key)
+	}
+	if notification.Occurrences > 0 {
+		fmt.Fprintf(&body, "Повторов: %d\r\n", notification.Occurrences)
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", e.config.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(e.config.To, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "[trade-hedge] "+notification.Title))
+	fmt.Fprintf(&message, "Date: %s\r\n", notification.CreatedAt.Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	message.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	message.Write(body.Bytes())
+	return message.Bytes()
+}
+
+// send доставляет письмо на SMTP сервер: TLS с начала соединения (implicit_tls) или STARTTLS,
+// если сервер его поддерживает
+func (e *EmailNotifier) send(message []byte) error {
+	address := net.JoinHostPort(e.config.Host, strconv.Itoa(e.config.Port))
+	tlsConfig := &tls.Config{ServerName: e.config.Host}
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	if e.config.ImplicitTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка подключения к SMTP серверу %s: %w", address, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(time.Minute))
+
+	client, err := smtp.NewClient(conn, e.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("ошибка SMTP: %w", err)
+	}
+	defer client.Close()
+
+	if !e.config.ImplicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("ошибка STARTTLS: %w", err)
+			}
+		}
+	}
+	if e.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.Host)); err != nil {
+			return fmt.Errorf("ошибка аутентификации SMTP: %w", err)
+		}
+	}
+
+	if err := client.Mail(envelopeAddress(e.config.From)); err != nil {
+		return fmt.Errorf("ошибка SMTP MAIL FROM: %w", err)
+	}
+	for _, recipient := range e.config.To {
+		if err := client.Rcpt(envelopeAddress(recipient)); err != nil {
+			return fmt.Errorf("ошибка SMTP RCPT TO %s: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("ошибка SMTP DATA: %w", err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		return fmt.Errorf("ошибка записи письма: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("ошибка завершения письма: %w", err)
+	}
+	return client.Quit()
+}
+
+// envelopeAddress возвращает адрес без имени ("Бот <bot@example.com>" -> bot@example.com) для команд SMTP
+func envelopeAddress(address string) string {
+	if parsed, err := mail.ParseAddress(address); err == nil {
+		return parsed.Address
+	}
+	return address
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// cachedPrice цена пары с временем получения
+type cachedPrice struct {
+	price     float64
+	fetchedAt time.Time
+}
+
+// ExchangePriceFeed источник цен на основе тикеров биржи с кэшированием:
+// веб-интерфейс обновляется часто, а цена для плавающей прибыли нужна не точнее нескольких секунд
+type ExchangePriceFeed struct {
+	exchangeService services.ExchangeService
+	ttl             time.Duration
+	mu              sync.Mutex
+	prices          map[string]cachedPrice
+}
+
+// NewExchangePriceFeed создает источник цен; ttl - время жизни закэшированной цены
+func NewExchangePriceFeed(exchangeService services.ExchangeService, ttl time.Duration) *ExchangePriceFeed {
+	return &ExchangePriceFeed{
+		exchangeService: exchangeService,
+		ttl:             ttl,
+		prices:          make(map[string]cachedPrice),
+	}
+}
+
+// CurrentPrice возвращает текущую цену пары из кэша или с биржи
+func (f *ExchangePriceFeed) CurrentPrice(ctx context.Context, pair string) (float64, error) {
+	f.mu.Lock()
+	cached, ok := f.prices[pair]
+	f.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < f.ttl {
+		return cached.price, nil
+	}
+
+	price, err := f.exchangeService.GetTickerPrice(ctx, valueobjects.NewTradingPair(pair).ToBybitFormat())
+	if err != nil {
+		return 0, err
+	}
+
+	f.mu.Lock()
+	f.prices[pair] = cachedPrice{price: price, fetchedAt: time.Now()}
+	f.mu.Unlock()
+	return price, nil
+}
+
+// CurrentPrices возвращает текущие цены нескольких пар. Устаревшие цены запрашиваются одним запросом тикеров,
+// если биржа это поддерживает, иначе - по одной паре; пары, цену которых получить не удалось, пропускаются
+func (f *ExchangePriceFeed) CurrentPrices(ctx context.Context, pairs []string) (map[string]float64, error) {
+	prices := make(map[string]float64, len(pairs))
+	var stale []string
+
+	f.mu.Lock()
+	for _, pair := range pairs {
+		if cached, ok := f.prices[pair]; ok && time.Since(cached.fetchedAt) < f.ttl {
+			prices[pair] = cached.price
+		} else {
+			stale = append(stale, pair)
+		}
+	}
+	f.mu.Unlock()
+
+	if len(stale) == 0 {
+		return prices, nil
+	}
+
+	tickers, ok := f.exchangeService.(services.TickersExchangeService)
+	if !ok || len(stale) == 1 {
+		var lastErr error
+		for _, pair := range stale {
+			price, err := f.CurrentPrice(ctx, pair)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			prices[pair] = price
+		}
+		if len(prices) == 0 && lastErr != nil {
+			return nil, lastErr
+		}
+		return prices, nil
+	}
+
+	symbols := make([]string, len(stale))
+	for i, pair := range stale {
+		symbols[i] = valueobjects.NewTradingPair(pair).ToBybitFormat()
+	}
+	fetched, err := tickers.GetTickerPrices(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	f.mu.Lock()
+	for i, pair := range stale {
+		if price, ok := fetched[symbols[i]]; ok {
+			prices[pair] = price
+			f.prices[pair] = cachedPrice{price: price, fetchedAt: now}
+		}
+	}
+	f.mu.Unlock()
+
+	return prices, nil
+}
@@ -2,9 +2,11 @@ package services
 
 import (
 	"context"
+	"time"
 	"trade-hedge/internal/domain/entities"
 	"trade-hedge/internal/domain/services"
 	"trade-hedge/internal/infrastructure/clients"
+	"trade-hedge/internal/pkg/tracing"
 )
 
 // ExchangeServiceAdapter адаптер для сервиса биржи
@@ -19,9 +21,33 @@ func NewExchangeServiceAdapter(bybitClient *clients.BybitClient) *ExchangeServic
 	}
 }
 
-// PlaceOrder размещает ордер на бирже
+// PlaceOrder размещает ордер на бирже (в трассе цикла - спан exchange.place_order)
 func (e *ExchangeServiceAdapter) PlaceOrder(ctx context.Context, order *entities.Order) (*entities.OrderResult, error) {
-	return e.bybitClient.PlaceOrder(ctx, order)
+	ctx, span := tracing.StartChild(ctx, "exchange.place_order",
+		tracing.String("symbol", order.Symbol),
+		tracing.String("side", string(order.Side)),
+		tracing.String("type", string(order.Type)),
+		tracing.Float64("quantity", order.Quantity),
+		tracing.Float64("price", order.Price))
+	defer span.End()
+
+	result, err := e.bybitClient.PlaceOrder(ctx, order)
+	span.RecordError(err)
+	if result != nil {
+		span.SetAttributes(tracing.String("order_id", result.OrderID))
+	}
+	return result, err
+}
+
+// CancelOrder отменяет ордер на бирже (в трассе цикла - спан exchange.cancel_order)
+func (e *ExchangeServiceAdapter) CancelOrder(ctx context.Context, orderID, symbol string) (*entities.OrderResult, error) {
+	ctx, span := tracing.StartChild(ctx, "exchange.cancel_order",
+		tracing.String("symbol", symbol), tracing.String("order_id", orderID))
+	defer span.End()
+
+	result, err := e.bybitClient.CancelOrder(ctx, orderID, symbol)
+	span.RecordError(err)
+	return result, err
 }
 
 // GetBalance получает баланс по определенной валюте
@@ -29,12 +55,73 @@ func (e *ExchangeServiceAdapter) GetBalance(ctx context.Context, asset string) (
 	return e.bybitClient.GetBalance(ctx, asset)
 }
 
-// GetOrderStatus получает статус ордера по ID
+// GetAllBalances получает балансы всех валют аккаунта
+func (e *ExchangeServiceAdapter) GetAllBalances(ctx context.Context) ([]*entities.Balance, error) {
+	return e.bybitClient.GetAllBalances(ctx)
+}
+
+// GetOrderStatus получает статус ордера по ID (в трассе цикла - спан exchange.order_status)
 func (e *ExchangeServiceAdapter) GetOrderStatus(ctx context.Context, orderID, symbol string) (*services.OrderStatusInfo, error) {
-	return e.bybitClient.GetOrderStatus(ctx, orderID, symbol)
+	ctx, span := tracing.StartChild(ctx, "exchange.order_status",
+		tracing.String("symbol", symbol), tracing.String("order_id", orderID))
+	defer span.End()
+
+	status, err := e.bybitClient.GetOrderStatus(ctx, orderID, symbol)
+	span.RecordError(err)
+	return status, err
+}
+
+// GetOrderStatusByClientID получает статус ордера по клиентскому ID
+func (e *ExchangeServiceAdapter) GetOrderStatusByClientID(ctx context.Context, clientOrderID, symbol string) (*services.OrderStatusInfo, error) {
+	return e.bybitClient.GetOrderStatusByClientID(ctx, clientOrderID, symbol)
 }
 
 // GetInstrumentInfo получает информацию об инструменте (минимальные лимиты, размеры шагов)
 func (e *ExchangeServiceAdapter) GetInstrumentInfo(ctx context.Context, symbol string) (*services.InstrumentInfo, error) {
 	return e.bybitClient.GetInstrumentInfo(ctx, symbol)
 }
+
+// GetInstruments получает все спотовые инструменты биржи
+func (e *ExchangeServiceAdapter) GetInstruments(ctx context.Context) ([]*services.InstrumentInfo, error) {
+	return e.bybitClient.GetInstruments(ctx)
+}
+
+// GetKlines получает часовые свечи инструмента за период
+func (e *ExchangeServiceAdapter) GetKlines(ctx context.Context, symbol string, start, end time.Time) ([]*entities.Kline, error) {
+	return e.bybitClient.GetKlines(ctx, symbol, start, end)
+}
+
+// GetTickerPrice получает последнюю цену инструмента
+func (e *ExchangeServiceAdapter) GetTickerPrice(ctx context.Context, symbol string) (float64, error) {
+	return e.bybitClient.GetTickerPrice(ctx, symbol)
+}
+
+// GetBookTicker получает лучшие цены покупки и продажи инструмента
+func (e *ExchangeServiceAdapter) GetBookTicker(ctx context.Context, symbol string) (*services.BookTicker, error) {
+	return e.bybitClient.GetBookTicker(ctx, symbol)
+}
+
+// GetAskDepth получает сумму заявок на продажу в пределах withinPercent от лучшей цены продажи
+func (e *ExchangeServiceAdapter) GetAskDepth(ctx context.Context, symbol string, withinPercent float64) (float64, error) {
+	return e.bybitClient.GetAskDepth(ctx, symbol, withinPercent)
+}
+
+// GetOrderHistory получает ордера инструмента, созданные в период
+func (e *ExchangeServiceAdapter) GetOrderHistory(ctx context.Context, symbol string, start, end time.Time) ([]*entities.ExchangeOrder, error) {
+	return e.bybitClient.GetOrderHistory(ctx, symbol, start, end)
+}
+
+// RequestConvertQuote запрашивает твердую котировку конвертации
+func (e *ExchangeServiceAdapter) RequestConvertQuote(ctx context.Context, fromCoin, toCoin string, fromAmount float64) (*services.ConvertQuote, error) {
+	return e.bybitClient.RequestConvertQuote(ctx, fromCoin, toCoin, fromAmount)
+}
+
+// ExecuteConvert исполняет котировку конвертации
+func (e *ExchangeServiceAdapter) ExecuteConvert(ctx context.Context, quoteID string) (*services.ConvertResult, error) {
+	return e.bybitClient.ExecuteConvert(ctx, quoteID)
+}
+
+// GetTickerPrices получает текущие цены нескольких символов одним запросом
+func (e *ExchangeServiceAdapter) GetTickerPrices(ctx context.Context, symbols []string) (map[string]float64, error) {
+	return e.bybitClient.GetTickerPrices(ctx, symbols)
+}
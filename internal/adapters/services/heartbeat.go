@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// HeartbeatPublisher отмечает успешные циклы для внешнего мониторинга: запросом на адрес пинга
+// (healthchecks.io, Uptime Kuma, Better Stack) и/или записью времени цикла в файл (проверка
+// healthcheck контейнера по времени изменения). Если отметки прекращаются, монитор оповещает
+// оператора даже тогда, когда процесс жив, но циклы не выполняются
+type HeartbeatPublisher struct {
+	config     *config.HeartbeatConfig
+	httpClient *http.Client
+
+	pinging atomic.Bool // Предыдущий пинг еще выполняется - следующий не запускается
+	failing atomic.Bool // Последний пинг не удался (ошибка логируется один раз до восстановления)
+	fileMu  sync.Mutex
+}
+
+// NewHeartbeatPublisher создает отметку успешных циклов
+func NewHeartbeatPublisher(cfg *config.HeartbeatConfig) *HeartbeatPublisher {
+	return &HeartbeatPublisher{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second},
+	}
+}
+
+// Beat записывает время цикла в файл и отправляет пинг в фоне: недоступный монитор не задерживает цикл
+func (h *HeartbeatPublisher) Beat(ctx context.Context) error {
+	if h.config.URL != "" && h.pinging.CompareAndSwap(false, true) {
+		go func() {
+			defer h.pinging.Store(false)
+			h.ping(context.WithoutCancel(ctx))
+		}()
+	}
+	if h.config.File != "" {
+		return h.writeFile(time.Now())
+	}
+	return nil
+}
+
+// ping отправляет GET на адрес пинга и логирует смену доступности монитора
+func (h *HeartbeatPublisher) ping(ctx context.Context) {
+	err := h.get(ctx)
+	if err != nil {
+		if !h.failing.Swap(true) {
+			logger.LogWithTime("⚠️ Пинг монитора %s не доставлен: %v", redactURL(h.config.URL), err)
+		}
+		return
+	}
+	if h.failing.Swap(false) {
+		logger.LogWithTime("✅ Пинг монитора %s снова доставляется", redactURL(h.config.URL))
+	}
+}
+
+// get выполняет запрос пинга
+func (h *HeartbeatPublisher) get(ctx context.Context) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, h.config.URL, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("User-Agent", "trade-hedge")
+
+	response, err := h.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("монитор ответил %s", response.Status)
+	}
+	return nil
+}
+
+// writeFile атомарно заменяет файл отметки временем цикла (RFC 3339): читатель не увидит пустой файл
+func (h *HeartbeatPublisher) writeFile(now time.Time) error {
+	h.fileMu.Lock()
+	defer h.fileMu.Unlock()
+
+	dir := filepath.Dir(h.config.File)
+	tmp, err := os.CreateTemp(dir, ".heartbeat-*")
+	if err != nil {
+		return fmt.Errorf("ошибка записи файла отметки %s: %w", h.config.File, err)
+	}
+	_, writeErr := tmp.WriteString(now.UTC().Format(time.RFC3339) + "\n")
+	closeErr := tmp.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr == nil {
+		writeErr = os.Rename(tmp.Name(), h.config.File)
+	}
+	if writeErr != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("ошибка записи файла отметки %s: %w", h.config.File, writeErr)
+	}
+	return nil
+}
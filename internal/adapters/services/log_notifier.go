@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// LogNotifier выводит оповещения в лог (используется, когда внешние каналы не настроены)
+type LogNotifier struct{}
+
+// NewLogNotifier создает оповещатель через лог
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Notify выводит оповещение в лог
+func (n *LogNotifier) Notify(ctx context.Context, notification *entities.Notification) error {
+	icon := "🔔"
+	switch {
+	case notification.Resolved:
+		icon = "✅"
+	case notification.Priority == entities.NotificationPriorityHigh:
+		icon = "🚨"
+	}
+	logger.LogWithTime("%s [%s] %s: %s", icon, notification.Priority, notification.Title, notification.Message)
+	if notification.RiskReward != nil {
+		for _, line := range riskRewardLines(notification.RiskReward) {
+			logger.LogWithTime("   %s", line)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// notificationRoute правило маршрутизации с разобранным приоритетом
+type notificationRoute struct {
+	events      map[string]bool // nil - любые события
+	minPriority entities.NotificationPriority
+	critical    bool
+	channels    []string
+}
+
+// matches проверяет, что оповещение подходит под правило. Минимальный приоритет не применяется
+// к оповещениям об устранении: они отправляются с низким приоритетом туда же, куда ушло само условие
+func (r *notificationRoute) matches(notification *entities.Notification) bool {
+	if r.events != nil && !r.events[notification.EventName()] {
+		return false
+	}
+	if r.critical && !usecases.IsCriticalAlert(notification.Key) {
+		return false
+	}
+	return notification.Resolved || notification.Priority >= r.minPriority
+}
+
+// NotificationRouter единая шина оповещений: отправляет каждое оповещение в каналы (лог, webhook, почта)
+// по правилам notifications.routes. Все источники оповещений (AlertManager, хеджирование, проверка
+// статусов, планировщик) подключаются к нему, а не к отдельным каналам
+type NotificationRouter struct {
+	channels map[string]services.Notifier
+	routes   []notificationRoute
+}
+
+// NewNotificationRouter создает шину оповещений: каналы создаются по настройкам notifications,
+// правила - из notifications.routes или config.DefaultNotificationRoutes, если правила не заданы.
+// Правила по умолчанию с ненастроенным каналом пропускаются
+func NewNotificationRouter(cfg *config.NotificationsConfig) *NotificationRouter {
+	router := &NotificationRouter{
+		channels: map[string]services.Notifier{
+			config.NotificationChannelLog: NewLogNotifier(),
+		},
+	}
+	if cfg.Webhook.Enabled() {
+		router.channels[config.NotificationChannelWebhook] = NewWebhookNotifier(&cfg.Webhook)
+	}
+	if cfg.Email.Enabled() {
+		router.channels[config.NotificationChannelEmail] = NewEmailNotifier(&cfg.Email)
+	}
+
+	routes := cfg.Routes
+	if len(routes) == 0 {
+		routes = config.DefaultNotificationRoutes()
+	}
+	for _, route := range routes {
+		router.addRoute(route)
+	}
+	return router
+}
+
+// addRoute разбирает правило; каналы, которые не настроены, отбрасываются
+func (r *NotificationRouter) addRoute(cfg config.NotificationRouteConfig) {
+	route := notificationRoute{critical: cfg.Critical}
+	if cfg.MinPriority != "" {
+		route.minPriority, _ = entities.ParseNotificationPriority(cfg.MinPriority)
+	}
+	if len(cfg.Events) > 0 {
+		route.events = make(map[string]bool, len(cfg.Events))
+		for _, event := range cfg.Events {
+			route.events[event] = true
+		}
+	}
+	for _, channel := range cfg.Channels {
+		if _, ok := r.channels[channel]; ok {
+			route.channels = append(route.channels, channel)
+		}
+	}
+	if len(route.channels) > 0 {
+		r.routes = append(r.routes, route)
+	}
+}
+
+// Channels возвращает каналы, в которые будет отправлено оповещение: объединение каналов всех
+// подходящих правил в порядке их появления. Оповещение о проблеме (alert, hedge_failed), не подошедшее
+// ни под одно правило, выводится в лог, чтобы не потеряться
+func (r *NotificationRouter) Channels(notification *entities.Notification) []string {
+	var channels []string
+	seen := make(map[string]bool)
+	for i := range r.routes {
+		if !r.routes[i].matches(notification) {
+			continue
+		}
+		for _, channel := range r.routes[i].channels {
+			if !seen[channel] {
+				seen[channel] = true
+				channels = append(channels, channel)
+			}
+		}
+	}
+
+	if len(channels) == 0 {
+		switch notification.EventName() {
+		case entities.NotificationEventAlert, entities.NotificationEventHedgeFailed:
+			channels = []string{config.NotificationChannelLog}
+		}
+	}
+	return channels
+}
+
+// Notify отправляет оповещение в каналы по правилам: ошибка одного канала не мешает остальным
+func (r *NotificationRouter) Notify(ctx context.Context, notification *entities.Notification) error {
+	var errs []error
+	for _, channel := range r.Channels(notification) {
+		if err := r.channels[channel].Notify(ctx, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LogRoutes выводит каналы и правила маршрутизации при запуске
+func (r *NotificationRouter) LogRoutes() {
+	channels := make([]string, 0, len(r.channels))
+	for _, channel := range []string{config.NotificationChannelLog, config.NotificationChannelWebhook, config.NotificationChannelEmail} {
+		if _, ok := r.channels[channel]; ok {
+			channels = append(channels, channel)
+		}
+	}
+	logger.LogWithTime("🔔 Каналы оповещений: %s, правил маршрутизации: %d", strings.Join(channels, ", "), len(r.routes))
+}
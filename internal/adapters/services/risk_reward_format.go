@@ -0,0 +1,42 @@
+package services
+
+import (
+	"fmt"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// riskRewardLines оформляет риск и прибыль хеджа короткими строками для текстовых каналов:
+// по одной величине в строке, чтобы позицию можно было оценить с экрана телефона
+func riskRewardLines(rr *entities.HedgeRiskReward) []string {
+	pair := valueobjects.NewTradingPair(rr.Pair)
+	amount := func(value float64) string {
+		return valueobjects.FormatAmount(value, rr.QuoteCurrency) + " " + rr.QuoteCurrency
+	}
+	percentFrom := func(price float64) string {
+		if rr.EntryPrice <= 0 {
+			return ""
+		}
+		return fmt.Sprintf(" (%+.2f%%)", (price-rr.EntryPrice)/rr.EntryPrice*100)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Вход: %s × %s", pair.FormatPrice(rr.EntryPrice), valueobjects.FormatAmount(rr.Quantity, pair.BaseCurrency())),
+		fmt.Sprintf("Тейк-профит: %s%s", pair.FormatPrice(rr.TakeProfitPrice), percentFrom(rr.TakeProfitPrice)),
+	}
+	if rr.StopLossPrice > 0 {
+		lines = append(lines, fmt.Sprintf("Стоп-лосс: %s%s", pair.FormatPrice(rr.StopLossPrice), percentFrom(rr.StopLossPrice)))
+	}
+	lines = append(lines, fmt.Sprintf("Прибыль на TP: %s (%+.2f%%)", amount(rr.ExpectedGain), rr.GainPercent()))
+	if rr.MaxLoss != nil {
+		lines = append(lines, fmt.Sprintf("Убыток на SL: %s", amount(*rr.MaxLoss)))
+		if ratio, ok := rr.Ratio(); ok {
+			lines = append(lines, fmt.Sprintf("Прибыль/риск: %.2f", ratio))
+		}
+	} else {
+		lines = append(lines, fmt.Sprintf("Стоп-лосса нет: под риском вся позиция %s", amount(rr.EntryPrice*rr.Quantity)))
+	}
+	lines = append(lines, fmt.Sprintf("Комиссии: %s (продажа - оценка)", amount(rr.TotalFees())))
+	return lines
+}
@@ -22,3 +22,13 @@ func NewTradeServiceAdapter(freqtradeClient *clients.FreqtradeClient) *TradeServ
 func (t *TradeServiceAdapter) GetActiveTrades(ctx context.Context) ([]*entities.Trade, error) {
 	return t.freqtradeClient.GetActiveTrades(ctx)
 }
+
+// GetPairLocks получает блокировки пар из Freqtrade
+func (t *TradeServiceAdapter) GetPairLocks(ctx context.Context) ([]*entities.PairLock, error) {
+	return t.freqtradeClient.GetPairLocks(ctx)
+}
+
+// GetClosedTrades получает закрытые сделки из истории Freqtrade
+func (t *TradeServiceAdapter) GetClosedTrades(ctx context.Context) ([]*entities.Trade, error) {
+	return t.freqtradeClient.GetClosedTrades(ctx)
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// Заголовки запросов исходящего webhook
+const (
+	WebhookEventHeader     = "X-Trade-Hedge-Event"     // Событие оповещения
+	WebhookDeliveryHeader  = "X-Trade-Hedge-Delivery"  // ID доставки: одинаков во всех повторах, получатель может отбросить дубли
+	WebhookTimestampHeader = "X-Trade-Hedge-Timestamp" // Время отправки (Unix, секунды)
+	WebhookSignatureHeader = "X-Trade-Hedge-Signature" // sha256=<hex HMAC-SHA256 от "<timestamp>.<тело>">
+)
+
+// WebhookPayload тело запроса исходящего webhook
+type WebhookPayload struct {
+	Event       string                 `json:"event"`
+	Title       string                 `json:"title"`
+	Message     string                 `json:"message"`
+	Priority    string                 `json:"priority"`
+	CreatedAt   time.Time              `json:"created_at"`
+	Key         string                 `json:"key,omitempty"`
+	Occurrences int                    `json:"occurrences,omitempty"`
+	Resolved    bool                   `json:"resolved,omitempty"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+
+	// Content текст оповещения одной строкой: Discord показывает его без дополнительной настройки
+	Content string `json:"content"`
+}
+
+// WebhookNotifier отправляет оповещения POST JSON на адреса исходящего webhook (n8n, Zapier, Discord).
+// Доставка выполняется в фоне, чтобы повторы не задерживали цикл стратегии: ошибки только логируются
+type WebhookNotifier struct {
+	config     *config.WebhookNotifierConfig
+	httpClient *http.Client
+	events     map[string]bool // Отправляемые события (nil - все)
+}
+
+// NewWebhookNotifier создает оповещатель через исходящий webhook
+func NewWebhookNotifier(cfg *config.WebhookNotifierConfig) *WebhookNotifier {
+	notifier := &WebhookNotifier{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second},
+	}
+	if len(cfg.Events) > 0 {
+		notifier.events = make(map[string]bool, len(cfg.Events))
+		for _, event := range cfg.Events {
+			notifier.events[event] = true
+		}
+	}
+	return notifier
+}
+
+// Notify ставит оповещение в отправку на все адреса, если его событие входит в notifications.webhook.events
+func (n *WebhookNotifier) Notify(ctx context.Context, notification *entities.Notification) error {
+	event := notification.EventName()
+	if n.events != nil && !n.events[event] {
+		return nil
+	}
+
+	body, err := json.Marshal(newWebhookPayload(notification))
+	if err != nil {
+		return fmt.Errorf("ошибка формирования тела webhook: %w", err)
+	}
+	deliveryID, err := newDeliveryID()
+	if err != nil {
+		return err
+	}
+
+	// Контекст отвязан от отмены: оповещение из завершенного веб-запроса или цикла должно дойти
+	deliveryCtx := context.WithoutCancel(ctx)
+	for _, address := range n.config.URLs {
+		go n.deliver(deliveryCtx, address, event, deliveryID, body)
+	}
+	return nil
+}
+
+// deliver отправляет тело на адрес с повторами: пауза перед повтором удваивается.
+// Ответ 4xx (кроме 408 и 429) означает, что получатель отклонил запрос - повтор не поможет
+func (n *WebhookNotifier) deliver(ctx context.Context, address, event, deliveryID string, body []byte) {
+	delay := time.Duration(n.config.RetryDelay) * time.Second
+	for attempt := 0; ; attempt++ {
+		retry, err := n.post(ctx, address, event, deliveryID, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= n.config.MaxRetries {
+			logger.LogWithTime("❌ Webhook %s не доставлен (событие %s, попыток %d): %v", redactURL(address), event, attempt+1, err)
+			return
+		}
+
+		logger.LogWithTime("⚠️ Ошибка доставки webhook %s (событие %s): %v - повтор через %v", redactURL(address), event, err, delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post выполняет один запрос и сообщает, имеет ли смысл повтор при ошибке
+func (n *WebhookNotifier) post(ctx context.Context, address, event, deliveryID string, body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, address, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "trade-hedge")
+	request.Header.Set(WebhookEventHeader, event)
+	request.Header.Set(WebhookDeliveryHeader, deliveryID)
+	request.Header.Set(WebhookTimestampHeader, timestamp)
+	if n.config.Secret != "" {
+		request.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(n.config.Secret, timestamp, body))
+	}
+
+	response, err := n.httpClient.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+	retry := response.StatusCode >= 500 || response.StatusCode == http.StatusRequestTimeout || response.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("получатель ответил %s", response.Status)
+}
+
+// SignWebhook возвращает подпись тела webhook: hex HMAC-SHA256 ключом secret от "<timestamp>.<тело>".
+// Время входит в подпись, чтобы перехваченный запрос нельзя было повторить позже
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newWebhookPayload переводит оповещение в тело запроса
+func newWebhookPayload(notification *entities.Notification) WebhookPayload {
+	return WebhookPayload{
+		Event:       notification.EventName(),
+		Title:       notification.Title,
+		Message:     notification.Message,
+		Priority:    notification.Priority.String(),
+		CreatedAt:   notification.CreatedAt,
+		Key:         notification.Key,
+		Occurrences: notification.Occurrences,
+		Resolved:    notification.Resolved,
+		Data:        notification.Data,
+		Content:     notification.Title + ": " + notification.Message,
+	}
+}
+
+// newDeliveryID создает случайный ID доставки
+func newDeliveryID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("ошибка генерации ID доставки webhook: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// redactURL скрывает путь и параметры адреса в логе: в них получатели (Discord, Zapier) передают токен
+func redactURL(address string) string {
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return "webhook"
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
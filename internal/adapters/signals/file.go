@@ -0,0 +1,79 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// Суффиксы обработанных файлов сигналов
+const (
+	fileDoneSuffix     = ".done"     // Сигналы переданы стратегии
+	fileRejectedSuffix = ".rejected" // Файл не разобран
+)
+
+// FileSource каталог JSON файлов сигналов (*.json, объект или массив). Забранный файл переименовывается
+// в *.json.done, неразобранный - в *.json.rejected, поэтому после перезапуска сигналы не повторяются.
+// Внешняя система записывает файл под временным именем и переименовывает в *.json, когда запись завершена
+type FileSource struct {
+	dir string
+}
+
+// NewFileSource создает источник сигналов из каталога
+func NewFileSource(dir string) *FileSource {
+	return &FileSource{dir: dir}
+}
+
+// Name возвращает название источника
+func (f *FileSource) Name() string {
+	return entities.SignalSourceFile
+}
+
+// Receive забирает сигналы из файлов каталога в порядке имен
+func (f *FileSource) Receive(ctx context.Context) ([]*entities.HedgeSignal, error) {
+	paths, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения каталога сигналов %s: %w", f.dir, err)
+	}
+	sort.Strings(paths)
+
+	var result []*entities.HedgeSignal
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return result, fmt.Errorf("ошибка чтения файла сигналов %s: %w", path, err)
+		}
+
+		signals, err := Decode(data, f.Name())
+		if err != nil {
+			logger.LogWithTime("⚠️ Файл сигналов %s отклонен: %v", filepath.Base(path), err)
+			if err := os.Rename(path, path+fileRejectedSuffix); err != nil {
+				return result, fmt.Errorf("ошибка переименования файла сигналов %s: %w", path, err)
+			}
+			continue
+		}
+
+		// Сигналы без ID получают ID по имени файла: повторно положенный файл не исполняется дважды
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		for i, signal := range signals {
+			if signal.ID == "" {
+				signal.ID = fmt.Sprintf("%s#%d", name, i+1)
+			}
+		}
+
+		if err := os.Rename(path, path+fileDoneSuffix); err != nil {
+			return result, fmt.Errorf("ошибка переименования файла сигналов %s: %w", path, err)
+		}
+		result = append(result, signals...)
+	}
+	return result, nil
+}
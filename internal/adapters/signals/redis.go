@@ -0,0 +1,128 @@
+package signals
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// redisTimeout время на подключение и обмен с Redis за один прием сигналов
+const redisTimeout = 5 * time.Second
+
+// RedisSource список Redis: внешняя система добавляет сигналы RPUSH, бот забирает LPOP.
+// Протокол RESP реализован минимально (AUTH, SELECT, LPOP) - отдельный клиент Redis не нужен
+type RedisSource struct {
+	config *config.SignalsRedisConfig
+}
+
+// NewRedisSource создает источник сигналов из списка Redis
+func NewRedisSource(cfg *config.SignalsRedisConfig) *RedisSource {
+	return &RedisSource{config: cfg}
+}
+
+// Name возвращает название источника
+func (r *RedisSource) Name() string {
+	return entities.SignalSourceRedis
+}
+
+// Receive забирает до redis.batch сигналов из списка
+func (r *RedisSource) Receive(ctx context.Context) ([]*entities.HedgeSignal, error) {
+	dialer := net.Dialer{Timeout: redisTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", r.config.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка подключения к Redis %s: %w", r.config.Addr, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(redisTimeout))
+
+	client := &respConn{conn: conn, reader: bufio.NewReader(conn)}
+	if r.config.Password != "" {
+		if _, _, err := client.do("AUTH", r.config.Password); err != nil {
+			return nil, fmt.Errorf("ошибка аутентификации Redis: %w", err)
+		}
+	}
+	if r.config.DB != 0 {
+		if _, _, err := client.do("SELECT", strconv.Itoa(r.config.DB)); err != nil {
+			return nil, fmt.Errorf("ошибка выбора базы Redis %d: %w", r.config.DB, err)
+		}
+	}
+
+	var result []*entities.HedgeSignal
+	for len(result) < r.config.Batch {
+		value, ok, err := client.do("LPOP", r.config.Key)
+		if err != nil {
+			return result, fmt.Errorf("ошибка LPOP %s: %w", r.config.Key, err)
+		}
+		if !ok {
+			break // Список пуст
+		}
+
+		signals, err := Decode([]byte(value), r.Name())
+		if err != nil {
+			logger.LogWithTime("⚠️ Сигнал из Redis %s отклонен: %v", r.config.Key, err)
+			continue
+		}
+		result = append(result, signals...)
+	}
+	return result, nil
+}
+
+// respConn соединение с Redis по протоколу RESP
+type respConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// do отправляет команду и читает ответ: строку (false - пустой ответ nil) или ошибку Redis
+func (c *respConn) do(args ...string) (string, bool, error) {
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, command.String()); err != nil {
+		return "", false, err
+	}
+	return c.readReply()
+}
+
+// readReply читает ответ простого типа: +строка, -ошибка, :число или $строка
+func (c *respConn) readReply() (string, bool, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", false, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return "", false, fmt.Errorf("пустой ответ Redis")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], true, nil
+	case '-':
+		return "", false, fmt.Errorf("%s", line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", false, fmt.Errorf("некорректная длина ответа Redis: %s", line)
+		}
+		if size < 0 {
+			return "", false, nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(c.reader, buf); err != nil {
+			return "", false, err
+		}
+		return string(buf[:size]), true, nil
+	}
+	return "", false, fmt.Errorf("неподдерживаемый ответ Redis: %q", line)
+}
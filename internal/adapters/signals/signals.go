@@ -0,0 +1,66 @@
+// Package signals реализует источники внешних сигналов хеджирования: webhook, каталог JSON файлов
+// и список Redis. Сигналы забирает стратегия хеджирования (services.SignalSource)
+package signals
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/infrastructure/config"
+)
+
+// Decode разбирает сигналы в JSON: один объект или массив объектов
+func Decode(data []byte, source string) ([]*entities.HedgeSignal, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("пустой сигнал")
+	}
+
+	var signals []*entities.HedgeSignal
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &signals); err != nil {
+			return nil, fmt.Errorf("некорректный JSON сигналов: %w", err)
+		}
+	} else {
+		var signal entities.HedgeSignal
+		if err := json.Unmarshal(data, &signal); err != nil {
+			return nil, fmt.Errorf("некорректный JSON сигнала: %w", err)
+		}
+		signals = append(signals, &signal)
+	}
+
+	now := time.Now()
+	for i, signal := range signals {
+		if signal == nil {
+			return nil, fmt.Errorf("сигнал %d: пустой объект", i+1)
+		}
+		signal.Source = source
+		signal.ReceivedAt = now
+	}
+	return signals, nil
+}
+
+// NewSources создает источники сигналов по конфигурации. Webhook возвращается отдельно:
+// его подключают и к стратегии, и к веб-серверу (nil - прием через webhook отключен)
+func NewSources(cfg *config.SignalsConfig) ([]services.SignalSource, *WebhookSource) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	var sources []services.SignalSource
+	var webhook *WebhookSource
+	if cfg.Webhook {
+		webhook = NewWebhookSource(DefaultWebhookQueue)
+		sources = append(sources, webhook)
+	}
+	if cfg.Dir != "" {
+		sources = append(sources, NewFileSource(cfg.Dir))
+	}
+	if cfg.Redis.Addr != "" {
+		sources = append(sources, NewRedisSource(&cfg.Redis))
+	}
+	return sources, webhook
+}
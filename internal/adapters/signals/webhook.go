@@ -0,0 +1,62 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"trade-hedge/internal/domain/entities"
+)
+
+// DefaultWebhookQueue максимальное количество сигналов webhook, ожидающих цикла стратегии
+const DefaultWebhookQueue = 100
+
+// WebhookSource очередь сигналов, принятых веб-сервером (POST /api/v1/signals)
+type WebhookSource struct {
+	mu    sync.Mutex
+	queue []*entities.HedgeSignal
+	limit int
+}
+
+// NewWebhookSource создает очередь сигналов webhook
+func NewWebhookSource(limit int) *WebhookSource {
+	return &WebhookSource{limit: limit}
+}
+
+// Name возвращает название источника
+func (w *WebhookSource) Name() string {
+	return entities.SignalSourceWebhook
+}
+
+// Submit ставит сигналы в очередь до следующего цикла стратегии. Сигналы проверяются сразу,
+// чтобы отправитель получил ошибку в ответе, а не только в логе бота
+func (w *WebhookSource) Submit(signals []*entities.HedgeSignal) error {
+	for i, signal := range signals {
+		if err := signal.Normalize(); err != nil {
+			return fmt.Errorf("сигнал %d: %w", i+1, err)
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.queue)+len(signals) > w.limit {
+		return fmt.Errorf("очередь сигналов заполнена (%d из %d)", len(w.queue), w.limit)
+	}
+	w.queue = append(w.queue, signals...)
+	return nil
+}
+
+// Pending возвращает количество сигналов в очереди
+func (w *WebhookSource) Pending() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.queue)
+}
+
+// Receive забирает сигналы из очереди
+func (w *WebhookSource) Receive(ctx context.Context) ([]*entities.HedgeSignal, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	signals := w.queue
+	w.queue = nil
+	return signals, nil
+}
@@ -0,0 +1,157 @@
+package stress
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+)
+
+// callCounter считает вызовы по названиям методов (потокобезопасно)
+type callCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// newCallCounter создает пустой счетчик вызовов
+func newCallCounter() *callCounter {
+	return &callCounter{counts: make(map[string]int)}
+}
+
+// count отмечает вызов метода
+func (c *callCounter) count(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[method]++
+}
+
+// snapshot возвращает копию счетчиков
+func (c *callCounter) snapshot() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := make(map[string]int, len(c.counts))
+	for method, n := range c.counts {
+		counts[method] = n
+	}
+	return counts
+}
+
+// CallStats количество вызовов одного метода
+type CallStats struct {
+	Method string
+	Calls  int
+}
+
+// sortedCalls возвращает вызовы от самых частых к редким
+func sortedCalls(counts map[string]int) []CallStats {
+	stats := make([]CallStats, 0, len(counts))
+	for method, n := range counts {
+		stats = append(stats, CallStats{Method: method, Calls: n})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Calls != stats[j].Calls {
+			return stats[i].Calls > stats[j].Calls
+		}
+		return stats[i].Method < stats[j].Method
+	})
+	return stats
+}
+
+// countingHedgeRepository считает обращения к репозиторию хеджей (нагрузку на БД)
+type countingHedgeRepository struct {
+	repo  repositories.HedgeRepository
+	calls *callCounter
+}
+
+// IsTradeHedged считает обращение и передает его хранилищу
+func (r *countingHedgeRepository) IsTradeHedged(ctx context.Context, tradeID int) (bool, error) {
+	r.calls.count("IsTradeHedged")
+	return r.repo.IsTradeHedged(ctx, tradeID)
+}
+
+// SaveHedgedTrade считает обращение и передает его хранилищу
+func (r *countingHedgeRepository) SaveHedgedTrade(ctx context.Context, hedgedTrade *entities.HedgedTrade) error {
+	r.calls.count("SaveHedgedTrade")
+	return r.repo.SaveHedgedTrade(ctx, hedgedTrade)
+}
+
+// GetHedgedTrades считает обращение и передает его хранилищу
+func (r *countingHedgeRepository) GetHedgedTrades(ctx context.Context, status *string) ([]*entities.HedgedTrade, error) {
+	r.calls.count("GetHedgedTrades")
+	return r.repo.GetHedgedTrades(ctx, status)
+}
+
+// QueryHedgedTrades считает обращение и передает его хранилищу
+func (r *countingHedgeRepository) QueryHedgedTrades(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeTradePage, error) {
+	r.calls.count("QueryHedgedTrades")
+	return r.repo.QueryHedgedTrades(ctx, query)
+}
+
+// GetTradeStats считает обращение и передает его хранилищу
+func (r *countingHedgeRepository) GetTradeStats(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeStats, error) {
+	r.calls.count("GetTradeStats")
+	return r.repo.GetTradeStats(ctx, query)
+}
+
+// UpdateHedgedTradeStatus считает обращение и передает его хранилищу
+func (r *countingHedgeRepository) UpdateHedgedTradeStatus(ctx context.Context, orderID string, status entities.OrderStatus, closePrice *float64, closeTime *time.Time) error {
+	r.calls.count("UpdateHedgedTradeStatus")
+	return r.repo.UpdateHedgedTradeStatus(ctx, orderID, status, closePrice, closeTime)
+}
+
+// UpdateHedgedTrade считает обращение и передает его хранилищу
+func (r *countingHedgeRepository) UpdateHedgedTrade(ctx context.Context, orderID string, hedgedTrade *entities.HedgedTrade) error {
+	r.calls.count("UpdateHedgedTrade")
+	return r.repo.UpdateHedgedTrade(ctx, orderID, hedgedTrade)
+}
+
+// GetHedgeHistory считает обращение и передает его хранилищу
+func (r *countingHedgeRepository) GetHedgeHistory(ctx context.Context, tradeID int) ([]*entities.HedgedTrade, error) {
+	r.calls.count("GetHedgeHistory")
+	return r.repo.GetHedgeHistory(ctx, tradeID)
+}
+
+// GetQuoteExposure считает обращение и передает его хранилищу
+func (r *countingHedgeRepository) GetQuoteExposure(ctx context.Context, quote string, since time.Time) (*entities.QuoteExposure, error) {
+	r.calls.count("GetQuoteExposure")
+	return r.repo.GetQuoteExposure(ctx, quote, since)
+}
+
+// countingHedgeIntentRepository считает обращения к репозиторию намерений хеджирования
+type countingHedgeIntentRepository struct {
+	repo  repositories.HedgeIntentRepository
+	calls *callCounter
+}
+
+// SaveHedgeIntent считает обращение и передает его хранилищу
+func (r *countingHedgeIntentRepository) SaveHedgeIntent(ctx context.Context, intent *entities.HedgeIntent) error {
+	r.calls.count("SaveHedgeIntent")
+	return r.repo.SaveHedgeIntent(ctx, intent)
+}
+
+// UpdateHedgeIntent считает обращение и передает его хранилищу
+func (r *countingHedgeIntentRepository) UpdateHedgeIntent(ctx context.Context, intent *entities.HedgeIntent) error {
+	r.calls.count("UpdateHedgeIntent")
+	return r.repo.UpdateHedgeIntent(ctx, intent)
+}
+
+// GetInFlightHedgeIntents считает обращение и передает его хранилищу
+func (r *countingHedgeIntentRepository) GetInFlightHedgeIntents(ctx context.Context) ([]*entities.HedgeIntent, error) {
+	r.calls.count("GetInFlightHedgeIntents")
+	return r.repo.GetInFlightHedgeIntents(ctx)
+}
+
+// GetHedgeIntentByOrderID считает обращение и передает его хранилищу
+func (r *countingHedgeIntentRepository) GetHedgeIntentByOrderID(ctx context.Context, orderID string) (*entities.HedgeIntent, error) {
+	r.calls.count("GetHedgeIntentByOrderID")
+	return r.repo.GetHedgeIntentByOrderID(ctx, orderID)
+}
+
+// CountHedgeIntents считает обращение и передает его хранилищу
+func (r *countingHedgeIntentRepository) CountHedgeIntents(ctx context.Context, tradeID, tranche int) (int, error) {
+	r.calls.count("CountHedgeIntents")
+	return r.repo.CountHedgeIntents(ctx, tradeID, tranche)
+}
@@ -0,0 +1,125 @@
+package stress
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// syntheticMarket синтетический рынок: цены пар меняются случайным блужданием каждый цикл.
+// Общий для синтетического Freqtrade и биржи-заглушки, чтобы цены сделок и тикеров совпадали
+type syntheticMarket struct {
+	mu      sync.RWMutex
+	rnd     *rand.Rand
+	pairs   []string           // Пары в формате BASE/QUOTE
+	symbols map[string]string  // Пара по символу биржи (SYN001USDT → SYN001/USDT)
+	prices  map[string]float64 // Текущая цена по паре
+}
+
+// newSyntheticMarket создает рынок из pairs пар с ценами от 0.01 до 1000
+func newSyntheticMarket(pairs int, quote string, seed int64) *syntheticMarket {
+	m := &syntheticMarket{
+		rnd:     rand.New(rand.NewSource(seed)),
+		symbols: make(map[string]string, pairs),
+		prices:  make(map[string]float64, pairs),
+	}
+	for i := 0; i < pairs; i++ {
+		pair := fmt.Sprintf("SYN%03d/%s", i+1, quote)
+		m.pairs = append(m.pairs, pair)
+		m.symbols[valueobjects.NewTradingPair(pair).ToBybitFormat()] = pair
+		// Цены разных порядков проверяют округление до шагов инструментов
+		m.prices[pair] = math.Pow(10, float64(i%6)-2) * (1 + m.rnd.Float64())
+	}
+	return m
+}
+
+// step сдвигает цены всех пар на случайную величину в пределах ±volatility
+func (m *syntheticMarket) step(volatility float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, pair := range m.pairs {
+		m.prices[pair] *= 1 + (m.rnd.Float64()*2-1)*volatility
+	}
+}
+
+// price возвращает текущую цену пары
+func (m *syntheticMarket) price(pair string) (float64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	price, ok := m.prices[pair]
+	return price, ok
+}
+
+// symbolPair находит пару по символу биржи; пара в формате BASE/QUOTE возвращается как есть
+// (проверка статусов передает бирже пару, а не символ)
+func (m *syntheticMarket) symbolPair(symbol string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.prices[symbol]; ok {
+		return symbol, true
+	}
+	pair, ok := m.symbols[symbol]
+	return pair, ok
+}
+
+// syntheticTradeService синтетический Freqtrade: открытые сделки распределены по парам рынка,
+// цена открытия фиксируется при создании, текущая цена и просадка берутся с рынка
+type syntheticTradeService struct {
+	market *syntheticMarket
+	trades []*entities.Trade
+	calls  *callCounter
+}
+
+// newSyntheticTradeService создает count сделок; цена открытия - до 15% выше текущей,
+// поэтому часть сделок сразу убыточна
+func newSyntheticTradeService(market *syntheticMarket, count int, calls *callCounter) *syntheticTradeService {
+	s := &syntheticTradeService{market: market, calls: calls}
+	for i := 0; i < count; i++ {
+		pair := market.pairs[i%len(market.pairs)]
+		price, _ := market.price(pair)
+		openRate := price * (1 + market.rnd.Float64()*0.15)
+		s.trades = append(s.trades, &entities.Trade{
+			ID:       i + 1,
+			Pair:     pair,
+			IsOpen:   true,
+			OpenRate: openRate,
+			Amount:   100 / openRate,
+			OpenTime: time.Now().Add(-time.Duration(market.rnd.Intn(72*60)) * time.Minute),
+		})
+	}
+	return s
+}
+
+// GetActiveTrades возвращает сделки с текущими ценами рынка (каждый раз новые копии, как ответ API)
+func (s *syntheticTradeService) GetActiveTrades(ctx context.Context) ([]*entities.Trade, error) {
+	s.calls.count("GetActiveTrades")
+
+	trades := make([]*entities.Trade, 0, len(s.trades))
+	for _, trade := range s.trades {
+		current := *trade
+		current.CurrentRate, _ = s.market.price(trade.Pair)
+		current.ProfitRatio = current.CurrentRate/current.OpenRate - 1
+		trades = append(trades, &current)
+	}
+	return trades, nil
+}
+
+// GetPairLocks возвращает пустой список: синтетический Freqtrade не блокирует пары
+func (s *syntheticTradeService) GetPairLocks(ctx context.Context) ([]*entities.PairLock, error) {
+	s.calls.count("GetPairLocks")
+	return nil, nil
+}
+
+// GetClosedTrades возвращает пустую историю: сделки синтетического Freqtrade не закрываются
+func (s *syntheticTradeService) GetClosedTrades(ctx context.Context) ([]*entities.Trade, error) {
+	s.calls.count("GetClosedTrades")
+	return nil, nil
+}
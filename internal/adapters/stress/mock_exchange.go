@@ -0,0 +1,255 @@
+package stress
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// mockFeeRate комиссия биржи-заглушки за исполнение (0.1%, как базовая ставка спота Bybit)
+const mockFeeRate = 0.001
+
+// mockOrder ордер биржи-заглушки
+type mockOrder struct {
+	id          string
+	pair        string
+	side        entities.OrderSide
+	price       float64
+	quantity    float64
+	status      entities.OrderStatus
+	filledPrice float64
+	filledTime  time.Time
+	fee         float64
+	feeCurrency string
+}
+
+// mockExchange биржа-заглушка на синтетическом рынке: покупки исполняются сразу, лимитные продажи -
+// когда цена рынка достигает цены ордера. Баланс не ограничен. Каждый вызов API считается и
+// задерживается на latency, чтобы оценить длительность цикла с реальной биржей
+type mockExchange struct {
+	market  *syntheticMarket
+	latency time.Duration
+	calls   *callCounter
+
+	mu       sync.Mutex
+	nextID   int
+	orders   map[string]*mockOrder
+	clientID map[string]string // ID ордера по клиентскому ID
+}
+
+// newMockExchange создает биржу-заглушку
+func newMockExchange(market *syntheticMarket, latency time.Duration, calls *callCounter) *mockExchange {
+	return &mockExchange{
+		market:   market,
+		latency:  latency,
+		calls:    calls,
+		orders:   make(map[string]*mockOrder),
+		clientID: make(map[string]string),
+	}
+}
+
+// call отмечает вызов API и имитирует сетевую задержку
+func (e *mockExchange) call(ctx context.Context, method string) error {
+	e.calls.count(method)
+	if e.latency <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(e.latency)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// PlaceOrder размещает ордер: покупка исполняется сразу по цене ордера (рыночная - по цене рынка)
+func (e *mockExchange) PlaceOrder(ctx context.Context, order *entities.Order) (*entities.OrderResult, error) {
+	if err := e.call(ctx, "PlaceOrder"); err != nil {
+		return nil, err
+	}
+
+	pair, ok := e.market.symbolPair(order.Symbol)
+	if !ok {
+		return &entities.OrderResult{Success: false, Error: fmt.Sprintf("неизвестный символ %s", order.Symbol)}, nil
+	}
+	marketPrice, _ := e.market.price(pair)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.nextID++
+	placed := &mockOrder{
+		id:       fmt.Sprintf("stress-%d", e.nextID),
+		pair:     pair,
+		side:     order.Side,
+		price:    order.Price,
+		quantity: order.Quantity,
+		status:   entities.OrderStatusPending,
+	}
+	if order.Type == entities.OrderTypeMarket {
+		placed.price = marketPrice
+		if order.QuoteQuantity && marketPrice > 0 {
+			placed.quantity = order.Quantity / marketPrice
+		}
+	}
+	if order.Side == entities.OrderSideBuy || order.Type == entities.OrderTypeMarket {
+		placed.fill(time.Now())
+	}
+
+	e.orders[placed.id] = placed
+	if order.ClientOrderID != "" {
+		e.clientID[order.ClientOrderID] = placed.id
+	}
+	return &entities.OrderResult{OrderID: placed.id, Success: true}, nil
+}
+
+// CancelOrder отменяет неисполненный ордер
+func (e *mockExchange) CancelOrder(ctx context.Context, orderID, symbol string) (*entities.OrderResult, error) {
+	if err := e.call(ctx, "CancelOrder"); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	order, ok := e.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("ордер %s не найден", orderID)
+	}
+	if !order.status.IsCompleted() {
+		order.status = entities.OrderStatusCancelled
+	}
+	return &entities.OrderResult{OrderID: orderID, Success: true}, nil
+}
+
+// GetBalance возвращает неограниченный баланс любой валюты
+func (e *mockExchange) GetBalance(ctx context.Context, asset string) (*entities.Balance, error) {
+	if err := e.call(ctx, "GetBalance"); err != nil {
+		return nil, err
+	}
+	return &entities.Balance{Asset: asset, Available: 1e12, Total: 1e12}, nil
+}
+
+// GetOrderStatus возвращает статус ордера; лимитная продажа исполняется, если рынок дошел до ее цены
+func (e *mockExchange) GetOrderStatus(ctx context.Context, orderID, symbol string) (*services.OrderStatusInfo, error) {
+	if err := e.call(ctx, "GetOrderStatus"); err != nil {
+		return nil, err
+	}
+	return e.orderStatus(orderID)
+}
+
+// GetOrderStatusByClientID возвращает статус ордера по клиентскому ID (nil, если ордер не найден)
+func (e *mockExchange) GetOrderStatusByClientID(ctx context.Context, clientOrderID, symbol string) (*services.OrderStatusInfo, error) {
+	if err := e.call(ctx, "GetOrderStatusByClientID"); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	orderID, ok := e.clientID[clientOrderID]
+	e.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	return e.orderStatus(orderID)
+}
+
+// GetInstrumentInfo возвращает правила инструмента с шагами под порядок цены пары
+func (e *mockExchange) GetInstrumentInfo(ctx context.Context, symbol string) (*services.InstrumentInfo, error) {
+	if err := e.call(ctx, "GetInstrumentInfo"); err != nil {
+		return nil, err
+	}
+
+	pair, ok := e.market.symbolPair(symbol)
+	if !ok {
+		return nil, fmt.Errorf("неизвестный символ %s", symbol)
+	}
+	price, _ := e.market.price(pair)
+	tickSize := 0.00000001
+	for tickSize*1e6 < price {
+		tickSize *= 10
+	}
+
+	return &services.InstrumentInfo{
+		Symbol:      symbol,
+		MinOrderQty: 0.000001,
+		MinOrderAmt: 1,
+		TickSize:    tickSize,
+		StepSize:    0.000001,
+		Status:      "Trading",
+	}, nil
+}
+
+// GetTickerPrice возвращает текущую цену синтетического рынка
+func (e *mockExchange) GetTickerPrice(ctx context.Context, symbol string) (float64, error) {
+	if err := e.call(ctx, "GetTickerPrice"); err != nil {
+		return 0, err
+	}
+
+	pair, ok := e.market.symbolPair(symbol)
+	if !ok {
+		return 0, fmt.Errorf("неизвестный символ %s", symbol)
+	}
+	price, _ := e.market.price(pair)
+	return price, nil
+}
+
+// GetKlines возвращает пустую историю свечей
+func (e *mockExchange) GetKlines(ctx context.Context, symbol string, start, end time.Time) ([]*entities.Kline, error) {
+	if err := e.call(ctx, "GetKlines"); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// orderStatus возвращает статус ордера, исполняя лимитную продажу по достижении цены
+func (e *mockExchange) orderStatus(orderID string) (*services.OrderStatusInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	order, ok := e.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("ордер %s не найден", orderID)
+	}
+	if order.status == entities.OrderStatusPending {
+		if price, _ := e.market.price(order.pair); price >= order.price {
+			order.fill(time.Now())
+		}
+	}
+
+	info := &services.OrderStatusInfo{
+		OrderID:      order.id,
+		Status:       order.status,
+		RemainingQty: order.quantity,
+	}
+	if order.status == entities.OrderStatusFilled {
+		filledPrice, filledTime := order.filledPrice, order.filledTime
+		info.FilledPrice = &filledPrice
+		info.FilledTime = &filledTime
+		info.FilledQty = order.quantity
+		info.RemainingQty = 0
+		info.Fee = order.fee
+		info.FeeCurrency = order.feeCurrency
+	}
+	return info, nil
+}
+
+// fill исполняет ордер полностью по его цене; комиссия списывается в получаемой валюте, как на споте
+func (o *mockOrder) fill(at time.Time) {
+	o.status = entities.OrderStatusFilled
+	o.filledPrice = o.price
+	o.filledTime = at
+
+	pair := valueobjects.NewTradingPair(o.pair)
+	if o.side == entities.OrderSideBuy {
+		o.fee, o.feeCurrency = o.quantity*mockFeeRate, pair.BaseCurrency()
+	} else {
+		o.fee, o.feeCurrency = o.quantity*o.price*mockFeeRate, pair.QuoteCurrency()
+	}
+}
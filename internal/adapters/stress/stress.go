@@ -0,0 +1,239 @@
+// Package stress реализует нагрузочную проверку: команда trade-hedge stress генерирует синтетические
+// ответы Freqtrade и прогоняет циклы стратегии против биржи-заглушки, показывая длительность цикла,
+// нагрузку на БД и количество вызовов API до того, как бот вырастет до такого объема
+package stress
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+	"trade-hedge/internal/adapters/repositories"
+	domainErrors "trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// CommandName название подкоманды нагрузочной проверки
+const CommandName = "stress"
+
+// stressQuote котируемая валюта синтетических пар
+const stressQuote = "USDT"
+
+// stressVolatility максимальное изменение цены пары за цикл
+const stressVolatility = 0.03
+
+// Options параметры нагрузочной проверки
+type Options struct {
+	Trades   int           // Количество открытых сделок в синтетическом Freqtrade
+	Pairs    int           // Количество пар, по которым распределены сделки
+	Cycles   int           // Количество циклов планировщика
+	Parallel int           // Сколько сделок хеджировать за цикл параллельно (max_parallel_hedges)
+	Latency  time.Duration // Имитируемая задержка каждого вызова API биржи
+	Seed     int64         // Зерно генератора цен (одинаковое зерно - одинаковый прогон)
+}
+
+// ParseOptions разбирает аргументы подкоманды: --trades 500 --pairs 100 --cycles 20 ...
+func ParseOptions(args []string, output io.Writer) (*Options, error) {
+	opts := &Options{}
+	flags := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.IntVar(&opts.Trades, "trades", 500, "количество открытых сделок Freqtrade")
+	flags.IntVar(&opts.Pairs, "pairs", 100, "количество пар")
+	flags.IntVar(&opts.Cycles, "cycles", 20, "количество циклов стратегии")
+	flags.IntVar(&opts.Parallel, "parallel", 1, "сколько сделок хеджировать за цикл параллельно")
+	flags.DurationVar(&opts.Latency, "latency", 0, "задержка каждого вызова API биржи (например, 50ms)")
+	flags.Int64Var(&opts.Seed, "seed", 1, "зерно генератора цен")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// Validate проверяет параметры нагрузочной проверки
+func (o *Options) Validate() error {
+	if o.Trades <= 0 {
+		return fmt.Errorf("--trades должен быть больше 0")
+	}
+	if o.Pairs <= 0 {
+		return fmt.Errorf("--pairs должен быть больше 0")
+	}
+	if o.Pairs > 999 {
+		return fmt.Errorf("--pairs не должен превышать 999")
+	}
+	if o.Cycles <= 0 {
+		return fmt.Errorf("--cycles должен быть больше 0")
+	}
+	if o.Parallel <= 0 {
+		return fmt.Errorf("--parallel должен быть больше 0")
+	}
+	if o.Latency < 0 {
+		return fmt.Errorf("--latency не может быть отрицательной")
+	}
+	return nil
+}
+
+// Report результаты нагрузочной проверки
+type Report struct {
+	Options Options
+
+	CycleDurations []time.Duration // Длительность каждого цикла
+	CycleErrors    int             // Циклы, завершившиеся непредвиденной ошибкой
+
+	HedgesOpened      int // Хеджей открыто за прогон
+	TakeProfitsFilled int // Тейк-профитов исполнено за прогон
+
+	ExchangeCalls   map[string]int // Вызовы API биржи по методам
+	RepositoryCalls map[string]int // Обращения к хранилищу (нагрузка на БД) по методам
+	FreqtradeCalls  map[string]int // Вызовы API Freqtrade по методам
+}
+
+// Run выполняет нагрузочную проверку: каждый цикл повторяет работу планировщика
+// (проверка статусов, затем стратегия), после чего цены синтетического рынка сдвигаются
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	market := newSyntheticMarket(opts.Pairs, stressQuote, opts.Seed)
+	exchangeCalls, repositoryCalls, freqtradeCalls := newCallCounter(), newCallCounter(), newCallCounter()
+
+	tradeService := newSyntheticTradeService(market, opts.Trades, freqtradeCalls)
+	exchange := newMockExchange(market, opts.Latency, exchangeCalls)
+	memoryHedgeRepo := repositories.NewMemoryHedgeRepository()
+	hedgeRepo := &countingHedgeRepository{repo: memoryHedgeRepo, calls: repositoryCalls}
+	intentRepo := &countingHedgeIntentRepository{repo: repositories.NewMemoryHedgeIntentRepository(), calls: repositoryCalls}
+
+	statusChecker := usecases.NewStatusCheckerUseCase(hedgeRepo, intentRepo, exchange)
+	hedgeUseCase := usecases.NewHedgeStrategyUseCase(tradeService, hedgeRepo, intentRepo, exchange, statusChecker, strategyConfig(opts))
+
+	report := &Report{Options: opts}
+	for cycle := 1; cycle <= opts.Cycles; cycle++ {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		logger.LogWithTime("🏋️ Нагрузочная проверка: цикл %d из %d", cycle, opts.Cycles)
+		started := time.Now()
+
+		if err := statusChecker.CheckAllActiveOrders(ctx); err != nil {
+			logger.LogWithTime("❌ Ошибка проверки статусов ордеров: %v", err)
+			report.CycleErrors++
+		}
+		if err := hedgeUseCase.ExecuteHedgeStrategy(ctx); err != nil && !isExpectedStrategyError(err) {
+			logger.LogWithTime("❌ Ошибка выполнения стратегии: %v", err)
+			report.CycleErrors++
+		}
+
+		report.CycleDurations = append(report.CycleDurations, time.Since(started))
+		market.step(stressVolatility)
+	}
+
+	// Итоги считаются напрямую по хранилищу, чтобы не учитывать их в нагрузке на БД
+	hedges, err := memoryHedgeRepo.GetHedgedTrades(ctx, nil)
+	if err != nil {
+		return report, fmt.Errorf("ошибка получения хеджей: %w", err)
+	}
+	report.HedgesOpened = len(hedges)
+	for _, hedge := range hedges {
+		if hedge.OrderStatus.IsSuccessful() {
+			report.TakeProfitsFilled++
+		}
+	}
+
+	report.ExchangeCalls = exchangeCalls.snapshot()
+	report.RepositoryCalls = repositoryCalls.snapshot()
+	report.FreqtradeCalls = freqtradeCalls.snapshot()
+	return report, nil
+}
+
+// RunCommand выполняет подкоманду stress с аргументами командной строки и выводит отчет
+func RunCommand(ctx context.Context, args []string, output io.Writer) error {
+	opts, err := ParseOptions(args, output)
+	if err != nil {
+		return err
+	}
+
+	report, err := Run(ctx, *opts)
+	if report != nil {
+		report.Print(output)
+	}
+	return err
+}
+
+// strategyConfig конфигурация стратегии для прогона: значения по умолчанию из конфигурации,
+// без ожидания между опросами и без внеочередных проверок
+func strategyConfig(opts Options) *usecases.HedgeStrategyConfig {
+	return &usecases.HedgeStrategyConfig{
+		PositionAmount:      50.0,
+		MaxLossPercent:      3.0,
+		ProfitRatio:         0.7,
+		BaseCurrency:        stressQuote,
+		RetryAttempts:       1,
+		BuyFillTimeout:      time.Second,
+		BuyFillPollInterval: time.Millisecond,
+		MaxParallelHedges:   opts.Parallel,
+	}
+}
+
+// isExpectedStrategyError проверяет, что ошибка стратегии ожидаема (нет сделок, лимиты и т.п.)
+func isExpectedStrategyError(err error) bool {
+	var strategyErr *domainErrors.StrategyError
+	return errors.As(err, &strategyErr) && strategyErr.IsExpected()
+}
+
+// Print выводит отчет нагрузочной проверки
+func (r *Report) Print(output io.Writer) {
+	fmt.Fprintf(output, "\n🏋️ Нагрузочная проверка: %d сделок, %d пар, %d циклов (параллельно: %d, задержка API: %s)\n",
+		r.Options.Trades, r.Options.Pairs, len(r.CycleDurations), r.Options.Parallel, r.Options.Latency)
+
+	if len(r.CycleDurations) > 0 {
+		minimum, average, p95, maximum := durationStats(r.CycleDurations)
+		fmt.Fprintf(output, "⏱️ Длительность цикла: мин %s, сред %s, p95 %s, макс %s\n",
+			minimum.Round(time.Microsecond), average.Round(time.Microsecond),
+			p95.Round(time.Microsecond), maximum.Round(time.Microsecond))
+	}
+	fmt.Fprintf(output, "📈 Хеджей открыто: %d, тейк-профитов исполнено: %d, циклов с ошибкой: %d\n",
+		r.HedgesOpened, r.TakeProfitsFilled, r.CycleErrors)
+
+	r.printCalls(output, "🏦 Вызовы API биржи", r.ExchangeCalls)
+	r.printCalls(output, "🗄️ Обращения к БД", r.RepositoryCalls)
+	r.printCalls(output, "🤖 Вызовы API Freqtrade", r.FreqtradeCalls)
+}
+
+// printCalls выводит вызовы по методам: всего и в среднем за цикл
+func (r *Report) printCalls(output io.Writer, title string, counts map[string]int) {
+	cycles := len(r.CycleDurations)
+	if cycles == 0 {
+		cycles = 1
+	}
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	fmt.Fprintf(output, "%s: всего %d, за цикл %.1f\n", title, total, float64(total)/float64(cycles))
+	for _, stats := range sortedCalls(counts) {
+		fmt.Fprintf(output, "   %-26s %8d  (%.1f за цикл)\n", stats.Method, stats.Calls, float64(stats.Calls)/float64(cycles))
+	}
+}
+
+// durationStats возвращает минимальную, среднюю, 95-й перцентиль и максимальную длительность
+func durationStats(durations []time.Duration) (minimum, average, p95, maximum time.Duration) {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	index := (len(sorted)*95+99)/100 - 1
+	return sorted[0], total / time.Duration(len(sorted)), sorted[index], sorted[len(sorted)-1]
+}
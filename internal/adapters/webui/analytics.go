@@ -0,0 +1,285 @@
+package webui
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/usecases"
+)
+
+// Параметры тепловой карты по умолчанию
+const (
+	defaultHeatmapDays    = 30
+	defaultHeatmapHorizon = 24
+)
+
+// handleAnalytics страница аналитики возможностей хеджирования
+func (s *Server) handleAnalytics(w http.ResponseWriter, r *http.Request) {
+	data := PageData{
+		Title: "Аналитика",
+	}
+
+	if err := s.executeTemplate(w, r, "analytics.html", data); err != nil {
+		log.Printf("❌ Ошибка рендеринга шаблона analytics.html: %v", err)
+		return
+	}
+}
+
+// handleAPIHeatmap API тепловой карты: пересечения порога просадки по парам и часам суток (UTC)
+// и движение цены после них. Параметры: days (глубина истории), horizon (часов после пересечения)
+func (s *Server) handleAPIHeatmap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.heatmapUseCase == nil {
+		s.sendError(w, "Аналитика недоступна: база данных не настроена", http.StatusServiceUnavailable)
+		return
+	}
+
+	days := queryInt(r, "days", defaultHeatmapDays)
+	horizon := queryInt(r, "horizon", defaultHeatmapHorizon)
+	if days <= 0 || days > 365 || horizon <= 0 || horizon > 168 {
+		s.sendError(w, "Параметры days (1-365) и horizon (1-168) вне допустимого диапазона", http.StatusBadRequest)
+		return
+	}
+
+	heatmap, err := s.heatmapUseCase.BuildHeatmap(r.Context(), days, horizon)
+	if err != nil {
+		s.sendError(w, "Ошибка построения тепловой карты", http.StatusInternalServerError)
+		return
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    heatmap,
+	})
+}
+
+// handleAPIAccountHistory API сводки по импортированной истории ордеров аккаунта
+// (в том числе ручной торговли до запуска бота). Параметр: days (глубина истории)
+func (s *Server) handleAPIAccountHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.accountHistory == nil {
+		s.sendError(w, "История ордеров аккаунта не импортируется", http.StatusServiceUnavailable)
+		return
+	}
+
+	days := queryInt(r, "days", defaultHeatmapDays)
+	if days <= 0 || days > 730 {
+		s.sendError(w, "Параметр days (1-730) вне допустимого диапазона", http.StatusBadRequest)
+		return
+	}
+
+	summaries, err := s.accountHistory.Summarize(r.Context(), days)
+	if err != nil {
+		s.sendError(w, "Ошибка получения истории ордеров аккаунта", http.StatusInternalServerError)
+		return
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    summaries,
+	})
+}
+
+// handleAPILatency API распределений задержек хеджирования: от пересечения порога до ордера на покупку,
+// от исполнения покупки до тейк-профита и изменения цены за это время. Параметр: days (глубина истории)
+func (s *Server) handleAPILatency(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+
+	days := queryInt(r, "days", defaultHeatmapDays)
+	if days <= 0 || days > 365 {
+		s.sendError(w, "Параметр days (1-365) вне допустимого диапазона", http.StatusBadRequest)
+		return
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+	page, err := s.hedgeRepo.QueryHedgedTrades(r.Context(), &entities.HedgeTradeQuery{From: &since})
+	if err != nil {
+		s.sendError(w, "Ошибка получения хеджированных сделок", http.StatusInternalServerError)
+		return
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    usecases.BuildHedgeLatencyReport(page.Trades, since),
+	})
+}
+
+// handleAPIExitQuality API качества выхода из закрытых хеджей: насколько цена выхода близка
+// к максимуму цены за время удержания, по парам и способам закрытия. Параметр: days (глубина истории)
+func (s *Server) handleAPIExitQuality(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.exitQuality == nil {
+		s.sendError(w, "Отчет о качестве выхода недоступен", http.StatusServiceUnavailable)
+		return
+	}
+
+	days := queryInt(r, "days", defaultHeatmapDays)
+	if days <= 0 || days > 365 {
+		s.sendError(w, "Параметр days (1-365) вне допустимого диапазона", http.StatusBadRequest)
+		return
+	}
+
+	report, err := s.exitQuality.BuildReport(r.Context(), days)
+	if err != nil {
+		s.sendError(w, "Ошибка построения отчета о качестве выхода", http.StatusInternalServerError)
+		return
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// handleAPIPassiveEntry API статистики мейкерских попыток покупки с запуска процесса
+func (s *Server) handleAPIPassiveEntry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    s.hedgeUseCase.PassiveEntryStats(),
+	})
+}
+
+// ProfitSeriesView ряд реализованной прибыли для графиков
+type ProfitSeriesView struct {
+	Bucket string                   `json:"bucket"`
+	From   time.Time                `json:"from"`
+	To     time.Time                `json:"to"`
+	Series []*entities.ProfitBucket `json:"series"`
+}
+
+// handleAPIProfitSeries API ряда реализованной прибыли по дням или неделям.
+// Параметры: bucket (day/week), days (глубина истории)
+func (s *Server) handleAPIProfitSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	analytics, ok := s.hedgeRepo.(repositories.HedgeAnalyticsRepository)
+	if !ok {
+		s.sendError(w, "Ряд прибыли не поддерживается хранилищем", http.StatusServiceUnavailable)
+		return
+	}
+	bucket, from, to, ok := s.profitSeriesPeriod(w, r)
+	if !ok {
+		return
+	}
+
+	series, err := analytics.GetProfitTimeSeries(r.Context(), bucket, from, to)
+	if err != nil {
+		s.sendError(w, "Ошибка получения ряда прибыли", http.StatusInternalServerError)
+		return
+	}
+	if series == nil {
+		series = []*entities.ProfitBucket{}
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    ProfitSeriesView{Bucket: bucket, From: from, To: to, Series: series},
+	})
+}
+
+// ProfitChartView точки графиков прибыли дашборда: кривая накопленной прибыли, хеджи и доля прибыльных
+// хеджей по интервалам
+type ProfitChartView struct {
+	Bucket string                       `json:"bucket"`
+	From   time.Time                    `json:"from"`
+	To     time.Time                    `json:"to"`
+	Start  *entities.ProfitBucket       `json:"start"` // Итоги хеджей, закрытых до from (начало кривой)
+	Points []*entities.ProfitChartPoint `json:"points"`
+}
+
+// handleAPIProfitChart API графиков прибыли: ряд прибыли с интервалами без хеджей и накопленными итогами.
+// Параметры как у /api/analytics/pnl
+func (s *Server) handleAPIProfitChart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	analytics, ok := s.hedgeRepo.(repositories.HedgeAnalyticsRepository)
+	if !ok {
+		s.sendError(w, "Графики прибыли не поддерживаются хранилищем", http.StatusServiceUnavailable)
+		return
+	}
+	bucket, from, to, ok := s.profitSeriesPeriod(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	start, err := analytics.GetProfitTotals(ctx, from)
+	if err != nil {
+		log.Printf("❌ Ошибка получения итогов прибыли: %v", err)
+		s.sendError(w, "Ошибка получения итогов прибыли", http.StatusInternalServerError)
+		return
+	}
+	series, err := analytics.GetProfitTimeSeries(ctx, bucket, from, to)
+	if err != nil {
+		log.Printf("❌ Ошибка получения ряда прибыли: %v", err)
+		s.sendError(w, "Ошибка получения ряда прибыли", http.StatusInternalServerError)
+		return
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data: ProfitChartView{
+			Bucket: bucket,
+			From:   from,
+			To:     to,
+			Start:  start,
+			Points: entities.BuildProfitChart(bucket, from, to, series, start),
+		},
+	})
+}
+
+// profitSeriesPeriod читает параметры ряда прибыли bucket (day/week) и days (глубина истории).
+// При ошибке отправляет ответ 400 и возвращает ok = false
+func (s *Server) profitSeriesPeriod(w http.ResponseWriter, r *http.Request) (bucket string, from, to time.Time, ok bool) {
+	bucket = r.URL.Query().Get("bucket")
+	if bucket == "" {
+		bucket = entities.ProfitBucketDay
+	}
+	if !entities.IsProfitBucket(bucket) {
+		s.sendError(w, "Параметр bucket должен быть day или week", http.StatusBadRequest)
+		return "", time.Time{}, time.Time{}, false
+	}
+	days := queryInt(r, "days", defaultHeatmapDays)
+	if days <= 0 || days > 365 {
+		s.sendError(w, "Параметр days (1-365) вне допустимого диапазона", http.StatusBadRequest)
+		return "", time.Time{}, time.Time{}, false
+	}
+
+	to = time.Now().UTC()
+	from = entities.ProfitBucketStart(bucket, to.AddDate(0, 0, -days))
+	return bucket, from, to, true
+}
+
+// queryInt читает целочисленный параметр запроса; при отсутствии или ошибке возвращает значение по умолчанию
+func queryInt(r *http.Request, name string, fallback int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,385 @@
+package webui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// apiV1Prefix префикс версионированного REST API. Эндпоинты /api/v1 отвечают только JSON
+// в едином формате (data/meta при успехе, error при ошибке) и проверяют параметры запроса;
+// эндпоинты /api/... без версии остаются для HTML страниц и обратной совместимости
+const apiV1Prefix = "/api/v1"
+
+// Коды ошибок /api/v1
+const (
+	v1ErrNotFound         = "not_found"
+	v1ErrMethodNotAllowed = "method_not_allowed"
+	v1ErrInvalidParameter = "invalid_parameter"
+	v1ErrUnknownParameter = "unknown_parameter"
+	v1ErrConflict         = "conflict"
+	v1ErrUnavailable      = "unavailable"
+	v1ErrInternal         = "internal_error"
+)
+
+// Параметры запросов /api/v1: неизвестный параметр - ошибка, а не молча игнорируемая опечатка
+var (
+	v1FilterParams = []string{"status", "pair", "version", "from", "to", "archived"}
+	v1TradesParams = append([]string{"sort", "order", "limit", "offset", "facets"}, v1FilterParams...)
+	v1StatsParams  = append([]string{"days"}, v1FilterParams...)
+)
+
+// V1Response успешный ответ /api/v1
+type V1Response struct {
+	Data interface{} `json:"data"`
+	Meta interface{} `json:"meta,omitempty"`
+}
+
+// V1Error ошибка /api/v1
+type V1Error struct {
+	Code    string `json:"code"`              // Машиночитаемый код (not_found, invalid_parameter, ...)
+	Message string `json:"message"`           // Описание для человека
+	Param   string `json:"param,omitempty"`   // Параметр запроса, вызвавший ошибку
+	Details string `json:"details,omitempty"` // Текст ошибки сценария (execute, check-status)
+}
+
+// V1ErrorResponse ответ /api/v1 с ошибкой
+type V1ErrorResponse struct {
+	Error V1Error `json:"error"`
+}
+
+// V1PageMeta пагинация списка /api/v1/trades
+type V1PageMeta struct {
+	Total    int      `json:"total"` // Сделок под фильтрами без учета пагинации
+	Limit    int      `json:"limit,omitempty"`
+	Offset   int      `json:"offset"`
+	Pairs    []string `json:"pairs,omitempty"`    // При facets=true
+	Versions []string `json:"versions,omitempty"` // При facets=true
+}
+
+// V1TradeDetail хедж со связанными данными
+type V1TradeDetail struct {
+	Trade   TradeView        `json:"trade"`
+	History []TradeView      `json:"history"`          // Все хеджи той же сделки Freqtrade, новые первыми
+	Events  []OrderEventView `json:"events,omitempty"` // События ордеров хеджа (покупка, тейк-профит, стоп-лосс)
+}
+
+// V1CheckStatusResult результат проверки статусов
+type V1CheckStatusResult struct {
+	Updated int `json:"updated"` // Ордеров, закрытых проверкой
+}
+
+// setupAPIV1Routes настраивает маршруты /api/v1
+func (s *Server) setupAPIV1Routes(mux *http.ServeMux) {
+	mux.HandleFunc(apiV1Prefix+"/", s.handleV1NotFound)
+	mux.HandleFunc(apiV1Prefix+"/trades", s.v1Route(http.MethodGet, v1TradesParams, s.handleV1Trades))
+	mux.HandleFunc(apiV1Prefix+"/trades/", s.v1Route(http.MethodGet, nil, s.handleV1TradeDetail))
+	mux.HandleFunc(apiV1Prefix+"/stats", s.v1Route(http.MethodGet, v1StatsParams, s.handleV1Stats))
+	mux.HandleFunc(apiV1Prefix+"/execute", s.audited(entities.AuditActionExecute, s.v1Route(http.MethodPost, nil, s.handleV1Execute)))
+	mux.HandleFunc(apiV1Prefix+"/check-status", s.audited(entities.AuditActionCheckStatus, s.v1Route(http.MethodPost, nil, s.handleV1CheckStatus)))
+	mux.HandleFunc(apiV1Prefix+"/config", s.v1Route(http.MethodGet, nil, s.handleV1Config))
+	mux.HandleFunc(signalsPath, s.v1Route(http.MethodPost, v1SignalsParams, s.handleV1Signals))
+}
+
+// v1Route проверяет метод и параметры запроса до вызова обработчика
+func (s *Server) v1Route(method string, params []string, handler http.HandlerFunc) http.HandlerFunc {
+	allowed := make(map[string]bool, len(params))
+	for _, param := range params {
+		allowed[param] = true
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			s.sendV1Error(w, http.StatusMethodNotAllowed, V1Error{
+				Code:    v1ErrMethodNotAllowed,
+				Message: fmt.Sprintf("Метод %s не поддерживается, используйте %s", r.Method, method),
+			})
+			return
+		}
+		for param := range r.URL.Query() {
+			if !allowed[param] {
+				s.sendV1Error(w, http.StatusBadRequest, V1Error{
+					Code:    v1ErrUnknownParameter,
+					Message: fmt.Sprintf("Неизвестный параметр %q", param),
+					Param:   param,
+				})
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
+
+// handleV1NotFound отвечает на запросы к несуществующим эндпоинтам /api/v1
+func (s *Server) handleV1NotFound(w http.ResponseWriter, r *http.Request) {
+	s.sendV1Error(w, http.StatusNotFound, V1Error{
+		Code:    v1ErrNotFound,
+		Message: fmt.Sprintf("Эндпоинт %s не найден", r.URL.Path),
+	})
+}
+
+// handleV1Trades GET /api/v1/trades: страница хеджей с фильтрами, сортировкой и пагинацией
+func (s *Server) handleV1Trades(w http.ResponseWriter, r *http.Request) {
+	query, ok := s.parseV1Query(w, r, parseHedgeTradeQuery)
+	if !ok {
+		return
+	}
+
+	page, err := s.tradesPage(r.Context(), query)
+	if err != nil {
+		s.sendV1Error(w, http.StatusInternalServerError, V1Error{Code: v1ErrInternal, Message: err.Error()})
+		return
+	}
+
+	s.sendV1(w, http.StatusOK, page.Trades, V1PageMeta{
+		Total:    page.Total,
+		Limit:    page.Limit,
+		Offset:   page.Offset,
+		Pairs:    page.Pairs,
+		Versions: page.Versions,
+	})
+}
+
+// handleV1TradeDetail GET /api/v1/trades/{hedge_id}: хедж (в том числе из архива), остальные хеджи
+// той же сделки Freqtrade и события его ордеров
+func (s *Server) handleV1TradeDetail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	value := strings.TrimPrefix(r.URL.Path, apiV1Prefix+"/trades/")
+	hedgeID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || hedgeID <= 0 || strings.Contains(value, "/") {
+		s.sendV1Error(w, http.StatusBadRequest, V1Error{
+			Code:    v1ErrInvalidParameter,
+			Message: fmt.Sprintf("ID хеджа должен быть положительным числом: %q", value),
+			Param:   "hedge_id",
+		})
+		return
+	}
+
+	hedge, err := s.findHedge(ctx, hedgeID)
+	if err != nil {
+		s.sendV1Error(w, http.StatusInternalServerError, V1Error{Code: v1ErrInternal, Message: "Ошибка получения хеджа"})
+		return
+	}
+	if hedge == nil {
+		s.sendV1Error(w, http.StatusNotFound, V1Error{
+			Code:    v1ErrNotFound,
+			Message: fmt.Sprintf("Хедж %d не найден", hedgeID),
+		})
+		return
+	}
+
+	views := s.convertToTradeViews([]*entities.HedgedTrade{hedge})
+	s.applyUnrealizedProfit(ctx, views, []*entities.HedgedTrade{hedge})
+	detail := V1TradeDetail{Trade: views[0]}
+
+	history, err := s.hedgeRepo.GetHedgeHistory(ctx, hedge.FreqtradeTradeID)
+	if err != nil {
+		s.sendV1Error(w, http.StatusInternalServerError, V1Error{Code: v1ErrInternal, Message: "Ошибка получения истории сделки"})
+		return
+	}
+	detail.History = s.convertToTradeViews(history)
+
+	events, err := s.hedgeOrderEvents(ctx, hedge)
+	if err != nil {
+		s.sendV1Error(w, http.StatusInternalServerError, V1Error{Code: v1ErrInternal, Message: "Ошибка получения событий ордеров"})
+		return
+	}
+	detail.Events = events
+
+	s.sendV1(w, http.StatusOK, detail, nil)
+}
+
+// findHedge ищет хедж по ID в рабочей таблице, затем в архиве; nil - хедж не найден
+func (s *Server) findHedge(ctx context.Context, hedgeID int64) (*entities.HedgedTrade, error) {
+	for _, archived := range []bool{false, true} {
+		page, err := s.hedgeRepo.QueryHedgedTrades(ctx, &entities.HedgeTradeQuery{HedgeID: hedgeID, Archived: archived})
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Trades) > 0 {
+			return page.Trades[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// hedgeOrderEvents возвращает события ордеров хеджа по времени (без истории ордеров - пустой список)
+func (s *Server) hedgeOrderEvents(ctx context.Context, hedge *entities.HedgedTrade) ([]OrderEventView, error) {
+	if s.orderEventRepo == nil {
+		return nil, nil
+	}
+
+	var events []*entities.OrderEvent
+	seen := make(map[string]bool)
+	for _, orderID := range []string{hedge.BuyOrderID, hedge.BybitOrderID, hedge.StopLossOrderID} {
+		if orderID == "" || seen[orderID] {
+			continue
+		}
+		seen[orderID] = true
+
+		orderEvents, err := s.orderEventRepo.GetOrderEvents(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, orderEvents...)
+	}
+	// События нескольких ордеров - в порядке возникновения, как события одного ордера
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Timestamp.Equal(events[j].Timestamp) {
+			return events[i].Timestamp.Before(events[j].Timestamp)
+		}
+		return events[i].ID < events[j].ID
+	})
+	return newOrderEventViews(events), nil
+}
+
+// handleV1Stats GET /api/v1/stats: агрегированная статистика хеджей под фильтрами
+func (s *Server) handleV1Stats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query, ok := s.parseV1Query(w, r, parseStatsQuery)
+	if !ok {
+		return
+	}
+
+	hedgeStats, err := s.hedgeRepo.GetTradeStats(ctx, query)
+	if err != nil {
+		s.sendV1Error(w, http.StatusInternalServerError, V1Error{Code: v1ErrInternal, Message: "Ошибка расчета статистики"})
+		return
+	}
+	stats := newTradeStats(hedgeStats)
+	stats.UnrealizedProfit = s.unrealizedProfit(ctx, query)
+
+	s.sendV1(w, http.StatusOK, stats, nil)
+}
+
+// handleV1Execute POST /api/v1/execute: внеочередной цикл стратегии хеджирования
+func (s *Server) handleV1Execute(w http.ResponseWriter, r *http.Request) {
+	if !s.fullConfig.Lease.HasRole(config.RoleExecutor) {
+		s.sendV1Error(w, http.StatusConflict, V1Error{
+			Code:    v1ErrConflict,
+			Message: "Экземпляр не выполняет роль executor: хеджи открывает другой экземпляр",
+		})
+		return
+	}
+
+	finish, ok := s.startCycle()
+	if !ok {
+		s.sendV1Error(w, http.StatusConflict, V1Error{
+			Code:    v1ErrConflict,
+			Message: "Цикл стратегии уже выполняется: повторите запуск после его завершения",
+		})
+		return
+	}
+	defer finish()
+
+	logger.LogWithTime("🔌 API v1: внеочередной запуск стратегии хеджирования")
+	if err := s.hedgeUseCase.ExecuteHedgeStrategy(r.Context()); err != nil {
+		s.sendV1Error(w, http.StatusInternalServerError, V1Error{
+			Code:    v1ErrInternal,
+			Message: "Ошибка выполнения стратегии хеджирования",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	s.sendV1(w, http.StatusOK, map[string]string{"message": "Стратегия хеджирования выполнена успешно"}, nil)
+}
+
+// handleV1CheckStatus POST /api/v1/check-status: внеочередная проверка статусов активных ордеров
+func (s *Server) handleV1CheckStatus(w http.ResponseWriter, r *http.Request) {
+	if !s.fullConfig.Lease.HasRole(config.RoleStatusChecker) {
+		s.sendV1Error(w, http.StatusConflict, V1Error{
+			Code:    v1ErrConflict,
+			Message: "Экземпляр не выполняет роль status-checker: статусы проверяет другой экземпляр",
+		})
+		return
+	}
+	if s.statusCheckerUseCase == nil {
+		s.sendV1Error(w, http.StatusServiceUnavailable, V1Error{
+			Code:    v1ErrUnavailable,
+			Message: "Проверка статусов недоступна в текущем режиме работы",
+		})
+		return
+	}
+	finish, ok := s.startCycle()
+	if !ok {
+		s.sendV1Error(w, http.StatusConflict, V1Error{
+			Code:    v1ErrConflict,
+			Message: "Цикл стратегии уже выполняется: повторите запуск после его завершения",
+		})
+		return
+	}
+	defer finish()
+
+	logger.LogWithTime("🔌 API v1: внеочередная проверка статусов ордеров")
+	updated, err := s.checkStatuses(r.Context())
+	if err != nil {
+		s.sendV1Error(w, http.StatusInternalServerError, V1Error{
+			Code:    v1ErrInternal,
+			Message: "Ошибка проверки статусов ордеров",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	s.sendV1(w, http.StatusOK, V1CheckStatusResult{Updated: updated}, nil)
+}
+
+// handleV1Config GET /api/v1/config: действующая конфигурация, ключи API и пароли скрыты
+func (s *Server) handleV1Config(w http.ResponseWriter, r *http.Request) {
+	cfg, err := s.fullConfig.RedactedMap()
+	if err != nil {
+		s.sendV1Error(w, http.StatusInternalServerError, V1Error{Code: v1ErrInternal, Message: err.Error()})
+		return
+	}
+	s.sendV1(w, http.StatusOK, cfg, nil)
+}
+
+// parseV1Query разбирает фильтры запроса и проверяет статус; при ошибке отправляет ответ и возвращает false
+func (s *Server) parseV1Query(w http.ResponseWriter, r *http.Request, parse func(*http.Request) (*entities.HedgeTradeQuery, error)) (*entities.HedgeTradeQuery, bool) {
+	query, err := parse(r)
+	if err == nil && query.Status != nil && !entities.OrderStatus(*query.Status).IsKnown() {
+		err = invalidParam("status", "неизвестный статус ордера: %q", *query.Status)
+	}
+	if err != nil {
+		apiErr := V1Error{Code: v1ErrInvalidParameter, Message: err.Error()}
+		var param *paramError
+		if errors.As(err, &param) {
+			apiErr.Param = param.param
+		}
+		s.sendV1Error(w, http.StatusBadRequest, apiErr)
+		return nil, false
+	}
+	return query, true
+}
+
+// sendV1 отправляет успешный ответ /api/v1
+func (s *Server) sendV1(w http.ResponseWriter, status int, data, meta interface{}) {
+	writeJSON(w, status, V1Response{Data: data, Meta: meta})
+}
+
+// sendV1Error отправляет ошибку /api/v1
+func (s *Server) sendV1Error(w http.ResponseWriter, status int, apiErr V1Error) {
+	apiErr.Message = s.tr(apiErr.Message)
+	writeJSON(w, status, V1ErrorResponse{Error: apiErr})
+}
+
+// writeJSON отправляет JSON ответ с кодом статуса
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.LogWithTime("⚠️ Ошибка кодирования ответа API: %v", err)
+	}
+}
@@ -0,0 +1,299 @@
+package webui
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// Ограничения журнала аудита
+const (
+	auditMaxBody     = 1 << 20  // Тело запроса ручного действия, которое читается для записи параметров
+	auditMaxParams   = 4096     // Длина параметров в записи: тело длиннее обрезается
+	auditMaxResponse = 64 << 10 // Часть ответа, из которой берутся итог и сообщение
+	auditDefaultDays = 7        // Период журнала по умолчанию
+)
+
+// AuditEntryView представление записи журнала аудита для веб-интерфейса
+type AuditEntryView struct {
+	ID         int64     `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	Username   string    `json:"username"`
+	RemoteAddr string    `json:"remote_addr"`
+	Action     string    `json:"action"`
+	Params     string    `json:"params"`
+	Outcome    string    `json:"outcome"`
+	Message    string    `json:"message"`
+}
+
+// AuditLogReport записи журнала аудита за период
+type AuditLogReport struct {
+	From    time.Time        `json:"from"`
+	To      time.Time        `json:"to"`
+	Entries []AuditEntryView `json:"entries"`
+}
+
+// auditParams параметры ручного действия в записи журнала
+type auditParams struct {
+	Method string              `json:"method"`
+	Path   string              `json:"path"`
+	Query  map[string][]string `json:"query,omitempty"`
+	Body   json.RawMessage     `json:"body,omitempty"`
+}
+
+// auditResponse поля ответа, из которых берутся итог и сообщение: APIResponse и ошибка /api/v1
+type auditResponse struct {
+	Success *bool  `json:"success"`
+	Message string `json:"message"`
+	Error   *struct {
+		Message string `json:"message"`
+		Details string `json:"details"`
+	} `json:"error"`
+}
+
+// auditRecorder запоминает код и начало ответа обработчика ручного действия
+type auditRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader запоминает код ответа
+func (a *auditRecorder) WriteHeader(status int) {
+	if a.status == 0 {
+		a.status = status
+	}
+	a.ResponseWriter.WriteHeader(status)
+}
+
+// Write запоминает начало ответа
+func (a *auditRecorder) Write(data []byte) (int, error) {
+	if a.status == 0 {
+		a.status = http.StatusOK
+	}
+	if remaining := auditMaxResponse - a.body.Len(); remaining > 0 {
+		a.body.Write(data[:min(len(data), remaining)])
+	}
+	return a.ResponseWriter.Write(data)
+}
+
+// audited записывает в журнал аудита изменяющие запросы (POST, PUT, DELETE) обработчика: пользователя,
+// адрес клиента, параметры и итог. Просмотр (GET) и запросы неподдерживаемым методом не записываются.
+// Без базы данных действие только логируется
+func (s *Server) audited(action string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			handler(w, r)
+			return
+		}
+
+		// Тело читается заранее и подставляется обработчику заново
+		var body []byte
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(io.LimitReader(r.Body, auditMaxBody))
+			if err != nil {
+				s.sendError(w, "Некорректный формат запроса", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		recorder := &auditRecorder{ResponseWriter: w}
+		handler(recorder, r)
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+		if recorder.status == http.StatusMethodNotAllowed {
+			return
+		}
+
+		entry := &entities.AuditEntry{
+			CreatedAt:  time.Now(),
+			RemoteAddr: clientHost(r),
+			Action:     action,
+			Params:     newAuditParams(r, body),
+		}
+		if user, ok := requestUser(r); ok {
+			entry.Username = user.Username
+		}
+		entry.Outcome, entry.Message = auditOutcome(recorder)
+		s.recordAudit(r, entry)
+	}
+}
+
+// recordAudit логирует ручное действие и сохраняет его в журнале аудита.
+// Ошибка сохранения не меняет ответ: действие уже выполнено
+func (s *Server) recordAudit(r *http.Request, entry *entities.AuditEntry) {
+	icon := "🧾"
+	if !entry.Succeeded() {
+		icon = "⚠️"
+	}
+	logger.LogWithTime("%s Ручное действие %s (%s): %s", icon, entry.Action, requestAuthor(r), entry.Message)
+
+	if s.auditRepo == nil {
+		return
+	}
+	if err := s.auditRepo.SaveAuditEntry(r.Context(), entry); err != nil {
+		logger.LogWithTime("❌ Не удалось сохранить запись журнала аудита (%s): %v", entry.Action, err)
+	}
+}
+
+// newAuditParams собирает параметры запроса в JSON: метод, путь, параметры URL и тело.
+// Тело не в JSON сохраняется строкой, слишком длинное - обрезается
+func newAuditParams(r *http.Request, body []byte) string {
+	params := auditParams{
+		Method: r.Method,
+		Path:   r.URL.Path,
+	}
+	if query := r.URL.Query(); len(query) > 0 {
+		params.Query = query
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, body); err == nil && compact.Len() <= auditMaxParams {
+			params.Body = compact.Bytes()
+		} else {
+			text := string(body)
+			if len(text) > auditMaxParams {
+				text = strings.ToValidUTF8(text[:auditMaxParams], "") + "…"
+			}
+			params.Body, _ = json.Marshal(text)
+		}
+	}
+
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// auditOutcome определяет итог действия по коду ответа и полю success (или error в /api/v1)
+func auditOutcome(recorder *auditRecorder) (string, string) {
+	outcome := entities.AuditOutcomeSuccess
+	if recorder.status >= http.StatusBadRequest {
+		outcome = entities.AuditOutcomeFailure
+	}
+
+	var response auditResponse
+	if err := json.Unmarshal(recorder.body.Bytes(), &response); err != nil {
+		return outcome, http.StatusText(recorder.status)
+	}
+	if response.Success != nil && !*response.Success {
+		outcome = entities.AuditOutcomeFailure
+	}
+
+	message := response.Message
+	if response.Error != nil {
+		outcome = entities.AuditOutcomeFailure
+		message = response.Error.Message
+		if response.Error.Details != "" {
+			message += ": " + response.Error.Details
+		}
+	}
+	if message == "" {
+		message = http.StatusText(recorder.status)
+	}
+	return outcome, message
+}
+
+// handleAudit страница журнала аудита
+func (s *Server) handleAudit(w http.ResponseWriter, r *http.Request) {
+	data := PageData{
+		Title: "Аудит",
+	}
+
+	if err := s.executeTemplate(w, r, "audit.html", data); err != nil {
+		log.Printf("❌ Ошибка рендеринга шаблона audit.html: %v", err)
+		return
+	}
+}
+
+// handleAPIAudit API журнала аудита: /api/audit?from=...&to=...&action=...&user=...&outcome=...
+// По умолчанию - последние 7 дней
+func (s *Server) handleAPIAudit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.auditRepo == nil {
+		s.sendError(w, "Журнал аудита недоступен: база данных не настроена", http.StatusServiceUnavailable)
+		return
+	}
+
+	params := r.URL.Query()
+	from, err := parseTradesDate(params.Get("from"), false)
+	if err != nil {
+		s.sendError(w, "Некорректный параметр from", http.StatusBadRequest)
+		return
+	}
+	to, err := parseTradesDate(params.Get("to"), true)
+	if err != nil {
+		s.sendError(w, "Некорректный параметр to", http.StatusBadRequest)
+		return
+	}
+	if to == nil {
+		end := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+		to = &end
+	}
+	if from == nil {
+		start := to.AddDate(0, 0, -auditDefaultDays)
+		from = &start
+	}
+	if !from.Before(*to) {
+		s.sendError(w, "from должен быть раньше to", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := s.auditRepo.GetAuditEntries(r.Context(), *from, *to)
+	if err != nil {
+		s.sendError(w, "Ошибка получения журнала аудита", http.StatusInternalServerError)
+		return
+	}
+
+	action := strings.TrimSpace(params.Get("action"))
+	username := strings.TrimSpace(params.Get("user"))
+	outcome := strings.TrimSpace(params.Get("outcome"))
+	report := AuditLogReport{
+		From:    *from,
+		To:      *to,
+		Entries: []AuditEntryView{},
+	}
+	for _, entry := range entries {
+		if action != "" && entry.Action != action {
+			continue
+		}
+		if username != "" && !strings.EqualFold(entry.Username, username) {
+			continue
+		}
+		if outcome != "" && entry.Outcome != outcome {
+			continue
+		}
+		report.Entries = append(report.Entries, AuditEntryView{
+			ID:         entry.ID,
+			CreatedAt:  entry.CreatedAt,
+			Username:   entry.Username,
+			RemoteAddr: entry.RemoteAddr,
+			Action:     entry.Action,
+			Params:     entry.Params,
+			Outcome:    entry.Outcome,
+			Message:    entry.Message,
+		})
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
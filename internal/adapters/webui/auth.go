@@ -0,0 +1,349 @@
+package webui
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// sessionCookieName cookie сессии веб-интерфейса
+const sessionCookieName = "trade_hedge_session"
+
+// v1ErrUnauthorized код ошибки /api/v1 без аутентификации
+const v1ErrUnauthorized = "unauthorized"
+
+// authenticator проверяет учетные данные и хранит сессии веб-интерфейса.
+// Сессии хранятся в памяти процесса: после перезапуска нужно войти заново
+type authenticator struct {
+	config *config.WebUIAuthConfig
+
+	mu       sync.Mutex
+	sessions map[string]session // Токен сессии -> сессия
+}
+
+// session сессия веб-интерфейса: пользователь и время истечения
+type session struct {
+	user    webUIUser
+	expires time.Time
+}
+
+// newAuthenticator создает проверку учетных данных по конфигурации
+func newAuthenticator(cfg *config.WebUIAuthConfig) *authenticator {
+	return &authenticator{
+		config:   cfg,
+		sessions: make(map[string]session),
+	}
+}
+
+// checkPassword сравнивает логин и пароль со всеми пользователями за постоянное время и возвращает
+// пользователя с его ролью (основной пользователь webui.auth.username - администратор)
+func (a *authenticator) checkPassword(username, password string) (webUIUser, bool) {
+	var matched webUIUser
+	valid := false
+	if a.config.Username != "" && secretsEqual(username, a.config.Username) && secretsEqual(password, a.config.Password) {
+		matched, valid = webUIUser{Username: a.config.Username, Role: config.WebUIRoleAdmin}, true
+	}
+	for _, user := range a.config.Users {
+		if secretsEqual(username, user.Username) && secretsEqual(password, user.Password) {
+			matched, valid = webUIUser{Username: user.Username, Role: user.Role}, true
+		}
+	}
+	return matched, valid
+}
+
+// checkToken проверяет токен API
+func (a *authenticator) checkToken(token string) bool {
+	valid := false
+	for _, candidate := range a.config.APITokens {
+		if secretsEqual(token, candidate) {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// newSession создает сессию пользователя и возвращает ее токен
+func (a *authenticator) newSession(user webUIUser, now time.Time) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for existing, current := range a.sessions {
+		if !now.Before(current.expires) {
+			delete(a.sessions, existing)
+		}
+	}
+	a.sessions[token] = session{user: user, expires: now.Add(a.config.SessionDuration())}
+	return token, nil
+}
+
+// checkSession проверяет, что сессия существует и не истекла, и возвращает ее пользователя
+func (a *authenticator) checkSession(token string, now time.Time) (webUIUser, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	current, ok := a.sessions[token]
+	if !ok {
+		return webUIUser{}, false
+	}
+	if !now.Before(current.expires) {
+		delete(a.sessions, token)
+		return webUIUser{}, false
+	}
+	return current.user, true
+}
+
+// endSession удаляет сессию
+func (a *authenticator) endSession(token string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.sessions, token)
+}
+
+// authorize проверяет запрос и возвращает его пользователя: токен API (только /api и /metrics),
+// затем Basic auth или cookie сессии по режиму
+func (a *authenticator) authorize(r *http.Request) (webUIUser, bool) {
+	if token, ok := bearerToken(r); ok {
+		if (isAPIPath(r.URL.Path) || r.URL.Path == metricsPath) && a.checkToken(token) {
+			return apiTokenUser, true
+		}
+		return webUIUser{}, false
+	}
+
+	switch a.config.Mode {
+	case config.WebUIAuthBasic:
+		if username, password, ok := r.BasicAuth(); ok {
+			return a.checkPassword(username, password)
+		}
+	case config.WebUIAuthSession:
+		if cookie, err := r.Cookie(sessionCookieName); err == nil {
+			return a.checkSession(cookie.Value, time.Now())
+		}
+	}
+	return webUIUser{}, false
+}
+
+// secretsEqual сравнивает строки за постоянное время независимо от их длины
+func secretsEqual(a, b string) bool {
+	hashA := sha256.Sum256([]byte(a))
+	hashB := sha256.Sum256([]byte(b))
+	return subtle.ConstantTimeCompare(hashA[:], hashB[:]) == 1
+}
+
+// bearerToken возвращает токен из заголовка Authorization: Bearer <токен>
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(prefix):]), true
+}
+
+// isAPIPath проверяет, что путь относится к API
+func isAPIPath(path string) bool {
+	return path == "/api" || strings.HasPrefix(path, "/api/")
+}
+
+// safeRedirect возвращает локальный путь для перенаправления после входа (внешние адреса заменяются на /)
+func safeRedirect(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+	return next
+}
+
+// authMiddleware пропускает запросы с действительными учетными данными и достаточной ролью пользователя.
+// Без учетных данных /api получает 401 в JSON, страницы - запрос Basic auth или перенаправление на страницу
+// входа; действие, недоступное роли, получает 403
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	if !s.webUIConfig.Auth.Enabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.isPublicPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		user, ok := s.auth.authorize(r)
+		if !ok {
+			s.denyAccess(w, r)
+			return
+		}
+		if required := requiredRole(r); !roleAllows(user.Role, required) {
+			logger.LogWithTime("⚠️ Действие %s %s недоступно пользователю %q с ролью %s", r.Method, r.URL.Path, user.Username, user.Role)
+			s.denyRole(w, r, required)
+			return
+		}
+		next.ServeHTTP(w, withUser(r, user))
+	})
+}
+
+// isPublicPath проверяет, что путь доступен без учетных данных: вход и выход в режиме session,
+// webhook сигналов с собственным секретом
+func (s *Server) isPublicPath(path string) bool {
+	switch path {
+	case "/login", "/logout":
+		return s.webUIConfig.Auth.Mode == config.WebUIAuthSession
+	case signalsPath:
+		return s.signalSecret != ""
+	}
+	return false
+}
+
+// denyAccess отвечает на запрос без действительных учетных данных
+func (s *Server) denyAccess(w http.ResponseWriter, r *http.Request) {
+	_, withToken := bearerToken(r)
+	if s.webUIConfig.Auth.Mode == config.WebUIAuthBasic && !withToken {
+		w.Header().Set("WWW-Authenticate", `Basic realm="Trade Hedge", charset="UTF-8"`)
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, apiV1Prefix+"/"):
+		s.sendV1Error(w, http.StatusUnauthorized, V1Error{
+			Code:    v1ErrUnauthorized,
+			Message: "Требуется аутентификация: токен API в заголовке Authorization: Bearer <токен>",
+		})
+	case isAPIPath(r.URL.Path):
+		s.sendError(w, "Требуется аутентификация", http.StatusUnauthorized)
+	case r.URL.Path == metricsPath:
+		http.Error(w, s.tr("Требуется аутентификация: токен API в заголовке Authorization: Bearer <токен>"), http.StatusUnauthorized)
+	case s.webUIConfig.Auth.Mode == config.WebUIAuthSession:
+		http.Redirect(w, r, "/login?next="+url.QueryEscape(r.URL.RequestURI()), http.StatusSeeOther)
+	default:
+		http.Error(w, s.tr("Требуется аутентификация"), http.StatusUnauthorized)
+	}
+}
+
+// LoginPageData данные страницы входа
+type LoginPageData struct {
+	Next  string
+	Error string
+	Lang  string // Язык страницы (webui.language)
+
+	CSRFToken string // CSRF токен формы входа
+}
+
+// loginRequest учетные данные входа в JSON
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// handleLogin GET - страница входа, POST - вход по логину и паролю (форма или JSON) с выдачей cookie сессии
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	next := safeRedirect(r.URL.Query().Get("next"))
+
+	switch r.Method {
+	case http.MethodGet:
+		if !s.pagesEnabled() {
+			s.sendError(w, "Веб-интерфейс отключен: войдите POST /login с JSON {\"username\", \"password\"} или используйте токен API", http.StatusNotFound)
+			return
+		}
+		s.renderLogin(w, r, http.StatusOK, LoginPageData{Next: next})
+	case http.MethodPost:
+		isJSON := strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") || !s.pagesEnabled()
+
+		var credentials loginRequest
+		if isJSON {
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&credentials); err != nil {
+				s.sendError(w, "Некорректный JSON запроса", http.StatusBadRequest)
+				return
+			}
+		} else {
+			credentials.Username = r.PostFormValue("username")
+			credentials.Password = r.PostFormValue("password")
+			if value := r.PostFormValue("next"); value != "" {
+				next = safeRedirect(value)
+			}
+		}
+
+		user, ok := s.auth.checkPassword(credentials.Username, credentials.Password)
+		if !ok {
+			logger.LogWithTime("⚠️ Неудачная попытка входа в веб-интерфейс: пользователь %q, адрес %s", credentials.Username, r.RemoteAddr)
+			if isJSON {
+				s.sendError(w, "Неверное имя пользователя или пароль", http.StatusUnauthorized)
+				return
+			}
+			s.renderLogin(w, r, http.StatusUnauthorized, LoginPageData{Next: next, Error: "Неверное имя пользователя или пароль"})
+			return
+		}
+
+		token, err := s.auth.newSession(user, time.Now())
+		if err != nil {
+			s.sendError(w, "Ошибка создания сессии", http.StatusInternalServerError)
+			return
+		}
+		s.setSessionCookie(w, token, int(s.webUIConfig.Auth.SessionDuration().Seconds()))
+		logger.LogWithTime("🔐 Вход в веб-интерфейс: пользователь %q (роль %s), адрес %s", user.Username, user.Role, r.RemoteAddr)
+
+		if isJSON {
+			s.sendJSON(w, APIResponse{Success: true, Message: "Вход выполнен"})
+			return
+		}
+		http.Redirect(w, r, next, http.StatusSeeOther)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleLogout POST - завершение сессии
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		s.auth.endSession(cookie.Value)
+	}
+	s.setSessionCookie(w, "", -1)
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") || !s.pagesEnabled() {
+		s.sendJSON(w, APIResponse{Success: true, Message: "Выход выполнен"})
+		return
+	}
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// setSessionCookie устанавливает (maxAge > 0) или удаляет (maxAge < 0) cookie сессии
+func (s *Server) setSessionCookie(w http.ResponseWriter, token string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   s.secureCookies(),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// renderLogin отдает страницу входа
+func (s *Server) renderLogin(w http.ResponseWriter, r *http.Request, status int, data LoginPageData) {
+	data.Lang = s.language()
+	data.CSRFToken = s.csrfToken(w, r)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := s.templates.ExecuteTemplate(w, "login.html", data); err != nil {
+		log.Printf("❌ Ошибка рендеринга шаблона login.html: %v", err)
+	}
+}
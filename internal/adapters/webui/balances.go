@@ -0,0 +1,90 @@
+package webui
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/usecases"
+)
+
+// handleBalances обработчик страницы балансов кошелька биржи
+func (s *Server) handleBalances(w http.ResponseWriter, r *http.Request) {
+	data := PageData{
+		Title: "Балансы",
+	}
+
+	if err := s.executeTemplate(w, r, "balances.html", data); err != nil {
+		log.Printf("❌ Ошибка рендеринга шаблона balances.html: %v", err)
+		return
+	}
+}
+
+// handleAPIBalances API обзора балансов: все валюты UNIFIED аккаунта с доступной и общей суммой, оценкой в USD
+// и монетами в открытых хеджах, плюс сколько хеджей помещается в доступный баланс базовой валюты.
+// Если биржа недоступна, обзор строится по последнему снимку баланса
+func (s *Server) handleAPIBalances(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	baseCurrency := s.balanceBaseCurrency()
+
+	hedges, err := s.hedgeRepo.GetHedgedTrades(ctx, nil)
+	if err != nil {
+		log.Printf("❌ Ошибка получения хеджей для обзора балансов: %v", err)
+		s.sendError(w, "Ошибка получения хеджей", http.StatusInternalServerError)
+		return
+	}
+
+	response := APIResponse{Success: true}
+	balances, err := s.fetchAllBalances(ctx, baseCurrency)
+	if err != nil {
+		log.Printf("⚠️ Не удалось получить балансы: %v", err)
+		snapshot := s.latestSnapshot(ctx)
+		if snapshot == nil || snapshot.BalancesAt == nil {
+			s.sendError(w, "Ошибка получения балансов", http.StatusInternalServerError)
+			return
+		}
+		balances = make([]*entities.Balance, 0, len(snapshot.Balances))
+		for _, balance := range snapshot.Balances {
+			balances = append(balances, balance)
+		}
+		response.Message = "Биржа недоступна, показан последний снимок баланса"
+		response.Stale = true
+		response.SnapshotAt = snapshot.BalancesAt
+	}
+
+	response.Data = usecases.BuildBalanceOverview(balances, hedges, baseCurrency, s.positionAmount())
+	s.sendJSON(w, response)
+}
+
+// fetchAllBalances получает балансы всех валют аккаунта. Если биржа не умеет отдавать их одним запросом,
+// возвращаются базовая валюта и валюты дашборда
+func (s *Server) fetchAllBalances(ctx context.Context, baseCurrency string) ([]*entities.Balance, error) {
+	if all, ok := s.hedgeUseCase.GetExchangeService().(services.AllBalancesExchangeService); ok {
+		return all.GetAllBalances(ctx)
+	}
+
+	byCurrency, err := s.fetchBalances(ctx, baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]*entities.Balance, 0, len(byCurrency))
+	for _, balance := range byCurrency {
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+// positionAmount возвращает действующую сумму позиции (с учетом изменения во время работы)
+func (s *Server) positionAmount() float64 {
+	effective, err := s.effectiveConfig()
+	if err != nil {
+		return s.fullConfig.Strategy.PositionAmount
+	}
+	return effective.Strategy.PositionAmount
+}
@@ -0,0 +1,47 @@
+package webui
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/usecases"
+)
+
+// handleAPICapital API занятого капитала: стоимость входа, возраст и доля капитала каждого открытого хеджа.
+// Если биржа недоступна, капитал считается по последнему снимку баланса
+func (s *Server) handleAPICapital(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+
+	hedges, err := s.hedgeRepo.GetHedgedTrades(ctx, nil)
+	if err != nil {
+		log.Printf("❌ Ошибка получения хеджей для расчета капитала: %v", err)
+		s.sendError(w, "Ошибка получения хеджей", http.StatusInternalServerError)
+		return
+	}
+
+	currency := s.balanceBaseCurrency()
+	response := APIResponse{Success: true}
+	var balance *entities.Balance
+	if balances, err := s.fetchBalances(ctx, currency); err == nil {
+		balance = balances[currency]
+	} else {
+		log.Printf("⚠️ Не удалось получить баланс для расчета капитала: %v", err)
+		if snapshot := s.latestSnapshot(ctx); snapshot != nil && snapshot.BalancesAt != nil {
+			balance = snapshot.Balances[currency]
+			response.Message = "Биржа недоступна, капитал рассчитан по последнему снимку баланса"
+			response.Stale = true
+			response.SnapshotAt = snapshot.BalancesAt
+		} else {
+			response.Message = "Биржа недоступна, доля капитала не рассчитана"
+		}
+	}
+
+	response.Data = usecases.BuildCapitalLockReport(hedges, balance, currency, time.Now())
+	s.sendJSON(w, response)
+}
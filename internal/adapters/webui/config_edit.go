@@ -0,0 +1,246 @@
+package webui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/usecases"
+)
+
+// strategyKeyPrefix префикс ключей параметров стратегии в журнале параметров (entities.Setting*)
+const strategyKeyPrefix = "strategy."
+
+// ConfigView действующая конфигурация для страницы настроек: секреты скрыты, параметры стратегии
+// с учетом значений, измененных во время работы
+type ConfigView struct {
+	Config   map[string]interface{} `json:"config"`
+	Runtime  []string               `json:"runtime"`  // Параметры стратегии, которые применяются без перезапуска
+	Writable bool                   `json:"writable"` // Остальные параметры можно записать в файл конфигурации
+	Pending  map[string]interface{} `json:"pending"`  // Параметры стратегии, записанные в файл и ожидающие перезапуска
+}
+
+// ConfigUpdateRequest запрос на изменение параметров стратегии: PUT /api/config
+type ConfigUpdateRequest struct {
+	Strategy map[string]interface{} `json:"strategy"` // Новые значения по ключам YAML секции strategy
+	Author   string                 `json:"author"`   // Кто изменяет (по умолчанию - адрес клиента)
+}
+
+// ConfigUpdateView результат изменения параметров стратегии
+type ConfigUpdateView struct {
+	ConfigView
+	Applied         []string `json:"applied"`          // Применены сразу (действуют с начала следующего цикла)
+	RestartRequired []string `json:"restart_required"` // Записаны в файл, вступят в силу после перезапуска
+}
+
+// handleAPIConfig API конфигурации: GET /api/config - действующая конфигурация без секретов,
+// PUT /api/config - изменение параметров стратегии с проверкой валидацией конфигурации
+func (s *Server) handleAPIConfig(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.configMu.Lock()
+		view, err := s.configView()
+		s.configMu.Unlock()
+		if err != nil {
+			s.sendError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		s.sendJSON(w, APIResponse{
+			Success: true,
+			Data:    view,
+		})
+	case http.MethodPut:
+		s.updateConfig(w, r)
+	default:
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+	}
+}
+
+// updateConfig проверяет измененные параметры стратегии на действующей конфигурации и применяет их:
+// изменяемые во время работы сохраняются в БД и действуют с начала следующего цикла,
+// остальные записываются в файл конфигурации и вступают в силу после перезапуска
+func (s *Server) updateConfig(w http.ResponseWriter, r *http.Request) {
+	var req ConfigUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Strategy) == 0 {
+		s.sendError(w, "Некорректный формат запроса", http.StatusBadRequest)
+		return
+	}
+
+	author := strings.TrimSpace(req.Author)
+	if author == "" {
+		author = requestAuthor(r)
+	}
+
+	ctx := r.Context()
+
+	// Файл конфигурации и журнал параметров изменяются одним запросом за раз
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	// Изменения проверяются вместе с действующими значениями и записанными в файл до перезапуска
+	effective, err := s.effectiveConfig()
+	if err == nil && len(s.pendingStrategy) > 0 {
+		effective, _, err = effective.WithStrategyChanges(s.pendingStrategy)
+	}
+	if err != nil {
+		s.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, changed, err := effective.WithStrategyChanges(req.Strategy)
+	if err != nil {
+		s.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var live, restart []string
+	liveValues := make(map[string]float64)
+	for _, key := range changed {
+		if s.settings != nil && usecases.IsRuntimeSetting(strategyKeyPrefix+key) {
+			value, ok := req.Strategy[key].(float64)
+			if !ok {
+				s.sendError(w, fmt.Sprintf("Параметр strategy.%s должен быть числом", key), http.StatusBadRequest)
+				return
+			}
+			live = append(live, key)
+			liveValues[key] = value
+		} else {
+			restart = append(restart, key)
+		}
+	}
+	if len(restart) > 0 && s.configPath == "" {
+		s.sendError(w, fmt.Sprintf("Параметры %s применяются только после перезапуска, а файл конфигурации не настроен",
+			strings.Join(restart, ", ")), http.StatusServiceUnavailable)
+		return
+	}
+
+	// Сначала файл: при ошибке записи ничего не применяется
+	if len(restart) > 0 {
+		fileChanges := make(map[string]interface{}, len(restart))
+		for _, key := range restart {
+			fileChanges[key] = req.Strategy[key]
+		}
+		if err := config.UpdateStrategyFile(s.configPath, fileChanges); err != nil {
+			s.sendError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for key, value := range fileChanges {
+			s.pendingStrategy[key] = value
+		}
+		s.recordConfigVersion(r, author)
+	}
+
+	for _, key := range live {
+		if _, err := s.settings.Update(ctx, strategyKeyPrefix+key, liveValues[key], author); err != nil {
+			s.sendError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if len(changed) > 0 {
+		logger.LogWithTime("⚙️ Параметры стратегии изменены в веб-интерфейсе (%s): сразу %v, после перезапуска %v",
+			author, live, restart)
+	}
+
+	view, err := s.configView()
+	if err != nil {
+		s.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	message := "Значения совпадают с действующими"
+	switch {
+	case len(live) > 0 && len(restart) > 0:
+		message = "Часть параметров действует с начала следующего цикла, остальные записаны в файл и вступят в силу после перезапуска"
+	case len(live) > 0:
+		message = "Параметры сохранены и действуют с начала следующего цикла"
+	case len(restart) > 0:
+		message = "Параметры записаны в файл конфигурации и вступят в силу после перезапуска"
+	}
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Message: message,
+		Data: ConfigUpdateView{
+			ConfigView:      *view,
+			Applied:         live,
+			RestartRequired: restart,
+		},
+	})
+}
+
+// recordConfigVersion сохраняет в истории конфигурацию, которая будет действовать после перезапуска
+// (со всеми записанными в файл изменениями). Ошибка истории не отменяет записанный файл и только логируется
+func (s *Server) recordConfigVersion(r *http.Request, author string) {
+	if s.configHistory == nil {
+		return
+	}
+
+	pending, _, err := s.fullConfig.WithStrategyChanges(s.pendingStrategy)
+	if err == nil {
+		var content string
+		if content, err = pending.Snapshot(); err == nil {
+			_, err = s.configHistory.Record(r.Context(), &entities.ConfigVersion{
+				Author:  author,
+				Source:  entities.ConfigSourceWebUI,
+				Content: content,
+			})
+		}
+	}
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось сохранить версию конфигурации в истории: %v", err)
+	}
+}
+
+// effectiveConfig возвращает конфигурацию процесса с параметрами стратегии, измененными во время работы
+func (s *Server) effectiveConfig() (*config.Config, error) {
+	if s.settings == nil {
+		return s.fullConfig, nil
+	}
+
+	overrides := make(map[string]interface{})
+	for _, setting := range s.settings.Settings() {
+		if setting.Overridden {
+			overrides[strings.TrimPrefix(setting.Key, strategyKeyPrefix)] = setting.Value
+		}
+	}
+	if len(overrides) == 0 {
+		return s.fullConfig, nil
+	}
+
+	effective, _, err := s.fullConfig.WithStrategyChanges(overrides)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка применения параметров, измененных во время работы: %w", err)
+	}
+	return effective, nil
+}
+
+// configView собирает действующую конфигурацию без секретов. Вызывается под configMu
+func (s *Server) configView() (*ConfigView, error) {
+	effective, err := s.effectiveConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := effective.RedactedMap()
+	if err != nil {
+		return nil, err
+	}
+
+	view := &ConfigView{
+		Config:   cfg,
+		Runtime:  []string{},
+		Writable: s.configPath != "",
+		Pending:  make(map[string]interface{}, len(s.pendingStrategy)),
+	}
+	for key, value := range s.pendingStrategy {
+		view.Pending[key] = value
+	}
+	if s.settings != nil {
+		for _, setting := range s.settings.Settings() {
+			view.Runtime = append(view.Runtime, strings.TrimPrefix(setting.Key, strategyKeyPrefix))
+		}
+	}
+	return view, nil
+}
@@ -0,0 +1,150 @@
+package webui
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/infrastructure/config"
+)
+
+// ConfigVersionView представление версии конфигурации для веб-интерфейса
+type ConfigVersionView struct {
+	ID             int       `json:"id"`
+	CreatedAt      time.Time `json:"created_at"`
+	Author         string    `json:"author"`
+	Source         string    `json:"source"`
+	Diff           string    `json:"diff"`
+	Content        string    `json:"content,omitempty"` // Только при запросе одной версии
+	RolledBackFrom int       `json:"rolled_back_from,omitempty"`
+}
+
+// ConfigRollbackRequest запрос на откат конфигурации
+type ConfigRollbackRequest struct {
+	ID     int    `json:"id"`     // Версия, к которой выполняется откат
+	Author string `json:"author"` // Кто выполняет откат (по умолчанию - адрес клиента)
+}
+
+// handleAPIConfigHistory API истории конфигурации: GET /api/admin/config/history - список версий,
+// GET /api/admin/config/history?id=N - версия вместе с содержимым
+func (s *Server) handleAPIConfigHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.configHistory == nil {
+		s.sendError(w, "История конфигурации недоступна: база данных не настроена", http.StatusServiceUnavailable)
+		return
+	}
+
+	ctx := r.Context()
+
+	if idParam := r.URL.Query().Get("id"); idParam != "" {
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			s.sendError(w, "Некорректный id версии", http.StatusBadRequest)
+			return
+		}
+		version, err := s.configHistory.Version(ctx, id)
+		if err != nil {
+			s.sendError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		view := convertToConfigVersionView(version)
+		view.Content = version.Content
+		s.sendJSON(w, APIResponse{
+			Success: true,
+			Data:    view,
+		})
+		return
+	}
+
+	versions, err := s.configHistory.Versions(ctx)
+	if err != nil {
+		s.sendError(w, "Ошибка получения истории конфигурации", http.StatusInternalServerError)
+		return
+	}
+
+	views := make([]ConfigVersionView, 0, len(versions))
+	for _, version := range versions {
+		views = append(views, convertToConfigVersionView(version))
+	}
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    views,
+	})
+}
+
+// handleAPIConfigRollback API отката конфигурации: POST /api/admin/config/rollback.
+// Версия записывается в файл конфигурации и вступает в силу после перезапуска
+func (s *Server) handleAPIConfigRollback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.configHistory == nil || s.configPath == "" {
+		s.sendError(w, "Откат конфигурации недоступен: история или файл конфигурации не настроены", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req ConfigRollbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID <= 0 {
+		s.sendError(w, "Некорректный формат запроса", http.StatusBadRequest)
+		return
+	}
+
+	author := strings.TrimSpace(req.Author)
+	if author == "" {
+		author = requestAuthor(r)
+	}
+
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	version, err := s.configHistory.Rollback(r.Context(), req.ID, author, func(content string) (string, error) {
+		restored, err := config.RestoreSnapshot(s.configPath, content, s.fullConfig)
+		if err != nil {
+			return "", err
+		}
+		return restored.Snapshot()
+	})
+	if err != nil {
+		s.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	message := "Конфигурация записана в файл и вступит в силу после перезапуска"
+	if version == nil {
+		message = "Версия совпадает с текущей конфигурацией"
+	}
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Message: message,
+	})
+}
+
+// convertToConfigVersionView преобразует версию конфигурации в представление без содержимого
+func convertToConfigVersionView(version *entities.ConfigVersion) ConfigVersionView {
+	return ConfigVersionView{
+		ID:             version.ID,
+		CreatedAt:      version.CreatedAt,
+		Author:         version.Author,
+		Source:         version.Source,
+		Diff:           version.Diff,
+		RolledBackFrom: version.RolledBackFrom,
+	}
+}
+
+// clientHost возвращает адрес клиента запроса без порта
+func clientHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,141 @@
+package webui
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/usecases"
+)
+
+// metricsPath эндпоинт метрик в текстовом формате Prometheus (доступен по токенам API, как /api)
+const metricsPath = "/metrics"
+
+// customMetricUpName служебная метрика: 1 - запрос пользовательской метрики выполнен, 0 - ошибка
+const customMetricUpName = "trade_hedge_custom_metric_up"
+
+// CustomMetricView значение пользовательской метрики для карточки дашборда
+type CustomMetricView struct {
+	Name      string    `json:"name"`
+	Title     string    `json:"title"`
+	Help      string    `json:"help"`
+	Value     *float64  `json:"value"` // null - запрос не вернул строк, вернул NULL или завершился ошибкой
+	UpdatedAt time.Time `json:"updated_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// handleMetrics отдает метрики в текстовом формате Prometheus: пользовательские (gauge на каждую метрику
+// и trade_hedge_custom_metric_up с результатом ее запроса) и метрики циклов планировщика.
+// Метрика без значения пропускается
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.customMetrics == nil && s.scheduler == nil {
+		s.sendError(w, "Пользовательские метрики не настроены (metrics.custom)", http.StatusNotFound)
+		return
+	}
+
+	var b strings.Builder
+	if s.customMetrics != nil {
+		writeCustomMetrics(&b, s.customMetrics.Values(r.Context()))
+	}
+	if s.scheduler != nil {
+		writeSchedulerMetrics(&b, s.scheduler.Status())
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(b.String()))
+}
+
+// writeCustomMetrics записывает значения пользовательских метрик и trade_hedge_custom_metric_up
+func writeCustomMetrics(b *strings.Builder, values []*entities.CustomMetricValue) {
+	for _, value := range values {
+		metric := value.Metric
+		if metric.Help != "" {
+			fmt.Fprintf(b, "# HELP %s %s\n", metric.Name, escapeMetricHelp(metric.Help))
+		}
+		fmt.Fprintf(b, "# TYPE %s gauge\n", metric.Name)
+		if value.Err == nil && value.Value != nil {
+			fmt.Fprintf(b, "%s %s\n", metric.Name, strconv.FormatFloat(*value.Value, 'g', -1, 64))
+		}
+	}
+
+	fmt.Fprintf(b, "# HELP %s Результат запроса пользовательской метрики (1 - выполнен, 0 - ошибка)\n", customMetricUpName)
+	fmt.Fprintf(b, "# TYPE %s gauge\n", customMetricUpName)
+	for _, value := range values {
+		up := 1
+		if value.Err != nil {
+			up = 0
+		}
+		fmt.Fprintf(b, "%s{metric=\"%s\"} %d\n", customMetricUpName, value.Metric.Name, up)
+	}
+}
+
+// writeSchedulerMetrics записывает метрики циклов планировщика: длительность последнего цикла и счетчики
+// пропущенных, не уложившихся в расписание и прерванных по таймауту циклов
+func writeSchedulerMetrics(b *strings.Builder, status usecases.SchedulerStatus) {
+	metrics := []struct {
+		name, kind, help string
+		value            float64
+	}{
+		{"trade_hedge_scheduler_last_cycle_duration_seconds", "gauge", "Длительность последнего завершенного цикла стратегии", status.LastCycleDuration.Seconds()},
+		{"trade_hedge_scheduler_cycle_running", "gauge", "Цикл стратегии выполняется (1 - да, 0 - нет)", boolMetric(status.CycleRunning)},
+		{"trade_hedge_scheduler_cycles_skipped_total", "counter", "Циклы, пропущенные из-за выполняющегося или слишком долгого цикла", float64(status.CyclesSkipped)},
+		{"trade_hedge_scheduler_cycles_overrun_total", "counter", "Циклы, не уложившиеся в период расписания", float64(status.CyclesOverrun)},
+		{"trade_hedge_scheduler_cycles_timed_out_total", "counter", "Циклы, прерванные по таймауту strategy.cycle_timeout", float64(status.CyclesTimedOut)},
+	}
+	for _, metric := range metrics {
+		fmt.Fprintf(b, "# HELP %s %s\n", metric.name, metric.help)
+		fmt.Fprintf(b, "# TYPE %s %s\n", metric.name, metric.kind)
+		fmt.Fprintf(b, "%s %s\n", metric.name, strconv.FormatFloat(metric.value, 'g', -1, 64))
+	}
+}
+
+// boolMetric возвращает значение метрики-признака: 1 или 0
+func boolMetric(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// handleAPICustomMetrics API значений пользовательских метрик для карточек дашборда
+func (s *Server) handleAPICustomMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+
+	views := []CustomMetricView{}
+	if s.customMetrics != nil {
+		for _, value := range s.customMetrics.Values(r.Context()) {
+			view := CustomMetricView{
+				Name:      value.Metric.Name,
+				Title:     value.Metric.DisplayTitle(),
+				Help:      value.Metric.Help,
+				Value:     value.Value,
+				UpdatedAt: value.UpdatedAt,
+			}
+			if value.Err != nil {
+				view.Value = nil
+				view.Error = value.Err.Error()
+			}
+			views = append(views, view)
+		}
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    views,
+	})
+}
+
+// escapeMetricHelp экранирует описание метрики для строки # HELP
+func escapeMetricHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
@@ -0,0 +1,105 @@
+package webui
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/usecases"
+)
+
+// HedgeDecisionView представление решения не хеджировать сделку для веб-интерфейса
+type HedgeDecisionView struct {
+	TradeID         int       `json:"trade_id"`
+	Pair            string    `json:"pair"`
+	Reason          string    `json:"reason"`
+	Details         string    `json:"details"`
+	DrawdownPercent float64   `json:"drawdown_percent"`
+	DecidedAt       time.Time `json:"decided_at"`
+}
+
+// HedgeDecisionsReport решения за период и их количество по причинам
+type HedgeDecisionsReport struct {
+	From      time.Time                           `json:"from"`
+	To        time.Time                           `json:"to"`
+	Summary   []usecases.HedgeDecisionReasonCount `json:"summary"`
+	Decisions []HedgeDecisionView                 `json:"decisions"`
+}
+
+// handleAPIDecisions API решений не хеджировать сделки: /api/decisions?from=...&to=...&pair=...&reason=...
+// По умолчанию - текущие сутки UTC
+func (s *Server) handleAPIDecisions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.decisionRepo == nil {
+		s.sendError(w, "Решения по сделкам недоступны: база данных не настроена", http.StatusServiceUnavailable)
+		return
+	}
+
+	params := r.URL.Query()
+	from, err := parseTradesDate(params.Get("from"), false)
+	if err != nil {
+		s.sendError(w, "Некорректный параметр from", http.StatusBadRequest)
+		return
+	}
+	to, err := parseTradesDate(params.Get("to"), true)
+	if err != nil {
+		s.sendError(w, "Некорректный параметр to", http.StatusBadRequest)
+		return
+	}
+	if from == nil {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		from = &today
+	}
+	if to == nil {
+		end := from.AddDate(0, 0, 1)
+		to = &end
+	}
+	if !from.Before(*to) {
+		s.sendError(w, "from должен быть раньше to", http.StatusBadRequest)
+		return
+	}
+
+	decisions, err := s.decisionRepo.GetHedgeDecisions(r.Context(), *from, *to)
+	if err != nil {
+		s.sendError(w, "Ошибка получения решений по сделкам", http.StatusInternalServerError)
+		return
+	}
+
+	pair := strings.ToUpper(strings.TrimSpace(params.Get("pair")))
+	reason := strings.TrimSpace(params.Get("reason"))
+	filtered := decisions[:0]
+	for _, decision := range decisions {
+		if pair != "" && strings.ToUpper(decision.Pair) != pair {
+			continue
+		}
+		if reason != "" && decision.Reason != reason {
+			continue
+		}
+		filtered = append(filtered, decision)
+	}
+
+	report := HedgeDecisionsReport{
+		From:      *from,
+		To:        *to,
+		Summary:   usecases.SummarizeHedgeDecisions(filtered),
+		Decisions: make([]HedgeDecisionView, len(filtered)),
+	}
+	for i, decision := range filtered {
+		report.Decisions[i] = HedgeDecisionView{
+			TradeID:         decision.TradeID,
+			Pair:            decision.Pair,
+			Reason:          decision.Reason,
+			Details:         decision.Details,
+			DrawdownPercent: decision.DrawdownPercent,
+			DecidedAt:       decision.DecidedAt,
+		}
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
@@ -0,0 +1,205 @@
+package webui
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// exportTimeFormat формат времени в экспортируемых файлах
+const exportTimeFormat = "2006-01-02 15:04:05"
+
+// tradeExportHeader заголовок таблицы сделок в экспорте
+var tradeExportHeader = []string{
+	"ID Freqtrade", "Пара", "Статус", "Время хеджирования", "ID ордера",
+	"Цена Freqtrade", "Убыток Freqtrade %", "Цена покупки", "Количество",
+	"Тейк-профит", "Цена закрытия", "Время закрытия", "Прибыль", "Комиссии", "Прибыль после комиссий", "Заметки журнала",
+	"Версия стратегии", "Флаги",
+}
+
+// tradeExportNumericColumns колонки сделок, сохраняемые в Excel как числа
+var tradeExportNumericColumns = map[int]bool{0: true, 5: true, 6: true, 7: true, 8: true, 9: true, 10: true, 12: true, 13: true, 14: true}
+
+// journalExportHeader заголовок таблицы журнала в экспорте
+var journalExportHeader = []string{"ID", "Дата", "ID ордера хеджа", "Текст", "Создано"}
+
+// journalExportNumericColumns колонки журнала, сохраняемые в Excel как числа
+var journalExportNumericColumns = map[int]bool{0: true}
+
+// handleExportCSV экспорт сделок с заметками журнала в CSV (?type=journal - экспорт самого журнала)
+func (s *Server) handleExportCSV(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+
+	journal := s.getJournalEntries(r)
+
+	var rows [][]string
+	filename := "trades.csv"
+	if r.URL.Query().Get("type") == "journal" {
+		rows = buildJournalRows(journal)
+		filename = "journal.csv"
+	} else {
+		rows = buildTradeRows(s.getAllTrades(r.Context()), journal)
+	}
+
+	var buf bytes.Buffer
+	// BOM для корректного отображения кириллицы в Excel
+	buf.WriteString("\xEF\xBB\xBF")
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(rows); err != nil {
+		s.sendError(w, "Ошибка формирования CSV", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Printf("❌ Ошибка отправки CSV: %v", err)
+	}
+}
+
+// handleExportExcel экспорт сделок и журнала в Excel (XML Spreadsheet, два листа)
+func (s *Server) handleExportExcel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+
+	journal := s.getJournalEntries(r)
+
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buf.WriteString(`<?mso-application progid="Excel.Sheet"?>` + "\n")
+	buf.WriteString(`<Workbook xmlns="urn:schemas-microsoft-com:office:spreadsheet" xmlns:ss="urn:schemas-microsoft-com:office:spreadsheet">` + "\n")
+	writeSpreadsheetSheet(&buf, "Сделки", buildTradeRows(s.getAllTrades(r.Context()), journal), tradeExportNumericColumns)
+	writeSpreadsheetSheet(&buf, "Журнал", buildJournalRows(journal), journalExportNumericColumns)
+	buf.WriteString("</Workbook>\n")
+
+	w.Header().Set("Content-Type", "application/vnd.ms-excel")
+	w.Header().Set("Content-Disposition", `attachment; filename="trades.xls"`)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Printf("❌ Ошибка отправки Excel: %v", err)
+	}
+}
+
+// buildTradeRows формирует строки сделок; к каждой сделке добавляются относящиеся к ней записи журнала
+func buildTradeRows(trades []*entities.HedgedTrade, journal []*entities.JournalEntry) [][]string {
+	rows := [][]string{tradeExportHeader}
+
+	for _, trade := range trades {
+		var notes []string
+		for _, entry := range journal {
+			if entry.AppliesTo(trade) {
+				notes = append(notes, entry.Text)
+			}
+		}
+
+		pair := valueobjects.NewTradingPair(trade.Pair)
+		pricePrecision := pair.PricePrecision()
+		quotePrecision := valueobjects.CurrencyPrecision(pair.QuoteCurrency())
+		var grossProfit, netProfit *float64
+		if profit := trade.CalculateProfit(); profit != nil {
+			grossProfit, netProfit = &profit.Gross, &profit.Net
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(trade.FreqtradeTradeID),
+			trade.Pair,
+			trade.OrderStatus.String(),
+			trade.HedgeTime.Format(exportTimeFormat),
+			trade.BybitOrderID,
+			formatExportFloat(trade.FreqtradeOpenPrice, pricePrecision),
+			formatExportFloat(trade.FreqtradeProfitRatio*100, -1),
+			formatExportFloat(trade.HedgeOpenPrice, pricePrecision),
+			formatExportFloat(trade.HedgeAmount, valueobjects.CurrencyPrecision(pair.BaseCurrency())),
+			formatExportFloat(trade.HedgeTakeProfitPrice, pricePrecision),
+			formatExportOptionalFloat(trade.ClosePrice, pricePrecision),
+			formatExportOptionalTime(trade.CloseTime),
+			formatExportOptionalFloat(grossProfit, quotePrecision),
+			formatExportFloat(trade.TotalFees(), quotePrecision),
+			formatExportOptionalFloat(netProfit, quotePrecision),
+			strings.Join(notes, "; "),
+			trade.StrategyVersion,
+			trade.FeatureFlags,
+		})
+	}
+
+	return rows
+}
+
+// buildJournalRows формирует строки записей журнала
+func buildJournalRows(journal []*entities.JournalEntry) [][]string {
+	rows := [][]string{journalExportHeader}
+
+	for _, entry := range journal {
+		rows = append(rows, []string{
+			strconv.Itoa(entry.ID),
+			entry.EntryDate.Format("2006-01-02"),
+			entry.HedgeOrderID,
+			entry.Text,
+			entry.CreatedAt.Format(exportTimeFormat),
+		})
+	}
+
+	return rows
+}
+
+// writeSpreadsheetSheet записывает лист XML Spreadsheet; числовые колонки (кроме заголовка)
+// сохраняются как числовые ячейки, чтобы по ним работали формулы и сортировка
+func writeSpreadsheetSheet(buf *bytes.Buffer, name string, rows [][]string, numericColumns map[int]bool) {
+	fmt.Fprintf(buf, "<Worksheet ss:Name=\"%s\">\n<Table>\n", escapeXML(name))
+	for i, row := range rows {
+		buf.WriteString("<Row>")
+		for j, cell := range row {
+			cellType := "String"
+			if i > 0 && numericColumns[j] && cell != "" {
+				cellType = "Number"
+			}
+			fmt.Fprintf(buf, "<Cell><Data ss:Type=\"%s\">%s</Data></Cell>", cellType, escapeXML(cell))
+		}
+		buf.WriteString("</Row>\n")
+	}
+	buf.WriteString("</Table>\n</Worksheet>\n")
+}
+
+// escapeXML экранирует текст для XML
+func escapeXML(value string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
+
+// formatExportFloat форматирует число, округленное до precision знаков по реестру точности валют,
+// без незначащих нулей; при precision < 0 - без потери точности
+func formatExportFloat(value float64, precision int32) string {
+	if precision >= 0 {
+		value = valueobjects.NewDecimalFromFloat(value).Round(precision, valueobjects.RoundHalfUp).Float64()
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatExportOptionalFloat форматирует необязательное число (пусто, если значения нет)
+func formatExportOptionalFloat(value *float64, precision int32) string {
+	if value == nil {
+		return ""
+	}
+	return formatExportFloat(*value, precision)
+}
+
+// formatExportOptionalTime форматирует необязательное время (пусто, если значения нет)
+func formatExportOptionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(exportTimeFormat)
+}
@@ -0,0 +1,122 @@
+package webui
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/usecases"
+)
+
+// featureHistoryLimit количество записей журнала переключений флагов в ответе
+const featureHistoryLimit = 50
+
+// FeatureFlagChangeView представление переключения флага для веб-интерфейса
+type FeatureFlagChangeView struct {
+	ID        int64     `json:"id"`
+	Key       string    `json:"key"`
+	Enabled   *bool     `json:"enabled"` // null - сброс к конфигурации
+	ChangedBy string    `json:"changed_by"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// FeaturesView флаги возможностей, журнал переключений и версия стратегии
+type FeaturesView struct {
+	StrategyVersion string                     `json:"strategy_version"`
+	Flags           []usecases.FeatureFlagView `json:"flags"`
+	History         []FeatureFlagChangeView    `json:"history"`
+}
+
+// FeatureFlagUpdateRequest запрос на переключение флага
+type FeatureFlagUpdateRequest struct {
+	Key     string `json:"key"`
+	Enabled *bool  `json:"enabled"` // Новое состояние
+	Reset   bool   `json:"reset"`   // Сбросить к конфигурации
+	Author  string `json:"author"`  // Кто переключает (по умолчанию - адрес клиента)
+}
+
+// handleFeatures страница флагов возможностей
+func (s *Server) handleFeatures(w http.ResponseWriter, r *http.Request) {
+	data := PageData{
+		Title: "Флаги",
+	}
+
+	if err := s.executeTemplate(w, r, "features.html", data); err != nil {
+		// Логируем ошибку, но не пытаемся изменить заголовки если они уже отправлены
+		log.Printf("❌ Ошибка рендеринга шаблона features.html: %v", err)
+		return
+	}
+}
+
+// handleAPIFeatures API флагов возможностей: GET /api/admin/features - флаги и журнал,
+// POST /api/admin/features - переключение или сброс флага
+func (s *Server) handleAPIFeatures(w http.ResponseWriter, r *http.Request) {
+	if s.features == nil {
+		s.sendError(w, "Флаги возможностей не настроены", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.sendFeatures(w, r, "")
+	case http.MethodPost:
+		var req FeatureFlagUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Key == "" || (req.Enabled == nil && !req.Reset) {
+			s.sendError(w, "Некорректный формат запроса", http.StatusBadRequest)
+			return
+		}
+
+		author := strings.TrimSpace(req.Author)
+		if author == "" {
+			author = requestAuthor(r)
+		}
+
+		var err error
+		message := "Флаг переключен"
+		if req.Reset {
+			_, err = s.features.Reset(r.Context(), req.Key, author)
+			message = "Флаг сброшен к конфигурации"
+		} else {
+			_, err = s.features.Set(r.Context(), req.Key, *req.Enabled, author)
+		}
+		if err != nil {
+			s.sendError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.sendFeatures(w, r, message)
+	default:
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+	}
+}
+
+// sendFeatures отправляет флаги и журнал переключений
+func (s *Server) sendFeatures(w http.ResponseWriter, r *http.Request, message string) {
+	history, err := s.features.History(r.Context(), featureHistoryLimit)
+	if err != nil {
+		s.sendError(w, "Ошибка получения журнала флагов", http.StatusInternalServerError)
+		return
+	}
+
+	view := FeaturesView{
+		StrategyVersion: usecases.StrategyVersion,
+		Flags:           s.features.Flags(),
+		History:         make([]FeatureFlagChangeView, 0, len(history)),
+	}
+	for _, change := range history {
+		view.History = append(view.History, FeatureFlagChangeView{
+			ID:        change.ID,
+			Key:       change.Key,
+			Enabled:   change.Enabled,
+			ChangedBy: change.ChangedBy,
+			ChangedAt: change.ChangedAt,
+		})
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Message: message,
+		Data:    view,
+	})
+}
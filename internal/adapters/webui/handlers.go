@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"time"
 
 	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/usecases"
 )
 
 // TradeStats статистика по сделкам
@@ -18,6 +23,26 @@ type TradeStats struct {
 	Completed      int     `json:"completed"`
 	TotalProfit    float64 `json:"totalProfit"`
 	TotalOrderSize float64 `json:"totalOrderSize"` // Общий размер всех ордеров в долларах
+
+	TotalNetProfit float64 `json:"totalNetProfit"` // Прибыль закрытых хеджей за вычетом комиссий
+	TotalFees      float64 `json:"totalFees"`      // Комиссии закрытых хеджей
+
+	UnrealizedProfit float64 `json:"unrealizedProfit"` // Плавающая прибыль открытых хеджей по текущим ценам
+
+	Wins           int     `json:"wins"`           // Закрытые хеджи с прибылью после комиссий
+	Losses         int     `json:"losses"`         // Закрытые хеджи с убытком после комиссий
+	WinRate        float64 `json:"winRate"`        // Доля прибыльных среди закрытых, %
+	AvgHoldSeconds float64 `json:"avgHoldSeconds"` // Среднее время от хеджирования до закрытия, с
+
+	ByVersion []VersionStats `json:"byVersion"` // Результаты в разрезе версий стратегии
+}
+
+// VersionStats статистика по хеджам одной версии стратегии
+type VersionStats struct {
+	Version     string  `json:"version"`
+	Total       int     `json:"total"`
+	Completed   int     `json:"completed"`
+	TotalProfit float64 `json:"totalProfit"`
 }
 
 // APIResponse универсальный ответ API
@@ -26,16 +51,29 @@ type APIResponse struct {
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 	Updated int         `json:"updated,omitempty"`
+
+	Stale      bool       `json:"stale,omitempty"`      // Источник недоступен: данные из последнего снимка
+	SnapshotAt *time.Time `json:"snapshotAt,omitempty"` // Время получения данных снимка
 }
 
 // TradesResponse ответ с данными о сделках
 type TradesResponse struct {
 	Trades []TradeView `json:"trades"`
-	Stats  TradeStats  `json:"stats"`
+	Stats  *TradeStats `json:"stats,omitempty"` // Статистика по всем сделкам, подходящим под фильтры (не только по странице)
+
+	// Пагинация: total - количество сделок, подходящих под фильтры
+	Total  int `json:"total"`
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset"`
+
+	// Пары и версии стратегии всех сделок для фильтров (при facets=true)
+	Pairs    []string `json:"pairs,omitempty"`
+	Versions []string `json:"versions,omitempty"`
 }
 
 // TradeView представление сделки для веб-интерфейса
 type TradeView struct {
+	HedgeID              int64      `json:"hedge_id"`
 	FreqtradeTradeID     int        `json:"freqtrade_trade_id"`
 	Pair                 string     `json:"pair"`
 	HedgeTime            time.Time  `json:"hedge_time"`
@@ -50,14 +88,68 @@ type TradeView struct {
 	LastStatusCheck      *time.Time `json:"last_status_check"`
 	ClosePrice           *float64   `json:"close_price"`
 	CloseTime            *time.Time `json:"close_time"`
-	Profit               *float64   `json:"profit"`
+	Profit               *float64   `json:"profit"`         // Прибыль до комиссий
+	NetProfit            *float64   `json:"net_profit"`     // Прибыль за вычетом комиссий
+	EntryFee             float64    `json:"entry_fee"`      // Комиссия за покупку в котируемой валюте
+	ExitFee              float64    `json:"exit_fee"`       // Комиссия за продажу в котируемой валюте
 	OrderSizeUSD         float64    `json:"order_size_usd"` // Размер ордера в долларах
+	BuyRequestedQty      float64    `json:"buy_requested_qty"`
+	BuyFilledQty         float64    `json:"buy_filled_qty"`
+	PartialFill          bool       `json:"partial_fill"` // Покупка исполнена частично, остаток отменен
+	StrategyVersion      string     `json:"strategy_version"`
+	FeatureFlags         string     `json:"feature_flags"`
+	StopLossPrice        float64    `json:"stop_loss_price"`
+	StopLossOrderID      string     `json:"stop_loss_order_id"`
+	PricePrecision       int32      `json:"price_precision"`   // Точность цен пары
+	AmountPrecision      int32      `json:"amount_precision"`  // Точность количества базовой валюты
+	QuotePrecision       int32      `json:"quote_precision"`   // Точность сумм в котируемой валюте
+	CurrentPrice         *float64   `json:"current_price"`     // Текущая цена (для открытых хеджей)
+	UnrealizedProfit     *float64   `json:"unrealized_profit"` // Плавающая прибыль открытого хеджа
+
+	// Закрытие продажей (стоп-лосс, по времени, вручную): цена отметки при решении о закрытии
+	// и отклонение от нее цены закрытия, % (nil - хедж не закрыт продажей или цена отметки неизвестна)
+	CloseIntendedPrice   *float64 `json:"close_intended_price"`
+	CloseSlippagePercent *float64 `json:"close_slippage_percent"`
+
+	HedgeGroupID int64 `json:"hedge_group_id,omitempty"` // Хедж-группа, ногой которой является хедж
+}
+
+// OutcomeView итог хеджирования сделки Freqtrade для веб-интерфейса
+type OutcomeView struct {
+	FreqtradeTradeID int       `json:"freqtrade_trade_id"`
+	Pair             string    `json:"pair"`
+	FreqtradeProfit  float64   `json:"freqtrade_profit"`
+	HedgeProfit      float64   `json:"hedge_profit"`
+	NetOutcome       float64   `json:"net_outcome"`
+	Hedges           int       `json:"hedges"`
+	Improved         bool      `json:"improved"`
+	TradeCloseTime   time.Time `json:"trade_close_time"`
+}
+
+// OutcomeSummaryView агрегированная эффективность хеджирования
+type OutcomeSummaryView struct {
+	Trades          int     `json:"trades"`
+	Improved        int     `json:"improved"`
+	FreqtradeProfit float64 `json:"freqtradeProfit"`
+	HedgeProfit     float64 `json:"hedgeProfit"`
+	NetOutcome      float64 `json:"netOutcome"`
+}
+
+// OutcomesResponse ответ с итогами хеджирования
+type OutcomesResponse struct {
+	Outcomes []OutcomeView      `json:"outcomes"`
+	Summary  OutcomeSummaryView `json:"summary"`
 }
 
 // PageData данные для рендеринга страниц
 type PageData struct {
 	Title  string
 	Config interface{}
+
+	SessionAuth bool   // Вход по сессии: в меню показывается кнопка выхода
+	Lang        string // Язык страницы (webui.language)
+	Role        string // Роль пользователя: кнопки действий, недоступных роли, скрываются
+	CSRFToken   string // CSRF токен для изменяющих запросов страницы
 }
 
 // handleDashboard главная страница дашборда
@@ -67,7 +159,7 @@ func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Выполняем layout с dashboard content
-	if err := s.executeTemplate(w, "dashboard.html", data); err != nil {
+	if err := s.executeTemplate(w, r, "dashboard.html", data); err != nil {
 		// Логируем ошибку, но не пытаемся изменить заголовки если они уже отправлены
 		log.Printf("❌ Ошибка рендеринга шаблона dashboard.html: %v", err)
 		return
@@ -80,7 +172,7 @@ func (s *Server) handleTrades(w http.ResponseWriter, r *http.Request) {
 		Title: "Сделки",
 	}
 
-	if err := s.executeTemplate(w, "trades.html", data); err != nil {
+	if err := s.executeTemplate(w, r, "trades.html", data); err != nil {
 		// Логируем ошибку, но не пытаемся изменить заголовки если они уже отправлены
 		log.Printf("❌ Ошибка рендеринга шаблона trades.html: %v", err)
 		return
@@ -94,7 +186,7 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 		Config: s.fullConfig,
 	}
 
-	if err := s.executeTemplate(w, "config.html", data); err != nil {
+	if err := s.executeTemplate(w, r, "config.html", data); err != nil {
 		// Логируем ошибку, но не пытаемся изменить заголовки если они уже отправлены
 		log.Printf("❌ Ошибка рендеринга шаблона config.html: %v", err)
 		return
@@ -102,8 +194,16 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 }
 
 // executeTemplate выполняет шаблон с layout безопасно
-func (s *Server) executeTemplate(w http.ResponseWriter, templateName string, data interface{}) error {
+func (s *Server) executeTemplate(w http.ResponseWriter, r *http.Request, templateName string, data interface{}) error {
 	// Рендерим в буфер сначала чтобы поймать ошибки до отправки заголовков
+	if page, ok := data.(PageData); ok {
+		page.SessionAuth = s.webUIConfig.Auth.Mode == config.WebUIAuthSession
+		page.Lang = s.language()
+		page.Role = requestRole(r)
+		page.CSRFToken = s.csrfToken(w, r)
+		data = page
+	}
+
 	var buf bytes.Buffer
 	if err := s.templates.ExecuteTemplate(&buf, "layout.html", data); err != nil {
 		return err
@@ -119,44 +219,157 @@ func (s *Server) executeTemplate(w http.ResponseWriter, templateName string, dat
 func (s *Server) handleAPITrades(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// Получаем параметры фильтрации
-	statusParam := r.URL.Query().Get("status")
-
-	var status *string
-	if statusParam != "" {
-		status = &statusParam
+	// Получаем параметры фильтрации, сортировки и пагинации
+	query, err := parseHedgeTradeQuery(r)
+	if err != nil {
+		s.sendError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	// Используем универсальный метод для получения сделок
-	trades, err := s.hedgeRepo.GetHedgedTrades(ctx, status)
+	response, err := s.tradesPage(ctx, query)
 	if err != nil {
-		s.sendError(w, "Ошибка получения сделок", http.StatusInternalServerError)
+		s.sendError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	s.sendJSON(w, response)
+}
+
+// tradesPage загружает страницу сделок с плавающей прибылью и статистикой по всем сделкам под фильтрами
+func (s *Server) tradesPage(ctx context.Context, query *entities.HedgeTradeQuery) (*TradesResponse, error) {
+	page, err := s.hedgeRepo.QueryHedgedTrades(ctx, query)
+	if err != nil {
+		return nil, errors.New("Ошибка получения сделок")
+	}
+	trades := page.Trades
+
 	// Преобразуем в представление для веб-интерфейса
 	tradeViews := s.convertToTradeViews(trades)
+	s.applyUnrealizedProfit(ctx, tradeViews, trades)
+
+	response := &TradesResponse{
+		Trades:   tradeViews,
+		Total:    page.Total,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+		Pairs:    page.Pairs,
+		Versions: page.Versions,
+	}
+
+	// Статистика агрегируется хранилищем по всем сделкам под фильтрами, а не по странице
+	hedgeStats, err := s.hedgeRepo.GetTradeStats(ctx, query)
+	if err != nil {
+		return nil, errors.New("Ошибка расчета статистики")
+	}
+	stats := newTradeStats(hedgeStats)
+	if query.Limit == 0 && query.Offset == 0 {
+		// Полная выборка уже загружена и оценена по текущим ценам
+		for _, view := range tradeViews {
+			if view.UnrealizedProfit != nil {
+				stats.UnrealizedProfit += *view.UnrealizedProfit
+			}
+		}
+	} else {
+		stats.UnrealizedProfit = s.unrealizedProfit(ctx, query)
+	}
+	response.Stats = &stats
+	return response, nil
+}
 
-	// Рассчитываем статистику
-	stats := s.calculateStats(trades)
+// handleAPIStats API агрегированной статистики хеджей без загрузки сделок.
+// Фильтры совпадают с /api/trades (status, pair, version, from, to); days - период в днях вместо from
+func (s *Server) handleAPIStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
 
-	response := TradesResponse{
-		Trades: tradeViews,
-		Stats:  stats,
+	query, err := parseStatsQuery(r)
+	if err != nil {
+		s.sendError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	s.sendJSON(w, response)
+	hedgeStats, err := s.hedgeRepo.GetTradeStats(ctx, query)
+	if err != nil {
+		s.sendError(w, "Ошибка расчета статистики", http.StatusInternalServerError)
+		return
+	}
+	stats := newTradeStats(hedgeStats)
+	stats.UnrealizedProfit = s.unrealizedProfit(ctx, query)
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    stats,
+	})
+}
+
+// databasePingTimeout таймаут проверки базы данных для статуса системы
+const databasePingTimeout = 2 * time.Second
+
+// DatabaseStatus состояние базы данных в статусе системы
+type DatabaseStatus struct {
+	Status    string                          `json:"status"` // connected, unavailable, disabled
+	LatencyMs float64                         `json:"latencyMs,omitempty"`
+	Error     string                          `json:"error,omitempty"`
+	Pool      *repositories.DatabasePoolStats `json:"pool,omitempty"`
+}
+
+// databaseStatus проверяет базу данных запросом Ping; хранилища без проверки (в памяти) считаются доступными
+func (s *Server) databaseStatus(ctx context.Context) *DatabaseStatus {
+	if !s.fullConfig.IsDatabaseConfigured() {
+		return &DatabaseStatus{Status: "disabled"}
+	}
+
+	status := &DatabaseStatus{Status: "connected"}
+	if pooled, ok := s.hedgeRepo.(repositories.PooledRepository); ok {
+		status.Pool = pooled.PoolStats()
+	}
+	pingable, ok := s.hedgeRepo.(repositories.PingableRepository)
+	if !ok {
+		return status
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, databasePingTimeout)
+	defer cancel()
+	started := time.Now()
+	err := pingable.Ping(pingCtx)
+	status.LatencyMs = float64(time.Since(started).Microseconds()) / 1000
+	if err != nil {
+		status.Status = "unavailable"
+		status.Error = err.Error()
+	}
+	return status
 }
 
 // handleAPIStatus API для получения статуса системы
 func (s *Server) handleAPIStatus(w http.ResponseWriter, r *http.Request) {
+	database := s.databaseStatus(r.Context())
+	bybit := "connected"
+	if !s.fullConfig.IsExchangeConfigured() {
+		bybit = "disabled"
+	}
+
 	status := map[string]interface{}{
-		"database":  "connected",
-		"freqtrade": "connected",
-		"bybit":     "connected",
-		"webui":     "running",
+		"mode":            s.fullConfig.OperatingMode(),
+		"database":        database.Status,
+		"databaseDetails": database,
+		"freqtrade":       "connected",
+		"bybit":           bybit,
+		"webui":           "running",
+		"instance": map[string]interface{}{
+			"id":    s.fullConfig.Lease.ResolvedInstanceID(),
+			"roles": s.fullConfig.Lease.ResolvedRoles(),
+		},
 		"lastCheck": time.Now(),
 	}
+	if s.alerts != nil {
+		status["alerts"] = s.alerts.Active()
+	}
+	if s.scheduler != nil {
+		status["scheduler"] = s.schedulerView()
+	}
 
 	s.sendJSON(w, APIResponse{
 		Success: true,
@@ -171,6 +384,18 @@ func (s *Server) handleAPIExecute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.fullConfig.Lease.HasRole(config.RoleExecutor) {
+		s.sendError(w, "Экземпляр не выполняет роль executor: хеджи открывает другой экземпляр", http.StatusConflict)
+		return
+	}
+
+	finish, ok := s.startCycle()
+	if !ok {
+		s.sendError(w, "Цикл стратегии уже выполняется: повторите запуск после его завершения", http.StatusConflict)
+		return
+	}
+	defer finish()
+
 	ctx := r.Context()
 
 	err := s.hedgeUseCase.ExecuteHedgeStrategy(ctx)
@@ -194,15 +419,18 @@ func (s *Server) handleAPICheckStatus(w http.ResponseWriter, r *http.Request) {
 		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
 		return
 	}
+	if !s.fullConfig.Lease.HasRole(config.RoleStatusChecker) {
+		s.sendError(w, "Экземпляр не выполняет роль status-checker: статусы проверяет другой экземпляр", http.StatusConflict)
+		return
+	}
+	finish, ok := s.startCycle()
+	if !ok {
+		s.sendError(w, "Цикл стратегии уже выполняется: повторите запуск после его завершения", http.StatusConflict)
+		return
+	}
+	defer finish()
 
-	ctx := r.Context()
-
-	// Получаем количество активных ордеров до проверки
-	pendingStatus := "PENDING"
-	activeBefore, _ := s.hedgeRepo.GetHedgedTrades(ctx, &pendingStatus)
-	beforeCount := len(activeBefore)
-
-	err := s.statusCheckerUseCase.CheckAllActiveOrders(ctx)
+	updated, err := s.checkStatuses(r.Context())
 	if err != nil {
 		s.sendJSON(w, APIResponse{
 			Success: false,
@@ -211,8 +439,25 @@ func (s *Server) handleAPICheckStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Message: "Статусы ордеров проверены",
+		Updated: updated,
+	})
+}
+
+// checkStatuses проверяет статусы активных ордеров и возвращает количество закрытых проверкой
+func (s *Server) checkStatuses(ctx context.Context) (int, error) {
+	// Получаем количество активных ордеров до проверки
+	activeBefore, _ := s.statusCheckerUseCase.ActiveTrades(ctx)
+	beforeCount := len(activeBefore)
+
+	if err := s.statusCheckerUseCase.CheckAllActiveOrders(ctx); err != nil {
+		return 0, err
+	}
+
 	// Получаем количество активных ордеров после проверки
-	activeAfter, _ := s.hedgeRepo.GetHedgedTrades(ctx, &pendingStatus)
+	activeAfter, _ := s.statusCheckerUseCase.ActiveTrades(ctx)
 	afterCount := len(activeAfter)
 
 	// Количество обновленных ордеров = количество закрытых
@@ -220,48 +465,161 @@ func (s *Server) handleAPICheckStatus(w http.ResponseWriter, r *http.Request) {
 	if updated < 0 {
 		updated = 0
 	}
+	return updated, nil
+}
+
+// handleAPIBalance API для получения баланса Bybit. Если биржа недоступна,
+// возвращается последний снимок балансов с признаком stale и временем снимка
+func (s *Server) handleAPIBalance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	baseCurrency := s.balanceBaseCurrency()
+
+	balances, err := s.fetchBalances(ctx, baseCurrency)
+	if err != nil {
+		log.Printf("⚠️ Не удалось получить баланс: %v", err)
+		if snapshot := s.latestSnapshot(ctx); snapshot != nil && snapshot.BalancesAt != nil && snapshot.Balances[baseCurrency] != nil {
+			s.sendJSON(w, APIResponse{
+				Success:    true,
+				Message:    "Биржа недоступна, показан последний снимок баланса",
+				Data:       balanceResponse(snapshot.Balances, baseCurrency),
+				Stale:      true,
+				SnapshotAt: snapshot.BalancesAt,
+			})
+			return
+		}
+		s.sendError(w, "Ошибка получения баланса "+baseCurrency, http.StatusInternalServerError)
+		return
+	}
 
 	s.sendJSON(w, APIResponse{
 		Success: true,
-		Message: "Статусы ордеров проверены",
-		Updated: updated,
+		Data:    balanceResponse(balances, baseCurrency),
 	})
 }
 
-// handleAPIBalance API для получения баланса Bybit
-func (s *Server) handleAPIBalance(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// balanceBaseCurrency возвращает базовую валюту баланса дашборда
+func (s *Server) balanceBaseCurrency() string {
+	if s.snapshots != nil {
+		return s.snapshots.BaseCurrency()
+	}
+	return "USDT"
+}
 
-	// Получаем баланс USDT
-	usdtBalance, err := s.hedgeUseCase.GetExchangeService().GetBalance(ctx, "USDT")
+// fetchBalances получает балансы базовой валюты и популярных криптовалют (через снимок, если он ведется)
+func (s *Server) fetchBalances(ctx context.Context, baseCurrency string) (map[string]*entities.Balance, error) {
+	if s.snapshots != nil {
+		return s.snapshots.FetchBalances(ctx)
+	}
+
+	exchange := s.hedgeUseCase.GetExchangeService()
+	base, err := exchange.GetBalance(ctx, baseCurrency)
 	if err != nil {
-		s.sendError(w, "Ошибка получения баланса USDT", http.StatusInternalServerError)
+		return nil, err
+	}
+	balances := map[string]*entities.Balance{baseCurrency: base}
+	for _, currency := range usecases.DashboardCurrencies {
+		if balance, err := exchange.GetBalance(ctx, currency); err == nil {
+			balances[currency] = balance
+		}
+	}
+	return balances, nil
+}
+
+// latestSnapshot возвращает последний снимок балансов и цен (nil - снимок не ведется или пуст)
+func (s *Server) latestSnapshot(ctx context.Context) *entities.MarketSnapshot {
+	if s.snapshots == nil {
+		return nil
+	}
+	snapshot, err := s.snapshots.Latest(ctx)
+	if err != nil {
+		log.Printf("⚠️ Не удалось получить снимок балансов и цен: %v", err)
+		return nil
+	}
+	return snapshot
+}
+
+// balanceResponse формирует ответ баланса: базовая валюта отдельно, криптовалюты с точностью отображения
+func balanceResponse(balances map[string]*entities.Balance, baseCurrency string) map[string]interface{} {
+	crypto := make(map[string]interface{})
+	for currency, balance := range balances {
+		if currency == baseCurrency {
+			continue
+		}
+		crypto[currency] = map[string]interface{}{
+			"available": balance.Available,
+			"total":     balance.Total,
+			"precision": valueobjects.CurrencyPrecision(currency),
+		}
+	}
+
+	return map[string]interface{}{
+		"usdt":   balances[baseCurrency],
+		"crypto": crypto,
+	}
+}
+
+// handleAPICandidates API для просмотра кандидатов на хеджирование в следующем цикле
+func (s *Server) handleAPICandidates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Получаем баланс основных криптовалют
-	balances := make(map[string]interface{})
+	candidates, err := s.hedgeUseCase.GetHedgeCandidates(r.Context())
+	if err != nil {
+		s.sendError(w, "Ошибка получения кандидатов на хеджирование", http.StatusInternalServerError)
+		return
+	}
 
-	// Популярные криптовалюты для отображения
-	currencies := []string{"BTC", "ETH", "SOL", "XRP", "DOGE", "PEPE", "TON", "ONDO"}
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    candidates,
+	})
+}
 
-	for _, currency := range currencies {
-		if balance, err := s.hedgeUseCase.GetExchangeService().GetBalance(ctx, currency); err == nil {
-			balances[currency] = map[string]interface{}{
-				"available": balance.Available,
-				"total":     balance.Total,
-			}
-		}
+// handleAPIOutcomes API эффективности хеджирования: итог сделок Freqtrade с учетом прибыли хеджей
+func (s *Server) handleAPIOutcomes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.outcomeUseCase == nil {
+		s.sendError(w, "Расчет эффективности хеджирования недоступен", http.StatusServiceUnavailable)
+		return
+	}
+
+	outcomes, summary, err := s.outcomeUseCase.GetOutcomes(r.Context())
+	if err != nil {
+		s.sendError(w, "Ошибка получения итогов хеджирования", http.StatusInternalServerError)
+		return
 	}
 
-	response := map[string]interface{}{
-		"usdt":   usdtBalance,
-		"crypto": balances,
+	views := make([]OutcomeView, len(outcomes))
+	for i, outcome := range outcomes {
+		views[i] = OutcomeView{
+			FreqtradeTradeID: outcome.FreqtradeTradeID,
+			Pair:             outcome.Pair,
+			FreqtradeProfit:  outcome.FreqtradeProfit,
+			HedgeProfit:      outcome.HedgeProfit,
+			NetOutcome:       outcome.NetOutcome,
+			Hedges:           outcome.Hedges,
+			Improved:         outcome.Improved(),
+			TradeCloseTime:   outcome.TradeCloseTime,
+		}
 	}
 
 	s.sendJSON(w, APIResponse{
 		Success: true,
-		Data:    response,
+		Data: OutcomesResponse{
+			Outcomes: views,
+			Summary: OutcomeSummaryView{
+				Trades:          summary.Trades,
+				Improved:        summary.Improved,
+				FreqtradeProfit: summary.FreqtradeProfit,
+				HedgeProfit:     summary.HedgeProfit,
+				NetOutcome:      summary.NetOutcome,
+			},
+		},
 	})
 }
 
@@ -282,6 +640,7 @@ func (s *Server) convertToTradeViews(trades []*entities.HedgedTrade) []TradeView
 
 	for i, trade := range trades {
 		view := TradeView{
+			HedgeID:              trade.HedgeID,
 			FreqtradeTradeID:     trade.FreqtradeTradeID,
 			Pair:                 trade.Pair,
 			HedgeTime:            trade.HedgeTime,
@@ -296,11 +655,33 @@ func (s *Server) convertToTradeViews(trades []*entities.HedgedTrade) []TradeView
 			LastStatusCheck:      trade.LastStatusCheck,
 			ClosePrice:           trade.ClosePrice,
 			CloseTime:            trade.CloseTime,
+			BuyRequestedQty:      trade.BuyRequestedQty,
+			BuyFilledQty:         trade.BuyFilledQty,
+			PartialFill:          trade.IsPartialFill(),
+			StrategyVersion:      trade.StrategyVersion,
+			FeatureFlags:         trade.FeatureFlags,
+			StopLossPrice:        trade.StopLossPrice,
+			StopLossOrderID:      trade.StopLossOrderID,
+			EntryFee:             trade.EntryFee,
+			ExitFee:              trade.ExitFee,
+			HedgeGroupID:         trade.HedgeGroupID,
 		}
 
-		// Рассчитываем прибыль, если ордер закрыт
+		pair := valueobjects.NewTradingPair(trade.Pair)
+		view.PricePrecision = pair.PricePrecision()
+		view.AmountPrecision = valueobjects.CurrencyPrecision(pair.BaseCurrency())
+		view.QuotePrecision = valueobjects.CurrencyPrecision(pair.QuoteCurrency())
+
+		if slippage, ok := trade.CloseSlippage(); ok {
+			intended := trade.CloseIntendedPrice
+			view.CloseIntendedPrice = &intended
+			view.CloseSlippagePercent = &slippage
+		}
+
+		// Рассчитываем прибыль до и после комиссий, если ордер закрыт
 		if profit := trade.CalculateProfit(); profit != nil {
-			view.Profit = profit
+			view.Profit = &profit.Gross
+			view.NetProfit = &profit.Net
 		}
 
 		// Рассчитываем размер ордера в долларах (количество * цена открытия)
@@ -312,35 +693,116 @@ func (s *Server) convertToTradeViews(trades []*entities.HedgedTrade) []TradeView
 	return views
 }
 
-// calculateStats рассчитывает статистику по сделкам
-func (s *Server) calculateStats(trades []*entities.HedgedTrade) TradeStats {
-	stats := TradeStats{
-		Total: len(trades),
+// applyUnrealizedProfit заполняет текущую цену и плавающую прибыль открытых хеджей.
+// Цены всех пар запрашиваются одним вызовом; если источник цен не подключен или недоступен, поля остаются пустыми
+func (s *Server) applyUnrealizedProfit(ctx context.Context, views []TradeView, trades []*entities.HedgedTrade) {
+	if s.priceFeed == nil {
+		return
 	}
 
+	seen := make(map[string]bool)
+	var pairs []string
 	for _, trade := range trades {
-		// Рассчитываем общий размер всех ордеров
-		orderSize := trade.HedgeAmount * trade.HedgeOpenPrice
-		stats.TotalOrderSize += orderSize
-
-		if trade.IsActive() {
-			stats.Active++
-		} else {
-			stats.Completed++
-			if profit := trade.CalculateProfit(); profit != nil {
-				stats.TotalProfit += *profit
-			}
+		if trade.IsActive() && trade.OrderStatus != entities.OrderStatusBuyPending && !seen[trade.Pair] {
+			seen[trade.Pair] = true
+			pairs = append(pairs, trade.Pair)
 		}
 	}
+	if len(pairs) == 0 {
+		return
+	}
 
+	prices, err := s.priceFeed.CurrentPrices(ctx, pairs)
+	if err != nil {
+		log.Printf("⚠️ Не удалось получить цены для плавающей прибыли: %v", err)
+		return
+	}
 
+	for i, trade := range trades {
+		if !trade.IsActive() || trade.OrderStatus == entities.OrderStatusBuyPending {
+			continue
+		}
 
+		price := prices[trade.Pair]
+		if profit := trade.CalculateUnrealizedProfit(price); profit != nil {
+			currentPrice := price
+			views[i].CurrentPrice = &currentPrice
+			views[i].UnrealizedProfit = profit
+		}
+	}
+}
+
+// newTradeStats преобразует статистику хранилища в представление для веб-интерфейса
+func newTradeStats(hedgeStats *entities.HedgeStats) TradeStats {
+	stats := TradeStats{
+		Total:          hedgeStats.Total,
+		Active:         hedgeStats.Active,
+		Completed:      hedgeStats.Completed,
+		TotalProfit:    hedgeStats.RealizedProfit,
+		TotalOrderSize: hedgeStats.OrderSize,
+		TotalNetProfit: hedgeStats.NetProfit,
+		TotalFees:      hedgeStats.Fees,
+		Wins:           hedgeStats.Wins,
+		Losses:         hedgeStats.Losses,
+		WinRate:        hedgeStats.WinRate(),
+		AvgHoldSeconds: hedgeStats.AvgHoldTime.Seconds(),
+		ByVersion:      make([]VersionStats, 0, len(hedgeStats.ByVersion)),
+	}
+	for _, version := range hedgeStats.ByVersion {
+		stats.ByVersion = append(stats.ByVersion, VersionStats{
+			Version:     version.Version,
+			Total:       version.Total,
+			Completed:   version.Completed,
+			TotalProfit: version.RealizedProfit,
+		})
+	}
 	return stats
 }
 
+// unrealizedProfit возвращает плавающую прибыль открытых хеджей, подходящих под фильтры выборки.
+// Загружаются только хеджи с выставленным тейк-профитом - их немного по сравнению со всей историей
+func (s *Server) unrealizedProfit(ctx context.Context, query *entities.HedgeTradeQuery) float64 {
+	if s.priceFeed == nil {
+		return 0
+	}
+
+	var open []*entities.HedgedTrade
+	for _, status := range []entities.OrderStatus{entities.OrderStatusPending, entities.OrderStatusPartiallyFilled} {
+		statusStr := status.String()
+		if query.Status != nil && *query.Status != statusStr {
+			continue
+		}
+		trades, err := s.hedgeRepo.GetHedgedTrades(ctx, &statusStr)
+		if err != nil {
+			log.Printf("⚠️ Ошибка получения открытых хеджей для плавающей прибыли: %v", err)
+			return 0
+		}
+		for _, trade := range trades {
+			if query.Matches(trade) {
+				open = append(open, trade)
+			}
+		}
+	}
+
+	views := s.convertToTradeViews(open)
+	s.applyUnrealizedProfit(ctx, views, open)
+
+	total := 0.0
+	for _, view := range views {
+		if view.UnrealizedProfit != nil {
+			total += *view.UnrealizedProfit
+		}
+	}
+	return total
+}
+
 // sendJSON отправляет JSON ответ
 func (s *Server) sendJSON(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
+	if response, ok := data.(APIResponse); ok {
+		response.Message = s.tr(response.Message)
+		data = response
+	}
 
 	if err := json.NewEncoder(w).Encode(data); err != nil {
 		http.Error(w, "Ошибка кодирования JSON", http.StatusInternalServerError)
@@ -354,7 +816,7 @@ func (s *Server) sendError(w http.ResponseWriter, message string, code int) {
 
 	response := APIResponse{
 		Success: false,
-		Message: message,
+		Message: s.tr(message),
 	}
 
 	json.NewEncoder(w).Encode(response)
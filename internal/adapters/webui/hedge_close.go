@@ -0,0 +1,113 @@
+package webui
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/usecases"
+)
+
+// hedgesPathPrefix префикс действий над отдельным хеджем: /api/hedges/{order_id}/{action}
+const hedgesPathPrefix = "/api/hedges/"
+
+// HedgeCloseRequest тело POST /api/hedges/{order_id}/close
+type HedgeCloseRequest struct {
+	Sell bool `json:"sell"` // Продать позицию (false - только отменить ордера, монеты остаются на балансе)
+}
+
+// HedgeCloseView результат ручного закрытия хеджа
+type HedgeCloseView struct {
+	FreqtradeTradeID int      `json:"freqtrade_trade_id"`
+	Pair             string   `json:"pair"`
+	OrderID          string   `json:"order_id"`
+	Action           string   `json:"action"` // closed, cancelled, skipped, failed
+	ClosePrice       *float64 `json:"close_price"`
+	Profit           *float64 `json:"profit"`
+	Reason           string   `json:"reason,omitempty"`
+}
+
+// handleAPIHedgeAction маршрутизирует действия над хеджем по ID ордера
+func (s *Server) handleAPIHedgeAction(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, hedgesPathPrefix), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		s.sendError(w, "Не найдено", http.StatusNotFound)
+		return
+	}
+
+	switch parts[1] {
+	case "close":
+		s.handleAPIHedgeClose(w, r, parts[0])
+	default:
+		s.sendError(w, "Не найдено", http.StatusNotFound)
+	}
+}
+
+// handleAPIHedgeClose API ручного закрытия хеджа: отменяет тейк-профит (или покупку в ожидании)
+// и при {"sell": true} продает позицию. Хедж переводится в статус CLOSED_MANUAL
+func (s *Server) handleAPIHedgeClose(w http.ResponseWriter, r *http.Request, orderID string) {
+	if r.Method != http.MethodPost {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.hedgeCloser == nil {
+		s.sendError(w, "Ручное закрытие хеджей не настроено", http.StatusServiceUnavailable)
+		return
+	}
+	if s.lease != nil && !s.lease.CanManageOrders() {
+		s.sendError(w, "Экземпляр не держит аренду: ордерами управляет другой экземпляр", http.StatusConflict)
+		return
+	}
+	finish, ok := s.startCycle()
+	if !ok {
+		s.sendError(w, "Цикл стратегии уже выполняется: повторите запуск после его завершения", http.StatusConflict)
+		return
+	}
+	defer finish()
+
+	var req HedgeCloseRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.sendError(w, "Некорректный формат запроса", http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := s.hedgeCloser.CloseHedge(r.Context(), orderID, req.Sell)
+	if err != nil {
+		if strategyErr, ok := err.(*errors.StrategyError); ok && strategyErr.Type == errors.ErrorTypeHedgeNotFound {
+			s.sendError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		s.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	view := HedgeCloseView{
+		FreqtradeTradeID: result.TradeID,
+		Pair:             result.Pair,
+		OrderID:          result.OrderID,
+		Action:           result.Action,
+		ClosePrice:       result.ClosePrice,
+		Profit:           result.Profit,
+		Reason:           result.Reason,
+	}
+	response := APIResponse{Success: true, Data: view}
+	switch result.Action {
+	case usecases.FlatActionClosed:
+		response.Message = "Хедж закрыт, позиция продана"
+	case usecases.FlatActionCancelled:
+		response.Message = "Покупка отменена, позиции нет"
+		if result.Reason != "" {
+			response.Message = "Хедж закрыт без продажи: " + result.Reason
+		}
+	case usecases.FlatActionSkipped:
+		response.Success = false
+		response.Message = "Хедж не закрыт: " + result.Reason
+	default:
+		response.Success = false
+		response.Message = "Ошибка закрытия хеджа: " + result.Reason
+	}
+	s.sendJSON(w, response)
+}
@@ -0,0 +1,96 @@
+package webui
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/usecases"
+)
+
+// hedgeGroupsDefaultLimit количество хедж-групп в ответе по умолчанию
+const hedgeGroupsDefaultLimit = 50
+
+// HedgeGroupView хедж-группа крупной сделки со сводкой по ногам для веб-интерфейса
+type HedgeGroupView struct {
+	ID               int64     `json:"id"`
+	FreqtradeTradeID int       `json:"freqtrade_trade_id"`
+	Pair             string    `json:"pair"`
+	Status           string    `json:"status"`
+	StatusReason     string    `json:"status_reason"`
+	TotalAmount      float64   `json:"total_amount"`
+	LegAmount        float64   `json:"leg_amount"`
+	LegsPlanned      int       `json:"legs_planned"`
+	LegsPlaced       int       `json:"legs_placed"`
+	TakeProfitPrice  float64   `json:"take_profit_price"` // Общий тейк-профит ног (0 - еще не выставлен)
+	NextLegAt        time.Time `json:"next_leg_at"`
+	CreatedAt        time.Time `json:"created_at"`
+
+	*usecases.HedgeGroupSummary
+}
+
+// WithHedgeGroups подключает хедж-группы крупных сделок
+func (s *Server) WithHedgeGroups(repo repositories.HedgeGroupRepository) *Server {
+	s.hedgeGroupRepo = repo
+	return s
+}
+
+// handleAPIHedgeGroups API хедж-групп: /api/hedge-groups?limit=N - последние группы (новые первыми)
+// с прогрессом размещения ног, общим тейк-профитом и сводкой по купленному количеству
+func (s *Server) handleAPIHedgeGroups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.hedgeGroupRepo == nil {
+		s.sendError(w, "Хедж-группы недоступны: нужен PostgreSQL", http.StatusServiceUnavailable)
+		return
+	}
+
+	limit := hedgeGroupsDefaultLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			s.sendError(w, "Некорректный параметр limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	ctx := r.Context()
+	groups, err := s.hedgeGroupRepo.GetHedgeGroups(ctx, limit)
+	if err != nil {
+		log.Printf("❌ Ошибка получения хедж-групп: %v", err)
+		s.sendError(w, "Ошибка получения хедж-групп", http.StatusInternalServerError)
+		return
+	}
+
+	views := make([]HedgeGroupView, 0, len(groups))
+	for _, group := range groups {
+		legs, err := s.hedgeGroupRepo.GetHedgeGroupLegs(ctx, group.ID)
+		if err != nil {
+			log.Printf("❌ Ошибка получения ног хедж-группы %d: %v", group.ID, err)
+			s.sendError(w, "Ошибка получения ног хедж-группы", http.StatusInternalServerError)
+			return
+		}
+		views = append(views, HedgeGroupView{
+			ID:                group.ID,
+			FreqtradeTradeID:  group.FreqtradeTradeID,
+			Pair:              group.Pair,
+			Status:            group.Status.String(),
+			StatusReason:      group.StatusReason,
+			TotalAmount:       group.TotalAmount,
+			LegAmount:         group.LegAmount,
+			LegsPlanned:       group.LegsPlanned,
+			LegsPlaced:        group.LegsPlaced,
+			TakeProfitPrice:   group.TakeProfitPrice,
+			NextLegAt:         group.NextLegAt,
+			CreatedAt:         group.CreatedAt,
+			HedgeGroupSummary: usecases.SummarizeHedgeGroup(legs),
+		})
+	}
+
+	s.sendJSON(w, APIResponse{Success: true, Data: views})
+}
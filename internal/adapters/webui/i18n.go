@@ -0,0 +1,65 @@
+package webui
+
+import (
+	"regexp"
+	"strings"
+
+	"trade-hedge/internal/infrastructure/config"
+)
+
+// Переводы строк страниц и сообщений API. Исходный язык - русский: ключ перевода - русская строка,
+// строки без перевода показываются как есть. Числа в сообщениях заменяются на {n}, поэтому
+// "Сделка 42 хеджирована" переводится по ключу "Сделка {n} хеджирована"
+var translations = map[string]map[string]string{
+	config.WebUILanguageEN: englishTranslations,
+}
+
+// numberPattern числа в сообщениях (ID сделок, количества, диапазоны)
+var numberPattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+// translate переводит строку на язык lang (пустой язык и ru - без перевода)
+func translate(lang, text string) string {
+	dictionary := translations[lang]
+	if dictionary == nil || text == "" {
+		return text
+	}
+	if translated, ok := dictionary[text]; ok {
+		return translated
+	}
+
+	// Сообщение с числами: переводим шаблон и подставляем числа обратно по порядку
+	if numbers := numberPattern.FindAllString(text, -1); len(numbers) > 0 {
+		if translated, ok := dictionary[numberPattern.ReplaceAllString(text, "{n}")]; ok {
+			for _, number := range numbers {
+				translated = strings.Replace(translated, "{n}", number, 1)
+			}
+			return translated
+		}
+	}
+
+	// Сообщение с подробностями ошибки: "Ошибка получения балансов: <текст ошибки>"
+	if head, tail, found := strings.Cut(text, ": "); found {
+		if translated := translate(lang, head); translated != head {
+			return translated + ": " + tail
+		}
+	}
+	return text
+}
+
+// translationsFor словарь языка для перевода строк в скриптах страниц (nil - без перевода)
+func translationsFor(lang string) map[string]string {
+	return translations[lang]
+}
+
+// language язык страниц и сообщений API
+func (s *Server) language() string {
+	if s.webUIConfig.Language == "" {
+		return config.WebUILanguageRU
+	}
+	return s.webUIConfig.Language
+}
+
+// tr переводит строку на язык веб-интерфейса
+func (s *Server) tr(text string) string {
+	return translate(s.language(), text)
+}
@@ -0,0 +1,539 @@
+package webui
+
+// englishTranslations перевод страниц и сообщений API на английский (webui.language: en)
+var englishTranslations = map[string]string{
+	// Меню и страница входа
+	"Дашборд":               "Dashboard",
+	"Сделки":                "Trades",
+	"Журнал":                "Journal",
+	"Аналитика":             "Analytics",
+	"Аудит":                 "Audit",
+	"Балансы":               "Balances",
+	"Конфигурация":          "Configuration",
+	"Флаги":                 "Flags",
+	"Логи":                  "Logs",
+	"Светлая / темная тема": "Light / dark theme",
+	"Выход":                 "Log out",
+	"Вход":                  "Sign in",
+	"Имя пользователя":      "Username",
+	"Пароль":                "Password",
+	"Войти":                 "Sign in",
+
+	// Дашборд
+	"Дашборд хеджирования":                     "Hedging dashboard",
+	"Мониторинг активных позиций и статистика": "Active positions and statistics",
+	"Хеджирование на паузе":                    "Hedging paused",
+	"Выполняется цикл":                         "Cycle running",
+	"Хеджирование активно":                     "Hedging active",
+	"Возобновить":                              "Resume",
+	"Пауза":                                    "Pause",
+	"Всего хеджированных":                      "Total hedged",
+	"Активные ордера":                          "Active orders",
+	"Закрытые ордера":                          "Closed orders",
+	"Общая прибыль":                            "Total profit",
+	"Комиссии: ":                               "Fees: ",
+	"После комиссий: ":                         "After fees: ",
+	"Плавающая: ":                              "Unrealized: ",
+	"Ошибка запроса":                           "Query error",
+	"Баланс Bybit":                             "Bybit balance",
+	"Биржа недоступна. Баланс на":              "Exchange unavailable. Balance as of",
+	"Всего:":                                   "Total:",
+	"Обновить баланс":                          "Refresh balance",
+	"Обновляется...":                           "Refreshing...",
+	"Выполнить хеджирование":                   "Run hedging",
+	"Выполняется...":                           "Running...",
+	"Проверить статусы ордеров":                "Check order statuses",
+	"Проверяется...":                           "Checking...",
+	"Статус системы":                           "System status",
+	"База данных":                              "Database",
+	"Подключена":                               "Connected",
+	"Последняя проверка":                       "Last check",
+	"Автопроверка":                             "Auto check",
+	"Активна":                                  "Active",
+	"Занятый капитал":                          "Locked capital",
+	"из":                                       "of",
+	"Пара":                                     "Pair",
+	"Статус":                                   "Status",
+	"Стоимость входа":                          "Entry cost",
+	"Доля капитала":                            "Bankroll share",
+	"Возраст":                                  "Age",
+	"Прибыль хеджей":                           "Hedge profit",
+	"По дням":                                  "Daily",
+	"По неделям":                               "Weekly",
+	"30 дней":                                  "30 days",
+	"90 дней":                                  "90 days",
+	"Год":                                      "Year",
+	"Последние хеджированные сделки":           "Recent hedged trades",
+	"Время":                                    "Time",
+	"Прибыль":                                  "Profit",
+	"Размер ордера":                            "Order size",
+	"Профит %":                                 "Profit %",
+	"Хеджирование выполнено успешно!":          "Hedging completed successfully!",
+	"Ошибка выполнения":                        "Execution error",
+	"Ошибка выполнения: ":                      "Execution error: ",
+	"Пауза с {0} ({1}){2}. Статусы открытых хеджей проверяются": "Paused since {0} ({1}){2}. Open hedge statuses are still checked",
+	"Следующий цикл: ":                   "Next cycle: ",
+	"Ошибка управления планировщиком":    "Scheduler control error",
+	"Ошибка управления планировщиком: ":  "Scheduler control error: ",
+	"Статусы обновлены: {0} ордеров":     "Statuses updated: {0} orders",
+	"Ошибка проверки":                    "Check error",
+	"Ошибка проверки: ":                  "Check error: ",
+	"Исполнен":                           "Filled",
+	"Ожидает":                            "Pending",
+	"Покупка":                            "Buy",
+	"Отменен":                            "Cancelled",
+	"Отклонен":                           "Rejected",
+	"Закрыт вручную":                     "Closed manually",
+	"Неизвестно":                         "Unknown",
+	"Библиотека графиков не загружена":   "Chart library not loaded",
+	"Графики прибыли недоступны":         "Profit charts unavailable",
+	"Накопленная прибыль":                "Cumulative profit",
+	"Накопленная прибыль после комиссий": "Cumulative profit after fees",
+	"Закрыто хеджей":                     "Hedges closed",
+	"Закрыто хеджей (красным - убыточный интервал)": "Hedges closed (red - losing interval)",
+	"За интервал":               "Per interval",
+	"Накопленная":               "Cumulative",
+	"Доля прибыльных хеджей, %": "Winning hedges, %",
+	" мин":            " min",
+	" ч":              " h",
+	" дн":             " d",
+	"Баланс обновлен": "Balance refreshed",
+	"Ошибка обновления баланса: ": "Balance refresh error: ",
+
+	// Сделки
+	"Хеджированные сделки": "Hedged trades",
+	"Показываются все сделки. Используйте фильтры для ограничения результатов.": "All trades are shown. Use filters to narrow the results.",
+	"Источник цен недоступен: текущие цены из снимка от":                        "Price source unavailable: current prices from the snapshot of",
+	"Фильтры":          "Filters",
+	"Все сделки":       "All trades",
+	"Валютная пара":    "Currency pair",
+	"Все пары":         "All pairs",
+	"Версия стратегии": "Strategy version",
+	"Все версии":       "All versions",
+	"Дата от":          "Date from",
+	"Дата до":          "Date to",
+	"Очистить фильтры": "Clear filters",
+	"Хеджи, закрытые раньше срока хранения archive.retention_days": "Hedges closed before the archive.retention_days retention period",
+	"Архив":              "Archive",
+	"Сортировка":         "Sorting",
+	"Время хеджирования": "Hedge time",
+	"Время закрытия":     "Close time",
+	"По убыванию":        "Descending",
+	"По возрастанию":     "Ascending",
+	"Найдено:":           "Found:",
+	"Цена Freqtrade":     "Freqtrade price",
+	"Хедж (покупка)":     "Hedge (buy)",
+	"План (продажа)":     "Plan (sell)",
+	"Факт (продажа)":     "Actual (sell)",
+	"Кол-во":             "Qty",
+	"Прибыль (план)":     "Profit (plan)",
+	"Прибыль (факт)":     "Profit (actual)",
+	"Действия":           "Actions",
+	"Все хеджи и события ордеров сделки": "All hedges and order events of the trade",
+	"Закрыто:":           "Closed:",
+	"Цена покупки":       "Buy price",
+	"Лимитный ордер":     "Limit order",
+	"Текущая цена $":     "Current price $",
+	"До TP:":             "To TP:",
+	"Ордер стоп-лосса: ": "Stop-loss order: ",
+	"Стоп-лосс эмулируется проверкой статусов": "Stop-loss is emulated by status checks",
+	"OCO стоп: $":         "OCO stop: $",
+	"Цена продажи":        "Sell price",
+	"Размер позиции":      "Position size",
+	"Комиссии: покупка $": "Fees: buy $",
+	", продажа $":         ", sell $",
+	"После комиссий:":     "After fees:",
+	"Плавающая прибыль по текущей цене $": "Unrealized profit at current price $",
+	"Плавающая": "Unrealized",
+	"Хеджировать сделку Freqtrade сейчас":               "Hedge the Freqtrade trade now",
+	"Закрыть хедж: отменить ордера и продать позицию":   "Close hedge: cancel orders and sell the position",
+	"Отменить ордера хеджа, монеты оставить на балансе": "Cancel hedge orders, keep the coins on the balance",
+	"Предыдущая":    "Previous",
+	"Следующая":     "Next",
+	"Показано":      "Showing",
+	"результатов":   "results",
+	"{0} мин назад": "{0} min ago",
+	"{0} ч назад":   "{0} h ago",
+	"{0} дн назад":  "{0} d ago",
+	"Хеджировать сделку Freqtrade #{0} ({1}) сейчас, не дожидаясь порога просадки?": "Hedge Freqtrade trade #{0} ({1}) now without waiting for the drawdown threshold?",
+	"Сделка хеджирована":                                     "Trade hedged",
+	"Ошибка хеджирования":                                    "Hedging error",
+	"отменить ордера и продать позицию":                      "cancel orders and sell the position",
+	"отменить ордера, купленные монеты останутся на балансе": "cancel orders, bought coins stay on the balance",
+	"Закрыть хедж {0} (сделка #{1}): {2}?":                   "Close hedge {0} (trade #{1}): {2}?",
+	"Хедж закрыт":                                            "Hedge closed",
+	"Ошибка закрытия хеджа":                                  "Hedge close error",
+
+	// Страница сделки
+	"Сделка #": "Trade #",
+	"Сделка Freqtrade, все ее хеджи и события ордеров": "Freqtrade trade, all its hedges and order events",
+	"Обновить":         "Refresh",
+	"Сделка Freqtrade": "Freqtrade trade",
+	"Открыта":          "Open",
+	"Закрыта":          "Closed",
+	"Сделка не найдена в Freqtrade: показаны данные на момент первого хеджа": "Trade not found in Freqtrade: showing data as of the first hedge",
+	"Цена открытия":                 "Open price",
+	"Текущая цена":                  "Current price",
+	"Количество":                    "Amount",
+	"Прибыль, %":                    "Profit, %",
+	"Просадка при хеджировании, %":  "Drawdown at hedging, %",
+	"Итог с учетом хеджей":          "Outcome including hedges",
+	"Сделка (плавающая)":            "Trade (unrealized)",
+	"Сделка":                        "Trade",
+	"Закрытые хеджи после комиссий": "Closed hedges after fees",
+	"Открытые хеджи (плавающая)":    "Open hedges (unrealized)",
+	"Комиссии хеджей":               "Hedge fees",
+	"Хеджей (открыто / закрыто)":    "Hedges (open / closed)",
+	"Итог":                     "Net outcome",
+	"Хеджи (":                  "Hedges (",
+	"Цена входа":               "Entry price",
+	"Тейк-профит":              "Take profit",
+	"Стоп-лосс":                "Stop loss",
+	"Закрытие":                 "Close",
+	"Комиссии":                 "Fees",
+	"Хедж-группа ":             "Hedge group ",
+	"Частичное исполнение":     "Partial fill",
+	"плавающая":                "unrealized",
+	"События ордеров":          "Order events",
+	"Событий нет":              "No events",
+	"хеджа #":                  "of hedge #",
+	"по ":                      "at ",
+	", исполнено ":             ", filled ",
+	"Ошибка загрузки сделки":   "Trade loading error",
+	"Ошибка загрузки сделки: ": "Trade loading error: ",
+
+	// Аналитика
+	"Тепловая карта хеджирования":         "Hedging heatmap",
+	"Как часто просадка пересекала порог": "How often the drawdown crossed the threshold",
+	"по парам и часам суток (UTC) и как затем двигалась цена. Помогает подобрать MaxLossPercent для каждой пары.": "by pair and hour of day (UTC) and how the price moved afterwards. Helps choose MaxLossPercent for each pair.",
+	"Дней":        "Days",
+	"Горизонт, ч": "Horizon, h",
+	"Недостаточно истории наблюдений за выбранный период": "Not enough observation history for the selected period",
+	"Задержки хеджирования":                               "Hedging latency",
+	"Сколько проходит от цикла, в котором просадка впервые превысила порог, до ордера на покупку и от исполнения покупки до тейк-профита, и как за это время менялась цена входа (": "Time from the cycle in which the drawdown first exceeded the threshold to the buy order and from the buy fill to the take profit, and how the entry price changed meanwhile (",
+	"хеджей за период).": "hedges in the period).",
+	"Показатель":         "Metric",
+	"Хеджей":             "Hedges",
+	"Мин.":               "Min",
+	"Среднее":            "Average",
+	"Макс.":              "Max",
+	"Качество выхода":    "Exit quality",
+	"Какую долю роста от цены открытия до максимума за время удержания (по часовым свечам) забрал выход, и насколько максимум был выше цены выхода. Высокий упущенный рост у тейк-профитов говорит в пользу скользящего тейк-профита или лестницы выходов.": "Which share of the rise from the open price to the maximum during the holding period (hourly candles) the exit captured, and how far the maximum was above the exit price. High missed upside on take profits favors a trailing take profit or an exit ladder.",
+	"Закрытых хеджей за выбранный период нет": "No closed hedges in the selected period",
+	"Группа":                   "Group",
+	"Эффективность, ср.":       "Efficiency, avg",
+	"Эффективность, p50":       "Efficiency, p50",
+	"Упущено, ср.":             "Missed, avg",
+	"Упущено, p90":             "Missed, p90",
+	"Рост после тейк-профита":  "Rise after take profit",
+	"История ордеров аккаунта": "Account order history",
+	"Исполненные ордера по парам за тот же период, включая ручную торговлю до запуска бота.": "Filled orders by pair for the same period, including manual trading before the bot started.",
+	"Импортированных ордеров за выбранный период нет":                                        "No imported orders in the selected period",
+	"Ордеров":                                  "Orders",
+	"Покупки":                                  "Buys",
+	"Продажи":                                  "Sells",
+	"Ср. цена покупки":                         "Avg buy price",
+	"Ср. цена продажи":                         "Avg sell price",
+	"Последний ордер":                          "Last order",
+	"Пересечения порога":                       "Threshold crossings",
+	"Средний отскок, %":                        "Average rebound, %",
+	"Изменение цены, %":                        "Price change, %",
+	"Ошибка загрузки тепловой карты":           "Heatmap loading error",
+	"По рынку":                                 "Market",
+	"Все хеджи":                                "All hedges",
+	"Порог → покупка":                          "Threshold → buy",
+	" с":                                       " s",
+	"Исполнение → тейк-профит":                 "Fill → take profit",
+	"Изменение цены входа":                     "Entry price change",
+	"Наблюдений: {0}, пересечений: {1}":        "Observations: {0}, crossings: {1}",
+	"Средняя просадка: {0}%":                   "Average drawdown: {0}%",
+	"Средний отскок: {0}%, изменение: {1}%":    "Average rebound: {0}%, change: {1}%",
+	"Тейк-профит был бы достигнут: {0} из {1}": "Take profit would have been reached: {0} of {1}",
+
+	// Балансы
+	"Балансы биржи": "Exchange balances",
+	"Все валюты кошелька UNIFIED и запас на новые хеджи": "All UNIFIED wallet coins and headroom for new hedges",
+	"Биржа недоступна. Балансы на":                       "Exchange unavailable. Balances as of",
+	"Доступно,":                  "Available,",
+	"Запас на новые хеджи":       "Headroom for new hedges",
+	"Всего в USD":                "Total in USD",
+	"Валюты":                     "Coins",
+	"Скрыть меньше $1":           "Hide below $1",
+	"Валюта":                     "Coin",
+	"Доступно":                   "Available",
+	"Всего":                      "Total",
+	"В ордерах":                  "In orders",
+	"В хеджах":                   "In hedges",
+	"Нет балансов":               "No balances",
+	"Ошибка загрузки балансов":   "Balances loading error",
+	"Ошибка загрузки балансов: ": "Balances loading error: ",
+
+	// Конфигурация
+	"Конфигурация системы":              "System configuration",
+	"Параметры стратегии и подключений": "Strategy and connection settings",
+	"Параметры стратегии":               "Strategy settings",
+	"Сумма позиции":                     "Position amount",
+	"Максимальный убыток":               "Maximum loss",
+	"Коэффициент прибыли":               "Profit ratio",
+	"Базовая валюта":                    "Base currency",
+	"Интервал проверки":                 "Check interval",
+	"Одноразовое выполнение":            "Single run",
+	"сек": "s",
+	"Внимание: Минимальный лимит ордера": "Warning: minimum order limit",
+	"может быть меньше минимального лимита Bybit (5 USDT). Рекомендуется увеличить до минимум $100 для надежности.": "may be below the Bybit minimum (5 USDT). Increasing it to at least $100 is recommended for reliability.",
+	"Подключения":              "Connections",
+	"Подключено":               "Connected",
+	"Веб-интерфейс":            "Web UI",
+	"Редактирование стратегии": "Edit strategy",
+	"Измененные значения проверяются той же валидацией, что и при запуске. Параметры с отметкой «сразу» сохраняются в БД и действуют с начала следующего цикла, остальные записываются в файл конфигурации и вступают в силу после перезапуска.": "Changed values go through the same validation as at startup. Settings marked \"immediately\" are stored in the database and apply from the next cycle; the rest are written to the configuration file and take effect after a restart.",
+	"сразу":                     "immediately",
+	"после перезапуска":         "after restart",
+	"· в файле: ":               "· in file: ",
+	"Сохранить изменения":       "Save changes",
+	"Отменить":                  "Cancel",
+	"Изменено параметров: ":     "Changed settings: ",
+	"Параметры во время работы": "Runtime settings",
+	"Сохраненные значения переопределяют файл конфигурации, действуют с начала следующего цикла и сохраняются после перезапуска. Временное значение (например, порог убытка во время обвала) по окончании срока автоматически сменяется прежним.": "Saved values override the configuration file, apply from the next cycle and survive restarts. A temporary value (for example, a loss threshold during a crash) automatically reverts when it expires.",
+	"в файле: ":         "in file: ",
+	" · изменен ":       " · changed ",
+	"временно ":         "temporarily ",
+	" до ":              " until ",
+	", затем ":          ", then ",
+	" (файл)":           " (file)",
+	"Сохранить":         "Save",
+	"Часов":             "Hours",
+	"На время, ч":       "For, h",
+	"Сбросить":          "Reset",
+	"Журнал изменений":  "Change log",
+	"файл":              "file",
+	"до ":               "until ",
+	"История изменений": "Change history",
+	"Каждая примененная конфигурация сохраняется с автором и отличиями от предыдущей (секреты скрыты). Откат записывает выбранную версию в файл конфигурации, она вступает в силу после перезапуска.": "Every applied configuration is stored with its author and the diff from the previous one (secrets hidden). Rollback writes the selected version to the configuration file; it takes effect after a restart.",
+	"Истории пока нет":     "No history yet",
+	"откат к #":            "rollback to #",
+	"текущая":              "current",
+	"Изменения":            "Changes",
+	"Откатить":             "Roll back",
+	"Первая версия":        "First version",
+	"Переменные окружения": "Environment variables",
+	"Вы можете переопределить любые настройки с помощью переменных окружения:": "Any setting can be overridden with environment variables:",
+	"Логика хеджирования":                                  "Hedging logic",
+	"Система автоматически выполняет следующие шаги:":      "The system automatically performs the following steps:",
+	"Получает активные сделки из Freqtrade API":            "Fetches active trades from the Freqtrade API",
+	"Фильтрует уже хеджированные позиции":                  "Filters out already hedged positions",
+	"Находит сделки с убытком больше":                      "Finds trades with a loss greater than",
+	"Проверяет наличие средств в базовой валюте (":         "Checks funds in the base currency (",
+	"Рассчитывает количество для фиксированной суммы":      "Calculates the quantity for the fixed amount",
+	"Размещает рыночный ордер на покупку":                  "Places a market buy order",
+	"Устанавливает тейк-профит с коэффициентом":            "Sets the take profit with ratio",
+	"Размещает лимитный ордер на продажу":                  "Places a limit sell order",
+	"Сохраняет всю информацию в базе данных":               "Stores all data in the database",
+	"Отслеживает статус ордеров до их закрытия":            "Tracks order status until closed",
+	"Меры безопасности":                                    "Safety measures",
+	"Валидация всех параметров конфигурации при запуске":   "Validation of all configuration settings at startup",
+	"Проверка баланса перед размещением каждого ордера":    "Balance check before every order",
+	"Предотвращение повторного хеджирования той же сделки": "Protection against hedging the same trade twice",
+	"Автоматический расчет с учетом проскальзывания (+1%)": "Automatic slippage allowance (+1%)",
+	"Graceful shutdown с ожиданием завершения операций":    "Graceful shutdown waiting for operations to finish",
+	"Детальное логирование всех операций и ошибок":         "Detailed logging of all operations and errors",
+	"Сохранить изменения параметров стратегии?":            "Save strategy setting changes?",
+	"Ошибка сохранения конфигурации":                       "Configuration save error",
+	"Установить {0} = {1} на {2} ч?":                       "Set {0} = {1} for {2} h?",
+	"Сбросить {0} к значению из файла конфигурации?":       "Reset {0} to the configuration file value?",
+	"Ошибка сохранения параметра":                          "Setting save error",
+	"Откатить конфигурацию к версии #{0}?":                 "Roll back the configuration to version #{0}?",
+	"Ошибка отката конфигурации":                           "Configuration rollback error",
+
+	// Флаги возможностей
+	"Флаги возможностей": "Feature flags",
+	"Рискованные возможности включаются и отключаются по отдельности без перезапуска. Переключение в интерфейсе переопределяет раздел": "Risky features are enabled and disabled individually without a restart. Toggling in the UI overrides the",
+	"конфигурации и сохраняется после перезапуска.": "configuration section and survives restarts.",
+	"Версия стратегии: ":                            "Strategy version: ",
+	"Флаги недоступны":                              "Flags unavailable",
+	"источник: ":                                    "source: ",
+	" · по умолчанию: ":                             " · default: ",
+	"включен":                                       "enabled",
+	"выключен":                                      "disabled",
+	" · переключен ":                                " · toggled ",
+	"Выключить":                                     "Disable",
+	"Включить":                                      "Enable",
+	"Журнал переключений":                           "Toggle log",
+	"Флаги еще не переключались":                    "No flags toggled yet",
+	"сброс к конфигурации":                          "reset to configuration",
+	"по умолчанию":                                  "default",
+	"конфигурация":                                  "configuration",
+	"веб-интерфейс":                                 "web UI",
+	"Выключить «{0}»?":                              "Disable \"{0}\"?",
+	"Включить «{0}»?":                               "Enable \"{0}\"?",
+	"Ошибка переключения флага":                     "Flag toggle error",
+
+	// Торговый журнал
+	"Торговый журнал": "Trading journal",
+	"Заметки о решениях, привязанные к дате или хеджу. Включаются в экспорт сделок.": "Decision notes tied to a date or a hedge. Included in the trade export.",
+	"Сделки CSV":   "Trades CSV",
+	"Журнал CSV":   "Journal CSV",
+	"Новая запись": "New entry",
+	"Дата":         "Date",
+	"ID ордера хеджа (необязательно)":                "Hedge order ID (optional)",
+	"Оставьте пустым, чтобы привязать запись к дате": "Leave empty to tie the entry to the date",
+	"Почему было принято решение...":                 "Why the decision was made...",
+	"Добавить":                   "Add",
+	"Записей пока нет":           "No entries yet",
+	"Ошибка сохранения записи":   "Entry save error",
+	"Удалить запись из журнала?": "Delete the journal entry?",
+
+	// Логи
+	"Последние строки лога процесса (хранятся в памяти:": "Latest process log lines (kept in memory:",
+	"строк)": "lines)",
+	"Все":    "All",
+	"Предупреждения и ошибки": "Warnings and errors",
+	"Только ошибки":           "Errors only",
+	"200 строк":               "200 lines",
+	"1000 строк":              "1000 lines",
+	"5000 строк":              "5000 lines",
+	"Поиск":                   "Search",
+	"Обновлять":               "Follow",
+	"Нет строк":               "No lines",
+	"Ошибка загрузки лога":    "Log loading error",
+	"Ошибка загрузки лога: ":  "Log loading error: ",
+
+	// Тихие часы планировщика
+	"Тихие часы": "Quiet hours",
+	"Тихие часы до {0}: новые хеджи не открываются, статусы открытых хеджей проверяются": "Quiet hours until {0}: no new hedges, open hedge statuses are still checked",
+
+	// Журнал аудита
+	"Журнал аудита": "Audit log",
+	"Ручные действия в веб-интерфейсе и API: кто, когда, с какими параметрами и с каким итогом": "Manual actions in the web interface and API: who, when, with which parameters and with what outcome",
+	"Все действия":    "All actions",
+	"Любой результат": "Any result",
+	"Успешно":         "Succeeded",
+	"Ошибка":          "Failed",
+	"Пользователь":    "User",
+	"Действие":        "Action",
+	"Параметры":       "Parameters",
+	"Результат":       "Result",
+	"Ручных действий за период нет":    "No manual actions in the period",
+	"Запуск стратегии":                 "Strategy run",
+	"Проверка статусов":                "Status check",
+	"Ручной хедж":                      "Manual hedge",
+	"Закрытие хеджа":                   "Hedge close",
+	"Изменение конфигурации":           "Configuration change",
+	"Откат конфигурации":               "Configuration rollback",
+	"Изменение параметров":             "Settings change",
+	"Переключение флага":               "Flag toggle",
+	"Пауза хеджирования":               "Hedging pause",
+	"Снятие паузы":                     "Hedging resume",
+	"Завершение экземпляра":            "Instance drain",
+	"Ошибка загрузки журнала аудита":   "Audit log loading error",
+	"Ошибка загрузки журнала аудита: ": "Audit log loading error: ",
+
+	// Сообщения API
+	"Недостаточно прав: действие доступно ролям operator и admin": "Insufficient permissions: the action is available to the operator and admin roles",
+	"Недействительный CSRF токен: обновите страницу":              "Invalid CSRF token: reload the page",
+	"Недостаточно прав: действие доступно роли admin":             "Insufficient permissions: the action is available to the admin role",
+	"Метод не поддерживается":                                     "Method not allowed",
+	"Некорректный формат запроса":                                 "Invalid request format",
+	"Некорректный JSON запроса":                                   "Invalid request JSON",
+	"Не найдено":               "Not found",
+	"Требуется аутентификация": "Authentication required",
+	"Требуется аутентификация: токен API в заголовке Authorization: Bearer <токен>":                             "Authentication required: API token in the Authorization: Bearer <token> header",
+	"Веб-интерфейс отключен: войдите POST /login с JSON {\"username\", \"password\"} или используйте токен API": "Web UI is disabled: sign in with POST /login and JSON {\"username\", \"password\"} or use an API token",
+	"Веб-интерфейс отключен: доступен только API (/api/...)":                                                    "Web UI is disabled: only the API is available (/api/...)",
+	"Неверное имя пользователя или пароль":                                                                      "Invalid username or password",
+	"Ошибка создания сессии":                                                                                    "Session creation error",
+	"Вход выполнен":  "Signed in",
+	"Выход выполнен": "Signed out",
+	"Стратегия хеджирования выполнена успешно":                                            "Hedging strategy executed successfully",
+	"Ошибка выполнения стратегии хеджирования":                                            "Hedging strategy execution error",
+	"Статусы ордеров проверены":                                                           "Order statuses checked",
+	"Ошибка проверки статусов ордеров":                                                    "Order status check error",
+	"Проверка статусов недоступна в текущем режиме работы":                                "Status check is unavailable in the current mode",
+	"Экземпляр не выполняет роль executor: хеджи открывает другой экземпляр":              "This instance does not have the executor role: hedges are opened by another instance",
+	"Экземпляр не выполняет роль status-checker: статусы проверяет другой экземпляр":      "This instance does not have the status-checker role: statuses are checked by another instance",
+	"Экземпляр не держит аренду: ордерами управляет другой экземпляр":                     "This instance does not hold the lease: orders are managed by another instance",
+	"Экземпляр не держит аренду или завершает работу: новые хеджи не открываются":         "This instance does not hold the lease or is draining: new hedges are not opened",
+	"Экземпляр переведен в режим завершения":                                              "Instance switched to draining",
+	"Аренда экземпляра не настроена":                                                      "Instance lease is not configured",
+	"Ошибка получения аренды":                                                             "Lease loading error",
+	"Планировщик не запущен (strategy.check_interval: 0 или экземпляр без роли executor)": "Scheduler is not running (strategy.check_interval: 0 or instance without the executor role)",
+	"Биржа недоступна, показан последний снимок баланса":                                  "Exchange unavailable, showing the last balance snapshot",
+	"Ошибка получения баланса":                                                            "Balance loading error",
+	"Ошибка получения балансов":                                                           "Balances loading error",
+	"Источник цен не подключен":                                                           "Price source is not connected",
+	"Источник цен недоступен, показаны цены из последнего снимка":                         "Price source unavailable, showing prices from the last snapshot",
+	"Ошибка получения цен":                                                                "Prices loading error",
+	"Слишком много пар в запросе":                                                         "Too many pairs in the request",
+	"Ошибка получения хеджа":                                                              "Hedge loading error",
+	"Ошибка получения хеджей":                                                             "Hedges loading error",
+	"Ошибка получения открытых хеджей":                                                    "Open hedges loading error",
+	"Ошибка получения хеджированных сделок":                                               "Hedged trades loading error",
+	"Ошибка получения истории сделки":                                                     "Trade history loading error",
+	"Ошибка получения событий ордеров":                                                    "Order events loading error",
+	"Ошибка получения сделки":                                                             "Trade loading error",
+	"Некорректный ID сделки Freqtrade":                                                    "Invalid Freqtrade trade ID",
+	"Сделка {n} хеджирована":                                                              "Trade {n} hedged",
+	"Сделка Freqtrade {n} не найдена и не хеджировалась":                                  "Freqtrade trade {n} not found and never hedged",
+	"Хедж {n} не найден":                                                                  "Hedge {n} not found",
+	"Ручное закрытие хеджей не настроено":                                                 "Manual hedge closing is not configured",
+	"Ошибка расчета статистики":                                                           "Statistics calculation error",
+	"Ошибка получения кандидатов на хеджирование":                                         "Hedge candidates loading error",
+	"Расчет эффективности хеджирования недоступен":                                        "Hedge outcome calculation is unavailable",
+	"Ошибка получения итогов хеджирования":                                                "Hedge outcomes loading error",
+	"Аналитика недоступна: база данных не настроена":                                      "Analytics unavailable: database is not configured",
+	"Параметры days (1-365) и horizon (1-168) вне допустимого диапазона":                  "Parameters days (1-365) and horizon (1-168) are out of range",
+	"Параметр days (1-365) вне допустимого диапазона":                                     "Parameter days (1-365) is out of range",
+	"Параметр days (1-730) вне допустимого диапазона":                                     "Parameter days (1-730) is out of range",
+	"Параметр limit (1-5000) вне допустимого диапазона":                                   "Parameter limit (1-5000) is out of range",
+	"Параметр bucket должен быть day или week":                                            "Parameter bucket must be day or week",
+	"Параметр level должен быть info, warn или error":                                     "Parameter level must be info, warn or error",
+	"Ошибка построения тепловой карты":                                                    "Heatmap building error",
+	"История ордеров аккаунта не импортируется":                                           "Account order history is not imported",
+	"Ошибка получения истории ордеров аккаунта":                                           "Account order history loading error",
+	"Отчет о качестве выхода недоступен":                                                  "Exit quality report is unavailable",
+	"Ошибка построения отчета о качестве выхода":                                          "Exit quality report building error",
+	"Ряд прибыли не поддерживается хранилищем":                                            "Profit series is not supported by the storage",
+	"Ошибка получения ряда прибыли":                                                       "Profit series loading error",
+	"Графики прибыли не поддерживаются хранилищем":                                        "Profit charts are not supported by the storage",
+	"Ошибка получения итогов прибыли":                                                     "Profit totals loading error",
+	"Сводки хеджирования не подключены":                                                   "Hedging reports are not enabled",
+	"Ошибка расчета сводки":                                                               "Report building error",
+	"История конфигурации недоступна: база данных не настроена":                           "Configuration history unavailable: database is not configured",
+	"Некорректный id версии":                                                              "Invalid version id",
+	"Ошибка получения истории конфигурации":                                               "Configuration history loading error",
+	"Откат конфигурации недоступен: история или файл конфигурации не настроены":           "Configuration rollback unavailable: history or configuration file is not configured",
+	"Изменение параметров во время работы не настроено":                                   "Runtime settings are not configured",
+	"Ошибка получения журнала параметров":                                                 "Settings log loading error",
+	"Пользовательские метрики не настроены (metrics.custom)":                              "Custom metrics are not configured (metrics.custom)",
+	"Решения по сделкам недоступны: база данных не настроена":                             "Trade decisions unavailable: database is not configured",
+	"Некорректный параметр from":                                                          "Invalid parameter from",
+	"Некорректный параметр to":                                                            "Invalid parameter to",
+	"Некорректный параметр limit":                                                         "Invalid parameter limit",
+	"Некорректный параметр after":                                                         "Invalid parameter after",
+	"from должен быть раньше to":                                                          "from must be earlier than to",
+	"Дата должна быть в формате YYYY-MM-DD":                                               "Date must be in YYYY-MM-DD format",
+	"Ошибка получения решений по сделкам":                                                 "Trade decisions loading error",
+	"Ошибка формирования CSV":                                                             "CSV building error",
+	"Флаги возможностей не настроены":                                                     "Feature flags are not configured",
+	"Ошибка получения журнала флагов":                                                     "Flag log loading error",
+	"Хедж-группы недоступны: нужен PostgreSQL":                                            "Hedge groups unavailable: PostgreSQL is required",
+	"Ошибка получения хедж-групп":                                                         "Hedge groups loading error",
+	"Ошибка получения ног хедж-группы":                                                    "Hedge group legs loading error",
+	"Журнал недоступен: база данных не настроена":                                         "Journal unavailable: database is not configured",
+	"Ошибка получения записей журнала":                                                    "Journal entries loading error",
+	"Текст записи не может быть пустым":                                                   "Entry text cannot be empty",
+	"Ошибка сохранения записи журнала":                                                    "Journal entry save error",
+	"Запись добавлена в журнал":                                                           "Entry added to the journal",
+	"Некорректный ID записи":                                                              "Invalid entry ID",
+	"Ошибка удаления записи журнала":                                                      "Journal entry delete error",
+	"Запись удалена из журнала":                                                           "Entry deleted from the journal",
+	"Лог в памяти не хранится: webui.log_buffer_lines = 0":                                "Log is not kept in memory: webui.log_buffer_lines = 0",
+	"История ордеров недоступна: база данных не настроена":                                "Order history unavailable: database is not configured",
+	"Не указан order_id":                                                                  "order_id is not specified",
+	"Ошибка получения истории ордера":                                                     "Order history loading error",
+	"Прием сигналов отключен (signals.enabled, signals.webhook)":                          "Signal intake is disabled (signals.enabled, signals.webhook)",
+	"Неверный секрет webhook: заголовок X-Signal-Secret или параметр secret":              "Invalid webhook secret: X-Signal-Secret header or secret parameter",
+	"Журнал аудита недоступен: база данных не настроена":                                  "Audit log unavailable: database is not configured",
+	"Ошибка получения журнала аудита":                                                     "Audit log loading error",
+	"Тело запроса больше {n} КБ":                                                          "Request body is larger than {n} KB",
+	"Цикл стратегии уже выполняется: повторите запуск после его завершения":               "A strategy cycle is already running: retry after it finishes",
+}
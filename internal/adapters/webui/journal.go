@@ -0,0 +1,151 @@
+package webui
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+)
+
+// JournalEntryView представление записи журнала для веб-интерфейса
+type JournalEntryView struct {
+	ID           int       `json:"id"`
+	CreatedAt    time.Time `json:"created_at"`
+	EntryDate    string    `json:"entry_date"`
+	HedgeOrderID string    `json:"hedge_order_id,omitempty"`
+	Text         string    `json:"text"`
+}
+
+// JournalEntryRequest запрос на создание записи журнала
+type JournalEntryRequest struct {
+	EntryDate    string `json:"entry_date"` // Дата в формате YYYY-MM-DD (по умолчанию - сегодня)
+	HedgeOrderID string `json:"hedge_order_id"`
+	Text         string `json:"text"`
+}
+
+// handleJournal страница торгового журнала
+func (s *Server) handleJournal(w http.ResponseWriter, r *http.Request) {
+	data := PageData{
+		Title: "Журнал",
+	}
+
+	if err := s.executeTemplate(w, r, "journal.html", data); err != nil {
+		// Логируем ошибку, но не пытаемся изменить заголовки если они уже отправлены
+		log.Printf("❌ Ошибка рендеринга шаблона journal.html: %v", err)
+		return
+	}
+}
+
+// handleAPIJournal API торгового журнала: GET - список, POST - создание, DELETE - удаление по id
+func (s *Server) handleAPIJournal(w http.ResponseWriter, r *http.Request) {
+	if s.journalRepo == nil {
+		s.sendError(w, "Журнал недоступен: база данных не настроена", http.StatusServiceUnavailable)
+		return
+	}
+
+	ctx := r.Context()
+
+	switch r.Method {
+	case http.MethodGet:
+		entries, err := s.journalRepo.GetJournalEntries(ctx)
+		if err != nil {
+			s.sendError(w, "Ошибка получения записей журнала", http.StatusInternalServerError)
+			return
+		}
+		s.sendJSON(w, APIResponse{
+			Success: true,
+			Data:    convertToJournalViews(entries),
+		})
+
+	case http.MethodPost:
+		var req JournalEntryRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.sendError(w, "Некорректный формат запроса", http.StatusBadRequest)
+			return
+		}
+
+		text := strings.TrimSpace(req.Text)
+		if text == "" {
+			s.sendError(w, "Текст записи не может быть пустым", http.StatusBadRequest)
+			return
+		}
+
+		now := time.Now()
+		entryDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if req.EntryDate != "" {
+			parsed, err := time.ParseInLocation("2006-01-02", req.EntryDate, now.Location())
+			if err != nil {
+				s.sendError(w, "Дата должна быть в формате YYYY-MM-DD", http.StatusBadRequest)
+				return
+			}
+			entryDate = parsed
+		}
+
+		entry := &entities.JournalEntry{
+			CreatedAt:    now,
+			EntryDate:    entryDate,
+			HedgeOrderID: strings.TrimSpace(req.HedgeOrderID),
+			Text:         text,
+		}
+		if err := s.journalRepo.SaveJournalEntry(ctx, entry); err != nil {
+			s.sendError(w, "Ошибка сохранения записи журнала", http.StatusInternalServerError)
+			return
+		}
+
+		s.sendJSON(w, APIResponse{
+			Success: true,
+			Message: "Запись добавлена в журнал",
+			Data:    convertToJournalViews([]*entities.JournalEntry{entry})[0],
+		})
+
+	case http.MethodDelete:
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			s.sendError(w, "Некорректный ID записи", http.StatusBadRequest)
+			return
+		}
+		if err := s.journalRepo.DeleteJournalEntry(ctx, id); err != nil {
+			s.sendError(w, "Ошибка удаления записи журнала", http.StatusInternalServerError)
+			return
+		}
+		s.sendJSON(w, APIResponse{
+			Success: true,
+			Message: "Запись удалена из журнала",
+		})
+
+	default:
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+	}
+}
+
+// getJournalEntries получает записи журнала; при недоступности журнала возвращает пустой список
+func (s *Server) getJournalEntries(r *http.Request) []*entities.JournalEntry {
+	if s.journalRepo == nil {
+		return nil
+	}
+	entries, err := s.journalRepo.GetJournalEntries(r.Context())
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения записей журнала для экспорта: %v", err)
+		return nil
+	}
+	return entries
+}
+
+// convertToJournalViews преобразует записи журнала в представление для веб-интерфейса
+func convertToJournalViews(entries []*entities.JournalEntry) []JournalEntryView {
+	views := make([]JournalEntryView, len(entries))
+	for i, entry := range entries {
+		views[i] = JournalEntryView{
+			ID:           entry.ID,
+			CreatedAt:    entry.CreatedAt,
+			EntryDate:    entry.EntryDate.Format("2006-01-02"),
+			HedgeOrderID: entry.HedgeOrderID,
+			Text:         entry.Text,
+		}
+	}
+	return views
+}
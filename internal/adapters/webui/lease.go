@@ -0,0 +1,86 @@
+package webui
+
+import (
+	"net/http"
+	"time"
+)
+
+// LeaseView представление аренды экземпляра для веб-интерфейса
+type LeaseView struct {
+	InstanceID         string     `json:"instance_id"`
+	State              string     `json:"state"`
+	Holder             string     `json:"holder,omitempty"`
+	AcquiredAt         *time.Time `json:"acquired_at,omitempty"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	HandoffRequestedBy string     `json:"handoff_requested_by,omitempty"`
+}
+
+// handleAPILease API состояния аренды: GET /api/admin/lease
+func (s *Server) handleAPILease(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.lease == nil {
+		s.sendError(w, "Аренда экземпляра не настроена", http.StatusServiceUnavailable)
+		return
+	}
+
+	view, err := s.leaseView(r)
+	if err != nil {
+		s.sendError(w, "Ошибка получения аренды", http.StatusInternalServerError)
+		return
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    view,
+	})
+}
+
+// handleAPIDrain API завершения экземпляра перед остановкой: POST /api/admin/drain.
+// Новые хеджи не открываются, начатые доводятся до тейк-профита, затем аренда освобождается
+func (s *Server) handleAPIDrain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.lease == nil {
+		s.sendError(w, "Аренда экземпляра не настроена", http.StatusServiceUnavailable)
+		return
+	}
+
+	s.lease.Drain()
+
+	view, err := s.leaseView(r)
+	if err != nil {
+		s.sendError(w, "Ошибка получения аренды", http.StatusInternalServerError)
+		return
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Message: "Экземпляр переведен в режим завершения",
+		Data:    view,
+	})
+}
+
+// leaseView собирает состояние экземпляра и аренды в БД
+func (s *Server) leaseView(r *http.Request) (LeaseView, error) {
+	view := LeaseView{
+		InstanceID: s.lease.InstanceID(),
+		State:      string(s.lease.State()),
+	}
+
+	lease, err := s.lease.Lease(r.Context())
+	if err != nil {
+		return view, err
+	}
+	if lease != nil {
+		view.Holder = lease.Holder
+		view.AcquiredAt = &lease.AcquiredAt
+		view.ExpiresAt = &lease.ExpiresAt
+		view.HandoffRequestedBy = lease.HandoffRequestedBy
+	}
+	return view, nil
+}
@@ -0,0 +1,81 @@
+package webui
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"trade-hedge/internal/pkg/logger"
+)
+
+// Ограничения количества строк лога в ответе
+const (
+	logsDefaultLimit = 200
+	logsMaxLimit     = 5000
+)
+
+// LogsView последние строки лога из памяти процесса
+type LogsView struct {
+	Entries  []logger.Entry `json:"entries"`
+	LastSeq  uint64         `json:"last_seq"` // Номер последней записанной строки: передается в after для догрузки
+	Capacity int            `json:"capacity"` // Сколько строк хранится в памяти (webui.log_buffer_lines)
+}
+
+// handleLogs страница просмотра лога
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	data := PageData{
+		Title: "Логи",
+	}
+
+	if err := s.executeTemplate(w, r, "logs.html", data); err != nil {
+		log.Printf("❌ Ошибка рендеринга шаблона logs.html: %v", err)
+		return
+	}
+}
+
+// handleAPILogs API последних строк лога: /api/logs?limit=200&level=warn&after=123.
+// level - минимальный уровень (info, warn, error), after - вернуть только строки новее этого номера
+func (s *Server) handleAPILogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	capacity := logger.BufferCapacity()
+	if capacity == 0 {
+		s.sendError(w, "Лог в памяти не хранится: webui.log_buffer_lines = 0", http.StatusServiceUnavailable)
+		return
+	}
+
+	params := r.URL.Query()
+	limit := queryInt(r, "limit", logsDefaultLimit)
+	if limit <= 0 || limit > logsMaxLimit {
+		s.sendError(w, "Параметр limit (1-5000) вне допустимого диапазона", http.StatusBadRequest)
+		return
+	}
+	level := params.Get("level")
+	if level == "" {
+		level = logger.LevelInfo
+	}
+	if !logger.IsLevel(level) {
+		s.sendError(w, "Параметр level должен быть info, warn или error", http.StatusBadRequest)
+		return
+	}
+	var after uint64
+	if raw := params.Get("after"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			s.sendError(w, "Некорректный параметр after", http.StatusBadRequest)
+			return
+		}
+		after = parsed
+	}
+
+	entries, lastSeq := logger.Recent(limit, level, after)
+	if entries == nil {
+		entries = []logger.Entry{}
+	}
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    LogsView{Entries: entries, LastSeq: lastSeq, Capacity: capacity},
+	})
+}
@@ -0,0 +1,109 @@
+package webui
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/infrastructure/config"
+)
+
+// tradesPathPrefix префикс отдельной сделки Freqtrade: /api/trades/{freqtrade_id} и /api/trades/{freqtrade_id}/{action}
+const tradesPathPrefix = "/api/trades/"
+
+// ManualHedgeRequest тело POST /api/trades/{freqtrade_id}/hedge
+type ManualHedgeRequest struct {
+	Confirm bool `json:"confirm"` // Хеджировать, даже если просадка не превышает max_loss_percent
+}
+
+// handleAPITradeAction маршрутизирует запрос сделки Freqtrade и действия над ней по ее ID
+func (s *Server) handleAPITradeAction(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, tradesPathPrefix), "/")
+	if len(parts) == 0 || len(parts) > 2 {
+		s.sendError(w, "Не найдено", http.StatusNotFound)
+		return
+	}
+	tradeID, err := strconv.Atoi(parts[0])
+	if err != nil || tradeID <= 0 {
+		s.sendError(w, "Некорректный ID сделки Freqtrade", http.StatusBadRequest)
+		return
+	}
+
+	if len(parts) == 1 {
+		s.handleAPITradeDetails(w, r, tradeID)
+		return
+	}
+
+	switch parts[1] {
+	case "hedge":
+		s.handleAPIManualHedge(w, r, tradeID)
+	default:
+		s.sendError(w, "Не найдено", http.StatusNotFound)
+	}
+}
+
+// handleAPIManualHedge API ручного хеджирования одной сделки. Сделка с просадкой не больше порога
+// хеджируется только с подтверждением: {"confirm": true} в теле или параметр confirm=true
+func (s *Server) handleAPIManualHedge(w http.ResponseWriter, r *http.Request, tradeID int) {
+	if r.Method != http.MethodPost {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.fullConfig.Lease.HasRole(config.RoleExecutor) {
+		s.sendError(w, "Экземпляр не выполняет роль executor: хеджи открывает другой экземпляр", http.StatusConflict)
+		return
+	}
+	// Хедж размещается в слоте цикла планировщика: одновременно с циклом он мог бы купить ту же сделку
+	finish, ok := s.startCycle()
+	if !ok {
+		s.sendError(w, "Цикл стратегии уже выполняется: повторите запуск после его завершения", http.StatusConflict)
+		return
+	}
+	defer finish()
+	if s.lease != nil {
+		if !s.lease.CanHedge() {
+			s.sendError(w, "Экземпляр не держит аренду или завершает работу: новые хеджи не открываются", http.StatusConflict)
+			return
+		}
+		// Аренда не освобождается, пока хедж размещается
+		s.lease.CycleStarted()
+		defer s.lease.CycleFinished()
+	}
+
+	var req ManualHedgeRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.sendError(w, "Некорректный формат запроса", http.StatusBadRequest)
+			return
+		}
+	}
+	if confirm, err := strconv.ParseBool(r.URL.Query().Get("confirm")); err == nil && confirm {
+		req.Confirm = true
+	}
+
+	err := s.hedgeUseCase.HedgeTradeManually(r.Context(), tradeID, req.Confirm)
+	if err == nil {
+		s.sendJSON(w, APIResponse{
+			Success: true,
+			Message: "Сделка " + strconv.Itoa(tradeID) + " хеджирована",
+		})
+		return
+	}
+
+	if strategyErr, ok := err.(*errors.StrategyError); ok {
+		switch strategyErr.Type {
+		case errors.ErrorTypeTradeNotFound:
+			s.sendError(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.ErrorTypeHedgeInProgress, errors.ErrorTypeConfirmationRequired:
+			s.sendError(w, err.Error(), http.StatusConflict)
+			return
+		}
+	}
+	s.sendJSON(w, APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,72 @@
+package webui
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// OrderEventView представление события ордера для веб-интерфейса
+type OrderEventView struct {
+	OrderID   string    `json:"order_id"`
+	Pair      string    `json:"pair"`
+	OldStatus string    `json:"old_status"`
+	NewStatus string    `json:"new_status"`
+	FilledQty float64   `json:"filled_qty"`
+	Price     float64   `json:"price"`
+	Timestamp time.Time `json:"timestamp"`
+	Payload   string    `json:"payload,omitempty"`
+
+	RawPayload json.RawMessage `json:"raw_payload,omitempty"` // Необработанный ответ биржи
+}
+
+// handleAPIOrderEvents API истории событий ордера: /api/orders/events?order_id=...
+func (s *Server) handleAPIOrderEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.orderEventRepo == nil {
+		s.sendError(w, "История ордеров недоступна: база данных не настроена", http.StatusServiceUnavailable)
+		return
+	}
+
+	orderID := r.URL.Query().Get("order_id")
+	if orderID == "" {
+		s.sendError(w, "Не указан order_id", http.StatusBadRequest)
+		return
+	}
+
+	events, err := s.orderEventRepo.GetOrderEvents(r.Context(), orderID)
+	if err != nil {
+		s.sendError(w, "Ошибка получения истории ордера", http.StatusInternalServerError)
+		return
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    newOrderEventViews(events),
+	})
+}
+
+// newOrderEventViews преобразует события ордеров в представления
+func newOrderEventViews(events []*entities.OrderEvent) []OrderEventView {
+	views := make([]OrderEventView, len(events))
+	for i, event := range events {
+		views[i] = OrderEventView{
+			OrderID:   event.OrderID,
+			Pair:      event.Pair,
+			OldStatus: string(event.OldStatus),
+			NewStatus: string(event.NewStatus),
+			FilledQty: event.FilledQty,
+			Price:     event.Price,
+			Timestamp: event.Timestamp,
+			Payload:   event.Payload,
+		}
+		if event.RawPayload != "" {
+			views[i].RawPayload = json.RawMessage(event.RawPayload)
+		}
+	}
+	return views
+}
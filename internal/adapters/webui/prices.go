@@ -0,0 +1,100 @@
+package webui
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// maxPricePairs максимальное количество пар в одном запросе цен
+const maxPricePairs = 100
+
+// handleAPIPrices API текущих цен пар одним запросом: /api/prices?pairs=SOL/USDT,BTC/USDT.
+// Без pairs возвращает цены пар открытых хеджей. Пары без цены в ответ не попадают
+func (s *Server) handleAPIPrices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.priceFeed == nil {
+		s.sendError(w, "Источник цен не подключен", http.StatusServiceUnavailable)
+		return
+	}
+
+	var pairs []string
+	if raw := r.URL.Query().Get("pairs"); raw != "" {
+		seen := make(map[string]bool)
+		for _, value := range strings.Split(raw, ",") {
+			pair, err := valueobjects.ParseTradingPair(value)
+			if err != nil {
+				s.sendError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if !seen[pair.String()] {
+				seen[pair.String()] = true
+				pairs = append(pairs, pair.String())
+			}
+		}
+	} else {
+		activePairs, err := s.activeHedgePairs(r)
+		if err != nil {
+			s.sendError(w, "Ошибка получения открытых хеджей", http.StatusInternalServerError)
+			return
+		}
+		pairs = activePairs
+	}
+	if len(pairs) > maxPricePairs {
+		s.sendError(w, "Слишком много пар в запросе", http.StatusBadRequest)
+		return
+	}
+
+	prices, err := s.priceFeed.CurrentPrices(r.Context(), pairs)
+	if err != nil {
+		log.Printf("⚠️ Не удалось получить цены %v: %v", pairs, err)
+		// Источник цен недоступен - отдаем цены из последнего снимка с отметкой времени
+		if snapshot := s.latestSnapshot(r.Context()); snapshot != nil {
+			if cached, at := snapshot.PricesFor(pairs); len(cached) > 0 {
+				s.sendJSON(w, APIResponse{
+					Success:    true,
+					Message:    "Источник цен недоступен, показаны цены из последнего снимка",
+					Data:       cached,
+					Stale:      true,
+					SnapshotAt: at,
+				})
+				return
+			}
+		}
+		s.sendError(w, "Ошибка получения цен", http.StatusBadGateway)
+		return
+	}
+	if s.snapshots != nil {
+		s.snapshots.RecordPrices(r.Context(), prices)
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    prices,
+	})
+}
+
+// activeHedgePairs возвращает пары открытых хеджей без повторов
+func (s *Server) activeHedgePairs(r *http.Request) ([]string, error) {
+	pendingStatus := entities.OrderStatusPending.String()
+	trades, err := s.hedgeRepo.GetHedgedTrades(r.Context(), &pendingStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var pairs []string
+	for _, trade := range trades {
+		if !seen[trade.Pair] {
+			seen[trade.Pair] = true
+			pairs = append(pairs, trade.Pair)
+		}
+	}
+	return pairs, nil
+}
@@ -0,0 +1,39 @@
+package webui
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+)
+
+// reportsPathPrefix префикс сводок хеджирования: /api/reports/{daily|weekly}
+const reportsPathPrefix = "/api/reports/"
+
+// handleAPIReport API сводки хеджирования за последние 24 часа (daily) или 7 дней (weekly):
+// та же сводка, что отправляется оповещением report по расписанию reports
+func (s *Server) handleAPIReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.reports == nil {
+		s.sendError(w, "Сводки хеджирования не подключены", http.StatusNotFound)
+		return
+	}
+	period := strings.TrimPrefix(r.URL.Path, reportsPathPrefix)
+	if _, ok := entities.ReportPeriodDuration(period); !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	report, err := s.reports.BuildReport(r.Context(), period, time.Now())
+	if err != nil {
+		log.Printf("❌ Ошибка расчета сводки хеджирования: %v", err)
+		s.sendError(w, "Ошибка расчета сводки", http.StatusInternalServerError)
+		return
+	}
+	s.sendJSON(w, APIResponse{Success: true, Data: report})
+}
@@ -0,0 +1,92 @@
+package webui
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"trade-hedge/internal/infrastructure/config"
+)
+
+// v1ErrForbidden код ошибки /api/v1 при недостаточной роли пользователя
+const v1ErrForbidden = "forbidden"
+
+// webUIUser пользователь запроса: имя и роль
+type webUIUser struct {
+	Username string
+	Role     string
+}
+
+// apiTokenUser пользователь запросов с токеном API: токены дают полный доступ
+var apiTokenUser = webUIUser{Username: "api-token", Role: config.WebUIRoleAdmin}
+
+// roleRank порядок ролей: роль включает права всех предыдущих
+var roleRank = map[string]int{
+	config.WebUIRoleViewer:   0,
+	config.WebUIRoleOperator: 1,
+	config.WebUIRoleAdmin:    2,
+}
+
+// roleAllows проверяет, что роли role достаточно для действия с ролью required
+func roleAllows(role, required string) bool {
+	rank, ok := roleRank[role]
+	return ok && rank >= roleRank[required]
+}
+
+// requiredRole возвращает роль, нужную для запроса: просмотр доступен всем, действия (POST, PUT, DELETE) -
+// оператору, изменения через /api/admin (аренда, откат конфигурации, флаги) - администратору.
+// Параметры во время работы (/api/admin/settings) меняет оператор, как и параметры стратегии
+func requiredRole(r *http.Request) string {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return config.WebUIRoleViewer
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/admin/") && r.URL.Path != "/api/admin/settings" {
+		return config.WebUIRoleAdmin
+	}
+	return config.WebUIRoleOperator
+}
+
+// userContextKey ключ пользователя запроса в контексте
+type userContextKey struct{}
+
+// withUser сохраняет пользователя в контексте запроса
+func withUser(r *http.Request, user webUIUser) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), userContextKey{}, user))
+}
+
+// requestUser возвращает пользователя запроса (false - аутентификация выключена)
+func requestUser(r *http.Request) (webUIUser, bool) {
+	user, ok := r.Context().Value(userContextKey{}).(webUIUser)
+	return user, ok
+}
+
+// requestRole возвращает роль пользователя запроса; без аутентификации доступно все
+func requestRole(r *http.Request) string {
+	if user, ok := requestUser(r); ok {
+		return user.Role
+	}
+	return config.WebUIRoleAdmin
+}
+
+// requestAuthor возвращает автора изменения для журналов: пользователь (если аутентификация включена) и адрес клиента
+func requestAuthor(r *http.Request) string {
+	if user, ok := requestUser(r); ok {
+		return "webui " + user.Username + "@" + clientHost(r)
+	}
+	return "webui " + clientHost(r)
+}
+
+// denyRole отвечает на действие, для которого роли пользователя недостаточно
+func (s *Server) denyRole(w http.ResponseWriter, r *http.Request, required string) {
+	message := "Недостаточно прав: действие доступно ролям operator и admin"
+	if required == config.WebUIRoleAdmin {
+		message = "Недостаточно прав: действие доступно роли admin"
+	}
+
+	if strings.HasPrefix(r.URL.Path, apiV1Prefix+"/") {
+		s.sendV1Error(w, http.StatusForbidden, V1Error{Code: v1ErrForbidden, Message: message})
+		return
+	}
+	s.sendError(w, message, http.StatusForbidden)
+}
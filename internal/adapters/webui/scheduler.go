@@ -0,0 +1,162 @@
+package webui
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/pkg/logger"
+)
+
+// SchedulerView состояние планировщика для значка на дашборде
+type SchedulerView struct {
+	State           string     `json:"state"` // starting, waiting, running, paused, quiet, stopped
+	Paused          bool       `json:"paused"`
+	PausedAt        *time.Time `json:"paused_at,omitempty"`
+	PausedBy        string     `json:"paused_by,omitempty"`
+	PauseReason     string     `json:"pause_reason,omitempty"`
+	CycleRunning    bool       `json:"cycle_running"`
+	LastCycleAt     *time.Time `json:"last_cycle_at,omitempty"`
+	NextCycleAt     *time.Time `json:"next_cycle_at,omitempty"`
+	IntervalSeconds int        `json:"interval_seconds"` // 0 - циклы по расписанию cron
+
+	Schedule   string     `json:"schedule,omitempty"`    // Расписание циклов cron (strategy.schedule)
+	QuietHours []string   `json:"quiet_hours,omitempty"` // Тихие часы без новых хеджей (strategy.quiet_hours)
+	Timezone   string     `json:"timezone"`              // Часовой пояс расписания и тихих часов
+	QuietUntil *time.Time `json:"quiet_until,omitempty"` // Конец текущего тихого окна
+
+	CycleTimeoutSeconds int   `json:"cycle_timeout_seconds"`  // Ограничение длительности цикла (0 - без ограничения)
+	LastCycleDurationMs int64 `json:"last_cycle_duration_ms"` // Длительность последнего завершенного цикла
+	CyclesSkipped       int   `json:"cycles_skipped"`         // Пропущенные циклы: цикл уже выполнялся или предыдущий не уложился в расписание
+	CyclesOverrun       int   `json:"cycles_overrun"`         // Циклы, не уложившиеся в период расписания
+	CyclesTimedOut      int   `json:"cycles_timed_out"`       // Циклы, прерванные по strategy.cycle_timeout
+}
+
+// SchedulerPauseRequest тело POST /api/scheduler/pause и /api/scheduler/resume
+type SchedulerPauseRequest struct {
+	Reason string `json:"reason"` // Причина паузы (например, выход новостей)
+	Author string `json:"author"` // Кто приостанавливает (по умолчанию - адрес клиента)
+}
+
+// handleAPIScheduler API состояния планировщика: GET /api/scheduler
+func (s *Server) handleAPIScheduler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.scheduler == nil {
+		s.sendError(w, "Планировщик не запущен (strategy.check_interval: 0 или экземпляр без роли executor)", http.StatusServiceUnavailable)
+		return
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Data:    s.schedulerView(),
+	})
+}
+
+// handleAPISchedulerPause API паузы автоматического хеджирования: POST /api/scheduler/pause.
+// Процесс продолжает работать: статусы открытых хеджей проверяются, новые хеджи не открываются
+func (s *Server) handleAPISchedulerPause(w http.ResponseWriter, r *http.Request) {
+	req, ok := s.schedulerRequest(w, r)
+	if !ok {
+		return
+	}
+
+	message := "Автоматическое хеджирование уже приостановлено"
+	if s.scheduler.Pause(req.Author, req.Reason) {
+		message = "Автоматическое хеджирование приостановлено"
+	}
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Message: message,
+		Data:    s.schedulerView(),
+	})
+}
+
+// handleAPISchedulerResume API возобновления автоматического хеджирования: POST /api/scheduler/resume.
+// Цикл стратегии запускается сразу, не дожидаясь интервала
+func (s *Server) handleAPISchedulerResume(w http.ResponseWriter, r *http.Request) {
+	req, ok := s.schedulerRequest(w, r)
+	if !ok {
+		return
+	}
+
+	message := "Автоматическое хеджирование не было приостановлено"
+	if s.scheduler.Resume(req.Author) {
+		message = "Автоматическое хеджирование возобновлено"
+	}
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Message: message,
+		Data:    s.schedulerView(),
+	})
+}
+
+// schedulerRequest проверяет метод и разбирает тело запроса паузы или возобновления (тело необязательно)
+func (s *Server) schedulerRequest(w http.ResponseWriter, r *http.Request) (*SchedulerPauseRequest, bool) {
+	if r.Method != http.MethodPost {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+	if s.scheduler == nil {
+		s.sendError(w, "Планировщик не запущен (strategy.check_interval: 0 или экземпляр без роли executor)", http.StatusServiceUnavailable)
+		return nil, false
+	}
+
+	req := &SchedulerPauseRequest{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			s.sendError(w, "Некорректный формат запроса", http.StatusBadRequest)
+			return nil, false
+		}
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	req.Author = strings.TrimSpace(req.Author)
+	if req.Author == "" {
+		req.Author = requestAuthor(r)
+	}
+	return req, true
+}
+
+// startCycle занимает слот цикла планировщика для ручного действия, которое размещает или отменяет ордера
+// (запуск стратегии, хеджирование или закрытие хеджа, проверка статусов), чтобы оно не выполнялось одновременно
+// с циклом по расписанию или другим ручным действием. false - цикл уже выполняется: отказ учитывается
+// в пропущенных циклах. Возвращает функцию завершения цикла
+func (s *Server) startCycle() (func(), bool) {
+	if s.scheduler == nil {
+		return func() {}, true
+	}
+	if !s.scheduler.CycleStarted() {
+		logger.LogWithTime("⏭️ Ручное действие с ордерами отклонено: цикл стратегии уже выполняется")
+		return nil, false
+	}
+	return s.scheduler.CycleFinished, true
+}
+
+// schedulerView собирает состояние планировщика
+func (s *Server) schedulerView() SchedulerView {
+	status := s.scheduler.Status()
+	return SchedulerView{
+		State:           string(status.State),
+		Paused:          status.Paused,
+		PausedAt:        status.PausedAt,
+		PausedBy:        status.PausedBy,
+		PauseReason:     status.PauseReason,
+		CycleRunning:    status.CycleRunning,
+		LastCycleAt:     status.LastCycleAt,
+		NextCycleAt:     status.NextCycleAt,
+		IntervalSeconds: int(status.Interval / time.Second),
+		Schedule:        status.Schedule,
+		QuietHours:      status.QuietHours,
+		Timezone:        status.Timezone,
+		QuietUntil:      status.QuietUntil,
+
+		CycleTimeoutSeconds: int(status.CycleTimeout / time.Second),
+		LastCycleDurationMs: status.LastCycleDuration.Milliseconds(),
+		CyclesSkipped:       status.CyclesSkipped,
+		CyclesOverrun:       status.CyclesOverrun,
+		CyclesTimedOut:      status.CyclesTimedOut,
+	}
+}
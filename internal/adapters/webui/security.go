@@ -0,0 +1,129 @@
+package webui
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"trade-hedge/internal/pkg/logger"
+)
+
+const (
+	csrfCookieName = "trade_hedge_csrf" // cookie с CSRF токеном (double submit)
+	csrfHeaderName = "X-CSRF-Token"     // заголовок с CSRF токеном в запросах скриптов страниц
+	csrfFormField  = "csrf_token"       // поле формы с CSRF токеном (вход, выход)
+)
+
+// v1ErrCSRF код ошибки /api/v1 при недействительном CSRF токене
+const v1ErrCSRF = "csrf_failed"
+
+// contentSecurityPolicy разрешает скрипты, стили и шрифты страниц только с сервера и используемых CDN
+// (Tailwind, Alpine.js, Chart.js, Font Awesome). Alpine.js и Tailwind CDN требуют inline-скриптов и eval
+var contentSecurityPolicy = strings.Join([]string{
+	"default-src 'self'",
+	"script-src 'self' 'unsafe-inline' 'unsafe-eval' https://cdn.tailwindcss.com https://unpkg.com https://cdn.jsdelivr.net",
+	"style-src 'self' 'unsafe-inline' https://cdnjs.cloudflare.com",
+	"font-src 'self' data: https://cdnjs.cloudflare.com",
+	"img-src 'self' data:",
+	"connect-src 'self'",
+	"object-src 'none'",
+	"base-uri 'self'",
+	"form-action 'self'",
+	"frame-ancestors 'none'",
+}, "; ")
+
+// securityHeadersMiddleware добавляет заголовки безопасности ко всем ответам: CSP, запрет встраивания
+// во фреймы, запрет угадывания типа содержимого; при TLS - HSTS
+func (s *Server) securityHeadersMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		header.Set("Content-Security-Policy", contentSecurityPolicy)
+		header.Set("X-Frame-Options", "DENY")
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("Referrer-Policy", "same-origin")
+		if s.webUIConfig.TLSEnabled() {
+			header.Set("Strict-Transport-Security", "max-age=31536000")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// csrfMiddleware проверяет CSRF токен изменяющих запросов (POST, PUT, DELETE) из браузера:
+// токен из заголовка X-CSRF-Token или поля формы csrf_token должен совпасть с cookie trade_hedge_csrf,
+// которую сервер выдает вместе со страницей. Запросы с токеном API и webhook сигналов не проверяются:
+// браузер не подставляет их учетные данные сам
+func (s *Server) csrfMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !needsCSRFCheck(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := r.Header.Get(csrfHeaderName)
+		if token == "" {
+			token = r.PostFormValue(csrfFormField)
+		}
+		cookie, err := r.Cookie(csrfCookieName)
+		if err != nil || cookie.Value == "" || token == "" || !secretsEqual(token, cookie.Value) {
+			logger.LogWithTime("⚠️ Отклонен запрос %s %s без действительного CSRF токена, адрес %s", r.Method, r.URL.Path, r.RemoteAddr)
+			s.denyCSRF(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// needsCSRFCheck проверяет, что запрос изменяет состояние и может быть отправлен браузером от имени
+// пользователя: с cookie, заголовком Origin или Sec-Fetch-Site. Скрипты (curl) без cookie не проверяются
+func needsCSRFCheck(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	if r.URL.Path == signalsPath {
+		return false
+	}
+	if _, withToken := bearerToken(r); withToken {
+		return false
+	}
+	return r.Header.Get("Cookie") != "" || r.Header.Get("Origin") != "" || r.Header.Get("Sec-Fetch-Site") != ""
+}
+
+// denyCSRF отвечает на изменяющий запрос без действительного CSRF токена
+func (s *Server) denyCSRF(w http.ResponseWriter, r *http.Request) {
+	const message = "Недействительный CSRF токен: обновите страницу"
+	if strings.HasPrefix(r.URL.Path, apiV1Prefix+"/") {
+		s.sendV1Error(w, http.StatusForbidden, V1Error{Code: v1ErrCSRF, Message: message})
+		return
+	}
+	s.sendError(w, message, http.StatusForbidden)
+}
+
+// csrfToken возвращает CSRF токен браузера из cookie или выдает новый для страницы
+func (s *Server) csrfToken(w http.ResponseWriter, r *http.Request) string {
+	if cookie, err := r.Cookie(csrfCookieName); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		logger.LogWithTime("❌ Ошибка генерации CSRF токена: %v", err)
+		return ""
+	}
+	token := hex.EncodeToString(buf)
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   s.secureCookies(),
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token
+}
+
+// secureCookies проверяет, что cookie выдаются только для HTTPS: явно (webui.auth.secure_cookie) или при TLS
+func (s *Server) secureCookies() bool {
+	return s.webUIConfig.Auth.SecureCookie || s.webUIConfig.TLSEnabled()
+}
@@ -2,31 +2,62 @@ package webui
 
 import (
 	"context"
-	"embed"
 	"fmt"
-	"html/template"
+	"io"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
+	"trade-hedge/internal/adapters/signals"
+	"trade-hedge/internal/domain/entities"
 	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
 	"trade-hedge/internal/infrastructure/config"
 	"trade-hedge/internal/pkg/logger"
 	"trade-hedge/internal/usecases"
 )
 
-//go:embed templates/*
-var templateFS embed.FS
+// pageRenderer рендерит HTML страницы (html/template подключается только в сборке со страницами)
+type pageRenderer interface {
+	ExecuteTemplate(w io.Writer, name string, data interface{}) error
+}
 
 // Server веб-сервер для мониторинга
 type Server struct {
 	webUIConfig          *config.WebUIConfig
 	fullConfig           *config.Config
 	hedgeRepo            repositories.HedgeRepository
+	journalRepo          repositories.JournalRepository
+	orderEventRepo       repositories.OrderEventRepository
 	hedgeUseCase         *usecases.HedgeStrategyUseCase
 	statusCheckerUseCase *usecases.StatusCheckerUseCase
+	outcomeUseCase       *usecases.HedgeOutcomeUseCase
+	heatmapUseCase       *usecases.HeatmapUseCase
+	lease                *usecases.InstanceLease
+	accountHistory       *usecases.AccountHistoryUseCase
+	exitQuality          *usecases.ExitQualityUseCase
+	priceFeed            services.PriceFeed
+	configHistory        *usecases.ConfigHistoryUseCase
+	configPath           string
+	snapshots            *usecases.MarketSnapshotUseCase
+	decisionRepo         repositories.HedgeDecisionRepository
+	auditRepo            repositories.AuditLogRepository
+	hedgeGroupRepo       repositories.HedgeGroupRepository
+	settings             *usecases.SettingsUseCase
+	features             *usecases.FeatureFlagsUseCase
+	alerts               *usecases.AlertManager
+	signalWebhook        *signals.WebhookSource
+	signalSecret         string
+	hedgeCloser          *usecases.FlatCloserUseCase
+	customMetrics        *usecases.CustomMetricsUseCase
+	scheduler            *usecases.SchedulerControl
+	reports              *usecases.ReportUseCase
+	configMu             sync.Mutex             // Изменение файла конфигурации и параметров из веб-интерфейса
+	pendingStrategy      map[string]interface{} // Параметры стратегии, записанные в файл до перезапуска
+	auth                 *authenticator
 	server               *http.Server
-	templates            *template.Template
+	templates            pageRenderer
 }
 
 // NewServer создает новый веб-сервер
@@ -34,15 +65,25 @@ func NewServer(
 	webUIConfig *config.WebUIConfig,
 	fullConfig *config.Config,
 	hedgeRepo repositories.HedgeRepository,
+	journalRepo repositories.JournalRepository,
+	orderEventRepo repositories.OrderEventRepository,
 	hedgeUseCase *usecases.HedgeStrategyUseCase,
 	statusCheckerUseCase *usecases.StatusCheckerUseCase,
+	outcomeUseCase *usecases.HedgeOutcomeUseCase,
+	heatmapUseCase *usecases.HeatmapUseCase,
 ) *Server {
 	s := &Server{
 		webUIConfig:          webUIConfig,
 		fullConfig:           fullConfig,
 		hedgeRepo:            hedgeRepo,
+		journalRepo:          journalRepo,
+		orderEventRepo:       orderEventRepo,
 		hedgeUseCase:         hedgeUseCase,
 		statusCheckerUseCase: statusCheckerUseCase,
+		outcomeUseCase:       outcomeUseCase,
+		heatmapUseCase:       heatmapUseCase,
+		auth:                 newAuthenticator(&webUIConfig.Auth),
+		pendingStrategy:      make(map[string]interface{}),
 	}
 
 	// Загружаем шаблоны
@@ -54,7 +95,7 @@ func NewServer(
 
 	s.server = &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", webUIConfig.Host, webUIConfig.Port),
-		Handler:      mux,
+		Handler:      s.securityHeadersMiddleware(s.authMiddleware(s.csrfMiddleware(mux))),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -63,10 +104,110 @@ func NewServer(
 	return s
 }
 
-// loadTemplates загружает HTML шаблоны
+// WithInstanceLease подключает аренду экземпляра для эндпоинтов передачи и завершения
+func (s *Server) WithInstanceLease(lease *usecases.InstanceLease) *Server {
+	s.lease = lease
+	return s
+}
+
+// WithHedgeCloser включает ручное закрытие хеджей POST /api/hedges/{order_id}/close
+func (s *Server) WithHedgeCloser(closer *usecases.FlatCloserUseCase) *Server {
+	s.hedgeCloser = closer
+	return s
+}
+
+// WithScheduler подключает состояние планировщика: значок на дашборде, пауза и возобновление хеджирования
+func (s *Server) WithScheduler(scheduler *usecases.SchedulerControl) *Server {
+	s.scheduler = scheduler
+	return s
+}
+
+// WithReports включает сводки хеджирования /api/reports/daily и /api/reports/weekly
+func (s *Server) WithReports(reports *usecases.ReportUseCase) *Server {
+	s.reports = reports
+	return s
+}
+
+// WithCustomMetrics включает пользовательские метрики: /metrics для Prometheus и карточки дашборда
+func (s *Server) WithCustomMetrics(customMetrics *usecases.CustomMetricsUseCase) *Server {
+	s.customMetrics = customMetrics
+	return s
+}
+
+// WithAccountHistory подключает импортированную историю ордеров аккаунта к аналитике
+func (s *Server) WithAccountHistory(accountHistory *usecases.AccountHistoryUseCase) *Server {
+	s.accountHistory = accountHistory
+	return s
+}
+
+// WithExitQuality подключает отчет о качестве выхода из хеджей к аналитике
+func (s *Server) WithExitQuality(exitQuality *usecases.ExitQualityUseCase) *Server {
+	s.exitQuality = exitQuality
+	return s
+}
+
+// WithPriceFeed подключает источник текущих цен для плавающей прибыли открытых хеджей
+func (s *Server) WithPriceFeed(priceFeed services.PriceFeed) *Server {
+	s.priceFeed = priceFeed
+	return s
+}
+
+// WithMarketSnapshots включает показ последнего снимка балансов и цен при недоступности Bybit или Freqtrade
+func (s *Server) WithMarketSnapshots(snapshots *usecases.MarketSnapshotUseCase) *Server {
+	s.snapshots = snapshots
+	return s
+}
+
+// WithHedgeDecisions подключает историю решений не хеджировать сделки
+func (s *Server) WithHedgeDecisions(repo repositories.HedgeDecisionRepository) *Server {
+	s.decisionRepo = repo
+	return s
+}
+
+// WithAuditLog подключает хранение журнала аудита ручных действий (без него действия только логируются)
+func (s *Server) WithAuditLog(repo repositories.AuditLogRepository) *Server {
+	s.auditRepo = repo
+	return s
+}
+
+// WithSettings подключает параметры стратегии, изменяемые во время работы
+func (s *Server) WithSettings(settings *usecases.SettingsUseCase) *Server {
+	s.settings = settings
+	return s
+}
+
+// WithFeatureFlags подключает флаги возможностей для страницы /features
+func (s *Server) WithFeatureFlags(features *usecases.FeatureFlagsUseCase) *Server {
+	s.features = features
+	return s
+}
+
+// WithAlerts подключает группы оповещений: неустраненные условия возвращаются в /api/status
+func (s *Server) WithAlerts(alerts *usecases.AlertManager) *Server {
+	s.alerts = alerts
+	return s
+}
+
+// WithConfigHistory подключает историю конфигурации и файл, в который записывается откат
+func (s *Server) WithConfigHistory(configHistory *usecases.ConfigHistoryUseCase, configPath string) *Server {
+	s.configHistory = configHistory
+	s.configPath = configPath
+	return s
+}
+
+// pagesEnabled проверяет, отдает ли сервер HTML страницы: они встроены в сборку и не отключены webui.api_only
+func (s *Server) pagesEnabled() bool {
+	return pagesIncluded && !s.webUIConfig.APIOnly
+}
+
+// loadTemplates загружает HTML шаблоны (в режиме только API шаблоны не загружаются)
 func (s *Server) loadTemplates() {
+	if !s.pagesEnabled() {
+		return
+	}
+
 	var err error
-	s.templates, err = template.ParseFS(templateFS, "templates/*.html")
+	s.templates, err = parseTemplates()
 	if err != nil {
 		log.Fatalf("❌ Ошибка загрузки шаблонов: %v", err)
 	}
@@ -75,25 +216,110 @@ func (s *Server) loadTemplates() {
 // setupRoutes настраивает маршруты
 func (s *Server) setupRoutes(mux *http.ServeMux) {
 	// Статические файлы и основные страницы
-	mux.HandleFunc("/", s.handleDashboard)
-	mux.HandleFunc("/trades", s.handleTrades)
-	mux.HandleFunc("/config", s.handleConfig)
+	if s.pagesEnabled() {
+		mux.HandleFunc("/", s.handleDashboard)
+		mux.HandleFunc("/trades", s.handleTrades)
+		mux.HandleFunc(tradePagePrefix, s.handleTradePage)
+		mux.HandleFunc("/config", s.handleConfig)
+		mux.HandleFunc("/journal", s.handleJournal)
+		mux.HandleFunc("/analytics", s.handleAnalytics)
+		mux.HandleFunc("/balances", s.handleBalances)
+		mux.HandleFunc("/features", s.handleFeatures)
+		mux.HandleFunc("/logs", s.handleLogs)
+		mux.HandleFunc("/audit", s.handleAudit)
+	} else {
+		mux.HandleFunc("/", s.handlePagesDisabled)
+	}
 
-	// API эндпоинты
+	// Вход и выход (режим аутентификации session)
+	if s.webUIConfig.Auth.Mode == config.WebUIAuthSession {
+		mux.HandleFunc("/login", s.handleLogin)
+		mux.HandleFunc("/logout", s.handleLogout)
+	}
+
+	// API эндпоинты HTML страниц (без версии; внешним инструментам - /api/v1)
 	mux.HandleFunc("/api/trades", s.handleAPITrades)
+	mux.HandleFunc(tradesPathPrefix, s.audited(entities.AuditActionManualHedge, s.handleAPITradeAction))
+	mux.HandleFunc(hedgesPathPrefix, s.audited(entities.AuditActionHedgeClose, s.handleAPIHedgeAction))
+	mux.HandleFunc("/api/stats", s.handleAPIStats)
 	mux.HandleFunc("/api/status", s.handleAPIStatus)
-	mux.HandleFunc("/api/execute", s.handleAPIExecute)
-	mux.HandleFunc("/api/check-status", s.handleAPICheckStatus)
+	mux.HandleFunc("/api/execute", s.audited(entities.AuditActionExecute, s.handleAPIExecute))
+	mux.HandleFunc("/api/check-status", s.audited(entities.AuditActionCheckStatus, s.handleAPICheckStatus))
+	mux.HandleFunc("/api/scheduler", s.handleAPIScheduler)
+	mux.HandleFunc("/api/scheduler/pause", s.audited(entities.AuditActionSchedulerPause, s.handleAPISchedulerPause))
+	mux.HandleFunc("/api/scheduler/resume", s.audited(entities.AuditActionSchedulerResume, s.handleAPISchedulerResume))
 	mux.HandleFunc("/api/balance", s.handleAPIBalance)
+	mux.HandleFunc("/api/balances", s.handleAPIBalances)
+	mux.HandleFunc("/api/capital", s.handleAPICapital)
+	mux.HandleFunc("/api/prices", s.handleAPIPrices)
+	mux.HandleFunc("/api/candidates", s.handleAPICandidates)
+	mux.HandleFunc("/api/outcomes", s.handleAPIOutcomes)
+	mux.HandleFunc("/api/journal", s.handleAPIJournal)
+	mux.HandleFunc("/api/orders/events", s.handleAPIOrderEvents)
+	mux.HandleFunc("/api/decisions", s.handleAPIDecisions)
+	mux.HandleFunc("/api/logs", s.handleAPILogs)
+	mux.HandleFunc("/api/audit", s.handleAPIAudit)
+	mux.HandleFunc("/api/hedge-groups", s.handleAPIHedgeGroups)
+	mux.HandleFunc("/api/metrics/custom", s.handleAPICustomMetrics)
+	mux.HandleFunc("/api/analytics/heatmap", s.handleAPIHeatmap)
+	mux.HandleFunc("/api/analytics/account", s.handleAPIAccountHistory)
+	mux.HandleFunc("/api/analytics/latency", s.handleAPILatency)
+	mux.HandleFunc("/api/analytics/exits", s.handleAPIExitQuality)
+	mux.HandleFunc("/api/analytics/pnl", s.handleAPIProfitSeries)
+	mux.HandleFunc("/api/analytics/pnl/chart", s.handleAPIProfitChart)
+	mux.HandleFunc("/api/analytics/entry", s.handleAPIPassiveEntry)
+	mux.HandleFunc(reportsPathPrefix, s.handleAPIReport)
+	mux.HandleFunc("/api/admin/lease", s.handleAPILease)
+	mux.HandleFunc("/api/admin/drain", s.audited(entities.AuditActionDrain, s.handleAPIDrain))
+	mux.HandleFunc("/api/config", s.audited(entities.AuditActionConfigUpdate, s.handleAPIConfig))
+	mux.HandleFunc("/api/admin/config/history", s.handleAPIConfigHistory)
+	mux.HandleFunc("/api/admin/config/rollback", s.audited(entities.AuditActionConfigRollback, s.handleAPIConfigRollback))
+	mux.HandleFunc("/api/admin/settings", s.audited(entities.AuditActionSettingsUpdate, s.handleAPISettings))
+	mux.HandleFunc("/api/admin/features", s.audited(entities.AuditActionFeatureToggle, s.handleAPIFeatures))
+
+	// Экспорт сделок вместе с записями журнала
+	mux.HandleFunc("/api/export/trades.csv", s.handleExportCSV)
+	mux.HandleFunc("/api/export/trades.xls", s.handleExportExcel)
+
+	// Метрики для Prometheus
+	mux.HandleFunc(metricsPath, s.handleMetrics)
+
+	// Версионированный REST API для внешних скриптов и инструментов
+	s.setupAPIV1Routes(mux)
+}
+
+// handlePagesDisabled отвечает на запросы страниц в режиме только API
+func (s *Server) handlePagesDisabled(w http.ResponseWriter, r *http.Request) {
+	s.sendError(w, "Веб-интерфейс отключен: доступен только API (/api/...)", http.StatusNotFound)
 }
 
 // Start запускает веб-сервер
 func (s *Server) Start(ctx context.Context) error {
-	logger.LogWithTime("🌐 Запуск веб-интерфейса на http://%s:%d", s.webUIConfig.Host, s.webUIConfig.Port)
+	scheme := "http"
+	if s.webUIConfig.TLSEnabled() {
+		scheme = "https"
+	}
+	if s.pagesEnabled() {
+		logger.LogWithTime("🌐 Запуск веб-интерфейса на %s://%s:%d", scheme, s.webUIConfig.Host, s.webUIConfig.Port)
+	} else {
+		logger.LogWithTime("🌐 Запуск API без веб-интерфейса на %s://%s:%d", scheme, s.webUIConfig.Host, s.webUIConfig.Port)
+	}
+	if s.webUIConfig.Auth.Enabled() {
+		logger.LogWithTime("🔐 Аутентификация веб-интерфейса: %s, токенов API: %d", s.webUIConfig.Auth.Mode, len(s.webUIConfig.Auth.APITokens))
+	} else {
+		logger.LogWithTime("⚠️ Аутентификация веб-интерфейса отключена (webui.auth.mode: none)")
+	}
 
 	// Запускаем сервер в горутине
 	go func() {
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if s.webUIConfig.TLSEnabled() {
+			// TLS завершается на сервере: сертификат и ключ читаются при запуске
+			err = s.server.ListenAndServeTLS(s.webUIConfig.TLSCert, s.webUIConfig.TLSKey)
+		} else {
+			err = s.server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			logger.LogWithTime("❌ Ошибка веб-сервера: %v", err)
 		}
 	}()
@@ -0,0 +1,123 @@
+package webui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/usecases"
+)
+
+// settingsHistoryLimit количество записей журнала изменений параметров в ответе
+const settingsHistoryLimit = 50
+
+// SettingChangeView представление изменения параметра для веб-интерфейса
+type SettingChangeView struct {
+	ID        int64      `json:"id"`
+	Key       string     `json:"key"`
+	OldValue  *float64   `json:"old_value"`
+	NewValue  *float64   `json:"new_value"`
+	ChangedBy string     `json:"changed_by"`
+	ChangedAt time.Time  `json:"changed_at"`
+	ExpiresAt *time.Time `json:"expires_at"` // Окончание временного значения (null - изменение постоянное)
+}
+
+// SettingsView параметры стратегии и журнал их изменений
+type SettingsView struct {
+	Settings []usecases.SettingView `json:"settings"`
+	History  []SettingChangeView    `json:"history"`
+}
+
+// SettingUpdateRequest запрос на изменение параметра
+type SettingUpdateRequest struct {
+	Key    string   `json:"key"`
+	Value  *float64 `json:"value"`  // Новое значение
+	Reset  bool     `json:"reset"`  // Сбросить к значению из файла конфигурации
+	Hours  float64  `json:"hours"`  // Временное значение на указанное число часов (0 - постоянное)
+	Author string   `json:"author"` // Кто изменяет (по умолчанию - адрес клиента)
+}
+
+// handleAPISettings API параметров стратегии, изменяемых во время работы:
+// GET /api/admin/settings - параметры и журнал, POST /api/admin/settings - изменение или сброс
+func (s *Server) handleAPISettings(w http.ResponseWriter, r *http.Request) {
+	if s.settings == nil {
+		s.sendError(w, "Изменение параметров во время работы не настроено", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.sendSettings(w, r, "")
+	case http.MethodPost:
+		var req SettingUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Key == "" || (req.Value == nil && !req.Reset) {
+			s.sendError(w, "Некорректный формат запроса", http.StatusBadRequest)
+			return
+		}
+
+		author := strings.TrimSpace(req.Author)
+		if author == "" {
+			author = requestAuthor(r)
+		}
+
+		var err error
+		message := "Параметр сохранен и действует с начала следующего цикла"
+		switch {
+		case req.Reset:
+			_, err = s.settings.Reset(r.Context(), req.Key, author)
+			message = "Параметр сброшен к значению из файла конфигурации"
+		case req.Hours != 0:
+			duration := time.Duration(req.Hours * float64(time.Hour))
+			_, err = s.settings.Override(r.Context(), req.Key, *req.Value, duration, author)
+			message = fmt.Sprintf("Временное значение сохранено на %g ч: действует с начала следующего цикла, по окончании вернется прежнее", req.Hours)
+		default:
+			_, err = s.settings.Update(r.Context(), req.Key, *req.Value, author)
+		}
+		if err != nil {
+			s.sendError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.sendSettings(w, r, message)
+	default:
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+	}
+}
+
+// sendSettings отправляет параметры и журнал изменений
+func (s *Server) sendSettings(w http.ResponseWriter, r *http.Request, message string) {
+	history, err := s.settings.History(r.Context(), settingsHistoryLimit)
+	if err != nil {
+		s.sendError(w, "Ошибка получения журнала параметров", http.StatusInternalServerError)
+		return
+	}
+
+	view := SettingsView{
+		Settings: s.settings.Settings(),
+		History:  make([]SettingChangeView, 0, len(history)),
+	}
+	for _, change := range history {
+		view.History = append(view.History, convertToSettingChangeView(change))
+	}
+
+	s.sendJSON(w, APIResponse{
+		Success: true,
+		Message: message,
+		Data:    view,
+	})
+}
+
+// convertToSettingChangeView конвертирует изменение параметра в представление
+func convertToSettingChangeView(change *entities.SettingChange) SettingChangeView {
+	return SettingChangeView{
+		ID:        change.ID,
+		Key:       change.Key,
+		OldValue:  change.OldValue,
+		NewValue:  change.NewValue,
+		ChangedBy: change.ChangedBy,
+		ChangedAt: change.ChangedAt,
+		ExpiresAt: change.ExpiresAt,
+	}
+}
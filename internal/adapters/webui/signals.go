@@ -0,0 +1,82 @@
+package webui
+
+import (
+	"io"
+	"net/http"
+
+	"trade-hedge/internal/adapters/signals"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// signalsPath эндпоинт приема внешних сигналов хеджирования
+const signalsPath = apiV1Prefix + "/signals"
+
+// maxSignalBody максимальный размер тела запроса с сигналами
+const maxSignalBody = 64 << 10
+
+// v1SignalsParams параметры POST /api/v1/signals (секрет в адресе - для отправителей без заголовков, например TradingView)
+var v1SignalsParams = []string{"secret"}
+
+// V1SignalsAccepted результат приема сигналов
+type V1SignalsAccepted struct {
+	Accepted int `json:"accepted"` // Сигналов поставлено в очередь
+	Pending  int `json:"pending"`  // Сигналов в очереди до ближайшего цикла стратегии
+}
+
+// WithSignalWebhook включает прием сигналов POST /api/v1/signals в очередь webhook.
+// С непустым secret эндпоинт доступен без аутентификации веб-интерфейса, но требует секрет
+// в заголовке X-Signal-Secret или параметре secret
+func (s *Server) WithSignalWebhook(webhook *signals.WebhookSource, secret string) *Server {
+	s.signalWebhook = webhook
+	s.signalSecret = secret
+	return s
+}
+
+// handleV1Signals POST /api/v1/signals: сигнал (объект) или несколько сигналов (массив) в очередь стратегии
+func (s *Server) handleV1Signals(w http.ResponseWriter, r *http.Request) {
+	if s.signalWebhook == nil {
+		s.sendV1Error(w, http.StatusServiceUnavailable, V1Error{
+			Code:    v1ErrUnavailable,
+			Message: "Прием сигналов отключен (signals.enabled, signals.webhook)",
+		})
+		return
+	}
+
+	if s.signalSecret != "" {
+		secret := r.Header.Get("X-Signal-Secret")
+		if secret == "" {
+			secret = r.URL.Query().Get("secret")
+		}
+		if !secretsEqual(secret, s.signalSecret) {
+			s.sendV1Error(w, http.StatusUnauthorized, V1Error{
+				Code:    v1ErrUnauthorized,
+				Message: "Неверный секрет webhook: заголовок X-Signal-Secret или параметр secret",
+			})
+			return
+		}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSignalBody))
+	if err != nil {
+		s.sendV1Error(w, http.StatusRequestEntityTooLarge, V1Error{
+			Code:    v1ErrInvalidParameter,
+			Message: "Тело запроса больше 64 КБ",
+		})
+		return
+	}
+
+	received, err := signals.Decode(body, entities.SignalSourceWebhook)
+	if err == nil {
+		err = s.signalWebhook.Submit(received)
+	}
+	if err != nil {
+		s.sendV1Error(w, http.StatusBadRequest, V1Error{Code: v1ErrInvalidParameter, Message: err.Error()})
+		return
+	}
+
+	for _, signal := range received {
+		logger.LogWithTime("📨 Webhook: сигнал %q %s на %.2f принят (%s)", signal.ID, signal.Pair, signal.Amount, signal.Reason)
+	}
+	s.sendV1(w, http.StatusAccepted, V1SignalsAccepted{Accepted: len(received), Pending: s.signalWebhook.Pending()}, nil)
+}
@@ -0,0 +1,27 @@
+//go:build !headless
+
+package webui
+
+import (
+	"embed"
+	"html/template"
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+// pagesIncluded HTML страницы встроены в бинарный файл
+const pagesIncluded = true
+
+// templateFuncs функции шаблонов: t - перевод строки на язык страницы, translations - словарь для скриптов,
+// allows - роли пользователя достаточно для действия (кнопки недоступных действий не показываются)
+var templateFuncs = template.FuncMap{
+	"t":            translate,
+	"translations": translationsFor,
+	"allows":       roleAllows,
+}
+
+// parseTemplates разбирает встроенные HTML шаблоны
+func parseTemplates() (pageRenderer, error) {
+	return template.New("").Funcs(templateFuncs).ParseFS(templateFS, "templates/*.html")
+}
@@ -0,0 +1,11 @@
+//go:build headless
+
+package webui
+
+// pagesIncluded сборка с тегом headless: шаблоны и html/template исключены, веб-сервер отдает только API
+const pagesIncluded = false
+
+// parseTemplates в сборке headless шаблонов нет
+func parseTemplates() (pageRenderer, error) {
+	return nil, nil
+}
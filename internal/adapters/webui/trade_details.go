@@ -0,0 +1,215 @@
+package webui
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+)
+
+// tradePagePrefix префикс страницы сделки Freqtrade: /trades/{freqtrade_id}
+const tradePagePrefix = "/trades/"
+
+// FreqtradeTradeView сделка Freqtrade на странице сделки
+type FreqtradeTradeView struct {
+	ID          int        `json:"id"`
+	Pair        string     `json:"pair"`
+	IsOpen      bool       `json:"is_open"`
+	OpenRate    float64    `json:"open_rate"`
+	CurrentRate float64    `json:"current_rate"`
+	Amount      float64    `json:"amount"`
+	ProfitRatio float64    `json:"profit_ratio"`
+	OpenTime    *time.Time `json:"open_time"`
+	CloseTime   *time.Time `json:"close_time"`
+	Profit      *float64   `json:"profit"` // Прибыль в котируемой валюте: реализованная или плавающая по текущей цене
+	Live        bool       `json:"live"`   // Данные получены из Freqtrade (false - из первого хеджа на момент хеджирования)
+}
+
+// TradeEventView событие ордера хеджа на ленте событий сделки
+type TradeEventView struct {
+	OrderEventView
+	HedgeID   int64  `json:"hedge_id"`
+	OrderKind string `json:"order_kind"` // buy, take_profit, stop_loss
+}
+
+// TradeOutcomeView итог сделки с учетом хеджей в котируемой валюте
+type TradeOutcomeView struct {
+	FreqtradeProfit  *float64 `json:"freqtrade_profit"`   // Прибыль сделки Freqtrade (null - неизвестна)
+	HedgeNetProfit   float64  `json:"hedge_net_profit"`   // Прибыль закрытых хеджей после комиссий
+	HedgeUnrealized  float64  `json:"hedge_unrealized"`   // Плавающая прибыль открытых хеджей
+	Fees             float64  `json:"fees"`               // Комиссии всех хеджей
+	NetOutcome       *float64 `json:"net_outcome"`        // Сделка + хеджи (null - прибыль сделки неизвестна)
+	OpenHedges       int      `json:"open_hedges"`        // Незакрытых хеджей
+	CompletedHedges  int      `json:"completed_hedges"`   // Закрытых хеджей
+	InvestedInHedges float64  `json:"invested_in_hedges"` // Стоимость покупок всех хеджей
+}
+
+// TradeDetailsView сделка Freqtrade, ее хеджи, лента событий ордеров и итог
+type TradeDetailsView struct {
+	Trade   FreqtradeTradeView `json:"trade"`
+	Hedges  []TradeView        `json:"hedges"`
+	Events  []TradeEventView   `json:"events"`
+	Outcome TradeOutcomeView   `json:"outcome"`
+}
+
+// handleTradePage страница сделки Freqtrade со всеми хеджами и событиями их ордеров
+func (s *Server) handleTradePage(w http.ResponseWriter, r *http.Request) {
+	tradeID, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, tradePagePrefix))
+	if err != nil || tradeID <= 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	data := PageData{
+		Title:  "Сделка",
+		Config: tradeID,
+	}
+	if err := s.executeTemplate(w, r, "trade.html", data); err != nil {
+		log.Printf("❌ Ошибка рендеринга шаблона trade.html: %v", err)
+		return
+	}
+}
+
+// handleAPITradeDetails API сделки Freqtrade: контекст сделки, все хеджи (включая архив, старые первыми),
+// события ордеров хеджей в хронологическом порядке, комиссии и итог с учетом хеджей
+func (s *Server) handleAPITradeDetails(w http.ResponseWriter, r *http.Request, tradeID int) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+
+	details, err := s.hedgeUseCase.GetTradeDetails(ctx, tradeID)
+	if err != nil {
+		if strategyErr, ok := err.(*errors.StrategyError); ok && strategyErr.Type == errors.ErrorTypeTradeNotFound {
+			s.sendError(w, "Сделка Freqtrade "+strconv.Itoa(tradeID)+" не найдена и не хеджировалась", http.StatusNotFound)
+			return
+		}
+		log.Printf("❌ Ошибка получения сделки %d: %v", tradeID, err)
+		s.sendError(w, "Ошибка получения сделки", http.StatusInternalServerError)
+		return
+	}
+
+	hedges := s.convertToTradeViews(details.Hedges)
+	s.applyUnrealizedProfit(ctx, hedges, details.Hedges)
+
+	view := TradeDetailsView{
+		Trade:   newFreqtradeTradeView(details.Trade, details.Hedges),
+		Hedges:  hedges,
+		Events:  []TradeEventView{},
+		Outcome: newTradeOutcomeView(hedges),
+	}
+	view.Outcome.FreqtradeProfit = view.Trade.Profit
+	if view.Trade.Profit != nil {
+		net := *view.Trade.Profit + view.Outcome.HedgeNetProfit + view.Outcome.HedgeUnrealized
+		view.Outcome.NetOutcome = &net
+	}
+
+	if s.orderEventRepo != nil {
+		view.Events = s.tradeEvents(r, details.Hedges)
+	}
+
+	s.sendJSON(w, APIResponse{Success: true, Data: view})
+}
+
+// tradeEvents собирает события ордеров покупки, тейк-профита и стоп-лосса всех хеджей сделки.
+// Ошибка получения событий одного ордера не мешает показать остальные
+func (s *Server) tradeEvents(r *http.Request, hedges []*entities.HedgedTrade) []TradeEventView {
+	events := []TradeEventView{}
+	for _, hedge := range hedges {
+		orders := []struct{ id, kind string }{
+			{hedge.BuyOrderID, "buy"},
+			{hedge.BybitOrderID, "take_profit"},
+			{hedge.StopLossOrderID, "stop_loss"},
+		}
+		seen := make(map[string]bool)
+		for _, order := range orders {
+			if order.id == "" || seen[order.id] {
+				continue
+			}
+			seen[order.id] = true
+
+			orderEvents, err := s.orderEventRepo.GetOrderEvents(r.Context(), order.id)
+			if err != nil {
+				log.Printf("⚠️ Не удалось получить события ордера %s: %v", order.id, err)
+				continue
+			}
+			for _, event := range newOrderEventViews(orderEvents) {
+				events = append(events, TradeEventView{OrderEventView: event, HedgeID: hedge.HedgeID, OrderKind: order.kind})
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events
+}
+
+// newFreqtradeTradeView строит представление сделки Freqtrade. Если сделка не найдена в Freqtrade,
+// используются данные первого хеджа на момент хеджирования
+func newFreqtradeTradeView(trade *entities.Trade, hedges []*entities.HedgedTrade) FreqtradeTradeView {
+	if trade == nil {
+		first := hedges[0]
+		return FreqtradeTradeView{
+			ID:          first.FreqtradeTradeID,
+			Pair:        first.Pair,
+			OpenRate:    first.FreqtradeOpenPrice,
+			Amount:      first.FreqtradeAmount,
+			ProfitRatio: first.FreqtradeProfitRatio,
+		}
+	}
+
+	view := FreqtradeTradeView{
+		ID:          trade.ID,
+		Pair:        trade.Pair,
+		IsOpen:      trade.IsOpen,
+		OpenRate:    trade.OpenRate,
+		CurrentRate: trade.CurrentRate,
+		Amount:      trade.Amount,
+		ProfitRatio: trade.ProfitRatio,
+		CloseTime:   trade.CloseTime,
+		Live:        true,
+	}
+	if !trade.OpenTime.IsZero() {
+		openTime := trade.OpenTime
+		view.OpenTime = &openTime
+	}
+
+	var profit float64
+	if trade.CloseTime != nil {
+		profit = trade.CloseProfitAbs
+	} else {
+		profit = (trade.CurrentRate - trade.OpenRate) * trade.Amount
+	}
+	view.Profit = &profit
+	return view
+}
+
+// newTradeOutcomeView суммирует прибыль, плавающую прибыль и комиссии хеджей сделки
+func newTradeOutcomeView(hedges []TradeView) TradeOutcomeView {
+	var outcome TradeOutcomeView
+	for _, hedge := range hedges {
+		outcome.Fees += hedge.EntryFee + hedge.ExitFee
+		outcome.InvestedInHedges += hedge.OrderSizeUSD
+		if hedge.NetProfit != nil {
+			outcome.HedgeNetProfit += *hedge.NetProfit
+			outcome.CompletedHedges++
+			continue
+		}
+		if entities.OrderStatus(hedge.OrderStatus).IsCompleted() {
+			outcome.CompletedHedges++
+			continue
+		}
+		outcome.OpenHedges++
+		if hedge.UnrealizedProfit != nil {
+			outcome.HedgeUnrealized += *hedge.UnrealizedProfit
+		}
+	}
+	return outcome
+}
@@ -0,0 +1,122 @@
+package webui
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// maxTradesLimit максимальный размер страницы /api/trades
+const maxTradesLimit = 500
+
+// paramError ошибка параметра запроса; название параметра возвращается в ответах /api/v1
+type paramError struct {
+	param   string
+	message string
+}
+
+// Error возвращает текст ошибки (как в ответах /api/...)
+func (e *paramError) Error() string {
+	return e.message
+}
+
+// invalidParam создает ошибку параметра запроса
+func invalidParam(param, format string, args ...interface{}) error {
+	return &paramError{param: param, message: fmt.Sprintf(format, args...)}
+}
+
+// parseHedgeTradeQuery читает фильтры, сортировку и пагинацию /api/trades.
+// Даты from и to принимаются в формате YYYY-MM-DD (UTC, to включительно) или RFC3339
+func parseHedgeTradeQuery(r *http.Request) (*entities.HedgeTradeQuery, error) {
+	params := r.URL.Query()
+	query := &entities.HedgeTradeQuery{
+		Pair:            params.Get("pair"),
+		StrategyVersion: params.Get("version"),
+		SortBy:          params.Get("sort"),
+	}
+
+	if status := params.Get("status"); status != "" {
+		query.Status = &status
+	}
+
+	if query.SortBy == "" {
+		query.SortBy = entities.HedgeSortTime
+	}
+	if !entities.IsHedgeSortField(query.SortBy) {
+		return nil, invalidParam("sort", "неизвестное поле сортировки: %q", query.SortBy)
+	}
+	switch params.Get("order") {
+	case "", "desc":
+	case "asc":
+		query.Ascending = true
+	default:
+		return nil, invalidParam("order", "order должен быть asc или desc")
+	}
+
+	var err error
+	if query.From, err = parseTradesDate(params.Get("from"), false); err != nil {
+		return nil, invalidParam("from", "некорректная дата from: %v", err)
+	}
+	if query.To, err = parseTradesDate(params.Get("to"), true); err != nil {
+		return nil, invalidParam("to", "некорректная дата to: %v", err)
+	}
+
+	if value := params.Get("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil || query.Limit <= 0 {
+			return nil, invalidParam("limit", "limit должен быть положительным числом")
+		}
+		if query.Limit > maxTradesLimit {
+			query.Limit = maxTradesLimit
+		}
+	}
+	if value := params.Get("offset"); value != "" {
+		if query.Offset, err = strconv.Atoi(value); err != nil || query.Offset < 0 {
+			return nil, invalidParam("offset", "offset должен быть неотрицательным числом")
+		}
+	}
+
+	query.Facets = params.Get("facets") == "true"
+	query.Archived = params.Get("archived") == "true"
+	return query, nil
+}
+
+// maxStatsDays максимальный период статистики в днях (параметр days)
+const maxStatsDays = 3650
+
+// parseStatsQuery читает фильтры /api/stats: как в /api/trades, days - период в днях вместо from
+func parseStatsQuery(r *http.Request) (*entities.HedgeTradeQuery, error) {
+	query, err := parseHedgeTradeQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	if value := r.URL.Query().Get("days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days <= 0 || days > maxStatsDays {
+			return nil, invalidParam("days", "Параметр days (1-%d) вне допустимого диапазона", maxStatsDays)
+		}
+		since := time.Now().AddDate(0, 0, -days)
+		query.From = &since
+	}
+	return query, nil
+}
+
+// parseTradesDate разбирает дату фильтра; для верхней границы дата без времени означает конец дня
+func parseTradesDate(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
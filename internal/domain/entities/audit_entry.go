@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+// Ручные действия журнала аудита (AuditEntry.Action)
+const (
+	AuditActionExecute         = "execute"          // Внеочередной цикл стратегии хеджирования
+	AuditActionCheckStatus     = "check_status"     // Внеочередная проверка статусов ордеров
+	AuditActionManualHedge     = "manual_hedge"     // Ручное хеджирование сделки Freqtrade
+	AuditActionHedgeClose      = "hedge_close"      // Ручное закрытие хеджа
+	AuditActionConfigUpdate    = "config_update"    // Изменение параметров стратегии
+	AuditActionConfigRollback  = "config_rollback"  // Откат конфигурации к версии из истории
+	AuditActionSettingsUpdate  = "settings_update"  // Изменение параметров во время работы
+	AuditActionFeatureToggle   = "feature_toggle"   // Переключение флага возможности
+	AuditActionSchedulerPause  = "scheduler_pause"  // Пауза хеджирования
+	AuditActionSchedulerResume = "scheduler_resume" // Снятие паузы хеджирования
+	AuditActionDrain           = "drain"            // Завершение работы экземпляра с передачей аренды
+)
+
+// Итоги ручного действия (AuditEntry.Outcome)
+const (
+	AuditOutcomeSuccess = "success" // Действие выполнено
+	AuditOutcomeFailure = "failure" // Действие отклонено или завершилось ошибкой
+)
+
+// AuditEntry запись журнала аудита: кто, когда и с какими параметрами выполнил ручное действие и чем оно закончилось.
+// Позволяет разобраться, кто что сделал, когда ботом управляют несколько человек
+type AuditEntry struct {
+	ID         int64     // ID записи в хранилище
+	CreatedAt  time.Time // Время действия
+	Username   string    // Пользователь веб-интерфейса (пусто - аутентификация выключена)
+	RemoteAddr string    // Адрес клиента
+	Action     string    // Действие (AuditAction*)
+	Params     string    // Параметры запроса в JSON: путь, параметры URL и тело
+	Outcome    string    // Итог (AuditOutcome*)
+	Message    string    // Ответ сервера: результат или текст ошибки
+}
+
+// Succeeded проверяет, что действие выполнено
+func (e *AuditEntry) Succeeded() bool {
+	return e.Outcome == AuditOutcomeSuccess
+}
@@ -4,9 +4,10 @@ import "fmt"
 
 // Balance представляет баланс аккаунта
 type Balance struct {
-	Asset     string  // Валюта (например, USDT, BTC)
-	Available float64 // Доступный баланс
-	Total     float64 // Общий баланс
+	Asset     string   // Валюта (например, USDT, BTC)
+	Available float64  // Доступный баланс
+	Total     float64  // Общий баланс
+	USDValue  *float64 // Оценка общего баланса в USD по данным биржи (nil - неизвестна)
 }
 
 // HasSufficientBalance проверяет, достаточно ли средств для покупки
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// Источники версий конфигурации
+const (
+	ConfigSourceStartup  = "startup"  // Конфигурация, с которой запущен процесс
+	ConfigSourceRollback = "rollback" // Откат к предыдущей версии из веб-интерфейса
+	ConfigSourceWebUI    = "webui"    // Параметры стратегии, измененные на странице конфигурации
+)
+
+// ConfigVersion версия примененной конфигурации: кто и когда ее применил и чем она отличается от предыдущей
+type ConfigVersion struct {
+	ID             int       // ID версии
+	CreatedAt      time.Time // Время применения
+	Author         string    // Кто применил (пользователь@хост или адрес администратора)
+	Source         string    // Источник изменения (startup, rollback, webui)
+	Content        string    // Конфигурация в YAML без секретов
+	Diff           string    // Изменения относительно предыдущей версии (пусто для первой)
+	RolledBackFrom int       // ID версии, к которой выполнен откат (0 - не откат)
+}
+
+// IsRollback проверяет, что версия создана откатом
+func (v *ConfigVersion) IsRollback() bool {
+	return v.RolledBackFrom > 0
+}
@@ -0,0 +1,159 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CustomMetric пользовательская метрика оператора: скалярный результат read-only SQL запроса,
+// который отдается как gauge Prometheus и карточка дашборда
+type CustomMetric struct {
+	Name  string // Имя метрики Prometheus
+	Help  string // Описание метрики
+	Title string // Заголовок карточки дашборда (пусто - Help или Name)
+	Query string // SQL запрос, возвращающий одно число (одна строка, одна колонка)
+}
+
+// DisplayTitle возвращает заголовок карточки дашборда
+func (m *CustomMetric) DisplayTitle() string {
+	switch {
+	case m.Title != "":
+		return m.Title
+	case m.Help != "":
+		return m.Help
+	}
+	return m.Name
+}
+
+// CustomMetricValue значение пользовательской метрики на момент вычисления
+type CustomMetricValue struct {
+	Metric    CustomMetric
+	Value     *float64  // nil - запрос не вернул строк или вернул NULL
+	UpdatedAt time.Time // Время вычисления
+	Err       error     // Ошибка запроса (значение недоступно)
+}
+
+// metricNamePattern допустимое имя метрики Prometheus
+var metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// forbiddenQueryKeywords слова, с которыми запрос может изменить данные, схему или состояние соединения.
+// Запрос дополнительно выполняется в транзакции только для чтения - список отсекает ошибки заранее
+var forbiddenQueryKeywords = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true, "UPSERT": true,
+	"CREATE": true, "ALTER": true, "DROP": true, "TRUNCATE": true, "RENAME": true, "COMMENT": true,
+	"GRANT": true, "REVOKE": true, "COPY": true, "VACUUM": true, "ANALYZE": true, "REINDEX": true,
+	"CLUSTER": true, "REFRESH": true, "ATTACH": true, "DETACH": true, "PRAGMA": true, "CALL": true,
+	"DO": true, "EXECUTE": true, "PREPARE": true, "DEALLOCATE": true, "LOCK": true, "SET": true,
+	"RESET": true, "BEGIN": true, "COMMIT": true, "ROLLBACK": true, "SAVEPOINT": true, "RELEASE": true,
+	"LISTEN": true, "NOTIFY": true, "UNLISTEN": true, "DISCARD": true, "LOAD": true, "INTO": true,
+	"PG_SLEEP": true, "PG_TERMINATE_BACKEND": true, "PG_CANCEL_BACKEND": true, "DBLINK": true,
+	"LO_IMPORT": true, "LO_EXPORT": true, "PG_READ_FILE": true, "PG_READ_BINARY_FILE": true, "PG_LS_DIR": true,
+}
+
+// queryWordPattern слово запроса (ключевое слово, имя таблицы или функции)
+var queryWordPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_$]*`)
+
+// Validate проверяет имя метрики и запрос
+func (m *CustomMetric) Validate() error {
+	if !metricNamePattern.MatchString(m.Name) {
+		return fmt.Errorf("имя метрики %q не подходит для Prometheus (буквы, цифры, _ и :, не с цифры)", m.Name)
+	}
+	if err := ValidateReadOnlyQuery(m.Query); err != nil {
+		return fmt.Errorf("метрика %s: %w", m.Name, err)
+	}
+	return nil
+}
+
+// ValidateReadOnlyQuery проверяет, что запрос - один SELECT (или WITH ... SELECT) без слов,
+// изменяющих данные, схему или состояние соединения. Строковые литералы и комментарии не проверяются
+func ValidateReadOnlyQuery(query string) error {
+	stripped, err := stripQueryLiterals(query)
+	if err != nil {
+		return err
+	}
+	stripped = strings.TrimSpace(stripped)
+	stripped = strings.TrimSpace(strings.TrimSuffix(stripped, ";"))
+	if stripped == "" {
+		return fmt.Errorf("пустой запрос")
+	}
+	if strings.Contains(stripped, ";") {
+		return fmt.Errorf("запрос должен быть одной командой")
+	}
+
+	words := queryWordPattern.FindAllString(stripped, -1)
+	if len(words) == 0 {
+		return fmt.Errorf("запрос должен начинаться с SELECT или WITH")
+	}
+	if first := strings.ToUpper(words[0]); first != "SELECT" && first != "WITH" {
+		return fmt.Errorf("запрос должен начинаться с SELECT или WITH, получен: %s", words[0])
+	}
+	for _, word := range words {
+		if forbiddenQueryKeywords[strings.ToUpper(word)] {
+			return fmt.Errorf("запрос только для чтения не может содержать %s", strings.ToUpper(word))
+		}
+	}
+	// SELECT ... FOR UPDATE/SHARE блокирует строки
+	if strings.Contains(strings.ToUpper(strings.Join(strings.Fields(stripped), " ")), "FOR SHARE") {
+		return fmt.Errorf("запрос только для чтения не может блокировать строки (FOR SHARE)")
+	}
+	return nil
+}
+
+// stripQueryLiterals заменяет строковые литералы, идентификаторы в кавычках и комментарии пробелами,
+// чтобы слова внутри них не принимались за команды, а ';' внутри строки - за конец команды
+func stripQueryLiterals(query string) (string, error) {
+	var b strings.Builder
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\'' || runes[i] == '"':
+			quote := runes[i]
+			i++
+			for ; i < len(runes); i++ {
+				if runes[i] == quote {
+					if i+1 < len(runes) && runes[i+1] == quote {
+						i++ // Экранированная кавычка
+						continue
+					}
+					break
+				}
+			}
+			if i == len(runes) {
+				return "", fmt.Errorf("незакрытая кавычка в запросе")
+			}
+			// Идентификатор в кавычках остается словом запроса, но не ключевым
+			if quote == '"' {
+				b.WriteString(" ident ")
+			} else {
+				b.WriteString(" '' ")
+			}
+		case runes[i] == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			b.WriteRune(' ')
+		case runes[i] == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			if i+1 >= len(runes) {
+				return "", fmt.Errorf("незакрытый комментарий в запросе")
+			}
+			i++ // Закрывающий '/'
+			b.WriteRune(' ')
+		case runes[i] == '$' && i+1 < len(runes) && (runes[i+1] == '$' || isDollarTagStart(runes[i+1])):
+			return "", fmt.Errorf("строки в долларовых кавычках не поддерживаются")
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// isDollarTagStart проверяет начало метки долларовой кавычки PostgreSQL ($tag$), но не параметра ($1)
+func isDollarTagStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// TradeEvaluation наблюдение за открытой сделкой Freqtrade в одном цикле стратегии
+type TradeEvaluation struct {
+	TradeID          int       // ID сделки в Freqtrade
+	Pair             string    // Валютная пара
+	EvaluatedAt      time.Time // Время наблюдения
+	ProfitRatio      float64   // Коэффициент прибыли/убытка на момент наблюдения
+	CurrentRate      float64   // Цена на момент наблюдения
+	ThresholdCrossed bool      // Просадка превысила порог хеджирования
+}
+
+// Kline свеча цены инструмента
+type Kline struct {
+	StartTime time.Time
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+}
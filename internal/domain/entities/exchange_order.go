@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// ExchangeOrder ордер из истории биржевого аккаунта (в том числе размещенный вручную до запуска бота)
+type ExchangeOrder struct {
+	OrderID       string      // ID ордера на бирже
+	ClientOrderID string      // Клиентский ID (пустой для ручных ордеров)
+	Pair          string      // Валютная пара (например, SOL/USDT)
+	Side          OrderSide   // Направление
+	Type          OrderType   // Тип ордера
+	Status        OrderStatus // Итоговый статус
+	Price         float64     // Цена ордера (0 для рыночных)
+	Quantity      float64     // Запрошенное количество
+	FilledQty     float64     // Исполненное количество
+	AvgPrice      float64     // Средняя цена исполнения
+	CreatedAt     time.Time   // Время создания на бирже
+	UpdatedAt     time.Time   // Время последнего изменения на бирже
+}
+
+// FilledValue возвращает исполненный объем в котируемой валюте
+func (o *ExchangeOrder) FilledValue() float64 {
+	return o.FilledQty * o.AvgPrice
+}
@@ -0,0 +1,82 @@
+package entities
+
+import "time"
+
+// Флаги возможностей (features в конфигурации)
+const (
+	FlagAutoClose         = "auto_close"          // Закрытие хеджей по времени (flat)
+	FlagMarketBuyFallback = "market_buy_fallback" // Рыночная докупка неисполненной лимитной покупки
+	FlagConvertExecution  = "convert_execution"   // Покупка конвертацией по твердой котировке
+	FlagStopLoss          = "stop_loss"           // Стоп-лосс, связанный с тейк-профитом
+)
+
+// Источники состояния флага
+const (
+	FeatureFlagSourceDefault  = "default"  // Значение по умолчанию
+	FeatureFlagSourceConfig   = "config"   // Файл конфигурации или переменная окружения
+	FeatureFlagSourceDatabase = "database" // Переключено в веб-интерфейсе
+)
+
+// FeatureFlagDefinition флаг возможности: рискованную возможность можно отключить отдельно от остальных
+// без перезапуска. Новые подсистемы регистрируются с Default: false и включаются явно
+type FeatureFlagDefinition struct {
+	Key         string
+	Title       string
+	Description string
+	Default     bool
+}
+
+// FeatureFlagDefinitions зарегистрированные флаги возможностей
+var FeatureFlagDefinitions = []FeatureFlagDefinition{
+	{
+		Key:         FlagAutoClose,
+		Title:       "Закрытие хеджей по времени",
+		Description: "Ежедневное закрытие открытых хеджей по рынку (flat)",
+		Default:     true,
+	},
+	{
+		Key:         FlagMarketBuyFallback,
+		Title:       "Рыночная докупка",
+		Description: "Докупка по рынку остатка лимитной покупки, не исполненной за таймаут (strategy.buy_fallback: market)",
+		Default:     true,
+	},
+	{
+		Key:         FlagConvertExecution,
+		Title:       "Покупка конвертацией",
+		Description: "Покупка пар из strategy.convert_pairs конвертацией по твердой котировке вместо лимитного ордера",
+		Default:     true,
+	},
+	{
+		Key:         FlagStopLoss,
+		Title:       "Стоп-лосс",
+		Description: "Стоп-лосс, связанный с тейк-профитом как OCO (strategy.stop_loss_percent)",
+		Default:     true,
+	},
+}
+
+// FindFeatureFlag возвращает описание флага по ключу
+func FindFeatureFlag(key string) (FeatureFlagDefinition, bool) {
+	for _, definition := range FeatureFlagDefinitions {
+		if definition.Key == key {
+			return definition, true
+		}
+	}
+	return FeatureFlagDefinition{}, false
+}
+
+// FeatureFlag состояние флага, переключенное в веб-интерфейсе (переопределяет конфигурацию)
+type FeatureFlag struct {
+	Key       string
+	Enabled   bool
+	UpdatedAt time.Time
+	UpdatedBy string
+}
+
+// FeatureFlagChange запись журнала переключений флагов
+type FeatureFlagChange struct {
+	ID        int64     // ID записи
+	Key       string    // Ключ флага
+	Enabled   *bool     // Новое состояние (nil - сброс к конфигурации)
+	ChangedBy string    // Кто переключил
+	ChangedAt time.Time // Время переключения
+}
@@ -0,0 +1,44 @@
+package entities
+
+import "time"
+
+// Причины отказа от хеджирования сделки (HedgeDecision.Reason)
+const (
+	DecisionBelowThreshold      = "below_threshold"      // Просадка не превышает порог хеджирования
+	DecisionStrategySkipped     = "strategy_skipped"     // Стратегия не выделила сумму (например, исчерпаны ступени)
+	DecisionInFlight            = "in_flight"            // Хедж сделки в процессе открытия ожидает восстановления
+	DecisionPairLocked          = "pair_locked"          // Пара заблокирована Freqtrade
+	DecisionActiveOrder         = "active_order"         // У сделки есть хедж-ордер в ожидании
+	DecisionHedgeGroup          = "hedge_group"          // Сделка хеджируется группой ног, следующая нога - по расписанию
+	DecisionPortfolioCalm       = "portfolio_calm"       // Портфель не под нагрузкой
+	DecisionInsufficientBalance = "insufficient_balance" // Недостаточно средств на бирже
+	DecisionMinLimit            = "min_limit"            // Сумма позиции меньше минимального ордера
+	DecisionRiskLimit           = "risk_limit"           // Превышен общий лимит риска, лимит пары или котируемой валюты
+	DecisionPriceDeviation      = "price_deviation"      // Цена Freqtrade расходится с ценой биржи
+	DecisionQuoteConversion     = "quote_conversion"     // Пару нельзя перевести на рынок с базовой валютой
+	DecisionPreTradeFilter      = "pre_trade_filter"     // Отказ фильтра перед покупкой (черный список, спред, волатильность...)
+	DecisionError               = "error"                // Ошибка биржи или хранилища
+)
+
+// HedgeDecision решение не хеджировать сделку в цикле стратегии: позволяет разобрать, почему хеджей не было
+type HedgeDecision struct {
+	ID              int64     // ID решения в хранилище
+	TradeID         int       // ID сделки в Freqtrade
+	Pair            string    // Валютная пара
+	Reason          string    // Причина (Decision*)
+	Details         string    // Подробности: текст отказа
+	DrawdownPercent float64   // Просадка сделки в процентах на момент решения
+	DecidedAt       time.Time // Время решения
+}
+
+// NewHedgeDecision создает решение по сделке с ее текущей просадкой
+func NewHedgeDecision(trade *Trade, reason, details string, decidedAt time.Time) *HedgeDecision {
+	return &HedgeDecision{
+		TradeID:         trade.ID,
+		Pair:            trade.Pair,
+		Reason:          reason,
+		Details:         details,
+		DrawdownPercent: trade.ProfitRatio * -100,
+		DecidedAt:       decidedAt,
+	}
+}
@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// HedgeGroupStatus состояние хедж-группы
+type HedgeGroupStatus string
+
+const (
+	// HedgeGroupStatusActive ноги группы еще размещаются (или последняя покупка ожидает исполнения)
+	HedgeGroupStatusActive HedgeGroupStatus = "ACTIVE"
+	// HedgeGroupStatusCompleted все запланированные ноги размещены
+	HedgeGroupStatusCompleted HedgeGroupStatus = "COMPLETED"
+	// HedgeGroupStatusStopped размещение ног прекращено досрочно (сделка закрыта, нога закрыта, отказ фильтра)
+	HedgeGroupStatusStopped HedgeGroupStatus = "STOPPED"
+)
+
+// String возвращает строковое представление состояния
+func (s HedgeGroupStatus) String() string {
+	return string(s)
+}
+
+// HedgeGroup хедж крупной сделки Freqtrade, разбитый на ноги: сумма позиции больше максимального ордера
+// инструмента или доступной глубины стакана, поэтому покупка идет несколькими ордерами с интервалом.
+// Ноги - обычные хеджированные сделки с HedgeGroupID группы и общим тейк-профитом TakeProfitPrice
+type HedgeGroup struct {
+	ID               int64            // ID группы в хранилище (0 - еще не сохранена)
+	FreqtradeTradeID int              // ID сделки в Freqtrade
+	Pair             string           // Валютная пара рынка хеджа (например, BTC/USDT)
+	TotalAmount      float64          // Сумма хеджа в котируемой валюте (может быть меньше суммы стратегии при ограничении числа ног)
+	LegAmount        float64          // Сумма одной ноги в котируемой валюте
+	LegsPlanned      int              // Запланировано ног
+	LegsPlaced       int              // Размещено ног (покупка отправлена на биржу)
+	TakeProfitPrice  float64          // Общая цена тейк-профита ног (0 - еще не выставлен тейк-профит первой ноги)
+	Status           HedgeGroupStatus // Состояние группы
+	StatusReason     string           // Причина досрочной остановки
+	NextLegAt        time.Time        // Не раньше этого момента размещается следующая нога
+	CreatedAt        time.Time        // Создание группы
+	UpdatedAt        time.Time        // Последнее изменение
+}
+
+// NewHedgeGroup создает группу из legs равных ног на сумму totalAmount; первая нога размещается сразу
+func NewHedgeGroup(tradeID int, pair string, totalAmount float64, legs int, now time.Time) *HedgeGroup {
+	return &HedgeGroup{
+		FreqtradeTradeID: tradeID,
+		Pair:             pair,
+		TotalAmount:      totalAmount,
+		LegAmount:        totalAmount / float64(legs),
+		LegsPlanned:      legs,
+		Status:           HedgeGroupStatusActive,
+		NextLegAt:        now,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// IsActive проверяет, что группа еще размещает ноги
+func (g *HedgeGroup) IsActive() bool {
+	return g.Status == HedgeGroupStatusActive
+}
+
+// AllLegsPlaced проверяет, что все запланированные ноги размещены
+func (g *HedgeGroup) AllLegsPlaced() bool {
+	return g.LegsPlaced >= g.LegsPlanned
+}
+
+// LegDue проверяет, что подошло время следующей ноги
+func (g *HedgeGroup) LegDue(now time.Time) bool {
+	return g.IsActive() && !g.AllLegsPlaced() && !now.Before(g.NextLegAt)
+}
+
+// NextLegAmount возвращает сумму следующей ноги (последняя нога добирает остаток)
+func (g *HedgeGroup) NextLegAmount() float64 {
+	remaining := g.TotalAmount - g.LegAmount*float64(g.LegsPlaced)
+	return math.Max(math.Min(g.LegAmount, remaining), 0)
+}
+
+// LegPlaced отмечает размещение ноги; следующая нога - не раньше чем через interval
+func (g *HedgeGroup) LegPlaced(now time.Time, interval time.Duration) {
+	g.LegsPlaced++
+	g.NextLegAt = now.Add(interval)
+	g.UpdatedAt = now
+}
+
+// Complete завершает группу: все ноги размещены
+func (g *HedgeGroup) Complete(now time.Time) {
+	g.Status = HedgeGroupStatusCompleted
+	g.UpdatedAt = now
+}
+
+// Stop прекращает размещение оставшихся ног; размещенные ноги сопровождаются как обычные хеджи
+func (g *HedgeGroup) Stop(reason string, now time.Time) {
+	g.Status = HedgeGroupStatusStopped
+	g.StatusReason = reason
+	g.UpdatedAt = now
+}
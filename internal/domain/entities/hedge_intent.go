@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// HedgeState состояние хеджа в машине состояний INTENT → BUY_PLACED → BUY_FILLED → TP_PLACED → CLOSED
+type HedgeState string
+
+const (
+	// HedgeStateIntent намерение сохранено, ордер на покупку мог быть отправлен на биржу
+	HedgeStateIntent HedgeState = "INTENT"
+	// HedgeStateBuyPlaced ордер на покупку принят биржей
+	HedgeStateBuyPlaced HedgeState = "BUY_PLACED"
+	// HedgeStateBuyFilled покупка исполнена (полностью или частично), тейк-профит еще не выставлен
+	HedgeStateBuyFilled HedgeState = "BUY_FILLED"
+	// HedgeStateTPPlaced тейк-профит выставлен, хедж сохранен в хеджированных сделках
+	HedgeStateTPPlaced HedgeState = "TP_PLACED"
+	// HedgeStateClosed хедж завершен: тейк-профит исполнен/отменен или покупка не состоялась
+	HedgeStateClosed HedgeState = "CLOSED"
+)
+
+// hedgeStateTransitions допустимые переходы между состояниями хеджа
+var hedgeStateTransitions = map[HedgeState][]HedgeState{
+	HedgeStateIntent:    {HedgeStateBuyPlaced, HedgeStateClosed},
+	HedgeStateBuyPlaced: {HedgeStateBuyFilled, HedgeStateClosed},
+	HedgeStateBuyFilled: {HedgeStateTPPlaced, HedgeStateClosed},
+	HedgeStateTPPlaced:  {HedgeStateClosed},
+}
+
+// String возвращает строковое представление состояния
+func (s HedgeState) String() string {
+	return string(s)
+}
+
+// CanTransitionTo проверяет, допустим ли переход в указанное состояние
+func (s HedgeState) CanTransitionTo(next HedgeState) bool {
+	for _, allowed := range hedgeStateTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInFlight проверяет, находится ли хедж в процессе открытия (требует восстановления после перезапуска)
+func (s HedgeState) IsInFlight() bool {
+	return s == HedgeStateIntent || s == HedgeStateBuyPlaced || s == HedgeStateBuyFilled
+}
+
+// InFlightHedgeStates состояния, в которых хедж еще не защищен тейк-профитом
+var InFlightHedgeStates = []HedgeState{HedgeStateIntent, HedgeStateBuyPlaced, HedgeStateBuyFilled}
+
+// HedgeIntent намерение хеджирования и состояние его исполнения.
+// Сохраняется до обращения к бирже и продвигается по машине состояний, поэтому после
+// аварийного перезапуска хедж продолжается с места остановки вместо повторной покупки
+type HedgeIntent struct {
+	ClientOrderID    string     // Детерминированный клиентский ID ордера на покупку (orderLinkId)
+	FreqtradeTradeID int        // ID сделки во Freqtrade
+	Pair             string     // Торговая пара
+	Tranche          int        // Номер хеджа по сделке (количество предыдущих хеджей)
+	Attempt          int        // Номер попытки для этого хеджа
+	Quantity         float64    // Запрошенное количество
+	Price            float64    // Цена лимитного ордера
+	State            HedgeState // Текущее состояние
+	CreatedAt        time.Time  // Время создания
+	UpdatedAt        time.Time  // Время последнего перехода
+
+	BuyOrderID        string  // ID ордера на покупку (с BUY_PLACED)
+	FilledQty         float64 // Исполненное количество покупки (с BUY_FILLED)
+	TakeProfitOrderID string  // ID ордера тейк-профита (с TP_PLACED)
+
+	// Состояние сделки Freqtrade на момент намерения (для восстановления хеджа)
+	FreqtradeOpenPrice   float64
+	FreqtradeAmount      float64
+	FreqtradeProfitRatio float64
+	CurrentRate          float64
+}
+
+// TransitionTo переводит хедж в новое состояние, проверяя допустимость перехода
+func (i *HedgeIntent) TransitionTo(next HedgeState) error {
+	if !i.State.CanTransitionTo(next) {
+		return fmt.Errorf("недопустимый переход хеджа %s: %s → %s", i.ClientOrderID, i.State, next)
+	}
+	i.State = next
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// ToTrade восстанавливает состояние сделки Freqtrade на момент намерения
+func (i *HedgeIntent) ToTrade() *Trade {
+	return &Trade{
+		ID:          i.FreqtradeTradeID,
+		Pair:        i.Pair,
+		IsOpen:      true,
+		ProfitRatio: i.FreqtradeProfitRatio,
+		CurrentRate: i.CurrentRate,
+		OpenRate:    i.FreqtradeOpenPrice,
+		Amount:      i.FreqtradeAmount,
+	}
+}
+
+// BuildClientOrderID формирует детерминированный клиентский ID ордера для сделки, хеджа и попытки
+func BuildClientOrderID(tradeID, tranche, attempt int) string {
+	return fmt.Sprintf("th-%d-%d-%d", tradeID, tranche, attempt)
+}
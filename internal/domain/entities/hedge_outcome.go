@@ -0,0 +1,68 @@
+package entities
+
+import "time"
+
+// HedgeOutcome итог хеджирования по одной сделке Freqtrade:
+// реализованный результат сделки вместе с прибылью всех ее хеджей
+type HedgeOutcome struct {
+	FreqtradeTradeID int       // ID сделки в Freqtrade
+	Pair             string    // Валютная пара
+	FreqtradeProfit  float64   // Реализованная прибыль/убыток сделки в Freqtrade
+	HedgeProfit      float64   // Суммарная реализованная прибыль хеджей за вычетом комиссий
+	NetOutcome       float64   // Итог с учетом хеджирования (FreqtradeProfit + HedgeProfit)
+	Hedges           int       // Количество хеджей по сделке
+	TradeCloseTime   time.Time // Время закрытия сделки в Freqtrade
+	CalculatedAt     time.Time // Время расчета итога
+}
+
+// Improved показывает, улучшило ли хеджирование итог сделки
+func (o *HedgeOutcome) Improved() bool {
+	return o.HedgeProfit > 0
+}
+
+// OutcomeSummary агрегированная эффективность хеджирования
+type OutcomeSummary struct {
+	Trades          int     // Сделок с рассчитанным итогом
+	Improved        int     // Сделок, итог которых хеджирование улучшило
+	FreqtradeProfit float64 // Суммарный результат Freqtrade без хеджей
+	HedgeProfit     float64 // Суммарная прибыль хеджей
+	NetOutcome      float64 // Суммарный итог с учетом хеджирования
+}
+
+// NewHedgeOutcome рассчитывает итог сделки Freqtrade по ее хеджам
+func NewHedgeOutcome(trade *Trade, hedges []*HedgedTrade) *HedgeOutcome {
+	outcome := &HedgeOutcome{
+		FreqtradeTradeID: trade.ID,
+		Pair:             trade.Pair,
+		FreqtradeProfit:  trade.CloseProfitAbs,
+		Hedges:           len(hedges),
+		CalculatedAt:     time.Now(),
+	}
+	if trade.CloseTime != nil {
+		outcome.TradeCloseTime = *trade.CloseTime
+	}
+
+	for _, hedge := range hedges {
+		if profit := hedge.CalculateProfit(); profit != nil {
+			outcome.HedgeProfit += profit.Net
+		}
+	}
+	outcome.NetOutcome = outcome.FreqtradeProfit + outcome.HedgeProfit
+
+	return outcome
+}
+
+// SummarizeOutcomes агрегирует итоги хеджирования
+func SummarizeOutcomes(outcomes []*HedgeOutcome) OutcomeSummary {
+	var summary OutcomeSummary
+	for _, outcome := range outcomes {
+		summary.Trades++
+		if outcome.Improved() {
+			summary.Improved++
+		}
+		summary.FreqtradeProfit += outcome.FreqtradeProfit
+		summary.HedgeProfit += outcome.HedgeProfit
+		summary.NetOutcome += outcome.NetOutcome
+	}
+	return summary
+}
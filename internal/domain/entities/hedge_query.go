@@ -0,0 +1,193 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// Поля сортировки выборки хеджей
+const (
+	HedgeSortTime      = "hedge_time"         // Время хеджирования (по умолчанию)
+	HedgeSortCloseTime = "close_time"         // Время закрытия (открытые хеджи - последними)
+	HedgeSortPair      = "pair"               // Валютная пара
+	HedgeSortStatus    = "order_status"       // Статус ордера
+	HedgeSortAmount    = "hedge_amount"       // Количество базовой валюты
+	HedgeSortOrderSize = "order_size"         // Размер ордера в котируемой валюте
+	HedgeSortTradeID   = "freqtrade_trade_id" // ID сделки Freqtrade
+)
+
+// IsHedgeSortField проверяет, что поле сортировки поддерживается
+func IsHedgeSortField(field string) bool {
+	switch field {
+	case HedgeSortTime, HedgeSortCloseTime, HedgeSortPair, HedgeSortStatus,
+		HedgeSortAmount, HedgeSortOrderSize, HedgeSortTradeID:
+		return true
+	}
+	return false
+}
+
+// HedgeTradeQuery параметры выборки хеджей: фильтры, сортировка и пагинация
+type HedgeTradeQuery struct {
+	HedgeID         int64      // ID хеджа (0 - все)
+	Status          *string    // Статус ордера (nil - все)
+	Pair            string     // Валютная пара ("" - все)
+	StrategyVersion string     // Версия стратегии ("" - все)
+	From            *time.Time // Хеджи, открытые начиная с From
+	To              *time.Time // Хеджи, открытые раньше To
+
+	SortBy    string // Поле сортировки (HedgeSort*, по умолчанию - время хеджирования)
+	Ascending bool   // По возрастанию (по умолчанию - новые первыми)
+
+	Limit  int // Количество хеджей на странице (0 - без ограничения)
+	Offset int // Смещение от начала выборки
+
+	Facets bool // Вернуть списки пар и версий стратегии всех хеджей (для фильтров)
+
+	Archived bool // Выборка из архива давно закрытых хеджей вместо рабочей таблицы
+}
+
+// HedgeTradePage страница выборки хеджей
+type HedgeTradePage struct {
+	Trades []*HedgedTrade // Хеджи страницы
+	Total  int            // Количество хеджей, подходящих под фильтры, без учета пагинации
+
+	Pairs    []string // Пары всех хеджей без учета фильтров (при Facets)
+	Versions []string // Версии стратегии всех хеджей без учета фильтров (при Facets)
+}
+
+// Matches проверяет, что хедж подходит под фильтры выборки
+func (q *HedgeTradeQuery) Matches(trade *HedgedTrade) bool {
+	if q.HedgeID != 0 && trade.HedgeID != q.HedgeID {
+		return false
+	}
+	if q.Status != nil && trade.OrderStatus.String() != *q.Status {
+		return false
+	}
+	if q.Pair != "" && trade.Pair != q.Pair {
+		return false
+	}
+	if q.StrategyVersion != "" && trade.StrategyVersion != q.StrategyVersion {
+		return false
+	}
+	if q.From != nil && trade.HedgeTime.Before(*q.From) {
+		return false
+	}
+	if q.To != nil && !trade.HedgeTime.Before(*q.To) {
+		return false
+	}
+	return true
+}
+
+// Apply фильтрует, сортирует и разбивает на страницы хеджи в памяти
+// (для хранилищ без SQL; порядок и фильтры совпадают с SQL-реализациями)
+func (q *HedgeTradeQuery) Apply(trades []*HedgedTrade) *HedgeTradePage {
+	var matched []*HedgedTrade
+	for _, trade := range trades {
+		if q.Matches(trade) {
+			matched = append(matched, trade)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if cmp := compareHedges(q.SortBy, a, b); cmp != 0 {
+			return (cmp < 0) == q.Ascending
+		}
+		return (a.HedgeID < b.HedgeID) == q.Ascending
+	})
+
+	page := &HedgeTradePage{Total: len(matched)}
+	if q.Facets {
+		page.Pairs, page.Versions = hedgeFacets(trades)
+	}
+	if q.Offset >= len(matched) {
+		return page
+	}
+	matched = matched[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(matched) {
+		matched = matched[:q.Limit]
+	}
+	page.Trades = matched
+	return page
+}
+
+// hedgeFacets возвращает отсортированные списки пар и версий стратегии хеджей
+func hedgeFacets(trades []*HedgedTrade) (pairs, versions []string) {
+	seenPairs := make(map[string]bool)
+	seenVersions := make(map[string]bool)
+	for _, trade := range trades {
+		if !seenPairs[trade.Pair] {
+			seenPairs[trade.Pair] = true
+			pairs = append(pairs, trade.Pair)
+		}
+		if !seenVersions[trade.StrategyVersion] {
+			seenVersions[trade.StrategyVersion] = true
+			versions = append(versions, trade.StrategyVersion)
+		}
+	}
+	sort.Strings(pairs)
+	sort.Strings(versions)
+	return pairs, versions
+}
+
+// compareHedges сравнивает хеджи по полю сортировки: -1 если a меньше b, 1 если больше, 0 при равенстве
+func compareHedges(field string, a, b *HedgedTrade) int {
+	switch field {
+	case HedgeSortCloseTime:
+		// Открытые хеджи без времени закрытия считаются самыми поздними
+		switch {
+		case a.CloseTime == nil && b.CloseTime == nil:
+			return 0
+		case a.CloseTime == nil:
+			return 1
+		case b.CloseTime == nil:
+			return -1
+		}
+		return compareTimes(*a.CloseTime, *b.CloseTime)
+	case HedgeSortPair:
+		return compareStrings(a.Pair, b.Pair)
+	case HedgeSortStatus:
+		return compareStrings(a.OrderStatus.String(), b.OrderStatus.String())
+	case HedgeSortAmount:
+		return compareNumbers(a.HedgeAmount, b.HedgeAmount)
+	case HedgeSortOrderSize:
+		return compareNumbers(a.HedgeAmount*a.HedgeOpenPrice, b.HedgeAmount*b.HedgeOpenPrice)
+	case HedgeSortTradeID:
+		return compareNumbers(float64(a.FreqtradeTradeID), float64(b.FreqtradeTradeID))
+	default:
+		return compareTimes(a.HedgeTime, b.HedgeTime)
+	}
+}
+
+// compareTimes сравнивает время: -1 если a раньше b, 1 если позже, 0 при равенстве
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// compareStrings сравнивает строки лексикографически
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareNumbers сравнивает числа
+func compareNumbers(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
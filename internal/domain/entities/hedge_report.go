@@ -0,0 +1,49 @@
+package entities
+
+import "time"
+
+// Периоды сводки хеджирования
+const (
+	ReportPeriodDaily  = "daily"  // Последние 24 часа
+	ReportPeriodWeekly = "weekly" // Последние 7 дней
+)
+
+// ReportPeriodDuration возвращает длительность периода сводки (false - неизвестный период)
+func ReportPeriodDuration(period string) (time.Duration, bool) {
+	switch period {
+	case ReportPeriodDaily:
+		return 24 * time.Hour, true
+	case ReportPeriodWeekly:
+		return 7 * 24 * time.Hour, true
+	}
+	return 0, false
+}
+
+// HedgeReportItem хедж в сводке (самый прибыльный или убыточный)
+type HedgeReportItem struct {
+	HedgeID          int64   `json:"hedge_id"`
+	FreqtradeTradeID int     `json:"freqtrade_trade_id"`
+	Pair             string  `json:"pair"`
+	NetProfit        float64 `json:"net_profit"`
+}
+
+// HedgeReport сводка хеджирования за период [From, To): открытые и закрытые хеджи, реализованная прибыль
+type HedgeReport struct {
+	Period string    `json:"period"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+
+	Opened int `json:"opened"` // Хеджей открыто за период
+	Closed int `json:"closed"` // Хеджей закрыто за период
+	Active int `json:"active"` // Открытых хеджей на конец периода
+
+	RealizedProfit float64 `json:"realized_profit"` // Прибыль закрытых хеджей до комиссий
+	NetProfit      float64 `json:"net_profit"`      // Прибыль закрытых хеджей после комиссий
+	Fees           float64 `json:"fees"`            // Комиссии закрытых хеджей
+	Wins           int     `json:"wins"`            // Закрыто с прибылью после комиссий
+	Losses         int     `json:"losses"`          // Закрыто с убытком после комиссий
+	WinRate        float64 `json:"win_rate"`        // Доля прибыльных среди закрытых с прибылью или убытком, %
+
+	BestHedge  *HedgeReportItem `json:"best_hedge"`  // Самый прибыльный закрытый хедж (null - нет закрытых)
+	WorstHedge *HedgeReportItem `json:"worst_hedge"` // Самый убыточный закрытый хедж (null - нет закрытых)
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// Источники сигналов хеджирования
+const (
+	SignalSourceWebhook = "webhook" // POST /api/v1/signals (алерты TradingView, сканеры)
+	SignalSourceFile    = "file"    // JSON файлы в каталоге
+	SignalSourceRedis   = "redis"   // Список Redis
+)
+
+// HedgeSignal внешний сигнал хеджирования: «хеджировать пару сейчас на указанную сумму».
+// Сигнал проходит те же фильтры, лимиты риска и сохранение, что и сделки Freqtrade
+type HedgeSignal struct {
+	ID       string  `json:"id"`       // ID сигнала у источника: повтор с тем же ID не исполняется
+	Pair     string  `json:"pair"`     // Пара: SOL/USDT или символ биржи SOLUSDT
+	Amount   float64 `json:"amount"`   // Сумма позиции в котируемой валюте
+	Drawdown float64 `json:"drawdown"` // Просадка в процентах для расчета тейк-профита (0 - порог max_loss_percent)
+	Reason   string  `json:"reason"`   // Описание (название алерта, сканера)
+
+	Source     string    `json:"-"` // Источник сигнала (SignalSource*)
+	ReceivedAt time.Time `json:"-"` // Время получения
+}
+
+// Normalize приводит пару к формату BASE/QUOTE (символ биржи SOLUSDT → SOL/USDT) и проверяет сигнал
+func (s *HedgeSignal) Normalize() error {
+	pair := strings.ToUpper(strings.TrimSpace(s.Pair))
+	if pair == "" {
+		return fmt.Errorf("не указана пара")
+	}
+	parsed, err := valueobjects.FromExchangeSymbol(valueobjects.ExchangeBybit, pair)
+	if err != nil {
+		return err
+	}
+	s.Pair = parsed.String()
+
+	if s.Amount <= 0 {
+		return fmt.Errorf("сумма сигнала должна быть больше 0, получена: %.8f", s.Amount)
+	}
+	if s.Drawdown < 0 || s.Drawdown >= 100 {
+		return fmt.Errorf("просадка сигнала должна быть в диапазоне 0-100, получена: %.2f", s.Drawdown)
+	}
+	return nil
+}
+
+// Key возвращает ключ повтора сигнала: источник и ID (сигналы без ID не сравниваются)
+func (s *HedgeSignal) Key() string {
+	if s.ID == "" {
+		return ""
+	}
+	return s.Source + ":" + s.ID
+}
+
+// SignalTradeID возвращает ID виртуальной сделки пары для хеджей по сигналам.
+// Отрицательный ID не пересекается со сделками Freqtrade; хеджи сигналов одной пары образуют одну историю
+func SignalTradeID(pair string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(pair))
+	return -int(hash.Sum32()&0x7fffffff) - 1
+}
+
+// IsSignalTradeID проверяет, что ID сделки принадлежит хеджам по сигналам
+func IsSignalTradeID(tradeID int) bool {
+	return tradeID < 0
+}
@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// HedgeStats агрегированная статистика хеджей выборки
+type HedgeStats struct {
+	Total     int // Всего хеджей
+	Active    int // Открытых хеджей
+	Completed int // Завершенных хеджей
+
+	OrderSize      float64 // Суммарный размер ордеров на покупку в котируемой валюте
+	RealizedProfit float64 // Прибыль закрытых хеджей до комиссий
+	NetProfit      float64 // Прибыль закрытых хеджей за вычетом комиссий
+	Fees           float64 // Комиссии закрытых хеджей
+
+	Wins   int // Закрытые хеджи с положительной прибылью после комиссий
+	Losses int // Закрытые хеджи с отрицательной прибылью после комиссий
+
+	AvgHoldTime time.Duration // Среднее время от хеджирования до закрытия (завершенные хеджи с временем закрытия)
+
+	ByVersion []HedgeVersionStats // Результаты в разрезе версий стратегии, начиная с последней использованной
+}
+
+// HedgeVersionStats статистика хеджей одной версии стратегии
+type HedgeVersionStats struct {
+	Version        string
+	Total          int
+	Completed      int
+	RealizedProfit float64
+}
+
+// WinRate возвращает долю прибыльных хеджей среди закрытых с прибылью или убытком, в процентах
+func (s *HedgeStats) WinRate() float64 {
+	closed := s.Wins + s.Losses
+	if closed == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(closed) * 100
+}
+
+// CompletedOrderStatuses возвращает терминальные статусы в порядке сортировки (для SQL-агрегации)
+func CompletedOrderStatuses() []OrderStatus {
+	var statuses []OrderStatus
+	for status, terminal := range terminalOrderStatuses {
+		if terminal {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i] < statuses[j]
+	})
+	return statuses
+}
+
+// ComputeHedgeStats считает статистику хеджей в памяти
+// (для хранилищ без SQL; результаты совпадают с SQL-агрегацией)
+func ComputeHedgeStats(trades []*HedgedTrade) *HedgeStats {
+	stats := &HedgeStats{Total: len(trades)}
+
+	versions := make(map[string]*HedgeVersionStats)
+	lastUsed := make(map[string]time.Time)
+	var holdTotal time.Duration
+	holdCount := 0
+
+	for _, trade := range trades {
+		version, ok := versions[trade.StrategyVersion]
+		if !ok {
+			version = &HedgeVersionStats{Version: trade.StrategyVersion}
+			versions[trade.StrategyVersion] = version
+		}
+		version.Total++
+		if trade.HedgeTime.After(lastUsed[trade.StrategyVersion]) {
+			lastUsed[trade.StrategyVersion] = trade.HedgeTime
+		}
+
+		stats.OrderSize += trade.HedgeAmount * trade.HedgeOpenPrice
+
+		if trade.IsActive() {
+			stats.Active++
+			continue
+		}
+		stats.Completed++
+		version.Completed++
+
+		if trade.CloseTime != nil {
+			holdTotal += trade.CloseTime.Sub(trade.HedgeTime)
+			holdCount++
+		}
+
+		profit := trade.CalculateProfit()
+		if profit == nil {
+			continue
+		}
+		stats.RealizedProfit += profit.Gross
+		stats.NetProfit += profit.Net
+		stats.Fees += trade.TotalFees()
+		version.RealizedProfit += profit.Gross
+		switch {
+		case profit.Net > 0:
+			stats.Wins++
+		case profit.Net < 0:
+			stats.Losses++
+		}
+	}
+
+	if holdCount > 0 {
+		stats.AvgHoldTime = holdTotal / time.Duration(holdCount)
+	}
+
+	for _, version := range versions {
+		stats.ByVersion = append(stats.ByVersion, *version)
+	}
+	sort.Slice(stats.ByVersion, func(i, j int) bool {
+		return lastUsed[stats.ByVersion[i].Version].After(lastUsed[stats.ByVersion[j].Version])
+	})
+	return stats
+}
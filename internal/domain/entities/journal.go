@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// JournalEntry запись торгового журнала: контекст решения, привязанный к дате или хеджу
+type JournalEntry struct {
+	ID           int       // ID записи
+	CreatedAt    time.Time // Время создания записи
+	EntryDate    time.Time // Дата, к которой относится запись
+	HedgeOrderID string    // ID ордера хеджа (пусто, если запись относится только к дате)
+	Text         string    // Текст записи
+}
+
+// IsLinkedToHedge проверяет, привязана ли запись к конкретному хеджу
+func (e *JournalEntry) IsLinkedToHedge() bool {
+	return e.HedgeOrderID != ""
+}
+
+// AppliesTo проверяет, относится ли запись к хеджированной сделке:
+// по ID ордера хеджа или по дате хеджирования
+func (e *JournalEntry) AppliesTo(trade *HedgedTrade) bool {
+	if e.IsLinkedToHedge() {
+		return e.HedgeOrderID == trade.BybitOrderID || e.HedgeOrderID == trade.BuyOrderID
+	}
+	return e.EntryDate.Format("2006-01-02") == trade.HedgeTime.Format("2006-01-02")
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// Lease аренда роли ведущего экземпляра: только держатель аренды размещает ордера
+type Lease struct {
+	Name               string    // Название аренды
+	Holder             string    // ID экземпляра-держателя (пусто - аренда свободна)
+	AcquiredAt         time.Time // Когда текущий держатель получил аренду
+	ExpiresAt          time.Time // Аренда истекает, если держатель ее не продлит
+	HandoffRequestedBy string    // ID экземпляра, запросившего передачу аренды (пусто - запроса нет)
+}
+
+// HandoffRequested проверяет, запросил ли другой экземпляр передачу аренды
+func (l *Lease) HandoffRequested() bool {
+	return l.HandoffRequestedBy != "" && l.HandoffRequestedBy != l.Holder
+}
@@ -0,0 +1,77 @@
+package entities
+
+import "time"
+
+// SnapshotPrice цена пары в снимке и время ее получения
+type SnapshotPrice struct {
+	Price float64   `json:"price"`
+	At    time.Time `json:"at"`
+}
+
+// MarketSnapshot последние успешно полученные балансы и цены. Показывается веб-интерфейсом,
+// когда Bybit или Freqtrade недоступны, вместо пустых панелей
+type MarketSnapshot struct {
+	Balances   map[string]*Balance      // Балансы по валютам
+	BalancesAt *time.Time               // Время получения балансов (nil - балансов еще не было)
+	Prices     map[string]SnapshotPrice // Цены по парам (SOL/USDT)
+}
+
+// NewMarketSnapshot создает пустой снимок
+func NewMarketSnapshot() *MarketSnapshot {
+	return &MarketSnapshot{
+		Balances: make(map[string]*Balance),
+		Prices:   make(map[string]SnapshotPrice),
+	}
+}
+
+// SetBalances заменяет балансы снимка полученными в момент at
+func (s *MarketSnapshot) SetBalances(balances map[string]*Balance, at time.Time) {
+	s.Balances = balances
+	s.BalancesAt = &at
+}
+
+// SetPrices обновляет цены пар, полученные в момент at; цены остальных пар сохраняются со своим временем
+func (s *MarketSnapshot) SetPrices(prices map[string]float64, at time.Time) {
+	if s.Prices == nil {
+		s.Prices = make(map[string]SnapshotPrice, len(prices))
+	}
+	for pair, price := range prices {
+		if price > 0 {
+			s.Prices[pair] = SnapshotPrice{Price: price, At: at}
+		}
+	}
+}
+
+// PricesFor возвращает цены запрошенных пар из снимка и время самой старой из них (nil - цен нет)
+func (s *MarketSnapshot) PricesFor(pairs []string) (map[string]float64, *time.Time) {
+	prices := make(map[string]float64, len(pairs))
+	var oldest *time.Time
+	for _, pair := range pairs {
+		price, ok := s.Prices[pair]
+		if !ok {
+			continue
+		}
+		prices[pair] = price.Price
+		if oldest == nil || price.At.Before(*oldest) {
+			at := price.At
+			oldest = &at
+		}
+	}
+	return prices, oldest
+}
+
+// Clone возвращает копию снимка (карты копируются, балансы разделяются - они не изменяются)
+func (s *MarketSnapshot) Clone() *MarketSnapshot {
+	clone := &MarketSnapshot{
+		Balances:   make(map[string]*Balance, len(s.Balances)),
+		BalancesAt: s.BalancesAt,
+		Prices:     make(map[string]SnapshotPrice, len(s.Prices)),
+	}
+	for asset, balance := range s.Balances {
+		clone.Balances[asset] = balance
+	}
+	for pair, price := range s.Prices {
+		clone.Prices[pair] = price
+	}
+	return clone
+}
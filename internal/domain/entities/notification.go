@@ -0,0 +1,150 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// NotificationPriority приоритет оповещения
+type NotificationPriority int
+
+const (
+	NotificationPriorityLow    NotificationPriority = iota // Информационное
+	NotificationPriorityNormal                             // Обычное
+	NotificationPriorityHigh                               // Требует внимания оператора
+)
+
+// String возвращает строковое представление приоритета
+func (p NotificationPriority) String() string {
+	switch p {
+	case NotificationPriorityLow:
+		return "LOW"
+	case NotificationPriorityHigh:
+		return "HIGH"
+	default:
+		return "NORMAL"
+	}
+}
+
+// ParseNotificationPriority разбирает приоритет по имени: low, normal, high (регистр не важен)
+func ParseNotificationPriority(name string) (NotificationPriority, bool) {
+	switch strings.ToLower(name) {
+	case "low":
+		return NotificationPriorityLow, true
+	case "normal":
+		return NotificationPriorityNormal, true
+	case "high":
+		return NotificationPriorityHigh, true
+	}
+	return NotificationPriorityLow, false
+}
+
+// События оповещений: по ним внешние системы (webhook) отбирают и разбирают оповещения
+const (
+	NotificationEventAlert          = "alert"           // Оповещение о состоянии системы (ошибки циклов, зависания, балансы)
+	NotificationEventHedgeOpened    = "hedge_opened"    // Хедж открыт: куплено и выставлен тейк-профит
+	NotificationEventHedgeClosed    = "hedge_closed"    // Хедж закрыт по тейк-профиту или стоп-лоссу
+	NotificationEventHedgeFailed    = "hedge_failed"    // Не удалось хеджировать сделку
+	NotificationEventCycleCompleted = "cycle_completed" // Цикл стратегии завершен
+	NotificationEventReport         = "report"          // Сводка хеджирования за день или неделю
+)
+
+// NotificationEvents все события оповещений
+var NotificationEvents = []string{
+	NotificationEventAlert,
+	NotificationEventHedgeOpened,
+	NotificationEventHedgeClosed,
+	NotificationEventHedgeFailed,
+	NotificationEventCycleCompleted,
+	NotificationEventReport,
+}
+
+// IsNotificationEvent проверяет, что событие оповещений поддерживается
+func IsNotificationEvent(event string) bool {
+	for _, known := range NotificationEvents {
+		if event == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification оповещение оператора
+type Notification struct {
+	Title     string
+	Message   string
+	Priority  NotificationPriority
+	CreatedAt time.Time
+
+	Key         string // Ключ группировки повторяющихся оповещений об одном условии ("" - без группировки)
+	Occurrences int    // Сколько раз условие повторилось к моменту оповещения
+	Resolved    bool   // Оповещение об устранении условия
+
+	RiskReward *HedgeRiskReward // Риск и прибыль открытого хеджа: каждый канал оформляет их по-своему (nil - нет)
+
+	Event string                 // Событие оповещения ("" - NotificationEventAlert)
+	Data  map[string]interface{} // Данные события для внешних систем: пара, ID сделки и ордера, суммы (nil - нет)
+}
+
+// NewNotification создает оповещение с текущим временем
+func NewNotification(priority NotificationPriority, title, message string) *Notification {
+	return &Notification{
+		Title:     title,
+		Message:   message,
+		Priority:  priority,
+		CreatedAt: time.Now(),
+	}
+}
+
+// WithRiskReward прикладывает к оповещению риск и прибыль хеджа
+func (n *Notification) WithRiskReward(riskReward *HedgeRiskReward) *Notification {
+	n.RiskReward = riskReward
+	return n
+}
+
+// WithKey задает ключ группировки оповещения
+func (n *Notification) WithKey(key string) *Notification {
+	n.Key = key
+	return n
+}
+
+// WithEvent задает событие оповещения и его данные
+func (n *Notification) WithEvent(event string, data map[string]interface{}) *Notification {
+	n.Event = event
+	n.Data = data
+	return n
+}
+
+// EventName возвращает событие оповещения; оповещения без события относятся к состоянию системы
+func (n *Notification) EventName() string {
+	if n.Event == "" {
+		return NotificationEventAlert
+	}
+	return n.Event
+}
+
+// HedgeEventData данные хеджа для событий hedge_opened и hedge_closed
+func HedgeEventData(hedge *HedgedTrade) map[string]interface{} {
+	data := map[string]interface{}{
+		"hedge_id":           hedge.HedgeID,
+		"freqtrade_trade_id": hedge.FreqtradeTradeID,
+		"pair":               hedge.Pair,
+		"order_id":           hedge.BybitOrderID,
+		"buy_order_id":       hedge.BuyOrderID,
+		"amount":             hedge.HedgeAmount,
+		"open_price":         hedge.HedgeOpenPrice,
+		"take_profit_price":  hedge.HedgeTakeProfitPrice,
+		"order_status":       string(hedge.OrderStatus),
+	}
+	if hedge.StopLossPrice > 0 {
+		data["stop_loss_price"] = hedge.StopLossPrice
+	}
+	if hedge.ClosePrice != nil {
+		data["close_price"] = *hedge.ClosePrice
+	}
+	if profit := hedge.CalculateProfit(); profit != nil {
+		data["profit"] = profit.Gross
+		data["net_profit"] = profit.Net
+	}
+	return data
+}
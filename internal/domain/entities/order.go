@@ -1,5 +1,7 @@
 package entities
 
+import "trade-hedge/internal/domain/valueobjects"
+
 // OrderSide представляет направление ордера
 type OrderSide string
 
@@ -16,6 +18,24 @@ const (
 	OrderTypeLimit  OrderType = "LIMIT"
 )
 
+// TimeInForce определяет, сколько ордер остается активным
+type TimeInForce string
+
+const (
+	TimeInForceGTC      TimeInForce = "GTC"      // До отмены
+	TimeInForceIOC      TimeInForce = "IOC"      // Исполнить немедленно, остаток отменить
+	TimeInForceFOK      TimeInForce = "FOK"      // Исполнить немедленно полностью или отменить
+	TimeInForcePostOnly TimeInForce = "PostOnly" // Только мейкер: отменяется, если исполнился бы сразу
+)
+
+// OrderCategory рынок, на котором размещается ордер
+type OrderCategory string
+
+const (
+	OrderCategorySpot   OrderCategory = "spot"
+	OrderCategoryLinear OrderCategory = "linear" // Бессрочные и срочные USDT-контракты
+)
+
 // Order представляет торговый ордер
 type Order struct {
 	Symbol   string
@@ -23,13 +43,31 @@ type Order struct {
 	Type     OrderType
 	Quantity float64
 	Price    float64 // Для лимитных ордеров
+
+	ClientOrderID string        // Клиентский ID ордера (orderLinkId), пусто - биржа не получает ID
+	TimeInForce   TimeInForce   // Время действия ордера
+	Category      OrderCategory // Рынок ордера
+	QuoteQuantity bool          // Количество рыночного ордера указано в котируемой валюте (сумма), а не в базовой
+
+	PriceDecimals    int32 // Знаков после запятой в цене по шагу цены инструмента (PrecisionUnknown - по умолчанию)
+	QuantityDecimals int32 // Знаков после запятой в количестве по шагу количества инструмента
 }
 
+// PrecisionUnknown точность инструмента неизвестна: цена форматируется с 8 знаками, количество - с 6
+const PrecisionUnknown int32 = -1
+
+// Точность по умолчанию, если шаги инструмента неизвестны
+const (
+	defaultPriceDecimals    int32 = 8
+	defaultQuantityDecimals int32 = 6
+)
+
 // OrderResult представляет результат размещения ордера
 type OrderResult struct {
-	OrderID string
-	Success bool
-	Error   string
+	OrderID     string
+	Success     bool
+	Error       string
+	RawResponse string `json:"-"` // Необработанный ответ биржи (JSON), пусто - неизвестен
 }
 
 // NewMarketOrder создает рыночный ордер
@@ -40,6 +78,13 @@ func NewMarketOrder(symbol string, side OrderSide, quantity float64) *Order {
 		Type:     OrderTypeMarket,
 		Quantity: quantity,
 		Price:    0, // Цена не нужна для рыночного ордера
+
+		// Рыночный ордер исполняется сразу, неисполненный остаток не остается в стакане
+		TimeInForce: TimeInForceIOC,
+		Category:    OrderCategorySpot,
+
+		PriceDecimals:    PrecisionUnknown,
+		QuantityDecimals: PrecisionUnknown,
 	}
 }
 
@@ -51,10 +96,79 @@ func NewLimitOrder(symbol string, side OrderSide, quantity, price float64) *Orde
 		Type:     OrderTypeLimit,
 		Quantity: quantity,
 		Price:    price,
+
+		TimeInForce: TimeInForceGTC,
+		Category:    OrderCategorySpot,
+
+		PriceDecimals:    PrecisionUnknown,
+		QuantityDecimals: PrecisionUnknown,
 	}
 }
 
+// WithClientOrderID задает клиентский ID ордера (делает повторное размещение идемпотентным)
+func (o *Order) WithClientOrderID(clientOrderID string) *Order {
+	o.ClientOrderID = clientOrderID
+	return o
+}
+
+// WithTimeInForce задает время действия ордера
+func (o *Order) WithTimeInForce(timeInForce TimeInForce) *Order {
+	o.TimeInForce = timeInForce
+	return o
+}
+
+// WithCategory задает рынок ордера
+func (o *Order) WithCategory(category OrderCategory) *Order {
+	o.Category = category
+	return o
+}
+
+// WithQuoteQuantity указывает, что количество рыночного ордера - сумма в котируемой валюте
+func (o *Order) WithQuoteQuantity() *Order {
+	o.QuoteQuantity = true
+	return o
+}
+
+// WithInstrumentSteps задает точность цены и количества по шагам инструмента (0 - шаг неизвестен)
+func (o *Order) WithInstrumentSteps(tickSize, stepSize float64) *Order {
+	if tickSize > 0 {
+		o.PriceDecimals = valueobjects.NewDecimalFromFloat(tickSize).DecimalPlaces()
+	}
+	if stepSize > 0 {
+		o.QuantityDecimals = valueobjects.NewDecimalFromFloat(stepSize).DecimalPlaces()
+	}
+	return o
+}
+
+// FormatQuantity возвращает количество в десятичной записи с точностью инструмента.
+// Лишние знаки отбрасываются, чтобы не продать и не купить больше запрошенного
+func (o *Order) FormatQuantity() string {
+	places := o.QuantityDecimals
+	if places == PrecisionUnknown {
+		places = defaultQuantityDecimals
+	}
+	return valueobjects.NewDecimalFromFloat(o.Quantity).Round(places, valueobjects.RoundFloor).StringFixed(places)
+}
+
+// FormatPrice возвращает цену в десятичной записи с точностью инструмента
+func (o *Order) FormatPrice() string {
+	places := o.PriceDecimals
+	if places == PrecisionUnknown {
+		places = defaultPriceDecimals
+	}
+	return valueobjects.NewDecimalFromFloat(o.Price).StringFixed(places)
+}
+
 // CalculateQuantityFromAmount рассчитывает количество валюты для покупки на определенную сумму
+// в десятичной арифметике (точное частное, например 0.3, а не 0.29999999999999998)
 func CalculateQuantityFromAmount(amount, currentPrice float64) float64 {
-	return amount / currentPrice
+	if currentPrice <= 0 {
+		return 0
+	}
+	return valueobjects.NewDecimalFromFloat(amount).
+		Div(valueobjects.NewDecimalFromFloat(currentPrice), quantityDivisionScale, valueobjects.RoundFloor).
+		Float64()
 }
+
+// quantityDivisionScale точность частного суммы и цены (с запасом относительно шага количества любой биржи)
+const quantityDivisionScale = 18
@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+// OrderEvent событие в истории ордера хеджа: размещение, смена статуса, отмена
+type OrderEvent struct {
+	ID        int
+	OrderID   string      // ID ордера на бирже
+	Pair      string      // Валютная пара
+	OldStatus OrderStatus // Статус до события (пусто - ордер только размещен)
+	NewStatus OrderStatus // Статус после события
+	FilledQty float64     // Исполненное количество на момент события
+	Price     float64     // Цена ордера или средняя цена исполнения
+	Timestamp time.Time
+	Payload   string // Исходные данные события (JSON ответа биржи или ордера)
+
+	// RawPayload необработанный ответ биржи на размещение или запрос статуса (JSON, пусто - нет ответа).
+	// Сохраняется как есть для разбора спорных случаев: неверной средней цены, отклоненных ордеров
+	RawPayload string
+}
+
+// NewOrderEvent создает событие ордера с текущим временем
+func NewOrderEvent(orderID, pair string, oldStatus, newStatus OrderStatus, filledQty, price float64, payload string) *OrderEvent {
+	return &OrderEvent{
+		OrderID:   orderID,
+		Pair:      pair,
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+		FilledQty: filledQty,
+		Price:     price,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+}
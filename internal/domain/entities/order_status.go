@@ -1,5 +1,7 @@
 package entities
 
+import "sort"
+
 // OrderStatus представляет статус ордера
 type OrderStatus string
 
@@ -7,6 +9,10 @@ const (
 	// OrderStatusPending ордер размещен, но не исполнен
 	OrderStatusPending OrderStatus = "PENDING"
 
+	// OrderStatusBuyPending ордер на покупку хеджа размещен и ожидает исполнения,
+	// тейк-профит будет выставлен в одном из следующих циклов
+	OrderStatusBuyPending OrderStatus = "BUY_PENDING"
+
 	// OrderStatusFilled ордер полностью исполнен
 	OrderStatusFilled OrderStatus = "FILLED"
 
@@ -19,15 +25,79 @@ const (
 	// OrderStatusRejected ордер отклонен
 	OrderStatusRejected OrderStatus = "REJECTED"
 
+	// OrderStatusClosedManual хедж закрыт вручную из веб-интерфейса: ордера отменены,
+	// позиция продана (цена закрытия известна) или оставлена на балансе
+	OrderStatusClosedManual OrderStatus = "CLOSED_MANUAL"
+
 	// OrderStatusUnknown неизвестный статус
 	OrderStatusUnknown OrderStatus = "UNKNOWN"
 )
 
+// terminalOrderStatuses статусы, после которых ордер больше не меняется (успешно или неуспешно).
+// UNKNOWN не терминальный: такой ордер проверяется повторно, пока биржа не вернет известный статус
+var terminalOrderStatuses = map[OrderStatus]bool{
+	OrderStatusPending:         false,
+	OrderStatusBuyPending:      false,
+	OrderStatusPartiallyFilled: false,
+	OrderStatusUnknown:         false,
+	OrderStatusFilled:          true,
+	OrderStatusCancelled:       true,
+	OrderStatusRejected:        true,
+	OrderStatusClosedManual:    true,
+}
+
+// orderStatusAliases соответствие статусов Bybit v5 (и сохраненных ранее значений) внутренним статусам
+var orderStatusAliases = map[string]OrderStatus{
+	// Внутренние статусы в том виде, как они хранятся в БД
+	"PENDING":          OrderStatusPending,
+	"BUY_PENDING":      OrderStatusBuyPending,
+	"FILLED":           OrderStatusFilled,
+	"PARTIALLY_FILLED": OrderStatusPartiallyFilled,
+	"CANCELLED":        OrderStatusCancelled,
+	"REJECTED":         OrderStatusRejected,
+	"CLOSED_MANUAL":    OrderStatusClosedManual,
+
+	// Открытые ордера Bybit v5
+	"New":             OrderStatusPending,
+	"PartiallyFilled": OrderStatusPartiallyFilled,
+	"Untriggered":     OrderStatusPending, // Условный ордер ждет цены срабатывания
+	"Triggered":       OrderStatusPending, // Условный ордер сработал и переходит в New
+
+	// Закрытые ордера Bybit v5
+	"Filled":                  OrderStatusFilled,
+	"Cancelled":               OrderStatusCancelled,
+	"Rejected":                OrderStatusRejected,
+	"PartiallyFilledCanceled": OrderStatusCancelled, // Спот: отменен после частичного исполнения (исполненное количество в FilledQty)
+	"Deactivated":             OrderStatusCancelled, // Условный ордер отменен до срабатывания
+
+	// Прочие написания
+	"NEW": OrderStatusPending, "OPEN": OrderStatusPending, "Open": OrderStatusPending,
+	"CLOSED": OrderStatusFilled, "Closed": OrderStatusFilled,
+	"PARTIAL": OrderStatusPartiallyFilled, "Partial": OrderStatusPartiallyFilled,
+	"CANCELED": OrderStatusCancelled, "Canceled": OrderStatusCancelled,
+}
+
+// IsKnown проверяет, что статус - один из внутренних статусов (для проверки фильтров API)
+func (s OrderStatus) IsKnown() bool {
+	_, ok := terminalOrderStatuses[s]
+	return ok
+}
+
 // IsCompleted проверяет, завершен ли ордер (успешно или неуспешно)
 func (s OrderStatus) IsCompleted() bool {
-	return s == OrderStatusFilled ||
-		s == OrderStatusCancelled ||
-		s == OrderStatusRejected
+	return terminalOrderStatuses[s]
+}
+
+// ActiveOrderStatuses возвращает незавершенные статусы (IsCompleted() == false) в алфавитном порядке
+func ActiveOrderStatuses() []OrderStatus {
+	var statuses []OrderStatus
+	for status, terminal := range terminalOrderStatuses {
+		if !terminal {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+	return statuses
 }
 
 // IsSuccessful проверяет, успешно ли исполнен ордер
@@ -40,20 +110,10 @@ func (s OrderStatus) String() string {
 	return string(s)
 }
 
-// FromString создает OrderStatus из строки
+// OrderStatusFromString создает OrderStatus из строки (статус Bybit или сохраненный в БД)
 func OrderStatusFromString(status string) OrderStatus {
-	switch status {
-	case "PENDING", "NEW", "New", "OPEN", "Open":
-		return OrderStatusPending
-	case "FILLED", "Filled", "CLOSED", "Closed":
-		return OrderStatusFilled
-	case "PARTIALLY_FILLED", "PartiallyFilled", "PARTIAL", "Partial":
-		return OrderStatusPartiallyFilled
-	case "CANCELLED", "Cancelled", "CANCELED", "Canceled":
-		return OrderStatusCancelled
-	case "REJECTED", "Rejected":
-		return OrderStatusRejected
-	default:
-		return OrderStatusUnknown
+	if mapped, ok := orderStatusAliases[status]; ok {
+		return mapped
 	}
+	return OrderStatusUnknown
 }
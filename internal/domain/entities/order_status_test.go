@@ -0,0 +1,129 @@
+package entities
+
+import "testing"
+
+// TestOrderStatusFromStringBybitV5 проверяет соответствие всех статусов ордеров Bybit v5 внутренним статусам
+func TestOrderStatusFromStringBybitV5(t *testing.T) {
+	tests := []struct {
+		status string
+		want   OrderStatus
+	}{
+		// Открытые ордера
+		{"New", OrderStatusPending},
+		{"PartiallyFilled", OrderStatusPartiallyFilled},
+		{"Untriggered", OrderStatusPending},
+		{"Triggered", OrderStatusPending},
+
+		// Закрытые ордера
+		{"Filled", OrderStatusFilled},
+		{"Cancelled", OrderStatusCancelled},
+		{"Rejected", OrderStatusRejected},
+		{"PartiallyFilledCanceled", OrderStatusCancelled},
+		{"Deactivated", OrderStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		if got := OrderStatusFromString(tt.status); got != tt.want {
+			t.Errorf("OrderStatusFromString(%q) = %s, ожидался %s", tt.status, got, tt.want)
+		}
+	}
+}
+
+// TestOrderStatusFromStringStored проверяет разбор статусов, сохраненных в БД, и прочих написаний
+func TestOrderStatusFromStringStored(t *testing.T) {
+	tests := []struct {
+		status string
+		want   OrderStatus
+	}{
+		{"PENDING", OrderStatusPending},
+		{"BUY_PENDING", OrderStatusBuyPending},
+		{"FILLED", OrderStatusFilled},
+		{"PARTIALLY_FILLED", OrderStatusPartiallyFilled},
+		{"CANCELLED", OrderStatusCancelled},
+		{"REJECTED", OrderStatusRejected},
+		{"CLOSED_MANUAL", OrderStatusClosedManual},
+
+		{"NEW", OrderStatusPending},
+		{"OPEN", OrderStatusPending},
+		{"Open", OrderStatusPending},
+		{"CLOSED", OrderStatusFilled},
+		{"Closed", OrderStatusFilled},
+		{"PARTIAL", OrderStatusPartiallyFilled},
+		{"Partial", OrderStatusPartiallyFilled},
+		{"CANCELED", OrderStatusCancelled},
+		{"Canceled", OrderStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		if got := OrderStatusFromString(tt.status); got != tt.want {
+			t.Errorf("OrderStatusFromString(%q) = %s, ожидался %s", tt.status, got, tt.want)
+		}
+	}
+}
+
+// TestOrderStatusFromStringUnknown проверяет, что незнакомый статус становится UNKNOWN, а не считается исполненным
+func TestOrderStatusFromStringUnknown(t *testing.T) {
+	for _, status := range []string{"", "UNKNOWN", "Expired", "filled", "new", " Filled", "PartiallyFilledCancelled"} {
+		if got := OrderStatusFromString(status); got != OrderStatusUnknown {
+			t.Errorf("OrderStatusFromString(%q) = %s, ожидался %s", status, got, OrderStatusUnknown)
+		}
+	}
+}
+
+// TestOrderStatusTerminal проверяет таблицу терминальных статусов: завершенные ордера больше не проверяются,
+// UNKNOWN проверяется повторно
+func TestOrderStatusTerminal(t *testing.T) {
+	tests := []struct {
+		status     OrderStatus
+		known      bool
+		completed  bool
+		successful bool
+	}{
+		{OrderStatusPending, true, false, false},
+		{OrderStatusBuyPending, true, false, false},
+		{OrderStatusPartiallyFilled, true, false, false},
+		{OrderStatusUnknown, true, false, false},
+		{OrderStatusFilled, true, true, true},
+		{OrderStatusCancelled, true, true, false},
+		{OrderStatusRejected, true, true, false},
+		{OrderStatusClosedManual, true, true, false},
+		{OrderStatus("Filled"), false, false, false},
+		{OrderStatus("EXPIRED"), false, false, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.IsKnown(); got != tt.known {
+			t.Errorf("%s.IsKnown() = %v, ожидалось %v", tt.status, got, tt.known)
+		}
+		if got := tt.status.IsCompleted(); got != tt.completed {
+			t.Errorf("%s.IsCompleted() = %v, ожидалось %v", tt.status, got, tt.completed)
+		}
+		if got := tt.status.IsSuccessful(); got != tt.successful {
+			t.Errorf("%s.IsSuccessful() = %v, ожидалось %v", tt.status, got, tt.successful)
+		}
+	}
+}
+
+// TestOrderStatusTerminalCoversAliases проверяет, что каждый статус, в который переводится статус биржи,
+// есть в таблице терминальных статусов
+func TestOrderStatusTerminalCoversAliases(t *testing.T) {
+	for alias, status := range orderStatusAliases {
+		if !status.IsKnown() {
+			t.Errorf("статус %s (из %q) отсутствует в таблице терминальных статусов", status, alias)
+		}
+	}
+}
+
+// TestActiveOrderStatuses проверяет список незавершенных статусов, которые сопровождаются проверками
+func TestActiveOrderStatuses(t *testing.T) {
+	want := []OrderStatus{OrderStatusBuyPending, OrderStatusPartiallyFilled, OrderStatusPending, OrderStatusUnknown}
+	got := ActiveOrderStatuses()
+	if len(got) != len(want) {
+		t.Fatalf("ActiveOrderStatuses() = %v, ожидалось %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ActiveOrderStatuses() = %v, ожидалось %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package entities
+
+import "time"
+
+// PairLockAllPairs обозначение блокировки всех пар в Freqtrade
+const PairLockAllPairs = "*"
+
+// PairLock блокировка пары, установленная Freqtrade (например, пауза после стоп-лосса)
+type PairLock struct {
+	ID      int       // ID блокировки в Freqtrade
+	Pair    string    // Валютная пара или "*" для всех пар
+	Side    string    // Сторона блокировки: long, short или "*"
+	Reason  string    // Причина блокировки
+	LockEnd time.Time // Время окончания блокировки
+	Active  bool      // Блокировка активна
+}
+
+// IsActive проверяет, действует ли блокировка на указанный момент
+func (l *PairLock) IsActive(now time.Time) bool {
+	return l.Active && now.Before(l.LockEnd)
+}
+
+// Covers проверяет, распространяется ли блокировка на пару
+func (l *PairLock) Covers(pair string) bool {
+	return l.Pair == PairLockAllPairs || l.Pair == pair
+}
+
+// FindActivePairLock возвращает действующую блокировку пары или nil
+func FindActivePairLock(locks []*PairLock, pair string, now time.Time) *PairLock {
+	for _, lock := range locks {
+		if lock.Covers(pair) && lock.IsActive(now) {
+			return lock
+		}
+	}
+	return nil
+}
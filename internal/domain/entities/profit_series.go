@@ -0,0 +1,162 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// Интервалы ряда прибыли
+const (
+	ProfitBucketDay  = "day"  // Календарный день (UTC)
+	ProfitBucketWeek = "week" // Неделя с понедельника (UTC)
+)
+
+// IsProfitBucket проверяет, что интервал ряда прибыли поддерживается
+func IsProfitBucket(bucket string) bool {
+	return bucket == ProfitBucketDay || bucket == ProfitBucketWeek
+}
+
+// ProfitBucket результаты хеджей, закрытых в одном интервале ряда прибыли
+type ProfitBucket struct {
+	Start time.Time `json:"start"` // Начало интервала (UTC)
+
+	Hedges int `json:"hedges"` // Закрытых хеджей с ценой закрытия
+	Wins   int `json:"wins"`   // Из них с положительной прибылью после комиссий
+
+	RealizedProfit float64 `json:"realized_profit"` // Прибыль до комиссий
+	Fees           float64 `json:"fees"`            // Комиссии
+	NetProfit      float64 `json:"net_profit"`      // Прибыль за вычетом комиссий
+	AvgProfit      float64 `json:"avg_profit"`      // Средняя прибыль хеджа за вычетом комиссий
+}
+
+// Finish заполняет производные показатели интервала
+func (b *ProfitBucket) Finish() {
+	b.NetProfit = b.RealizedProfit - b.Fees
+	if b.Hedges > 0 {
+		b.AvgProfit = b.NetProfit / float64(b.Hedges)
+	}
+}
+
+// ProfitBucketStart возвращает начало интервала, в который попадает момент t
+func ProfitBucketStart(bucket string, t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if bucket != ProfitBucketWeek {
+		return day
+	}
+	// time.Weekday начинается с воскресенья: сдвигаем к понедельнику
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// ComputeProfitSeries считает ряд прибыли хеджей, закрытых в [from, to), в памяти
+// (для хранилищ без SQL; результаты совпадают с SQL-агрегацией). Интервалы без закрытых хеджей не возвращаются
+func ComputeProfitSeries(trades []*HedgedTrade, bucket string, from, to time.Time) []*ProfitBucket {
+	buckets := make(map[time.Time]*ProfitBucket)
+	for _, trade := range trades {
+		if trade.IsActive() || trade.CloseTime == nil || trade.CloseTime.Before(from) || !trade.CloseTime.Before(to) {
+			continue
+		}
+		profit := trade.CalculateProfit()
+		if profit == nil {
+			continue
+		}
+
+		start := ProfitBucketStart(bucket, *trade.CloseTime)
+		item, ok := buckets[start]
+		if !ok {
+			item = &ProfitBucket{Start: start}
+			buckets[start] = item
+		}
+		item.Hedges++
+		item.RealizedProfit += profit.Gross
+		item.Fees += trade.TotalFees()
+		if profit.Net > 0 {
+			item.Wins++
+		}
+	}
+
+	series := make([]*ProfitBucket, 0, len(buckets))
+	for _, item := range buckets {
+		item.Finish()
+		series = append(series, item)
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Start.Before(series[j].Start)
+	})
+	return series
+}
+
+// ComputeProfitTotals считает итоги хеджей, закрытых до before, в памяти (для хранилищ без SQL)
+func ComputeProfitTotals(trades []*HedgedTrade, before time.Time) *ProfitBucket {
+	totals := &ProfitBucket{}
+	for _, item := range ComputeProfitSeries(trades, ProfitBucketDay, time.Time{}, before) {
+		totals.Hedges += item.Hedges
+		totals.Wins += item.Wins
+		totals.RealizedProfit += item.RealizedProfit
+		totals.Fees += item.Fees
+	}
+	totals.Finish()
+	return totals
+}
+
+// ProfitChartPoint точка графиков прибыли: показатели интервала и накопленные итоги на его конец
+type ProfitChartPoint struct {
+	Start     time.Time `json:"start"`      // Начало интервала (UTC)
+	Hedges    int       `json:"hedges"`     // Закрыто хеджей в интервале
+	NetProfit float64   `json:"net_profit"` // Прибыль интервала за вычетом комиссий
+	WinRate   *float64  `json:"win_rate"`   // Доля прибыльных хеджей интервала, % (null - хеджей не было)
+
+	CumulativeNetProfit float64  `json:"cumulative_net_profit"` // Накопленная прибыль (кривая капитала)
+	CumulativeWinRate   *float64 `json:"cumulative_win_rate"`   // Доля прибыльных хеджей за все время, % (null - хеджей не было)
+}
+
+// BuildProfitChart строит непрерывный ряд точек графиков с from по to: интервалы без закрытых хеджей
+// заполняются нулями, накопленные итоги начинаются с итогов хеджей, закрытых до from (start, может быть nil)
+func BuildProfitChart(bucket string, from, to time.Time, series []*ProfitBucket, start *ProfitBucket) []*ProfitChartPoint {
+	byStart := make(map[time.Time]*ProfitBucket, len(series))
+	for _, item := range series {
+		byStart[item.Start.UTC()] = item
+	}
+
+	var cumulativeNet float64
+	var cumulativeHedges, cumulativeWins int
+	if start != nil {
+		cumulativeNet = start.NetProfit
+		cumulativeHedges = start.Hedges
+		cumulativeWins = start.Wins
+	}
+
+	var points []*ProfitChartPoint
+	for current := ProfitBucketStart(bucket, from); current.Before(to); current = nextProfitBucket(bucket, current) {
+		point := &ProfitChartPoint{Start: current}
+		if item, ok := byStart[current]; ok {
+			point.Hedges = item.Hedges
+			point.NetProfit = item.NetProfit
+			point.WinRate = winRate(item.Wins, item.Hedges)
+			cumulativeNet += item.NetProfit
+			cumulativeHedges += item.Hedges
+			cumulativeWins += item.Wins
+		}
+		point.CumulativeNetProfit = cumulativeNet
+		point.CumulativeWinRate = winRate(cumulativeWins, cumulativeHedges)
+		points = append(points, point)
+	}
+	return points
+}
+
+// nextProfitBucket возвращает начало следующего интервала
+func nextProfitBucket(bucket string, start time.Time) time.Time {
+	if bucket == ProfitBucketWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// winRate возвращает долю прибыльных хеджей в процентах (nil - хеджей не было)
+func winRate(wins, hedges int) *float64 {
+	if hedges == 0 {
+		return nil
+	}
+	rate := float64(wins) / float64(hedges) * 100
+	return &rate
+}
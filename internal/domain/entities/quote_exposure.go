@@ -0,0 +1,9 @@
+package entities
+
+// QuoteExposure агрегированные показатели хеджей по одной котируемой валюте
+type QuoteExposure struct {
+	Quote         string  // Котируемая валюта (USDT, USDC, BTC)
+	OpenHedges    int     // Количество активных хеджей
+	OpenNotional  float64 // Суммарный объем активных хеджей в котируемой валюте
+	DailyNotional float64 // Сумма хеджей, открытых с начала суток
+}
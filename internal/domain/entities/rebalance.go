@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// RebalanceRecord запись аудита ребалансировки прибыли от хеджирования
+type RebalanceRecord struct {
+	ID            int       // ID записи
+	CreatedAt     time.Time // Время ребалансировки
+	PeriodStart   time.Time // Начало периода, за который учтена прибыль
+	PeriodEnd     time.Time // Конец периода, за который учтена прибыль
+	TotalProfit   float64   // Накопленная прибыль за период в котируемой валюте
+	Asset         string    // Целевой актив (котируемая валюта - доля, оставленная без конвертации)
+	TargetPercent float64   // Целевая доля актива в процентах
+	QuoteAmount   float64   // Сумма в котируемой валюте, направленная в актив
+	OrderID       string    // ID ордера конвертации (пусто, если конвертация не требовалась)
+	Success       bool      // Успешна ли операция
+	Error         string    // Текст ошибки (если неуспешна)
+}
@@ -0,0 +1,70 @@
+package entities
+
+import "trade-hedge/internal/domain/valueobjects"
+
+// HedgeRiskReward соотношение риска и прибыли открытого хеджа для оповещений: сколько хедж заработает
+// на тейк-профите и сколько потеряет на стоп-лоссе с учетом комиссий, в котируемой валюте пары
+type HedgeRiskReward struct {
+	Pair            string
+	QuoteCurrency   string
+	Quantity        float64 // Количество в хеджирующей позиции
+	EntryPrice      float64 // Цена покупки
+	TakeProfitPrice float64 // Цена тейк-профита
+	StopLossPrice   float64 // Цена стоп-лосса (0 - без стоп-лосса)
+
+	EntryFee float64 // Комиссия покупки по данным исполнения
+	ExitFee  float64 // Оценка комиссии продажи по ставке покупки
+
+	ExpectedGain float64  // Прибыль на тейк-профите за вычетом комиссий
+	MaxLoss      *float64 // Убыток на стоп-лоссе с комиссиями (nil - без стоп-лосса убыток не ограничен)
+}
+
+// NewHedgeRiskReward рассчитывает риск и прибыль хеджа по выставленным ценам. Комиссия продажи
+// оценивается по фактической ставке комиссии покупки (без данных исполнения - ноль)
+func NewHedgeRiskReward(ht *HedgedTrade) *HedgeRiskReward {
+	rr := &HedgeRiskReward{
+		Pair:            ht.Pair,
+		QuoteCurrency:   valueobjects.NewTradingPair(ht.Pair).QuoteCurrency(),
+		Quantity:        ht.HedgeAmount,
+		EntryPrice:      ht.HedgeOpenPrice,
+		TakeProfitPrice: ht.HedgeTakeProfitPrice,
+		StopLossPrice:   ht.StopLossPrice,
+		EntryFee:        ht.EntryFee,
+	}
+
+	var feeRate float64
+	if entryValue := ht.HedgeOpenPrice * ht.HedgeAmount; entryValue > 0 {
+		feeRate = ht.EntryFee / entryValue
+	}
+
+	rr.ExitFee = ht.HedgeTakeProfitPrice * ht.HedgeAmount * feeRate
+	rr.ExpectedGain = (ht.HedgeTakeProfitPrice-ht.HedgeOpenPrice)*ht.HedgeAmount - ht.EntryFee - rr.ExitFee
+
+	if ht.HasStopLoss() {
+		stopExitFee := ht.StopLossPrice * ht.HedgeAmount * feeRate
+		maxLoss := (ht.HedgeOpenPrice-ht.StopLossPrice)*ht.HedgeAmount + ht.EntryFee + stopExitFee
+		rr.MaxLoss = &maxLoss
+	}
+	return rr
+}
+
+// TotalFees возвращает комиссии покупки и продажи на тейк-профите
+func (rr *HedgeRiskReward) TotalFees() float64 {
+	return rr.EntryFee + rr.ExitFee
+}
+
+// Ratio возвращает отношение прибыли на тейк-профите к убытку на стоп-лоссе (false - без стоп-лосса)
+func (rr *HedgeRiskReward) Ratio() (float64, bool) {
+	if rr.MaxLoss == nil || *rr.MaxLoss <= 0 {
+		return 0, false
+	}
+	return rr.ExpectedGain / *rr.MaxLoss, true
+}
+
+// GainPercent возвращает прибыль на тейк-профите в процентах от суммы покупки
+func (rr *HedgeRiskReward) GainPercent() float64 {
+	if rr.EntryPrice*rr.Quantity <= 0 {
+		return 0
+	}
+	return rr.ExpectedGain / (rr.EntryPrice * rr.Quantity) * 100
+}
@@ -0,0 +1,61 @@
+package entities
+
+import "time"
+
+// Параметры стратегии, изменяемые во время работы (ключи совпадают с путями в YAML)
+const (
+	SettingPositionAmount = "strategy.position_amount"
+	SettingMaxLossPercent = "strategy.max_loss_percent"
+	SettingProfitRatio    = "strategy.profit_ratio"
+
+	SettingMinTakeProfitPercent  = "strategy.min_take_profit_percent"
+	SettingBuyPriceOffsetPercent = "strategy.buy_price_offset_percent"
+	SettingStopLossPercent       = "strategy.stop_loss_percent"
+	SettingMinPortfolioLoss      = "strategy.min_portfolio_loss"
+)
+
+// Setting значение параметра, сохраненное в БД поверх значения из файла конфигурации
+type Setting struct {
+	Key       string    // Ключ параметра (Setting*)
+	Value     float64   // Значение
+	UpdatedAt time.Time // Время изменения
+	UpdatedBy string    // Кто изменил
+
+	// Временное значение действует до ExpiresAt, затем возвращается RevertValue
+	ExpiresAt   *time.Time // Окончание временного значения (nil - значение постоянное)
+	RevertValue *float64   // Значение после окончания (nil - значение из файла)
+}
+
+// IsTemporary проверяет, что значение действует ограниченное время
+func (s *Setting) IsTemporary() bool {
+	return s.ExpiresAt != nil
+}
+
+// Expired проверяет, что временное значение уже закончилось
+func (s *Setting) Expired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
+}
+
+// BaseValue возвращает постоянное значение под параметром: для временного значения - то, что вернется
+// после его окончания (nil - значение из файла). Для s == nil возвращает nil
+func (s *Setting) BaseValue() *float64 {
+	if s == nil {
+		return nil
+	}
+	if s.IsTemporary() {
+		return s.RevertValue
+	}
+	value := s.Value
+	return &value
+}
+
+// SettingChange запись журнала изменений параметров
+type SettingChange struct {
+	ID        int64      // ID записи
+	Key       string     // Ключ параметра
+	OldValue  *float64   // Значение до изменения (nil - действовало значение из файла)
+	NewValue  *float64   // Новое значение (nil - сброс к значению из файла)
+	ChangedBy string     // Кто изменил
+	ChangedAt time.Time  // Время изменения
+	ExpiresAt *time.Time // Окончание временного значения (nil - изменение постоянное)
+}
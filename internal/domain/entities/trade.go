@@ -1,27 +1,35 @@
 package entities
 
 import (
-	"strconv"
+	"sort"
 	"time"
+	"trade-hedge/internal/domain/valueobjects"
 )
 
 // Trade представляет торговую сделку из Freqtrade
 type Trade struct {
-	ID          int     // ID сделки
-	Pair        string  // Валютная пара
-	IsOpen      bool    // Открыта ли сделка
-	ProfitRatio float64 // Текущий коэффициент прибыли/убытка
-	CurrentRate float64 // Текущая цена
-	OpenRate    float64 // Цена открытия
-	Amount      float64 // Количество валюты
+	ID          int       // ID сделки
+	Pair        string    // Валютная пара
+	IsOpen      bool      // Открыта ли сделка
+	ProfitRatio float64   // Текущий коэффициент прибыли/убытка
+	CurrentRate float64   // Текущая цена
+	OpenRate    float64   // Цена открытия
+	Amount      float64   // Количество валюты
+	OpenTime    time.Time // Время открытия (нулевое, если Freqtrade его не передал)
+
+	// Итог закрытой сделки (заполняется только для истории сделок)
+	CloseProfitAbs float64    // Реализованная прибыль/убыток в котируемой валюте
+	CloseTime      *time.Time // Время закрытия
 }
 
 // HedgedTrade представляет хеджированную сделку в базе данных
 type HedgedTrade struct {
+	HedgeID          int64     // ID хеджа в хранилище (0 - еще не сохранен); у сделки Freqtrade может быть несколько хеджей
 	FreqtradeTradeID int       // ID сделки в Freqtrade
 	Pair             string    // Валютная пара (например, BTC/USDT)
 	HedgeTime        time.Time // Время хеджирования
 	BybitOrderID     string    // ID ордера в Bybit
+	BuyOrderID       string    // ID ордера на покупку хеджирующей позиции
 
 	// Информация об исходной сделке Freqtrade
 	FreqtradeOpenPrice   float64 // Цена открытия в Freqtrade
@@ -33,11 +41,78 @@ type HedgedTrade struct {
 	HedgeAmount          float64 // Количество валюты в хеджирующей позиции
 	HedgeTakeProfitPrice float64 // Цена тейк-профита
 
+	// Стоп-лосс, связанный с тейк-профитом как OCO: исполнение одного отменяет другой
+	StopLossPrice   float64 // Цена стоп-лосса (0 - без стоп-лосса)
+	StopLossOrderID string  // ID ордера стоп-лосса (нативный OCO или рыночная продажа при эмуляции)
+
+	// Информация об исполнении ордера на покупку
+	BuyRequestedQty float64 // Запрошенное количество в ордере на покупку
+	BuyFilledQty    float64 // Фактически исполненное количество (остаток отменен при частичном исполнении)
+
+	// Комиссии биржи по данным исполнения, в котируемой валюте
+	EntryFee float64 // Комиссия за покупку
+	ExitFee  float64 // Комиссия за продажу (тейк-профит, стоп-лосс или закрытие по рынку)
+
 	// Статус ордера
 	OrderStatus     OrderStatus // Текущий статус ордера на Bybit
 	LastStatusCheck *time.Time  // Время последней проверки статуса
 	ClosePrice      *float64    // Цена закрытия (если исполнен)
 	CloseTime       *time.Time  // Время закрытия (если исполнен)
+
+	// Цена отметки, по которой принято решение о закрытии продажей (стоп-лосс, закрытие по времени
+	// и вручную); 0 - неизвестна или хедж закрыт тейк-профитом
+	CloseIntendedPrice float64
+
+	// Версия логики на момент хеджирования (для сегментации аналитики)
+	StrategyVersion string // Версия кода стратегии
+	FeatureFlags    string // Активные флаги поведения в виде "ключ=значение,..."
+
+	// Моменты этапов хеджирования для метрик задержки (nil - момент неизвестен, например для хеджей
+	// старых версий или восстановленных после сбоя)
+	ThresholdCrossedAt    *time.Time // Первый цикл, в котором просадка сделки превысила порог
+	ThresholdCrossedPrice float64    // Цена сделки в этом цикле (0 - неизвестна)
+	BuyPlacedAt           *time.Time // Выставление ордера на покупку
+	BuyFilledAt           *time.Time // Исполнение покупки (по данным биржи или момент обнаружения)
+	TakeProfitPlacedAt    *time.Time // Выставление тейк-профита
+
+	// Хедж-группа, ногой которой является хедж (0 - самостоятельный хедж)
+	HedgeGroupID int64
+}
+
+// EntryLatency возвращает задержку от пересечения порога до выставления ордера на покупку
+func (ht *HedgedTrade) EntryLatency() (time.Duration, bool) {
+	return latencyBetween(ht.ThresholdCrossedAt, ht.BuyPlacedAt)
+}
+
+// ProtectionLatency возвращает задержку от исполнения покупки до выставления тейк-профита
+func (ht *HedgedTrade) ProtectionLatency() (time.Duration, bool) {
+	return latencyBetween(ht.BuyFilledAt, ht.TakeProfitPlacedAt)
+}
+
+// EntryPriceDrift возвращает изменение цены от пересечения порога до цены открытия хеджа в процентах:
+// отрицательное значение - хедж открыт дешевле, положительное - ожидание стоило дороже
+func (ht *HedgedTrade) EntryPriceDrift() (float64, bool) {
+	if ht.ThresholdCrossedPrice <= 0 || ht.HedgeOpenPrice <= 0 {
+		return 0, false
+	}
+	return (ht.HedgeOpenPrice - ht.ThresholdCrossedPrice) / ht.ThresholdCrossedPrice * 100, true
+}
+
+// CloseSlippage возвращает отклонение цены закрытия от цены отметки в момент решения о закрытии в процентах:
+// положительное значение - продано дешевле цены отметки, отрицательное - дороже
+func (ht *HedgedTrade) CloseSlippage() (float64, bool) {
+	if ht.CloseIntendedPrice <= 0 || ht.ClosePrice == nil {
+		return 0, false
+	}
+	return (ht.CloseIntendedPrice - *ht.ClosePrice) / ht.CloseIntendedPrice * 100, true
+}
+
+// latencyBetween возвращает интервал между моментами, если оба известны и идут по порядку
+func latencyBetween(from, to *time.Time) (time.Duration, bool) {
+	if from == nil || to == nil || to.Before(*from) {
+		return 0, false
+	}
+	return to.Sub(*from), true
 }
 
 // IsActive проверяет, активна ли хеджированная сделка
@@ -45,13 +120,69 @@ func (ht *HedgedTrade) IsActive() bool {
 	return !ht.OrderStatus.IsCompleted()
 }
 
-// CalculateProfit рассчитывает прибыль от хеджирования (если закрыто)
-func (ht *HedgedTrade) CalculateProfit() *float64 {
+// HasStopLoss проверяет, связан ли с тейк-профитом стоп-лосс
+func (ht *HedgedTrade) HasStopLoss() bool {
+	return ht.StopLossPrice > 0
+}
+
+// IsPartialFill проверяет, была ли покупка исполнена частично
+func (ht *HedgedTrade) IsPartialFill() bool {
+	return ht.BuyRequestedQty > 0 && ht.BuyFilledQty > 0 && ht.BuyFilledQty < ht.BuyRequestedQty
+}
+
+// Способы закрытия хеджа
+const (
+	HedgeExitTakeProfit = "take_profit" // Исполнен тейк-профит
+	HedgeExitStopLoss   = "stop_loss"   // Сработал стоп-лосс
+	HedgeExitMarket     = "market"      // Продажа по рынку (закрытие по времени, ручное закрытие)
+)
+
+// ExitKind определяет способ закрытия хеджа по цене закрытия ("" - хедж не закрыт продажей)
+func (ht *HedgedTrade) ExitKind() string {
+	if ht.ClosePrice != nil && ht.OrderStatus == OrderStatusClosedManual {
+		return HedgeExitMarket
+	}
+	if ht.ClosePrice == nil || ht.OrderStatus != OrderStatusFilled {
+		return ""
+	}
+	switch {
+	case ht.HedgeTakeProfitPrice > 0 && *ht.ClosePrice >= ht.HedgeTakeProfitPrice:
+		return HedgeExitTakeProfit
+	case ht.HasStopLoss() && *ht.ClosePrice <= ht.StopLossPrice:
+		return HedgeExitStopLoss
+	}
+	return HedgeExitMarket
+}
+
+// HedgeProfit прибыль закрытого хеджа в котируемой валюте
+type HedgeProfit struct {
+	Gross float64 // Разница цен закрытия и открытия на количество
+	Net   float64 // Прибыль за вычетом комиссий покупки и продажи
+}
+
+// TotalFees возвращает сумму комиссий хеджа в котируемой валюте
+func (ht *HedgedTrade) TotalFees() float64 {
+	return ht.EntryFee + ht.ExitFee
+}
+
+// CalculateProfit рассчитывает прибыль от хеджирования до и после комиссий (если закрыто)
+func (ht *HedgedTrade) CalculateProfit() *HedgeProfit {
 	if ht.ClosePrice == nil {
 		return nil // Сделка еще не закрыта
 	}
 
-	profit := (*ht.ClosePrice - ht.HedgeOpenPrice) * ht.HedgeAmount
+	gross := (*ht.ClosePrice - ht.HedgeOpenPrice) * ht.HedgeAmount
+	return &HedgeProfit{Gross: gross, Net: gross - ht.TotalFees()}
+}
+
+// CalculateUnrealizedProfit рассчитывает плавающую прибыль открытого хеджа по текущей цене.
+// Возвращает nil, если хедж закрыт или покупка еще не исполнена
+func (ht *HedgedTrade) CalculateUnrealizedProfit(currentPrice float64) *float64 {
+	if !ht.IsActive() || ht.OrderStatus == OrderStatusBuyPending || currentPrice <= 0 {
+		return nil
+	}
+
+	profit := (currentPrice - ht.HedgeOpenPrice) * ht.HedgeAmount
 	return &profit
 }
 
@@ -62,49 +193,38 @@ func (t *Trade) ShouldBeHedged(maxLossPercent float64) bool {
 	return t.ProfitRatio < threshold
 }
 
-// SortTradesByDrawdown сортирует сделки по максимальной просадке (от большей к меньшей)
-// ProfitRatio отрицательный при убытке, поэтому сортируем по возрастанию (от -0.05 к -0.02)
+// SortTradesByDrawdown сортирует сделки по максимальной просадке (от большей к меньшей).
+// ProfitRatio отрицательный при убытке, поэтому сортируем по возрастанию (от -0.05 к -0.02);
+// сортировка устойчивая - сделки с одинаковой просадкой сохраняют порядок Freqtrade
 func SortTradesByDrawdown(trades []*Trade) {
-	if len(trades) <= 1 {
-		return
-	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].ProfitRatio < trades[j].ProfitRatio
+	})
+}
 
-	// Сортируем по убыванию просадки (от большей к меньшей)
-	// Поскольку ProfitRatio отрицательный при убытке, сортируем по возрастанию
-	for i := 0; i < len(trades)-1; i++ {
-		for j := i + 1; j < len(trades); j++ {
-			// Если просадка i-й сделки меньше просадки j-й сделки, меняем местами
-			// ProfitRatio отрицательный, поэтому сравниваем наоборот
-			if trades[i].ProfitRatio > trades[j].ProfitRatio {
-				trades[i], trades[j] = trades[j], trades[i]
-			}
-		}
-	}
+// NotionalAtRisk возвращает текущую стоимость позиции сделки в котируемой валюте
+func (t *Trade) NotionalAtRisk() float64 {
+	return t.CurrentRate * t.Amount
+}
+
+// UnrealizedProfit возвращает нереализованную прибыль/убыток сделки в котируемой валюте
+func (t *Trade) UnrealizedProfit() float64 {
+	return (t.CurrentRate - t.OpenRate) * t.Amount
 }
 
 // CalculateTakeProfitPrice рассчитывает цену тейк-профита
 func (t *Trade) CalculateTakeProfitPrice(profitRatio float64) float64 {
-	takeProfitPercent := t.ProfitRatio * -100 * profitRatio // убыток в процентах * коэффициент
-	rawPrice := t.CurrentRate * (1 + takeProfitPercent/100)
+	// Убыток в процентах * коэффициент; считаем в десятичной арифметике без погрешности float
+	takeProfitPercent := valueobjects.NewDecimalFromFloat(t.ProfitRatio).
+		Mul(valueobjects.NewDecimal(-100, 0)).
+		Mul(valueobjects.NewDecimalFromFloat(profitRatio))
+	rawPrice := valueobjects.NewDecimalFromFloat(t.CurrentRate).ApplyPercent(takeProfitPercent)
 
 	// Для очень маленьких цен используем 8 знаков, для обычных - 4 знака
-	var multiplier float64
-	if t.CurrentRate < 0.0001 {
-		multiplier = 100000000.0 // 10^8 для 8 знаков
-	} else {
-		multiplier = 10000.0 // 10^4 для 4 знаков
-	}
-
-	roundedPrice := float64(int(rawPrice*multiplier+0.5)) / multiplier
-
-	// Дополнительная проверка - форматируем строку и парсим обратно для гарантии точности
-	precision := 8
+	var precision int32 = 8
 	if t.CurrentRate >= 0.0001 {
 		precision = 4
 	}
 
-	priceStr := strconv.FormatFloat(roundedPrice, 'f', precision, 64)
-	finalPrice, _ := strconv.ParseFloat(priceStr, 64)
-
-	return finalPrice
+	return rawPrice.Round(precision, valueobjects.RoundHalfUp).Float64()
 }
@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // StrategyError базовый тип для ошибок стратегии
 type StrategyError struct {
@@ -22,6 +25,34 @@ const (
 	ErrorTypeInsufficientBalanceForMinLimit
 	// ErrorTypeExchangeError ошибка биржи
 	ErrorTypeExchangeError
+	// ErrorTypeOrderFillTimeout ордер не исполнился за отведенное время
+	ErrorTypeOrderFillTimeout
+	// ErrorTypeStrategySkipped стратегия отказалась хеджировать сделку
+	ErrorTypeStrategySkipped
+	// ErrorTypeDryRun ордера не размещаются (режим без торговли)
+	ErrorTypeDryRun
+	// ErrorTypeRiskLimitExceeded превышен общий лимит риска
+	ErrorTypeRiskLimitExceeded
+	// ErrorTypePairRiskLimitExceeded превышен лимит риска по паре
+	ErrorTypePairRiskLimitExceeded
+	// ErrorTypeQuoteRiskLimitExceeded превышен лимит риска по котируемой валюте
+	ErrorTypeQuoteRiskLimitExceeded
+	// ErrorTypePriceDeviation цена Freqtrade расходится с текущей ценой биржи
+	ErrorTypePriceDeviation
+	// ErrorTypeQuoteConversion пару невозможно перевести на рынок с базовой валютой кошелька
+	ErrorTypeQuoteConversion
+	// ErrorTypePortfolioCalm портфель Freqtrade не под нагрузкой - хеджирование не требуется
+	ErrorTypePortfolioCalm
+	// ErrorTypePreTradeFilter фильтр перед хеджированием отклонил пару
+	ErrorTypePreTradeFilter
+	// ErrorTypeTradeNotFound сделка не найдена среди открытых сделок Freqtrade
+	ErrorTypeTradeNotFound
+	// ErrorTypeHedgeInProgress у сделки уже есть хедж в процессе открытия или ордер в ожидании
+	ErrorTypeHedgeInProgress
+	// ErrorTypeConfirmationRequired действие требует явного подтверждения
+	ErrorTypeConfirmationRequired
+	// ErrorTypeHedgeNotFound открытый хедж не найден
+	ErrorTypeHedgeNotFound
 )
 
 // Error реализует интерфейс error
@@ -33,7 +64,16 @@ func (e *StrategyError) Error() string {
 func (e *StrategyError) IsExpected() bool {
 	return e.Type == ErrorTypeNoTrades ||
 		e.Type == ErrorTypeNoLossyTrades ||
-		e.Type == ErrorTypeInsufficientBalanceForMinLimit
+		e.Type == ErrorTypeInsufficientBalanceForMinLimit ||
+		e.Type == ErrorTypeStrategySkipped ||
+		e.Type == ErrorTypeDryRun ||
+		e.Type == ErrorTypeRiskLimitExceeded ||
+		e.Type == ErrorTypePairRiskLimitExceeded ||
+		e.Type == ErrorTypeQuoteRiskLimitExceeded ||
+		e.Type == ErrorTypePriceDeviation ||
+		e.Type == ErrorTypeQuoteConversion ||
+		e.Type == ErrorTypePortfolioCalm ||
+		e.Type == ErrorTypePreTradeFilter
 }
 
 // NewNoTradesError создает ошибку "нет сделок"
@@ -75,3 +115,118 @@ func NewExchangeError(message string) *StrategyError {
 		Message: fmt.Sprintf("Ошибка биржи: %s", message),
 	}
 }
+
+// NewOrderFillTimeoutError создает ошибку превышения времени ожидания исполнения ордера
+func NewOrderFillTimeoutError(orderID string, timeout time.Duration) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypeOrderFillTimeout,
+		Message: fmt.Sprintf("превышено время ожидания исполнения ордера %s (%v)", orderID, timeout),
+	}
+}
+
+// NewStrategySkippedError создает ошибку отказа стратегии хеджировать сделку
+func NewStrategySkippedError(pair, strategy string) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypeStrategySkipped,
+		Message: fmt.Sprintf("Стратегия %s не хеджирует пару %s", strategy, pair),
+	}
+}
+
+// NewDryRunError создает ошибку режима без торговли
+func NewDryRunError(candidates int) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypeDryRun,
+		Message: fmt.Sprintf("Режим без торговли: ордера не размещаются (кандидатов: %d)", candidates),
+	}
+}
+
+// NewRiskLimitError создает ошибку превышения общего лимита риска
+func NewRiskLimitError(limit string, value, max float64) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypeRiskLimitExceeded,
+		Message: fmt.Sprintf("Хеджирование заблокировано лимитом риска %s: %.2f при лимите %.2f", limit, value, max),
+	}
+}
+
+// NewPairRiskLimitError создает ошибку превышения лимита риска по паре
+func NewPairRiskLimitError(pair, limit string, value, max float64) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypePairRiskLimitExceeded,
+		Message: fmt.Sprintf("Хеджирование пары %s заблокировано лимитом риска %s: %.2f при лимите %.2f", pair, limit, value, max),
+	}
+}
+
+// NewQuoteRiskLimitError создает ошибку превышения лимита риска по котируемой валюте
+func NewQuoteRiskLimitError(quote, limit string, value, max float64) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypeQuoteRiskLimitExceeded,
+		Message: fmt.Sprintf("Хеджирование пар с котируемой валютой %s заблокировано лимитом риска %s: %.2f при лимите %.2f", quote, limit, value, max),
+	}
+}
+
+// NewPriceDeviationError создает ошибку расхождения цены Freqtrade с текущей ценой биржи
+func NewPriceDeviationError(pair string, freqtradePrice, exchangePrice, deviationPercent, maxPercent float64) *StrategyError {
+	return &StrategyError{
+		Type: ErrorTypePriceDeviation,
+		Message: fmt.Sprintf("Цена пары %s устарела: Freqtrade %.8f, биржа %.8f (отклонение %.2f%% при лимите %.2f%%)",
+			pair, freqtradePrice, exchangePrice, deviationPercent, maxPercent),
+	}
+}
+
+// NewQuoteConversionError создает ошибку перевода пары на рынок с базовой валютой кошелька
+func NewQuoteConversionError(pair, baseCurrency, reason string) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypeQuoteConversion,
+		Message: fmt.Sprintf("Пару %s невозможно хеджировать за %s: %s", pair, baseCurrency, reason),
+	}
+}
+
+// NewPortfolioCalmError создает ошибку отсутствия нагрузки на портфель Freqtrade
+func NewPortfolioCalmError(losingTrades, minLosingTrades int, portfolioLoss, minPortfolioLoss float64, currency string) *StrategyError {
+	return &StrategyError{
+		Type: ErrorTypePortfolioCalm,
+		Message: fmt.Sprintf("Портфель не под нагрузкой: убыточных сделок %d (условие: больше %d), убыток %.2f %s (условие: больше %.2f %s)",
+			losingTrades, minLosingTrades, portfolioLoss, currency, minPortfolioLoss, currency),
+	}
+}
+
+// NewPreTradeFilterError создает ошибку отказа фильтра перед хеджированием
+func NewPreTradeFilterError(pair, filter, reason string) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypePreTradeFilter,
+		Message: fmt.Sprintf("Фильтр %s отклонил пару %s: %s", filter, pair, reason),
+	}
+}
+
+// NewTradeNotFoundError создает ошибку "сделка не найдена"
+func NewTradeNotFoundError(tradeID int) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypeTradeNotFound,
+		Message: fmt.Sprintf("Открытая сделка Freqtrade %d не найдена", tradeID),
+	}
+}
+
+// NewHedgeInProgressError создает ошибку "хедж сделки уже открывается"
+func NewHedgeInProgressError(tradeID int, pair, reason string) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypeHedgeInProgress,
+		Message: fmt.Sprintf("Сделка %d (%s) уже хеджируется: %s", tradeID, pair, reason),
+	}
+}
+
+// NewDrawdownConfirmationError создает ошибку "просадка ниже порога, требуется подтверждение"
+func NewDrawdownConfirmationError(pair string, drawdownPercent, maxLossPercent float64) *StrategyError {
+	return &StrategyError{
+		Type: ErrorTypeConfirmationRequired,
+		Message: fmt.Sprintf("Просадка %s %.2f%% не превышает порог %.2f%%: для хеджирования требуется подтверждение (confirm)",
+			pair, drawdownPercent, maxLossPercent),
+	}
+}
+
+// NewHedgeNotFoundError создает ошибку "открытый хедж не найден"
+func NewHedgeNotFoundError(orderID string) *StrategyError {
+	return &StrategyError{
+		Type:    ErrorTypeHedgeNotFound,
+		Message: fmt.Sprintf("Открытый хедж с ордером %s не найден (уже закрыт или не существует)", orderID),
+	}
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// AuditLogRepository отвечает за хранение журнала аудита ручных действий
+type AuditLogRepository interface {
+	// SaveAuditEntry сохраняет запись журнала аудита
+	SaveAuditEntry(ctx context.Context, entry *entities.AuditEntry) error
+
+	// GetAuditEntries возвращает записи в интервале [from, to) (новые первыми)
+	GetAuditEntries(ctx context.Context, from, to time.Time) ([]*entities.AuditEntry, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+)
+
+// ConfigHistoryRepository отвечает за хранение истории изменений конфигурации
+type ConfigHistoryRepository interface {
+	// SaveConfigVersion сохраняет версию конфигурации
+	SaveConfigVersion(ctx context.Context, version *entities.ConfigVersion) error
+
+	// GetConfigVersions возвращает версии конфигурации (новые первыми)
+	GetConfigVersions(ctx context.Context) ([]*entities.ConfigVersion, error)
+
+	// GetConfigVersion возвращает версию по ID (nil, если не найдена)
+	GetConfigVersion(ctx context.Context, id int) (*entities.ConfigVersion, error)
+
+	// GetLatestConfigVersion возвращает последнюю версию (nil, если истории нет)
+	GetLatestConfigVersion(ctx context.Context) (*entities.ConfigVersion, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// CustomMetricRepository необязательная возможность хранилища хеджей: выполнение read-only запросов
+// пользовательских метрик (entities.CustomMetric)
+type CustomMetricRepository interface {
+	// QueryScalar выполняет запрос в транзакции только для чтения с таймаутом и возвращает число
+	// из единственной колонки. nil - запрос не вернул строк или вернул NULL; больше одной строки или колонки - ошибка
+	QueryScalar(ctx context.Context, query string, timeout time.Duration) (*float64, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// EvaluationRepository отвечает за хранение истории наблюдений за сделками
+type EvaluationRepository interface {
+	// SaveEvaluations сохраняет наблюдения одного цикла
+	SaveEvaluations(ctx context.Context, evaluations []*entities.TradeEvaluation) error
+
+	// GetEvaluations возвращает наблюдения начиная с since (старые первыми)
+	GetEvaluations(ctx context.Context, since time.Time) ([]*entities.TradeEvaluation, error)
+}
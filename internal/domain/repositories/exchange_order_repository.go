@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// ExchangeOrderRepository отвечает за хранение импортированной истории ордеров биржевого аккаунта
+type ExchangeOrderRepository interface {
+	// SaveExchangeOrders сохраняет ордера, пропуская уже импортированные; возвращает количество новых
+	SaveExchangeOrders(ctx context.Context, orders []*entities.ExchangeOrder) (int, error)
+
+	// HasExchangeOrders проверяет, импортировалась ли уже история пары
+	HasExchangeOrders(ctx context.Context, pair string) (bool, error)
+
+	// GetExchangeOrders возвращает ордера, созданные начиная с since (старые первыми)
+	GetExchangeOrders(ctx context.Context, since time.Time) ([]*entities.ExchangeOrder, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+)
+
+// FeatureFlagRepository отвечает за хранение переключенных флагов возможностей и журнала переключений
+type FeatureFlagRepository interface {
+	// GetFeatureFlags возвращает переключенные флаги
+	GetFeatureFlags(ctx context.Context) ([]*entities.FeatureFlag, error)
+
+	// SaveFeatureFlag сохраняет состояние флага и запись журнала в одной транзакции.
+	// enabled nil удаляет сохраненное состояние (снова действует конфигурация)
+	SaveFeatureFlag(ctx context.Context, key string, enabled *bool, author string) (*entities.FeatureFlagChange, error)
+
+	// GetFeatureFlagChanges возвращает журнал переключений (новые первыми, не больше limit записей)
+	GetFeatureFlagChanges(ctx context.Context, limit int) ([]*entities.FeatureFlagChange, error)
+}
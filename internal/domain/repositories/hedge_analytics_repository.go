@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// HedgeAnalyticsRepository необязательная возможность хранилища хеджей: агрегаты для графиков аналитики
+type HedgeAnalyticsRepository interface {
+	// GetProfitTimeSeries возвращает реализованную прибыль хеджей, закрытых в [from, to), включая архив,
+	// по интервалам bucket (entities.ProfitBucket*) в хронологическом порядке.
+	// Интервалы без закрытых хеджей не возвращаются
+	GetProfitTimeSeries(ctx context.Context, bucket string, from, to time.Time) ([]*entities.ProfitBucket, error)
+
+	// GetProfitTotals возвращает итоги хеджей, закрытых до before, включая архив (Start не заполняется):
+	// с них начинается кривая накопленной прибыли
+	GetProfitTotals(ctx context.Context, before time.Time) (*entities.ProfitBucket, error)
+}
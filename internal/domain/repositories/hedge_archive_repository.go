@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// HedgeArchiveRepository переносит давно закрытые хеджи из рабочей таблицы в архив.
+// Архивные хеджи доступны выборке с HedgeTradeQuery.Archived и истории сделки (GetHedgeHistory)
+type HedgeArchiveRepository interface {
+	// ArchiveHedgedTrades переносит в архив хеджи в конечном статусе, закрытые раньше closedBefore.
+	// Возвращает количество перенесенных хеджей
+	ArchiveHedgedTrades(ctx context.Context, closedBefore time.Time) (int, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// HedgeDecisionRepository отвечает за хранение решений не хеджировать сделки
+type HedgeDecisionRepository interface {
+	// SaveHedgeDecisions сохраняет решения одного цикла
+	SaveHedgeDecisions(ctx context.Context, decisions []*entities.HedgeDecision) error
+
+	// GetHedgeDecisions возвращает решения в интервале [from, to) (старые первыми)
+	GetHedgeDecisions(ctx context.Context, from, to time.Time) ([]*entities.HedgeDecision, error)
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+)
+
+// HedgeGroupRepository отвечает за хранение хедж-групп: хеджей крупных сделок, разбитых на ноги
+type HedgeGroupRepository interface {
+	// SaveHedgeGroup сохраняет новую группу и заполняет ее ID
+	SaveHedgeGroup(ctx context.Context, group *entities.HedgeGroup) error
+
+	// UpdateHedgeGroup сохраняет прогресс и состояние группы
+	UpdateHedgeGroup(ctx context.Context, group *entities.HedgeGroup) error
+
+	// GetActiveHedgeGroups возвращает группы, которые еще размещают ноги (старые первыми)
+	GetActiveHedgeGroups(ctx context.Context) ([]*entities.HedgeGroup, error)
+
+	// GetHedgeGroups возвращает последние limit групп (новые первыми)
+	GetHedgeGroups(ctx context.Context, limit int) ([]*entities.HedgeGroup, error)
+
+	// GetHedgeGroupLegs возвращает ноги группы, включая архив (старые первыми)
+	GetHedgeGroupLegs(ctx context.Context, groupID int64) ([]*entities.HedgedTrade, error)
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+)
+
+// HedgeIntentRepository отвечает за хранение намерений хеджирования и их состояний
+type HedgeIntentRepository interface {
+	// SaveHedgeIntent сохраняет намерение до размещения ордера
+	SaveHedgeIntent(ctx context.Context, intent *entities.HedgeIntent) error
+
+	// UpdateHedgeIntent сохраняет состояние намерения и данные ордеров
+	UpdateHedgeIntent(ctx context.Context, intent *entities.HedgeIntent) error
+
+	// GetInFlightHedgeIntents возвращает намерения, не дошедшие до тейк-профита (старые первыми)
+	GetInFlightHedgeIntents(ctx context.Context) ([]*entities.HedgeIntent, error)
+
+	// GetHedgeIntentByOrderID находит намерение по ID ордера на покупку или тейк-профита (nil, если не найдено)
+	GetHedgeIntentByOrderID(ctx context.Context, orderID string) (*entities.HedgeIntent, error)
+
+	// CountHedgeIntents возвращает количество намерений для сделки и номера хеджа
+	CountHedgeIntents(ctx context.Context, tradeID, tranche int) (int, error)
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+)
+
+// HedgeOutcomeRepository отвечает за хранение итогов хеджирования по сделкам Freqtrade
+type HedgeOutcomeRepository interface {
+	// SaveHedgeOutcome сохраняет итог сделки (повторный расчет перезаписывает прежний)
+	SaveHedgeOutcome(ctx context.Context, outcome *entities.HedgeOutcome) error
+
+	// GetHedgeOutcomes возвращает рассчитанные итоги (недавно закрытые сделки первыми)
+	GetHedgeOutcomes(ctx context.Context) ([]*entities.HedgeOutcome, error)
+}
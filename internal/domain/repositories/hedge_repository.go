@@ -19,9 +19,45 @@ type HedgeRepository interface {
 	// Если status указан, возвращает сделки только с этим статусом
 	GetHedgedTrades(ctx context.Context, status *string) ([]*entities.HedgedTrade, error)
 
+	// QueryHedgedTrades возвращает страницу хеджей с фильтрами и сортировкой
+	// и общее количество хеджей, подходящих под фильтры
+	QueryHedgedTrades(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeTradePage, error)
+
+	// GetTradeStats возвращает агрегированную статистику хеджей, подходящих под фильтры выборки
+	// (период, пара, версия стратегии, статус); сортировка и пагинация не учитываются
+	GetTradeStats(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeStats, error)
+
 	// UpdateHedgedTradeStatus обновляет статус хеджированной сделки
 	UpdateHedgedTradeStatus(ctx context.Context, orderID string, status entities.OrderStatus, closePrice *float64, closeTime *time.Time) error
 
+	// UpdateHedgedTrade обновляет данные хеджированной сделки, найденной по текущему ID ордера
+	UpdateHedgedTrade(ctx context.Context, orderID string, hedgedTrade *entities.HedgedTrade) error
+
 	// GetHedgeHistory получает историю хедж-ордеров по конкретной сделке
 	GetHedgeHistory(ctx context.Context, tradeID int) ([]*entities.HedgedTrade, error)
+
+	// GetQuoteExposure возвращает агрегаты хеджей по котируемой валюте;
+	// DailyNotional считается по хеджам, открытым начиная с since
+	GetQuoteExposure(ctx context.Context, quote string, since time.Time) (*entities.QuoteExposure, error)
+}
+
+// DatabasePoolStats состояние пула соединений с базой данных
+type DatabasePoolStats struct {
+	MaxConns      int `json:"maxConns"`
+	TotalConns    int `json:"totalConns"`
+	IdleConns     int `json:"idleConns"`
+	AcquiredConns int `json:"acquiredConns"`
+}
+
+// PingableRepository необязательная возможность хранилища: проверка доступности базы данных
+// (для статуса веб-интерфейса). Хранилища в памяти ее не реализуют
+type PingableRepository interface {
+	// Ping проверяет соединение с базой данных
+	Ping(ctx context.Context) error
+}
+
+// PooledRepository необязательная возможность хранилища: состояние пула соединений
+type PooledRepository interface {
+	// PoolStats возвращает состояние пула соединений
+	PoolStats() *DatabasePoolStats
 }
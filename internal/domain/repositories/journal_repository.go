@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+)
+
+// JournalRepository отвечает за хранение записей торгового журнала
+type JournalRepository interface {
+	// SaveJournalEntry сохраняет запись журнала
+	SaveJournalEntry(ctx context.Context, entry *entities.JournalEntry) error
+
+	// GetJournalEntries возвращает все записи журнала (новые первыми)
+	GetJournalEntries(ctx context.Context) ([]*entities.JournalEntry, error)
+
+	// DeleteJournalEntry удаляет запись журнала по ID
+	DeleteJournalEntry(ctx context.Context, id int) error
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// LeaseRepository отвечает за аренду роли ведущего экземпляра
+type LeaseRepository interface {
+	// AcquireLease получает или продлевает аренду, если она свободна, истекла или уже принадлежит holder.
+	// Возвращает nil без ошибки, если аренда у другого экземпляра
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*entities.Lease, error)
+
+	// ReleaseLease освобождает аренду, если она принадлежит holder
+	ReleaseLease(ctx context.Context, name, holder string) error
+
+	// RequestHandoff просит текущего держателя передать аренду экземпляру requester
+	RequestHandoff(ctx context.Context, name, requester string) error
+
+	// GetLease возвращает текущее состояние аренды (nil, если ее еще никто не получал)
+	GetLease(ctx context.Context, name string) (*entities.Lease, error)
+}
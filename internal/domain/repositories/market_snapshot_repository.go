@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+)
+
+// MarketSnapshotRepository хранит последний снимок балансов и цен для показа при недоступности биржи
+type MarketSnapshotRepository interface {
+	// SaveMarketSnapshot заменяет сохраненный снимок
+	SaveMarketSnapshot(ctx context.Context, snapshot *entities.MarketSnapshot) error
+
+	// GetMarketSnapshot возвращает сохраненный снимок (nil, если снимка еще нет)
+	GetMarketSnapshot(ctx context.Context) (*entities.MarketSnapshot, error)
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+)
+
+// OrderEventRepository отвечает за хранение истории событий ордеров
+type OrderEventRepository interface {
+	// SaveOrderEvent сохраняет событие ордера
+	SaveOrderEvent(ctx context.Context, event *entities.OrderEvent) error
+
+	// GetOrderEvents возвращает события ордера в порядке возникновения
+	GetOrderEvents(ctx context.Context, orderID string) ([]*entities.OrderEvent, error)
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// RebalanceRepository отвечает за хранение аудита ребалансировки
+type RebalanceRepository interface {
+	// SaveRebalanceRecord сохраняет запись аудита ребалансировки
+	SaveRebalanceRecord(ctx context.Context, record *entities.RebalanceRecord) error
+
+	// GetLastRebalanceTime возвращает конец периода последней завершенной ребалансировки - все конвертации
+	// периода успешны (nil, если завершенных ребалансировок не было)
+	GetLastRebalanceTime(ctx context.Context) (*time.Time, error)
+
+	// GetConvertedAmounts возвращает суммы успешных конвертаций по активам в незавершенных ребалансировках
+	// периода, начатого в periodStart: при повторе конвертируется только остаток
+	GetConvertedAmounts(ctx context.Context, periodStart time.Time) (map[string]float64, error)
+
+	// GetRebalanceRecords возвращает записи аудита ребалансировки (новые первыми)
+	GetRebalanceRecords(ctx context.Context, limit int) ([]*entities.RebalanceRecord, error)
+}
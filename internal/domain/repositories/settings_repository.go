@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// SettingsRepository отвечает за хранение параметров, измененных во время работы, и журнала их изменений
+type SettingsRepository interface {
+	// GetSettings возвращает сохраненные параметры
+	GetSettings(ctx context.Context) ([]*entities.Setting, error)
+
+	// SaveSetting сохраняет значение параметра и запись журнала в одной транзакции.
+	// value nil удаляет сохраненное значение (снова действует значение из файла).
+	// ttl > 0 сохраняет временное значение: после ttl возвращается постоянное значение (Setting.BaseValue)
+	SaveSetting(ctx context.Context, key string, value *float64, ttl time.Duration, author string) (*entities.SettingChange, error)
+
+	// RevertExpiredSettings возвращает постоянные значения параметров, временные значения которых закончились,
+	// и записывает возврат в журнал от имени author. Возвращает записи журнала
+	RevertExpiredSettings(ctx context.Context, author string) ([]*entities.SettingChange, error)
+
+	// GetSettingChanges возвращает журнал изменений (новые первыми, не больше limit записей)
+	GetSettingChanges(ctx context.Context, limit int) ([]*entities.SettingChange, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// StatusClaimRepository распределяет проверку статусов хеджей между экземплярами с ролью status-checker
+type StatusClaimRepository interface {
+	// ClaimHedges захватывает для holder до limit хеджей из hedgeIDs на срок ttl: свободные, с истекшим
+	// захватом или уже захваченные holder (они продлеваются первыми). Захваты хеджей не из hedgeIDs,
+	// принадлежащие holder или истекшие, удаляются. Возвращает ID захваченных хеджей
+	ClaimHedges(ctx context.Context, holder string, hedgeIDs []int64, limit int, ttl time.Duration) ([]int64, error)
+}
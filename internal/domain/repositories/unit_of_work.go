@@ -0,0 +1,12 @@
+package repositories
+
+import "context"
+
+// UnitOfWork выполняет несколько записей в хранилище атомарно: хедж и начальные события его ордеров
+// сохраняются вместе, и сбой процесса между записями не оставляет частично записанный хедж
+type UnitOfWork interface {
+	// WithinTransaction выполняет fn в транзакции. Репозитории, вызванные с контекстом fn, пишут в эту
+	// транзакцию; она фиксируется, если fn завершилась без ошибки, иначе откатывается.
+	// Вложенный вызов выполняется в уже открытой транзакции
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
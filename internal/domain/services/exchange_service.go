@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/valueobjects"
 )
 
 // OrderStatusInfo информация о статусе ордера
@@ -14,6 +15,9 @@ type OrderStatusInfo struct {
 	FilledTime   *time.Time // Время исполнения (если исполнен)
 	FilledQty    float64    // Исполненное количество
 	RemainingQty float64    // Остаток количества
+	Fee          float64    // Накопленная комиссия за исполнение
+	FeeCurrency  string     // Валюта комиссии (на споте при покупке обычно базовая, при продаже - котируемая)
+	RawResponse  string     `json:"-"` // Необработанный ответ биржи (JSON), пусто - неизвестен
 }
 
 // InstrumentInfo информация об инструменте (минимальные лимиты, размеры шагов и т.д.)
@@ -30,17 +34,150 @@ type InstrumentInfo struct {
 	Status      string  // Статус инструмента (Trading, Break, etc.)
 }
 
+// Rules возвращает ограничения инструмента для построения цен и количеств ордеров
+func (i *InstrumentInfo) Rules() valueobjects.InstrumentRules {
+	return valueobjects.InstrumentRules{
+		TickSize:  i.TickSize,
+		StepSize:  i.StepSize,
+		MinQty:    i.MinOrderQty,
+		MaxQty:    i.MaxOrderQty,
+		MinAmount: i.MinOrderAmt,
+		MaxAmount: i.MaxOrderAmt,
+	}
+}
+
 // ExchangeService определяет интерфейс для работы с биржей
 type ExchangeService interface {
 	// PlaceOrder размещает ордер на бирже
 	PlaceOrder(ctx context.Context, order *entities.Order) (*entities.OrderResult, error)
 
+	// CancelOrder отменяет ордер (неисполненный остаток) по ID
+	CancelOrder(ctx context.Context, orderID, symbol string) (*entities.OrderResult, error)
+
 	// GetBalance получает баланс по определенной валюте
 	GetBalance(ctx context.Context, asset string) (*entities.Balance, error)
 
 	// GetOrderStatus получает статус ордера по ID
 	GetOrderStatus(ctx context.Context, orderID, symbol string) (*OrderStatusInfo, error)
 
+	// GetOrderStatusByClientID получает статус ордера по клиентскому ID.
+	// Возвращает nil без ошибки, если ордер не найден
+	GetOrderStatusByClientID(ctx context.Context, clientOrderID, symbol string) (*OrderStatusInfo, error)
+
 	// GetInstrumentInfo получает информацию об инструменте (минимальные лимиты, размеры шагов)
 	GetInstrumentInfo(ctx context.Context, symbol string) (*InstrumentInfo, error)
+
+	// GetTickerPrice получает последнюю цену инструмента
+	GetTickerPrice(ctx context.Context, symbol string) (float64, error)
+
+	// GetKlines получает часовые свечи инструмента за период (старые первыми)
+	GetKlines(ctx context.Context, symbol string, start, end time.Time) ([]*entities.Kline, error)
+}
+
+// OCOOrderResult результат размещения связанной пары ордеров на выход
+type OCOOrderResult struct {
+	TakeProfitOrderID string // ID лимитного ордера тейк-профита
+	StopLossOrderID   string // ID ордера стоп-лосса
+}
+
+// OCOExchangeService необязательная возможность биржи: нативные OCO-ордера на выход
+// (исполнение одного ордера биржа сама отменяет другой). Если сервис биржи ее не реализует,
+// OCO эмулируется проверкой статусов
+type OCOExchangeService interface {
+	// PlaceOCOOrder размещает тейк-профит и стоп-лосс на продажу количества как связанную пару
+	PlaceOCOOrder(ctx context.Context, symbol string, quantity, takeProfitPrice, stopLossPrice float64) (*OCOOrderResult, error)
+}
+
+// OrderHistoryExchangeService необязательная возможность биржи: история ордеров аккаунта.
+// Используется для импорта ручной торговли до запуска бота в аналитику
+type OrderHistoryExchangeService interface {
+	// GetOrderHistory получает ордера инструмента, созданные в период (старые первыми)
+	GetOrderHistory(ctx context.Context, symbol string, start, end time.Time) ([]*entities.ExchangeOrder, error)
+}
+
+// ConvertQuote твердая котировка конвертации (RFQ): биржа обязуется обменять FromAmount на ToAmount до ExpiresAt
+type ConvertQuote struct {
+	QuoteID    string    // ID котировки для исполнения
+	FromCoin   string    // Отдаваемая валюта (например, USDT)
+	ToCoin     string    // Получаемая валюта (например, SOL)
+	FromAmount float64   // Сумма к списанию в FromCoin
+	ToAmount   float64   // Сумма к зачислению в ToCoin
+	ExpiresAt  time.Time // Время окончания действия котировки
+}
+
+// Price возвращает цену получаемой валюты в отдаваемой (для покупки - цену входа в котируемой валюте)
+func (q *ConvertQuote) Price() float64 {
+	if q.ToAmount <= 0 {
+		return 0
+	}
+	return q.FromAmount / q.ToAmount
+}
+
+// ConvertResult результат исполненной конвертации
+type ConvertResult struct {
+	QuoteID    string  // ID исполненной котировки
+	ExchangeID string  // ID операции обмена на бирже
+	FromAmount float64 // Фактически списано в отдаваемой валюте
+	ToAmount   float64 // Фактически зачислено в получаемой валюте
+}
+
+// ConvertExchangeService необязательная возможность биржи: конвертация по твердой котировке (RFQ)
+// в обход стакана. Используется для пар с тонким стаканом, где лимитная покупка не исполняется
+// или исполняется с большим проскальзыванием
+type ConvertExchangeService interface {
+	// RequestConvertQuote запрашивает котировку обмена fromAmount валюты fromCoin на toCoin
+	RequestConvertQuote(ctx context.Context, fromCoin, toCoin string, fromAmount float64) (*ConvertQuote, error)
+
+	// ExecuteConvert исполняет котировку и дожидается завершения обмена.
+	// Возвращает ошибку, если биржа отклонила обмен или он не завершился
+	ExecuteConvert(ctx context.Context, quoteID string) (*ConvertResult, error)
+}
+
+// InstrumentsExchangeService необязательная возможность биржи: полный список спотовых инструментов.
+// Используется для проверки соответствия пар Freqtrade символам биржи перед развертыванием
+type InstrumentsExchangeService interface {
+	// GetInstruments возвращает все спотовые инструменты биржи
+	GetInstruments(ctx context.Context) ([]*InstrumentInfo, error)
+}
+
+// AllBalancesExchangeService необязательная возможность биржи: балансы всех валют аккаунта одним запросом.
+// Используется страницей балансов; без нее показываются базовая валюта и валюты дашборда
+type AllBalancesExchangeService interface {
+	// GetAllBalances возвращает балансы всех валют с ненулевым балансом (с оценкой в USD, если биржа ее отдает)
+	GetAllBalances(ctx context.Context) ([]*entities.Balance, error)
+}
+
+// TickersExchangeService необязательная возможность биржи: текущие цены нескольких символов одним запросом
+type TickersExchangeService interface {
+	// GetTickerPrices возвращает последние цены символов (например, SOLUSDT); ненайденные символы в результат не попадают
+	GetTickerPrices(ctx context.Context, symbols []string) (map[string]float64, error)
+}
+
+// BookTicker лучшие цены стакана инструмента
+type BookTicker struct {
+	Bid float64 // Лучшая цена покупки
+	Ask float64 // Лучшая цена продажи
+}
+
+// SpreadPercent возвращает спред между лучшими ценами в процентах от цены продажи (0 - если цены неизвестны)
+func (t *BookTicker) SpreadPercent() float64 {
+	if t.Bid <= 0 || t.Ask <= 0 {
+		return 0
+	}
+	return (t.Ask - t.Bid) / t.Ask * 100
+}
+
+// BookTickerExchangeService необязательная возможность биржи: лучшие цены стакана.
+// Используется фильтром спреда перед хеджированием; без нее фильтр пропускает пару
+type BookTickerExchangeService interface {
+	// GetBookTicker получает лучшие цены покупки и продажи инструмента
+	GetBookTicker(ctx context.Context, symbol string) (*BookTicker, error)
+}
+
+// OrderBookExchangeService необязательная возможность биржи: глубина стакана.
+// Используется для разбиения крупного хеджа на ноги, которые стакан исполнит без сильного проскальзывания
+type OrderBookExchangeService interface {
+	// GetAskDepth возвращает сумму заявок на продажу в котируемой валюте по ценам не выше лучшей цены
+	// продажи плюс withinPercent процентов
+	GetAskDepth(ctx context.Context, symbol string, withinPercent float64) (float64, error)
 }
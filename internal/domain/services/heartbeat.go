@@ -0,0 +1,9 @@
+package services
+
+import "context"
+
+// Heartbeat сообщает внешнему мониторингу, что бот продолжает выполнять циклы
+type Heartbeat interface {
+	// Beat отмечает успешно завершенный цикл
+	Beat(ctx context.Context) error
+}
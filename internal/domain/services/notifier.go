@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+)
+
+// Notifier отправляет оповещения оператору
+type Notifier interface {
+	// Notify отправляет оповещение
+	Notify(ctx context.Context, notification *entities.Notification) error
+}
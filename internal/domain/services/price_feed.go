@@ -0,0 +1,12 @@
+package services
+
+import "context"
+
+// PriceFeed источник текущих цен для оценки открытых позиций
+type PriceFeed interface {
+	// CurrentPrice возвращает текущую цену пары (например, SOL/USDT)
+	CurrentPrice(ctx context.Context, pair string) (float64, error)
+
+	// CurrentPrices возвращает текущие цены нескольких пар; пары без цены в результат не попадают
+	CurrentPrices(ctx context.Context, pairs []string) (map[string]float64, error)
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"trade-hedge/internal/domain/entities"
+)
+
+// SignalSource внешний источник сигналов хеджирования (webhook, файлы, список Redis)
+type SignalSource interface {
+	// Name возвращает название источника для логов
+	Name() string
+
+	// Receive забирает накопленные сигналы: полученный сигнал источник больше не возвращает.
+	// Некорректные записи источник пропускает сам, ошибка - только недоступность источника
+	Receive(ctx context.Context) ([]*entities.HedgeSignal, error)
+}
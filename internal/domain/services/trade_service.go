@@ -9,4 +9,10 @@ import (
 type TradeService interface {
 	// GetActiveTrades получает активные сделки из торговой платформы
 	GetActiveTrades(ctx context.Context) ([]*entities.Trade, error)
+
+	// GetPairLocks получает блокировки пар, установленные торговой платформой
+	GetPairLocks(ctx context.Context) ([]*entities.PairLock, error)
+
+	// GetClosedTrades получает закрытые сделки из истории торговой платформы
+	GetClosedTrades(ctx context.Context) ([]*entities.Trade, error)
 }
@@ -0,0 +1,74 @@
+package valueobjects
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Точность отображения сумм по классам активов (знаков после запятой)
+const (
+	FiatPrecision     int32 = 2  // Фиат и стейблкоины
+	MajorPrecision    int32 = 8  // BTC, ETH и другие дорогие активы
+	DefaultPrecision  int32 = 6  // Прочие активы
+	MicroCapPrecision int32 = 10 // Микрокапы с ценой в миллионных долях
+)
+
+// currencyPrecisionRegistry реестр точности отображения сумм по активам.
+// Логи, веб-интерфейс, экспорт и оповещения берут точность отсюда, чтобы суммы выглядели одинаково везде
+type currencyPrecisionRegistry struct {
+	mu        sync.RWMutex
+	precision map[string]int32
+}
+
+// currencyPrecisions реестр точности по умолчанию
+var currencyPrecisions = &currencyPrecisionRegistry{
+	precision: map[string]int32{
+		"USD": FiatPrecision, "EUR": FiatPrecision, "GBP": FiatPrecision, "RUB": FiatPrecision,
+		"USDT": FiatPrecision, "USDC": FiatPrecision, "FDUSD": FiatPrecision, "DAI": FiatPrecision,
+		"TUSD": FiatPrecision, "BUSD": FiatPrecision, "USDE": FiatPrecision,
+		"BTC": MajorPrecision, "ETH": MajorPrecision,
+		"SHIB": MicroCapPrecision, "PEPE": MicroCapPrecision, "BONK": MicroCapPrecision,
+		"FLOKI": MicroCapPrecision, "BTT": MicroCapPrecision, "LUNC": MicroCapPrecision,
+	},
+}
+
+// RegisterCurrencyPrecision задает точность отображения сумм актива (например, для нового микрокапа)
+func RegisterCurrencyPrecision(currency string, precision int32) {
+	currencyPrecisions.mu.Lock()
+	defer currencyPrecisions.mu.Unlock()
+	currencyPrecisions.precision[strings.ToUpper(currency)] = precision
+}
+
+// CurrencyPrecision возвращает точность отображения сумм актива; для неизвестного актива - DefaultPrecision
+func CurrencyPrecision(currency string) int32 {
+	currencyPrecisions.mu.RLock()
+	defer currencyPrecisions.mu.RUnlock()
+	if precision, ok := currencyPrecisions.precision[strings.ToUpper(currency)]; ok {
+		return precision
+	}
+	return DefaultPrecision
+}
+
+// FormatAmount форматирует сумму в активе currency с его точностью (без обозначения валюты)
+func FormatAmount(amount float64, currency string) string {
+	return strconv.FormatFloat(amount, 'f', int(CurrencyPrecision(currency)), 64)
+}
+
+// PricePrecision возвращает точность отображения цены пары: не меньше точности котируемой валюты,
+// для микрокапов - MicroCapPrecision, чтобы цена вроде 0.0000123 не превращалась в 0.000012
+func (tp *TradingPair) PricePrecision() int32 {
+	precision := DefaultPrecision
+	if CurrencyPrecision(tp.BaseCurrency()) == MicroCapPrecision {
+		precision = MicroCapPrecision
+	}
+	if quote := CurrencyPrecision(tp.QuoteCurrency()); quote > precision {
+		precision = quote
+	}
+	return precision
+}
+
+// FormatPrice форматирует цену пары с ее точностью
+func (tp *TradingPair) FormatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', int(tp.PricePrecision()), 64)
+}
@@ -0,0 +1,253 @@
+package valueobjects
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode режим округления десятичного числа
+type RoundingMode int
+
+const (
+	RoundFloor    RoundingMode = iota // Вниз (к минус бесконечности)
+	RoundCeil                         // Вверх (к плюс бесконечности)
+	RoundHalfUp                       // До ближайшего, половина - от нуля
+	RoundHalfEven                     // До ближайшего, половина - к четному (банковское)
+)
+
+// Decimal точное десятичное число: coef * 10^-scale.
+// Используется для цен и количеств ордеров вместо float64, чтобы значения, кратные шагу биржи,
+// не превращались в 0.30000000000000004
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+// NewDecimal создает число coef * 10^-scale
+func NewDecimal(coef int64, scale int32) Decimal {
+	return Decimal{coef: big.NewInt(coef), scale: scale}
+}
+
+// NewDecimalFromFloat создает число из кратчайшего десятичного представления float64
+// (0.1 → 0.1, а не 0.1000000000000000055...)
+func NewDecimalFromFloat(value float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		// NaN и бесконечности не встречаются в ценах и количествах
+		return NewDecimal(0, 0)
+	}
+	return d
+}
+
+// ParseDecimal разбирает десятичную строку вида "-123.456"
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	digits := intPart + fracPart
+	if digits == "" || digits == "-" || digits == "+" {
+		return Decimal{}, fmt.Errorf("некорректное десятичное число: %q", s)
+	}
+
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("некорректное десятичное число: %q", s)
+	}
+	return Decimal{coef: coef, scale: int32(len(fracPart))}, nil
+}
+
+// value возвращает коэффициент; нулевое значение Decimal{} считается нулем
+func (d Decimal) value() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale возвращает коэффициент числа при большей или равной точности scale
+func (d Decimal) rescale(scale int32) *big.Int {
+	coef := new(big.Int).Set(d.value())
+	if scale > d.scale {
+		coef.Mul(coef, pow10(scale-d.scale))
+	}
+	return coef
+}
+
+// align приводит два числа к общей точности
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// Add возвращает d + other
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{coef: a.Add(a, b), scale: scale}
+}
+
+// Sub возвращает d - other
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{coef: a.Sub(a, b), scale: scale}
+}
+
+// Mul возвращает d * other (точно, без округления)
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.value(), other.value()), scale: d.scale + other.scale}
+}
+
+// Div возвращает d / other с scale знаками после запятой, округленное режимом mode.
+// Деление на ноль возвращает ноль
+func (d Decimal) Div(other Decimal, scale int32, mode RoundingMode) Decimal {
+	if other.Sign() == 0 {
+		return NewDecimal(0, scale)
+	}
+
+	// d / other = (d.coef * 10^(scale + other.scale - d.scale)) / other.coef * 10^-scale
+	num := new(big.Int).Set(d.value())
+	den := new(big.Int).Set(other.value())
+	if shift := scale + other.scale - d.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return Decimal{coef: divRound(num, den, mode), scale: scale}
+}
+
+// Round округляет до places знаков после запятой (как RoundToStep с шагом 10^-places)
+func (d Decimal) Round(places int32, mode RoundingMode) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	rounded := d.RoundToStep(NewDecimal(1, places), mode)
+	return Decimal{coef: rounded.value().Quo(rounded.value(), pow10(d.scale-places)), scale: places}
+}
+
+// stepSnapDivisor доля шага (1/stepSnapDivisor), в пределах которой число считается кратным шагу
+const stepSnapDivisor = 1_000_000_000
+
+// RoundToStep округляет до кратного step (шага цены или количества); при step <= 0 число не меняется.
+// Число в пределах миллиардной доли шага от кратного считается кратным: цены и количества считаются в float64,
+// и шум вроде 0.1+0.2 = 0.30000000000000004 не должен сдвигать округление вверх или вниз на целый шаг
+func (d Decimal) RoundToStep(step Decimal, mode RoundingMode) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	value, stepCoef, scale := align(d, step)
+
+	steps := divRound(value, stepCoef, RoundHalfUp)
+	deviation := new(big.Int).Sub(value, new(big.Int).Mul(steps, stepCoef))
+	deviation.Abs(deviation).Mul(deviation, big.NewInt(stepSnapDivisor))
+	if deviation.Cmp(stepCoef) > 0 {
+		steps = divRound(value, stepCoef, mode)
+	}
+	return Decimal{coef: steps.Mul(steps, stepCoef), scale: scale}
+}
+
+// Cmp сравнивает числа: -1 если d < other, 0 если равны, 1 если d > other
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := align(d, other)
+	return a.Cmp(b)
+}
+
+// Sign возвращает -1, 0 или 1 в зависимости от знака числа
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// Float64 возвращает ближайшее к числу значение float64
+func (d Decimal) Float64() float64 {
+	value, _ := strconv.ParseFloat(d.String(), 64)
+	return value
+}
+
+// String возвращает число без незначащих нулей в дробной части
+func (d Decimal) String() string {
+	s := d.StringFixed(d.scale)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// StringFixed возвращает число ровно с places знаками после запятой (лишние знаки округляются от нуля)
+func (d Decimal) StringFixed(places int32) string {
+	if places < 0 {
+		places = 0
+	}
+	coef := d.Round(places, RoundHalfUp).rescale(places)
+
+	negative := coef.Sign() < 0
+	digits := new(big.Int).Abs(coef).String()
+	if places > 0 {
+		if pad := int(places) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(places)] + "." + digits[len(digits)-int(places):]
+	}
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
+
+// ApplyPercent возвращает d * (1 + percent/100)
+func (d Decimal) ApplyPercent(percent Decimal) Decimal {
+	return d.Add(d.Mul(percent).Mul(NewDecimal(1, 2)))
+}
+
+// DecimalPlaces возвращает количество значащих знаков после запятой (0.0100 → 2, 1 → 0);
+// для шага цены или количества это точность инструмента
+func (d Decimal) DecimalPlaces() int32 {
+	_, fracPart, found := strings.Cut(d.String(), ".")
+	if !found {
+		return 0
+	}
+	return int32(len(fracPart))
+}
+
+// divRound делит num на den (den > 0 после нормализации знака) с округлением режимом mode
+func divRound(num, den *big.Int, mode RoundingMode) *big.Int {
+	if den.Sign() < 0 {
+		num = new(big.Int).Neg(num)
+		den = new(big.Int).Neg(den)
+	}
+
+	// Деление с остатком к минус бесконечности: num = quo*den + rem, 0 <= rem < den
+	quo, rem := new(big.Int).DivMod(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	switch mode {
+	case RoundFloor:
+		return quo
+	case RoundCeil:
+		return quo.Add(quo, big.NewInt(1))
+	}
+
+	// Сравниваем остаток с половиной делителя
+	cmp := new(big.Int).Mul(rem, big.NewInt(2)).Cmp(den)
+	roundUp := cmp > 0
+	if cmp == 0 {
+		if mode == RoundHalfEven {
+			roundUp = quo.Bit(0) == 1
+		} else {
+			// Половина от нуля: quo округлен к минус бесконечности, поэтому поднимаем только положительные
+			roundUp = num.Sign() >= 0
+		}
+	}
+	if roundUp {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return quo
+}
+
+// pow10 возвращает 10^n
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,198 @@
+package valueobjects
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestDivRound проверяет четыре режима округления для положительных и отрицательных частных
+func TestDivRound(t *testing.T) {
+	tests := []struct {
+		num, den                      int64
+		floor, ceil, halfUp, halfEven int64
+	}{
+		// Без остатка
+		{6, 2, 3, 3, 3, 3},
+		{-6, 2, -3, -3, -3, -3},
+
+		// Меньше половины
+		{7, 3, 2, 3, 2, 2},
+		{-7, 3, -3, -2, -2, -2},
+
+		// Больше половины
+		{8, 3, 2, 3, 3, 3},
+		{-8, 3, -3, -2, -3, -3},
+
+		// Ровно половина: к четному вниз
+		{5, 2, 2, 3, 3, 2},
+		{-5, 2, -3, -2, -3, -2},
+
+		// Ровно половина: к четному вверх
+		{7, 2, 3, 4, 4, 4},
+		{-7, 2, -4, -3, -4, -4},
+
+		// Отрицательный делитель
+		{5, -2, -3, -2, -3, -2},
+		{-5, -2, 2, 3, 3, 2},
+	}
+
+	for _, tt := range tests {
+		for _, c := range []struct {
+			mode RoundingMode
+			name string
+			want int64
+		}{
+			{RoundFloor, "RoundFloor", tt.floor},
+			{RoundCeil, "RoundCeil", tt.ceil},
+			{RoundHalfUp, "RoundHalfUp", tt.halfUp},
+			{RoundHalfEven, "RoundHalfEven", tt.halfEven},
+		} {
+			num, den := big.NewInt(tt.num), big.NewInt(tt.den)
+			if got := divRound(num, den, c.mode); got.Int64() != c.want {
+				t.Errorf("divRound(%d, %d, %s) = %s, ожидалось %d", tt.num, tt.den, c.name, got, c.want)
+			}
+			if num.Int64() != tt.num || den.Int64() != tt.den {
+				t.Errorf("divRound(%d, %d, %s) изменил аргументы: %s, %s", tt.num, tt.den, c.name, num, den)
+			}
+		}
+	}
+}
+
+// TestRoundToStep проверяет округление до шага цены или количества
+func TestRoundToStep(t *testing.T) {
+	tests := []struct {
+		value string
+		step  string
+		mode  RoundingMode
+		want  string
+	}{
+		// Шум float64 (0.1 + 0.2 = 0.30000000000000004) не сдвигает округление на шаг ни в одном режиме
+		{"0.30000000000000004", "0.1", RoundFloor, "0.3"},
+		{"0.30000000000000004", "0.01", RoundFloor, "0.3"},
+		{"0.30000000000000004", "0.01", RoundHalfUp, "0.3"},
+		{"0.30000000000000004", "0.01", RoundCeil, "0.3"},
+		{"0.29999999999999993", "0.01", RoundFloor, "0.3"},
+		{"-0.30000000000000004", "0.01", RoundFloor, "-0.3"},
+		{"60000.000000000007", "0.01", RoundCeil, "60000"},
+
+		// Отклонение больше шума округляется по режиму
+		{"0.301", "0.01", RoundCeil, "0.31"},
+		{"0.3000001", "0.01", RoundCeil, "0.31"},
+		{"0.2999999", "0.01", RoundFloor, "0.29"},
+
+		{"1.26", "0.5", RoundFloor, "1"},
+		{"1.26", "0.5", RoundCeil, "1.5"},
+		{"1.26", "0.5", RoundHalfUp, "1.5"},
+		{"-1.26", "0.5", RoundFloor, "-1.5"},
+		{"-1.26", "0.5", RoundCeil, "-1"},
+		{"12", "5", RoundHalfEven, "10"},
+		{"12.5", "5", RoundHalfEven, "10"},
+		{"17.5", "5", RoundHalfEven, "20"},
+		{"100", "0.001", RoundFloor, "100"},
+		{"0.123456", "0.0001", RoundFloor, "0.1234"},
+
+		// Шаг не задан - число не меняется
+		{"1.23456", "0", RoundFloor, "1.23456"},
+		{"1.23456", "-0.1", RoundFloor, "1.23456"},
+	}
+
+	for _, tt := range tests {
+		value := mustParseDecimal(t, tt.value)
+		step := mustParseDecimal(t, tt.step)
+		if got := value.RoundToStep(step, tt.mode).String(); got != tt.want {
+			t.Errorf("%s.RoundToStep(%s, %d) = %s, ожидалось %s", tt.value, tt.step, tt.mode, got, tt.want)
+		}
+	}
+
+	// Сумма float64, лежащая на шаге, остается на нем при направленном округлении
+	sum := NewDecimalFromFloat(0.1 + 0.2)
+	for _, mode := range []RoundingMode{RoundFloor, RoundCeil, RoundHalfUp, RoundHalfEven} {
+		if got := sum.RoundToStep(NewDecimalFromFloat(0.01), mode).StringFixed(2); got != "0.30" {
+			t.Errorf("(0.1+0.2).RoundToStep(0.01, %d) = %s, ожидалось 0.30", mode, got)
+		}
+	}
+	if got := NewDecimalFromFloat(0.7-0.4).Round(2, RoundFloor).StringFixed(2); got != "0.30" {
+		t.Errorf("(0.7-0.4).Round(2, RoundFloor) = %s, ожидалось 0.30", got)
+	}
+	if got := NewDecimalFromFloat(0.1).Add(NewDecimalFromFloat(0.2)).String(); got != "0.3" {
+		t.Errorf("0.1 + 0.2 = %s, ожидалось 0.3", got)
+	}
+}
+
+// TestStringFixed проверяет дополнение нулями и округление до заданного количества знаков
+func TestStringFixed(t *testing.T) {
+	tests := []struct {
+		value  Decimal
+		places int32
+		want   string
+	}{
+		{NewDecimal(5, 0), 2, "5.00"},
+		{NewDecimal(5, 3), 3, "0.005"},
+		{NewDecimal(5, 3), 6, "0.005000"},
+		{NewDecimal(-5, 4), 6, "-0.000500"},
+		{NewDecimal(12345, 2), 2, "123.45"},
+		{NewDecimal(12345, 2), 0, "123"},
+
+		// Лишние знаки округляются от нуля
+		{NewDecimal(5, 3), 2, "0.01"},
+		{NewDecimal(-5, 3), 2, "-0.01"},
+		{NewDecimal(15, 1), 0, "2"},
+		{NewDecimal(-15, 1), 0, "-2"},
+		{NewDecimal(-1, 3), 2, "0.00"},
+
+		// Отрицательная точность считается нулевой, нулевое значение - нулем
+		{NewDecimal(15, 1), -1, "2"},
+		{Decimal{}, 2, "0.00"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.value.StringFixed(tt.places); got != tt.want {
+			t.Errorf("%s.StringFixed(%d) = %s, ожидалось %s", tt.value, tt.places, got, tt.want)
+		}
+	}
+}
+
+// TestParseDecimal проверяет разбор десятичных строк со знаком
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"123.456", "123.456"},
+		{"-123.456", "-123.456"},
+		{"+1.50", "1.5"},
+		{"-0.05", "-0.05"},
+		{"-0", "0"},
+		{".5", "0.5"},
+		{"-.5", "-0.5"},
+		{"  42 ", "42"},
+		{"0.000001", "0.000001"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDecimal(tt.input)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q): %v", tt.input, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, ожидалось %s", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "-", "+", ".", "-.", "abc", "1.2.3", "1e5", "--1", "1,5"} {
+		if _, err := ParseDecimal(input); err == nil {
+			t.Errorf("ParseDecimal(%q): ожидалась ошибка", input)
+		}
+	}
+}
+
+// mustParseDecimal разбирает число для таблицы теста
+func mustParseDecimal(t *testing.T, s string) Decimal {
+	t.Helper()
+	d, err := ParseDecimal(s)
+	if err != nil {
+		t.Fatalf("ParseDecimal(%q): %v", s, err)
+	}
+	return d
+}
@@ -0,0 +1,49 @@
+package valueobjects
+
+import "fmt"
+
+// InstrumentRules ограничения инструмента биржи для цен и количеств ордеров (нулевое значение - ограничения нет)
+type InstrumentRules struct {
+	TickSize  float64 // Шаг цены
+	StepSize  float64 // Шаг количества
+	MinQty    float64 // Минимальное количество
+	MaxQty    float64 // Максимальное количество
+	MinAmount float64 // Минимальная сумма ордера в котируемой валюте
+	MaxAmount float64 // Максимальная сумма ордера в котируемой валюте
+}
+
+// ValidateAmount проверяет сумму ордера в котируемой валюте на минимальный и максимальный лимиты
+func (r InstrumentRules) ValidateAmount(amount float64) error {
+	if r.MinAmount > 0 && amount < r.MinAmount {
+		return fmt.Errorf("сумма ордера %.8g меньше минимальной %.8g", amount, r.MinAmount)
+	}
+	if r.MaxAmount > 0 && amount > r.MaxAmount {
+		return fmt.Errorf("сумма ордера %.8g больше максимальной %.8g", amount, r.MaxAmount)
+	}
+	return nil
+}
+
+// stepDecimals возвращает точность по шагу: количество знаков после запятой или -1, если шаг неизвестен
+func stepDecimals(step float64) int32 {
+	if step <= 0 {
+		return -1
+	}
+	return NewDecimalFromFloat(step).DecimalPlaces()
+}
+
+// roundToStep округляет value до кратного step; при step <= 0 значение не меняется
+func roundToStep(value, step float64, mode RoundingMode) Decimal {
+	decimal := NewDecimalFromFloat(value)
+	if step <= 0 {
+		return decimal
+	}
+	return decimal.RoundToStep(NewDecimalFromFloat(step), mode)
+}
+
+// formatStep возвращает число с точностью шага; при неизвестном шаге - без незначащих нулей
+func formatStep(value Decimal, step float64) string {
+	if places := stepDecimals(step); places >= 0 {
+		return value.StringFixed(places)
+	}
+	return value.String()
+}
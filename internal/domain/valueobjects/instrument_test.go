@@ -0,0 +1,118 @@
+package valueobjects
+
+import "testing"
+
+// Ограничения инструментов в том виде, как их возвращает Bybit v5 (instruments-info, spot)
+var (
+	btcRules  = InstrumentRules{TickSize: 0.01, StepSize: 0.000001, MinQty: 0.000048, MaxQty: 71.73956243, MinAmount: 1, MaxAmount: 2000000}
+	solRules  = InstrumentRules{TickSize: 0.01, StepSize: 0.001, MinQty: 0.001, MaxQty: 12000, MinAmount: 1, MaxAmount: 400000}
+	pepeRules = InstrumentRules{TickSize: 0.00000001, StepSize: 1, MinQty: 1000, MaxQty: 5000000000, MinAmount: 1, MaxAmount: 200000}
+	halfRules = InstrumentRules{TickSize: 0.5, StepSize: 0.5}
+)
+
+// TestQuantity проверяет округление количества до шага, лимиты инструмента и запись для биржи
+func TestQuantity(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		rules   InstrumentRules
+		mode    RoundingMode
+		want    string
+		wantErr bool
+	}{
+		{"BTC вниз", 0.0012345678, btcRules, RoundFloor, "0.001234", false},
+		{"BTC вверх", 0.0012345678, btcRules, RoundCeil, "0.001235", false},
+		{"BTC меньше минимального", 0.00002, btcRules, RoundFloor, "0.000020", true},
+		{"BTC больше максимального", 100, btcRules, RoundFloor, "100.000000", true},
+		{"SOL дополняется нулями", 1.5, solRules, RoundFloor, "1.500", false},
+		{"SOL шум float64", 0.1 + 0.2, solRules, RoundFloor, "0.300", false},
+		{"SOL округлено до нуля", 0.0004, solRules, RoundFloor, "0.000", true},
+		{"PEPE целый шаг вниз", 123456.7, pepeRules, RoundFloor, "123456", false},
+		{"PEPE целый шаг до ближайшего", 123456.7, pepeRules, RoundHalfUp, "123457", false},
+		{"PEPE вверх до минимального", 999.9, pepeRules, RoundCeil, "1000", false},
+		{"PEPE меньше минимального вниз", 999.9, pepeRules, RoundFloor, "999", true},
+		{"шаг 0.5 к четному", 1.25, halfRules, RoundHalfEven, "1.0", false},
+		{"шаг неизвестен", 1.2300, InstrumentRules{}, RoundFloor, "1.23", false},
+		{"отрицательное", -1, solRules, RoundFloor, "-1.000", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quantity := NewQuantity(tt.value, tt.rules, tt.mode)
+			if got := quantity.String(); got != tt.want {
+				t.Errorf("NewQuantity(%v).String() = %s, ожидалось %s", tt.value, got, tt.want)
+			}
+			if err := quantity.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("NewQuantity(%v).Validate() = %v, ожидалась ошибка: %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestPrice проверяет округление цены до шага цены и запись для биржи
+func TestPrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		rules   InstrumentRules
+		mode    RoundingMode
+		want    string
+		wantErr bool
+	}{
+		{"BTC вверх", 65432.109, btcRules, RoundCeil, "65432.11", false},
+		{"BTC вниз", 65432.109, btcRules, RoundFloor, "65432.10", false},
+		{"BTC на шаге", 65432.1, btcRules, RoundCeil, "65432.10", false},
+		{"SOL шум float64 вверх", 0.1 + 0.2, solRules, RoundCeil, "0.30", false},
+		{"PEPE вниз", 0.0000123456789, pepeRules, RoundFloor, "0.00001234", false},
+		{"PEPE к четному", 0.000012345, pepeRules, RoundHalfEven, "0.00001234", false},
+		{"шаг 0.5 до ближайшего", 100.3, halfRules, RoundHalfUp, "100.5", false},
+		{"шаг неизвестен", 1.50, InstrumentRules{}, RoundCeil, "1.5", false},
+		{"округлено до нуля", 0.004, solRules, RoundFloor, "0.00", true},
+		{"ноль", 0, solRules, RoundCeil, "0.00", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price := NewPrice(tt.value, tt.rules, tt.mode)
+			if got := price.String(); got != tt.want {
+				t.Errorf("NewPrice(%v).String() = %s, ожидалось %s", tt.value, got, tt.want)
+			}
+			if err := price.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("NewPrice(%v).Validate() = %v, ожидалась ошибка: %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestQuantityNotional проверяет сумму ордера: точное произведение количества и цены, кратных шагам
+func TestQuantityNotional(t *testing.T) {
+	quantity := NewQuantity(1.5, solRules, RoundFloor)
+	price := NewPrice(150.25, solRules, RoundCeil)
+	if got := quantity.Notional(price); got != 225.375 {
+		t.Errorf("Notional = %v, ожидалось 225.375", got)
+	}
+}
+
+// TestValidateAmount проверяет минимальную и максимальную сумму ордера в котируемой валюте
+func TestValidateAmount(t *testing.T) {
+	tests := []struct {
+		rules   InstrumentRules
+		amount  float64
+		wantErr bool
+	}{
+		{btcRules, 0.5, true},
+		{btcRules, 1, false},
+		{btcRules, 5, false},
+		{btcRules, 3000000, true},
+		{pepeRules, 200000, false},
+		{pepeRules, 200000.01, true},
+		{InstrumentRules{}, 0.01, false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.rules.ValidateAmount(tt.amount); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateAmount(%v) при лимитах %v-%v = %v, ожидалась ошибка: %v",
+				tt.amount, tt.rules.MinAmount, tt.rules.MaxAmount, err, tt.wantErr)
+		}
+	}
+}
@@ -1,6 +1,30 @@
 package valueobjects
 
-import "strings"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Биржи, для которых поддерживается формат символа
+const (
+	ExchangeBybit   = "bybit"
+	ExchangeBinance = "binance"
+	ExchangeKraken  = "kraken"
+)
+
+// pairPattern формат пары Freqtrade: BASE/QUOTE с необязательной расчетной валютой фьючерсов (BTC/USDT:USDT)
+var pairPattern = regexp.MustCompile(`^[A-Z0-9]+/[A-Z0-9]+(:[A-Z0-9]+)?$`)
+
+// knownQuoteCurrencies котируемые валюты для разбора символа биржи без разделителя (SOLUSDT → SOL/USDT).
+// Более длинные валюты идут первыми, чтобы FDUSD не разбиралась как USD
+var knownQuoteCurrencies = []string{"FDUSD", "USDT", "USDC", "USDE", "TUSD", "BUSD", "EUR", "USD", "GBP", "DAI", "BTC", "ETH", "BNB"}
+
+// krakenAssetCodes коды активов Kraken, отличающиеся от общепринятых
+var krakenAssetCodes = map[string]string{
+	"BTC":  "XBT",
+	"DOGE": "XDG",
+}
 
 // TradingPair представляет торговую пару
 type TradingPair struct {
@@ -12,6 +36,53 @@ func NewTradingPair(pair string) *TradingPair {
 	return &TradingPair{value: pair}
 }
 
+// ParseTradingPair создает торговую пару с проверкой формата BASE/QUOTE
+func ParseTradingPair(pair string) (*TradingPair, error) {
+	tp := NewTradingPair(strings.TrimSpace(pair))
+	if err := tp.Validate(); err != nil {
+		return nil, err
+	}
+	return tp, nil
+}
+
+// FromExchangeSymbol создает торговую пару из символа биржи (SOLUSDT, XBTUSDT, XBT/USD)
+func FromExchangeSymbol(exchange, symbol string) (*TradingPair, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+
+	var base, quote string
+	if parts := strings.SplitN(symbol, "/", 2); len(parts) == 2 {
+		base, quote = parts[0], parts[1]
+	} else {
+		for _, candidate := range knownQuoteCurrencies {
+			if strings.HasSuffix(symbol, candidate) && len(symbol) > len(candidate) {
+				base, quote = strings.TrimSuffix(symbol, candidate), candidate
+				break
+			}
+		}
+		if quote == "" {
+			return nil, fmt.Errorf("не удалось определить котируемую валюту символа %s (%s)", symbol, exchange)
+		}
+	}
+
+	switch exchange {
+	case ExchangeBybit, ExchangeBinance:
+	case ExchangeKraken:
+		base, quote = fromKrakenAsset(base), fromKrakenAsset(quote)
+	default:
+		return nil, fmt.Errorf("неподдерживаемая биржа: %s", exchange)
+	}
+
+	return ParseTradingPair(base + "/" + quote)
+}
+
+// Validate проверяет формат пары: BASE/QUOTE в верхнем регистре (например, SOL/USDT или BTC/USDT:USDT)
+func (tp *TradingPair) Validate() error {
+	if !pairPattern.MatchString(tp.value) {
+		return fmt.Errorf("некорректная торговая пара %q: ожидается формат BASE/QUOTE (например, SOL/USDT)", tp.value)
+	}
+	return nil
+}
+
 // String возвращает строковое представление пары
 func (tp *TradingPair) String() string {
 	return tp.value
@@ -22,6 +93,30 @@ func (tp *TradingPair) ToBybitFormat() string {
 	return strings.ReplaceAll(tp.value, "/", "")
 }
 
+// ToBinanceFormat конвертирует пару в формат Binance (SOLUSDT)
+func (tp *TradingPair) ToBinanceFormat() string {
+	return tp.BaseCurrency() + tp.QuoteCurrency()
+}
+
+// ToKrakenFormat конвертирует пару в формат Kraken с его кодами активов (BTC/USDT → XBTUSDT)
+func (tp *TradingPair) ToKrakenFormat() string {
+	return toKrakenAsset(tp.BaseCurrency()) + toKrakenAsset(tp.QuoteCurrency())
+}
+
+// ToExchangeFormat конвертирует пару в формат символа указанной биржи
+func (tp *TradingPair) ToExchangeFormat(exchange string) (string, error) {
+	switch exchange {
+	case ExchangeBybit:
+		return tp.ToBybitFormat(), nil
+	case ExchangeBinance:
+		return tp.ToBinanceFormat(), nil
+	case ExchangeKraken:
+		return tp.ToKrakenFormat(), nil
+	default:
+		return "", fmt.Errorf("неподдерживаемая биржа: %s", exchange)
+	}
+}
+
 // BaseCurrency возвращает базовую валюту торговой пары (например, XRP для XRP/USDT)
 func (tp *TradingPair) BaseCurrency() string {
 	parts := strings.Split(tp.value, "/")
@@ -30,3 +125,31 @@ func (tp *TradingPair) BaseCurrency() string {
 	}
 	return tp.value
 }
+
+// QuoteCurrency возвращает котируемую валюту торговой пары (например, USDT для XRP/USDT).
+// Суффикс расчетной валюты фьючерсов (BTC/USDT:USDT) отбрасывается
+func (tp *TradingPair) QuoteCurrency() string {
+	parts := strings.SplitN(tp.value, "/", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.SplitN(parts[1], ":", 2)[0]
+}
+
+// toKrakenAsset возвращает код актива в Kraken
+func toKrakenAsset(asset string) string {
+	if code, ok := krakenAssetCodes[asset]; ok {
+		return code
+	}
+	return asset
+}
+
+// fromKrakenAsset возвращает общепринятый код актива по коду Kraken
+func fromKrakenAsset(code string) string {
+	for asset, krakenCode := range krakenAssetCodes {
+		if code == krakenCode {
+			return asset
+		}
+	}
+	return code
+}
@@ -0,0 +1,37 @@
+package valueobjects
+
+import "fmt"
+
+// Price цена ордера, кратная шагу цены инструмента
+type Price struct {
+	value Decimal
+	rules InstrumentRules
+}
+
+// NewPrice создает цену, округленную до шага инструмента режимом mode
+func NewPrice(value float64, rules InstrumentRules, mode RoundingMode) Price {
+	return Price{value: roundToStep(value, rules.TickSize, mode), rules: rules}
+}
+
+// Decimal возвращает цену как десятичное число
+func (p Price) Decimal() Decimal {
+	return p.value
+}
+
+// Float64 возвращает цену как float64
+func (p Price) Float64() float64 {
+	return p.value.Float64()
+}
+
+// String возвращает цену с точностью шага инструмента (для запроса к бирже)
+func (p Price) String() string {
+	return formatStep(p.value, p.rules.TickSize)
+}
+
+// Validate проверяет, что цена положительна
+func (p Price) Validate() error {
+	if p.value.Sign() <= 0 {
+		return fmt.Errorf("цена должна быть больше 0: %s", p.value)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package valueobjects
+
+import "fmt"
+
+// Quantity количество базовой валюты в ордере, кратное шагу количества инструмента
+type Quantity struct {
+	value Decimal
+	rules InstrumentRules
+}
+
+// NewQuantity создает количество, округленное до шага инструмента режимом mode
+func NewQuantity(value float64, rules InstrumentRules, mode RoundingMode) Quantity {
+	return Quantity{value: roundToStep(value, rules.StepSize, mode), rules: rules}
+}
+
+// Decimal возвращает количество как десятичное число
+func (q Quantity) Decimal() Decimal {
+	return q.value
+}
+
+// Float64 возвращает количество как float64
+func (q Quantity) Float64() float64 {
+	return q.value.Float64()
+}
+
+// String возвращает количество с точностью шага инструмента (для запроса к бирже)
+func (q Quantity) String() string {
+	return formatStep(q.value, q.rules.StepSize)
+}
+
+// Notional возвращает сумму ордера в котируемой валюте по цене price
+func (q Quantity) Notional(price Price) float64 {
+	return q.value.Mul(price.value).Float64()
+}
+
+// Validate проверяет количество на минимальный и максимальный лимиты инструмента
+func (q Quantity) Validate() error {
+	value := q.Float64()
+	if q.value.Sign() <= 0 {
+		return fmt.Errorf("количество должно быть больше 0: %s", q.value)
+	}
+	if q.rules.MinQty > 0 && value < q.rules.MinQty {
+		return fmt.Errorf("количество %s меньше минимального %.8g", q.value, q.rules.MinQty)
+	}
+	if q.rules.MaxQty > 0 && value > q.rules.MaxQty {
+		return fmt.Errorf("количество %s больше максимального %.8g", q.value, q.rules.MaxQty)
+	}
+	return nil
+}
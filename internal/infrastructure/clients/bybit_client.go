@@ -1,20 +1,18 @@
 package clients
 
 import (
-	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 	"trade-hedge/internal/domain/entities"
 	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
 	"trade-hedge/internal/infrastructure/config"
 )
 
@@ -74,6 +72,7 @@ type BybitOrderStatusResponse struct {
 			Price       string `json:"price"`
 			Qty         string `json:"qty"`
 			CumExecQty  string `json:"cumExecQty"`
+			CumExecFee  string `json:"cumExecFee"`
 			LeavesQty   string `json:"leavesQty"`
 			AvgPrice    string `json:"avgPrice"`
 			CreatedTime string `json:"createdTime"`
@@ -107,67 +106,137 @@ type BybitInstrumentInfoResponse struct {
 	} `json:"result"`
 }
 
-// NewBybitClient создает новый клиент Bybit
-func NewBybitClient(config *config.BybitConfig) *BybitClient {
+// BybitTickerResponse ответ от Bybit API с текущими ценами инструмента
+type BybitTickerResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		List []struct {
+			Symbol    string `json:"symbol"`
+			LastPrice string `json:"lastPrice"`
+			Bid1Price string `json:"bid1Price"`
+			Ask1Price string `json:"ask1Price"`
+		} `json:"list"`
+	} `json:"result"`
+}
+
+// BybitKlineResponse ответ от Bybit API со свечами: [startTime, open, high, low, close, volume, turnover]
+type BybitKlineResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		Symbol string     `json:"symbol"`
+		List   [][]string `json:"list"`
+	} `json:"result"`
+}
+
+// BybitOrderBookResponse ответ от Bybit API со стаканом: заявки [цена, количество], лучшие первыми
+type BybitOrderBookResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		Symbol string     `json:"s"`
+		Asks   [][]string `json:"a"`
+		Bids   [][]string `json:"b"`
+	} `json:"result"`
+}
+
+// bybitOrderBookLimit максимальная глубина стакана спота в одном ответе Bybit
+const bybitOrderBookLimit = 200
+
+// bybitKlineLimit максимальное количество свечей в одном ответе Bybit
+const bybitKlineLimit = 1000
+
+// BybitOrderHistoryResponse ответ от Bybit API с историей ордеров
+type BybitOrderHistoryResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		List []struct {
+			OrderID     string `json:"orderId"`
+			OrderLinkID string `json:"orderLinkId"`
+			Symbol      string `json:"symbol"`
+			OrderStatus string `json:"orderStatus"`
+			Side        string `json:"side"`
+			OrderType   string `json:"orderType"`
+			Price       string `json:"price"`
+			Qty         string `json:"qty"`
+			CumExecQty  string `json:"cumExecQty"`
+			AvgPrice    string `json:"avgPrice"`
+			CreatedTime string `json:"createdTime"`
+			UpdatedTime string `json:"updatedTime"`
+		} `json:"list"`
+		NextPageCursor string `json:"nextPageCursor"`
+	} `json:"result"`
+}
+
+// Ограничения истории ордеров Bybit: не более 50 ордеров в ответе и не более 7 дней в одном запросе
+const (
+	bybitOrderHistoryLimit  = 50
+	bybitOrderHistoryWindow = 7 * 24 * time.Hour
+)
+
+// NewBybitClient создает новый клиент Bybit.
+// httpClient - общий клиент с настроенным транспортом (см. NewHTTPClient); nil - клиент по умолчанию
+func NewBybitClient(config *config.BybitConfig, httpClient *http.Client) *BybitClient {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
 	return &BybitClient{
 		config: config,
-		client: &http.Client{},
+		client: httpClient,
 	}
 }
 
 // PlaceOrder размещает ордер на Bybit
 func (b *BybitClient) PlaceOrder(ctx context.Context, order *entities.Order) (*entities.OrderResult, error) {
-	timestamp := time.Now().UnixMilli()
-	recvWindow := "5000"
+	// Рынок и время действия задает use case; пустые значения - спот и GTC
+	category := order.Category
+	if category == "" {
+		category = entities.OrderCategorySpot
+	}
+	timeInForce := order.TimeInForce
+	if timeInForce == "" {
+		timeInForce = entities.TimeInForceGTC
+	}
 
 	params := map[string]interface{}{
-		"category":    "spot", // Обязательно для V5 API
+		"category":    string(category), // Обязательно для V5 API
 		"symbol":      order.Symbol,
 		"side":        string(order.Side),
 		"orderType":   string(order.Type), // В V5 API это orderType, не type
-		"qty":         strconv.FormatFloat(order.Quantity, 'f', 6, 64),
-		"timeInForce": "GTC",
+		"qty":         order.FormatQuantity(),
+		"timeInForce": string(timeInForce),
+	}
+
+	// Единица количества рыночного спотового ордера передается явно (по умолчанию Bybit трактует
+	// количество рыночной покупки как сумму в котируемой валюте)
+	if order.Type == entities.OrderTypeMarket && category == entities.OrderCategorySpot {
+		params["marketUnit"] = "baseCoin"
+		if order.QuoteQuantity {
+			params["marketUnit"] = "quoteCoin"
+		}
 	}
 
 	// Для лимитных ордеров добавляем цену
 	if order.Type == entities.OrderTypeLimit {
-		// Используем 8 знаков после запятой для очень маленьких цен
-		params["price"] = strconv.FormatFloat(order.Price, 'f', 8, 64)
+		// Точная десятичная запись с точностью шага цены инструмента (8 знаков, если шаг неизвестен)
+		params["price"] = order.FormatPrice()
 	}
 
-	paramStr, err := json.Marshal(params)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка сериализации параметров: %w", err)
+	// Клиентский ID делает повторное размещение того же ордера идемпотентным
+	if order.ClientOrderID != "" {
+		params["orderLinkId"] = order.ClientOrderID
 	}
 
-	// Генерация подписи
-	signature := hmac.New(sha256.New, []byte(b.config.APISecret))
-	signature.Write([]byte(fmt.Sprintf("%d%s%s%s", timestamp, b.config.APIKey, recvWindow, paramStr)))
-	sign := hex.EncodeToString(signature.Sum(nil))
-
-	// Создание запроса (без category в URL для V5 API)
-	reqBody, _ := json.Marshal(params)
-	req, err := http.NewRequestWithContext(ctx, "POST", b.config.SpotURL, bytes.NewBuffer(reqBody))
+	reqBody, err := json.Marshal(params)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
-	}
-
-	req.Header.Add("X-BAPI-API-KEY", b.config.APIKey)
-	req.Header.Add("X-BAPI-SIGN", sign)
-	req.Header.Add("X-BAPI-SIGN-TYPE", "2")
-	req.Header.Add("X-BAPI-TIMESTAMP", fmt.Sprintf("%d", timestamp))
-	req.Header.Add("X-BAPI-RECV-WINDOW", recvWindow)
-	req.Header.Add("Content-Type", "application/json")
-
-	resp, err := b.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка отправки запроса: %w", err)
+		return nil, fmt.Errorf("ошибка сериализации параметров: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := b.doSignedRequest(ctx, http.MethodPost, b.config.SpotURL, "", reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+		return nil, err
 	}
 
 	// Проверка на ошибку
@@ -176,14 +245,16 @@ func (b *BybitClient) PlaceOrder(ctx context.Context, order *entities.Order) (*e
 		// Специальная обработка для ошибки минимального лимита ордера
 		if errResp.RetCode == 170140 {
 			return &entities.OrderResult{
-				Success: false,
-				Error:   fmt.Sprintf("ошибка Bybit: %s (код: %d) - Стоимость ордера меньше минимального лимита. Увеличьте размер позиции в конфигурации.", errResp.RetMsg, errResp.RetCode),
+				Success:     false,
+				Error:       fmt.Sprintf("ошибка Bybit: %s (код: %d) - Стоимость ордера меньше минимального лимита. Увеличьте размер позиции в конфигурации.", errResp.RetMsg, errResp.RetCode),
+				RawResponse: string(body),
 			}, nil
 		}
 
 		return &entities.OrderResult{
-			Success: false,
-			Error:   fmt.Sprintf("ошибка Bybit: %s (код: %d)", errResp.RetMsg, errResp.RetCode),
+			Success:     false,
+			Error:       fmt.Sprintf("ошибка Bybit: %s (код: %d)", errResp.RetMsg, errResp.RetCode),
+			RawResponse: string(body),
 		}, nil
 	}
 
@@ -194,46 +265,78 @@ func (b *BybitClient) PlaceOrder(ctx context.Context, order *entities.Order) (*e
 	}
 
 	return &entities.OrderResult{
-		OrderID: result.Result.OrderID,
-		Success: true,
-		Error:   "",
+		OrderID:     result.Result.OrderID,
+		Success:     true,
+		Error:       "",
+		RawResponse: string(body),
 	}, nil
 }
 
-// GetBalance получает баланс по указанной валюте
-func (b *BybitClient) GetBalance(ctx context.Context, asset string) (*entities.Balance, error) {
-	timestamp := time.Now().UnixMilli()
-	recvWindow := "5000"
-
-	// Создаем параметры запроса (используем UNIFIED аккаунт)
-	params := fmt.Sprintf("accountType=UNIFIED&coin=%s", asset)
+// CancelOrder отменяет ордер на Bybit
+func (b *BybitClient) CancelOrder(ctx context.Context, orderID, symbol string) (*entities.OrderResult, error) {
+	params := map[string]interface{}{
+		"category": "spot",
+		"symbol":   symbol,
+		"orderId":  orderID,
+	}
 
-	// Генерация подписи для GET запроса
-	signature := hmac.New(sha256.New, []byte(b.config.APISecret))
-	signature.Write([]byte(fmt.Sprintf("%d%s%s%s", timestamp, b.config.APIKey, recvWindow, params)))
-	sign := hex.EncodeToString(signature.Sum(nil))
+	reqBody, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации параметров: %w", err)
+	}
 
-	// Создание запроса
-	url := fmt.Sprintf("%s?%s", b.config.BalanceURL, params)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	body, err := b.doSignedRequest(ctx, http.MethodPost, b.config.CancelURL, "", reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+		return nil, err
+	}
+
+	// Проверка на ошибку
+	var errResp BybitErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.RetCode != 0 {
+		return &entities.OrderResult{
+			OrderID:     orderID,
+			Success:     false,
+			Error:       fmt.Sprintf("ошибка Bybit: %s (код: %d)", errResp.RetMsg, errResp.RetCode),
+			RawResponse: string(body),
+		}, nil
 	}
 
-	req.Header.Add("X-BAPI-API-KEY", b.config.APIKey)
-	req.Header.Add("X-BAPI-SIGN", sign)
-	req.Header.Add("X-BAPI-TIMESTAMP", fmt.Sprintf("%d", timestamp))
-	req.Header.Add("X-BAPI-RECV-WINDOW", recvWindow)
+	return &entities.OrderResult{
+		OrderID:     orderID,
+		Success:     true,
+		RawResponse: string(body),
+	}, nil
+}
 
-	resp, err := b.client.Do(req)
+// GetBalance получает баланс по указанной валюте
+func (b *BybitClient) GetBalance(ctx context.Context, asset string) (*entities.Balance, error) {
+	// Создаем параметры запроса (используем UNIFIED аккаунт)
+	balances, err := b.getWalletBalances(ctx, fmt.Sprintf("accountType=UNIFIED&coin=%s", asset))
 	if err != nil {
-		return nil, fmt.Errorf("ошибка отправки запроса: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	// Поиск баланса нужной валюты в UNIFIED account
+	for _, balance := range balances {
+		if strings.EqualFold(balance.Asset, asset) {
+			balance.Asset = asset
+			return balance, nil
+		}
+	}
+
+	return nil, fmt.Errorf("валюта %s не найдена в балансе UNIFIED аккаунта", asset)
+}
+
+// GetAllBalances получает балансы всех валют UNIFIED аккаунта (без параметра coin Bybit отдает валюты с ненулевым балансом)
+func (b *BybitClient) GetAllBalances(ctx context.Context) ([]*entities.Balance, error) {
+	return b.getWalletBalances(ctx, "accountType=UNIFIED")
+}
+
+// getWalletBalances запрашивает балансы кошелька с параметрами params
+func (b *BybitClient) getWalletBalances(ctx context.Context, params string) ([]*entities.Balance, error) {
+	body, err := b.doSignedRequest(ctx, http.MethodGet, b.config.BalanceURL, params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+		return nil, err
 	}
 
 	// Проверка на ошибку
@@ -248,35 +351,50 @@ func (b *BybitClient) GetBalance(ctx context.Context, asset string) (*entities.B
 		return nil, fmt.Errorf("ошибка парсинга ответа: %w", err)
 	}
 
-	// Поиск баланса нужной валюты в UNIFIED account
+	var balances []*entities.Balance
 	for _, account := range result.Result.List {
 		for _, coinBalance := range account.Coin {
-			if strings.EqualFold(coinBalance.Coin, asset) {
-				walletBalance, _ := strconv.ParseFloat(coinBalance.WalletBalance, 64)
-				availableBalance, _ := strconv.ParseFloat(coinBalance.AvailableToWithdraw, 64)
-
-				// Если AvailableToWithdraw пустой, используем WalletBalance
-				if coinBalance.AvailableToWithdraw == "" {
-					availableBalance = walletBalance
-				}
-
-				return &entities.Balance{
-					Asset:     asset,
-					Available: availableBalance, // Доступный для вывода/торговли
-					Total:     walletBalance,    // Общий баланс кошелька
-				}, nil
+			walletBalance, _ := strconv.ParseFloat(coinBalance.WalletBalance, 64)
+			availableBalance, _ := strconv.ParseFloat(coinBalance.AvailableToWithdraw, 64)
+
+			// Если AvailableToWithdraw пустой, используем WalletBalance
+			if coinBalance.AvailableToWithdraw == "" {
+				availableBalance = walletBalance
+			}
+
+			balance := &entities.Balance{
+				Asset:     coinBalance.Coin,
+				Available: availableBalance, // Доступный для вывода/торговли
+				Total:     walletBalance,    // Общий баланс кошелька
+			}
+			if usdValue, err := strconv.ParseFloat(coinBalance.UsdValue, 64); err == nil {
+				balance.USDValue = &usdValue
 			}
+			balances = append(balances, balance)
 		}
 	}
-
-	return nil, fmt.Errorf("валюта %s не найдена в балансе UNIFIED аккаунта", asset)
+	return balances, nil
 }
 
 // GetInstrumentInfo получает информацию об инструменте (минимальные лимиты, размеры шагов и т.д.)
 func (b *BybitClient) GetInstrumentInfo(ctx context.Context, symbol string) (*services.InstrumentInfo, error) {
-	// Создаем параметры запроса
-	params := fmt.Sprintf("category=spot&symbol=%s", symbol)
+	instruments, err := b.getInstruments(ctx, fmt.Sprintf("category=spot&symbol=%s", symbol))
+	if err != nil {
+		return nil, err
+	}
+	if len(instruments) == 0 {
+		return nil, fmt.Errorf("инструмент %s не найден", symbol)
+	}
+	return instruments[0], nil
+}
+
+// GetInstruments получает все спотовые инструменты биржи (спот Bybit отдает список одной страницей)
+func (b *BybitClient) GetInstruments(ctx context.Context) ([]*services.InstrumentInfo, error) {
+	return b.getInstruments(ctx, "category=spot")
+}
 
+// getInstruments запрашивает инструменты с параметрами params
+func (b *BybitClient) getInstruments(ctx context.Context, params string) ([]*services.InstrumentInfo, error) {
 	// Создание запроса (публичный API, не требует подписи)
 	url := fmt.Sprintf("https://api.bybit.com/v5/market/instruments-info?%s", params)
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -284,18 +402,10 @@ func (b *BybitClient) GetInstrumentInfo(ctx context.Context, symbol string) (*se
 		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
 	}
 
-	resp, err := b.client.Do(req)
+	body, err := b.send(req)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка отправки запроса: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
-	}
-
-	// Ответ получен успешно
 
 	// Проверка на ошибку
 	var errResp BybitErrorResponse
@@ -309,68 +419,210 @@ func (b *BybitClient) GetInstrumentInfo(ctx context.Context, symbol string) (*se
 		return nil, fmt.Errorf("ошибка парсинга ответа: %w", err)
 	}
 
+	instruments := make([]*services.InstrumentInfo, 0, len(result.Result.List))
+	for _, instrument := range result.Result.List {
+		// Парсим численные значения
+		minOrderQty, _ := strconv.ParseFloat(instrument.LotSizeFilter.MinOrderQty, 64)
+		minOrderAmt, _ := strconv.ParseFloat(instrument.LotSizeFilter.MinOrderAmt, 64)
+		maxOrderQty, _ := strconv.ParseFloat(instrument.LotSizeFilter.MaxOrderQty, 64)
+		maxOrderAmt, _ := strconv.ParseFloat(instrument.LotSizeFilter.MaxOrderAmt, 64)
+		tickSize, _ := strconv.ParseFloat(instrument.PriceFilter.TickSize, 64)
+		stepSize, _ := strconv.ParseFloat(instrument.LotSizeFilter.BasePrecision, 64) // Step size is base precision
+
+		instruments = append(instruments, &services.InstrumentInfo{
+			Symbol:      instrument.Symbol,
+			BaseCoin:    instrument.BaseCoin,
+			QuoteCoin:   instrument.QuoteCoin,
+			MinOrderQty: minOrderQty,
+			MinOrderAmt: minOrderAmt,
+			MaxOrderQty: maxOrderQty,
+			MaxOrderAmt: maxOrderAmt,
+			TickSize:    tickSize,
+			StepSize:    stepSize,
+			Status:      instrument.Status,
+		})
+	}
+	return instruments, nil
+}
+
+// GetTickerPrice получает последнюю цену инструмента
+func (b *BybitClient) GetTickerPrice(ctx context.Context, symbol string) (float64, error) {
+	// Публичный API, не требует подписи
+	url := fmt.Sprintf("https://api.bybit.com/v5/market/tickers?category=spot&symbol=%s", symbol)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+
+	body, err := b.send(req)
+	if err != nil {
+		return 0, err
+	}
+
+	var result BybitTickerResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("ошибка парсинга ответа: %w", err)
+	}
+	if result.RetCode != 0 {
+		return 0, fmt.Errorf("ошибка Bybit: %s (код: %d)", result.RetMsg, result.RetCode)
+	}
 	if len(result.Result.List) == 0 {
-		return nil, fmt.Errorf("инструмент %s не найден", symbol)
+		return 0, fmt.Errorf("тикер %s не найден", symbol)
 	}
 
-	instrument := result.Result.List[0]
+	price, err := strconv.ParseFloat(result.Result.List[0].LastPrice, 64)
+	if err != nil || price <= 0 {
+		return 0, fmt.Errorf("некорректная цена тикера %s: %q", symbol, result.Result.List[0].LastPrice)
+	}
 
-	// Парсим численные значения
-	minOrderQty, _ := strconv.ParseFloat(instrument.LotSizeFilter.MinOrderQty, 64)
-	minOrderAmt, _ := strconv.ParseFloat(instrument.LotSizeFilter.MinOrderAmt, 64)
-	maxOrderQty, _ := strconv.ParseFloat(instrument.LotSizeFilter.MaxOrderQty, 64)
-	maxOrderAmt, _ := strconv.ParseFloat(instrument.LotSizeFilter.MaxOrderAmt, 64)
-	tickSize, _ := strconv.ParseFloat(instrument.PriceFilter.TickSize, 64)
-	stepSize, _ := strconv.ParseFloat(instrument.LotSizeFilter.BasePrecision, 64) // Step size is base precision
-
-	return &services.InstrumentInfo{
-		Symbol:      instrument.Symbol,
-		BaseCoin:    instrument.BaseCoin,
-		QuoteCoin:   instrument.QuoteCoin,
-		MinOrderQty: minOrderQty,
-		MinOrderAmt: minOrderAmt,
-		MaxOrderQty: maxOrderQty,
-		MaxOrderAmt: maxOrderAmt,
-		TickSize:    tickSize,
-		StepSize:    stepSize,
-		Status:      instrument.Status,
-	}, nil
+	return price, nil
 }
 
-// GetOrderStatus получает статус ордера по ID
-func (b *BybitClient) GetOrderStatus(ctx context.Context, orderID, symbol string) (*services.OrderStatusInfo, error) {
-	timestamp := time.Now().UnixMilli()
-	recvWindow := "5000"
+// GetBookTicker получает лучшие цены покупки и продажи инструмента
+func (b *BybitClient) GetBookTicker(ctx context.Context, symbol string) (*services.BookTicker, error) {
+	// Публичный API, не требует подписи
+	url := fmt.Sprintf("https://api.bybit.com/v5/market/tickers?category=spot&symbol=%s", symbol)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+
+	body, err := b.send(req)
+	if err != nil {
+		return nil, err
+	}
 
-	// Создаем параметры запроса
-	params := fmt.Sprintf("category=spot&orderId=%s", orderID)
+	var result BybitTickerResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга ответа: %w", err)
+	}
+	if result.RetCode != 0 {
+		return nil, fmt.Errorf("ошибка Bybit: %s (код: %d)", result.RetMsg, result.RetCode)
+	}
+	if len(result.Result.List) == 0 {
+		return nil, fmt.Errorf("тикер %s не найден", symbol)
+	}
 
-	// Генерация подписи для GET запроса
-	signature := hmac.New(sha256.New, []byte(b.config.APISecret))
-	signature.Write([]byte(fmt.Sprintf("%d%s%s%s", timestamp, b.config.APIKey, recvWindow, params)))
-	sign := hex.EncodeToString(signature.Sum(nil))
+	ticker := result.Result.List[0]
+	bid, bidErr := strconv.ParseFloat(ticker.Bid1Price, 64)
+	ask, askErr := strconv.ParseFloat(ticker.Ask1Price, 64)
+	if bidErr != nil || askErr != nil || bid <= 0 || ask <= 0 {
+		return nil, fmt.Errorf("некорректные цены стакана %s: bid %q, ask %q", symbol, ticker.Bid1Price, ticker.Ask1Price)
+	}
 
-	// Создание запроса
-	url := fmt.Sprintf("%s?%s", b.config.OrderStatusURL, params)
+	return &services.BookTicker{Bid: bid, Ask: ask}, nil
+}
+
+// GetAskDepth получает стакан и суммирует заявки на продажу (в котируемой валюте) по ценам
+// не выше лучшей цены продажи плюс withinPercent процентов
+func (b *BybitClient) GetAskDepth(ctx context.Context, symbol string, withinPercent float64) (float64, error) {
+	// Публичный API, не требует подписи
+	url := fmt.Sprintf("https://api.bybit.com/v5/market/orderbook?category=spot&symbol=%s&limit=%d", symbol, bybitOrderBookLimit)
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+
+	body, err := b.send(req)
+	if err != nil {
+		return 0, err
+	}
+
+	var result BybitOrderBookResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("ошибка парсинга ответа: %w", err)
+	}
+	if result.RetCode != 0 {
+		return 0, fmt.Errorf("ошибка Bybit: %s (код: %d)", result.RetMsg, result.RetCode)
+	}
+	if len(result.Result.Asks) == 0 {
+		return 0, fmt.Errorf("стакан %s пуст", symbol)
+	}
+
+	var depth, maxPrice float64
+	for _, level := range result.Result.Asks {
+		if len(level) < 2 {
+			continue
+		}
+		price, priceErr := strconv.ParseFloat(level[0], 64)
+		size, sizeErr := strconv.ParseFloat(level[1], 64)
+		if priceErr != nil || sizeErr != nil || price <= 0 {
+			return 0, fmt.Errorf("некорректная заявка стакана %s: %v", symbol, level)
+		}
+		if maxPrice == 0 {
+			maxPrice = price * (1 + withinPercent/100)
+		}
+		if price > maxPrice {
+			break
+		}
+		depth += price * size
+	}
+
+	return depth, nil
+}
+
+// GetTickerPrices получает последние цены нескольких символов одним запросом тикеров всего спота
+func (b *BybitClient) GetTickerPrices(ctx context.Context, symbols []string) (map[string]float64, error) {
+	// Публичный API, не требует подписи
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.bybit.com/v5/market/tickers?category=spot", nil)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
 	}
 
-	req.Header.Add("X-BAPI-API-KEY", b.config.APIKey)
-	req.Header.Add("X-BAPI-SIGN", sign)
-	req.Header.Add("X-BAPI-TIMESTAMP", fmt.Sprintf("%d", timestamp))
-	req.Header.Add("X-BAPI-RECV-WINDOW", recvWindow)
+	body, err := b.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BybitTickerResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга ответа: %w", err)
+	}
+	if result.RetCode != 0 {
+		return nil, fmt.Errorf("ошибка Bybit: %s (код: %d)", result.RetMsg, result.RetCode)
+	}
+
+	requested := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		requested[symbol] = true
+	}
 
-	resp, err := b.client.Do(req)
+	prices := make(map[string]float64, len(symbols))
+	for _, ticker := range result.Result.List {
+		if !requested[ticker.Symbol] {
+			continue
+		}
+		if price, err := strconv.ParseFloat(ticker.LastPrice, 64); err == nil && price > 0 {
+			prices[ticker.Symbol] = price
+		}
+	}
+
+	return prices, nil
+}
+
+// GetOrderStatus получает статус ордера по ID
+func (b *BybitClient) GetOrderStatus(ctx context.Context, orderID, symbol string) (*services.OrderStatusInfo, error) {
+	statusInfo, err := b.queryOrder(ctx, fmt.Sprintf("category=spot&orderId=%s", orderID))
 	if err != nil {
-		return nil, fmt.Errorf("ошибка отправки запроса: %w", err)
+		return nil, err
+	}
+	if statusInfo == nil {
+		return nil, fmt.Errorf("ордер %s не найден", orderID)
 	}
-	defer resp.Body.Close()
+	return statusInfo, nil
+}
+
+// GetOrderStatusByClientID получает статус ордера по клиентскому ID (orderLinkId).
+// Возвращает nil без ошибки, если ордер с таким ID на бирже не найден
+func (b *BybitClient) GetOrderStatusByClientID(ctx context.Context, clientOrderID, symbol string) (*services.OrderStatusInfo, error) {
+	return b.queryOrder(ctx, fmt.Sprintf("category=spot&symbol=%s&orderLinkId=%s", symbol, clientOrderID))
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
+// queryOrder запрашивает ордер по параметрам запроса; возвращает nil, если ордер не найден
+func (b *BybitClient) queryOrder(ctx context.Context, params string) (*services.OrderStatusInfo, error) {
+	body, err := b.doSignedRequest(ctx, http.MethodGet, b.config.OrderStatusURL, params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+		return nil, err
 	}
 
 	// Проверка на ошибку
@@ -386,7 +638,7 @@ func (b *BybitClient) GetOrderStatus(ctx context.Context, orderID, symbol string
 	}
 
 	if len(result.Result.List) == 0 {
-		return nil, fmt.Errorf("ордер %s не найден", orderID)
+		return nil, nil
 	}
 
 	orderData := result.Result.List[0]
@@ -403,14 +655,30 @@ func (b *BybitClient) GetOrderStatus(ctx context.Context, orderID, symbol string
 		Status:       status,
 		FilledQty:    filledQty,
 		RemainingQty: remainingQty,
+		RawResponse:  string(body),
 	}
 
-	// Если ордер исполнен, добавляем информацию о цене и времени
-	if status == entities.OrderStatusFilled && orderData.AvgPrice != "" {
-		avgPrice, err := strconv.ParseFloat(orderData.AvgPrice, 64)
-		if err == nil {
+	// Комиссия спота списывается в получаемой валюте: при покупке - в базовой, при продаже - в котируемой
+	if fee, err := strconv.ParseFloat(orderData.CumExecFee, 64); err == nil && fee > 0 {
+		statusInfo.Fee = fee
+		if pair, err := valueobjects.FromExchangeSymbol(valueobjects.ExchangeBybit, orderData.Symbol); err == nil {
+			if orderData.Side == string(entities.OrderSideBuy) {
+				statusInfo.FeeCurrency = pair.BaseCurrency()
+			} else {
+				statusInfo.FeeCurrency = pair.QuoteCurrency()
+			}
+		}
+	}
+
+	// Средняя цена исполнения нужна и для частично исполненных ордеров (в т.ч. PartiallyFilledCanceled)
+	if filledQty > 0 && orderData.AvgPrice != "" {
+		if avgPrice, err := strconv.ParseFloat(orderData.AvgPrice, 64); err == nil && avgPrice > 0 {
 			statusInfo.FilledPrice = &avgPrice
 		}
+	}
+
+	// Если ордер исполнен, добавляем время исполнения
+	if status == entities.OrderStatusFilled {
 
 		// Парсим время обновления как время исполнения
 		if orderData.UpdatedTime != "" {
@@ -423,3 +691,141 @@ func (b *BybitClient) GetOrderStatus(ctx context.Context, orderID, symbol string
 
 	return statusInfo, nil
 }
+
+// GetKlines получает часовые свечи инструмента за период (публичный API, постранично)
+func (b *BybitClient) GetKlines(ctx context.Context, symbol string, start, end time.Time) ([]*entities.Kline, error) {
+	var klines []*entities.Kline
+
+	for from := start; from.Before(end); {
+		url := fmt.Sprintf("https://api.bybit.com/v5/market/kline?category=spot&symbol=%s&interval=60&start=%d&end=%d&limit=%d",
+			symbol, from.UnixMilli(), end.UnixMilli(), bybitKlineLimit)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+		}
+
+		body, err := b.send(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var result BybitKlineResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("ошибка парсинга ответа: %w", err)
+		}
+		if result.RetCode != 0 {
+			return nil, fmt.Errorf("ошибка Bybit: %s (код: %d)", result.RetMsg, result.RetCode)
+		}
+
+		// Bybit возвращает свечи от новых к старым
+		page := make([]*entities.Kline, 0, len(result.Result.List))
+		for i := len(result.Result.List) - 1; i >= 0; i-- {
+			if kline := parseBybitKline(result.Result.List[i]); kline != nil {
+				page = append(page, kline)
+			}
+		}
+		klines = append(klines, page...)
+
+		if len(result.Result.List) < bybitKlineLimit || len(page) == 0 {
+			break
+		}
+		from = page[len(page)-1].StartTime.Add(time.Hour)
+	}
+
+	return klines, nil
+}
+
+// parseBybitKline разбирает свечу Bybit; nil - некорректная запись
+func parseBybitKline(fields []string) *entities.Kline {
+	if len(fields) < 5 {
+		return nil
+	}
+
+	startMs, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil
+	}
+	values := make([]float64, 4)
+	for i := range values {
+		if values[i], err = strconv.ParseFloat(fields[i+1], 64); err != nil {
+			return nil
+		}
+	}
+
+	return &entities.Kline{
+		StartTime: time.UnixMilli(startMs),
+		Open:      values[0],
+		High:      values[1],
+		Low:       values[2],
+		Close:     values[3],
+	}
+}
+
+// GetOrderHistory получает спотовые ордера инструмента за период: окнами по 7 дней, постранично
+func (b *BybitClient) GetOrderHistory(ctx context.Context, symbol string, start, end time.Time) ([]*entities.ExchangeOrder, error) {
+	var orders []*entities.ExchangeOrder
+
+	for from := start; from.Before(end); from = from.Add(bybitOrderHistoryWindow) {
+		to := from.Add(bybitOrderHistoryWindow)
+		if to.After(end) {
+			to = end
+		}
+
+		cursor := ""
+		for {
+			params := fmt.Sprintf("category=spot&symbol=%s&startTime=%d&endTime=%d&limit=%d",
+				symbol, from.UnixMilli(), to.UnixMilli(), bybitOrderHistoryLimit)
+			if cursor != "" {
+				params += "&cursor=" + url.QueryEscape(cursor)
+			}
+
+			body, err := b.doSignedRequest(ctx, http.MethodGet, b.config.OrderHistoryURL, params, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var result BybitOrderHistoryResponse
+			if err := json.Unmarshal(body, &result); err != nil {
+				return nil, fmt.Errorf("ошибка парсинга ответа: %w", err)
+			}
+			if result.RetCode != 0 {
+				return nil, fmt.Errorf("ошибка Bybit: %s (код: %d)", result.RetMsg, result.RetCode)
+			}
+
+			for _, item := range result.Result.List {
+				price, _ := strconv.ParseFloat(item.Price, 64)
+				qty, _ := strconv.ParseFloat(item.Qty, 64)
+				filledQty, _ := strconv.ParseFloat(item.CumExecQty, 64)
+				avgPrice, _ := strconv.ParseFloat(item.AvgPrice, 64)
+				createdMs, _ := strconv.ParseInt(item.CreatedTime, 10, 64)
+				updatedMs, _ := strconv.ParseInt(item.UpdatedTime, 10, 64)
+
+				orders = append(orders, &entities.ExchangeOrder{
+					OrderID:       item.OrderID,
+					ClientOrderID: item.OrderLinkID,
+					Side:          entities.OrderSide(item.Side),
+					Type:          entities.OrderType(strings.ToUpper(item.OrderType)),
+					Status:        entities.OrderStatusFromString(item.OrderStatus),
+					Price:         price,
+					Quantity:      qty,
+					FilledQty:     filledQty,
+					AvgPrice:      avgPrice,
+					CreatedAt:     time.UnixMilli(createdMs),
+					UpdatedAt:     time.UnixMilli(updatedMs),
+				})
+			}
+
+			cursor = result.Result.NextPageCursor
+			if cursor == "" || len(result.Result.List) < bybitOrderHistoryLimit {
+				break
+			}
+		}
+	}
+
+	// Bybit возвращает ордера от новых к старым
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+
+	return orders, nil
+}
@@ -0,0 +1,173 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"trade-hedge/internal/domain/services"
+)
+
+// bybitConvertAccountType аккаунт конвертации для единого торгового аккаунта (UNIFIED)
+const bybitConvertAccountType = "eb_convert_uta"
+
+// Ожидание завершения конвертации после исполнения котировки
+const (
+	bybitConvertPollAttempts = 10
+	bybitConvertPollInterval = 500 * time.Millisecond
+)
+
+// Статусы конвертации Bybit
+const (
+	bybitConvertStatusSuccess = "success"
+	bybitConvertStatusFailure = "failure"
+)
+
+// BybitConvertQuoteResponse ответ от Bybit API с котировкой конвертации
+type BybitConvertQuoteResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		QuoteTxID   string `json:"quoteTxId"`
+		FromCoin    string `json:"fromCoin"`
+		ToCoin      string `json:"toCoin"`
+		FromAmount  string `json:"fromAmount"`
+		ToAmount    string `json:"toAmount"`
+		ExpiredTime string `json:"expiredTime"`
+	} `json:"result"`
+}
+
+// BybitConvertExecuteResponse ответ от Bybit API на исполнение котировки
+type BybitConvertExecuteResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		QuoteTxID      string `json:"quoteTxId"`
+		ExchangeStatus string `json:"exchangeStatus"`
+	} `json:"result"`
+}
+
+// BybitConvertResultResponse ответ от Bybit API со статусом конвертации
+type BybitConvertResultResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		Result struct {
+			ExchangeTxID   string `json:"exchangeTxId"`
+			FromAmount     string `json:"fromAmount"`
+			ToAmount       string `json:"toAmount"`
+			ExchangeStatus string `json:"exchangeStatus"`
+		} `json:"result"`
+	} `json:"result"`
+}
+
+// RequestConvertQuote запрашивает твердую котировку обмена fromAmount валюты fromCoin на toCoin
+func (b *BybitClient) RequestConvertQuote(ctx context.Context, fromCoin, toCoin string, fromAmount float64) (*services.ConvertQuote, error) {
+	params := map[string]interface{}{
+		"accountType":   bybitConvertAccountType,
+		"fromCoin":      fromCoin,
+		"toCoin":        toCoin,
+		"requestCoin":   fromCoin,
+		"requestAmount": strconv.FormatFloat(fromAmount, 'f', -1, 64),
+	}
+
+	reqBody, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации параметров: %w", err)
+	}
+
+	body, err := b.doSignedRequest(ctx, http.MethodPost, b.config.ConvertQuoteURL, "", reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BybitConvertQuoteResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга ответа: %w", err)
+	}
+	if result.RetCode != 0 {
+		return nil, fmt.Errorf("ошибка Bybit: %s (код: %d)", result.RetMsg, result.RetCode)
+	}
+
+	quotedFrom, _ := strconv.ParseFloat(result.Result.FromAmount, 64)
+	quotedTo, _ := strconv.ParseFloat(result.Result.ToAmount, 64)
+	expiredMs, _ := strconv.ParseInt(result.Result.ExpiredTime, 10, 64)
+	if result.Result.QuoteTxID == "" || quotedFrom <= 0 || quotedTo <= 0 {
+		return nil, fmt.Errorf("некорректная котировка конвертации %s → %s: %s → %s",
+			fromCoin, toCoin, result.Result.FromAmount, result.Result.ToAmount)
+	}
+
+	return &services.ConvertQuote{
+		QuoteID:    result.Result.QuoteTxID,
+		FromCoin:   fromCoin,
+		ToCoin:     toCoin,
+		FromAmount: quotedFrom,
+		ToAmount:   quotedTo,
+		ExpiresAt:  time.UnixMilli(expiredMs),
+	}, nil
+}
+
+// ExecuteConvert исполняет котировку и опрашивает статус обмена до успеха или отказа биржи
+func (b *BybitClient) ExecuteConvert(ctx context.Context, quoteID string) (*services.ConvertResult, error) {
+	reqBody, err := json.Marshal(map[string]interface{}{"quoteTxId": quoteID})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации параметров: %w", err)
+	}
+
+	body, err := b.doSignedRequest(ctx, http.MethodPost, b.config.ConvertExecuteURL, "", reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var executed BybitConvertExecuteResponse
+	if err := json.Unmarshal(body, &executed); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга ответа: %w", err)
+	}
+	if executed.RetCode != 0 {
+		return nil, fmt.Errorf("ошибка Bybit: %s (код: %d)", executed.RetMsg, executed.RetCode)
+	}
+	if executed.Result.ExchangeStatus == bybitConvertStatusFailure {
+		return nil, fmt.Errorf("биржа отклонила конвертацию %s", quoteID)
+	}
+
+	params := fmt.Sprintf("quoteTxId=%s&accountType=%s", url.QueryEscape(quoteID), bybitConvertAccountType)
+	for attempt := 1; attempt <= bybitConvertPollAttempts; attempt++ {
+		body, err := b.doSignedRequest(ctx, http.MethodGet, b.config.ConvertResultURL, params, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var status BybitConvertResultResponse
+		if err := json.Unmarshal(body, &status); err != nil {
+			return nil, fmt.Errorf("ошибка парсинга ответа: %w", err)
+		}
+		if status.RetCode != 0 {
+			return nil, fmt.Errorf("ошибка Bybit: %s (код: %d)", status.RetMsg, status.RetCode)
+		}
+
+		switch status.Result.Result.ExchangeStatus {
+		case bybitConvertStatusSuccess:
+			fromAmount, _ := strconv.ParseFloat(status.Result.Result.FromAmount, 64)
+			toAmount, _ := strconv.ParseFloat(status.Result.Result.ToAmount, 64)
+			return &services.ConvertResult{
+				QuoteID:    quoteID,
+				ExchangeID: status.Result.Result.ExchangeTxID,
+				FromAmount: fromAmount,
+				ToAmount:   toAmount,
+			}, nil
+		case bybitConvertStatusFailure:
+			return nil, fmt.Errorf("биржа отклонила конвертацию %s", quoteID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(bybitConvertPollInterval):
+		}
+	}
+
+	return nil, fmt.Errorf("конвертация %s не завершилась за %d проверок статуса", quoteID, bybitConvertPollAttempts)
+}
@@ -0,0 +1,111 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// bybitErrCodeTimestamp код ошибки Bybit: время запроса вне окна recv_window
+const bybitErrCodeTimestamp = 10002
+
+// doSignedRequest выполняет подписанный запрос к Bybit V5 API.
+// Для GET запросов подписывается строка query, для POST - тело запроса.
+// При ошибке 10002 запрос повторяется с новой меткой времени согласно конфигурации
+func (b *BybitClient) doSignedRequest(ctx context.Context, method, endpoint, query string, body []byte) ([]byte, error) {
+	attempts := 1 + b.config.TimestampRetries
+	if !b.config.RetryOnTimestampError {
+		attempts = 1
+	}
+
+	var respBody []byte
+	for attempt := 1; attempt <= attempts; attempt++ {
+		req, err := b.newSignedRequest(ctx, method, endpoint, query, body)
+		if err != nil {
+			return nil, err
+		}
+
+		respBody, err = b.send(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var errResp BybitErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.RetCode == bybitErrCodeTimestamp && attempt < attempts {
+			logger.LogWithTime("⚠️ Bybit: метка времени вне окна recv_window (%s), повтор %d/%d",
+				errResp.RetMsg, attempt, attempts-1)
+			continue
+		}
+
+		break
+	}
+
+	return respBody, nil
+}
+
+// newSignedRequest создает HTTP запрос с заголовками аутентификации Bybit
+func (b *BybitClient) newSignedRequest(ctx context.Context, method, endpoint, query string, body []byte) (*http.Request, error) {
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	recvWindow := strconv.Itoa(b.config.RecvWindow)
+
+	payload := query
+	url := endpoint
+	if method == http.MethodGet && query != "" {
+		url = fmt.Sprintf("%s?%s", endpoint, query)
+	} else if body != nil {
+		payload = string(body)
+	}
+
+	signature := hmac.New(sha256.New, []byte(b.config.APISecret))
+	signature.Write([]byte(timestamp + b.config.APIKey + recvWindow + payload))
+	sign := hex.EncodeToString(signature.Sum(nil))
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+
+	req.Header.Add("X-BAPI-API-KEY", b.config.APIKey)
+	req.Header.Add("X-BAPI-SIGN", sign)
+	if b.config.SendSignType {
+		req.Header.Add("X-BAPI-SIGN-TYPE", "2")
+	}
+	req.Header.Add("X-BAPI-TIMESTAMP", timestamp)
+	req.Header.Add("X-BAPI-RECV-WINDOW", recvWindow)
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+// send отправляет запрос и читает тело ответа
+func (b *BybitClient) send(req *http.Request) ([]byte, error) {
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка отправки запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	return body, nil
+}
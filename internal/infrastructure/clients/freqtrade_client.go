@@ -4,8 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
 	"trade-hedge/internal/domain/entities"
 	"trade-hedge/internal/infrastructure/config"
 	"trade-hedge/internal/pkg/logger"
@@ -26,13 +31,51 @@ type FreqtradeTradeResponse struct {
 	CurrentRate float64 `json:"current_rate"`
 	OpenRate    float64 `json:"open_rate"`
 	Amount      float64 `json:"amount"`
+
+	OpenTimestamp int64 `json:"open_timestamp"` // Миллисекунды
+
+	CloseProfitAbs float64 `json:"close_profit_abs"`
+	CloseTimestamp int64   `json:"close_timestamp"` // Миллисекунды
+}
+
+// FreqtradeTradesResponse ответ от Freqtrade API с историей сделок (эндпоинт /trades)
+type FreqtradeTradesResponse struct {
+	Trades      []FreqtradeTradeResponse `json:"trades"`
+	TradesCount int                      `json:"trades_count"`
+	TotalTrades int                      `json:"total_trades"`
+}
+
+// freqtradeTradesPageSize максимальный размер страницы истории сделок в Freqtrade API
+const freqtradeTradesPageSize = 500
+
+// FreqtradeLocksResponse ответ от Freqtrade API со списком блокировок пар
+type FreqtradeLocksResponse struct {
+	LockCount int `json:"lock_count"`
+	Locks     []struct {
+		ID               int    `json:"id"`
+		Pair             string `json:"pair"`
+		Side             string `json:"side"`
+		Reason           string `json:"reason"`
+		LockEndTimestamp int64  `json:"lock_end_timestamp"` // Миллисекунды
+		Active           bool   `json:"active"`
+	} `json:"locks"`
 }
 
-// NewFreqtradeClient создает новый клиент Freqtrade
-func NewFreqtradeClient(config *config.FreqtradeConfig) *FreqtradeClient {
+// FreqtradeWhitelistResponse ответ от Freqtrade API со списком пар whitelist
+type FreqtradeWhitelistResponse struct {
+	Whitelist []string `json:"whitelist"`
+	Length    int      `json:"length"`
+}
+
+// NewFreqtradeClient создает новый клиент Freqtrade.
+// httpClient - общий клиент с настроенным транспортом (см. NewHTTPClient); nil - клиент по умолчанию
+func NewFreqtradeClient(config *config.FreqtradeConfig, httpClient *http.Client) *FreqtradeClient {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
 	return &FreqtradeClient{
 		config: config,
-		client: &http.Client{},
+		client: httpClient,
 	}
 }
 
@@ -53,6 +96,8 @@ func (f *FreqtradeClient) GetActiveTrades(ctx context.Context) ([]*entities.Trad
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		// Дочитываем тело, чтобы соединение вернулось в пул keep-alive
+		io.Copy(io.Discard, resp.Body)
 		return nil, fmt.Errorf("неверный статус код: %d", resp.StatusCode)
 	}
 
@@ -95,8 +140,188 @@ func (f *FreqtradeClient) convertTradesToEntities(apiTrades []FreqtradeTradeResp
 				OpenRate:    apiTrade.OpenRate,
 				Amount:      apiTrade.Amount,
 			}
+			if apiTrade.OpenTimestamp > 0 {
+				trade.OpenTime = time.UnixMilli(apiTrade.OpenTimestamp)
+			}
 			trades = append(trades, trade)
 		}
 	}
 	return trades
 }
+
+// GetClosedTrades получает закрытые сделки из истории Freqtrade (эндпоинт /trades, постранично)
+func (f *FreqtradeClient) GetClosedTrades(ctx context.Context) ([]*entities.Trade, error) {
+	tradesURL, err := f.siblingURL(f.config.TradesURL, "trades")
+	if err != nil {
+		return nil, err
+	}
+
+	var trades []*entities.Trade
+	for offset := 0; ; offset += freqtradeTradesPageSize {
+		page, err := f.getTradesPage(ctx, tradesURL, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, apiTrade := range page.Trades {
+			if apiTrade.IsOpen {
+				continue
+			}
+			trade := &entities.Trade{
+				ID:             apiTrade.TradeID,
+				Pair:           apiTrade.Pair,
+				IsOpen:         false,
+				ProfitRatio:    apiTrade.ProfitRatio,
+				OpenRate:       apiTrade.OpenRate,
+				Amount:         apiTrade.Amount,
+				CloseProfitAbs: apiTrade.CloseProfitAbs,
+			}
+			if apiTrade.CloseTimestamp > 0 {
+				closeTime := time.UnixMilli(apiTrade.CloseTimestamp)
+				trade.CloseTime = &closeTime
+			}
+			trades = append(trades, trade)
+		}
+
+		if len(page.Trades) < freqtradeTradesPageSize || offset+len(page.Trades) >= page.TotalTrades {
+			break
+		}
+	}
+
+	logger.LogWithTime("✅ Получено закрытых сделок Freqtrade: %d", len(trades))
+	return trades, nil
+}
+
+// getTradesPage получает одну страницу истории сделок
+func (f *FreqtradeClient) getTradesPage(ctx context.Context, tradesURL string, offset int) (*FreqtradeTradesResponse, error) {
+	u, err := url.Parse(tradesURL)
+	if err != nil {
+		return nil, fmt.Errorf("некорректный адрес истории сделок Freqtrade: %w", err)
+	}
+	query := u.Query()
+	query.Set("limit", strconv.Itoa(freqtradeTradesPageSize))
+	query.Set("offset", strconv.Itoa(offset))
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+
+	req.SetBasicAuth(f.config.Username, f.config.Password)
+	req.Header.Add("accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("неверный статус код: %d", resp.StatusCode)
+	}
+
+	var page FreqtradeTradesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга истории сделок Freqtrade: %w", err)
+	}
+
+	return &page, nil
+}
+
+// GetPairLocks получает блокировки пар из Freqtrade (эндпоинт /locks)
+func (f *FreqtradeClient) GetPairLocks(ctx context.Context) ([]*entities.PairLock, error) {
+	locksURL, err := f.siblingURL(f.config.LocksURL, "locks")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", locksURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+
+	req.SetBasicAuth(f.config.Username, f.config.Password)
+	req.Header.Add("accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("неверный статус код: %d", resp.StatusCode)
+	}
+
+	var apiLocks FreqtradeLocksResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiLocks); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга блокировок Freqtrade: %w", err)
+	}
+
+	locks := make([]*entities.PairLock, 0, len(apiLocks.Locks))
+	for _, apiLock := range apiLocks.Locks {
+		locks = append(locks, &entities.PairLock{
+			ID:      apiLock.ID,
+			Pair:    apiLock.Pair,
+			Side:    apiLock.Side,
+			Reason:  apiLock.Reason,
+			LockEnd: time.UnixMilli(apiLock.LockEndTimestamp),
+			Active:  apiLock.Active,
+		})
+	}
+
+	return locks, nil
+}
+
+// GetWhitelist получает текущий whitelist пар Freqtrade (эндпоинт /whitelist)
+func (f *FreqtradeClient) GetWhitelist(ctx context.Context) ([]string, error) {
+	whitelistURL, err := f.siblingURL(f.config.WhitelistURL, "whitelist")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", whitelistURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+
+	req.SetBasicAuth(f.config.Username, f.config.Password)
+	req.Header.Add("accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("неверный статус код: %d", resp.StatusCode)
+	}
+
+	var apiWhitelist FreqtradeWhitelistResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiWhitelist); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга whitelist Freqtrade: %w", err)
+	}
+
+	return apiWhitelist.Whitelist, nil
+}
+
+// siblingURL возвращает адрес эндпоинта: из настроек (override) или /name рядом с api_url
+func (f *FreqtradeClient) siblingURL(override, name string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+
+	u, err := url.Parse(f.config.APIURL)
+	if err != nil {
+		return "", fmt.Errorf("некорректный api_url Freqtrade: %w", err)
+	}
+	u.Path = path.Join(path.Dir(u.Path), name)
+	u.RawQuery = ""
+
+	return u.String(), nil
+}
@@ -0,0 +1,75 @@
+package clients
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/tracing"
+)
+
+// NewHTTPClient создает HTTP клиент с настроенным транспортом.
+// Один клиент передается во все клиенты бирж, чтобы соединения и TLS сессии
+// переиспользовались между множеством мелких запросов каждого цикла
+func NewHTTPClient(cfg *config.HTTPConfig) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: time.Duration(cfg.KeepAlive) * time.Second,
+	}
+
+	tlsConfig := &tls.Config{}
+	if cfg.TLSSessionCacheSize > 0 {
+		// Возобновление TLS сессии избавляет от полного рукопожатия при переподключении
+		tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(cfg.TLSSessionCacheSize)
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       time.Duration(cfg.IdleConnTimeout) * time.Second,
+		TLSHandshakeTimeout:   time.Duration(cfg.TLSHandshakeTimeout) * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+
+	return &http.Client{
+		Transport: tracingTransport{next: transport},
+		Timeout:   time.Duration(cfg.RequestTimeout) * time.Second,
+	}
+}
+
+// tracingTransport записывает запросы к бирже и Freqtrade дочерними спанами трассы цикла:
+// по ним видно, сколько цикл ждал каждый внешний сервис. Вне трассы запрос выполняется как есть
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip выполняет запрос в спане "HTTP <метод>" с адресом, путем и кодом ответа
+func (t tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, span := tracing.StartChild(req.Context(), "HTTP "+req.Method,
+		tracing.String("http.request.method", req.Method),
+		tracing.String("server.address", req.URL.Host),
+		tracing.String("url.path", req.URL.Path))
+	if span == nil {
+		return t.next.RoundTrip(req)
+	}
+	span.SetKind(tracing.SpanKindClient)
+	defer span.End()
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(tracing.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 500 {
+		span.RecordError(fmt.Errorf("HTTP %d", resp.StatusCode))
+	}
+	return resp, nil
+}
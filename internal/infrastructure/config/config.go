@@ -1,11 +1,19 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/mail"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/cron"
 
 	"gopkg.in/yaml.v2"
 )
@@ -17,32 +25,76 @@ type Config struct {
 	Database  DatabaseConfig  `yaml:"database"`
 	Strategy  StrategyConfig  `yaml:"strategy"`
 	WebUI     WebUIConfig     `yaml:"webui"`
+	Rebalance RebalanceConfig `yaml:"rebalance"`
+	Risk      RiskConfig      `yaml:"risk"`
+	HTTP      HTTPConfig      `yaml:"http"`
+	Flat      FlatConfig      `yaml:"flat"`
+	Watchdog  WatchdogConfig  `yaml:"watchdog"`
+	Alerts    AlertsConfig    `yaml:"alerts"`
+	Lease     LeaseConfig     `yaml:"lease"`
+	History   HistoryConfig   `yaml:"history"`
+	Balance   BalanceConfig   `yaml:"balance_check"`
+	Archive   ArchiveConfig   `yaml:"archive"`
+	Reports   ReportsConfig   `yaml:"reports"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Heartbeat HeartbeatConfig `yaml:"heartbeat"`
+	Signals   SignalsConfig   `yaml:"signals"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	Features  map[string]bool `yaml:"features"` // Флаги рискованных возможностей (entities.Flag*); переключаются в веб-интерфейсе
+
+	Notifications NotificationsConfig `yaml:"notifications"` // Внешние каналы оповещений
 }
 
 // FreqtradeConfig конфигурация для подключения к Freqtrade
 type FreqtradeConfig struct {
-	APIURL   string `yaml:"api_url"`
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
+	APIURL    string `yaml:"api_url"`
+	LocksURL  string `yaml:"locks_url"`  // Эндпоинт блокировок пар (по умолчанию /locks рядом с api_url)
+	TradesURL string `yaml:"trades_url"` // Эндпоинт истории сделок (по умолчанию /trades рядом с api_url)
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
+
+	WhitelistURL string `yaml:"whitelist_url"` // Эндпоинт whitelist пар (по умолчанию /whitelist рядом с api_url)
 }
 
 // BybitConfig конфигурация для подключения к Bybit
 type BybitConfig struct {
-	APIKey         string `yaml:"api_key"`
-	APISecret      string `yaml:"api_secret"`
-	SpotURL        string `yaml:"spot_url"`
-	BalanceURL     string `yaml:"balance_url"`
-	OrderStatusURL string `yaml:"order_status_url"`
+	APIKey          string `yaml:"api_key"`
+	APISecret       string `yaml:"api_secret"`
+	SpotURL         string `yaml:"spot_url"`
+	BalanceURL      string `yaml:"balance_url"`
+	OrderStatusURL  string `yaml:"order_status_url"`
+	CancelURL       string `yaml:"cancel_url"`
+	OrderHistoryURL string `yaml:"order_history_url"` // История ордеров для импорта ручной торговли
+
+	ConvertQuoteURL   string `yaml:"convert_quote_url"`   // Запрос котировки конвертации (convert_pairs)
+	ConvertExecuteURL string `yaml:"convert_execute_url"` // Исполнение котировки конвертации
+	ConvertResultURL  string `yaml:"convert_result_url"`  // Статус конвертации
+
+	RecvWindow            int  `yaml:"recv_window"`              // Окно допустимого расхождения времени запроса в мс
+	RetryOnTimestampError bool `yaml:"retry_on_timestamp_error"` // Повторять запрос при ошибке 10002 (время вне окна)
+	TimestampRetries      int  `yaml:"timestamp_retries"`        // Количество повторов при ошибке 10002
+	SendSignType          bool `yaml:"send_sign_type"`           // Передавать заголовок X-BAPI-SIGN-TYPE
 }
 
 // DatabaseConfig конфигурация базы данных
 type DatabaseConfig struct {
+	Driver   string `yaml:"driver"` // Хранилище: postgres или sqlite (файл path, без внешнего сервера)
+	Path     string `yaml:"path"`   // Путь к файлу базы SQLite
 	Host     string `yaml:"host"`
 	Port     int    `yaml:"port"`
 	User     string `yaml:"user"`
 	Password string `yaml:"password"`
 	DBName   string `yaml:"dbname"`
 	SSLMode  string `yaml:"sslmode"`
+
+	MaxConns             int `yaml:"max_conns"`               // Максимум соединений в пуле
+	MinConns             int `yaml:"min_conns"`               // Минимум открытых соединений (держатся прогретыми)
+	MaxConnLifetime      int `yaml:"max_conn_lifetime"`       // Время жизни соединения в секундах
+	MaxConnIdleTime      int `yaml:"max_conn_idle_time"`      // Время простоя, после которого соединение закрывается, в секундах
+	HealthCheckPeriod    int `yaml:"health_check_period"`     // Интервал проверки простаивающих соединений в секундах
+	ConnectRetries       int `yaml:"connect_retries"`         // Повторные попытки подключения при старте, пока PostgreSQL недоступен (0 - без повторов)
+	ConnectRetryDelay    int `yaml:"connect_retry_delay"`     // Задержка перед первым повтором в секундах (удваивается с каждой попыткой)
+	ConnectRetryMaxDelay int `yaml:"connect_retry_max_delay"` // Максимальная задержка между попытками в секундах
 }
 
 // StrategyConfig конфигурация торговой стратегии
@@ -54,6 +106,116 @@ type StrategyConfig struct {
 	CheckInterval  int     `yaml:"check_interval"` // Интервал проверки в секундах (0 = одноразовое выполнение)
 	RetryAttempts  int     `yaml:"retry_attempts"` // Количество попыток размещения ордера
 	RetryDelay     int     `yaml:"retry_delay"`    // Задержка между попытками в секундах
+
+	// Расписание циклов: cron вместо интервала, тихие часы, в которые новые хеджи не открываются,
+	// и ограничение длительности цикла
+	Schedule         string   `yaml:"schedule"`          // Расписание циклов в формате cron ("*/5 * * * *"); пусто - каждые check_interval секунд
+	QuietHours       []string `yaml:"quiet_hours"`       // Окна "ЧЧ:ММ-ЧЧ:ММ" без новых хеджей (статусы ордеров проверяются), например "00:00-02:00"
+	ScheduleTimezone string   `yaml:"schedule_timezone"` // Часовой пояс schedule и quiet_hours (IANA, по умолчанию UTC)
+	CycleTimeout     int      `yaml:"cycle_timeout"`     // Максимальная длительность цикла в секундах, после нее цикл прерывается (0 - без ограничения)
+
+	// EarlyStatusChecks задержки внеочередных проверок статуса тейк-профита после размещения (в секундах)
+	EarlyStatusChecks []int `yaml:"early_status_checks"`
+
+	BuyFillTimeout      int  `yaml:"buy_fill_timeout"`       // Максимальное время ожидания исполнения покупки в секундах
+	BuyFillPollInterval int  `yaml:"buy_fill_poll_interval"` // Интервал опроса статуса покупки в секундах
+	LeaveBuyPending     bool `yaml:"leave_buy_pending"`      // Оставить неисполненную покупку до следующего цикла
+
+	Name                     string   `yaml:"name"`                        // Стратегия хеджирования: classic, martingale-ladder
+	MartingaleMultiplier     float64  `yaml:"martingale_multiplier"`       // Множитель суммы для каждой следующей ступени
+	MartingaleMaxSteps       int      `yaml:"martingale_max_steps"`        // Максимальное количество ступеней по сделке
+	MinTakeProfitTicks       int      `yaml:"min_take_profit_ticks"`       // Минимальное расстояние тейк-профита от цены покупки в шагах цены
+	MinTakeProfitPercent     float64  `yaml:"min_take_profit_percent"`     // Минимальное расстояние тейк-профита от цены покупки в процентах
+	MaxPriceDeviationPercent float64  `yaml:"max_price_deviation_percent"` // Максимальное отклонение цены Freqtrade от текущей цены биржи перед покупкой (%, 0 - без проверки)
+	BuyPriceOffsetPercent    float64  `yaml:"buy_price_offset_percent"`    // Надбавка к текущей цене для лимитного ордера на покупку (%)
+	SlippageBufferPercent    float64  `yaml:"slippage_buffer_percent"`     // Запас баланса на проскальзывание при проверке средств перед покупкой (%)
+	RespectPairLocks         bool     `yaml:"respect_pair_locks"`          // Не хеджировать пары, заблокированные Freqtrade (например, после стоп-лосса)
+	QuoteConversion          bool     `yaml:"quote_conversion"`            // Хеджировать пары с другой котируемой валютой через рынок к base_currency (BTC/EUR → BTC/USDT)
+	MaxParallelHedges        int      `yaml:"max_parallel_hedges"`         // Сколько сделок хеджировать за цикл параллельно (1 - одна сделка за цикл)
+	BuyPriceRounding         string   `yaml:"buy_price_rounding"`          // Округление цены покупки до шага цены: floor, ceil, nearest, bankers
+	SellPriceRounding        string   `yaml:"sell_price_rounding"`         // Округление цены тейк-профита до шага цены: floor, ceil, nearest, bankers
+	QuantityRounding         string   `yaml:"quantity_rounding"`           // Округление количества до шага количества: floor, ceil, nearest, bankers
+	StopLossPercent          float64  `yaml:"stop_loss_percent"`           // Стоп-лосс хеджа ниже цены покупки в процентах, связанный с тейк-профитом как OCO (0 - без стоп-лосса)
+	BuyFallback              string   `yaml:"buy_fallback"`                // Что делать, если лимитная покупка не исполнилась за buy_fill_timeout: none - отменить остаток, market - докупить остаток по рынку
+	PassiveEntryTimeout      int      `yaml:"passive_entry_timeout"`       // Сначала покупать мейкером (PostOnly по лучшей цене покупки) до N секунд, затем докупать остаток по рынку (0 - выключено)
+	MinLosingTrades          int      `yaml:"min_losing_trades"`           // Хеджировать только если у Freqtrade открыто больше N убыточных сделок (0 - без условия)
+	MinPortfolioLoss         float64  `yaml:"min_portfolio_loss"`          // Хеджировать только если суммарный нереализованный убыток открытых сделок больше суммы в базовой валюте (0 - без условия)
+	ConvertPairs             []string `yaml:"convert_pairs"`               // Пары с тонким стаканом, которые покупаются конвертацией по твердой котировке Bybit вместо лимитного ордера
+	Priority                 string   `yaml:"priority"`                    // Порядок хеджирования отобранных сделок: drawdown, notional, loss, age, pairs
+	PriorityPairs            []string `yaml:"priority_pairs"`              // Порядок пар для priority: pairs (пары вне списка - после, по дополнительным ключам)
+	PriorityTiebreakers      []string `yaml:"priority_tiebreakers"`        // Дополнительные ключи при равенстве основного: drawdown, notional, loss, age (последним всегда идет просадка)
+
+	Filters               []string            `yaml:"filters"`                 // Фильтры перед покупкой по порядку (пусто - все в порядке по умолчанию); не указанные фильтры отключены
+	FiltersByStrategy     map[string][]string `yaml:"filters_by_strategy"`     // Порядок фильтров для отдельных стратегий (classic, martingale-ladder), переопределяет filters
+	BlacklistPairs        []string            `yaml:"blacklist_pairs"`         // Пары, которые не хеджируются (фильтр blacklist)
+	MaxSpreadPercent      float64             `yaml:"max_spread_percent"`      // Максимальный спред стакана в процентах (фильтр spread, 0 - без проверки)
+	MaxVolatilityPercent  float64             `yaml:"max_volatility_percent"`  // Максимальный размах цены за окно в процентах (фильтр volatility, 0 - без проверки)
+	VolatilityWindowHours int                 `yaml:"volatility_window_hours"` // Окно расчета размаха цены в часах (фильтр volatility)
+
+	// Защита цены закрытия продажей (эмуляция стоп-лосса, закрытие по времени и вручную)
+	CloseSlippagePercent float64 `yaml:"close_slippage_percent"` // Продавать лимитными ордерами не ниже цены отметки минус N% с перевыставлением, остаток - по рынку (0 - сразу рыночный ордер)
+	CloseRepriceInterval int     `yaml:"close_reprice_interval"` // Сколько секунд ждать исполнения лимитной продажи перед перевыставлением по свежей цене
+	CloseRepriceAttempts int     `yaml:"close_reprice_attempts"` // Лимитных попыток продажи до продажи остатка по рынку
+
+	// Хедж крупной сделки несколькими ордерами (ногами) с общим тейк-профитом, если сумма позиции больше
+	// максимального ордера инструмента, split_max_leg_amount или доли глубины стакана (нужен PostgreSQL)
+	SplitMaxLegs           int     `yaml:"split_max_legs"`            // Максимум ног; остаток сверх max_legs ног не хеджируется (1 - не разбивать)
+	SplitMaxLegAmount      float64 `yaml:"split_max_leg_amount"`      // Максимальная сумма одной ноги в базовой валюте (0 - только лимит инструмента)
+	SplitLegInterval       int     `yaml:"split_leg_interval"`        // Интервал между ногами в секундах (нога размещается в первом цикле после интервала)
+	SplitDepthPercent      float64 `yaml:"split_depth_percent"`       // Учитывать заявки на продажу не дальше N% от лучшей цены (0 - глубина стакана не учитывается)
+	SplitDepthSharePercent float64 `yaml:"split_depth_share_percent"` // Какую долю этой глубины может забрать одна нога, %
+}
+
+// schedulePeriodHorizon за какой период ищется самый длинный промежуток между циклами расписания
+const schedulePeriodHorizon = 400 * 24 * time.Hour
+
+// Scheduled проверяет, что стратегия выполняется циклами: по расписанию schedule или каждые check_interval секунд
+func (s *StrategyConfig) Scheduled() bool {
+	return s.Schedule != "" || s.CheckInterval > 0
+}
+
+// CronSchedule возвращает разобранное расписание циклов (nil - циклы каждые check_interval секунд)
+func (s *StrategyConfig) CronSchedule() (*cron.Schedule, error) {
+	if s.Schedule == "" {
+		return nil, nil
+	}
+	return cron.Parse(s.Schedule)
+}
+
+// QuietWindows возвращает разобранные тихие часы
+func (s *StrategyConfig) QuietWindows() ([]cron.Window, error) {
+	windows := make([]cron.Window, 0, len(s.QuietHours))
+	for _, spec := range s.QuietHours {
+		window, err := cron.ParseWindow(spec)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// ScheduleLocation возвращает часовой пояс расписания и тихих часов
+func (s *StrategyConfig) ScheduleLocation() (*time.Location, error) {
+	if s.ScheduleTimezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.ScheduleTimezone)
+}
+
+// CyclePeriod возвращает наибольший промежуток между циклами: check_interval или самый длинный промежуток
+// расписания schedule. 0 - разовое выполнение. Пороги, которые должны превышать период циклов
+// (сторожевой таймер, захват статусов), проверяются по нему
+func (s *StrategyConfig) CyclePeriod() time.Duration {
+	schedule, err := s.CronSchedule()
+	if err != nil || schedule == nil {
+		return time.Duration(s.CheckInterval) * time.Second
+	}
+	location, err := s.ScheduleLocation()
+	if err != nil {
+		location = time.UTC
+	}
+	return schedule.LongestGap(time.Now().In(location), schedulePeriodHorizon)
 }
 
 // WebUIConfig конфигурация веб-интерфейса
@@ -61,9 +223,440 @@ type WebUIConfig struct {
 	Enabled bool   `yaml:"enabled"`
 	Port    int    `yaml:"port"`
 	Host    string `yaml:"host"`
+	APIOnly bool   `yaml:"api_only"` // Только API: HTML страницы не загружаются и не отдаются
+
+	LogBufferLines int    `yaml:"log_buffer_lines"` // Последних строк лога в памяти для страницы /logs (0 - не хранить)
+	Language       string `yaml:"language"`         // Язык страниц и сообщений API: ru или en
+	TLSCert        string `yaml:"tls_cert"`         // Сертификат TLS (PEM): сервер принимает HTTPS без прокси
+	TLSKey         string `yaml:"tls_key"`          // Закрытый ключ сертификата TLS (PEM)
+
+	Auth WebUIAuthConfig `yaml:"auth"` // Аутентификация страниц и API
+}
+
+// Режимы аутентификации веб-интерфейса
+const (
+	WebUIAuthNone    = "none"    // Без аутентификации (только для localhost или за reverse proxy с авторизацией)
+	WebUIAuthBasic   = "basic"   // HTTP Basic auth на каждый запрос
+	WebUIAuthSession = "session" // Страница входа и cookie сессии
+)
+
+// Языки веб-интерфейса
+const (
+	WebUILanguageRU = "ru" // Русский (исходный язык страниц и сообщений)
+	WebUILanguageEN = "en" // Английский
+)
+
+// Роли пользователей веб-интерфейса по возрастанию прав
+const (
+	WebUIRoleViewer   = "viewer"   // Только просмотр страниц и API
+	WebUIRoleOperator = "operator" // Просмотр и действия: хеджирование, закрытие хеджей, пауза, изменение параметров стратегии
+	WebUIRoleAdmin    = "admin"    // Все, включая изменения через /api/admin: аренда, откат конфигурации, флаги возможностей
+)
+
+// WebUIAuthConfig аутентификация веб-интерфейса: логин и пароль для страниц, токены для /api
+type WebUIAuthConfig struct {
+	Mode         string      `yaml:"mode"`          // none, basic или session
+	Username     string      `yaml:"username"`      // Имя пользователя (роль admin)
+	Password     string      `yaml:"password"`      // Пароль
+	Users        []WebUIUser `yaml:"users"`         // Дополнительные пользователи с ролями
+	SessionTTL   int         `yaml:"session_ttl"`   // Время жизни сессии в минутах (режим session)
+	SecureCookie bool        `yaml:"secure_cookie"` // Cookie сессии только по HTTPS (за TLS-прокси)
+	APITokens    []string    `yaml:"api_tokens"`    // Токены для /api (заголовок Authorization: Bearer <токен>, роль admin)
+}
+
+// WebUIUser пользователь веб-интерфейса с ролью
+type WebUIUser struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Role     string `yaml:"role"` // viewer, operator или admin
+}
+
+// IsWebUIRole проверяет, что роль пользователя веб-интерфейса известна
+func IsWebUIRole(role string) bool {
+	switch role {
+	case WebUIRoleViewer, WebUIRoleOperator, WebUIRoleAdmin:
+		return true
+	}
+	return false
+}
+
+// Enabled проверяет, что аутентификация включена
+func (a *WebUIAuthConfig) Enabled() bool {
+	return a.Mode != WebUIAuthNone
+}
+
+// TLSEnabled проверяет, что веб-сервер принимает HTTPS сам (заданы сертификат и ключ)
+func (w *WebUIConfig) TLSEnabled() bool {
+	return w.TLSCert != "" && w.TLSKey != ""
+}
+
+// SessionDuration возвращает время жизни сессии
+func (a *WebUIAuthConfig) SessionDuration() time.Duration {
+	return time.Duration(a.SessionTTL) * time.Minute
+}
+
+// HTTPConfig настройки общего HTTP транспорта клиентов бирж (keep-alive, пул соединений, TLS)
+type HTTPConfig struct {
+	MaxIdleConns        int `yaml:"max_idle_conns"`          // Максимум простаивающих соединений всего
+	MaxIdleConnsPerHost int `yaml:"max_idle_conns_per_host"` // Максимум простаивающих соединений на хост
+	MaxConnsPerHost     int `yaml:"max_conns_per_host"`      // Максимум соединений на хост (0 - без ограничения)
+	IdleConnTimeout     int `yaml:"idle_conn_timeout"`       // Время жизни простаивающего соединения в секундах
+	KeepAlive           int `yaml:"keep_alive"`              // Интервал TCP keep-alive в секундах
+	TLSHandshakeTimeout int `yaml:"tls_handshake_timeout"`   // Таймаут TLS рукопожатия в секундах
+	TLSSessionCacheSize int `yaml:"tls_session_cache_size"`  // Размер кэша TLS сессий для возобновления (0 - отключено)
+	RequestTimeout      int `yaml:"request_timeout"`         // Общий таймаут HTTP запроса в секундах
+}
+
+// RiskConfig лимиты риска, проверяемые перед каждым хеджированием (0 - без ограничения)
+type RiskConfig struct {
+	MaxOpenNotional     float64 `yaml:"max_open_notional"`     // Максимальный суммарный объем открытых хеджей
+	MaxConcurrentHedges int     `yaml:"max_concurrent_hedges"` // Максимальное количество активных хеджей
+	DailyBudget         float64 `yaml:"daily_budget"`          // Максимальная сумма новых хеджей за сутки
+	MaxHedgesPerPair    int     `yaml:"max_hedges_per_pair"`   // Максимальное количество активных хеджей по паре
+
+	PerQuote map[string]QuoteRiskConfig `yaml:"per_quote"` // Независимые лимиты по котируемым валютам (USDT, USDC, BTC)
+}
+
+// QuoteRiskConfig лимиты риска по одной котируемой валюте (0 - без ограничения)
+type QuoteRiskConfig struct {
+	MaxConcurrentHedges int     `yaml:"max_concurrent_hedges"` // Максимальное количество активных хеджей
+	MaxOpenNotional     float64 `yaml:"max_open_notional"`     // Максимальный суммарный объем открытых хеджей в котируемой валюте
+	DailyBudget         float64 `yaml:"daily_budget"`          // Максимальная сумма новых хеджей за сутки в котируемой валюте
+}
+
+// RebalanceConfig конфигурация ребалансировки прибыли от хеджирования
+type RebalanceConfig struct {
+	Enabled     bool               `yaml:"enabled"`
+	Interval    int                `yaml:"interval"`    // Интервал ребалансировки в секундах
+	MinProfit   float64            `yaml:"min_profit"`  // Минимальная накопленная прибыль для ребалансировки
+	Allocations map[string]float64 `yaml:"allocations"` // Доли активов в процентах (остаток остается в base_currency)
+}
+
+// FlatConfig конфигурация ежедневного закрытия хеджей по времени
+type FlatConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	Time           string `yaml:"time"`            // Локальное время закрытия в формате ЧЧ:ММ
+	OnlyProfitable bool   `yaml:"only_profitable"` // Закрывать только хеджи в прибыли
+}
+
+// WatchdogConfig конфигурация сторожевого таймера циклов
+type WatchdogConfig struct {
+	Enabled        bool `yaml:"enabled"`
+	StallThreshold int  `yaml:"stall_threshold"` // Сколько минут без завершенного цикла считается зависанием
+	ExitOnStall    bool `yaml:"exit_on_stall"`   // Завершить процесс при зависании, чтобы супервизор его перезапустил
+}
+
+// HeartbeatConfig отметка успешных циклов для внешнего мониторинга: пинг адреса и/или файл с временем цикла
+type HeartbeatConfig struct {
+	URL     string `yaml:"url"`     // Адрес пинга (healthchecks.io, Uptime Kuma push); "" - без пинга
+	File    string `yaml:"file"`    // Файл с временем последнего успешного цикла; "" - без файла
+	Timeout int    `yaml:"timeout"` // Таймаут пинга в секундах
+}
+
+// Enabled проверяет, что отметка циклов настроена
+func (h *HeartbeatConfig) Enabled() bool {
+	return h.URL != "" || h.File != ""
+}
+
+// AlertsConfig конфигурация группировки повторяющихся оповещений
+type AlertsConfig struct {
+	EscalateAfter  int `yaml:"escalate_after"`  // Повторов условия до оповещения с высоким приоритетом (0 - без эскалации)
+	RepeatInterval int `yaml:"repeat_interval"` // Напоминание о продолжающемся условии не чаще, чем раз в N минут (0 - без напоминаний)
+
+	StuckHedgeAfter int `yaml:"stuck_hedge_after"` // Через сколько минут купленная позиция без тейк-профита - критическое условие (0 - не проверяется)
+}
+
+// BalanceConfig конфигурация сверки балансов биржи с открытыми хеджами
+type BalanceConfig struct {
+	Enabled          bool    `yaml:"enabled"`
+	Interval         int     `yaml:"interval"`          // Интервал сверки в секундах
+	TolerancePercent float64 `yaml:"tolerance_percent"` // Допустимая нехватка актива в процентах (комиссии, округление)
+}
+
+// ArchiveConfig конфигурация архивации давно закрытых хеджей
+type ArchiveConfig struct {
+	Enabled       bool `yaml:"enabled"`
+	RetentionDays int  `yaml:"retention_days"` // Хеджи, закрытые раньше, переносятся в архив
+	Interval      int  `yaml:"interval"`       // Интервал архивации в секундах
+}
+
+// ReportsConfig сводки хеджирования за день и неделю, отправляемые оповещением report по расписанию cron
+// (минута час день месяц день_недели, в часовом поясе процесса)
+type ReportsConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	DailySchedule  string `yaml:"daily_schedule"`  // Расписание сводки за 24 часа ("" - не отправляется)
+	WeeklySchedule string `yaml:"weekly_schedule"` // Расписание сводки за 7 дней ("" - не отправляется)
+}
+
+// TracingConfig трассировка OpenTelemetry: трасса на каждый цикл планировщика с дочерними спанами
+// хеджирования сделок, ордеров, запросов к бирже, Freqtrade и PostgreSQL; отправка на OTLP/HTTP коллектор
+type TracingConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	Endpoint    string  `yaml:"endpoint"`     // OTLP/HTTP коллектор (Jaeger, Tempo, OpenTelemetry Collector)
+	ServiceName string  `yaml:"service_name"` // service.name в трассах
+	SampleRatio float64 `yaml:"sample_ratio"` // Доля отправляемых трасс циклов (0-1)
+}
+
+// SignalsConfig внешние сигналы хеджирования: кроме сделок Freqtrade, хедж может запросить внешняя система
+// (алерт TradingView, сканер). Сигналы забираются в начале каждого цикла стратегии
+type SignalsConfig struct {
+	Enabled       bool               `yaml:"enabled"`
+	MaxAmount     float64            `yaml:"max_amount"`     // Максимальная сумма сигнала в котируемой валюте (0 - без ограничения)
+	Webhook       bool               `yaml:"webhook"`        // Прием сигналов POST /api/v1/signals
+	WebhookSecret string             `yaml:"webhook_secret"` // Секрет webhook: с ним эндпоинт доступен без аутентификации веб-интерфейса
+	Dir           string             `yaml:"dir"`            // Каталог JSON файлов сигналов ("" - не используется)
+	Redis         SignalsRedisConfig `yaml:"redis"`
+}
+
+// SignalsRedisConfig список Redis, из которого забираются сигналы
+type SignalsRedisConfig struct {
+	Addr     string `yaml:"addr"` // host:port ("" - не используется)
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+	Key      string `yaml:"key"`   // Ключ списка: сигналы добавляются RPUSH, бот забирает LPOP
+	Batch    int    `yaml:"batch"` // Сигналов, забираемых за цикл
+}
+
+// NotificationsConfig внешние каналы оповещений (кроме лога)
+type NotificationsConfig struct {
+	Webhook WebhookNotifierConfig `yaml:"webhook"`
+	Email   EmailNotifierConfig   `yaml:"email"`
+
+	// Правила маршрутизации оповещений по каналам (пусто - правила по умолчанию, см. DefaultNotificationRoutes)
+	Routes []NotificationRouteConfig `yaml:"routes"`
+}
+
+// Каналы оповещений
+const (
+	NotificationChannelLog     = "log"     // Лог процесса
+	NotificationChannelWebhook = "webhook" // Исходящий webhook (notifications.webhook)
+	NotificationChannelEmail   = "email"   // Почта (notifications.email)
+)
+
+// NotificationRouteConfig правило маршрутизации: оповещение, подходящее под все условия правила,
+// отправляется в его каналы. Оповещение уходит в объединение каналов всех подходящих правил
+type NotificationRouteConfig struct {
+	Events      []string `yaml:"events"`       // События (entities.NotificationEvent*); пусто - любые
+	MinPriority string   `yaml:"min_priority"` // Минимальный приоритет: low, normal, high ("" - любой)
+	Critical    bool     `yaml:"critical"`     // Только критические условия (отказ биржи в аутентификации, база, позиция без тейк-профита)
+	Channels    []string `yaml:"channels"`     // Каналы: log, webhook, email
+}
+
+// DefaultNotificationRoutes правила по умолчанию: в лог - все, кроме завершенных циклов (о них лог пишет и так),
+// в webhook - все события, на почту - критические условия с высоким приоритетом
+func DefaultNotificationRoutes() []NotificationRouteConfig {
+	return []NotificationRouteConfig{
+		{
+			Events: []string{
+				entities.NotificationEventAlert,
+				entities.NotificationEventHedgeOpened,
+				entities.NotificationEventHedgeClosed,
+				entities.NotificationEventHedgeFailed,
+				entities.NotificationEventReport,
+			},
+			Channels: []string{NotificationChannelLog},
+		},
+		{Channels: []string{NotificationChannelWebhook}},
+		{MinPriority: "high", Critical: true, Channels: []string{NotificationChannelEmail}},
+	}
+}
+
+// ChannelEnabled проверяет, что канал оповещений настроен (лог доступен всегда)
+func (n *NotificationsConfig) ChannelEnabled(channel string) bool {
+	switch channel {
+	case NotificationChannelLog:
+		return true
+	case NotificationChannelWebhook:
+		return n.Webhook.Enabled()
+	case NotificationChannelEmail:
+		return n.Email.Enabled()
+	}
+	return false
+}
+
+// EmailNotifierConfig письма через SMTP. Какие оповещения уходят на почту, задают notifications.routes:
+// по умолчанию - критические условия (отказ биржи в аутентификации, недоступная база, позиция без тейк-профита)
+type EmailNotifierConfig struct {
+	Host        string   `yaml:"host"` // SMTP сервер ("" - почта выключена)
+	Port        int      `yaml:"port"`
+	Username    string   `yaml:"username"` // "" - без аутентификации
+	Password    string   `yaml:"password"`
+	From        string   `yaml:"from"`
+	To          []string `yaml:"to"`
+	ImplicitTLS bool     `yaml:"implicit_tls"` // TLS с начала соединения (порт 465); иначе STARTTLS, если сервер его поддерживает
+	Throttle    int      `yaml:"throttle"`     // Не чаще одного письма об одном условии за N минут (0 - без ограничения)
+	MaxPerHour  int      `yaml:"max_per_hour"` // Не больше N писем в час на все условия (0 - без ограничения)
+}
+
+// Enabled проверяет, что почта настроена
+func (e *EmailNotifierConfig) Enabled() bool {
+	return e.Host != "" && len(e.To) > 0
+}
+
+// WebhookNotifierConfig исходящий webhook: события (entities.NotificationEvent*) отправляются POST JSON
+// на каждый адрес с подписью HMAC-SHA256 и повторами при ошибке доставки
+type WebhookNotifierConfig struct {
+	URLs       []string `yaml:"urls"`        // Адреса получателей (n8n, Zapier, Discord); пустой список - webhook выключен
+	Secret     string   `yaml:"secret"`      // Ключ подписи X-Trade-Hedge-Signature ("" - без подписи)
+	Events     []string `yaml:"events"`      // Отправляемые события (пусто - все)
+	Timeout    int      `yaml:"timeout"`     // Таймаут одного запроса в секундах
+	MaxRetries int      `yaml:"max_retries"` // Повторов после неудачной доставки
+	RetryDelay int      `yaml:"retry_delay"` // Пауза перед первым повтором в секундах, каждый следующий - вдвое дольше
+}
+
+// Enabled проверяет, что исходящий webhook настроен
+func (w *WebhookNotifierConfig) Enabled() bool {
+	return len(w.URLs) > 0
+}
+
+// MetricsConfig пользовательские метрики оператора: read-only SQL запросы, скалярный результат которых
+// отдается как gauge Prometheus (/metrics) и карточка дашборда
+type MetricsConfig struct {
+	Interval     int                  `yaml:"interval"`      // Сколько секунд значения кэшируются до повторного выполнения запросов
+	QueryTimeout int                  `yaml:"query_timeout"` // Таймаут одного запроса в секундах
+	Custom       []CustomMetricConfig `yaml:"custom"`
+}
+
+// CustomMetricConfig пользовательская метрика (entities.CustomMetric)
+type CustomMetricConfig struct {
+	Name  string `yaml:"name"`  // Имя метрики Prometheus (например, hedges_opened_weekends_month)
+	Help  string `yaml:"help"`  // Описание метрики
+	Title string `yaml:"title"` // Заголовок карточки дашборда (по умолчанию help)
+	Query string `yaml:"query"` // SELECT, возвращающий одно число
+}
+
+// CustomMetrics возвращает пользовательские метрики в виде сущностей
+func (m *MetricsConfig) CustomMetrics() []entities.CustomMetric {
+	metrics := make([]entities.CustomMetric, 0, len(m.Custom))
+	for _, metric := range m.Custom {
+		metrics = append(metrics, entities.CustomMetric{
+			Name:  strings.TrimSpace(metric.Name),
+			Help:  metric.Help,
+			Title: metric.Title,
+			Query: metric.Query,
+		})
+	}
+	return metrics
+}
+
+// CacheDuration возвращает время кэширования значений метрик
+func (m *MetricsConfig) CacheDuration() time.Duration {
+	return time.Duration(m.Interval) * time.Second
+}
+
+// QueryTimeoutDuration возвращает таймаут запроса метрики
+func (m *MetricsConfig) QueryTimeoutDuration() time.Duration {
+	return time.Duration(m.QueryTimeout) * time.Second
+}
+
+// LeaseConfig конфигурация аренды ведущего экземпляра (развертывание без простоя)
+// и ролей экземпляров при развертывании нескольких процессов
+type LeaseConfig struct {
+	Enabled        bool     `yaml:"enabled"`
+	InstanceID     string   `yaml:"instance_id"`      // ID экземпляра (по умолчанию hostname-pid)
+	TTL            int      `yaml:"ttl"`              // Срок аренды в секундах; продлевается каждые ttl/3
+	RequestHandoff bool     `yaml:"request_handoff"`  // При запуске запросить передачу аренды у работающего экземпляра
+	Roles          []string `yaml:"roles"`            // Роли экземпляра (Role*); пусто - все роли в одном процессе
+	StatusBatch    int      `yaml:"status_batch"`     // Хеджей, захватываемых для проверки статусов за цикл (0 - все)
+	StatusClaimTTL int      `yaml:"status_claim_ttl"` // Срок захвата хеджа для проверки статусов в секундах
+}
+
+// Роли экземпляра (lease.roles)
+const (
+	RoleExecutor      = "executor"       // Открывает хеджи под арендой ведущего экземпляра
+	RoleStatusChecker = "status-checker" // Проверяет статусы ордеров; хеджи распределяются между экземплярами
+	RoleWebUI         = "webui"          // Веб-интерфейс и API
+	RoleReporter      = "reporter"       // Итоги хеджирования и сверка балансов под отдельной арендой
+)
+
+// AllRoles роли экземпляра в порядке документации
+var AllRoles = []string{RoleExecutor, RoleStatusChecker, RoleWebUI, RoleReporter}
+
+// HasRole проверяет, что экземпляр выполняет роль (без lease.roles экземпляр выполняет все роли)
+func (l *LeaseConfig) HasRole(role string) bool {
+	if len(l.Roles) == 0 {
+		return true
+	}
+	for _, assigned := range l.Roles {
+		if strings.EqualFold(strings.TrimSpace(assigned), role) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvedRoles возвращает роли экземпляра (все роли, если lease.roles не задан)
+func (l *LeaseConfig) ResolvedRoles() []string {
+	var roles []string
+	for _, role := range AllRoles {
+		if l.HasRole(role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// StatusClaimDuration возвращает срок захвата хеджа для проверки статусов
+func (l *LeaseConfig) StatusClaimDuration() time.Duration {
+	return time.Duration(l.StatusClaimTTL) * time.Second
+}
+
+// HistoryConfig конфигурация импорта истории ордеров биржевого аккаунта
+type HistoryConfig struct {
+	ImportEnabled bool     `yaml:"import_enabled"` // При первом запуске импортировать ордера, размещенные до бота
+	Pairs         []string `yaml:"pairs"`          // Пары для импорта (например, SOL/USDT)
+	Days          int      `yaml:"days"`           // Глубина импорта в днях (Bybit хранит не более 2 лет)
+}
+
+// ResolvedInstanceID возвращает ID экземпляра: заданный в конфигурации или hostname-pid
+func (l *LeaseConfig) ResolvedInstanceID() string {
+	if id := strings.TrimSpace(l.InstanceID); id != "" {
+		return id
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "trade-hedge"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// RenewInterval возвращает периодичность продления аренды
+func (l *LeaseConfig) RenewInterval() time.Duration {
+	return time.Duration(l.TTL) * time.Second / 3
+}
+
+// ParseTime возвращает час и минуту закрытия
+func (f *FlatConfig) ParseTime() (int, int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(f.Time))
+	if err != nil {
+		return 0, 0, err
+	}
+	return t.Hour(), t.Minute(), nil
 }
 
-// LoadConfig загружает конфигурацию из YAML файла с поддержкой переменных окружения
+// OperatingMode режим работы приложения в зависимости от заполненности конфигурации
+type OperatingMode string
+
+const (
+	// ModeFull полноценная работа: отслеживание сделок, размещение ордеров, хранение в БД
+	ModeFull OperatingMode = "full"
+	// ModeMonitorOnly ключи Bybit не заданы: сделки отслеживаются и сохраняются, ордера не размещаются
+	ModeMonitorOnly OperatingMode = "monitor-only"
+	// ModeDryRun БД не настроена: работа без сохранения состояния, ордера не размещаются
+	ModeDryRun OperatingMode = "dry-run"
+)
+
+// Драйверы хранилища (database.driver)
+const (
+	DatabaseDriverPostgres = "postgres"
+	DatabaseDriverSQLite   = "sqlite"
+)
+
+// configJSONEnv переменная окружения с полной конфигурацией в виде JSON
+const configJSONEnv = "CONFIG_JSON"
+
+// LoadConfig загружает конфигурацию из YAML или JSON файла (по расширению .json) с поддержкой
+// переменных окружения. Порядок: значения по умолчанию, файл, JSON из CONFIG_JSON, отдельные переменные окружения
 func LoadConfig(path string) (*Config, error) {
 	config := &Config{}
 
@@ -77,6 +670,13 @@ func LoadConfig(path string) (*Config, error) {
 		}
 	}
 
+	// Конфигурация одним JSON-блоком из окружения (поверх файла)
+	if blob := strings.TrimSpace(os.Getenv(configJSONEnv)); blob != "" {
+		if err := config.loadFromJSON([]byte(blob)); err != nil {
+			return nil, fmt.Errorf("ошибка загрузки из %s: %w", configJSONEnv, err)
+		}
+	}
+
 	// Переопределяем переменными окружения
 	config.loadFromEnv()
 
@@ -90,27 +690,162 @@ func LoadConfig(path string) (*Config, error) {
 
 // setDefaults устанавливает значения по умолчанию
 func (c *Config) setDefaults() {
+	c.Database.Driver = DatabaseDriverPostgres
+	c.Database.Path = "trade_hedge.db"
 	c.Database.Host = "localhost"
 	c.Database.Port = 5432
 	c.Database.User = "postgres"
 	c.Database.DBName = "trade_hedge"
 	c.Database.SSLMode = "disable"
+	c.Database.MaxConns = 10
+	c.Database.MinConns = 0
+	c.Database.MaxConnLifetime = 3600
+	c.Database.MaxConnIdleTime = 1800
+	c.Database.HealthCheckPeriod = 60
+	c.Database.ConnectRetries = 10
+	c.Database.ConnectRetryDelay = 1
+	c.Database.ConnectRetryMaxDelay = 30
+
+	c.Bybit.CancelURL = "https://api.bybit.com/v5/order/cancel"
+	c.Bybit.OrderHistoryURL = "https://api.bybit.com/v5/order/history"
+	c.Bybit.ConvertQuoteURL = "https://api.bybit.com/v5/asset/exchange/quote-apply"
+	c.Bybit.ConvertExecuteURL = "https://api.bybit.com/v5/asset/exchange/convert-execute"
+	c.Bybit.ConvertResultURL = "https://api.bybit.com/v5/asset/exchange/convert-result-query"
+	c.Bybit.RecvWindow = 5000
+	c.Bybit.RetryOnTimestampError = true
+	c.Bybit.TimestampRetries = 1
+	c.Bybit.SendSignType = true
 
 	c.Strategy.PositionAmount = 50.0
 	c.Strategy.MaxLossPercent = 3.0
 	c.Strategy.ProfitRatio = 0.7
 	c.Strategy.BaseCurrency = "USDT"
 	c.Strategy.CheckInterval = 300
+	c.Strategy.ScheduleTimezone = "UTC"
 	c.Strategy.RetryAttempts = 3
 	c.Strategy.RetryDelay = 2
+	c.Strategy.EarlyStatusChecks = []int{10, 60}
+	c.Strategy.BuyFillTimeout = 30
+	c.Strategy.BuyFillPollInterval = 1
+	c.Strategy.LeaveBuyPending = false
+	c.Strategy.Name = "classic"
+	c.Strategy.MartingaleMultiplier = 2.0
+	c.Strategy.MartingaleMaxSteps = 3
+	c.Strategy.MinTakeProfitTicks = 1
+	c.Strategy.MinTakeProfitPercent = 0.0
+	c.Strategy.MaxPriceDeviationPercent = 1.0
+	c.Strategy.BuyPriceOffsetPercent = 0.1
+	c.Strategy.SlippageBufferPercent = 1.0
+	c.Strategy.RespectPairLocks = true
+	c.Strategy.QuoteConversion = true
+	c.Strategy.MaxParallelHedges = 1
+	c.Strategy.BuyPriceRounding = "ceil"
+	c.Strategy.SellPriceRounding = "ceil"
+	c.Strategy.QuantityRounding = "floor"
+	c.Strategy.StopLossPercent = 0.0
+	c.Strategy.BuyFallback = "none"
+	c.Strategy.PassiveEntryTimeout = 0
+	c.Strategy.MinLosingTrades = 0
+	c.Strategy.MinPortfolioLoss = 0.0
+	c.Strategy.Priority = "drawdown"
+	c.Strategy.MaxSpreadPercent = 0.0
+	c.Strategy.MaxVolatilityPercent = 0.0
+	c.Strategy.VolatilityWindowHours = 24
+	c.Strategy.CloseSlippagePercent = 0.0
+	c.Strategy.CloseRepriceInterval = 5
+	c.Strategy.CloseRepriceAttempts = 3
+	c.Strategy.SplitMaxLegs = 5
+	c.Strategy.SplitMaxLegAmount = 0.0
+	c.Strategy.SplitLegInterval = 60
+	c.Strategy.SplitDepthPercent = 0.0
+	c.Strategy.SplitDepthSharePercent = 50.0
+
+	c.HTTP.MaxIdleConns = 100
+	c.HTTP.MaxIdleConnsPerHost = 10
+	c.HTTP.MaxConnsPerHost = 0
+	c.HTTP.IdleConnTimeout = 90
+	c.HTTP.KeepAlive = 30
+	c.HTTP.TLSHandshakeTimeout = 10
+	c.HTTP.TLSSessionCacheSize = 64
+	c.HTTP.RequestTimeout = 30
+
+	c.Rebalance.Enabled = false
+	c.Rebalance.Interval = 86400
+	c.Rebalance.MinProfit = 10.0
+
+	c.Flat.Enabled = false
+	c.Flat.Time = "23:45"
+	c.Flat.OnlyProfitable = false
+
+	c.Watchdog.Enabled = true
+	c.Watchdog.StallThreshold = 30
+	c.Watchdog.ExitOnStall = false
+
+	c.Alerts.EscalateAfter = 5
+	c.Alerts.RepeatInterval = 60
+	c.Alerts.StuckHedgeAfter = 15
+
+	c.Lease.Enabled = false
+	c.Lease.TTL = 60
+	c.Lease.RequestHandoff = true
+	c.Lease.StatusBatch = 100
+	c.Lease.StatusClaimTTL = 900
+
+	c.History.ImportEnabled = false
+	c.History.Days = 90
+
+	c.Balance.Enabled = false
+	c.Balance.Interval = 900
+	c.Balance.TolerancePercent = 2.0
+
+	c.Archive.Enabled = false
+	c.Archive.RetentionDays = 90
+	c.Archive.Interval = 86400
+
+	c.Reports.Enabled = false
+	c.Reports.DailySchedule = "0 9 * * *"
+	c.Reports.WeeklySchedule = "0 9 * * 1"
+
+	c.Heartbeat.Timeout = 10
+
+	c.Tracing.Enabled = false
+	c.Tracing.Endpoint = "http://localhost:4318"
+	c.Tracing.ServiceName = "trade-hedge"
+	c.Tracing.SampleRatio = 1.0
+
+	c.Signals.Enabled = false
+	c.Signals.Redis.Key = "trade-hedge:signals"
+	c.Signals.Redis.Batch = 10
+
+	c.Metrics.Interval = 60
+	c.Metrics.QueryTimeout = 5
+
+	c.Notifications.Webhook.Timeout = 10
+	c.Notifications.Webhook.MaxRetries = 3
+	c.Notifications.Webhook.RetryDelay = 5
+	c.Notifications.Email.Port = 587
+	c.Notifications.Email.Throttle = 30
+	c.Notifications.Email.MaxPerHour = 10
 
 	c.WebUI.Enabled = false
 	c.WebUI.Host = "localhost"
 	c.WebUI.Port = 8081
+	c.WebUI.LogBufferLines = 2000
+	c.WebUI.Language = WebUILanguageRU
+	c.WebUI.Auth.Mode = WebUIAuthNone
+	c.WebUI.Auth.SessionTTL = 720
 }
 
-// loadFromFile загружает конфигурацию из YAML файла
+// loadFromFile загружает конфигурацию из YAML или JSON файла
 func (c *Config) loadFromFile(path string) error {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("ошибка чтения файла: %w", err)
+		}
+		return c.loadFromJSON(data)
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
 		return fmt.Errorf("ошибка открытия файла: %w", err)
@@ -125,12 +860,70 @@ func (c *Config) loadFromFile(path string) error {
 	return nil
 }
 
+// loadFromJSON загружает конфигурацию из JSON. Ключи совпадают с YAML: документ разбирается
+// как JSON (с его синтаксисом и ошибками) и применяется через те же yaml-теги, что и YAML файл
+func (c *Config) loadFromJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document map[string]interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return fmt.Errorf("ошибка парсинга JSON: %w", err)
+	}
+
+	converted, err := yaml.Marshal(normalizeJSONNumbers(document))
+	if err != nil {
+		return fmt.Errorf("ошибка преобразования JSON: %w", err)
+	}
+	if err := yaml.Unmarshal(converted, c); err != nil {
+		return fmt.Errorf("ошибка применения JSON: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeJSONNumbers заменяет json.Number на int64 или float64, чтобы целые числа
+// (например, 1000000) не превращались в float с экспонентой при преобразовании в YAML
+func normalizeJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeJSONNumbers(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeJSONNumbers(item)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	default:
+		return v
+	}
+}
+
 // loadFromEnv загружает настройки из переменных окружения
 func (c *Config) loadFromEnv() {
 	// Freqtrade
 	if v := os.Getenv("FREQTRADE_API_URL"); v != "" {
 		c.Freqtrade.APIURL = v
 	}
+	if v := os.Getenv("FREQTRADE_LOCKS_URL"); v != "" {
+		c.Freqtrade.LocksURL = v
+	}
+	if v := os.Getenv("FREQTRADE_TRADES_URL"); v != "" {
+		c.Freqtrade.TradesURL = v
+	}
+	if v := os.Getenv("FREQTRADE_WHITELIST_URL"); v != "" {
+		c.Freqtrade.WhitelistURL = v
+	}
 	if v := os.Getenv("FREQTRADE_USERNAME"); v != "" {
 		c.Freqtrade.Username = v
 	}
@@ -155,7 +948,45 @@ func (c *Config) loadFromEnv() {
 		c.Bybit.OrderStatusURL = v
 	}
 
+	if v := os.Getenv("BYBIT_CANCEL_URL"); v != "" {
+		c.Bybit.CancelURL = v
+	}
+	if v := os.Getenv("BYBIT_ORDER_HISTORY_URL"); v != "" {
+		c.Bybit.OrderHistoryURL = v
+	}
+	if v := os.Getenv("BYBIT_CONVERT_QUOTE_URL"); v != "" {
+		c.Bybit.ConvertQuoteURL = v
+	}
+	if v := os.Getenv("BYBIT_CONVERT_EXECUTE_URL"); v != "" {
+		c.Bybit.ConvertExecuteURL = v
+	}
+	if v := os.Getenv("BYBIT_CONVERT_RESULT_URL"); v != "" {
+		c.Bybit.ConvertResultURL = v
+	}
+	if v := os.Getenv("BYBIT_RECV_WINDOW"); v != "" {
+		if window, err := strconv.Atoi(v); err == nil {
+			c.Bybit.RecvWindow = window
+		}
+	}
+	if v := os.Getenv("BYBIT_RETRY_ON_TIMESTAMP_ERROR"); v != "" {
+		c.Bybit.RetryOnTimestampError = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("BYBIT_TIMESTAMP_RETRIES"); v != "" {
+		if retries, err := strconv.Atoi(v); err == nil {
+			c.Bybit.TimestampRetries = retries
+		}
+	}
+	if v := os.Getenv("BYBIT_SEND_SIGN_TYPE"); v != "" {
+		c.Bybit.SendSignType = strings.ToLower(v) == "true"
+	}
+
 	// Database
+	if v := os.Getenv("DB_DRIVER"); v != "" {
+		c.Database.Driver = v
+	}
+	if v := os.Getenv("DB_PATH"); v != "" {
+		c.Database.Path = v
+	}
 	if v := os.Getenv("DB_HOST"); v != "" {
 		c.Database.Host = v
 	}
@@ -176,6 +1007,46 @@ func (c *Config) loadFromEnv() {
 	if v := os.Getenv("DB_SSL_MODE"); v != "" {
 		c.Database.SSLMode = v
 	}
+	if v := os.Getenv("DB_MAX_CONNS"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Database.MaxConns = value
+		}
+	}
+	if v := os.Getenv("DB_MIN_CONNS"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Database.MinConns = value
+		}
+	}
+	if v := os.Getenv("DB_MAX_CONN_LIFETIME"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Database.MaxConnLifetime = value
+		}
+	}
+	if v := os.Getenv("DB_MAX_CONN_IDLE_TIME"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Database.MaxConnIdleTime = value
+		}
+	}
+	if v := os.Getenv("DB_HEALTH_CHECK_PERIOD"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Database.HealthCheckPeriod = value
+		}
+	}
+	if v := os.Getenv("DB_CONNECT_RETRIES"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Database.ConnectRetries = value
+		}
+	}
+	if v := os.Getenv("DB_CONNECT_RETRY_DELAY"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Database.ConnectRetryDelay = value
+		}
+	}
+	if v := os.Getenv("DB_CONNECT_RETRY_MAX_DELAY"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Database.ConnectRetryMaxDelay = value
+		}
+	}
 
 	// Strategy
 	if v := os.Getenv("STRATEGY_POSITION_AMOUNT"); v != "" {
@@ -201,6 +1072,20 @@ func (c *Config) loadFromEnv() {
 			c.Strategy.CheckInterval = interval
 		}
 	}
+	if v := os.Getenv("STRATEGY_SCHEDULE"); v != "" {
+		c.Strategy.Schedule = v
+	}
+	if v := os.Getenv("STRATEGY_QUIET_HOURS"); v != "" {
+		c.Strategy.QuietHours = parseList(v)
+	}
+	if v := os.Getenv("STRATEGY_SCHEDULE_TIMEZONE"); v != "" {
+		c.Strategy.ScheduleTimezone = v
+	}
+	if v := os.Getenv("STRATEGY_CYCLE_TIMEOUT"); v != "" {
+		if timeout, err := strconv.Atoi(v); err == nil {
+			c.Strategy.CycleTimeout = timeout
+		}
+	}
 	if v := os.Getenv("STRATEGY_RETRY_ATTEMPTS"); v != "" {
 		if attempts, err := strconv.Atoi(v); err == nil {
 			c.Strategy.RetryAttempts = attempts
@@ -211,75 +1096,786 @@ func (c *Config) loadFromEnv() {
 			c.Strategy.RetryDelay = delay
 		}
 	}
-
-	// WebUI
-	if v := os.Getenv("WEBUI_ENABLED"); v != "" {
-		c.WebUI.Enabled = strings.ToLower(v) == "true"
+	if v := os.Getenv("STRATEGY_EARLY_STATUS_CHECKS"); v != "" {
+		if delays, err := parseIntList(v); err == nil {
+			c.Strategy.EarlyStatusChecks = delays
+		}
 	}
-	if v := os.Getenv("WEBUI_HOST"); v != "" {
-		c.WebUI.Host = v
+	if v := os.Getenv("STRATEGY_BUY_FILL_TIMEOUT"); v != "" {
+		if timeout, err := strconv.Atoi(v); err == nil {
+			c.Strategy.BuyFillTimeout = timeout
+		}
 	}
-	if v := os.Getenv("WEBUI_PORT"); v != "" {
-		if port, err := strconv.Atoi(v); err == nil {
-			c.WebUI.Port = port
+	if v := os.Getenv("STRATEGY_BUY_FILL_POLL_INTERVAL"); v != "" {
+		if interval, err := strconv.Atoi(v); err == nil {
+			c.Strategy.BuyFillPollInterval = interval
 		}
 	}
-}
-
-// Validate проверяет корректность конфигурации
-func (c *Config) Validate() error {
-	// Валидация Freqtrade
-	if strings.TrimSpace(c.Freqtrade.APIURL) == "" {
-		return fmt.Errorf("freqtrade.api_url не может быть пустым")
+	if v := os.Getenv("STRATEGY_LEAVE_BUY_PENDING"); v != "" {
+		c.Strategy.LeaveBuyPending = strings.ToLower(v) == "true"
 	}
-	if _, err := url.Parse(c.Freqtrade.APIURL); err != nil {
-		return fmt.Errorf("freqtrade.api_url содержит некорректный URL: %w", err)
+	if v := os.Getenv("STRATEGY_NAME"); v != "" {
+		c.Strategy.Name = v
 	}
-	if strings.TrimSpace(c.Freqtrade.Username) == "" {
-		return fmt.Errorf("freqtrade.username не может быть пустым")
+	if v := os.Getenv("STRATEGY_MARTINGALE_MULTIPLIER"); v != "" {
+		if multiplier, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.MartingaleMultiplier = multiplier
+		}
 	}
-	if strings.TrimSpace(c.Freqtrade.Password) == "" {
-		return fmt.Errorf("freqtrade.password не может быть пустым")
+	if v := os.Getenv("STRATEGY_MARTINGALE_MAX_STEPS"); v != "" {
+		if steps, err := strconv.Atoi(v); err == nil {
+			c.Strategy.MartingaleMaxSteps = steps
+		}
+	}
+	if v := os.Getenv("STRATEGY_MIN_TAKE_PROFIT_TICKS"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Strategy.MinTakeProfitTicks = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_MIN_TAKE_PROFIT_PERCENT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.MinTakeProfitPercent = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_MAX_PRICE_DEVIATION_PERCENT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.MaxPriceDeviationPercent = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_BUY_PRICE_OFFSET_PERCENT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.BuyPriceOffsetPercent = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_SLIPPAGE_BUFFER_PERCENT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.SlippageBufferPercent = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_RESPECT_PAIR_LOCKS"); v != "" {
+		c.Strategy.RespectPairLocks = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("STRATEGY_QUOTE_CONVERSION"); v != "" {
+		c.Strategy.QuoteConversion = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("STRATEGY_MAX_PARALLEL_HEDGES"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Strategy.MaxParallelHedges = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_BUY_PRICE_ROUNDING"); v != "" {
+		c.Strategy.BuyPriceRounding = v
+	}
+	if v := os.Getenv("STRATEGY_SELL_PRICE_ROUNDING"); v != "" {
+		c.Strategy.SellPriceRounding = v
+	}
+	if v := os.Getenv("STRATEGY_QUANTITY_ROUNDING"); v != "" {
+		c.Strategy.QuantityRounding = v
+	}
+	if v := os.Getenv("STRATEGY_STOP_LOSS_PERCENT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.StopLossPercent = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_BUY_FALLBACK"); v != "" {
+		c.Strategy.BuyFallback = v
+	}
+	if v := os.Getenv("STRATEGY_PASSIVE_ENTRY_TIMEOUT"); v != "" {
+		if timeout, err := strconv.Atoi(v); err == nil {
+			c.Strategy.PassiveEntryTimeout = timeout
+		}
+	}
+	if v := os.Getenv("STRATEGY_CONVERT_PAIRS"); v != "" {
+		c.Strategy.ConvertPairs = parseList(v)
+	}
+	if v := os.Getenv("STRATEGY_PRIORITY"); v != "" {
+		c.Strategy.Priority = v
+	}
+	if v := os.Getenv("STRATEGY_PRIORITY_PAIRS"); v != "" {
+		c.Strategy.PriorityPairs = parseList(v)
+	}
+	if v := os.Getenv("STRATEGY_PRIORITY_TIEBREAKERS"); v != "" {
+		c.Strategy.PriorityTiebreakers = parseList(v)
+	}
+	if v := os.Getenv("STRATEGY_FILTERS"); v != "" {
+		c.Strategy.Filters = parseList(v)
+	}
+	if v := os.Getenv("STRATEGY_BLACKLIST_PAIRS"); v != "" {
+		c.Strategy.BlacklistPairs = parseList(v)
+	}
+	if v := os.Getenv("STRATEGY_MAX_SPREAD_PERCENT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.MaxSpreadPercent = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_MAX_VOLATILITY_PERCENT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.MaxVolatilityPercent = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_VOLATILITY_WINDOW_HOURS"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Strategy.VolatilityWindowHours = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_MIN_LOSING_TRADES"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Strategy.MinLosingTrades = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_MIN_PORTFOLIO_LOSS"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.MinPortfolioLoss = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_CLOSE_SLIPPAGE_PERCENT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.CloseSlippagePercent = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_CLOSE_REPRICE_INTERVAL"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Strategy.CloseRepriceInterval = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_CLOSE_REPRICE_ATTEMPTS"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Strategy.CloseRepriceAttempts = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_SPLIT_MAX_LEGS"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Strategy.SplitMaxLegs = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_SPLIT_MAX_LEG_AMOUNT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.SplitMaxLegAmount = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_SPLIT_LEG_INTERVAL"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.Strategy.SplitLegInterval = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_SPLIT_DEPTH_PERCENT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.SplitDepthPercent = value
+		}
+	}
+	if v := os.Getenv("STRATEGY_SPLIT_DEPTH_SHARE_PERCENT"); v != "" {
+		if value, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Strategy.SplitDepthSharePercent = value
+		}
+	}
+
+	// Risk
+	if v := os.Getenv("RISK_MAX_OPEN_NOTIONAL"); v != "" {
+		if notional, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Risk.MaxOpenNotional = notional
+		}
+	}
+	if v := os.Getenv("RISK_MAX_CONCURRENT_HEDGES"); v != "" {
+		if hedges, err := strconv.Atoi(v); err == nil {
+			c.Risk.MaxConcurrentHedges = hedges
+		}
+	}
+	if v := os.Getenv("RISK_DAILY_BUDGET"); v != "" {
+		if budget, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Risk.DailyBudget = budget
+		}
+	}
+	if v := os.Getenv("RISK_MAX_HEDGES_PER_PAIR"); v != "" {
+		if hedges, err := strconv.Atoi(v); err == nil {
+			c.Risk.MaxHedgesPerPair = hedges
+		}
+	}
+	if v := os.Getenv("RISK_PER_QUOTE"); v != "" {
+		if limits, err := parseQuoteRiskLimits(v); err == nil {
+			c.Risk.PerQuote = limits
+		}
+	}
+
+	// HTTP
+	if v := os.Getenv("HTTP_MAX_IDLE_CONNS"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.HTTP.MaxIdleConns = value
+		}
+	}
+	if v := os.Getenv("HTTP_MAX_IDLE_CONNS_PER_HOST"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.HTTP.MaxIdleConnsPerHost = value
+		}
+	}
+	if v := os.Getenv("HTTP_MAX_CONNS_PER_HOST"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.HTTP.MaxConnsPerHost = value
+		}
+	}
+	if v := os.Getenv("HTTP_IDLE_CONN_TIMEOUT"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.HTTP.IdleConnTimeout = value
+		}
+	}
+	if v := os.Getenv("HTTP_KEEP_ALIVE"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.HTTP.KeepAlive = value
+		}
+	}
+	if v := os.Getenv("HTTP_TLS_HANDSHAKE_TIMEOUT"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.HTTP.TLSHandshakeTimeout = value
+		}
+	}
+	if v := os.Getenv("HTTP_TLS_SESSION_CACHE_SIZE"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.HTTP.TLSSessionCacheSize = value
+		}
+	}
+	if v := os.Getenv("HTTP_REQUEST_TIMEOUT"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil {
+			c.HTTP.RequestTimeout = value
+		}
+	}
+
+	// Rebalance
+	if v := os.Getenv("REBALANCE_ENABLED"); v != "" {
+		c.Rebalance.Enabled = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("REBALANCE_INTERVAL"); v != "" {
+		if interval, err := strconv.Atoi(v); err == nil {
+			c.Rebalance.Interval = interval
+		}
+	}
+	if v := os.Getenv("REBALANCE_MIN_PROFIT"); v != "" {
+		if minProfit, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Rebalance.MinProfit = minProfit
+		}
+	}
+	if v := os.Getenv("REBALANCE_ALLOCATIONS"); v != "" {
+		if allocations, err := parseAllocations(v); err == nil {
+			c.Rebalance.Allocations = allocations
+		}
+	}
+
+	// Flat
+	if v := os.Getenv("FLAT_ENABLED"); v != "" {
+		c.Flat.Enabled = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("FLAT_TIME"); v != "" {
+		c.Flat.Time = v
+	}
+	if v := os.Getenv("FLAT_ONLY_PROFITABLE"); v != "" {
+		c.Flat.OnlyProfitable = strings.ToLower(v) == "true"
+	}
+
+	// Watchdog
+	if v := os.Getenv("WATCHDOG_ENABLED"); v != "" {
+		c.Watchdog.Enabled = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("WATCHDOG_STALL_THRESHOLD"); v != "" {
+		if minutes, err := strconv.Atoi(v); err == nil {
+			c.Watchdog.StallThreshold = minutes
+		}
+	}
+	if v := os.Getenv("WATCHDOG_EXIT_ON_STALL"); v != "" {
+		c.Watchdog.ExitOnStall = strings.ToLower(v) == "true"
+	}
+
+	// Alerts
+	if v := os.Getenv("ALERTS_ESCALATE_AFTER"); v != "" {
+		if count, err := strconv.Atoi(v); err == nil {
+			c.Alerts.EscalateAfter = count
+		}
+	}
+	if v := os.Getenv("ALERTS_STUCK_HEDGE_AFTER"); v != "" {
+		if minutes, err := strconv.Atoi(v); err == nil {
+			c.Alerts.StuckHedgeAfter = minutes
+		}
+	}
+	if v := os.Getenv("ALERTS_REPEAT_INTERVAL"); v != "" {
+		if minutes, err := strconv.Atoi(v); err == nil {
+			c.Alerts.RepeatInterval = minutes
+		}
+	}
+
+	// Lease
+	if v := os.Getenv("LEASE_ENABLED"); v != "" {
+		c.Lease.Enabled = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("LEASE_INSTANCE_ID"); v != "" {
+		c.Lease.InstanceID = v
+	}
+	if v := os.Getenv("LEASE_TTL"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			c.Lease.TTL = seconds
+		}
+	}
+	if v := os.Getenv("LEASE_REQUEST_HANDOFF"); v != "" {
+		c.Lease.RequestHandoff = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("LEASE_ROLES"); v != "" {
+		c.Lease.Roles = parseList(v)
+	}
+	if v := os.Getenv("LEASE_STATUS_BATCH"); v != "" {
+		if batch, err := strconv.Atoi(v); err == nil {
+			c.Lease.StatusBatch = batch
+		}
+	}
+	if v := os.Getenv("LEASE_STATUS_CLAIM_TTL"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			c.Lease.StatusClaimTTL = seconds
+		}
+	}
+
+	// History
+	if v := os.Getenv("HISTORY_IMPORT_ENABLED"); v != "" {
+		c.History.ImportEnabled = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("HISTORY_PAIRS"); v != "" {
+		c.History.Pairs = parseList(v)
+	}
+	if v := os.Getenv("HISTORY_DAYS"); v != "" {
+		if days, err := strconv.Atoi(v); err == nil {
+			c.History.Days = days
+		}
+	}
+
+	// Balance check
+	if v := os.Getenv("BALANCE_CHECK_ENABLED"); v != "" {
+		c.Balance.Enabled = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("BALANCE_CHECK_INTERVAL"); v != "" {
+		if interval, err := strconv.Atoi(v); err == nil {
+			c.Balance.Interval = interval
+		}
+	}
+	if v := os.Getenv("BALANCE_CHECK_TOLERANCE_PERCENT"); v != "" {
+		if tolerance, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Balance.TolerancePercent = tolerance
+		}
+	}
+
+	// Archive
+	if v := os.Getenv("ARCHIVE_ENABLED"); v != "" {
+		c.Archive.Enabled = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("ARCHIVE_RETENTION_DAYS"); v != "" {
+		if days, err := strconv.Atoi(v); err == nil {
+			c.Archive.RetentionDays = days
+		}
+	}
+	if v := os.Getenv("ARCHIVE_INTERVAL"); v != "" {
+		if interval, err := strconv.Atoi(v); err == nil {
+			c.Archive.Interval = interval
+		}
+	}
+
+	// Reports
+	if v := os.Getenv("REPORTS_ENABLED"); v != "" {
+		c.Reports.Enabled = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("REPORTS_DAILY_SCHEDULE"); v != "" {
+		c.Reports.DailySchedule = v
+	}
+	if v := os.Getenv("REPORTS_WEEKLY_SCHEDULE"); v != "" {
+		c.Reports.WeeklySchedule = v
+	}
+
+	// Heartbeat
+	if v := os.Getenv("HEARTBEAT_URL"); v != "" {
+		c.Heartbeat.URL = v
+	}
+	if v := os.Getenv("HEARTBEAT_FILE"); v != "" {
+		c.Heartbeat.File = v
+	}
+	if v := os.Getenv("HEARTBEAT_TIMEOUT"); v != "" {
+		if timeout, err := strconv.Atoi(v); err == nil {
+			c.Heartbeat.Timeout = timeout
+		}
 	}
 
-	// Валидация Bybit
-	if strings.TrimSpace(c.Bybit.APIKey) == "" {
-		return fmt.Errorf("bybit.api_key не может быть пустым")
+	// Tracing
+	if v := os.Getenv("TRACING_ENABLED"); v != "" {
+		c.Tracing.Enabled = strings.ToLower(v) == "true"
 	}
-	if strings.TrimSpace(c.Bybit.APISecret) == "" {
-		return fmt.Errorf("bybit.api_secret не может быть пустым")
+	// Стандартная переменная OpenTelemetry, если адрес не задан явно
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); v != "" {
+		c.Tracing.Endpoint = v
+	}
+	if v := os.Getenv("TRACING_ENDPOINT"); v != "" {
+		c.Tracing.Endpoint = v
+	}
+	if v := os.Getenv("TRACING_SERVICE_NAME"); v != "" {
+		c.Tracing.ServiceName = v
+	}
+	if v := os.Getenv("TRACING_SAMPLE_RATIO"); v != "" {
+		if ratio, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Tracing.SampleRatio = ratio
+		}
 	}
 
-	urls := map[string]string{
-		"bybit.spot_url":         c.Bybit.SpotURL,
-		"bybit.balance_url":      c.Bybit.BalanceURL,
-		"bybit.order_status_url": c.Bybit.OrderStatusURL,
+	// Notifications
+	if v := os.Getenv("NOTIFY_WEBHOOK_URLS"); v != "" {
+		c.Notifications.Webhook.URLs = parseList(v)
+	}
+	if v := os.Getenv("NOTIFY_WEBHOOK_SECRET"); v != "" {
+		c.Notifications.Webhook.Secret = v
+	}
+	if v := os.Getenv("NOTIFY_WEBHOOK_EVENTS"); v != "" {
+		c.Notifications.Webhook.Events = parseList(v)
+	}
+	if v := os.Getenv("NOTIFY_WEBHOOK_TIMEOUT"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			c.Notifications.Webhook.Timeout = seconds
+		}
+	}
+	if v := os.Getenv("NOTIFY_WEBHOOK_MAX_RETRIES"); v != "" {
+		if retries, err := strconv.Atoi(v); err == nil {
+			c.Notifications.Webhook.MaxRetries = retries
+		}
+	}
+	if v := os.Getenv("NOTIFY_EMAIL_HOST"); v != "" {
+		c.Notifications.Email.Host = v
+	}
+	if v := os.Getenv("NOTIFY_EMAIL_PORT"); v != "" {
+		if port, err := strconv.Atoi(v); err == nil {
+			c.Notifications.Email.Port = port
+		}
+	}
+	if v := os.Getenv("NOTIFY_EMAIL_USERNAME"); v != "" {
+		c.Notifications.Email.Username = v
+	}
+	if v := os.Getenv("NOTIFY_EMAIL_PASSWORD"); v != "" {
+		c.Notifications.Email.Password = v
+	}
+	if v := os.Getenv("NOTIFY_EMAIL_FROM"); v != "" {
+		c.Notifications.Email.From = v
+	}
+	if v := os.Getenv("NOTIFY_EMAIL_TO"); v != "" {
+		c.Notifications.Email.To = parseList(v)
+	}
+	if v := os.Getenv("NOTIFY_EMAIL_IMPLICIT_TLS"); v != "" {
+		c.Notifications.Email.ImplicitTLS = strings.ToLower(v) == "true"
+	}
+
+	// Signals
+	if v := os.Getenv("SIGNALS_ENABLED"); v != "" {
+		c.Signals.Enabled = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("SIGNALS_MAX_AMOUNT"); v != "" {
+		if amount, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Signals.MaxAmount = amount
+		}
+	}
+	if v := os.Getenv("SIGNALS_WEBHOOK"); v != "" {
+		c.Signals.Webhook = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("SIGNALS_WEBHOOK_SECRET"); v != "" {
+		c.Signals.WebhookSecret = v
+	}
+	if v := os.Getenv("SIGNALS_DIR"); v != "" {
+		c.Signals.Dir = v
+	}
+	if v := os.Getenv("SIGNALS_REDIS_ADDR"); v != "" {
+		c.Signals.Redis.Addr = v
+	}
+	if v := os.Getenv("SIGNALS_REDIS_PASSWORD"); v != "" {
+		c.Signals.Redis.Password = v
+	}
+	if v := os.Getenv("SIGNALS_REDIS_DB"); v != "" {
+		if db, err := strconv.Atoi(v); err == nil {
+			c.Signals.Redis.DB = db
+		}
+	}
+	if v := os.Getenv("SIGNALS_REDIS_KEY"); v != "" {
+		c.Signals.Redis.Key = v
 	}
 
-	for name, urlStr := range urls {
-		if strings.TrimSpace(urlStr) == "" {
-			return fmt.Errorf("%s не может быть пустым", name)
+	// Metrics
+	if v := os.Getenv("METRICS_INTERVAL"); v != "" {
+		if interval, err := strconv.Atoi(v); err == nil {
+			c.Metrics.Interval = interval
 		}
-		if _, err := url.Parse(urlStr); err != nil {
-			return fmt.Errorf("%s содержит некорректный URL: %w", name, err)
+	}
+	if v := os.Getenv("METRICS_QUERY_TIMEOUT"); v != "" {
+		if timeout, err := strconv.Atoi(v); err == nil {
+			c.Metrics.QueryTimeout = timeout
 		}
 	}
 
-	// Валидация Database
-	if strings.TrimSpace(c.Database.Host) == "" {
-		return fmt.Errorf("database.host не может быть пустым")
+	// Features
+	if v := os.Getenv("FEATURES"); v != "" {
+		if features, err := parseFeatures(v); err == nil {
+			c.Features = features
+		}
+	}
+
+	// WebUI
+	if v := os.Getenv("WEBUI_ENABLED"); v != "" {
+		c.WebUI.Enabled = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("WEBUI_HOST"); v != "" {
+		c.WebUI.Host = v
+	}
+	if v := os.Getenv("WEBUI_API_ONLY"); v != "" {
+		c.WebUI.APIOnly = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("WEBUI_PORT"); v != "" {
+		if port, err := strconv.Atoi(v); err == nil {
+			c.WebUI.Port = port
+		}
+	}
+	if v := os.Getenv("WEBUI_LOG_BUFFER_LINES"); v != "" {
+		if lines, err := strconv.Atoi(v); err == nil {
+			c.WebUI.LogBufferLines = lines
+		}
+	}
+	if v := os.Getenv("WEBUI_LANGUAGE"); v != "" {
+		c.WebUI.Language = v
+	}
+	if v := os.Getenv("WEBUI_TLS_CERT"); v != "" {
+		c.WebUI.TLSCert = v
 	}
-	if c.Database.Port < 1 || c.Database.Port > 65535 {
-		return fmt.Errorf("database.port должен быть в диапазоне 1-65535, получен: %d", c.Database.Port)
+	if v := os.Getenv("WEBUI_TLS_KEY"); v != "" {
+		c.WebUI.TLSKey = v
 	}
-	if strings.TrimSpace(c.Database.User) == "" {
-		return fmt.Errorf("database.user не может быть пустым")
+	if v := os.Getenv("WEBUI_AUTH_MODE"); v != "" {
+		c.WebUI.Auth.Mode = strings.ToLower(strings.TrimSpace(v))
 	}
-	if strings.TrimSpace(c.Database.Password) == "" {
-		return fmt.Errorf("database.password не может быть пустым")
+	if v := os.Getenv("WEBUI_USERNAME"); v != "" {
+		c.WebUI.Auth.Username = v
 	}
-	if strings.TrimSpace(c.Database.DBName) == "" {
-		return fmt.Errorf("database.dbname не может быть пустым")
+	if v := os.Getenv("WEBUI_PASSWORD"); v != "" {
+		c.WebUI.Auth.Password = v
+	}
+	if v := os.Getenv("WEBUI_SESSION_TTL"); v != "" {
+		if ttl, err := strconv.Atoi(v); err == nil {
+			c.WebUI.Auth.SessionTTL = ttl
+		}
+	}
+	if v := os.Getenv("WEBUI_SECURE_COOKIE"); v != "" {
+		c.WebUI.Auth.SecureCookie = strings.ToLower(v) == "true"
+	}
+	if v := os.Getenv("WEBUI_API_TOKENS"); v != "" {
+		c.WebUI.Auth.APITokens = parseList(v)
+	}
+}
+
+// parseIntList разбирает список целых чисел, разделенных запятыми (например, "10,60")
+func parseIntList(value string) ([]int, error) {
+	var result []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// parseList разбирает список через запятую, пропуская пустые элементы
+func parseList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// parseAllocations разбирает распределение активов вида "BTC:20,ETH:10"
+func parseAllocations(value string) (map[string]float64, error) {
+	result := make(map[string]float64)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("некорректный элемент распределения: %s", part)
+		}
+		percent, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil {
+			return nil, err
+		}
+		result[strings.ToUpper(strings.TrimSpace(kv[0]))] = percent
+	}
+	return result, nil
+}
+
+// parseFeatures разбирает флаги возможностей вида "auto_close:false,stop_loss:true"
+func parseFeatures(value string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("некорректный элемент флагов: %s", part)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, err
+		}
+		result[strings.ToLower(strings.TrimSpace(kv[0]))] = enabled
+	}
+	return result, nil
+}
+
+// parseQuoteRiskLimits разбирает лимиты по котируемым валютам вида
+// "USDT:5:1000:500,BTC:2:0.05:0" (валюта:max_concurrent_hedges:max_open_notional:daily_budget)
+func parseQuoteRiskLimits(value string) (map[string]QuoteRiskConfig, error) {
+	result := make(map[string]QuoteRiskConfig)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		fields := strings.Split(part, ":")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("некорректный элемент лимитов по котируемой валюте: %s", part)
+		}
+		hedges, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+		if err != nil {
+			return nil, err
+		}
+		notional, err := strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
+		if err != nil {
+			return nil, err
+		}
+		budget, err := strconv.ParseFloat(strings.TrimSpace(fields[3]), 64)
+		if err != nil {
+			return nil, err
+		}
+		result[strings.ToUpper(strings.TrimSpace(fields[0]))] = QuoteRiskConfig{
+			MaxConcurrentHedges: hedges,
+			MaxOpenNotional:     notional,
+			DailyBudget:         budget,
+		}
+	}
+	return result, nil
+}
+
+// Validate проверяет корректность конфигурации
+func (c *Config) Validate() error {
+	// Валидация Freqtrade
+	if strings.TrimSpace(c.Freqtrade.APIURL) == "" {
+		return fmt.Errorf("freqtrade.api_url не может быть пустым")
+	}
+	if _, err := url.Parse(c.Freqtrade.APIURL); err != nil {
+		return fmt.Errorf("freqtrade.api_url содержит некорректный URL: %w", err)
+	}
+	if _, err := url.Parse(c.Freqtrade.LocksURL); err != nil {
+		return fmt.Errorf("freqtrade.locks_url содержит некорректный URL: %w", err)
+	}
+	if _, err := url.Parse(c.Freqtrade.TradesURL); err != nil {
+		return fmt.Errorf("freqtrade.trades_url содержит некорректный URL: %w", err)
+	}
+	if _, err := url.Parse(c.Freqtrade.WhitelistURL); err != nil {
+		return fmt.Errorf("freqtrade.whitelist_url содержит некорректный URL: %w", err)
+	}
+	if strings.TrimSpace(c.Freqtrade.Username) == "" {
+		return fmt.Errorf("freqtrade.username не может быть пустым")
+	}
+	if strings.TrimSpace(c.Freqtrade.Password) == "" {
+		return fmt.Errorf("freqtrade.password не может быть пустым")
+	}
+
+	// Валидация Bybit (ключи необязательны: без них приложение работает в режиме мониторинга)
+	hasKey := strings.TrimSpace(c.Bybit.APIKey) != ""
+	hasSecret := strings.TrimSpace(c.Bybit.APISecret) != ""
+	if hasKey && !hasSecret {
+		return fmt.Errorf("bybit.api_secret не может быть пустым, если указан bybit.api_key")
+	}
+	if hasSecret && !hasKey {
+		return fmt.Errorf("bybit.api_key не может быть пустым, если указан bybit.api_secret")
+	}
+
+	if c.IsExchangeConfigured() {
+		urls := map[string]string{
+			"bybit.spot_url":            c.Bybit.SpotURL,
+			"bybit.balance_url":         c.Bybit.BalanceURL,
+			"bybit.order_status_url":    c.Bybit.OrderStatusURL,
+			"bybit.cancel_url":          c.Bybit.CancelURL,
+			"bybit.order_history_url":   c.Bybit.OrderHistoryURL,
+			"bybit.convert_quote_url":   c.Bybit.ConvertQuoteURL,
+			"bybit.convert_execute_url": c.Bybit.ConvertExecuteURL,
+			"bybit.convert_result_url":  c.Bybit.ConvertResultURL,
+		}
+
+		for name, urlStr := range urls {
+			if strings.TrimSpace(urlStr) == "" {
+				return fmt.Errorf("%s не может быть пустым", name)
+			}
+			if _, err := url.Parse(urlStr); err != nil {
+				return fmt.Errorf("%s содержит некорректный URL: %w", name, err)
+			}
+		}
+	}
+
+	if c.Bybit.RecvWindow <= 0 || c.Bybit.RecvWindow > 60000 {
+		return fmt.Errorf("bybit.recv_window должен быть в диапазоне 1-60000 мс, получен: %d", c.Bybit.RecvWindow)
+	}
+	if c.Bybit.TimestampRetries < 0 {
+		return fmt.Errorf("bybit.timestamp_retries не может быть отрицательным, получен: %d", c.Bybit.TimestampRetries)
+	}
+
+	// Валидация Database (без пароля БД приложение работает без сохранения состояния)
+	switch c.Database.Driver {
+	case DatabaseDriverPostgres, DatabaseDriverSQLite:
+	default:
+		return fmt.Errorf("database.driver должен быть postgres или sqlite, получен: %q", c.Database.Driver)
+	}
+	if c.Database.Driver == DatabaseDriverSQLite {
+		if strings.TrimSpace(c.Database.Path) == "" {
+			return fmt.Errorf("database.path не может быть пустым при database.driver: sqlite")
+		}
+	} else if c.IsDatabaseConfigured() {
+		if strings.TrimSpace(c.Database.Host) == "" {
+			return fmt.Errorf("database.host не может быть пустым")
+		}
+		if c.Database.Port < 1 || c.Database.Port > 65535 {
+			return fmt.Errorf("database.port должен быть в диапазоне 1-65535, получен: %d", c.Database.Port)
+		}
+		if strings.TrimSpace(c.Database.User) == "" {
+			return fmt.Errorf("database.user не может быть пустым")
+		}
+		if strings.TrimSpace(c.Database.DBName) == "" {
+			return fmt.Errorf("database.dbname не может быть пустым")
+		}
+		if c.Database.MaxConns < 1 {
+			return fmt.Errorf("database.max_conns должен быть не меньше 1, получен: %d", c.Database.MaxConns)
+		}
+		if c.Database.MinConns < 0 || c.Database.MinConns > c.Database.MaxConns {
+			return fmt.Errorf("database.min_conns должен быть в диапазоне 0-%d (database.max_conns), получен: %d", c.Database.MaxConns, c.Database.MinConns)
+		}
+		for _, setting := range []struct {
+			key   string
+			value int
+		}{
+			{"max_conn_lifetime", c.Database.MaxConnLifetime},
+			{"max_conn_idle_time", c.Database.MaxConnIdleTime},
+			{"health_check_period", c.Database.HealthCheckPeriod},
+			{"connect_retry_delay", c.Database.ConnectRetryDelay},
+			{"connect_retry_max_delay", c.Database.ConnectRetryMaxDelay},
+		} {
+			if setting.value <= 0 {
+				return fmt.Errorf("database.%s должен быть положительным, получен: %d", setting.key, setting.value)
+			}
+		}
+		if c.Database.ConnectRetries < 0 {
+			return fmt.Errorf("database.connect_retries не может быть отрицательным, получен: %d", c.Database.ConnectRetries)
+		}
 	}
 
 	// Валидация Strategy
@@ -298,12 +1894,485 @@ func (c *Config) Validate() error {
 	if c.Strategy.CheckInterval < 0 {
 		return fmt.Errorf("strategy.check_interval не может быть отрицательным, получен: %d", c.Strategy.CheckInterval)
 	}
+	location, err := c.Strategy.ScheduleLocation()
+	if err != nil {
+		return fmt.Errorf("strategy.schedule_timezone: неизвестный часовой пояс %q", c.Strategy.ScheduleTimezone)
+	}
+	schedule, err := c.Strategy.CronSchedule()
+	if err != nil {
+		return fmt.Errorf("strategy.schedule: %w", err)
+	}
+	if schedule != nil && schedule.Next(time.Now().In(location)).IsZero() {
+		return fmt.Errorf("strategy.schedule: расписание %q не наступает", c.Strategy.Schedule)
+	}
+	if _, err := c.Strategy.QuietWindows(); err != nil {
+		return fmt.Errorf("strategy.quiet_hours: %w", err)
+	}
 	if c.Strategy.RetryAttempts <= 0 {
 		return fmt.Errorf("strategy.retry_attempts должен быть положительным, получен: %d", c.Strategy.RetryAttempts)
 	}
 	if c.Strategy.RetryDelay < 0 {
 		return fmt.Errorf("strategy.retry_delay не может быть отрицательным, получен: %d", c.Strategy.RetryDelay)
 	}
+	if c.Strategy.BuyFillTimeout <= 0 {
+		return fmt.Errorf("strategy.buy_fill_timeout должен быть положительным, получен: %d", c.Strategy.BuyFillTimeout)
+	}
+	if c.Strategy.BuyFillPollInterval <= 0 {
+		return fmt.Errorf("strategy.buy_fill_poll_interval должен быть положительным, получен: %d", c.Strategy.BuyFillPollInterval)
+	}
+	if c.Strategy.CycleTimeout < 0 {
+		return fmt.Errorf("strategy.cycle_timeout не может быть отрицательным, получен: %d", c.Strategy.CycleTimeout)
+	}
+	if c.Strategy.CycleTimeout > 0 && c.Strategy.CycleTimeout <= c.Strategy.BuyFillTimeout {
+		return fmt.Errorf("strategy.cycle_timeout (%d) должен превышать strategy.buy_fill_timeout (%d): иначе цикл прерывается во время ожидания исполнения покупки",
+			c.Strategy.CycleTimeout, c.Strategy.BuyFillTimeout)
+	}
+	if c.Strategy.MinTakeProfitTicks < 0 {
+		return fmt.Errorf("strategy.min_take_profit_ticks не может быть отрицательным, получен: %d", c.Strategy.MinTakeProfitTicks)
+	}
+	if c.Strategy.MinTakeProfitPercent < 0 {
+		return fmt.Errorf("strategy.min_take_profit_percent не может быть отрицательным, получен: %.2f", c.Strategy.MinTakeProfitPercent)
+	}
+	if c.Strategy.MaxPriceDeviationPercent < 0 {
+		return fmt.Errorf("strategy.max_price_deviation_percent не может быть отрицательным, получен: %.2f", c.Strategy.MaxPriceDeviationPercent)
+	}
+	if c.Strategy.BuyPriceOffsetPercent < 0 {
+		return fmt.Errorf("strategy.buy_price_offset_percent не может быть отрицательным, получен: %.2f", c.Strategy.BuyPriceOffsetPercent)
+	}
+	if c.Strategy.SlippageBufferPercent < 0 {
+		return fmt.Errorf("strategy.slippage_buffer_percent не может быть отрицательным, получен: %.2f", c.Strategy.SlippageBufferPercent)
+	}
+	if c.Strategy.MaxParallelHedges < 1 {
+		return fmt.Errorf("strategy.max_parallel_hedges должен быть не меньше 1, получен: %d", c.Strategy.MaxParallelHedges)
+	}
+	for _, rounding := range []struct{ key, mode string }{
+		{"buy_price_rounding", c.Strategy.BuyPriceRounding},
+		{"sell_price_rounding", c.Strategy.SellPriceRounding},
+		{"quantity_rounding", c.Strategy.QuantityRounding},
+	} {
+		switch rounding.mode {
+		case "floor", "ceil", "nearest", "bankers":
+		default:
+			return fmt.Errorf("strategy.%s должен быть одним из: floor, ceil, nearest, bankers, получен: %q", rounding.key, rounding.mode)
+		}
+	}
+	if c.Strategy.StopLossPercent < 0 || c.Strategy.StopLossPercent >= 100 {
+		return fmt.Errorf("strategy.stop_loss_percent должен быть в диапазоне [0, 100), получен: %.2f", c.Strategy.StopLossPercent)
+	}
+	if c.Strategy.BuyFallback != "none" && c.Strategy.BuyFallback != "market" {
+		return fmt.Errorf("strategy.buy_fallback должен быть none или market, получен: %q", c.Strategy.BuyFallback)
+	}
+	if c.Strategy.PassiveEntryTimeout < 0 {
+		return fmt.Errorf("strategy.passive_entry_timeout не может быть отрицательным, получен: %d", c.Strategy.PassiveEntryTimeout)
+	}
+	for _, pair := range c.Strategy.ConvertPairs {
+		if _, err := valueobjects.ParseTradingPair(pair); err != nil {
+			return fmt.Errorf("strategy.convert_pairs: %w", err)
+		}
+	}
+	switch c.Strategy.Priority {
+	case "drawdown", "notional", "loss", "age":
+	case "pairs":
+		if len(c.Strategy.PriorityPairs) == 0 {
+			return fmt.Errorf("strategy.priority_pairs не может быть пустым при strategy.priority: pairs")
+		}
+	default:
+		return fmt.Errorf("strategy.priority должен быть одним из: drawdown, notional, loss, age, pairs, получен: %q", c.Strategy.Priority)
+	}
+	for _, key := range c.Strategy.PriorityTiebreakers {
+		switch key {
+		case "drawdown", "notional", "loss", "age":
+		default:
+			return fmt.Errorf("strategy.priority_tiebreakers может содержать только drawdown, notional, loss, age, получен: %q", key)
+		}
+	}
+	for _, pair := range c.Strategy.PriorityPairs {
+		if _, err := valueobjects.ParseTradingPair(pair); err != nil {
+			return fmt.Errorf("strategy.priority_pairs: %w", err)
+		}
+	}
+	if err := validateStrategyFilters("strategy.filters", c.Strategy.Filters); err != nil {
+		return err
+	}
+	for strategy, filters := range c.Strategy.FiltersByStrategy {
+		switch strategy {
+		case "classic", "martingale-ladder":
+		default:
+			return fmt.Errorf("strategy.filters_by_strategy: неизвестная стратегия %q (classic, martingale-ladder)", strategy)
+		}
+		if err := validateStrategyFilters("strategy.filters_by_strategy."+strategy, filters); err != nil {
+			return err
+		}
+	}
+	for _, pair := range c.Strategy.BlacklistPairs {
+		if _, err := valueobjects.ParseTradingPair(pair); err != nil {
+			return fmt.Errorf("strategy.blacklist_pairs: %w", err)
+		}
+	}
+	if c.Strategy.MaxSpreadPercent < 0 {
+		return fmt.Errorf("strategy.max_spread_percent не может быть отрицательным, получен: %.2f", c.Strategy.MaxSpreadPercent)
+	}
+	if c.Strategy.MaxVolatilityPercent < 0 {
+		return fmt.Errorf("strategy.max_volatility_percent не может быть отрицательным, получен: %.2f", c.Strategy.MaxVolatilityPercent)
+	}
+	if c.Strategy.VolatilityWindowHours <= 0 {
+		return fmt.Errorf("strategy.volatility_window_hours должен быть положительным, получен: %d", c.Strategy.VolatilityWindowHours)
+	}
+	if c.Strategy.MinLosingTrades < 0 {
+		return fmt.Errorf("strategy.min_losing_trades не может быть отрицательным, получен: %d", c.Strategy.MinLosingTrades)
+	}
+	if c.Strategy.MinPortfolioLoss < 0 {
+		return fmt.Errorf("strategy.min_portfolio_loss не может быть отрицательным, получен: %.2f", c.Strategy.MinPortfolioLoss)
+	}
+	if c.Strategy.CloseSlippagePercent < 0 || c.Strategy.CloseSlippagePercent >= 100 {
+		return fmt.Errorf("strategy.close_slippage_percent должен быть в диапазоне [0, 100), получен: %.2f", c.Strategy.CloseSlippagePercent)
+	}
+	if c.Strategy.CloseRepriceInterval <= 0 {
+		return fmt.Errorf("strategy.close_reprice_interval должен быть положительным, получен: %d", c.Strategy.CloseRepriceInterval)
+	}
+	if c.Strategy.CloseRepriceAttempts <= 0 {
+		return fmt.Errorf("strategy.close_reprice_attempts должен быть положительным, получен: %d", c.Strategy.CloseRepriceAttempts)
+	}
+	if c.Strategy.SplitMaxLegs <= 0 {
+		return fmt.Errorf("strategy.split_max_legs должен быть положительным, получен: %d", c.Strategy.SplitMaxLegs)
+	}
+	if c.Strategy.SplitMaxLegAmount < 0 {
+		return fmt.Errorf("strategy.split_max_leg_amount не может быть отрицательным, получен: %.2f", c.Strategy.SplitMaxLegAmount)
+	}
+	if c.Strategy.SplitLegInterval < 0 {
+		return fmt.Errorf("strategy.split_leg_interval не может быть отрицательным, получен: %d", c.Strategy.SplitLegInterval)
+	}
+	if c.Strategy.SplitDepthPercent < 0 {
+		return fmt.Errorf("strategy.split_depth_percent не может быть отрицательным, получен: %.2f", c.Strategy.SplitDepthPercent)
+	}
+	if c.Strategy.SplitDepthSharePercent <= 0 || c.Strategy.SplitDepthSharePercent > 100 {
+		return fmt.Errorf("strategy.split_depth_share_percent должен быть в диапазоне (0, 100], получен: %.2f", c.Strategy.SplitDepthSharePercent)
+	}
+	switch c.Strategy.Name {
+	case "classic":
+	case "martingale-ladder":
+		if c.Strategy.MartingaleMultiplier < 1 {
+			return fmt.Errorf("strategy.martingale_multiplier должен быть не меньше 1, получен: %.2f", c.Strategy.MartingaleMultiplier)
+		}
+		if c.Strategy.MartingaleMaxSteps <= 0 {
+			return fmt.Errorf("strategy.martingale_max_steps должен быть положительным, получен: %d", c.Strategy.MartingaleMaxSteps)
+		}
+	case "short-futures":
+		return fmt.Errorf("strategy.name: стратегия short-futures требует торговли фьючерсами, которая пока не поддерживается клиентом биржи")
+	default:
+		return fmt.Errorf("strategy.name должен быть classic или martingale-ladder, получен: %s", c.Strategy.Name)
+	}
+	for _, delay := range c.Strategy.EarlyStatusChecks {
+		if delay <= 0 {
+			return fmt.Errorf("strategy.early_status_checks должен содержать только положительные значения, получен: %d", delay)
+		}
+	}
+
+	// Валидация Risk
+	if c.Risk.MaxOpenNotional < 0 {
+		return fmt.Errorf("risk.max_open_notional не может быть отрицательным, получен: %.2f", c.Risk.MaxOpenNotional)
+	}
+	if c.Risk.MaxConcurrentHedges < 0 {
+		return fmt.Errorf("risk.max_concurrent_hedges не может быть отрицательным, получен: %d", c.Risk.MaxConcurrentHedges)
+	}
+	if c.Risk.DailyBudget < 0 {
+		return fmt.Errorf("risk.daily_budget не может быть отрицательным, получен: %.2f", c.Risk.DailyBudget)
+	}
+	if c.Risk.MaxHedgesPerPair < 0 {
+		return fmt.Errorf("risk.max_hedges_per_pair не может быть отрицательным, получен: %d", c.Risk.MaxHedgesPerPair)
+	}
+	for quote, limits := range c.Risk.PerQuote {
+		if limits.MaxConcurrentHedges < 0 || limits.MaxOpenNotional < 0 || limits.DailyBudget < 0 {
+			return fmt.Errorf("risk.per_quote.%s: лимиты не могут быть отрицательными", quote)
+		}
+	}
+
+	// Валидация HTTP
+	if c.HTTP.MaxIdleConns < 0 || c.HTTP.MaxIdleConnsPerHost < 0 || c.HTTP.MaxConnsPerHost < 0 {
+		return fmt.Errorf("лимиты соединений http не могут быть отрицательными")
+	}
+	if c.HTTP.IdleConnTimeout < 0 || c.HTTP.KeepAlive < 0 || c.HTTP.TLSHandshakeTimeout < 0 {
+		return fmt.Errorf("таймауты http не могут быть отрицательными")
+	}
+	if c.HTTP.TLSSessionCacheSize < 0 {
+		return fmt.Errorf("http.tls_session_cache_size не может быть отрицательным, получен: %d", c.HTTP.TLSSessionCacheSize)
+	}
+	if c.HTTP.RequestTimeout <= 0 {
+		return fmt.Errorf("http.request_timeout должен быть положительным, получен: %d", c.HTTP.RequestTimeout)
+	}
+
+	// Валидация Rebalance
+	if c.Rebalance.Enabled {
+		if c.Rebalance.Interval <= 0 {
+			return fmt.Errorf("rebalance.interval должен быть положительным, получен: %d", c.Rebalance.Interval)
+		}
+		if c.Rebalance.MinProfit < 0 {
+			return fmt.Errorf("rebalance.min_profit не может быть отрицательным, получен: %.2f", c.Rebalance.MinProfit)
+		}
+		if len(c.Rebalance.Allocations) == 0 {
+			return fmt.Errorf("rebalance.allocations не может быть пустым при включенной ребалансировке")
+		}
+		var totalPercent float64
+		for asset, percent := range c.Rebalance.Allocations {
+			if percent <= 0 {
+				return fmt.Errorf("rebalance.allocations.%s должен быть положительным, получен: %.2f", asset, percent)
+			}
+			if strings.EqualFold(asset, c.Strategy.BaseCurrency) {
+				return fmt.Errorf("rebalance.allocations не должен содержать базовую валюту %s", asset)
+			}
+			totalPercent += percent
+		}
+		if totalPercent > 100 {
+			return fmt.Errorf("сумма rebalance.allocations не может превышать 100%%, получено: %.2f", totalPercent)
+		}
+	}
+
+	// Валидация Flat
+	if c.Flat.Enabled {
+		if _, _, err := c.Flat.ParseTime(); err != nil {
+			return fmt.Errorf("flat.time должен быть в формате ЧЧ:ММ, получен: %q", c.Flat.Time)
+		}
+	}
+
+	// Валидация Watchdog
+	if c.Watchdog.Enabled {
+		if c.Watchdog.StallThreshold <= 0 {
+			return fmt.Errorf("watchdog.stall_threshold должен быть положительным, получен: %d", c.Watchdog.StallThreshold)
+		}
+		if period := c.Strategy.CyclePeriod(); time.Duration(c.Watchdog.StallThreshold)*time.Minute <= period {
+			return fmt.Errorf("watchdog.stall_threshold (%d мин) должен быть больше периода циклов strategy.check_interval или strategy.schedule (%v)",
+				c.Watchdog.StallThreshold, period)
+		}
+	}
+
+	// Валидация Alerts
+	if c.Alerts.EscalateAfter < 0 || c.Alerts.EscalateAfter == 1 {
+		return fmt.Errorf("alerts.escalate_after должен быть 0 (без эскалации) или не меньше 2, получен: %d", c.Alerts.EscalateAfter)
+	}
+	if c.Alerts.RepeatInterval < 0 {
+		return fmt.Errorf("alerts.repeat_interval не может быть отрицательным, получен: %d", c.Alerts.RepeatInterval)
+	}
+	if c.Alerts.StuckHedgeAfter < 0 {
+		return fmt.Errorf("alerts.stuck_hedge_after не может быть отрицательным, получен: %d", c.Alerts.StuckHedgeAfter)
+	}
+
+	// Валидация Lease
+	if c.Lease.Enabled && c.Lease.TTL < 3 {
+		return fmt.Errorf("lease.ttl должен быть не меньше 3 секунд, получен: %d", c.Lease.TTL)
+	}
+	for _, role := range c.Lease.Roles {
+		known := false
+		for _, supported := range AllRoles {
+			known = known || strings.EqualFold(strings.TrimSpace(role), supported)
+		}
+		if !known {
+			return fmt.Errorf("неизвестная роль экземпляра в lease.roles: %s (допустимо: %s)", role, strings.Join(AllRoles, ", "))
+		}
+	}
+	if len(c.Lease.Roles) > 0 {
+		// Экземпляры с разными ролями согласуют работу через аренды и захваты в общей БД
+		if !c.Lease.Enabled {
+			return fmt.Errorf("lease.roles требует lease.enabled: true")
+		}
+		if c.Database.Driver != DatabaseDriverPostgres {
+			return fmt.Errorf("lease.roles требует database.driver: %s, получен: %s", DatabaseDriverPostgres, c.Database.Driver)
+		}
+	}
+	if c.Lease.StatusBatch < 0 {
+		return fmt.Errorf("lease.status_batch не может быть отрицательным, получен: %d", c.Lease.StatusBatch)
+	}
+	if period := c.Strategy.CyclePeriod(); len(c.Lease.Roles) > 0 && time.Duration(c.Lease.StatusClaimTTL)*time.Second <= period {
+		return fmt.Errorf("lease.status_claim_ttl (%d сек) должен быть больше периода циклов strategy.check_interval или strategy.schedule (%v)",
+			c.Lease.StatusClaimTTL, period)
+	}
+
+	// Валидация Signals
+	if c.Signals.Enabled {
+		if c.Signals.MaxAmount < 0 {
+			return fmt.Errorf("signals.max_amount не может быть отрицательным, получен: %.2f", c.Signals.MaxAmount)
+		}
+		if !c.Signals.Webhook && c.Signals.Dir == "" && c.Signals.Redis.Addr == "" {
+			return fmt.Errorf("signals: не задан ни один источник (webhook, dir или redis.addr)")
+		}
+		if c.Signals.Webhook && !c.WebUI.Enabled {
+			return fmt.Errorf("signals.webhook требует webui.enabled: true")
+		}
+		if c.Signals.Webhook && c.Signals.WebhookSecret == "" && !c.WebUI.Auth.Enabled() {
+			return fmt.Errorf("signals.webhook требует signals.webhook_secret или аутентификацию веб-интерфейса (webui.auth.mode)")
+		}
+		if c.Signals.Redis.Addr != "" {
+			if strings.TrimSpace(c.Signals.Redis.Key) == "" {
+				return fmt.Errorf("signals.redis.key не может быть пустым")
+			}
+			if c.Signals.Redis.Batch <= 0 {
+				return fmt.Errorf("signals.redis.batch должен быть положительным, получен: %d", c.Signals.Redis.Batch)
+			}
+		}
+	}
+
+	// Валидация Notifications
+	if webhook := c.Notifications.Webhook; webhook.Enabled() {
+		for _, address := range webhook.URLs {
+			parsed, err := url.Parse(address)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return fmt.Errorf("notifications.webhook.urls: некорректный адрес %q (нужен http:// или https://)", address)
+			}
+		}
+		for _, event := range webhook.Events {
+			if !entities.IsNotificationEvent(event) {
+				return fmt.Errorf("notifications.webhook.events: неизвестное событие %q (доступны: %s)",
+					event, strings.Join(entities.NotificationEvents, ", "))
+			}
+		}
+		if webhook.Timeout <= 0 {
+			return fmt.Errorf("notifications.webhook.timeout должен быть положительным, получен: %d", webhook.Timeout)
+		}
+		if webhook.MaxRetries < 0 || webhook.RetryDelay < 0 {
+			return fmt.Errorf("notifications.webhook.max_retries и retry_delay не могут быть отрицательными")
+		}
+	}
+
+	if email := c.Notifications.Email; email.Enabled() {
+		if email.Port <= 0 || email.Port > 65535 {
+			return fmt.Errorf("notifications.email.port должен быть в диапазоне 1-65535, получен: %d", email.Port)
+		}
+		if _, err := mail.ParseAddress(email.From); err != nil {
+			return fmt.Errorf("notifications.email.from: некорректный адрес %q", email.From)
+		}
+		for _, address := range email.To {
+			if _, err := mail.ParseAddress(address); err != nil {
+				return fmt.Errorf("notifications.email.to: некорректный адрес %q", address)
+			}
+		}
+		if email.Throttle < 0 || email.MaxPerHour < 0 {
+			return fmt.Errorf("notifications.email.throttle и max_per_hour не могут быть отрицательными")
+		}
+	}
+
+	for i, route := range c.Notifications.Routes {
+		for _, event := range route.Events {
+			if !entities.IsNotificationEvent(event) {
+				return fmt.Errorf("notifications.routes[%d].events: неизвестное событие %q (доступны: %s)",
+					i, event, strings.Join(entities.NotificationEvents, ", "))
+			}
+		}
+		if route.MinPriority != "" {
+			if _, ok := entities.ParseNotificationPriority(route.MinPriority); !ok {
+				return fmt.Errorf("notifications.routes[%d].min_priority должен быть low, normal или high, получен: %q", i, route.MinPriority)
+			}
+		}
+		if len(route.Channels) == 0 {
+			return fmt.Errorf("notifications.routes[%d]: не задан ни один канал", i)
+		}
+		for _, channel := range route.Channels {
+			switch channel {
+			case NotificationChannelLog, NotificationChannelWebhook, NotificationChannelEmail:
+			default:
+				return fmt.Errorf("notifications.routes[%d].channels: неизвестный канал %q (доступны: log, webhook, email)", i, channel)
+			}
+			if !c.Notifications.ChannelEnabled(channel) {
+				return fmt.Errorf("notifications.routes[%d].channels: канал %s не настроен (notifications.%s)", i, channel, channel)
+			}
+		}
+	}
+
+	// Валидация Metrics
+	if len(c.Metrics.Custom) > 0 {
+		if c.Metrics.Interval <= 0 {
+			return fmt.Errorf("metrics.interval должен быть положительным, получен: %d", c.Metrics.Interval)
+		}
+		if c.Metrics.QueryTimeout <= 0 {
+			return fmt.Errorf("metrics.query_timeout должен быть положительным, получен: %d", c.Metrics.QueryTimeout)
+		}
+		names := make(map[string]bool, len(c.Metrics.Custom))
+		for _, metric := range c.Metrics.CustomMetrics() {
+			if err := metric.Validate(); err != nil {
+				return fmt.Errorf("metrics.custom: %w", err)
+			}
+			if names[metric.Name] {
+				return fmt.Errorf("metrics.custom: метрика %s указана дважды", metric.Name)
+			}
+			names[metric.Name] = true
+		}
+	}
+
+	// Валидация History
+	if c.History.ImportEnabled {
+		if c.History.Days <= 0 || c.History.Days > 730 {
+			return fmt.Errorf("history.days должен быть в диапазоне 1-730, получен: %d", c.History.Days)
+		}
+		if len(c.History.Pairs) == 0 {
+			return fmt.Errorf("history.pairs не может быть пустым при включенном импорте истории")
+		}
+		for _, pair := range c.History.Pairs {
+			if _, err := valueobjects.ParseTradingPair(pair); err != nil {
+				return fmt.Errorf("history.pairs: %w", err)
+			}
+		}
+	}
+
+	// Валидация Balance check
+	if c.Balance.Enabled {
+		if c.Balance.Interval <= 0 {
+			return fmt.Errorf("balance_check.interval должен быть положительным, получен: %d", c.Balance.Interval)
+		}
+		if c.Balance.TolerancePercent < 0 || c.Balance.TolerancePercent >= 100 {
+			return fmt.Errorf("balance_check.tolerance_percent должен быть в диапазоне 0-100, получен: %.2f", c.Balance.TolerancePercent)
+		}
+	}
+
+	// Валидация Archive
+	if c.Archive.Enabled {
+		if c.Archive.RetentionDays <= 0 {
+			return fmt.Errorf("archive.retention_days должен быть положительным, получен: %d", c.Archive.RetentionDays)
+		}
+		if c.Archive.Interval <= 0 {
+			return fmt.Errorf("archive.interval должен быть положительным, получен: %d", c.Archive.Interval)
+		}
+	}
+
+	// Валидация Reports
+	for _, schedule := range []struct{ name, value string }{
+		{"reports.daily_schedule", c.Reports.DailySchedule},
+		{"reports.weekly_schedule", c.Reports.WeeklySchedule},
+	} {
+		if schedule.value == "" {
+			continue
+		}
+		if _, err := cron.Parse(schedule.value); err != nil {
+			return fmt.Errorf("%s: %w", schedule.name, err)
+		}
+	}
+
+	// Валидация Heartbeat
+	if c.Heartbeat.URL != "" {
+		if parsed, err := url.Parse(c.Heartbeat.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("heartbeat.url должен быть адресом http(s)")
+		}
+		if c.Heartbeat.Timeout <= 0 {
+			return fmt.Errorf("heartbeat.timeout должен быть положительным, получен: %d", c.Heartbeat.Timeout)
+		}
+	}
+
+	// Валидация Tracing
+	if c.Tracing.Enabled {
+		if parsed, err := url.Parse(c.Tracing.Endpoint); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("tracing.endpoint должен быть адресом http(s) OTLP коллектора, получен: %q", c.Tracing.Endpoint)
+		}
+		if c.Tracing.ServiceName == "" {
+			return fmt.Errorf("tracing.service_name не может быть пустым")
+		}
+		if c.Tracing.SampleRatio <= 0 || c.Tracing.SampleRatio > 1 {
+			return fmt.Errorf("tracing.sample_ratio должен быть в диапазоне (0, 1], получен: %.2f", c.Tracing.SampleRatio)
+		}
+	}
+
+	// Валидация Features
+	for key := range c.Features {
+		if _, ok := entities.FindFeatureFlag(key); !ok {
+			return fmt.Errorf("неизвестный флаг в features: %s", key)
+		}
+	}
 
 	// Валидация WebUI
 	if c.WebUI.Enabled {
@@ -314,10 +2383,101 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("webui.host не может быть пустым")
 		}
 	}
+	if c.WebUI.LogBufferLines < 0 {
+		return fmt.Errorf("webui.log_buffer_lines не может быть отрицательным, получен: %d", c.WebUI.LogBufferLines)
+	}
+	if c.WebUI.Language != WebUILanguageRU && c.WebUI.Language != WebUILanguageEN {
+		return fmt.Errorf("webui.language должен быть ru или en, получен: %q", c.WebUI.Language)
+	}
+	if (c.WebUI.TLSCert == "") != (c.WebUI.TLSKey == "") {
+		return fmt.Errorf("webui.tls_cert и webui.tls_key задаются вместе")
+	}
+	if err := c.WebUI.Auth.validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validate проверяет аутентификацию веб-интерфейса: в режимах basic и session нужны логин и пароль или пользователи с ролями
+func (a *WebUIAuthConfig) validate() error {
+	switch a.Mode {
+	case WebUIAuthNone:
+		return nil
+	case WebUIAuthBasic, WebUIAuthSession:
+	default:
+		return fmt.Errorf("webui.auth.mode должен быть none, basic или session, получен: %q", a.Mode)
+	}
+	// Основной пользователь необязателен при заданных users, но задается логином и паролем вместе
+	mainUser := a.Username != "" || a.Password != ""
+	if (mainUser || len(a.Users) == 0) && (strings.TrimSpace(a.Username) == "" || a.Password == "") {
+		return fmt.Errorf("webui.auth.username и webui.auth.password (или webui.auth.users) обязательны в режиме %s", a.Mode)
+	}
+	usernames := map[string]bool{a.Username: a.Username != ""}
+	for i, user := range a.Users {
+		if strings.TrimSpace(user.Username) == "" || user.Password == "" {
+			return fmt.Errorf("webui.auth.users[%d]: username и password обязательны", i)
+		}
+		if !IsWebUIRole(user.Role) {
+			return fmt.Errorf("webui.auth.users[%d].role должен быть viewer, operator или admin, получен: %q", i, user.Role)
+		}
+		if usernames[user.Username] {
+			return fmt.Errorf("webui.auth.users[%d]: пользователь %q указан дважды", i, user.Username)
+		}
+		usernames[user.Username] = true
+	}
+	if a.Mode == WebUIAuthSession && a.SessionTTL < 1 {
+		return fmt.Errorf("webui.auth.session_ttl должен быть больше 0, получен: %d", a.SessionTTL)
+	}
+	for i, token := range a.APITokens {
+		if len(strings.TrimSpace(token)) < 16 {
+			return fmt.Errorf("webui.auth.api_tokens[%d]: токен должен быть не короче 16 символов", i)
+		}
+	}
+	return nil
+}
 
+// validateStrategyFilters проверяет список фильтров перед покупкой: известные названия без повторов
+func validateStrategyFilters(key string, filters []string) error {
+	seen := make(map[string]bool, len(filters))
+	for _, filter := range filters {
+		switch filter {
+		case "blacklist", "budget", "price_deviation", "spread", "volatility", "balance", "instrument_status", "min_limit":
+		default:
+			return fmt.Errorf("%s может содержать только blacklist, budget, price_deviation, spread, volatility, balance, instrument_status, min_limit, получен: %q", key, filter)
+		}
+		if seen[filter] {
+			return fmt.Errorf("%s: фильтр %q указан дважды", key, filter)
+		}
+		seen[filter] = true
+	}
 	return nil
 }
 
+// IsExchangeConfigured проверяет, заданы ли ключи Bybit
+func (c *Config) IsExchangeConfigured() bool {
+	return strings.TrimSpace(c.Bybit.APIKey) != "" && strings.TrimSpace(c.Bybit.APISecret) != ""
+}
+
+// IsDatabaseConfigured проверяет, настроено ли подключение к БД (для SQLite достаточно пути к файлу)
+func (c *Config) IsDatabaseConfigured() bool {
+	if c.Database.Driver == DatabaseDriverSQLite {
+		return strings.TrimSpace(c.Database.Path) != ""
+	}
+	return strings.TrimSpace(c.Database.Password) != ""
+}
+
+// OperatingMode определяет режим работы по заполненности конфигурации
+func (c *Config) OperatingMode() OperatingMode {
+	if !c.IsDatabaseConfigured() {
+		return ModeDryRun
+	}
+	if !c.IsExchangeConfigured() {
+		return ModeMonitorOnly
+	}
+	return ModeFull
+}
+
 // GetDatabaseConnectionString возвращает строку подключения к базе данных
 func (c *Config) GetDatabaseConnectionString() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -0,0 +1,243 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// RedactedValue заменяет секреты в снимках конфигурации, которые сохраняются в истории
+const RedactedValue = "***"
+
+// Snapshot возвращает действующую конфигурацию (файл с переопределениями окружения) в YAML.
+// Ключи API и пароли заменяются на RedactedValue, чтобы история в БД не содержала секретов
+func (c *Config) Snapshot() (string, error) {
+	data, err := yaml.Marshal(c.redacted())
+	if err != nil {
+		return "", fmt.Errorf("ошибка сериализации конфигурации: %w", err)
+	}
+	return string(data), nil
+}
+
+// RedactedMap возвращает действующую конфигурацию со скрытыми секретами как дерево значений
+// с ключами из YAML (для ответов API в JSON)
+func (c *Config) RedactedMap() (map[string]interface{}, error) {
+	data, err := yaml.Marshal(c.redacted())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации конфигурации: %w", err)
+	}
+
+	var tree map[interface{}]interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("ошибка разбора конфигурации: %w", err)
+	}
+	return stringKeys(tree), nil
+}
+
+// redacted возвращает копию конфигурации с секретами, замененными на RedactedValue
+func (c *Config) redacted() *Config {
+	redacted := *c
+	redacted.Freqtrade.Password = redactSecret(c.Freqtrade.Password)
+	redacted.Bybit.APIKey = redactSecret(c.Bybit.APIKey)
+	redacted.Bybit.APISecret = redactSecret(c.Bybit.APISecret)
+	redacted.Database.Password = redactSecret(c.Database.Password)
+	redacted.WebUI.Auth.Password = redactSecret(c.WebUI.Auth.Password)
+	redacted.WebUI.Auth.APITokens = redactSecrets(c.WebUI.Auth.APITokens)
+	redacted.WebUI.Auth.Users = redactUsers(c.WebUI.Auth.Users)
+	redacted.Signals.WebhookSecret = redactSecret(c.Signals.WebhookSecret)
+	redacted.Signals.Redis.Password = redactSecret(c.Signals.Redis.Password)
+	// Адреса webhook (Discord, Zapier) содержат токен получателя
+	redacted.Notifications.Webhook.URLs = redactSecrets(c.Notifications.Webhook.URLs)
+	redacted.Notifications.Webhook.Secret = redactSecret(c.Notifications.Webhook.Secret)
+	redacted.Notifications.Email.Password = redactSecret(c.Notifications.Email.Password)
+	// Адрес пинга (healthchecks.io) содержит ключ проверки: по нему можно отметить цикл за бота
+	redacted.Heartbeat.URL = redactSecret(c.Heartbeat.URL)
+	return &redacted
+}
+
+// stringKeys переводит вложенные таблицы YAML в таблицы со строковыми ключами
+func stringKeys(tree map[interface{}]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(tree))
+	for key, value := range tree {
+		result[fmt.Sprint(key)] = stringKeysValue(value)
+	}
+	return result
+}
+
+// stringKeysValue переводит значение YAML: таблицы и списки - рекурсивно
+func stringKeysValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		return stringKeys(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = stringKeysValue(item)
+		}
+		return items
+	}
+	return value
+}
+
+// ParseSnapshot восстанавливает конфигурацию из снимка поверх значений по умолчанию.
+// Скрытые секреты берутся из current, результат проходит ту же валидацию, что и при запуске
+func ParseSnapshot(content string, current *Config) (*Config, error) {
+	restored := &Config{}
+	restored.setDefaults()
+	if err := yaml.Unmarshal([]byte(content), restored); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга YAML: %w", err)
+	}
+
+	restoreSecret(&restored.Freqtrade.Password, current.Freqtrade.Password)
+	restoreSecret(&restored.Bybit.APIKey, current.Bybit.APIKey)
+	restoreSecret(&restored.Bybit.APISecret, current.Bybit.APISecret)
+	restoreSecret(&restored.Database.Password, current.Database.Password)
+	restoreSecret(&restored.WebUI.Auth.Password, current.WebUI.Auth.Password)
+	restoreSecrets(&restored.WebUI.Auth.APITokens, current.WebUI.Auth.APITokens)
+	restoreUsers(restored.WebUI.Auth.Users, current.WebUI.Auth.Users)
+	restoreSecret(&restored.Signals.WebhookSecret, current.Signals.WebhookSecret)
+	restoreSecret(&restored.Signals.Redis.Password, current.Signals.Redis.Password)
+	restoreSecrets(&restored.Notifications.Webhook.URLs, current.Notifications.Webhook.URLs)
+	restoreSecret(&restored.Notifications.Webhook.Secret, current.Notifications.Webhook.Secret)
+	restoreSecret(&restored.Notifications.Email.Password, current.Notifications.Email.Password)
+	restoreSecret(&restored.Heartbeat.URL, current.Heartbeat.URL)
+
+	if err := restored.Validate(); err != nil {
+		return nil, fmt.Errorf("ошибка валидации конфигурации: %w", err)
+	}
+	return restored, nil
+}
+
+// RestoreSnapshot записывает конфигурацию из снимка в файл path и возвращает ее.
+// Секреты в файле остаются прежними: заданные через окружение в файл не попадают
+func RestoreSnapshot(path, content string, current *Config) (*Config, error) {
+	restored, err := ParseSnapshot(content, current)
+	if err != nil {
+		return nil, err
+	}
+
+	onDisk := &Config{}
+	if _, err := os.Stat(path); err == nil {
+		if err := onDisk.loadFromFile(path); err != nil {
+			return nil, fmt.Errorf("ошибка чтения текущего файла конфигурации: %w", err)
+		}
+	}
+
+	toWrite := *restored
+	toWrite.Freqtrade.Password = onDisk.Freqtrade.Password
+	toWrite.Bybit.APIKey = onDisk.Bybit.APIKey
+	toWrite.Bybit.APISecret = onDisk.Bybit.APISecret
+	toWrite.Database.Password = onDisk.Database.Password
+	toWrite.WebUI.Auth.Password = onDisk.WebUI.Auth.Password
+	toWrite.WebUI.Auth.APITokens = onDisk.WebUI.Auth.APITokens
+	toWrite.WebUI.Auth.Users = onDisk.WebUI.Auth.Users
+	toWrite.Signals.WebhookSecret = onDisk.Signals.WebhookSecret
+	toWrite.Signals.Redis.Password = onDisk.Signals.Redis.Password
+	toWrite.Notifications.Webhook.URLs = onDisk.Notifications.Webhook.URLs
+	toWrite.Notifications.Webhook.Secret = onDisk.Notifications.Webhook.Secret
+	toWrite.Notifications.Email.Password = onDisk.Notifications.Email.Password
+	toWrite.Heartbeat.URL = onDisk.Heartbeat.URL
+	if err := toWrite.writeFile(path); err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// writeFile записывает конфигурацию в YAML файл (права 0600: файл может содержать секреты). Запись атомарная:
+// через временный файл в том же каталоге, чтобы процесс, читающий файл, не увидел его наполовину записанным
+func (c *Config) writeFile(path string) error {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return fmt.Errorf("запись поддерживается только для YAML файла, получен: %s", path)
+	}
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации конфигурации: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("ошибка создания временного файла: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка записи конфигурации: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("ошибка записи конфигурации: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("ошибка замены файла конфигурации: %w", err)
+	}
+
+	return nil
+}
+
+// redactSecret скрывает непустой секрет
+func redactSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return RedactedValue
+}
+
+// redactSecrets скрывает список секретов, сохраняя их количество
+func redactSecrets(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	redacted := make([]string, len(values))
+	for i, value := range values {
+		redacted[i] = redactSecret(value)
+	}
+	return redacted
+}
+
+// redactUsers скрывает пароли пользователей веб-интерфейса
+func redactUsers(users []WebUIUser) []WebUIUser {
+	if len(users) == 0 {
+		return nil
+	}
+	redacted := make([]WebUIUser, len(users))
+	for i, user := range users {
+		redacted[i] = user
+		redacted[i].Password = redactSecret(user.Password)
+	}
+	return redacted
+}
+
+// restoreUsers подставляет текущие пароли пользователей вместо скрытых (по имени пользователя)
+func restoreUsers(users []WebUIUser, current []WebUIUser) {
+	for i := range users {
+		for _, user := range current {
+			if user.Username == users[i].Username {
+				restoreSecret(&users[i].Password, user.Password)
+			}
+		}
+	}
+}
+
+// restoreSecrets подставляет текущий список вместо скрытого целиком
+func restoreSecrets(values *[]string, current []string) {
+	for _, value := range *values {
+		if value != RedactedValue {
+			return
+		}
+	}
+	if len(*values) > 0 {
+		*values = current
+	}
+}
+
+// restoreSecret подставляет текущее значение вместо скрытого секрета
+func restoreSecret(value *string, current string) {
+	if *value == RedactedValue {
+		*value = current
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// WithStrategyChanges возвращает копию конфигурации с измененными параметрами стратегии (ключи как в YAML
+// секции strategy) и проверяет ее той же валидацией, что и при запуске. Вторым значением возвращаются
+// ключи, значения которых действительно изменились (по порядку)
+func (c *Config) WithStrategyChanges(changes map[string]interface{}) (*Config, []string, error) {
+	strategy, changed, err := applyStrategyChanges(c.Strategy, changes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := *c
+	updated.Strategy = strategy
+	if err := updated.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("ошибка валидации конфигурации: %w", err)
+	}
+	return &updated, changed, nil
+}
+
+// UpdateStrategyFile записывает измененные параметры стратегии в файл конфигурации path.
+// Остальные значения файла (в том числе секреты) не меняются, значения из окружения в файл не попадают
+func UpdateStrategyFile(path string, changes map[string]interface{}) error {
+	onDisk := &Config{}
+	onDisk.setDefaults()
+	if _, err := os.Stat(path); err == nil {
+		if err := onDisk.loadFromFile(path); err != nil {
+			return fmt.Errorf("ошибка чтения текущего файла конфигурации: %w", err)
+		}
+	}
+
+	strategy, _, err := applyStrategyChanges(onDisk.Strategy, changes)
+	if err != nil {
+		return err
+	}
+	onDisk.Strategy = strategy
+	return onDisk.writeFile(path)
+}
+
+// applyStrategyChanges применяет изменения к параметрам стратегии через их YAML представление:
+// неизвестный ключ или значение неподходящего типа - ошибка
+func applyStrategyChanges(strategy StrategyConfig, changes map[string]interface{}) (StrategyConfig, []string, error) {
+	before, err := strategyTree(strategy)
+	if err != nil {
+		return strategy, nil, err
+	}
+
+	tree := make(map[string]interface{}, len(before))
+	for key, value := range before {
+		tree[key] = value
+	}
+	for key, value := range changes {
+		if _, ok := tree[key]; !ok {
+			return strategy, nil, fmt.Errorf("неизвестный параметр strategy.%s", key)
+		}
+		tree[key] = value
+	}
+
+	data, err := yaml.Marshal(tree)
+	if err != nil {
+		return strategy, nil, fmt.Errorf("ошибка сериализации параметров стратегии: %w", err)
+	}
+	var updated StrategyConfig
+	if err := yaml.UnmarshalStrict(data, &updated); err != nil {
+		return strategy, nil, fmt.Errorf("некорректное значение параметра стратегии: %w", err)
+	}
+
+	after, err := strategyTree(updated)
+	if err != nil {
+		return strategy, nil, err
+	}
+	var changed []string
+	for key, value := range changes {
+		// YAML молча отбрасывает дробную часть числа для целого параметра: значение должно сохраниться как есть
+		requested, err := yaml.Marshal(value)
+		if err != nil {
+			return strategy, nil, fmt.Errorf("ошибка сериализации параметра strategy.%s: %w", key, err)
+		}
+		applied, err := yaml.Marshal(after[key])
+		if err != nil {
+			return strategy, nil, fmt.Errorf("ошибка сериализации параметра strategy.%s: %w", key, err)
+		}
+		if string(requested) != string(applied) {
+			return strategy, nil, fmt.Errorf("некорректное значение параметра strategy.%s: %s", key, strings.TrimSpace(string(requested)))
+		}
+		if !reflect.DeepEqual(before[key], after[key]) {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return updated, changed, nil
+}
+
+// strategyTree возвращает параметры стратегии как таблицу YAML ключ - значение
+func strategyTree(strategy StrategyConfig) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(strategy)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации параметров стратегии: %w", err)
+	}
+	var tree map[interface{}]interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("ошибка разбора параметров стратегии: %w", err)
+	}
+	return stringKeys(tree), nil
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// Таблицы хеджей: рабочая и архив давно закрытых
+const (
+	hedgedTradesTable        = "hedged_trades"
+	hedgedTradesArchiveTable = "hedged_trades_archive"
+)
+
+// archivedHedgeColumns колонки, переносимые в архив (все колонки hedged_trades)
+const archivedHedgeColumns = `hedge_id, freqtrade_trade_id, pair, hedge_time, bybit_order_id,
+	freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio,
+	hedge_open_price, hedge_amount, hedge_take_profit_price,
+	order_status, last_status_check, close_price, close_time,
+	buy_order_id, buy_requested_qty, buy_filled_qty,
+	strategy_version, feature_flags, stop_loss_price, stop_loss_order_id,
+	entry_fee, exit_fee,
+	threshold_crossed_at, threshold_crossed_price,
+	buy_placed_at, buy_filled_at, take_profit_placed_at,
+	close_intended_price, hedge_group_id`
+
+// hedgeTradesTable возвращает таблицу выборки хеджей: рабочую или архив
+func hedgeTradesTable(query *entities.HedgeTradeQuery) string {
+	if query.Archived {
+		return hedgedTradesArchiveTable
+	}
+	return hedgedTradesTable
+}
+
+// archiveCondition условие переноса в архив: конечный статус и закрытие раньше параметра placeholder.
+// Хеджи в конечном статусе без времени закрытия (старые записи) архивируются по времени хеджирования
+func archiveCondition(placeholder string) string {
+	statuses := make([]string, 0, len(entities.CompletedOrderStatuses()))
+	for _, status := range entities.CompletedOrderStatuses() {
+		statuses = append(statuses, "'"+status.String()+"'")
+	}
+	return fmt.Sprintf("order_status IN (%s) AND COALESCE(close_time, hedge_time) < %s",
+		strings.Join(statuses, ", "), placeholder)
+}
+
+// ArchiveHedgedTrades переносит в архив хеджи в конечном статусе, закрытые раньше closedBefore.
+// Удаление и вставка выполняются одним запросом, поэтому хедж не может потеряться или задвоиться
+func (r *PostgreSQLTradeRepository) ArchiveHedgedTrades(ctx context.Context, closedBefore time.Time) (int, error) {
+	query := fmt.Sprintf(`
+		WITH moved AS (
+			DELETE FROM hedged_trades WHERE %s
+			RETURNING %s
+		)
+		INSERT INTO hedged_trades_archive (%s)
+		SELECT %s FROM moved`,
+		archiveCondition("$1"), archivedHedgeColumns, archivedHedgeColumns, archivedHedgeColumns)
+
+	tag, err := r.pool.Exec(ctx, query, closedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка архивации хеджированных сделок: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ArchiveHedgedTrades переносит в архив хеджи в конечном статусе, закрытые раньше closedBefore
+func (r *SQLiteTradeRepository) ArchiveHedgedTrades(ctx context.Context, closedBefore time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка начала транзакции архивации: %w", err)
+	}
+	defer tx.Rollback()
+
+	condition := archiveCondition("?")
+	closedBefore = closedBefore.UTC()
+	insert := fmt.Sprintf("INSERT INTO hedged_trades_archive (%s) SELECT %s FROM hedged_trades WHERE %s",
+		archivedHedgeColumns, archivedHedgeColumns, condition)
+	if _, err := tx.ExecContext(ctx, insert, closedBefore); err != nil {
+		return 0, fmt.Errorf("ошибка копирования хеджей в архив: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM hedged_trades WHERE "+condition, closedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка удаления архивированных хеджей: %w", err)
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка завершения транзакции архивации: %w", err)
+	}
+	return int(moved), nil
+}
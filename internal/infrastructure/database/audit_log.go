@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// SaveAuditEntry сохраняет запись журнала аудита
+func (r *PostgreSQLTradeRepository) SaveAuditEntry(ctx context.Context, entry *entities.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (created_at, username, remote_addr, action, params, outcome, message)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	err := r.pool.QueryRow(ctx, query,
+		entry.CreatedAt,
+		entry.Username,
+		entry.RemoteAddr,
+		entry.Action,
+		entry.Params,
+		entry.Outcome,
+		entry.Message).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения записи журнала аудита: %w", err)
+	}
+
+	return nil
+}
+
+// GetAuditEntries возвращает записи журнала аудита в интервале [from, to) (новые первыми)
+func (r *PostgreSQLTradeRepository) GetAuditEntries(ctx context.Context, from, to time.Time) ([]*entities.AuditEntry, error) {
+	query := `
+		SELECT id, created_at, username, remote_addr, action, params, outcome, message
+		FROM audit_log
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения журнала аудита: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entities.AuditEntry
+	for rows.Next() {
+		entry := &entities.AuditEntry{}
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.CreatedAt,
+			&entry.Username,
+			&entry.RemoteAddr,
+			&entry.Action,
+			&entry.Params,
+			&entry.Outcome,
+			&entry.Message); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования записи журнала аудита: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения журнала аудита: %w", err)
+	}
+
+	return entries, nil
+}
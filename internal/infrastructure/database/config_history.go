@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"trade-hedge/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// configVersionColumns колонки версии конфигурации в порядке сканирования
+const configVersionColumns = `id, created_at, author, source, content, diff, COALESCE(rolled_back_from, 0)`
+
+// SaveConfigVersion сохраняет версию конфигурации
+func (r *PostgreSQLTradeRepository) SaveConfigVersion(ctx context.Context, version *entities.ConfigVersion) error {
+	query := `
+		INSERT INTO config_history (created_at, author, source, content, diff, rolled_back_from)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0))
+		RETURNING id`
+
+	err := r.pool.QueryRow(ctx, query,
+		version.CreatedAt,
+		version.Author,
+		version.Source,
+		version.Content,
+		version.Diff,
+		version.RolledBackFrom).Scan(&version.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения версии конфигурации: %w", err)
+	}
+
+	return nil
+}
+
+// GetConfigVersions возвращает версии конфигурации (новые первыми)
+func (r *PostgreSQLTradeRepository) GetConfigVersions(ctx context.Context) ([]*entities.ConfigVersion, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+configVersionColumns+` FROM config_history ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории конфигурации: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*entities.ConfigVersion
+	for rows.Next() {
+		version, err := scanConfigVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования версии конфигурации: %w", err)
+		}
+		versions = append(versions, version)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+
+	return versions, nil
+}
+
+// GetConfigVersion возвращает версию конфигурации по ID (nil, если не найдена)
+func (r *PostgreSQLTradeRepository) GetConfigVersion(ctx context.Context, id int) (*entities.ConfigVersion, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+configVersionColumns+` FROM config_history WHERE id = $1`, id)
+	version, err := scanConfigVersion(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения версии конфигурации %d: %w", id, err)
+	}
+	return version, nil
+}
+
+// GetLatestConfigVersion возвращает последнюю версию конфигурации (nil, если истории нет)
+func (r *PostgreSQLTradeRepository) GetLatestConfigVersion(ctx context.Context) (*entities.ConfigVersion, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+configVersionColumns+` FROM config_history ORDER BY id DESC LIMIT 1`)
+	version, err := scanConfigVersion(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения последней версии конфигурации: %w", err)
+	}
+	return version, nil
+}
+
+// scanConfigVersion сканирует строку с колонками configVersionColumns
+func scanConfigVersion(row pgx.Row) (*entities.ConfigVersion, error) {
+	version := &entities.ConfigVersion{}
+	err := row.Scan(
+		&version.ID,
+		&version.CreatedAt,
+		&version.Author,
+		&version.Source,
+		&version.Content,
+		&version.Diff,
+		&version.RolledBackFrom)
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
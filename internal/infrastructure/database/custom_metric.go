@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+)
+
+// QueryScalar выполняет запрос пользовательской метрики в транзакции только для чтения
+// с таймаутом на стороне сервера (statement_timeout) и возвращает число из единственной колонки
+func (r *PostgreSQLTradeRepository) QueryScalar(ctx context.Context, query string, timeout time.Duration) (*float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds())); err != nil {
+		return nil, fmt.Errorf("ошибка установки таймаута запроса: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса метрики: %w", err)
+	}
+	defer rows.Close()
+
+	if len(rows.FieldDescriptions()) != 1 {
+		return nil, fmt.Errorf("запрос метрики должен возвращать одну колонку, получено: %d", len(rows.FieldDescriptions()))
+	}
+
+	var value *float64
+	for count := 0; rows.Next(); count++ {
+		if count > 0 {
+			return nil, fmt.Errorf("запрос метрики должен возвращать не больше одной строки")
+		}
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения результата метрики: %w", err)
+		}
+		if value, err = scalarValue(values[0]); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса метрики: %w", err)
+	}
+	return value, nil
+}
+
+// scalarValue приводит значение колонки результата к числу (nil - NULL)
+func scalarValue(raw interface{}) (*float64, error) {
+	var value float64
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		value = v
+	case float32:
+		value = float64(v)
+	case int64:
+		value = float64(v)
+	case int32:
+		value = float64(v)
+	case int16:
+		value = float64(v)
+	case int:
+		value = float64(v)
+	case bool:
+		if v {
+			value = 1
+		}
+	case []byte:
+		return scalarValue(string(v))
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("запрос метрики вернул не число: %q", v)
+		}
+		value = parsed
+	case pgtype.Numeric:
+		// numeric (SUM, AVG, ROUND) не имеет встроенного типа Go
+		if err := v.AssignTo(&value); err != nil {
+			return nil, fmt.Errorf("запрос метрики вернул не число: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("запрос метрики вернул не число: %T", raw)
+	}
+	return &value, nil
+}
+
+// QueryScalar выполняет запрос пользовательской метрики с таймаутом на отдельном соединении
+// в режиме query_only (SQLite не поддерживает транзакции только для чтения) и возвращает число из единственной колонки
+func (r *SQLiteTradeRepository) QueryScalar(ctx context.Context, query string, timeout time.Duration) (*float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения соединения: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return nil, fmt.Errorf("ошибка включения режима только для чтения: %w", err)
+	}
+	// Соединение возвращается в пул: режим снимается и после истечения таймаута запроса
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "PRAGMA query_only = OFF"); err != nil {
+			conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса метрики: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения результата метрики: %w", err)
+	}
+	if len(columns) != 1 {
+		return nil, fmt.Errorf("запрос метрики должен возвращать одну колонку, получено: %d", len(columns))
+	}
+
+	var value *float64
+	for count := 0; rows.Next(); count++ {
+		if count > 0 {
+			return nil, fmt.Errorf("запрос метрики должен возвращать не больше одной строки")
+		}
+		var raw interface{}
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("ошибка чтения результата метрики: %w", err)
+		}
+		if value, err = scalarValue(raw); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса метрики: %w", err)
+	}
+	return value, nil
+}
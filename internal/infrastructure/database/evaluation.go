@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// SaveEvaluations сохраняет наблюдения одного цикла одним пакетом
+func (r *PostgreSQLTradeRepository) SaveEvaluations(ctx context.Context, evaluations []*entities.TradeEvaluation) error {
+	if len(evaluations) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, evaluation := range evaluations {
+		batch.Queue(`
+			INSERT INTO trade_evaluations
+			(freqtrade_trade_id, pair, evaluated_at, profit_ratio, current_rate, threshold_crossed)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			evaluation.TradeID,
+			evaluation.Pair,
+			evaluation.EvaluatedAt,
+			evaluation.ProfitRatio,
+			evaluation.CurrentRate,
+			evaluation.ThresholdCrossed)
+	}
+
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for range evaluations {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("ошибка сохранения наблюдений: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetEvaluations возвращает наблюдения начиная с since
+func (r *PostgreSQLTradeRepository) GetEvaluations(ctx context.Context, since time.Time) ([]*entities.TradeEvaluation, error) {
+	query := `
+		SELECT freqtrade_trade_id, pair, evaluated_at, profit_ratio, current_rate, threshold_crossed
+		FROM trade_evaluations
+		WHERE evaluated_at >= $1
+		ORDER BY evaluated_at`
+
+	rows, err := r.pool.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения наблюдений: %w", err)
+	}
+	defer rows.Close()
+
+	var evaluations []*entities.TradeEvaluation
+	for rows.Next() {
+		evaluation := &entities.TradeEvaluation{}
+		if err := rows.Scan(
+			&evaluation.TradeID,
+			&evaluation.Pair,
+			&evaluation.EvaluatedAt,
+			&evaluation.ProfitRatio,
+			&evaluation.CurrentRate,
+			&evaluation.ThresholdCrossed); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования наблюдения: %w", err)
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+
+	return evaluations, nil
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// SaveExchangeOrders сохраняет ордера одним пакетом; уже импортированные ордера пропускаются
+func (r *PostgreSQLTradeRepository) SaveExchangeOrders(ctx context.Context, orders []*entities.ExchangeOrder) (int, error) {
+	if len(orders) == 0 {
+		return 0, nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, order := range orders {
+		batch.Queue(`
+			INSERT INTO exchange_order_history
+			(order_id, client_order_id, pair, side, order_type, status, price, quantity, filled_qty, avg_price, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			ON CONFLICT (order_id) DO NOTHING`,
+			order.OrderID,
+			order.ClientOrderID,
+			order.Pair,
+			string(order.Side),
+			string(order.Type),
+			string(order.Status),
+			order.Price,
+			order.Quantity,
+			order.FilledQty,
+			order.AvgPrice,
+			order.CreatedAt,
+			order.UpdatedAt)
+	}
+
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+
+	inserted := 0
+	for range orders {
+		tag, err := results.Exec()
+		if err != nil {
+			return inserted, fmt.Errorf("ошибка сохранения истории ордеров: %w", err)
+		}
+		inserted += int(tag.RowsAffected())
+	}
+
+	return inserted, nil
+}
+
+// HasExchangeOrders проверяет, есть ли импортированные ордера пары
+func (r *PostgreSQLTradeRepository) HasExchangeOrders(ctx context.Context, pair string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM exchange_order_history WHERE pair = $1)", pair).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки истории ордеров: %w", err)
+	}
+	return exists, nil
+}
+
+// GetExchangeOrders возвращает импортированные ордера, созданные начиная с since
+func (r *PostgreSQLTradeRepository) GetExchangeOrders(ctx context.Context, since time.Time) ([]*entities.ExchangeOrder, error) {
+	query := `
+		SELECT order_id, client_order_id, pair, side, order_type, status,
+		       price, quantity, filled_qty, avg_price, created_at, updated_at
+		FROM exchange_order_history
+		WHERE created_at >= $1
+		ORDER BY created_at`
+
+	rows, err := r.pool.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории ордеров: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*entities.ExchangeOrder
+	for rows.Next() {
+		order := &entities.ExchangeOrder{}
+		var side, orderType, status string
+		if err := rows.Scan(
+			&order.OrderID,
+			&order.ClientOrderID,
+			&order.Pair,
+			&side,
+			&orderType,
+			&status,
+			&order.Price,
+			&order.Quantity,
+			&order.FilledQty,
+			&order.AvgPrice,
+			&order.CreatedAt,
+			&order.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования ордера: %w", err)
+		}
+		order.Side = entities.OrderSide(side)
+		order.Type = entities.OrderType(orderType)
+		order.Status = entities.OrderStatus(status)
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+
+	return orders, nil
+}
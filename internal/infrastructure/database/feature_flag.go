@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"trade-hedge/internal/domain/entities"
+)
+
+// GetFeatureFlags возвращает переключенные флаги
+func (r *PostgreSQLTradeRepository) GetFeatureFlags(ctx context.Context) ([]*entities.FeatureFlag, error) {
+	query := `
+		SELECT key, enabled, updated_at, updated_by
+		FROM feature_flags
+		ORDER BY key`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения флагов: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []*entities.FeatureFlag
+	for rows.Next() {
+		flag := &entities.FeatureFlag{}
+		if err := rows.Scan(&flag.Key, &flag.Enabled, &flag.UpdatedAt, &flag.UpdatedBy); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования флага: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения флагов: %w", err)
+	}
+
+	return flags, nil
+}
+
+// SaveFeatureFlag сохраняет состояние флага и запись журнала в одной транзакции
+func (r *PostgreSQLTradeRepository) SaveFeatureFlag(ctx context.Context, key string, enabled *bool, author string) (*entities.FeatureFlagChange, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if enabled != nil {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO feature_flags (key, enabled, updated_at, updated_by)
+			VALUES ($1, $2, NOW(), $3)
+			ON CONFLICT (key) DO UPDATE SET
+				enabled = EXCLUDED.enabled,
+				updated_at = EXCLUDED.updated_at,
+				updated_by = EXCLUDED.updated_by`,
+			key, *enabled, author)
+	} else {
+		_, err = tx.Exec(ctx, `DELETE FROM feature_flags WHERE key = $1`, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения флага %s: %w", key, err)
+	}
+
+	change := &entities.FeatureFlagChange{Key: key, Enabled: enabled, ChangedBy: author}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO feature_flag_changes (key, enabled, changed_by, changed_at)
+		VALUES ($1, $2, $3, NOW())
+		RETURNING id, changed_at`,
+		key, enabled, author).Scan(&change.ID, &change.ChangedAt)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка записи журнала флага %s: %w", key, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения флага %s: %w", key, err)
+	}
+	return change, nil
+}
+
+// GetFeatureFlagChanges возвращает журнал переключений флагов (новые первыми)
+func (r *PostgreSQLTradeRepository) GetFeatureFlagChanges(ctx context.Context, limit int) ([]*entities.FeatureFlagChange, error) {
+	query := `
+		SELECT id, key, enabled, changed_by, changed_at
+		FROM feature_flag_changes
+		ORDER BY changed_at DESC, id DESC
+		LIMIT $1`
+
+	rows, err := r.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения журнала флагов: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*entities.FeatureFlagChange
+	for rows.Next() {
+		change := &entities.FeatureFlagChange{}
+		if err := rows.Scan(&change.ID, &change.Key, &change.Enabled, &change.ChangedBy, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования переключения флага: %w", err)
+		}
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения журнала флагов: %w", err)
+	}
+
+	return changes, nil
+}
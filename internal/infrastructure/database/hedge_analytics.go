@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 	"trade-hedge/internal/domain/entities"
 )
 
@@ -10,13 +11,14 @@ import (
 func (r *PostgreSQLTradeRepository) GetHedgedTradesAnalytics(ctx context.Context) ([]*entities.HedgedTrade, error) {
 	query := `
 		SELECT 
-			freqtrade_trade_id, pair, hedge_time, bybit_order_id,
+			hedge_id, freqtrade_trade_id, pair, hedge_time, bybit_order_id,
 			freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio,
-			hedge_open_price, hedge_amount, hedge_take_profit_price
+			hedge_open_price, hedge_amount, hedge_take_profit_price,
+			COALESCE(strategy_version, ''), COALESCE(feature_flags, '')
 		FROM hedged_trades 
-		ORDER BY hedge_time DESC`
+		ORDER BY hedge_time DESC, hedge_id DESC`
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := r.query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения аналитики: %w", err)
 	}
@@ -27,6 +29,7 @@ func (r *PostgreSQLTradeRepository) GetHedgedTradesAnalytics(ctx context.Context
 	for rows.Next() {
 		trade := &entities.HedgedTrade{}
 		err := rows.Scan(
+			&trade.HedgeID,
 			&trade.FreqtradeTradeID,
 			&trade.Pair,
 			&trade.HedgeTime,
@@ -37,6 +40,8 @@ func (r *PostgreSQLTradeRepository) GetHedgedTradesAnalytics(ctx context.Context
 			&trade.HedgeOpenPrice,
 			&trade.HedgeAmount,
 			&trade.HedgeTakeProfitPrice,
+			&trade.StrategyVersion,
+			&trade.FeatureFlags,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("ошибка сканирования строки: %w", err)
@@ -55,9 +60,76 @@ func (r *PostgreSQLTradeRepository) GetHedgedTradesAnalytics(ctx context.Context
 // GetHedgedTradesCount получает количество хеджированных сделок
 func (r *PostgreSQLTradeRepository) GetHedgedTradesCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, "SELECT COUNT(*) FROM hedged_trades").Scan(&count)
+	err := r.queryRow(ctx, "SELECT COUNT(*) FROM hedged_trades").Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("ошибка получения количества хеджированных сделок: %w", err)
 	}
 	return count, nil
 }
+
+// GetQuoteExposure возвращает агрегаты хеджей по котируемой валюте.
+// Активными считаются хеджи с незавершенным и известным статусом ордера
+func (r *PostgreSQLTradeRepository) GetQuoteExposure(ctx context.Context, quote string, since time.Time) (*entities.QuoteExposure, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE order_status NOT IN ('FILLED', 'CANCELLED', 'REJECTED', 'CLOSED_MANUAL', 'UNKNOWN')),
+			COALESCE(SUM(hedge_amount * hedge_open_price)
+				FILTER (WHERE order_status NOT IN ('FILLED', 'CANCELLED', 'REJECTED', 'CLOSED_MANUAL', 'UNKNOWN')), 0),
+			COALESCE(SUM(hedge_amount * hedge_open_price) FILTER (WHERE hedge_time >= $2), 0)
+		FROM hedged_trades
+		WHERE split_part(split_part(pair, '/', 2), ':', 1) = $1`
+
+	exposure := &entities.QuoteExposure{Quote: quote}
+	err := r.queryRow(ctx, query, quote, since).Scan(
+		&exposure.OpenHedges,
+		&exposure.OpenNotional,
+		&exposure.DailyNotional,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения агрегатов по котируемой валюте %s: %w", quote, err)
+	}
+
+	return exposure, nil
+}
+
+// GetProfitTimeSeries возвращает реализованную прибыль хеджей, закрытых в [from, to), включая архив, по интервалам
+func (r *PostgreSQLTradeRepository) GetProfitTimeSeries(ctx context.Context, bucket string, from, to time.Time) ([]*entities.ProfitBucket, error) {
+	if !entities.IsProfitBucket(bucket) {
+		return nil, fmt.Errorf("неизвестный интервал ряда прибыли: %q", bucket)
+	}
+
+	// Время хранится в UTC (TIMESTAMP без часового пояса); date_trunc('week') начинает неделю с понедельника
+	query := profitSeriesQuery("date_trunc('"+bucket+"', close_time)", "$1", "$2")
+	rows, err := r.query(ctx, query, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения ряда прибыли: %w", err)
+	}
+	defer rows.Close()
+
+	var series []*entities.ProfitBucket
+	for rows.Next() {
+		item := &entities.ProfitBucket{}
+		if err := rows.Scan(&item.Start, &item.Hedges, &item.Wins, &item.RealizedProfit, &item.Fees); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования интервала ряда прибыли: %w", err)
+		}
+		item.Start = item.Start.UTC()
+		item.Finish()
+		series = append(series, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+	return series, nil
+}
+
+// GetProfitTotals возвращает итоги хеджей, закрытых до before, включая архив (начальная точка кривой прибыли)
+func (r *PostgreSQLTradeRepository) GetProfitTotals(ctx context.Context, before time.Time) (*entities.ProfitBucket, error) {
+	totals := &entities.ProfitBucket{}
+	err := r.queryRow(ctx, profitTotalsQuery("$1"), before.UTC()).Scan(
+		&totals.Hedges, &totals.Wins, &totals.RealizedProfit, &totals.Fees)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения итогов прибыли: %w", err)
+	}
+	totals.Finish()
+	return totals, nil
+}
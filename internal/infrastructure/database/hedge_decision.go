@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// SaveHedgeDecisions сохраняет решения одного цикла одним пакетом
+func (r *PostgreSQLTradeRepository) SaveHedgeDecisions(ctx context.Context, decisions []*entities.HedgeDecision) error {
+	if len(decisions) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, decision := range decisions {
+		batch.Queue(`
+			INSERT INTO hedge_decisions
+			(freqtrade_trade_id, pair, reason, details, drawdown_percent, decided_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			decision.TradeID,
+			decision.Pair,
+			decision.Reason,
+			decision.Details,
+			decision.DrawdownPercent,
+			decision.DecidedAt)
+	}
+
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for range decisions {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("ошибка сохранения решений по сделкам: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetHedgeDecisions возвращает решения в интервале [from, to)
+func (r *PostgreSQLTradeRepository) GetHedgeDecisions(ctx context.Context, from, to time.Time) ([]*entities.HedgeDecision, error) {
+	query := `
+		SELECT id, freqtrade_trade_id, pair, reason, details, drawdown_percent, decided_at
+		FROM hedge_decisions
+		WHERE decided_at >= $1 AND decided_at < $2
+		ORDER BY decided_at, id`
+
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения решений по сделкам: %w", err)
+	}
+	defer rows.Close()
+
+	var decisions []*entities.HedgeDecision
+	for rows.Next() {
+		decision := &entities.HedgeDecision{}
+		if err := rows.Scan(
+			&decision.ID,
+			&decision.TradeID,
+			&decision.Pair,
+			&decision.Reason,
+			&decision.Details,
+			&decision.DrawdownPercent,
+			&decision.DecidedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования решения: %w", err)
+		}
+		decisions = append(decisions, decision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения решений по сделкам: %w", err)
+	}
+
+	return decisions, nil
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"trade-hedge/internal/domain/entities"
+)
+
+// hedgeGroupColumns колонки хедж-группы в порядке сканирования queryHedgeGroups
+const hedgeGroupColumns = `id, freqtrade_trade_id, pair, total_amount, leg_amount, legs_planned, legs_placed,
+	take_profit_price, status, status_reason, next_leg_at, created_at, updated_at`
+
+// SaveHedgeGroup сохраняет новую хедж-группу и заполняет ее ID
+func (r *PostgreSQLTradeRepository) SaveHedgeGroup(ctx context.Context, group *entities.HedgeGroup) error {
+	query := `
+		INSERT INTO hedge_groups
+		(freqtrade_trade_id, pair, total_amount, leg_amount, legs_planned, legs_placed,
+		 take_profit_price, status, status_reason, next_leg_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id`
+
+	err := r.queryRow(ctx, query,
+		group.FreqtradeTradeID,
+		group.Pair,
+		group.TotalAmount,
+		group.LegAmount,
+		group.LegsPlanned,
+		group.LegsPlaced,
+		group.TakeProfitPrice,
+		group.Status.String(),
+		group.StatusReason,
+		group.NextLegAt,
+		group.CreatedAt,
+		group.UpdatedAt).Scan(&group.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения хедж-группы: %w", err)
+	}
+	return nil
+}
+
+// UpdateHedgeGroup сохраняет прогресс и состояние хедж-группы
+func (r *PostgreSQLTradeRepository) UpdateHedgeGroup(ctx context.Context, group *entities.HedgeGroup) error {
+	query := `
+		UPDATE hedge_groups
+		SET legs_placed = $1, take_profit_price = $2, status = $3, status_reason = $4,
+		    next_leg_at = $5, updated_at = $6
+		WHERE id = $7`
+
+	err := r.exec(ctx, query,
+		group.LegsPlaced,
+		group.TakeProfitPrice,
+		group.Status.String(),
+		group.StatusReason,
+		group.NextLegAt,
+		group.UpdatedAt,
+		group.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления хедж-группы %d: %w", group.ID, err)
+	}
+	return nil
+}
+
+// GetActiveHedgeGroups возвращает группы, которые еще размещают ноги (старые первыми)
+func (r *PostgreSQLTradeRepository) GetActiveHedgeGroups(ctx context.Context) ([]*entities.HedgeGroup, error) {
+	groups, err := r.queryHedgeGroups(ctx,
+		"SELECT "+hedgeGroupColumns+" FROM hedge_groups WHERE status = $1 ORDER BY created_at, id",
+		entities.HedgeGroupStatusActive.String())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения активных хедж-групп: %w", err)
+	}
+	return groups, nil
+}
+
+// GetHedgeGroups возвращает последние limit хедж-групп (новые первыми)
+func (r *PostgreSQLTradeRepository) GetHedgeGroups(ctx context.Context, limit int) ([]*entities.HedgeGroup, error) {
+	groups, err := r.queryHedgeGroups(ctx,
+		"SELECT "+hedgeGroupColumns+" FROM hedge_groups ORDER BY created_at DESC, id DESC LIMIT $1", limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения хедж-групп: %w", err)
+	}
+	return groups, nil
+}
+
+// GetHedgeGroupLegs возвращает ноги хедж-группы, включая архив (старые первыми)
+func (r *PostgreSQLTradeRepository) GetHedgeGroupLegs(ctx context.Context, groupID int64) ([]*entities.HedgedTrade, error) {
+	query := "SELECT " + hedgedTradeColumns + " FROM hedged_trades WHERE hedge_group_id = $1" +
+		" UNION ALL SELECT " + hedgedTradeColumns + " FROM hedged_trades_archive WHERE hedge_group_id = $1" +
+		" ORDER BY 5, 1"
+
+	legs, err := r.queryHedgedTrades(ctx, query, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения ног хедж-группы %d: %w", groupID, err)
+	}
+	return legs, nil
+}
+
+// queryHedgeGroups выполняет запрос с колонками hedgeGroupColumns
+func (r *PostgreSQLTradeRepository) queryHedgeGroups(ctx context.Context, query string, args ...interface{}) ([]*entities.HedgeGroup, error) {
+	rows, err := r.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*entities.HedgeGroup
+	for rows.Next() {
+		group := &entities.HedgeGroup{}
+		var status string
+		if err := rows.Scan(
+			&group.ID,
+			&group.FreqtradeTradeID,
+			&group.Pair,
+			&group.TotalAmount,
+			&group.LegAmount,
+			&group.LegsPlanned,
+			&group.LegsPlaced,
+			&group.TakeProfitPrice,
+			&status,
+			&group.StatusReason,
+			&group.NextLegAt,
+			&group.CreatedAt,
+			&group.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования хедж-группы: %w", err)
+		}
+		group.Status = entities.HedgeGroupStatus(status)
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+	return groups, nil
+}
+
+// nullableID возвращает nil для нулевого ID, чтобы необязательная ссылка хранилась как NULL
+func nullableID(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"trade-hedge/internal/domain/entities"
+)
+
+// hedgeIntentColumns колонки таблицы намерений хеджирования в порядке сканирования
+const hedgeIntentColumns = `client_order_id, freqtrade_trade_id, pair, tranche, attempt, quantity, price, state,
+		created_at, updated_at, COALESCE(buy_order_id, ''), COALESCE(filled_qty, 0), COALESCE(tp_order_id, ''),
+		freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio, current_rate`
+
+// SaveHedgeIntent сохраняет намерение хеджирования
+func (r *PostgreSQLTradeRepository) SaveHedgeIntent(ctx context.Context, intent *entities.HedgeIntent) error {
+	query := `
+		INSERT INTO hedge_intents
+		(client_order_id, freqtrade_trade_id, pair, tranche, attempt, quantity, price, state,
+		 created_at, updated_at, buy_order_id, filled_qty, tp_order_id,
+		 freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio, current_rate)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), $12, NULLIF($13, ''), $14, $15, $16, $17)`
+
+	_, err := r.pool.Exec(ctx, query,
+		intent.ClientOrderID,
+		intent.FreqtradeTradeID,
+		intent.Pair,
+		intent.Tranche,
+		intent.Attempt,
+		intent.Quantity,
+		intent.Price,
+		intent.State.String(),
+		intent.CreatedAt,
+		intent.UpdatedAt,
+		intent.BuyOrderID,
+		intent.FilledQty,
+		intent.TakeProfitOrderID,
+		intent.FreqtradeOpenPrice,
+		intent.FreqtradeAmount,
+		intent.FreqtradeProfitRatio,
+		intent.CurrentRate)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения намерения хеджирования: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateHedgeIntent сохраняет состояние намерения и данные ордеров
+func (r *PostgreSQLTradeRepository) UpdateHedgeIntent(ctx context.Context, intent *entities.HedgeIntent) error {
+	query := `
+		UPDATE hedge_intents
+		SET state = $1, updated_at = $2, buy_order_id = NULLIF($3, ''), filled_qty = $4, tp_order_id = NULLIF($5, '')
+		WHERE client_order_id = $6`
+
+	result, err := r.pool.Exec(ctx, query,
+		intent.State.String(),
+		intent.UpdatedAt,
+		intent.BuyOrderID,
+		intent.FilledQty,
+		intent.TakeProfitOrderID,
+		intent.ClientOrderID)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления намерения хеджирования: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("намерение хеджирования %s не найдено", intent.ClientOrderID)
+	}
+
+	return nil
+}
+
+// GetInFlightHedgeIntents возвращает намерения, не дошедшие до тейк-профита
+func (r *PostgreSQLTradeRepository) GetInFlightHedgeIntents(ctx context.Context) ([]*entities.HedgeIntent, error) {
+	states := make([]string, len(entities.InFlightHedgeStates))
+	for i, state := range entities.InFlightHedgeStates {
+		states[i] = state.String()
+	}
+
+	query := `SELECT ` + hedgeIntentColumns + `
+		FROM hedge_intents
+		WHERE state = ANY($1)
+		ORDER BY created_at ASC`
+
+	rows, err := r.pool.Query(ctx, query, states)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения намерений хеджирования: %w", err)
+	}
+	defer rows.Close()
+
+	var intents []*entities.HedgeIntent
+	for rows.Next() {
+		intent, err := scanHedgeIntent(rows)
+		if err != nil {
+			return nil, err
+		}
+		intents = append(intents, intent)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+
+	return intents, nil
+}
+
+// GetHedgeIntentByOrderID находит намерение по ID ордера на покупку или тейк-профита
+func (r *PostgreSQLTradeRepository) GetHedgeIntentByOrderID(ctx context.Context, orderID string) (*entities.HedgeIntent, error) {
+	query := `SELECT ` + hedgeIntentColumns + `
+		FROM hedge_intents
+		WHERE buy_order_id = $1 OR tp_order_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	rows, err := r.pool.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска намерения хеджирования: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	return scanHedgeIntent(rows)
+}
+
+// CountHedgeIntents возвращает количество намерений для сделки и номера хеджа
+func (r *PostgreSQLTradeRepository) CountHedgeIntents(ctx context.Context, tradeID, tranche int) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx,
+		"SELECT COUNT(*) FROM hedge_intents WHERE freqtrade_trade_id = $1 AND tranche = $2",
+		tradeID, tranche).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка подсчета намерений хеджирования: %w", err)
+	}
+	return count, nil
+}
+
+// hedgeIntentScanner источник строк для сканирования намерения
+type hedgeIntentScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanHedgeIntent сканирует намерение хеджирования из строки результата
+func scanHedgeIntent(row hedgeIntentScanner) (*entities.HedgeIntent, error) {
+	intent := &entities.HedgeIntent{}
+	var state string
+	err := row.Scan(
+		&intent.ClientOrderID,
+		&intent.FreqtradeTradeID,
+		&intent.Pair,
+		&intent.Tranche,
+		&intent.Attempt,
+		&intent.Quantity,
+		&intent.Price,
+		&state,
+		&intent.CreatedAt,
+		&intent.UpdatedAt,
+		&intent.BuyOrderID,
+		&intent.FilledQty,
+		&intent.TakeProfitOrderID,
+		&intent.FreqtradeOpenPrice,
+		&intent.FreqtradeAmount,
+		&intent.FreqtradeProfitRatio,
+		&intent.CurrentRate)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сканирования намерения хеджирования: %w", err)
+	}
+	intent.State = entities.HedgeState(state)
+	return intent, nil
+}
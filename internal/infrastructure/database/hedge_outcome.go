@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"trade-hedge/internal/domain/entities"
+)
+
+// SaveHedgeOutcome сохраняет итог хеджирования по сделке Freqtrade
+func (r *PostgreSQLTradeRepository) SaveHedgeOutcome(ctx context.Context, outcome *entities.HedgeOutcome) error {
+	query := `
+		INSERT INTO hedge_outcomes
+		(freqtrade_trade_id, pair, freqtrade_profit, hedge_profit, net_outcome, hedges, trade_close_time, calculated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (freqtrade_trade_id) DO UPDATE SET
+			pair = EXCLUDED.pair,
+			freqtrade_profit = EXCLUDED.freqtrade_profit,
+			hedge_profit = EXCLUDED.hedge_profit,
+			net_outcome = EXCLUDED.net_outcome,
+			hedges = EXCLUDED.hedges,
+			trade_close_time = EXCLUDED.trade_close_time,
+			calculated_at = EXCLUDED.calculated_at`
+
+	_, err := r.pool.Exec(ctx, query,
+		outcome.FreqtradeTradeID,
+		outcome.Pair,
+		outcome.FreqtradeProfit,
+		outcome.HedgeProfit,
+		outcome.NetOutcome,
+		outcome.Hedges,
+		outcome.TradeCloseTime,
+		outcome.CalculatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения итога хеджирования: %w", err)
+	}
+
+	return nil
+}
+
+// GetHedgeOutcomes возвращает рассчитанные итоги хеджирования
+func (r *PostgreSQLTradeRepository) GetHedgeOutcomes(ctx context.Context) ([]*entities.HedgeOutcome, error) {
+	query := `
+		SELECT freqtrade_trade_id, pair, freqtrade_profit, hedge_profit, net_outcome, hedges,
+			   COALESCE(trade_close_time, calculated_at), calculated_at
+		FROM hedge_outcomes
+		ORDER BY trade_close_time DESC NULLS LAST`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения итогов хеджирования: %w", err)
+	}
+	defer rows.Close()
+
+	var outcomes []*entities.HedgeOutcome
+	for rows.Next() {
+		outcome := &entities.HedgeOutcome{}
+		if err := rows.Scan(
+			&outcome.FreqtradeTradeID,
+			&outcome.Pair,
+			&outcome.FreqtradeProfit,
+			&outcome.HedgeProfit,
+			&outcome.NetOutcome,
+			&outcome.Hedges,
+			&outcome.TradeCloseTime,
+			&outcome.CalculatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования итога хеджирования: %w", err)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+
+	return outcomes, nil
+}
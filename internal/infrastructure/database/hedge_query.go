@@ -0,0 +1,72 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"trade-hedge/internal/domain/entities"
+)
+
+// hedgeSortExpressions SQL-выражения полей сортировки выборки хеджей
+var hedgeSortExpressions = map[string]string{
+	entities.HedgeSortTime:      "hedge_time",
+	entities.HedgeSortPair:      "pair",
+	entities.HedgeSortStatus:    "order_status",
+	entities.HedgeSortAmount:    "hedge_amount",
+	entities.HedgeSortOrderSize: "hedge_amount * hedge_open_price",
+	entities.HedgeSortTradeID:   "freqtrade_trade_id",
+}
+
+// hedgeTradeQuerySQL строит условие WHERE, порядок ORDER BY и LIMIT/OFFSET выборки хеджей.
+// placeholder возвращает параметр запроса по номеру: $1 для PostgreSQL, ? для SQLite;
+// unlimited - значение LIMIT без ограничения для смещения без лимита: ALL для PostgreSQL, -1 для SQLite
+func hedgeTradeQuerySQL(query *entities.HedgeTradeQuery, placeholder func(n int) string, unlimited string) (where, orderBy, limit string, args []interface{}) {
+	var conditions []string
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, placeholder(len(args))))
+	}
+
+	if query.HedgeID != 0 {
+		addCondition("hedge_id = %s", query.HedgeID)
+	}
+	if query.Status != nil {
+		addCondition("order_status = %s", *query.Status)
+	}
+	if query.Pair != "" {
+		addCondition("pair = %s", query.Pair)
+	}
+	if query.StrategyVersion != "" {
+		addCondition("strategy_version = %s", query.StrategyVersion)
+	}
+	if query.From != nil {
+		addCondition("hedge_time >= %s", *query.From)
+	}
+	if query.To != nil {
+		addCondition("hedge_time < %s", *query.To)
+	}
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	direction := "DESC"
+	if query.Ascending {
+		direction = "ASC"
+	}
+	if query.SortBy == entities.HedgeSortCloseTime {
+		// Открытые хеджи без времени закрытия считаются самыми поздними в обеих СУБД
+		orderBy = fmt.Sprintf(" ORDER BY (close_time IS NULL) %s, close_time %s, hedge_id %s", direction, direction, direction)
+	} else {
+		expression, ok := hedgeSortExpressions[query.SortBy]
+		if !ok {
+			expression = hedgeSortExpressions[entities.HedgeSortTime]
+		}
+		orderBy = fmt.Sprintf(" ORDER BY %s %s, hedge_id %s", expression, direction, direction)
+	}
+
+	if query.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d OFFSET %d", query.Limit, query.Offset)
+	} else if query.Offset > 0 {
+		limit = fmt.Sprintf(" LIMIT %s OFFSET %d", unlimited, query.Offset)
+	}
+	return where, orderBy, limit, args
+}
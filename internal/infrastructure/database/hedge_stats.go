@@ -0,0 +1,91 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// hedgeStatsQueries строит запросы агрегированной статистики хеджей таблицы table по условию WHERE выборки:
+// итоги и разбивку по версиям стратегии. holdSeconds - выражение длительности хеджа в секундах,
+// которое в PostgreSQL и SQLite записывается по-разному
+func hedgeStatsQueries(table, where, holdSeconds string) (totals, byVersion string) {
+	completed := completedCondition()
+	closed := completed + " AND close_price IS NOT NULL"
+
+	totals = fmt.Sprintf(`SELECT COUNT(*),
+		COALESCE(SUM(CASE WHEN %[1]s THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(hedge_amount * hedge_open_price), 0),
+		COALESCE(SUM(CASE WHEN %[2]s THEN %[3]s ELSE 0 END), 0),
+		COALESCE(SUM(CASE WHEN %[2]s THEN %[4]s ELSE 0 END), 0),
+		COALESCE(SUM(CASE WHEN %[2]s AND %[3]s - %[4]s > 0 THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(CASE WHEN %[2]s AND %[3]s - %[4]s < 0 THEN 1 ELSE 0 END), 0),
+		AVG(CASE WHEN %[1]s AND close_time IS NOT NULL THEN %[5]s END)
+		FROM %[6]s`, completed, closed, hedgeProfitExpr, hedgeFeesExpr, holdSeconds, table) + where
+
+	byVersion = fmt.Sprintf(`SELECT COALESCE(strategy_version, ''), COUNT(*),
+		COALESCE(SUM(CASE WHEN %[1]s THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(CASE WHEN %[2]s THEN %[3]s ELSE 0 END), 0)
+		FROM %[4]s`, completed, closed, hedgeProfitExpr, table) + where +
+		" GROUP BY COALESCE(strategy_version, '') ORDER BY MAX(hedge_time) DESC"
+	return totals, byVersion
+}
+
+// Выражения прибыли до комиссий и комиссий хеджа
+const (
+	hedgeProfitExpr = "(close_price - hedge_open_price) * hedge_amount"
+	hedgeFeesExpr   = "(COALESCE(entry_fee, 0) + COALESCE(exit_fee, 0))"
+)
+
+// completedCondition возвращает условие завершенного хеджа (терминальный статус ордера)
+func completedCondition() string {
+	statuses := make([]string, 0, len(entities.CompletedOrderStatuses()))
+	for _, status := range entities.CompletedOrderStatuses() {
+		statuses = append(statuses, "'"+status.String()+"'")
+	}
+	return "order_status IN (" + strings.Join(statuses, ", ") + ")"
+}
+
+// profitSeriesQuery строит запрос ряда прибыли хеджей рабочей таблицы и архива, закрытых в [from, to).
+// bucketStart - выражение начала интервала по close_time, from и to - плейсхолдеры границ;
+// колонки: начало интервала, хеджей, прибыльных хеджей, прибыль до комиссий, комиссии
+func profitSeriesQuery(bucketStart, from, to string) string {
+	source := closedHedgesSource(fmt.Sprintf("close_time >= %s AND close_time < %s", from, to))
+
+	return fmt.Sprintf(`SELECT %[1]s, COUNT(*),
+		COALESCE(SUM(CASE WHEN %[2]s - %[3]s > 0 THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(%[2]s), 0),
+		COALESCE(SUM(%[3]s), 0)
+		FROM (%[4]s) AS closed
+		GROUP BY 1 ORDER BY 1`, bucketStart, hedgeProfitExpr, hedgeFeesExpr, source)
+}
+
+// profitTotalsQuery строит запрос итогов хеджей рабочей таблицы и архива, закрытых до before (плейсхолдер);
+// колонки: хеджей, прибыльных хеджей, прибыль до комиссий, комиссии
+func profitTotalsQuery(before string) string {
+	source := closedHedgesSource("close_time < " + before)
+
+	return fmt.Sprintf(`SELECT COUNT(*),
+		COALESCE(SUM(CASE WHEN %[1]s - %[2]s > 0 THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(%[1]s), 0),
+		COALESCE(SUM(%[2]s), 0)
+		FROM (%[3]s) AS closed`, hedgeProfitExpr, hedgeFeesExpr, source)
+}
+
+// closedHedgesSource строит выборку закрытых хеджей рабочей таблицы и архива по условию на close_time
+func closedHedgesSource(closeTimeCondition string) string {
+	closed := fmt.Sprintf("%s AND close_price IS NOT NULL AND %s", completedCondition(), closeTimeCondition)
+	columns := "close_time, close_price, hedge_open_price, hedge_amount, entry_fee, exit_fee"
+	return fmt.Sprintf("SELECT %[1]s FROM %[2]s WHERE %[4]s UNION ALL SELECT %[1]s FROM %[3]s WHERE %[4]s",
+		columns, hedgedTradesTable, hedgedTradesArchiveTable, closed)
+}
+
+// finishHedgeStats заполняет производные показатели статистики
+func finishHedgeStats(stats *entities.HedgeStats, avgHoldSeconds *float64) {
+	stats.Active = stats.Total - stats.Completed
+	stats.NetProfit = stats.RealizedProfit - stats.Fees
+	if avgHoldSeconds != nil {
+		stats.AvgHoldTime = time.Duration(*avgHoldSeconds * float64(time.Second))
+	}
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"trade-hedge/internal/domain/entities"
+)
+
+// SaveJournalEntry сохраняет запись журнала
+func (r *PostgreSQLTradeRepository) SaveJournalEntry(ctx context.Context, entry *entities.JournalEntry) error {
+	query := `
+		INSERT INTO journal_entries (created_at, entry_date, hedge_order_id, text)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		RETURNING id`
+
+	err := r.pool.QueryRow(ctx, query,
+		entry.CreatedAt,
+		entry.EntryDate,
+		entry.HedgeOrderID,
+		entry.Text).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения записи журнала: %w", err)
+	}
+
+	return nil
+}
+
+// GetJournalEntries возвращает все записи журнала (новые первыми)
+func (r *PostgreSQLTradeRepository) GetJournalEntries(ctx context.Context) ([]*entities.JournalEntry, error) {
+	query := `
+		SELECT id, created_at, entry_date, COALESCE(hedge_order_id, ''), text
+		FROM journal_entries
+		ORDER BY entry_date DESC, created_at DESC, id DESC`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения записей журнала: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entities.JournalEntry
+	for rows.Next() {
+		entry := &entities.JournalEntry{}
+		if err := rows.Scan(&entry.ID, &entry.CreatedAt, &entry.EntryDate, &entry.HedgeOrderID, &entry.Text); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования записи журнала: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+
+	return entries, nil
+}
+
+// DeleteJournalEntry удаляет запись журнала по ID
+func (r *PostgreSQLTradeRepository) DeleteJournalEntry(ctx context.Context, id int) error {
+	result, err := r.pool.Exec(ctx, "DELETE FROM journal_entries WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления записи журнала: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("запись журнала %d не найдена", id)
+	}
+	return nil
+}
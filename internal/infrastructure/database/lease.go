@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// AcquireLease получает или продлевает аренду. Время сравнивается по часам БД,
+// чтобы расхождение часов экземпляров не влияло на истечение аренды
+func (r *PostgreSQLTradeRepository) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*entities.Lease, error) {
+	query := `
+		INSERT INTO instance_leases (name, holder, acquired_at, expires_at, handoff_requested_by)
+		VALUES ($1, $2, NOW(), NOW() + $3 * INTERVAL '1 second', '')
+		ON CONFLICT (name) DO UPDATE SET
+			holder = EXCLUDED.holder,
+			acquired_at = CASE WHEN instance_leases.holder = EXCLUDED.holder
+				THEN instance_leases.acquired_at ELSE NOW() END,
+			expires_at = EXCLUDED.expires_at,
+			handoff_requested_by = CASE WHEN instance_leases.holder = EXCLUDED.holder
+				THEN instance_leases.handoff_requested_by ELSE '' END
+		WHERE instance_leases.holder = EXCLUDED.holder
+			OR instance_leases.holder = ''
+			OR instance_leases.expires_at < NOW()
+		RETURNING name, holder, acquired_at, expires_at, handoff_requested_by`
+
+	lease := &entities.Lease{}
+	err := r.pool.QueryRow(ctx, query, name, holder, ttl.Seconds()).Scan(
+		&lease.Name,
+		&lease.Holder,
+		&lease.AcquiredAt,
+		&lease.ExpiresAt,
+		&lease.HandoffRequestedBy)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения аренды %s: %w", name, err)
+	}
+
+	return lease, nil
+}
+
+// ReleaseLease освобождает аренду, если она принадлежит holder
+func (r *PostgreSQLTradeRepository) ReleaseLease(ctx context.Context, name, holder string) error {
+	query := `
+		UPDATE instance_leases
+		SET holder = '', expires_at = NOW(), handoff_requested_by = ''
+		WHERE name = $1 AND holder = $2`
+
+	if _, err := r.pool.Exec(ctx, query, name, holder); err != nil {
+		return fmt.Errorf("ошибка освобождения аренды %s: %w", name, err)
+	}
+	return nil
+}
+
+// RequestHandoff отмечает запрос на передачу аренды, занятой другим экземпляром
+func (r *PostgreSQLTradeRepository) RequestHandoff(ctx context.Context, name, requester string) error {
+	query := `
+		UPDATE instance_leases
+		SET handoff_requested_by = $2
+		WHERE name = $1 AND holder <> '' AND holder <> $2`
+
+	if _, err := r.pool.Exec(ctx, query, name, requester); err != nil {
+		return fmt.Errorf("ошибка запроса передачи аренды %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetLease возвращает текущее состояние аренды
+func (r *PostgreSQLTradeRepository) GetLease(ctx context.Context, name string) (*entities.Lease, error) {
+	query := `
+		SELECT name, holder, acquired_at, expires_at, handoff_requested_by
+		FROM instance_leases
+		WHERE name = $1`
+
+	lease := &entities.Lease{}
+	err := r.pool.QueryRow(ctx, query, name).Scan(
+		&lease.Name,
+		&lease.Holder,
+		&lease.AcquiredAt,
+		&lease.ExpiresAt,
+		&lease.HandoffRequestedBy)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения состояния аренды %s: %w", name, err)
+	}
+
+	return lease, nil
+}
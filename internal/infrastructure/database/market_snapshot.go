@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"trade-hedge/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// SaveMarketSnapshot заменяет сохраненный снимок балансов и цен
+func (r *PostgreSQLTradeRepository) SaveMarketSnapshot(ctx context.Context, snapshot *entities.MarketSnapshot) error {
+	balances, err := json.Marshal(snapshot.Balances)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации балансов снимка: %w", err)
+	}
+	prices, err := json.Marshal(snapshot.Prices)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации цен снимка: %w", err)
+	}
+
+	query := `
+		INSERT INTO market_snapshot (id, balances, balances_at, prices, updated_at)
+		VALUES (1, $1, $2, $3, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			balances = EXCLUDED.balances,
+			balances_at = EXCLUDED.balances_at,
+			prices = EXCLUDED.prices,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.pool.Exec(ctx, query, balances, snapshot.BalancesAt, prices); err != nil {
+		return fmt.Errorf("ошибка сохранения снимка балансов и цен: %w", err)
+	}
+	return nil
+}
+
+// GetMarketSnapshot возвращает сохраненный снимок балансов и цен (nil, если снимка еще нет)
+func (r *PostgreSQLTradeRepository) GetMarketSnapshot(ctx context.Context) (*entities.MarketSnapshot, error) {
+	var balances, prices []byte
+	snapshot := entities.NewMarketSnapshot()
+
+	err := r.pool.QueryRow(ctx, "SELECT balances, balances_at, prices FROM market_snapshot WHERE id = 1").
+		Scan(&balances, &snapshot.BalancesAt, &prices)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения снимка балансов и цен: %w", err)
+	}
+
+	if err := json.Unmarshal(balances, &snapshot.Balances); err != nil {
+		return nil, fmt.Errorf("ошибка разбора балансов снимка: %w", err)
+	}
+	if err := json.Unmarshal(prices, &snapshot.Prices); err != nil {
+		return nil, fmt.Errorf("ошибка разбора цен снимка: %w", err)
+	}
+	return snapshot, nil
+}
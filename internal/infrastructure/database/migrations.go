@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// migrationFiles SQL-миграции схемы PostgreSQL в порядке номеров (NNNN_название.sql)
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID ключ advisory lock, под которым применяются миграции:
+// экземпляры, запущенные одновременно, не применяют одну миграцию дважды
+const migrationLockID = 7_406_402
+
+// migrationFileName формат имени файла миграции: номер версии и название
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+// Migration версионированная миграция схемы
+type Migration struct {
+	Version  int    // Номер версии (из имени файла)
+	Name     string // Название (из имени файла)
+	SQL      string // Текст миграции
+	Checksum string // SHA-256 текста: изменение уже примененной миграции считается ошибкой
+}
+
+// LoadMigrations возвращает встроенные миграции, упорядоченные по версии
+func LoadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения миграций: %w", err)
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	versions := make(map[int]string, len(entries))
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("некорректное имя файла миграции: %s", entry.Name())
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("некорректный номер версии миграции: %s", entry.Name())
+		}
+		if existing, ok := versions[version]; ok {
+			return nil, fmt.Errorf("миграции %s и %s имеют одинаковую версию %d", existing, entry.Name(), version)
+		}
+		versions[version] = entry.Name()
+
+		content, err := migrationFiles.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения миграции %s: %w", entry.Name(), err)
+		}
+		checksum := sha256.Sum256(content)
+
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     match[2],
+			SQL:      string(content),
+			Checksum: hex.EncodeToString(checksum[:]),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Migrate применяет еще не примененные миграции и возвращает их количество.
+// Каждая миграция выполняется в отдельной транзакции вместе с записью в schema_migrations.
+// Если примененная миграция отсутствует в приложении или ее текст изменился, возвращается ошибка:
+// схема базы расходится с ожидаемой, и продолжать работу нельзя
+func (r *PostgreSQLTradeRepository) Migrate(ctx context.Context) (int, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return 0, err
+	}
+
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения соединения для миграций: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return 0, fmt.Errorf("ошибка блокировки миграций: %w", err)
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	_, err = conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка создания таблицы schema_migrations: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, "SELECT version, name, checksum FROM schema_migrations")
+	if err != nil {
+		return 0, fmt.Errorf("ошибка чтения примененных миграций: %w", err)
+	}
+	applied := make(map[int]Migration)
+	for rows.Next() {
+		var migration Migration
+		if err := rows.Scan(&migration.Version, &migration.Name, &migration.Checksum); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("ошибка чтения примененной миграции: %w", err)
+		}
+		applied[migration.Version] = migration
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("ошибка чтения примененных миграций: %w", err)
+	}
+
+	known := make(map[int]bool, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = true
+		if existing, ok := applied[migration.Version]; ok && existing.Checksum != migration.Checksum {
+			return 0, fmt.Errorf("миграция %04d_%s изменена после применения", migration.Version, migration.Name)
+		}
+	}
+	for version, migration := range applied {
+		if !known[version] {
+			return 0, fmt.Errorf("в базе применена неизвестная миграция %04d_%s: база обновлена более новой версией приложения",
+				version, migration.Name)
+		}
+	}
+
+	count := 0
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return count, fmt.Errorf("ошибка начала транзакции миграции %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := tx.Exec(ctx, migration.SQL); err != nil {
+			tx.Rollback(ctx)
+			return count, fmt.Errorf("ошибка применения миграции %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := tx.Exec(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum); err != nil {
+			tx.Rollback(ctx)
+			return count, fmt.Errorf("ошибка записи миграции %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return count, fmt.Errorf("ошибка фиксации миграции %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		logger.LogWithTime("🗄️ Применена миграция %04d_%s", migration.Version, migration.Name)
+		count++
+	}
+
+	return count, nil
+}
+
+// RunMigrations подключается к PostgreSQL, применяет миграции и закрывает соединение.
+// Используется точкой входа с флагом --migrate-only
+func RunMigrations(ctx context.Context, config *config.Config) (int, error) {
+	pool, err := connectPool(ctx, config)
+	if err != nil {
+		return 0, err
+	}
+	defer pool.Close()
+
+	repo := &PostgreSQLTradeRepository{pool: pool}
+	return repo.Migrate(ctx)
+}
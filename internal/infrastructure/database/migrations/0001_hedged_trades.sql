@@ -0,0 +1,41 @@
+-- Хеджированные сделки
+CREATE TABLE IF NOT EXISTS hedged_trades (
+	freqtrade_trade_id INTEGER PRIMARY KEY,
+	pair TEXT NOT NULL,
+	hedge_time TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	bybit_order_id TEXT,
+
+	-- Информация об исходной сделке Freqtrade
+	freqtrade_open_price FLOAT NOT NULL,
+	freqtrade_amount FLOAT NOT NULL,
+	freqtrade_profit_ratio FLOAT NOT NULL,
+
+	-- Информация о хеджирующей позиции
+	hedge_open_price FLOAT NOT NULL,
+	hedge_amount FLOAT NOT NULL,
+	hedge_take_profit_price FLOAT NOT NULL
+);
+
+-- Колонки, добавленные до появления миграций: в базах, созданных старыми версиями, их может не быть
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS freqtrade_open_price FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS freqtrade_amount FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS freqtrade_profit_ratio FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS hedge_open_price FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS hedge_amount FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS hedge_take_profit_price FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS order_status TEXT DEFAULT 'PENDING';
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS last_status_check TIMESTAMP;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS close_price FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS close_time TIMESTAMP;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS buy_order_id TEXT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS buy_requested_qty FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS buy_filled_qty FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS strategy_version TEXT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS feature_flags TEXT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS stop_loss_price FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS stop_loss_order_id TEXT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS entry_fee FLOAT DEFAULT 0;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS exit_fee FLOAT DEFAULT 0;
+
+-- Хеджи, созданные до появления версионирования, помечаем как legacy
+UPDATE hedged_trades SET strategy_version = 'legacy' WHERE strategy_version IS NULL;
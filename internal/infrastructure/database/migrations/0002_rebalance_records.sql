@@ -0,0 +1,14 @@
+-- Аудит ребалансировки
+CREATE TABLE IF NOT EXISTS rebalance_records (
+	id SERIAL PRIMARY KEY,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	period_start TIMESTAMP NOT NULL,
+	period_end TIMESTAMP NOT NULL,
+	total_profit FLOAT NOT NULL,
+	asset TEXT NOT NULL,
+	target_percent FLOAT NOT NULL,
+	quote_amount FLOAT NOT NULL,
+	order_id TEXT,
+	success BOOLEAN NOT NULL,
+	error TEXT
+);
@@ -0,0 +1,44 @@
+-- Намерения хеджирования и их состояния
+CREATE TABLE IF NOT EXISTS hedge_intents (
+	client_order_id TEXT PRIMARY KEY,
+	freqtrade_trade_id INTEGER NOT NULL,
+	pair TEXT NOT NULL,
+	tranche INTEGER NOT NULL,
+	attempt INTEGER NOT NULL,
+	quantity FLOAT NOT NULL,
+	price FLOAT NOT NULL,
+	state TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	buy_order_id TEXT,
+	filled_qty FLOAT,
+	tp_order_id TEXT,
+	freqtrade_open_price FLOAT NOT NULL,
+	freqtrade_amount FLOAT NOT NULL,
+	freqtrade_profit_ratio FLOAT NOT NULL,
+	current_rate FLOAT NOT NULL
+);
+
+-- Переход от статусов намерений к машине состояний (базы, созданные старыми версиями)
+DO $$
+BEGIN
+	IF EXISTS (
+		SELECT 1 FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'hedge_intents' AND column_name = 'status'
+	) THEN
+		ALTER TABLE hedge_intents RENAME COLUMN status TO state;
+	END IF;
+END $$;
+
+ALTER TABLE hedge_intents ADD COLUMN IF NOT EXISTS buy_order_id TEXT;
+ALTER TABLE hedge_intents ADD COLUMN IF NOT EXISTS filled_qty FLOAT;
+ALTER TABLE hedge_intents ADD COLUMN IF NOT EXISTS tp_order_id TEXT;
+
+UPDATE hedge_intents SET state = 'INTENT' WHERE state = 'PENDING';
+UPDATE hedge_intents SET state = 'TP_PLACED' WHERE state = 'COMPLETED';
+UPDATE hedge_intents SET state = 'CLOSED' WHERE state = 'ABANDONED';
+
+DROP INDEX IF EXISTS idx_hedge_intents_status;
+CREATE INDEX IF NOT EXISTS idx_hedge_intents_state ON hedge_intents (state);
+CREATE INDEX IF NOT EXISTS idx_hedge_intents_buy_order_id ON hedge_intents (buy_order_id);
+CREATE INDEX IF NOT EXISTS idx_hedge_intents_tp_order_id ON hedge_intents (tp_order_id);
@@ -0,0 +1,8 @@
+-- Торговый журнал
+CREATE TABLE IF NOT EXISTS journal_entries (
+	id SERIAL PRIMARY KEY,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	entry_date DATE NOT NULL,
+	hedge_order_id TEXT,
+	text TEXT NOT NULL
+);
@@ -0,0 +1,11 @@
+-- Итоги хеджирования по закрытым сделкам Freqtrade
+CREATE TABLE IF NOT EXISTS hedge_outcomes (
+	freqtrade_trade_id INTEGER PRIMARY KEY,
+	pair TEXT NOT NULL,
+	freqtrade_profit FLOAT NOT NULL,
+	hedge_profit FLOAT NOT NULL,
+	net_outcome FLOAT NOT NULL,
+	hedges INTEGER NOT NULL,
+	trade_close_time TIMESTAMP,
+	calculated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,12 @@
+-- История наблюдений за сделками
+CREATE TABLE IF NOT EXISTS trade_evaluations (
+	id BIGSERIAL PRIMARY KEY,
+	freqtrade_trade_id INTEGER NOT NULL,
+	pair TEXT NOT NULL,
+	evaluated_at TIMESTAMP NOT NULL,
+	profit_ratio FLOAT NOT NULL,
+	current_rate FLOAT NOT NULL,
+	threshold_crossed BOOLEAN NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS trade_evaluations_evaluated_at_idx ON trade_evaluations (evaluated_at);
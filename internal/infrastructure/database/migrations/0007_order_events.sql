@@ -0,0 +1,14 @@
+-- История событий ордеров
+CREATE TABLE IF NOT EXISTS order_events (
+	id SERIAL PRIMARY KEY,
+	order_id TEXT NOT NULL,
+	pair TEXT NOT NULL,
+	old_status TEXT NOT NULL DEFAULT '',
+	new_status TEXT NOT NULL,
+	filled_qty FLOAT NOT NULL DEFAULT 0,
+	price FLOAT NOT NULL DEFAULT 0,
+	event_time TIMESTAMP NOT NULL,
+	payload TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS order_events_order_id_idx ON order_events (order_id);
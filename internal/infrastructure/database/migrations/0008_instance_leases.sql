@@ -0,0 +1,8 @@
+-- Аренда роли ведущего экземпляра
+CREATE TABLE IF NOT EXISTS instance_leases (
+	name TEXT PRIMARY KEY,
+	holder TEXT NOT NULL DEFAULT '',
+	acquired_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	expires_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	handoff_requested_by TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,20 @@
+-- Импортированная история ордеров аккаунта.
+-- Таблица отделена от hedged_trades: эти ордера размещались не ботом и не участвуют в хеджировании
+CREATE TABLE IF NOT EXISTS exchange_order_history (
+	order_id TEXT PRIMARY KEY,
+	client_order_id TEXT NOT NULL DEFAULT '',
+	pair TEXT NOT NULL,
+	side TEXT NOT NULL,
+	order_type TEXT NOT NULL,
+	status TEXT NOT NULL,
+	price FLOAT NOT NULL,
+	quantity FLOAT NOT NULL,
+	filled_qty FLOAT NOT NULL,
+	avg_price FLOAT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	imported_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS exchange_order_history_pair_idx ON exchange_order_history (pair);
+CREATE INDEX IF NOT EXISTS exchange_order_history_created_at_idx ON exchange_order_history (created_at);
@@ -0,0 +1,10 @@
+-- История изменений конфигурации
+CREATE TABLE IF NOT EXISTS config_history (
+	id SERIAL PRIMARY KEY,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	author TEXT NOT NULL DEFAULT '',
+	source TEXT NOT NULL,
+	content TEXT NOT NULL,
+	diff TEXT NOT NULL DEFAULT '',
+	rolled_back_from INTEGER
+);
@@ -0,0 +1,8 @@
+-- Несколько хеджей на одну сделку Freqtrade (лестница DCA, повторное хеджирование):
+-- первичный ключ переносится с freqtrade_trade_id на суррогатный hedge_id
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS hedge_id BIGSERIAL;
+ALTER TABLE hedged_trades DROP CONSTRAINT IF EXISTS hedged_trades_pkey;
+ALTER TABLE hedged_trades ADD PRIMARY KEY (hedge_id);
+
+CREATE INDEX IF NOT EXISTS idx_hedged_trades_freqtrade_trade_id ON hedged_trades (freqtrade_trade_id);
+CREATE INDEX IF NOT EXISTS idx_hedged_trades_bybit_order_id ON hedged_trades (bybit_order_id);
@@ -0,0 +1,6 @@
+-- Задержки хеджирования: пересечение порога -> ордер на покупку, исполнение покупки -> тейк-профит
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS threshold_crossed_at TIMESTAMP;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS threshold_crossed_price FLOAT;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS buy_placed_at TIMESTAMP;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS buy_filled_at TIMESTAMP;
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS take_profit_placed_at TIMESTAMP;
@@ -0,0 +1,8 @@
+-- Последний снимок балансов и цен для веб-интерфейса при недоступности биржи (одна строка id = 1)
+CREATE TABLE IF NOT EXISTS market_snapshot (
+	id INTEGER PRIMARY KEY,
+	balances JSONB NOT NULL DEFAULT '{}',
+	balances_at TIMESTAMP,
+	prices JSONB NOT NULL DEFAULT '{}',
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,9 @@
+-- Захваты хеджей для проверки статусов: экземпляры с ролью status-checker проверяют
+-- непересекающиеся наборы хеджей, после падения экземпляра его захваты истекают
+CREATE TABLE IF NOT EXISTS hedge_status_claims (
+	hedge_id BIGINT PRIMARY KEY,
+	holder TEXT NOT NULL,
+	expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_hedge_status_claims_holder ON hedge_status_claims (holder);
@@ -0,0 +1,12 @@
+-- Решения не хеджировать сделки: причина и просадка на момент решения
+CREATE TABLE IF NOT EXISTS hedge_decisions (
+	id BIGSERIAL PRIMARY KEY,
+	freqtrade_trade_id INTEGER NOT NULL,
+	pair TEXT NOT NULL,
+	reason TEXT NOT NULL,
+	details TEXT NOT NULL DEFAULT '',
+	drawdown_percent FLOAT NOT NULL,
+	decided_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS hedge_decisions_decided_at_idx ON hedge_decisions (decided_at);
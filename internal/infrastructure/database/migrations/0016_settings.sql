@@ -0,0 +1,19 @@
+-- Параметры стратегии, измененные во время работы (переопределяют значения из файла конфигурации)
+CREATE TABLE IF NOT EXISTS settings (
+	key TEXT PRIMARY KEY,
+	value FLOAT NOT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	updated_by TEXT NOT NULL DEFAULT ''
+);
+
+-- Журнал изменений параметров: кто, когда и что изменил
+CREATE TABLE IF NOT EXISTS setting_changes (
+	id BIGSERIAL PRIMARY KEY,
+	key TEXT NOT NULL,
+	old_value FLOAT,
+	new_value FLOAT,
+	changed_by TEXT NOT NULL DEFAULT '',
+	changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS setting_changes_changed_at_idx ON setting_changes (changed_at);
@@ -0,0 +1,18 @@
+-- Флаги возможностей, переключенные в веб-интерфейсе (переопределяют конфигурацию)
+CREATE TABLE IF NOT EXISTS feature_flags (
+	key TEXT PRIMARY KEY,
+	enabled BOOLEAN NOT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	updated_by TEXT NOT NULL DEFAULT ''
+);
+
+-- Журнал переключений флагов
+CREATE TABLE IF NOT EXISTS feature_flag_changes (
+	id BIGSERIAL PRIMARY KEY,
+	key TEXT NOT NULL,
+	enabled BOOLEAN,
+	changed_by TEXT NOT NULL DEFAULT '',
+	changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS feature_flag_changes_changed_at_idx ON feature_flag_changes (changed_at);
@@ -0,0 +1,9 @@
+-- Архив давно закрытых хеджей: рабочая таблица hedged_trades остается небольшой, история сохраняется.
+-- Колонки копируются из hedged_trades; новые колонки hedged_trades нужно добавлять и сюда
+CREATE TABLE IF NOT EXISTS hedged_trades_archive (LIKE hedged_trades);
+ALTER TABLE hedged_trades_archive ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE hedged_trades_archive DROP CONSTRAINT IF EXISTS hedged_trades_archive_pkey;
+ALTER TABLE hedged_trades_archive ADD PRIMARY KEY (hedge_id);
+
+CREATE INDEX IF NOT EXISTS idx_hedged_trades_archive_freqtrade_trade_id ON hedged_trades_archive (freqtrade_trade_id);
+CREATE INDEX IF NOT EXISTS idx_hedged_trades_archive_hedge_time ON hedged_trades_archive (hedge_time);
@@ -0,0 +1,6 @@
+-- Временные значения параметров: после expires_at действует revert_value (NULL - значение из файла)
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS revert_value FLOAT;
+
+-- Окно временного значения в журнале: с changed_at до expires_at
+ALTER TABLE setting_changes ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
@@ -0,0 +1,2 @@
+-- Необработанный ответ биржи на размещение или запрос статуса ордера (NULL - ответа нет)
+ALTER TABLE order_events ADD COLUMN IF NOT EXISTS raw_payload JSONB;
@@ -0,0 +1,3 @@
+-- Цена отметки в момент решения о закрытии хеджа продажей: проскальзывание закрытия = отклонение close_price от нее
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS close_intended_price FLOAT;
+ALTER TABLE hedged_trades_archive ADD COLUMN IF NOT EXISTS close_intended_price FLOAT;
@@ -0,0 +1,24 @@
+-- Хедж-группы: хедж крупной сделки Freqtrade, разбитый на ноги с общим тейк-профитом
+CREATE TABLE IF NOT EXISTS hedge_groups (
+	id BIGSERIAL PRIMARY KEY,
+	freqtrade_trade_id INTEGER NOT NULL,
+	pair TEXT NOT NULL,
+	total_amount FLOAT NOT NULL,
+	leg_amount FLOAT NOT NULL,
+	legs_planned INTEGER NOT NULL,
+	legs_placed INTEGER NOT NULL DEFAULT 0,
+	take_profit_price FLOAT NOT NULL DEFAULT 0,
+	status TEXT NOT NULL,
+	status_reason TEXT NOT NULL DEFAULT '',
+	next_leg_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS hedge_groups_status_idx ON hedge_groups (status);
+
+-- Ноги группы - обычные хеджи со ссылкой на группу
+ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS hedge_group_id BIGINT;
+ALTER TABLE hedged_trades_archive ADD COLUMN IF NOT EXISTS hedge_group_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS hedged_trades_hedge_group_idx ON hedged_trades (hedge_group_id);
@@ -0,0 +1,13 @@
+-- Журнал аудита: ручные действия в веб-интерфейсе и API (кто, когда, с какими параметрами и с каким итогом)
+CREATE TABLE IF NOT EXISTS audit_log (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP NOT NULL,
+	username TEXT NOT NULL DEFAULT '',
+	remote_addr TEXT NOT NULL DEFAULT '',
+	action TEXT NOT NULL,
+	params TEXT NOT NULL DEFAULT '',
+	outcome TEXT NOT NULL,
+	message TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"trade-hedge/internal/domain/entities"
+)
+
+// SaveOrderEvent сохраняет событие ордера (в транзакции контекста, если она открыта).
+// Необработанный ответ биржи, который не является корректным JSON, сохраняется как JSON-строка
+func (r *PostgreSQLTradeRepository) SaveOrderEvent(ctx context.Context, event *entities.OrderEvent) error {
+	query := `
+		INSERT INTO order_events (order_id, pair, old_status, new_status, filled_qty, price, event_time, payload, raw_payload)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::jsonb)
+		RETURNING id`
+
+	err := r.queryRow(ctx, query,
+		event.OrderID,
+		event.Pair,
+		string(event.OldStatus),
+		string(event.NewStatus),
+		event.FilledQty,
+		event.Price,
+		event.Timestamp,
+		event.Payload,
+		rawJSONPayload(event.RawPayload)).Scan(&event.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения события ордера: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrderEvents возвращает события ордера в порядке возникновения
+func (r *PostgreSQLTradeRepository) GetOrderEvents(ctx context.Context, orderID string) ([]*entities.OrderEvent, error) {
+	query := `
+		SELECT id, order_id, pair, old_status, new_status, filled_qty, price, event_time, payload,
+			COALESCE(raw_payload::text, '')
+		FROM order_events
+		WHERE order_id = $1
+		ORDER BY event_time, id`
+
+	rows, err := r.pool.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения событий ордера: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entities.OrderEvent
+	for rows.Next() {
+		event := &entities.OrderEvent{}
+		var oldStatus, newStatus string
+		if err := rows.Scan(
+			&event.ID,
+			&event.OrderID,
+			&event.Pair,
+			&oldStatus,
+			&newStatus,
+			&event.FilledQty,
+			&event.Price,
+			&event.Timestamp,
+			&event.Payload,
+			&event.RawPayload); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования события ордера: %w", err)
+		}
+		event.OldStatus = entities.OrderStatus(oldStatus)
+		event.NewStatus = entities.OrderStatus(newStatus)
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+
+	return events, nil
+}
+
+// rawJSONPayload возвращает ответ биржи для колонки JSONB: корректный JSON - как есть,
+// иначе (обрезанный ответ, HTML страницы ошибки) - JSON-строкой, чтобы вставка не падала
+func rawJSONPayload(raw string) string {
+	if raw == "" || json.Valid([]byte(raw)) {
+		return raw
+	}
+	quoted, _ := json.Marshal(raw)
+	return string(quoted)
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/infrastructure/config"
+	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/pkg/tracing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// poolConfig собирает настройки пула соединений из database.*
+func poolConfig(cfg *config.Config) (*pgxpool.Config, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.GetDatabaseConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разбора параметров подключения к PostgreSQL: %w", err)
+	}
+
+	db := cfg.Database
+	poolCfg.MaxConns = int32(db.MaxConns)
+	poolCfg.MinConns = int32(db.MinConns)
+	poolCfg.MaxConnLifetime = time.Duration(db.MaxConnLifetime) * time.Second
+	poolCfg.MaxConnIdleTime = time.Duration(db.MaxConnIdleTime) * time.Second
+	poolCfg.HealthCheckPeriod = time.Duration(db.HealthCheckPeriod) * time.Second
+	if cfg.Tracing.Enabled {
+		poolCfg.ConnConfig.Logger = queryTracer{}
+		poolCfg.ConnConfig.LogLevel = pgx.LogLevelInfo
+	}
+	return poolCfg, nil
+}
+
+// connectPool подключается к PostgreSQL и проверяет соединение. Пока сервер недоступен
+// (в docker-compose PostgreSQL стартует дольше приложения), попытки повторяются с удвоением задержки
+// от connect_retry_delay до connect_retry_max_delay, всего 1 + connect_retries попыток
+func connectPool(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
+	poolCfg, err := poolConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := time.Duration(cfg.Database.ConnectRetryDelay) * time.Second
+	maxDelay := time.Duration(cfg.Database.ConnectRetryMaxDelay) * time.Second
+	attempts := cfg.Database.ConnectRetries + 1
+
+	for attempt := 1; ; attempt++ {
+		pool, err := pgxpool.ConnectConfig(ctx, poolCfg)
+		if err == nil {
+			if err = pool.Ping(ctx); err == nil {
+				if attempt > 1 {
+					logger.LogWithTime("✅ Подключение к PostgreSQL установлено с попытки %d", attempt)
+				}
+				return pool, nil
+			}
+			pool.Close()
+		}
+
+		if attempt >= attempts {
+			return nil, fmt.Errorf("ошибка подключения к PostgreSQL (попыток: %d): %w", attempt, err)
+		}
+		logger.LogWithTime("⏳ PostgreSQL недоступен (попытка %d/%d): %v - повтор через %v", attempt, attempts, err, delay)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("ошибка подключения к PostgreSQL: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+// queryTracer записывает запросы к PostgreSQL дочерними спанами трассы цикла. pgx v4 сообщает о запросе
+// через Logger после выполнения (с длительностью), поэтому спан записывается задним числом.
+// Параметры запроса в спан не попадают
+type queryTracer struct{}
+
+// Log записывает спан для сообщений о выполненных запросах (Query, Exec, SendBatch), остальное игнорирует
+func (queryTracer) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	switch msg {
+	case "Query", "Exec", "SendBatch":
+	default:
+		return
+	}
+	duration, ok := data["time"].(time.Duration)
+	if !ok {
+		return
+	}
+
+	end := time.Now()
+	attributes := []tracing.Attribute{tracing.String("db.system", "postgresql")}
+	if sql, ok := data["sql"].(string); ok {
+		attributes = append(attributes, tracing.String("db.statement", sql))
+	}
+	if rows, ok := data["rowCount"].(int); ok {
+		attributes = append(attributes, tracing.Int("db.rows", rows))
+	}
+	var err error
+	if queryErr, ok := data["err"].(error); ok {
+		err = queryErr
+	}
+	tracing.Record(ctx, "db "+msg, tracing.SpanKindClient, end.Add(-duration), end, err, attributes...)
+}
@@ -5,11 +5,25 @@ import (
 	"fmt"
 	"time"
 	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
 	"trade-hedge/internal/infrastructure/config"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+// hedgedTradeColumns колонки хеджированной сделки в порядке сканирования queryHedgedTrades
+const hedgedTradeColumns = `hedge_id, freqtrade_trade_id, pair, bybit_order_id, hedge_time,
+	freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio,
+	hedge_open_price, hedge_amount, hedge_take_profit_price,
+	order_status, last_status_check, close_price, close_time,
+	COALESCE(buy_order_id, ''), COALESCE(buy_requested_qty, 0), COALESCE(buy_filled_qty, 0),
+	COALESCE(strategy_version, ''), COALESCE(feature_flags, ''),
+	COALESCE(stop_loss_price, 0), COALESCE(stop_loss_order_id, ''),
+	COALESCE(entry_fee, 0), COALESCE(exit_fee, 0),
+	threshold_crossed_at, COALESCE(threshold_crossed_price, 0),
+	buy_placed_at, buy_filled_at, take_profit_placed_at,
+	COALESCE(close_intended_price, 0), COALESCE(hedge_group_id, 0)`
+
 // PostgreSQLTradeRepository реализует репозиторий для работы с PostgreSQL
 type PostgreSQLTradeRepository struct {
 	pool *pgxpool.Pool
@@ -17,16 +31,17 @@ type PostgreSQLTradeRepository struct {
 
 // NewPostgreSQLTradeRepository создает новый экземпляр репозитория
 func NewPostgreSQLTradeRepository(config *config.Config) (*PostgreSQLTradeRepository, error) {
-	pool, err := pgxpool.Connect(context.Background(), config.GetDatabaseConnectionString())
+	pool, err := connectPool(context.Background(), config)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка подключения к PostgreSQL: %w", err)
+		return nil, err
 	}
 
 	repo := &PostgreSQLTradeRepository{pool: pool}
 
-	// Инициализируем таблицы
-	if err := repo.initTables(); err != nil {
-		return nil, fmt.Errorf("ошибка инициализации таблиц: %w", err)
+	// Применяем миграции схемы
+	if _, err := repo.Migrate(context.Background()); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("ошибка миграции схемы: %w", err)
 	}
 
 	return repo, nil
@@ -37,63 +52,29 @@ func (r *PostgreSQLTradeRepository) Close() {
 	r.pool.Close()
 }
 
-// initTables создает необходимые таблицы
-func (r *PostgreSQLTradeRepository) initTables() error {
-	// Создаем новую таблицу с расширенной информацией
-	query := `
-		CREATE TABLE IF NOT EXISTS hedged_trades (
-			freqtrade_trade_id INTEGER PRIMARY KEY,
-			pair TEXT NOT NULL,
-			hedge_time TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			bybit_order_id TEXT,
-			
-			-- Информация об исходной сделке Freqtrade
-			freqtrade_open_price FLOAT NOT NULL,
-			freqtrade_amount FLOAT NOT NULL,
-			freqtrade_profit_ratio FLOAT NOT NULL,
-			
-			-- Информация о хеджирующей позиции
-			hedge_open_price FLOAT NOT NULL,
-			hedge_amount FLOAT NOT NULL,
-			hedge_take_profit_price FLOAT NOT NULL
-		)`
-
-	_, err := r.pool.Exec(context.Background(), query)
-	if err != nil {
-		return err
-	}
-
-	// Добавляем новые колонки к существующей таблице (для совместимости)
-	alterQueries := []string{
-		"ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS freqtrade_open_price FLOAT",
-		"ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS freqtrade_amount FLOAT",
-		"ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS freqtrade_profit_ratio FLOAT",
-		"ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS hedge_open_price FLOAT",
-		"ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS hedge_amount FLOAT",
-		"ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS hedge_take_profit_price FLOAT",
-		"ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS order_status TEXT DEFAULT 'PENDING'",
-		"ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS last_status_check TIMESTAMP",
-		"ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS close_price FLOAT",
-		"ALTER TABLE hedged_trades ADD COLUMN IF NOT EXISTS close_time TIMESTAMP",
-	}
+// Ping проверяет доступность базы данных
+func (r *PostgreSQLTradeRepository) Ping(ctx context.Context) error {
+	return r.pool.Ping(ctx)
+}
 
-	for _, alterQuery := range alterQueries {
-		_, err = r.pool.Exec(context.Background(), alterQuery)
-		if err != nil {
-			// Игнорируем ошибки добавления колонок (они могут уже существовать)
-			continue
-		}
+// PoolStats возвращает состояние пула соединений
+func (r *PostgreSQLTradeRepository) PoolStats() *repositories.DatabasePoolStats {
+	stat := r.pool.Stat()
+	return &repositories.DatabasePoolStats{
+		MaxConns:      int(stat.MaxConns()),
+		TotalConns:    int(stat.TotalConns()),
+		IdleConns:     int(stat.IdleConns()),
+		AcquiredConns: int(stat.AcquiredConns()),
 	}
-
-	return nil
 }
 
 // IsTradeHedged проверяет, была ли сделка хеджирована
-// Считаются хеджированными только сделки с успешно исполненными ордерами (FILLED)
+// Считаются хеджированными только сделки с успешно исполненными ордерами (FILLED), в том числе в архиве
 func (r *PostgreSQLTradeRepository) IsTradeHedged(ctx context.Context, tradeID int) (bool, error) {
 	var count int
-	err := r.pool.QueryRow(ctx,
-		"SELECT COUNT(*) FROM hedged_trades WHERE freqtrade_trade_id = $1 AND order_status = 'FILLED'",
+	err := r.queryRow(ctx,
+		`SELECT (SELECT COUNT(*) FROM hedged_trades WHERE freqtrade_trade_id = $1 AND order_status = 'FILLED')
+			+ (SELECT COUNT(*) FROM hedged_trades_archive WHERE freqtrade_trade_id = $1 AND order_status = 'FILLED')`,
 		tradeID).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("ошибка проверки хеджирования: %w", err)
@@ -101,17 +82,23 @@ func (r *PostgreSQLTradeRepository) IsTradeHedged(ctx context.Context, tradeID i
 	return count > 0, nil
 }
 
-// SaveHedgedTrade сохраняет информацию о хеджированной сделке
+// SaveHedgedTrade сохраняет информацию о хеджированной сделке (в транзакции контекста, если она открыта)
 func (r *PostgreSQLTradeRepository) SaveHedgedTrade(ctx context.Context, hedgedTrade *entities.HedgedTrade) error {
 	query := `
 		INSERT INTO hedged_trades 
 		(freqtrade_trade_id, pair, bybit_order_id, hedge_time,
 		 freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio,
 		 hedge_open_price, hedge_amount, hedge_take_profit_price,
-		 order_status, last_status_check, close_price, close_time) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
-
-	_, err := r.pool.Exec(ctx, query,
+		 order_status, last_status_check, close_price, close_time, buy_order_id,
+		 buy_requested_qty, buy_filled_qty, strategy_version, feature_flags,
+		 stop_loss_price, stop_loss_order_id, entry_fee, exit_fee,
+		 threshold_crossed_at, threshold_crossed_price, buy_placed_at, buy_filled_at, take_profit_placed_at,
+		 close_intended_price, hedge_group_id) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23,
+		        $24, $25, $26, $27, $28, $29, $30)
+		RETURNING hedge_id`
+
+	err := r.queryRow(ctx, query,
 		hedgedTrade.FreqtradeTradeID,
 		hedgedTrade.Pair,
 		hedgedTrade.BybitOrderID,
@@ -125,7 +112,23 @@ func (r *PostgreSQLTradeRepository) SaveHedgedTrade(ctx context.Context, hedgedT
 		hedgedTrade.OrderStatus.String(),
 		hedgedTrade.LastStatusCheck,
 		hedgedTrade.ClosePrice,
-		hedgedTrade.CloseTime)
+		hedgedTrade.CloseTime,
+		hedgedTrade.BuyOrderID,
+		hedgedTrade.BuyRequestedQty,
+		hedgedTrade.BuyFilledQty,
+		hedgedTrade.StrategyVersion,
+		hedgedTrade.FeatureFlags,
+		hedgedTrade.StopLossPrice,
+		hedgedTrade.StopLossOrderID,
+		hedgedTrade.EntryFee,
+		hedgedTrade.ExitFee,
+		hedgedTrade.ThresholdCrossedAt,
+		hedgedTrade.ThresholdCrossedPrice,
+		hedgedTrade.BuyPlacedAt,
+		hedgedTrade.BuyFilledAt,
+		hedgedTrade.TakeProfitPlacedAt,
+		hedgedTrade.CloseIntendedPrice,
+		nullableID(hedgedTrade.HedgeGroupID)).Scan(&hedgedTrade.HedgeID)
 
 	if err != nil {
 		return fmt.Errorf("ошибка сохранения хеджированной сделки: %w", err)
@@ -136,35 +139,99 @@ func (r *PostgreSQLTradeRepository) SaveHedgedTrade(ctx context.Context, hedgedT
 
 // GetHedgedTrades получает хеджированные сделки по статусу
 func (r *PostgreSQLTradeRepository) GetHedgedTrades(ctx context.Context, status *string) ([]*entities.HedgedTrade, error) {
-	var query string
+	query := "SELECT " + hedgedTradeColumns + " FROM hedged_trades"
 	var args []interface{}
 
-	if status == nil {
-		// Если статус не указан (nil), возвращаем все сделки
-		query = `
-			SELECT freqtrade_trade_id, pair, bybit_order_id, hedge_time,
-				   freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio,
-				   hedge_open_price, hedge_amount, hedge_take_profit_price,
-				   order_status, last_status_check, close_price, close_time
-			FROM hedged_trades 
-			ORDER BY hedge_time DESC`
-	} else {
+	if status != nil {
 		// Если указан конкретный статус, фильтруем по нему
-		query = `
-			SELECT freqtrade_trade_id, pair, bybit_order_id, hedge_time,
-				   freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio,
-				   hedge_open_price, hedge_amount, hedge_take_profit_price,
-				   order_status, last_status_check, close_price, close_time
-			FROM hedged_trades 
-			WHERE order_status = $1
-			ORDER BY hedge_time DESC`
+		query += " WHERE order_status = $1"
 		args = append(args, *status)
 	}
+	query += " ORDER BY hedge_time DESC, hedge_id DESC"
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	hedgedTrades, err := r.queryHedgedTrades(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения хеджированных сделок: %w", err)
 	}
+	return hedgedTrades, nil
+}
+
+// UpdateHedgedTradeStatus обновляет статус хеджированной сделки
+func (r *PostgreSQLTradeRepository) UpdateHedgedTradeStatus(ctx context.Context, orderID string, status entities.OrderStatus, closePrice *float64, closeTime *time.Time) error {
+	query := `
+		UPDATE hedged_trades 
+		SET order_status = $1, last_status_check = $2, close_price = $3, close_time = $4
+		WHERE bybit_order_id = $5`
+
+	now := time.Now()
+	err := r.exec(ctx, query, status.String(), now, closePrice, closeTime, orderID)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления статуса хеджированной сделки: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateHedgedTrade обновляет данные хеджированной сделки, найденной по текущему ID ордера
+func (r *PostgreSQLTradeRepository) UpdateHedgedTrade(ctx context.Context, orderID string, hedgedTrade *entities.HedgedTrade) error {
+	query := `
+		UPDATE hedged_trades 
+		SET bybit_order_id = $1, buy_order_id = $2,
+		    hedge_open_price = $3, hedge_amount = $4, hedge_take_profit_price = $5,
+		    order_status = $6, last_status_check = $7, close_price = $8, close_time = $9,
+		    buy_requested_qty = $10, buy_filled_qty = $11,
+		    stop_loss_price = $12, stop_loss_order_id = $13,
+		    entry_fee = $14, exit_fee = $15,
+		    buy_filled_at = $16, take_profit_placed_at = $17,
+		    close_intended_price = $18
+		WHERE bybit_order_id = $19`
+
+	err := r.exec(ctx, query,
+		hedgedTrade.BybitOrderID,
+		hedgedTrade.BuyOrderID,
+		hedgedTrade.HedgeOpenPrice,
+		hedgedTrade.HedgeAmount,
+		hedgedTrade.HedgeTakeProfitPrice,
+		hedgedTrade.OrderStatus.String(),
+		hedgedTrade.LastStatusCheck,
+		hedgedTrade.ClosePrice,
+		hedgedTrade.CloseTime,
+		hedgedTrade.BuyRequestedQty,
+		hedgedTrade.BuyFilledQty,
+		hedgedTrade.StopLossPrice,
+		hedgedTrade.StopLossOrderID,
+		hedgedTrade.EntryFee,
+		hedgedTrade.ExitFee,
+		hedgedTrade.BuyFilledAt,
+		hedgedTrade.TakeProfitPlacedAt,
+		hedgedTrade.CloseIntendedPrice,
+		orderID)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления хеджированной сделки: %w", err)
+	}
+
+	return nil
+}
+
+// GetHedgeHistory получает историю хедж-ордеров по конкретной сделке (все хеджи сделки, включая архив, новые первыми)
+func (r *PostgreSQLTradeRepository) GetHedgeHistory(ctx context.Context, tradeID int) ([]*entities.HedgedTrade, error) {
+	query := "SELECT " + hedgedTradeColumns + " FROM hedged_trades WHERE freqtrade_trade_id = $1" +
+		" UNION ALL SELECT " + hedgedTradeColumns + " FROM hedged_trades_archive WHERE freqtrade_trade_id = $1" +
+		" ORDER BY 5 DESC, 1 DESC"
+
+	hedgeHistory, err := r.queryHedgedTrades(ctx, query, tradeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории хеджирования: %w", err)
+	}
+	return hedgeHistory, nil
+}
+
+// queryHedgedTrades выполняет запрос с колонками hedgedTradeColumns
+func (r *PostgreSQLTradeRepository) queryHedgedTrades(ctx context.Context, query string, args ...interface{}) ([]*entities.HedgedTrade, error) {
+	rows, err := r.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	var hedgedTrades []*entities.HedgedTrade
@@ -173,6 +240,7 @@ func (r *PostgreSQLTradeRepository) GetHedgedTrades(ctx context.Context, status
 		var orderStatusStr string
 
 		err := rows.Scan(
+			&trade.HedgeID,
 			&trade.FreqtradeTradeID,
 			&trade.Pair,
 			&trade.BybitOrderID,
@@ -186,7 +254,23 @@ func (r *PostgreSQLTradeRepository) GetHedgedTrades(ctx context.Context, status
 			&orderStatusStr,
 			&trade.LastStatusCheck,
 			&trade.ClosePrice,
-			&trade.CloseTime)
+			&trade.CloseTime,
+			&trade.BuyOrderID,
+			&trade.BuyRequestedQty,
+			&trade.BuyFilledQty,
+			&trade.StrategyVersion,
+			&trade.FeatureFlags,
+			&trade.StopLossPrice,
+			&trade.StopLossOrderID,
+			&trade.EntryFee,
+			&trade.ExitFee,
+			&trade.ThresholdCrossedAt,
+			&trade.ThresholdCrossedPrice,
+			&trade.BuyPlacedAt,
+			&trade.BuyFilledAt,
+			&trade.TakeProfitPlacedAt,
+			&trade.CloseIntendedPrice,
+			&trade.HedgeGroupID)
 
 		if err != nil {
 			return nil, fmt.Errorf("ошибка сканирования хеджированной сделки: %w", err)
@@ -203,71 +287,87 @@ func (r *PostgreSQLTradeRepository) GetHedgedTrades(ctx context.Context, status
 	return hedgedTrades, nil
 }
 
-// UpdateHedgedTradeStatus обновляет статус хеджированной сделки
-func (r *PostgreSQLTradeRepository) UpdateHedgedTradeStatus(ctx context.Context, orderID string, status entities.OrderStatus, closePrice *float64, closeTime *time.Time) error {
-	query := `
-		UPDATE hedged_trades 
-		SET order_status = $1, last_status_check = $2, close_price = $3, close_time = $4
-		WHERE bybit_order_id = $5`
+// QueryHedgedTrades возвращает страницу хеджей с фильтрами и сортировкой и общее количество подходящих хеджей
+func (r *PostgreSQLTradeRepository) QueryHedgedTrades(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeTradePage, error) {
+	where, orderBy, limit, args := hedgeTradeQuerySQL(query, func(n int) string {
+		return fmt.Sprintf("$%d", n)
+	}, "ALL")
+	table := hedgeTradesTable(query)
 
-	now := time.Now()
-	_, err := r.pool.Exec(ctx, query, status.String(), now, closePrice, closeTime, orderID)
+	page := &entities.HedgeTradePage{}
+	if err := r.queryRow(ctx, "SELECT COUNT(*) FROM "+table+where, args...).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("ошибка подсчета хеджированных сделок: %w", err)
+	}
+
+	trades, err := r.queryHedgedTrades(ctx, "SELECT "+hedgedTradeColumns+" FROM "+table+where+orderBy+limit, args...)
 	if err != nil {
-		return fmt.Errorf("ошибка обновления статуса хеджированной сделки: %w", err)
+		return nil, fmt.Errorf("ошибка получения хеджированных сделок: %w", err)
 	}
+	page.Trades = trades
 
-	return nil
+	if query.Facets {
+		if page.Pairs, err = r.queryStrings(ctx, "SELECT DISTINCT pair FROM "+table+" ORDER BY pair"); err != nil {
+			return nil, fmt.Errorf("ошибка получения списка пар: %w", err)
+		}
+		if page.Versions, err = r.queryStrings(ctx, "SELECT DISTINCT COALESCE(strategy_version, '') FROM "+table+" ORDER BY 1"); err != nil {
+			return nil, fmt.Errorf("ошибка получения списка версий стратегии: %w", err)
+		}
+	}
+	return page, nil
 }
 
-// GetHedgeHistory получает историю хедж-ордеров по конкретной сделке
-func (r *PostgreSQLTradeRepository) GetHedgeHistory(ctx context.Context, tradeID int) ([]*entities.HedgedTrade, error) {
-	query := `
-		SELECT freqtrade_trade_id, pair, bybit_order_id, hedge_time,
-			   freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio,
-			   hedge_open_price, hedge_amount, hedge_take_profit_price,
-			   order_status, last_status_check, close_price, close_time
-		FROM hedged_trades 
-		WHERE freqtrade_trade_id = $1
-		ORDER BY hedge_time DESC`
-
-	rows, err := r.pool.Query(ctx, query, tradeID)
+// queryStrings выполняет запрос, возвращающий одну текстовую колонку
+func (r *PostgreSQLTradeRepository) queryStrings(ctx context.Context, query string) ([]string, error) {
+	rows, err := r.query(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка получения истории хеджирования: %w", err)
+		return nil, err
 	}
 	defer rows.Close()
 
-	var hedgeHistory []*entities.HedgedTrade
+	var values []string
 	for rows.Next() {
-		trade := &entities.HedgedTrade{}
-		var orderStatusStr string
-
-		err := rows.Scan(
-			&trade.FreqtradeTradeID,
-			&trade.Pair,
-			&trade.BybitOrderID,
-			&trade.HedgeTime,
-			&trade.FreqtradeOpenPrice,
-			&trade.FreqtradeAmount,
-			&trade.FreqtradeProfitRatio,
-			&trade.HedgeOpenPrice,
-			&trade.HedgeAmount,
-			&trade.HedgeTakeProfitPrice,
-			&orderStatusStr,
-			&trade.LastStatusCheck,
-			&trade.ClosePrice,
-			&trade.CloseTime)
-
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования истории хеджирования: %w", err)
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
 		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
 
-		trade.OrderStatus = entities.OrderStatusFromString(orderStatusStr)
-		hedgeHistory = append(hedgeHistory, trade)
+// GetTradeStats считает статистику хеджей, подходящих под фильтры выборки (период, пара, версия, статус),
+// агрегацией в SQL без загрузки сделок; сортировка и пагинация выборки не учитываются
+func (r *PostgreSQLTradeRepository) GetTradeStats(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeStats, error) {
+	where, _, _, args := hedgeTradeQuerySQL(query, func(n int) string {
+		return fmt.Sprintf("$%d", n)
+	}, "ALL")
+	totals, byVersion := hedgeStatsQueries(hedgeTradesTable(query), where, "EXTRACT(EPOCH FROM (close_time - hedge_time))::float8")
+
+	stats := &entities.HedgeStats{}
+	var avgHoldSeconds *float64
+	err := r.queryRow(ctx, totals, args...).Scan(
+		&stats.Total, &stats.Completed, &stats.OrderSize, &stats.RealizedProfit, &stats.Fees,
+		&stats.Wins, &stats.Losses, &avgHoldSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расчета статистики хеджей: %w", err)
 	}
+	finishHedgeStats(stats, avgHoldSeconds)
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	rows, err := r.query(ctx, byVersion, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расчета статистики по версиям стратегии: %w", err)
 	}
+	defer rows.Close()
 
-	return hedgeHistory, nil
+	for rows.Next() {
+		var version entities.HedgeVersionStats
+		if err := rows.Scan(&version.Version, &version.Total, &version.Completed, &version.RealizedProfit); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования статистики версии стратегии: %w", err)
+		}
+		stats.ByVersion = append(stats.ByVersion, version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+	return stats, nil
 }
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+)
+
+// SaveRebalanceRecord сохраняет запись аудита ребалансировки
+func (r *PostgreSQLTradeRepository) SaveRebalanceRecord(ctx context.Context, record *entities.RebalanceRecord) error {
+	query := `
+		INSERT INTO rebalance_records
+		(created_at, period_start, period_end, total_profit, asset, target_percent, quote_amount, order_id, success, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+
+	err := r.pool.QueryRow(ctx, query,
+		record.CreatedAt,
+		record.PeriodStart,
+		record.PeriodEnd,
+		record.TotalProfit,
+		record.Asset,
+		record.TargetPercent,
+		record.QuoteAmount,
+		record.OrderID,
+		record.Success,
+		record.Error).Scan(&record.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения записи ребалансировки: %w", err)
+	}
+
+	return nil
+}
+
+// GetLastRebalanceTime возвращает конец периода последней завершенной ребалансировки: период с неудачной
+// конвертацией не завершен, и его прибыль учитывается при следующей ребалансировке
+func (r *PostgreSQLTradeRepository) GetLastRebalanceTime(ctx context.Context) (*time.Time, error) {
+	query := `
+		SELECT MAX(period_end) FROM (
+			SELECT period_end FROM rebalance_records
+			GROUP BY period_end
+			HAVING bool_and(success)
+		) completed`
+
+	var last *time.Time
+	err := r.pool.QueryRow(ctx, query).Scan(&last)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения времени последней ребалансировки: %w", err)
+	}
+	return last, nil
+}
+
+// GetConvertedAmounts возвращает суммы успешных конвертаций по активам для периода, начатого в periodStart
+func (r *PostgreSQLTradeRepository) GetConvertedAmounts(ctx context.Context, periodStart time.Time) (map[string]float64, error) {
+	query := `
+		SELECT asset, SUM(quote_amount)
+		FROM rebalance_records
+		WHERE period_start = $1 AND success AND order_id <> ''
+		GROUP BY asset`
+
+	rows, err := r.pool.Query(ctx, query, periodStart)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения сумм конвертаций: %w", err)
+	}
+	defer rows.Close()
+
+	converted := make(map[string]float64)
+	for rows.Next() {
+		var asset string
+		var amount float64
+		if err := rows.Scan(&asset, &amount); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования суммы конвертации: %w", err)
+		}
+		converted[asset] = amount
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+
+	return converted, nil
+}
+
+// GetRebalanceRecords возвращает записи аудита ребалансировки (новые первыми)
+func (r *PostgreSQLTradeRepository) GetRebalanceRecords(ctx context.Context, limit int) ([]*entities.RebalanceRecord, error) {
+	query := `
+		SELECT id, created_at, period_start, period_end, total_profit, asset,
+		       target_percent, quote_amount, COALESCE(order_id, ''), success, COALESCE(error, '')
+		FROM rebalance_records
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1`
+
+	rows, err := r.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения записей ребалансировки: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*entities.RebalanceRecord
+	for rows.Next() {
+		record := &entities.RebalanceRecord{}
+		err := rows.Scan(
+			&record.ID,
+			&record.CreatedAt,
+			&record.PeriodStart,
+			&record.PeriodEnd,
+			&record.TotalProfit,
+			&record.Asset,
+			&record.TargetPercent,
+			&record.QuoteAmount,
+			&record.OrderID,
+			&record.Success,
+			&record.Error)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования записи ребалансировки: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,203 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// GetSettings возвращает сохраненные параметры
+func (r *PostgreSQLTradeRepository) GetSettings(ctx context.Context) ([]*entities.Setting, error) {
+	query := `
+		SELECT key, value, updated_at, updated_by, expires_at, revert_value
+		FROM settings
+		ORDER BY key`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения параметров: %w", err)
+	}
+	defer rows.Close()
+
+	var settings []*entities.Setting
+	for rows.Next() {
+		setting := &entities.Setting{}
+		if err := rows.Scan(&setting.Key, &setting.Value, &setting.UpdatedAt, &setting.UpdatedBy,
+			&setting.ExpiresAt, &setting.RevertValue); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования параметра: %w", err)
+		}
+		settings = append(settings, setting)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения параметров: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SaveSetting сохраняет значение параметра и запись журнала в одной транзакции.
+// Строка параметра блокируется, чтобы одновременные изменения записали в журнал верные старые значения
+func (r *PostgreSQLTradeRepository) SaveSetting(ctx context.Context, key string, value *float64, ttl time.Duration, author string) (*entities.SettingChange, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	change := &entities.SettingChange{Key: key, NewValue: value, ChangedBy: author}
+
+	var current *entities.Setting
+	stored := &entities.Setting{Key: key}
+	err = tx.QueryRow(ctx, `SELECT value, expires_at, revert_value FROM settings WHERE key = $1 FOR UPDATE`, key).
+		Scan(&stored.Value, &stored.ExpiresAt, &stored.RevertValue)
+	switch {
+	case err == pgx.ErrNoRows:
+	case err != nil:
+		return nil, fmt.Errorf("ошибка получения параметра %s: %w", key, err)
+	default:
+		current = stored
+		oldValue := stored.Value
+		change.OldValue = &oldValue
+	}
+
+	// Временное значение поверх временного возвращается к тому же постоянному значению
+	var expiresAt interface{}
+	var revertValue *float64
+	if ttl > 0 {
+		expiresAt = ttl.Seconds()
+		revertValue = current.BaseValue()
+	}
+
+	switch {
+	case value == nil:
+		_, err = tx.Exec(ctx, `DELETE FROM settings WHERE key = $1`, key)
+	default:
+		err = tx.QueryRow(ctx, `
+			INSERT INTO settings (key, value, updated_at, updated_by, expires_at, revert_value)
+			VALUES ($1, $2, NOW(), $3, NOW() + $4::float8 * INTERVAL '1 second', $5)
+			ON CONFLICT (key) DO UPDATE SET
+				value = EXCLUDED.value,
+				updated_at = EXCLUDED.updated_at,
+				updated_by = EXCLUDED.updated_by,
+				expires_at = EXCLUDED.expires_at,
+				revert_value = EXCLUDED.revert_value
+			RETURNING expires_at`,
+			key, *value, author, expiresAt, revertValue).Scan(&change.ExpiresAt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения параметра %s: %w", key, err)
+	}
+
+	if err := insertSettingChange(ctx, tx, change); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения параметра %s: %w", key, err)
+	}
+	return change, nil
+}
+
+// RevertExpiredSettings возвращает постоянные значения параметров с закончившимися временными значениями.
+// Строки блокируются: при нескольких экземплярах возврат записывается в журнал один раз
+func (r *PostgreSQLTradeRepository) RevertExpiredSettings(ctx context.Context, author string) ([]*entities.SettingChange, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT key, value, revert_value
+		FROM settings
+		WHERE expires_at IS NOT NULL AND expires_at <= NOW()
+		ORDER BY key
+		FOR UPDATE`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истекших параметров: %w", err)
+	}
+	var changes []*entities.SettingChange
+	for rows.Next() {
+		var oldValue float64
+		change := &entities.SettingChange{ChangedBy: author}
+		if err := rows.Scan(&change.Key, &oldValue, &change.NewValue); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("ошибка сканирования истекшего параметра: %w", err)
+		}
+		change.OldValue = &oldValue
+		changes = append(changes, change)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения истекших параметров: %w", err)
+	}
+
+	for _, change := range changes {
+		if change.NewValue == nil {
+			_, err = tx.Exec(ctx, `DELETE FROM settings WHERE key = $1`, change.Key)
+		} else {
+			_, err = tx.Exec(ctx, `
+				UPDATE settings
+				SET value = $2, updated_at = NOW(), updated_by = $3, expires_at = NULL, revert_value = NULL
+				WHERE key = $1`,
+				change.Key, *change.NewValue, author)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ошибка возврата параметра %s: %w", change.Key, err)
+		}
+		if err := insertSettingChange(ctx, tx, change); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("ошибка возврата истекших параметров: %w", err)
+	}
+	return changes, nil
+}
+
+// insertSettingChange записывает изменение параметра в журнал и заполняет ID и время записи
+func insertSettingChange(ctx context.Context, tx pgx.Tx, change *entities.SettingChange) error {
+	err := tx.QueryRow(ctx, `
+		INSERT INTO setting_changes (key, old_value, new_value, changed_by, changed_at, expires_at)
+		VALUES ($1, $2, $3, $4, NOW(), $5)
+		RETURNING id, changed_at`,
+		change.Key, change.OldValue, change.NewValue, change.ChangedBy, change.ExpiresAt).Scan(&change.ID, &change.ChangedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка записи журнала изменения параметра %s: %w", change.Key, err)
+	}
+	return nil
+}
+
+// GetSettingChanges возвращает журнал изменений параметров (новые первыми)
+func (r *PostgreSQLTradeRepository) GetSettingChanges(ctx context.Context, limit int) ([]*entities.SettingChange, error) {
+	query := `
+		SELECT id, key, old_value, new_value, changed_by, changed_at, expires_at
+		FROM setting_changes
+		ORDER BY changed_at DESC, id DESC
+		LIMIT $1`
+
+	rows, err := r.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения журнала параметров: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*entities.SettingChange
+	for rows.Next() {
+		change := &entities.SettingChange{}
+		if err := rows.Scan(&change.ID, &change.Key, &change.OldValue, &change.NewValue, &change.ChangedBy,
+			&change.ChangedAt, &change.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования изменения параметра: %w", err)
+		}
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения журнала параметров: %w", err)
+	}
+
+	return changes, nil
+}
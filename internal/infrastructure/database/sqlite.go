@@ -0,0 +1,611 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/infrastructure/config"
+
+	// Драйвер SQLite на чистом Go: сборка без cgo (CGO_ENABLED=0) и без внешних библиотек
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteDriverName имя драйвера database/sql для SQLite (регистрирует modernc.org/sqlite)
+const SQLiteDriverName = "sqlite"
+
+// sqliteHedgedTradeColumns колонки хеджированной сделки в порядке сканирования queryHedgedTrades
+const sqliteHedgedTradeColumns = `hedge_id, freqtrade_trade_id, pair, bybit_order_id, hedge_time,
+	freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio,
+	hedge_open_price, hedge_amount, hedge_take_profit_price,
+	order_status, last_status_check, close_price, close_time,
+	COALESCE(buy_order_id, ''), COALESCE(buy_requested_qty, 0), COALESCE(buy_filled_qty, 0),
+	COALESCE(strategy_version, ''), COALESCE(feature_flags, ''),
+	COALESCE(stop_loss_price, 0), COALESCE(stop_loss_order_id, ''),
+	COALESCE(entry_fee, 0), COALESCE(exit_fee, 0),
+	threshold_crossed_at, COALESCE(threshold_crossed_price, 0),
+	buy_placed_at, buy_filled_at, take_profit_placed_at,
+	COALESCE(close_intended_price, 0), COALESCE(hedge_group_id, 0)`
+
+// sqliteHedgedTradesTable схема таблицы хеджированных сделок
+const sqliteHedgedTradesTable = `CREATE TABLE IF NOT EXISTS hedged_trades (
+	hedge_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	freqtrade_trade_id INTEGER NOT NULL,
+	pair TEXT NOT NULL,
+	hedge_time TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	bybit_order_id TEXT,
+	freqtrade_open_price FLOAT NOT NULL,
+	freqtrade_amount FLOAT NOT NULL,
+	freqtrade_profit_ratio FLOAT NOT NULL,
+	hedge_open_price FLOAT NOT NULL,
+	hedge_amount FLOAT NOT NULL,
+	hedge_take_profit_price FLOAT NOT NULL,
+	order_status TEXT DEFAULT 'PENDING',
+	last_status_check TIMESTAMP,
+	close_price FLOAT,
+	close_time TIMESTAMP,
+	buy_order_id TEXT,
+	buy_requested_qty FLOAT,
+	buy_filled_qty FLOAT,
+	strategy_version TEXT,
+	feature_flags TEXT,
+	stop_loss_price FLOAT,
+	stop_loss_order_id TEXT,
+	entry_fee FLOAT DEFAULT 0,
+	exit_fee FLOAT DEFAULT 0,
+	threshold_crossed_at TIMESTAMP,
+	threshold_crossed_price FLOAT,
+	buy_placed_at TIMESTAMP,
+	buy_filled_at TIMESTAMP,
+	take_profit_placed_at TIMESTAMP,
+	close_intended_price FLOAT,
+	hedge_group_id INTEGER
+)`
+
+// sqliteHedgedTradesArchiveTable схема архива давно закрытых хеджей: колонки hedged_trades
+// без автоинкремента и время переноса в архив
+var sqliteHedgedTradesArchiveTable = strings.Replace(strings.Replace(strings.TrimSuffix(sqliteHedgedTradesTable, "\n)"),
+	"hedged_trades (", "hedged_trades_archive (", 1),
+	"INTEGER PRIMARY KEY AUTOINCREMENT", "INTEGER PRIMARY KEY", 1) +
+	",\n\tarchived_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP\n)"
+
+// sqliteAddedColumns колонки, добавленные после создания схемы: в файлах старых версий их нет
+var sqliteAddedColumns = []struct{ name, definition string }{
+	{"threshold_crossed_at", "TIMESTAMP"},
+	{"threshold_crossed_price", "FLOAT"},
+	{"buy_placed_at", "TIMESTAMP"},
+	{"buy_filled_at", "TIMESTAMP"},
+	{"take_profit_placed_at", "TIMESTAMP"},
+	{"close_intended_price", "FLOAT"},
+	{"hedge_group_id", "INTEGER"},
+}
+
+// SQLiteTradeRepository хранит хеджированные сделки в файле SQLite - для запуска без сервера PostgreSQL.
+// Схема таблицы hedged_trades совпадает с PostgreSQL; время хранится в UTC
+type SQLiteTradeRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTradeRepository открывает (или создает) базу SQLite по пути database.path
+func NewSQLiteTradeRepository(config *config.Config) (*SQLiteTradeRepository, error) {
+	db, err := sql.Open(SQLiteDriverName, config.Database.Path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия базы SQLite %s: %w", config.Database.Path, err)
+	}
+	// SQLite допускает одного писателя: одно соединение исключает ошибки SQLITE_BUSY между горутинами
+	db.SetMaxOpenConns(1)
+
+	repo := &SQLiteTradeRepository{db: db}
+	if err := repo.initTables(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ошибка инициализации таблиц: %w", err)
+	}
+
+	return repo, nil
+}
+
+// Close закрывает базу данных
+func (r *SQLiteTradeRepository) Close() {
+	r.db.Close()
+}
+
+// initTables создает таблицу хеджированных сделок и архив
+func (r *SQLiteTradeRepository) initTables() error {
+	queries := []string{
+		"PRAGMA journal_mode = WAL",
+		"PRAGMA busy_timeout = 5000",
+		sqliteHedgedTradesTable,
+		sqliteHedgedTradesArchiveTable,
+	}
+	for _, query := range queries {
+		if _, err := r.db.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	if err := r.migrateHedgeID(); err != nil {
+		return fmt.Errorf("ошибка переноса первичного ключа на hedge_id: %w", err)
+	}
+	if err := r.addMissingColumns(); err != nil {
+		return fmt.Errorf("ошибка добавления колонок: %w", err)
+	}
+
+	indexes := []string{
+		"CREATE INDEX IF NOT EXISTS idx_hedged_trades_trade ON hedged_trades (freqtrade_trade_id)",
+		"CREATE INDEX IF NOT EXISTS idx_hedged_trades_order ON hedged_trades (bybit_order_id)",
+		"CREATE INDEX IF NOT EXISTS idx_hedged_trades_status ON hedged_trades (order_status)",
+		"CREATE INDEX IF NOT EXISTS idx_hedged_trades_archive_trade ON hedged_trades_archive (freqtrade_trade_id)",
+	}
+	for _, query := range indexes {
+		if _, err := r.db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateHedgeID пересоздает таблицу, созданную с первичным ключом freqtrade_trade_id, с суррогатным hedge_id.
+// SQLite не умеет менять первичный ключ через ALTER TABLE, поэтому данные копируются в новую таблицу
+func (r *SQLiteTradeRepository) migrateHedgeID() error {
+	var hasHedgeID int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('hedged_trades') WHERE name = 'hedge_id'").Scan(&hasHedgeID)
+	if err != nil || hasHedgeID > 0 {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	columns := `freqtrade_trade_id, pair, hedge_time, bybit_order_id,
+		freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio,
+		hedge_open_price, hedge_amount, hedge_take_profit_price,
+		order_status, last_status_check, close_price, close_time,
+		buy_order_id, buy_requested_qty, buy_filled_qty, strategy_version, feature_flags,
+		stop_loss_price, stop_loss_order_id, entry_fee, exit_fee`
+	queries := []string{
+		"ALTER TABLE hedged_trades RENAME TO hedged_trades_old",
+		"DROP INDEX IF EXISTS idx_hedged_trades_order",
+		"DROP INDEX IF EXISTS idx_hedged_trades_status",
+		strings.Replace(sqliteHedgedTradesTable, "IF NOT EXISTS ", "", 1),
+		"INSERT INTO hedged_trades (" + columns + ") SELECT " + columns + " FROM hedged_trades_old ORDER BY hedge_time",
+		"DROP TABLE hedged_trades_old",
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// addMissingColumns добавляет колонки sqliteAddedColumns, которых нет в рабочей таблице и архиве
+// (в SQLite нет ADD COLUMN IF NOT EXISTS)
+func (r *SQLiteTradeRepository) addMissingColumns() error {
+	for _, table := range []string{hedgedTradesTable, hedgedTradesArchiveTable} {
+		for _, column := range sqliteAddedColumns {
+			var exists int
+			err := r.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column.name).Scan(&exists)
+			if err != nil {
+				return err
+			}
+			if exists > 0 {
+				continue
+			}
+			if _, err := r.db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column.name + " " + column.definition); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Ping проверяет доступность файла базы
+func (r *SQLiteTradeRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// IsTradeHedged проверяет, была ли сделка хеджирована
+// Считаются хеджированными только сделки с успешно исполненными ордерами (FILLED), в том числе в архиве
+func (r *SQLiteTradeRepository) IsTradeHedged(ctx context.Context, tradeID int) (bool, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT (SELECT COUNT(*) FROM hedged_trades WHERE freqtrade_trade_id = ? AND order_status = 'FILLED')
+			+ (SELECT COUNT(*) FROM hedged_trades_archive WHERE freqtrade_trade_id = ? AND order_status = 'FILLED')`,
+		tradeID, tradeID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки хеджирования: %w", err)
+	}
+	return count > 0, nil
+}
+
+// SaveHedgedTrade сохраняет информацию о хеджированной сделке
+func (r *SQLiteTradeRepository) SaveHedgedTrade(ctx context.Context, hedgedTrade *entities.HedgedTrade) error {
+	query := `
+		INSERT INTO hedged_trades
+		(freqtrade_trade_id, pair, bybit_order_id, hedge_time,
+		 freqtrade_open_price, freqtrade_amount, freqtrade_profit_ratio,
+		 hedge_open_price, hedge_amount, hedge_take_profit_price,
+		 order_status, last_status_check, close_price, close_time, buy_order_id,
+		 buy_requested_qty, buy_filled_qty, strategy_version, feature_flags,
+		 stop_loss_price, stop_loss_order_id, entry_fee, exit_fee,
+		 threshold_crossed_at, threshold_crossed_price, buy_placed_at, buy_filled_at, take_profit_placed_at,
+		 close_intended_price, hedge_group_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query,
+		hedgedTrade.FreqtradeTradeID,
+		hedgedTrade.Pair,
+		hedgedTrade.BybitOrderID,
+		hedgedTrade.HedgeTime.UTC(),
+		hedgedTrade.FreqtradeOpenPrice,
+		hedgedTrade.FreqtradeAmount,
+		hedgedTrade.FreqtradeProfitRatio,
+		hedgedTrade.HedgeOpenPrice,
+		hedgedTrade.HedgeAmount,
+		hedgedTrade.HedgeTakeProfitPrice,
+		hedgedTrade.OrderStatus.String(),
+		utcTime(hedgedTrade.LastStatusCheck),
+		hedgedTrade.ClosePrice,
+		utcTime(hedgedTrade.CloseTime),
+		hedgedTrade.BuyOrderID,
+		hedgedTrade.BuyRequestedQty,
+		hedgedTrade.BuyFilledQty,
+		hedgedTrade.StrategyVersion,
+		hedgedTrade.FeatureFlags,
+		hedgedTrade.StopLossPrice,
+		hedgedTrade.StopLossOrderID,
+		hedgedTrade.EntryFee,
+		hedgedTrade.ExitFee,
+		utcTime(hedgedTrade.ThresholdCrossedAt),
+		hedgedTrade.ThresholdCrossedPrice,
+		utcTime(hedgedTrade.BuyPlacedAt),
+		utcTime(hedgedTrade.BuyFilledAt),
+		utcTime(hedgedTrade.TakeProfitPlacedAt),
+		hedgedTrade.CloseIntendedPrice,
+		nullableID(hedgedTrade.HedgeGroupID))
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения хеджированной сделки: %w", err)
+	}
+
+	if hedgedTrade.HedgeID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("ошибка получения ID сохраненного хеджа: %w", err)
+	}
+
+	return nil
+}
+
+// GetHedgedTrades получает хеджированные сделки по статусу (nil - все сделки)
+func (r *SQLiteTradeRepository) GetHedgedTrades(ctx context.Context, status *string) ([]*entities.HedgedTrade, error) {
+	query := "SELECT " + sqliteHedgedTradeColumns + " FROM hedged_trades"
+	var args []interface{}
+	if status != nil {
+		query += " WHERE order_status = ?"
+		args = append(args, *status)
+	}
+	query += " ORDER BY hedge_time DESC, hedge_id DESC"
+
+	trades, err := r.queryHedgedTrades(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения хеджированных сделок: %w", err)
+	}
+	return trades, nil
+}
+
+// UpdateHedgedTradeStatus обновляет статус хеджированной сделки
+func (r *SQLiteTradeRepository) UpdateHedgedTradeStatus(ctx context.Context, orderID string, status entities.OrderStatus, closePrice *float64, closeTime *time.Time) error {
+	query := `
+		UPDATE hedged_trades
+		SET order_status = ?, last_status_check = ?, close_price = ?, close_time = ?
+		WHERE bybit_order_id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, status.String(), time.Now().UTC(), closePrice, utcTime(closeTime), orderID)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления статуса хеджированной сделки: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateHedgedTrade обновляет данные хеджированной сделки, найденной по текущему ID ордера
+func (r *SQLiteTradeRepository) UpdateHedgedTrade(ctx context.Context, orderID string, hedgedTrade *entities.HedgedTrade) error {
+	query := `
+		UPDATE hedged_trades
+		SET bybit_order_id = ?, buy_order_id = ?,
+		    hedge_open_price = ?, hedge_amount = ?, hedge_take_profit_price = ?,
+		    order_status = ?, last_status_check = ?, close_price = ?, close_time = ?,
+		    buy_requested_qty = ?, buy_filled_qty = ?,
+		    stop_loss_price = ?, stop_loss_order_id = ?,
+		    entry_fee = ?, exit_fee = ?,
+		    buy_filled_at = ?, take_profit_placed_at = ?,
+		    close_intended_price = ?
+		WHERE bybit_order_id = ?`
+
+	_, err := r.db.ExecContext(ctx, query,
+		hedgedTrade.BybitOrderID,
+		hedgedTrade.BuyOrderID,
+		hedgedTrade.HedgeOpenPrice,
+		hedgedTrade.HedgeAmount,
+		hedgedTrade.HedgeTakeProfitPrice,
+		hedgedTrade.OrderStatus.String(),
+		utcTime(hedgedTrade.LastStatusCheck),
+		hedgedTrade.ClosePrice,
+		utcTime(hedgedTrade.CloseTime),
+		hedgedTrade.BuyRequestedQty,
+		hedgedTrade.BuyFilledQty,
+		hedgedTrade.StopLossPrice,
+		hedgedTrade.StopLossOrderID,
+		hedgedTrade.EntryFee,
+		hedgedTrade.ExitFee,
+		utcTime(hedgedTrade.BuyFilledAt),
+		utcTime(hedgedTrade.TakeProfitPlacedAt),
+		hedgedTrade.CloseIntendedPrice,
+		orderID)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления хеджированной сделки: %w", err)
+	}
+
+	return nil
+}
+
+// GetHedgeHistory получает историю хедж-ордеров по конкретной сделке (все хеджи сделки, включая архив, новые первыми)
+func (r *SQLiteTradeRepository) GetHedgeHistory(ctx context.Context, tradeID int) ([]*entities.HedgedTrade, error) {
+	query := "SELECT " + sqliteHedgedTradeColumns + " FROM hedged_trades WHERE freqtrade_trade_id = ?" +
+		" UNION ALL SELECT " + sqliteHedgedTradeColumns + " FROM hedged_trades_archive WHERE freqtrade_trade_id = ?" +
+		" ORDER BY 5 DESC, 1 DESC"
+
+	trades, err := r.queryHedgedTrades(ctx, query, tradeID, tradeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории хеджирования: %w", err)
+	}
+	return trades, nil
+}
+
+// GetQuoteExposure возвращает агрегаты хеджей по котируемой валюте.
+// В SQLite нет split_part, поэтому котируемая валюта пары определяется в Go
+func (r *SQLiteTradeRepository) GetQuoteExposure(ctx context.Context, quote string, since time.Time) (*entities.QuoteExposure, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT pair, order_status, hedge_amount * hedge_open_price, hedge_time FROM hedged_trades")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения агрегатов по котируемой валюте %s: %w", quote, err)
+	}
+	defer rows.Close()
+
+	exposure := &entities.QuoteExposure{Quote: quote}
+	for rows.Next() {
+		var pair, status string
+		var notional float64
+		var hedgeTime time.Time
+		if err := rows.Scan(&pair, &status, &notional, &hedgeTime); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования агрегатов по котируемой валюте %s: %w", quote, err)
+		}
+		if valueobjects.NewTradingPair(pair).QuoteCurrency() != quote {
+			continue
+		}
+
+		orderStatus := entities.OrderStatusFromString(status)
+		if !orderStatus.IsCompleted() && orderStatus != entities.OrderStatusUnknown {
+			exposure.OpenHedges++
+			exposure.OpenNotional += notional
+		}
+		if !hedgeTime.Before(since) {
+			exposure.DailyNotional += notional
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+	return exposure, nil
+}
+
+// queryHedgedTrades выполняет запрос с колонками sqliteHedgedTradeColumns
+func (r *SQLiteTradeRepository) queryHedgedTrades(ctx context.Context, query string, args ...interface{}) ([]*entities.HedgedTrade, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trades []*entities.HedgedTrade
+	for rows.Next() {
+		trade := &entities.HedgedTrade{}
+		var orderStatusStr string
+
+		err := rows.Scan(
+			&trade.HedgeID,
+			&trade.FreqtradeTradeID,
+			&trade.Pair,
+			&trade.BybitOrderID,
+			&trade.HedgeTime,
+			&trade.FreqtradeOpenPrice,
+			&trade.FreqtradeAmount,
+			&trade.FreqtradeProfitRatio,
+			&trade.HedgeOpenPrice,
+			&trade.HedgeAmount,
+			&trade.HedgeTakeProfitPrice,
+			&orderStatusStr,
+			&trade.LastStatusCheck,
+			&trade.ClosePrice,
+			&trade.CloseTime,
+			&trade.BuyOrderID,
+			&trade.BuyRequestedQty,
+			&trade.BuyFilledQty,
+			&trade.StrategyVersion,
+			&trade.FeatureFlags,
+			&trade.StopLossPrice,
+			&trade.StopLossOrderID,
+			&trade.EntryFee,
+			&trade.ExitFee,
+			&trade.ThresholdCrossedAt,
+			&trade.ThresholdCrossedPrice,
+			&trade.BuyPlacedAt,
+			&trade.BuyFilledAt,
+			&trade.TakeProfitPlacedAt,
+			&trade.CloseIntendedPrice,
+			&trade.HedgeGroupID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования хеджированной сделки: %w", err)
+		}
+
+		trade.OrderStatus = entities.OrderStatusFromString(orderStatusStr)
+		trades = append(trades, trade)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+	return trades, nil
+}
+
+// utcTime переводит необязательное время в UTC, чтобы строки времени в SQLite сравнивались и сортировались корректно
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// QueryHedgedTrades возвращает страницу хеджей с фильтрами и сортировкой и общее количество подходящих хеджей
+func (r *SQLiteTradeRepository) QueryHedgedTrades(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeTradePage, error) {
+	utcQuery := *query
+	utcQuery.From = utcTime(query.From)
+	utcQuery.To = utcTime(query.To)
+	where, orderBy, limit, args := hedgeTradeQuerySQL(&utcQuery, func(int) string { return "?" }, "-1")
+	table := hedgeTradesTable(query)
+
+	page := &entities.HedgeTradePage{}
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+where, args...).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("ошибка подсчета хеджированных сделок: %w", err)
+	}
+
+	trades, err := r.queryHedgedTrades(ctx, "SELECT "+sqliteHedgedTradeColumns+" FROM "+table+where+orderBy+limit, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения хеджированных сделок: %w", err)
+	}
+	page.Trades = trades
+
+	if query.Facets {
+		if page.Pairs, err = r.queryStrings(ctx, "SELECT DISTINCT pair FROM "+table+" ORDER BY pair"); err != nil {
+			return nil, fmt.Errorf("ошибка получения списка пар: %w", err)
+		}
+		if page.Versions, err = r.queryStrings(ctx, "SELECT DISTINCT COALESCE(strategy_version, '') FROM "+table+" ORDER BY 1"); err != nil {
+			return nil, fmt.Errorf("ошибка получения списка версий стратегии: %w", err)
+		}
+	}
+	return page, nil
+}
+
+// queryStrings выполняет запрос, возвращающий одну текстовую колонку
+func (r *SQLiteTradeRepository) queryStrings(ctx context.Context, query string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// sqliteProfitBuckets выражения начала интервала ряда прибыли: date() возвращает день строкой ГГГГ-ММ-ДД,
+// модификаторы '-6 days', 'weekday 1' переводят дату к понедельнику ее недели
+var sqliteProfitBuckets = map[string]string{
+	entities.ProfitBucketDay:  "date(close_time)",
+	entities.ProfitBucketWeek: "date(close_time, '-6 days', 'weekday 1')",
+}
+
+// GetProfitTimeSeries возвращает реализованную прибыль хеджей, закрытых в [from, to), включая архив, по интервалам
+func (r *SQLiteTradeRepository) GetProfitTimeSeries(ctx context.Context, bucket string, from, to time.Time) ([]*entities.ProfitBucket, error) {
+	bucketStart, ok := sqliteProfitBuckets[bucket]
+	if !ok {
+		return nil, fmt.Errorf("неизвестный интервал ряда прибыли: %q", bucket)
+	}
+
+	// Нумерованные параметры: каждая граница используется в обеих таблицах
+	rows, err := r.db.QueryContext(ctx, profitSeriesQuery(bucketStart, "?1", "?2"), from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения ряда прибыли: %w", err)
+	}
+	defer rows.Close()
+
+	var series []*entities.ProfitBucket
+	for rows.Next() {
+		item := &entities.ProfitBucket{}
+		var start string
+		if err := rows.Scan(&start, &item.Hedges, &item.Wins, &item.RealizedProfit, &item.Fees); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования интервала ряда прибыли: %w", err)
+		}
+		if item.Start, err = time.Parse("2006-01-02", start); err != nil {
+			return nil, fmt.Errorf("некорректное начало интервала ряда прибыли %q: %w", start, err)
+		}
+		item.Finish()
+		series = append(series, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+	return series, nil
+}
+
+// GetProfitTotals возвращает итоги хеджей, закрытых до before, включая архив (начальная точка кривой прибыли)
+func (r *SQLiteTradeRepository) GetProfitTotals(ctx context.Context, before time.Time) (*entities.ProfitBucket, error) {
+	totals := &entities.ProfitBucket{}
+	err := r.db.QueryRowContext(ctx, profitTotalsQuery("?1"), before.UTC()).Scan(
+		&totals.Hedges, &totals.Wins, &totals.RealizedProfit, &totals.Fees)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения итогов прибыли: %w", err)
+	}
+	totals.Finish()
+	return totals, nil
+}
+
+// GetTradeStats считает статистику хеджей, подходящих под фильтры выборки, агрегацией в SQL
+func (r *SQLiteTradeRepository) GetTradeStats(ctx context.Context, query *entities.HedgeTradeQuery) (*entities.HedgeStats, error) {
+	utcQuery := *query
+	utcQuery.From = utcTime(query.From)
+	utcQuery.To = utcTime(query.To)
+	where, _, _, args := hedgeTradeQuerySQL(&utcQuery, func(int) string { return "?" }, "-1")
+	totals, byVersion := hedgeStatsQueries(hedgeTradesTable(query), where, "(julianday(close_time) - julianday(hedge_time)) * 86400")
+
+	stats := &entities.HedgeStats{}
+	var avgHoldSeconds *float64
+	err := r.db.QueryRowContext(ctx, totals, args...).Scan(
+		&stats.Total, &stats.Completed, &stats.OrderSize, &stats.RealizedProfit, &stats.Fees,
+		&stats.Wins, &stats.Losses, &avgHoldSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расчета статистики хеджей: %w", err)
+	}
+	finishHedgeStats(stats, avgHoldSeconds)
+
+	rows, err := r.db.QueryContext(ctx, byVersion, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расчета статистики по версиям стратегии: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version entities.HedgeVersionStats
+		if err := rows.Scan(&version.Version, &version.Total, &version.Completed, &version.RealizedProfit); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования статистики версии стратегии: %w", err)
+		}
+		stats.ByVersion = append(stats.ByVersion, version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %w", err)
+	}
+	return stats, nil
+}
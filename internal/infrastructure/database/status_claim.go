@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ClaimHedges захватывает хеджи для проверки статусов. Как и аренда, срок захвата считается по часам БД;
+// при одновременном захвате одного хеджа ON CONFLICT ... WHERE пропускает строку, уже продленную другим экземпляром
+func (r *PostgreSQLTradeRepository) ClaimHedges(ctx context.Context, holder string, hedgeIDs []int64, limit int, ttl time.Duration) ([]int64, error) {
+	if hedgeIDs == nil {
+		hedgeIDs = []int64{}
+	}
+
+	cleanup := `
+		DELETE FROM hedge_status_claims
+		WHERE hedge_id <> ALL($1) AND (holder = $2 OR expires_at < NOW())`
+	if _, err := r.pool.Exec(ctx, cleanup, hedgeIDs, holder); err != nil {
+		return nil, fmt.Errorf("ошибка удаления устаревших захватов хеджей: %w", err)
+	}
+	if len(hedgeIDs) == 0 {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = len(hedgeIDs)
+	}
+
+	query := `
+		WITH candidates AS (
+			SELECT ids.id
+			FROM unnest($1::BIGINT[]) AS ids(id)
+			LEFT JOIN hedge_status_claims c ON c.hedge_id = ids.id
+			WHERE c.hedge_id IS NULL OR c.holder = $2 OR c.expires_at < NOW()
+			ORDER BY (c.holder = $2) IS TRUE DESC, ids.id
+			LIMIT $4
+		)
+		INSERT INTO hedge_status_claims (hedge_id, holder, expires_at)
+		SELECT id, $2, NOW() + $3 * INTERVAL '1 second' FROM candidates
+		ON CONFLICT (hedge_id) DO UPDATE SET
+			holder = EXCLUDED.holder,
+			expires_at = EXCLUDED.expires_at
+		WHERE hedge_status_claims.holder = EXCLUDED.holder
+			OR hedge_status_claims.expires_at < NOW()
+		RETURNING hedge_id`
+
+	rows, err := r.pool.Query(ctx, query, hedgeIDs, holder, ttl.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка захвата хеджей для проверки статусов: %w", err)
+	}
+	defer rows.Close()
+
+	var claimed []int64
+	for rows.Next() {
+		var hedgeID int64
+		if err := rows.Scan(&hedgeID); err != nil {
+			return nil, fmt.Errorf("ошибка чтения захваченного хеджа: %w", err)
+		}
+		claimed = append(claimed, hedgeID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения захваченных хеджей: %w", err)
+	}
+
+	return claimed, nil
+}
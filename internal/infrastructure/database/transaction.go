@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// txContextKey ключ контекста открытой транзакции
+type txContextKey struct{}
+
+// WithinTransaction выполняет fn в транзакции, переданной репозиториям через контекст.
+// Если в контексте уже есть транзакция, fn выполняется в ней
+func (r *PostgreSQLTradeRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return nil
+}
+
+// txFromContext возвращает транзакцию, открытую WithinTransaction
+func txFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// queryRow выполняет запрос в транзакции контекста, если она открыта, иначе - через пул
+func (r *PostgreSQLTradeRepository) queryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.QueryRow(ctx, query, args...)
+	}
+	return r.pool.QueryRow(ctx, query, args...)
+}
+
+// exec выполняет команду в транзакции контекста, если она открыта, иначе - через пул
+func (r *PostgreSQLTradeRepository) exec(ctx context.Context, query string, args ...interface{}) error {
+	if tx, ok := txFromContext(ctx); ok {
+		_, err := tx.Exec(ctx, query, args...)
+		return err
+	}
+	_, err := r.pool.Exec(ctx, query, args...)
+	return err
+}
+
+// query выполняет запрос строк в транзакции контекста, если она открыта, иначе - через пул.
+// Чтение в транзакции видит ее незафиксированные записи
+func (r *PostgreSQLTradeRepository) query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.Query(ctx, query, args...)
+	}
+	return r.pool.Query(ctx, query, args...)
+}
@@ -0,0 +1,216 @@
+// Package cron разбирает расписания в формате cron (минута, час, день месяца, месяц, день недели)
+// и рассчитывает время следующего запуска
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// descriptors сокращения расписаний
+var descriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// field границы поля расписания
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"минута", 0, 59},
+	{"час", 0, 23},
+	{"день месяца", 1, 31},
+	{"месяц", 1, 12},
+	{"день недели", 0, 7}, // 0 и 7 - воскресенье
+}
+
+// searchLimit сколько лет вперед искать следующий запуск (расписание "0 0 30 2 *" не наступает никогда)
+const searchLimit = 5
+
+// maxGapRuns сколько запусков перебирает LongestGap (неделя расписания "* * * * 1-5" - 7200 запусков)
+const maxGapRuns = 20000
+
+// Schedule разобранное расписание: биты разрешенных значений каждого поля
+type Schedule struct {
+	expr     string
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+	anyDay   bool // День месяца не ограничен ("*", "*/n")
+	anyWeek  bool // День недели не ограничен ("*", "*/n")
+}
+
+// Parse разбирает расписание из пяти полей: "*", числа, списки через запятую, диапазоны "a-b"
+// и шаги "*/n", "a-b/n"; а также сокращения @hourly, @daily, @weekly, @monthly, @yearly.
+// Как в cron, если ограничены и день месяца, и день недели, запуск выполняется при совпадении любого из них
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if descriptor, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = descriptor
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("расписание %q: ожидается 5 полей (минута час день месяц день_недели), получено %d", expr, len(parts))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, part := range parts {
+		value, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("расписание %q: %w", expr, err)
+		}
+		bits[i] = value
+	}
+
+	weekdays := bits[4]
+	if weekdays&(1<<7) != 0 {
+		weekdays |= 1
+	}
+	return &Schedule{
+		expr:     strings.TrimSpace(expr),
+		minutes:  bits[0],
+		hours:    bits[1],
+		days:     bits[2],
+		months:   bits[3],
+		weekdays: weekdays,
+		anyDay:   strings.HasPrefix(parts[2], "*"),
+		anyWeek:  strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseField разбирает одно поле в биты разрешенных значений
+func parseField(part string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(part, ",") {
+		rangePart, step := item, 1
+		if slash := strings.Index(item, "/"); slash >= 0 {
+			rangePart = item[:slash]
+			n, err := strconv.Atoi(item[slash+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s: недопустимый шаг в %q", f.name, item)
+			}
+			step = n
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			low, err1 = strconv.Atoi(bounds[0])
+			high, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("%s: недопустимый диапазон %q", f.name, item)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("%s: недопустимое значение %q", f.name, item)
+			}
+			low, high = n, n
+			if step > 1 {
+				high = f.max
+			}
+		}
+		if low < f.min || high > f.max || low > high {
+			return 0, fmt.Errorf("%s: значение %q вне диапазона %d-%d", f.name, item, f.min, f.max)
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// String возвращает исходное расписание
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next возвращает ближайшее время запуска строго после after (с точностью до минуты, в часовом поясе after).
+// Нулевое время - расписание не наступает в ближайшие годы
+func (s *Schedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Year() + searchLimit
+
+	for t.Year() <= limit {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// LongestGap возвращает наибольший промежуток между соседними запусками за horizon после from
+// (не дальше maxGapRuns запусков): для проверки порогов, которые должны превышать период циклов.
+// 0 - расписание не наступает
+func (s *Schedule) LongestGap(from time.Time, horizon time.Duration) time.Duration {
+	prev := s.Next(from)
+	if prev.IsZero() {
+		return 0
+	}
+
+	var longest time.Duration
+	limit := prev.Add(horizon)
+	for i := 0; i < maxGapRuns && prev.Before(limit); i++ {
+		next := s.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if gap := next.Sub(prev); gap > longest {
+			longest = gap
+		}
+		prev = next
+	}
+	return longest
+}
+
+// Matches проверяет, что минута t входит в расписание
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.months&(1<<uint(t.Month())) != 0 && s.dayMatches(t) &&
+		s.hours&(1<<uint(t.Hour())) != 0 && s.minutes&(1<<uint(t.Minute())) != 0
+}
+
+// dayMatches проверяет день месяца и день недели по правилам cron
+func (s *Schedule) dayMatches(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekdays&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeek:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeek:
+		return day
+	default:
+		return day || weekday
+	}
+}
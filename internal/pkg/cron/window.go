@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// minutesPerDay минут в сутках
+const minutesPerDay = 24 * 60
+
+// Window ежедневное окно времени "ЧЧ:ММ-ЧЧ:ММ": начало входит в окно, конец - нет.
+// Окно с концом раньше начала переходит через полночь ("22:00-02:00")
+type Window struct {
+	start int // Минута суток начала
+	end   int // Минута суток конца
+}
+
+// ParseWindow разбирает окно "ЧЧ:ММ-ЧЧ:ММ" (конец "24:00" - до полуночи)
+func ParseWindow(spec string) (Window, error) {
+	parts := strings.Split(strings.TrimSpace(spec), "-")
+	if len(parts) != 2 {
+		return Window{}, fmt.Errorf("окно %q: ожидается формат ЧЧ:ММ-ЧЧ:ММ", spec)
+	}
+	start, err := parseClock(parts[0], false)
+	if err != nil {
+		return Window{}, fmt.Errorf("окно %q: %w", spec, err)
+	}
+	end, err := parseClock(parts[1], true)
+	if err != nil {
+		return Window{}, fmt.Errorf("окно %q: %w", spec, err)
+	}
+	if start == end%minutesPerDay {
+		return Window{}, fmt.Errorf("окно %q: начало совпадает с концом", spec)
+	}
+	return Window{start: start, end: end % minutesPerDay}, nil
+}
+
+// parseClock разбирает время суток "ЧЧ:ММ" в минуты; allowMidnight разрешает "24:00"
+func parseClock(value string, allowMidnight bool) (int, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err == nil {
+		return clock.Hour()*60 + clock.Minute(), nil
+	}
+	if allowMidnight && strings.TrimSpace(value) == "24:00" {
+		return minutesPerDay, nil
+	}
+	return 0, fmt.Errorf("некорректное время %q: ожидается ЧЧ:ММ", strings.TrimSpace(value))
+}
+
+// Contains проверяет, что t (в своем часовом поясе) попадает в окно
+func (w Window) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if w.start < w.end {
+		return minute >= w.start && minute < w.end
+	}
+	return minute >= w.start || minute < w.end
+}
+
+// End возвращает ближайший после t конец окна в часовом поясе t
+func (w Window) End(t time.Time) time.Time {
+	end := time.Date(t.Year(), t.Month(), t.Day(), w.end/60, w.end%60, 0, 0, t.Location())
+	if !end.After(t) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// String возвращает окно в формате "ЧЧ:ММ-ЧЧ:ММ"
+func (w Window) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.start/60, w.start%60, w.end/60, w.end%60)
+}
@@ -0,0 +1,194 @@
+package logger
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Уровни строк журнала в памяти. Сообщения приложения не размечены уровнем явно:
+// уровень определяется по эмодзи в начале сообщения
+const (
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// levelRank порядок уровней для фильтра "не ниже"
+var levelRank = map[string]int{
+	LevelInfo:  0,
+	LevelWarn:  1,
+	LevelError: 2,
+}
+
+// IsLevel проверяет, что уровень поддерживается
+func IsLevel(level string) bool {
+	_, ok := levelRank[level]
+	return ok
+}
+
+// Entry строка журнала в памяти
+type Entry struct {
+	Seq     uint64    `json:"seq"` // Сквозной номер строки с запуска (для догрузки новых строк)
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+}
+
+// ringBuffer последние строки журнала фиксированной емкости
+type ringBuffer struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int // Позиция следующей записи
+	full    bool
+	seq     uint64
+}
+
+// buffer журнал в памяти (nil - не включен)
+var (
+	bufferMu sync.RWMutex
+	buffer   *ringBuffer
+)
+
+// EnableBuffer включает журнал последних capacity строк в памяти для просмотра в веб-интерфейсе
+// (0 - выключает). Строки, записанные до включения, в журнал не попадают
+func EnableBuffer(capacity int) {
+	bufferMu.Lock()
+	defer bufferMu.Unlock()
+	if capacity <= 0 {
+		buffer = nil
+		return
+	}
+	buffer = &ringBuffer{entries: make([]Entry, capacity)}
+}
+
+// BufferCapacity возвращает емкость журнала в памяти (0 - не включен)
+func BufferCapacity() int {
+	bufferMu.RLock()
+	defer bufferMu.RUnlock()
+	if buffer == nil {
+		return 0
+	}
+	return len(buffer.entries)
+}
+
+// Recent возвращает до limit последних строк с уровнем не ниже minLevel и номером больше afterSeq
+// (старые первыми) и номер последней записанной строки
+func Recent(limit int, minLevel string, afterSeq uint64) ([]Entry, uint64) {
+	bufferMu.RLock()
+	b := buffer
+	bufferMu.RUnlock()
+	if b == nil {
+		return nil, 0
+	}
+	return b.recent(limit, levelRank[minLevel], afterSeq)
+}
+
+// BufferWriter возвращает io.Writer, записывающий строки в журнал в памяти: точка входа подключает его
+// к стандартному log, чтобы в журнал попадали и сообщения, выведенные через log.Printf
+func BufferWriter() io.Writer {
+	return bufferWriter{}
+}
+
+// bufferWriter записывает вывод стандартного log в журнал в памяти
+type bufferWriter struct{}
+
+// Write записывает каждую непустую строку p отдельной строкой журнала без метки времени стандартного log
+func (bufferWriter) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(string(p), "\n") {
+		record(trimStdLogTime(line))
+	}
+	return len(p), nil
+}
+
+// stdLogTimeFormat метка времени стандартного log (флаги log.LstdFlags)
+const stdLogTimeFormat = "2006/01/02 15:04:05 "
+
+// trimStdLogTime убирает метку времени стандартного log в начале строки: время хранится в Entry.Time
+func trimStdLogTime(line string) string {
+	if len(line) < len(stdLogTimeFormat) {
+		return line
+	}
+	if _, err := time.Parse(stdLogTimeFormat, line[:len(stdLogTimeFormat)]); err != nil {
+		return line
+	}
+	return line[len(stdLogTimeFormat):]
+}
+
+// record добавляет строку в журнал в памяти с уровнем по эмодзи сообщения
+func record(message string) {
+	recordLevel(levelOf(message), message)
+}
+
+// recordLevel добавляет строку в журнал в памяти, если он включен
+func recordLevel(level, message string) {
+	message = strings.TrimRight(message, " \r\n")
+	if strings.TrimSpace(message) == "" {
+		return
+	}
+	bufferMu.RLock()
+	b := buffer
+	bufferMu.RUnlock()
+	if b != nil {
+		b.add(time.Now(), level, message)
+	}
+}
+
+// levelOf определяет уровень сообщения по эмодзи в начале (после метки времени, если она есть)
+func levelOf(message string) string {
+	if strings.HasPrefix(message, "[") {
+		if end := strings.Index(message, "] "); end > 0 {
+			message = message[end+2:]
+		}
+	}
+	message = strings.TrimSpace(message)
+	switch {
+	case strings.HasPrefix(message, "❌"), strings.HasPrefix(message, "🚨"), strings.HasPrefix(message, "💥"):
+		return LevelError
+	case strings.HasPrefix(message, "⚠"):
+		return LevelWarn
+	}
+	return LevelInfo
+}
+
+// add записывает строку, вытесняя самую старую при заполнении
+func (b *ringBuffer) add(at time.Time, level, message string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.seq++
+	b.entries[b.next] = Entry{Seq: b.seq, Time: at, Level: level, Message: message}
+	b.next++
+	if b.next == len(b.entries) {
+		b.next = 0
+		b.full = true
+	}
+}
+
+// recent отбирает строки с конца журнала
+func (b *ringBuffer) recent(limit, minRank int, afterSeq uint64) ([]Entry, uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	size := b.next
+	if b.full {
+		size = len(b.entries)
+	}
+
+	var selected []Entry
+	for i := 1; i <= size && (limit <= 0 || len(selected) < limit); i++ {
+		entry := b.entries[(b.next-i+len(b.entries))%len(b.entries)]
+		if entry.Seq <= afterSeq {
+			break
+		}
+		if levelRank[entry.Level] >= minRank {
+			selected = append(selected, entry)
+		}
+	}
+
+	// Отбирали с конца: возвращаем в хронологическом порядке
+	for i, j := 0, len(selected)-1; i < j; i, j = i+1, j-1 {
+		selected[i], selected[j] = selected[j], selected[i]
+	}
+	return selected, b.seq
+}
@@ -3,34 +3,47 @@ package logger
 import (
 	"fmt"
 	"log"
+	"os"
+	"strings"
 	"time"
 )
 
 // timeFormat единый формат времени для всех логов
 const timeFormat = "2006-01-02 15:04:05"
 
+// stderr вывод LogError и LogInfo. Отдельный от стандартного log, чтобы строки не попадали в журнал
+// в памяти дважды, когда стандартный log подключен к BufferWriter
+var stderr = log.New(os.Stderr, "", log.LstdFlags)
+
 // LogWithTime выводит сообщение с единым форматом времени
 func LogWithTime(format string, args ...interface{}) {
 	timestamp := time.Now().Format(timeFormat)
 	message := fmt.Sprintf(format, args...)
 	fmt.Printf("[%s] %s\n", timestamp, message)
+	record(message)
 }
 
 // LogPlain выводит сообщение без времени (для многострочных выводов)
 func LogPlain(format string, args ...interface{}) {
-	fmt.Printf(format, args...)
+	message := fmt.Sprintf(format, args...)
+	fmt.Print(message)
+	for _, line := range strings.Split(message, "\n") {
+		record(line)
+	}
 }
 
 // LogError выводит ошибку с временной меткой
 func LogError(format string, args ...interface{}) {
 	timestamp := time.Now().Format(timeFormat)
 	message := fmt.Sprintf(format, args...)
-	log.Printf("[%s] %s", timestamp, message)
+	stderr.Printf("[%s] %s", timestamp, message)
+	recordLevel(LevelError, message)
 }
 
 // LogInfo выводит информационное сообщение с временной меткой
 func LogInfo(format string, args ...interface{}) {
 	timestamp := time.Now().Format(timeFormat)
 	message := fmt.Sprintf(format, args...)
-	log.Printf("[%s] %s", timestamp, message)
+	stderr.Printf("[%s] %s", timestamp, message)
+	record(message)
 }
@@ -0,0 +1,279 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"trade-hedge/internal/pkg/logger"
+)
+
+const (
+	queueSize     = 4096            // Завершенных спанов в очереди отправки; при переполнении новые отбрасываются
+	batchSize     = 512             // Спанов в одном запросе
+	flushInterval = 5 * time.Second // Отправка неполной пачки
+	exportTimeout = 10 * time.Second
+	scopeName     = "trade-hedge"
+)
+
+// Options настройки трассировки
+type Options struct {
+	Endpoint    string  // OTLP/HTTP коллектор (http://localhost:4318); путь /v1/traces добавляется, если не указан
+	ServiceName string  // service.name ресурса
+	InstanceID  string  // service.instance.id ресурса ("" - не передается)
+	SampleRatio float64 // Доля отправляемых трасс (0-1)
+}
+
+// Init включает трассировку и запускает отправку спанов. Возвращает функцию остановки,
+// которая отправляет оставшиеся спаны и выключает трассировку
+func Init(options Options) func(ctx context.Context) error {
+	endpoint := strings.TrimRight(options.Endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+
+	resource := []Attribute{String("service.name", options.ServiceName)}
+	if options.InstanceID != "" {
+		resource = append(resource, String("service.instance.id", options.InstanceID))
+	}
+
+	e := &exporter{
+		url:        endpoint,
+		httpClient: &http.Client{Timeout: exportTimeout},
+		resource:   encodeAttributes(resource),
+		queue:      make(chan *Span, queueSize),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go e.run()
+	current.Store(&tracer{sampleRatio: options.SampleRatio, exporter: e})
+	logger.LogWithTime("🔭 Трассировка OpenTelemetry включена: %s (доля трасс %.2f)", endpoint, options.SampleRatio)
+
+	return func(ctx context.Context) error {
+		current.Store(nil)
+		close(e.stop)
+		select {
+		case <-e.done:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("трассировка: не все спаны отправлены: %w", ctx.Err())
+		}
+	}
+}
+
+// exporter отправляет завершенные спаны на коллектор пачками в фоне, чтобы не задерживать цикл
+type exporter struct {
+	url        string
+	httpClient *http.Client
+	resource   []otlpKeyValue
+	queue      chan *Span
+	stop       chan struct{}
+	done       chan struct{}
+
+	dropped atomic.Int64 // Спанов, отброшенных из-за переполнения очереди
+	failing atomic.Bool  // Последняя отправка не удалась (ошибки логируются один раз до восстановления)
+}
+
+// enqueue ставит спан в очередь; при переполнении спан отбрасывается
+func (e *exporter) enqueue(span *Span) {
+	select {
+	case e.queue <- span:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// run собирает пачки и отправляет их по заполнении или раз в flushInterval
+func (e *exporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, batchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			e.export(batch)
+			batch = batch[:0]
+		}
+	}
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.stop:
+			for {
+				select {
+				case span := <-e.queue:
+					batch = append(batch, span)
+					if len(batch) >= batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// export отправляет пачку спанов (ExportTraceServiceRequest в JSON)
+func (e *exporter) export(batch []*Span) {
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, span := range batch {
+		spans = append(spans, encodeSpan(span))
+	}
+	body, err := json.Marshal(otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: e.resource},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: scopeName}, Spans: spans}},
+	}}})
+	if err != nil {
+		logger.LogWithTime("❌ Трассировка: ошибка формирования пачки спанов: %v", err)
+		return
+	}
+
+	if err := e.post(body); err != nil {
+		if !e.failing.Swap(true) {
+			logger.LogWithTime("⚠️ Трассировка: коллектор %s недоступен, спаны отбрасываются: %v", e.url, err)
+		}
+		return
+	}
+	if e.failing.Swap(false) {
+		logger.LogWithTime("✅ Трассировка: отправка спанов на %s восстановлена", e.url)
+	}
+	if dropped := e.dropped.Swap(0); dropped > 0 {
+		logger.LogWithTime("⚠️ Трассировка: отброшено спанов из-за переполнения очереди: %d", dropped)
+	}
+}
+
+// post выполняет запрос к коллектору
+func (e *exporter) post(body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := e.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("коллектор ответил %s", response.Status)
+	}
+	return nil
+}
+
+// Формат OTLP/HTTP JSON (opentelemetry-proto): ID трассы и спана - hex, 64-битные числа - строки
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"` // 0 - не задан, 2 - ошибка
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+// encodeSpan переводит завершенный спан в формат OTLP
+func encodeSpan(span *Span) otlpSpan {
+	span.mu.Lock()
+	defer span.mu.Unlock()
+
+	encoded := otlpSpan{
+		TraceID:           hex.EncodeToString(span.traceID[:]),
+		SpanID:            hex.EncodeToString(span.spanID[:]),
+		Name:              span.name,
+		Kind:              span.kind,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+		Attributes:        encodeAttributes(span.attributes),
+	}
+	if span.parentID != ([8]byte{}) {
+		encoded.ParentSpanID = hex.EncodeToString(span.parentID[:])
+	}
+	if span.statusError {
+		encoded.Status = otlpStatus{Code: 2, Message: span.statusMessage}
+	}
+	return encoded
+}
+
+// encodeAttributes переводит атрибуты в формат OTLP; значения других типов передаются строкой
+func encodeAttributes(attributes []Attribute) []otlpKeyValue {
+	encoded := make([]otlpKeyValue, 0, len(attributes))
+	for _, attribute := range attributes {
+		var value otlpValue
+		switch v := attribute.Value.(type) {
+		case string:
+			value.StringValue = &v
+		case int64:
+			s := strconv.FormatInt(v, 10)
+			value.IntValue = &s
+		case float64:
+			value.DoubleValue = &v
+		case bool:
+			value.BoolValue = &v
+		default:
+			s := fmt.Sprint(v)
+			value.StringValue = &s
+		}
+		encoded = append(encoded, otlpKeyValue{Key: attribute.Key, Value: value})
+	}
+	return encoded
+}
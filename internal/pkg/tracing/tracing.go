@@ -0,0 +1,217 @@
+// Package tracing трассировка циклов стратегии в формате OpenTelemetry: спаны передаются через контекст,
+// завершенные спаны отправляются пачками на OTLP/HTTP коллектор (JSON). Пока трассировка не включена
+// (Init не вызван), Start возвращает nil-спан, методы которого ничего не делают
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SpanKind вид спана OTLP
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1 // Внутренняя операция (цикл, хеджирование сделки)
+	SpanKindServer   SpanKind = 2 // Обработка входящего запроса
+	SpanKindClient   SpanKind = 3 // Исходящий запрос (биржа, Freqtrade, база данных)
+)
+
+// Attribute атрибут спана: значение string, int, int64, float64 или bool
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String создает строковый атрибут
+func String(key, value string) Attribute { return Attribute{Key: key, Value: value} }
+
+// Int создает целочисленный атрибут
+func Int(key string, value int) Attribute { return Attribute{Key: key, Value: int64(value)} }
+
+// Int64 создает целочисленный атрибут
+func Int64(key string, value int64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Float64 создает атрибут с плавающей точкой
+func Float64(key string, value float64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Bool создает логический атрибут
+func Bool(key string, value bool) Attribute { return Attribute{Key: key, Value: value} }
+
+// Span операция трассировки. Все методы допускают nil-спан (трассировка выключена)
+type Span struct {
+	tracer   *tracer
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte // Нулевой у корневого спана
+	sampled  bool    // Трасса отобрана для отправки (решение принимается для корневого спана)
+
+	mu            sync.Mutex
+	name          string
+	kind          SpanKind
+	start         time.Time
+	end           time.Time
+	attributes    []Attribute
+	statusError   bool
+	statusMessage string
+	ended         bool
+}
+
+// tracer настройки трассировки и очередь отправки
+type tracer struct {
+	sampleRatio float64
+	exporter    *exporter
+}
+
+// current включенная трассировка (nil - выключена)
+var current atomic.Pointer[tracer]
+
+// spanContextKey ключ текущего спана в контексте
+type spanContextKey struct{}
+
+// Enabled проверяет, что трассировка включена
+func Enabled() bool {
+	return current.Load() != nil
+}
+
+// Start начинает спан: дочерний, если в контексте есть спан, иначе корневой спан новой трассы.
+// Возвращает контекст со спаном для дочерних операций; спан нужно завершить End
+func Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	t := current.Load()
+	if t == nil {
+		return ctx, nil
+	}
+	span := newSpan(t, FromContext(ctx), name, attributes)
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// StartChild начинает спан, только если контекст уже принадлежит трассе (цикл стратегии): запросы
+// к бирже и базе данных вне цикла (веб-интерфейс, фоновые проверки) не порождают отдельных трасс
+func StartChild(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	if FromContext(ctx) == nil {
+		return ctx, nil
+	}
+	return Start(ctx, name, attributes...)
+}
+
+// Record записывает завершенную дочернюю операцию задним числом (запрос к базе данных, о котором
+// драйвер сообщает после выполнения). Вне трассы ничего не записывается
+func Record(ctx context.Context, name string, kind SpanKind, start, end time.Time, err error, attributes ...Attribute) {
+	parent := FromContext(ctx)
+	t := current.Load()
+	if parent == nil || t == nil {
+		return
+	}
+	span := newSpan(t, parent, name, attributes)
+	span.kind = kind
+	span.start = start
+	span.RecordError(err)
+	span.finish(end)
+}
+
+// FromContext возвращает текущий спан контекста (nil - вне трассы)
+func FromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// newSpan создает спан: корневой спан решает, отбирается ли трасса, дочерние наследуют решение
+func newSpan(t *tracer, parent *Span, name string, attributes []Attribute) *Span {
+	span := &Span{
+		tracer:     t,
+		name:       name,
+		kind:       SpanKindInternal,
+		start:      time.Now(),
+		attributes: append([]Attribute(nil), attributes...),
+	}
+	_, _ = rand.Read(span.spanID[:])
+	if parent != nil {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+		span.sampled = parent.sampled
+		return span
+	}
+	_, _ = rand.Read(span.traceID[:])
+	span.sampled = sampled(span.traceID, t.sampleRatio)
+	return span
+}
+
+// sampled отбирает трассу по доле sampleRatio: решение зависит только от ID трассы
+func sampled(traceID [16]byte, ratio float64) bool {
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+	return float64(binary.BigEndian.Uint64(traceID[8:])>>11)/(1<<53) < ratio
+}
+
+// SetKind задает вид спана
+func (s *Span) SetKind(kind SpanKind) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.kind = kind
+	s.mu.Unlock()
+}
+
+// SetAttributes добавляет атрибуты спана
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.attributes = append(s.attributes, attributes...)
+	s.mu.Unlock()
+}
+
+// RecordError отмечает спан ошибочным (nil игнорируется)
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.statusError = true
+	s.statusMessage = err.Error()
+	s.mu.Unlock()
+}
+
+// End завершает спан и ставит его в очередь отправки (повторный вызов игнорируется)
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.finish(time.Now())
+}
+
+// finish завершает спан временем end
+func (s *Span) finish(end time.Time) {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = end
+	s.mu.Unlock()
+
+	if s.sampled {
+		s.tracer.exporter.enqueue(s)
+	}
+}
+
+// TraceID возвращает ID трассы в hex ("" - вне трассы или трасса не отобрана): по нему трасса
+// находится в Jaeger или Tempo из лога и оповещения cycle_completed
+func (s *Span) TraceID() string {
+	if s == nil || !s.sampled {
+		return ""
+	}
+	return hex.EncodeToString(s.traceID[:])
+}
@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// AccountPairSummary сводка по истории ордеров аккаунта для одной пары
+type AccountPairSummary struct {
+	Pair         string    `json:"pair"`
+	Orders       int       `json:"orders"`         // Всего ордеров за период
+	FilledBuys   int       `json:"filled_buys"`    // Исполненных (полностью или частично) покупок
+	FilledSells  int       `json:"filled_sells"`   // Исполненных (полностью или частично) продаж
+	BoughtQty    float64   `json:"bought_qty"`     // Куплено базовой валюты
+	SoldQty      float64   `json:"sold_qty"`       // Продано базовой валюты
+	BuyVolume    float64   `json:"buy_volume"`     // Объем покупок в котируемой валюте
+	SellVolume   float64   `json:"sell_volume"`    // Объем продаж в котируемой валюте
+	AvgBuyPrice  float64   `json:"avg_buy_price"`  // Средневзвешенная цена покупки
+	AvgSellPrice float64   `json:"avg_sell_price"` // Средневзвешенная цена продажи
+	FirstOrderAt time.Time `json:"first_order_at"`
+	LastOrderAt  time.Time `json:"last_order_at"`
+}
+
+// AccountHistoryUseCase импортирует историю ордеров биржевого аккаунта, размещенных до запуска бота,
+// чтобы аналитика показывала контекст торговли по парам еще до первых хеджей
+type AccountHistoryUseCase struct {
+	exchangeOrderRepo repositories.ExchangeOrderRepository
+	exchangeService   services.ExchangeService
+	pairs             []string
+	days              int
+}
+
+// NewAccountHistoryUseCase создает новый use case истории ордеров аккаунта
+func NewAccountHistoryUseCase(
+	exchangeOrderRepo repositories.ExchangeOrderRepository,
+	exchangeService services.ExchangeService,
+	pairs []string,
+	days int,
+) *AccountHistoryUseCase {
+	return &AccountHistoryUseCase{
+		exchangeOrderRepo: exchangeOrderRepo,
+		exchangeService:   exchangeService,
+		pairs:             pairs,
+		days:              days,
+	}
+}
+
+// ImportOnce импортирует историю ордеров пар, для которых она еще не импортировалась.
+// Вызывается при запуске: повторные запуски не обращаются к бирже за уже импортированными парами
+func (u *AccountHistoryUseCase) ImportOnce(ctx context.Context) error {
+	history, ok := u.exchangeService.(services.OrderHistoryExchangeService)
+	if !ok {
+		logger.LogWithTime("ℹ️ Биржа не предоставляет историю ордеров - импорт пропущен")
+		return nil
+	}
+
+	end := time.Now().UTC()
+	start := end.AddDate(0, 0, -u.days)
+
+	for _, pairName := range u.pairs {
+		imported, err := u.exchangeOrderRepo.HasExchangeOrders(ctx, pairName)
+		if err != nil {
+			return err
+		}
+		if imported {
+			continue
+		}
+
+		pair := valueobjects.NewTradingPair(pairName)
+		orders, err := history.GetOrderHistory(ctx, pair.ToBybitFormat(), start, end)
+		if err != nil {
+			return fmt.Errorf("ошибка получения истории ордеров %s: %w", pairName, err)
+		}
+		for _, order := range orders {
+			order.Pair = pairName
+		}
+
+		saved, err := u.exchangeOrderRepo.SaveExchangeOrders(ctx, orders)
+		if err != nil {
+			return err
+		}
+		logger.LogWithTime("📥 Импортирована история ордеров %s за %d дней: %d ордеров", pairName, u.days, saved)
+	}
+
+	return nil
+}
+
+// Summarize возвращает сводку по импортированной истории ордеров за последние days дней
+func (u *AccountHistoryUseCase) Summarize(ctx context.Context, days int) ([]AccountPairSummary, error) {
+	orders, err := u.exchangeOrderRepo.GetExchangeOrders(ctx, time.Now().UTC().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]*AccountPairSummary)
+	for _, order := range orders {
+		summary := summaries[order.Pair]
+		if summary == nil {
+			summary = &AccountPairSummary{Pair: order.Pair, FirstOrderAt: order.CreatedAt}
+			summaries[order.Pair] = summary
+		}
+		summary.Orders++
+		summary.LastOrderAt = order.CreatedAt
+
+		if order.FilledQty <= 0 {
+			continue
+		}
+		switch order.Side {
+		case entities.OrderSideBuy:
+			summary.FilledBuys++
+			summary.BoughtQty += order.FilledQty
+			summary.BuyVolume += order.FilledValue()
+		case entities.OrderSideSell:
+			summary.FilledSells++
+			summary.SoldQty += order.FilledQty
+			summary.SellVolume += order.FilledValue()
+		}
+	}
+
+	result := make([]AccountPairSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		if summary.BoughtQty > 0 {
+			summary.AvgBuyPrice = summary.BuyVolume / summary.BoughtQty
+		}
+		if summary.SoldQty > 0 {
+			summary.AvgSellPrice = summary.SellVolume / summary.SoldQty
+		}
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Pair < result[j].Pair
+	})
+
+	return result, nil
+}
@@ -0,0 +1,214 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// Ключи групп оповещений
+const (
+	AlertKeyStrategy    = "strategy"     // Ошибка цикла стратегии хеджирования
+	AlertKeyStatusCheck = "status_check" // Ошибка проверки статусов ордеров
+	AlertKeyWatchdog    = "watchdog:"    // Префикс: зависание компонента
+	AlertKeyBalance     = "balance:"     // Префикс: расхождение баланса актива
+
+	// Критические условия: о них оповещают и каналы только для критических сбоев (email)
+	AlertKeyExchangeAuth = "exchange_auth" // Биржа отклоняет ключ API (неверный, просрочен, нет прав, IP не разрешен)
+	AlertKeyDatabase     = "database"      // База данных недоступна
+	AlertKeyStuckHedge   = "stuck_hedge:"  // Префикс: позиция куплена, но тейк-профит не выставлен дольше порога
+)
+
+// criticalAlertKeys ключи (и префиксы ключей) критических условий
+var criticalAlertKeys = []string{AlertKeyExchangeAuth, AlertKeyDatabase, AlertKeyStuckHedge}
+
+// IsCriticalAlert проверяет, что ключ группы оповещений относится к критическому условию
+func IsCriticalAlert(key string) bool {
+	for _, critical := range criticalAlertKeys {
+		if key == critical || (strings.HasSuffix(critical, ":") && strings.HasPrefix(key, critical)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveAlert открытая группа оповещений об условии, которое еще не устранено
+type ActiveAlert struct {
+	Key         string    `json:"key"`
+	Title       string    `json:"title"`
+	Message     string    `json:"message"` // Текст последнего повтора
+	Priority    string    `json:"priority"`
+	Occurrences int       `json:"occurrences"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+	Escalated   bool      `json:"escalated"`
+}
+
+// activeAlert состояние группы оповещений
+type activeAlert struct {
+	last      *entities.Notification // Последний повтор условия
+	count     int
+	firstSeen time.Time
+	lastSent  time.Time
+	escalated bool
+}
+
+// AlertManager группирует повторяющиеся оповещения об одном условии по ключу, чтобы ошибка, повторяющаяся
+// в каждом цикле (например, недоступный Freqtrade), не отправлялась каждый раз. Оператор получает первое
+// оповещение, эскалацию с высоким приоритетом после escalateAfter повторов, напоминания не чаще repeatInterval
+// и оповещение об устранении, когда условие пропадает
+type AlertManager struct {
+	mu             sync.Mutex
+	notifier       services.Notifier
+	escalateAfter  int           // 0 - без эскалации
+	repeatInterval time.Duration // 0 - без напоминаний
+	active         map[string]*activeAlert
+}
+
+// NewAlertManager создает группировщик оповещений поверх канала оповещений
+func NewAlertManager(notifier services.Notifier, escalateAfter int, repeatInterval time.Duration) *AlertManager {
+	return &AlertManager{
+		notifier:       notifier,
+		escalateAfter:  escalateAfter,
+		repeatInterval: repeatInterval,
+		active:         make(map[string]*activeAlert),
+	}
+}
+
+// Raise регистрирует очередной повтор условия с ключом notification.Key и отправляет оповещение,
+// если это первый повтор, порог эскалации или время напоминания. Оповещения без ключа отправляются всегда
+func (a *AlertManager) Raise(ctx context.Context, notification *entities.Notification) {
+	if notification.Key == "" {
+		a.send(ctx, notification)
+		return
+	}
+
+	now := notification.CreatedAt
+	a.mu.Lock()
+	alert, ok := a.active[notification.Key]
+	if !ok {
+		alert = &activeAlert{firstSeen: now}
+		a.active[notification.Key] = alert
+	}
+	alert.count++
+	alert.last = notification
+	notification.Occurrences = alert.count
+
+	var outgoing *entities.Notification
+	switch {
+	case !ok:
+		outgoing = notification
+	case a.escalateAfter > 0 && !alert.escalated && alert.count >= a.escalateAfter:
+		alert.escalated = true
+		outgoing = repeatedNotification(notification, entities.NotificationPriorityHigh, "Повторяется", alert.firstSeen)
+	case a.repeatInterval > 0 && now.Sub(alert.lastSent) >= a.repeatInterval:
+		outgoing = repeatedNotification(notification, notification.Priority, "Продолжается", alert.firstSeen)
+		if alert.escalated {
+			outgoing.Priority = entities.NotificationPriorityHigh
+		}
+	}
+	if outgoing != nil {
+		alert.lastSent = now
+	}
+	a.mu.Unlock()
+
+	if outgoing != nil {
+		a.send(ctx, outgoing)
+	}
+}
+
+// Resolve закрывает группу оповещений по ключу и сообщает, что условие устранено (если группа была открыта)
+func (a *AlertManager) Resolve(ctx context.Context, key string) {
+	a.mu.Lock()
+	alert, ok := a.active[key]
+	delete(a.active, key)
+	a.mu.Unlock()
+
+	if ok {
+		a.send(ctx, resolvedNotification(alert, time.Now()))
+	}
+}
+
+// ResolveMissing закрывает группы с префиксом ключа, условия которых нет среди current
+// (для проверок, которые каждый раз возвращают полный список текущих условий)
+func (a *AlertManager) ResolveMissing(ctx context.Context, prefix string, current map[string]bool) {
+	a.mu.Lock()
+	var resolved []*activeAlert
+	for key, alert := range a.active {
+		if strings.HasPrefix(key, prefix) && !current[key] {
+			resolved = append(resolved, alert)
+			delete(a.active, key)
+		}
+	}
+	a.mu.Unlock()
+
+	now := time.Now()
+	for _, alert := range resolved {
+		a.send(ctx, resolvedNotification(alert, now))
+	}
+}
+
+// Active возвращает неустраненные условия (первыми - самые давние)
+func (a *AlertManager) Active() []ActiveAlert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	alerts := make([]ActiveAlert, 0, len(a.active))
+	for key, alert := range a.active {
+		alerts = append(alerts, ActiveAlert{
+			Key:         key,
+			Title:       alert.last.Title,
+			Message:     alert.last.Message,
+			Priority:    alert.last.Priority.String(),
+			Occurrences: alert.count,
+			FirstSeen:   alert.firstSeen,
+			LastSeen:    alert.last.CreatedAt,
+			Escalated:   alert.escalated,
+		})
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if !alerts[i].FirstSeen.Equal(alerts[j].FirstSeen) {
+			return alerts[i].FirstSeen.Before(alerts[j].FirstSeen)
+		}
+		return alerts[i].Key < alerts[j].Key
+	})
+	return alerts
+}
+
+// send отправляет оповещение; ошибка канала только логируется
+func (a *AlertManager) send(ctx context.Context, notification *entities.Notification) {
+	if a.notifier == nil {
+		return
+	}
+	if err := a.notifier.Notify(ctx, notification); err != nil {
+		logger.LogWithTime("❌ Ошибка отправки оповещения «%s»: %v", notification.Title, err)
+	}
+}
+
+// repeatedNotification оповещение о повторе условия с количеством повторов и длительностью
+func repeatedNotification(last *entities.Notification, priority entities.NotificationPriority, prefix string, firstSeen time.Time) *entities.Notification {
+	notification := entities.NewNotification(priority, prefix+": "+last.Title,
+		fmt.Sprintf("%s (повторов: %d за %v)", last.Message, last.Occurrences, last.CreatedAt.Sub(firstSeen).Round(time.Second))).
+		WithKey(last.Key)
+	notification.CreatedAt = last.CreatedAt
+	notification.Occurrences = last.Occurrences
+	return notification
+}
+
+// resolvedNotification оповещение об устранении условия
+func resolvedNotification(alert *activeAlert, now time.Time) *entities.Notification {
+	notification := entities.NewNotification(entities.NotificationPriorityLow, "Устранено: "+alert.last.Title,
+		fmt.Sprintf("условие больше не наблюдается (повторов: %d за %v, последнее: %s)",
+			alert.count, now.Sub(alert.firstSeen).Round(time.Second), alert.last.Message)).
+		WithKey(alert.last.Key)
+	notification.Occurrences = alert.count
+	notification.Resolved = true
+	return notification
+}
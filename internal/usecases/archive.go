@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// HedgeArchiveUseCase переносит в архив хеджи, закрытые больше retention назад:
+// рабочая таблица и выборки веб-интерфейса остаются быстрыми, история сохраняется в архиве
+type HedgeArchiveUseCase struct {
+	archiveRepo repositories.HedgeArchiveRepository
+	retention   time.Duration
+}
+
+// NewHedgeArchiveUseCase создает use case архивации с хранением закрытых хеджей retentionDays дней
+func NewHedgeArchiveUseCase(archiveRepo repositories.HedgeArchiveRepository, retentionDays int) *HedgeArchiveUseCase {
+	return &HedgeArchiveUseCase{
+		archiveRepo: archiveRepo,
+		retention:   time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// Archive переносит в архив хеджи, закрытые раньше now - retention, и возвращает их количество
+func (u *HedgeArchiveUseCase) Archive(ctx context.Context, now time.Time) (int, error) {
+	closedBefore := now.Add(-u.retention)
+	moved, err := u.archiveRepo.ArchiveHedgedTrades(ctx, closedBefore)
+	if err != nil {
+		return 0, err
+	}
+	if moved > 0 {
+		logger.LogWithTime("🗄️ В архив перенесено хеджей: %d (закрыты до %s)", moved, closedBefore.Format("2006-01-02 15:04"))
+	}
+	return moved, nil
+}
@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// BalanceDivergence расхождение между количеством актива в открытых хеджах и балансом на бирже
+type BalanceDivergence struct {
+	Asset           string  // Актив (например, SOL)
+	Expected        float64 // Сумма количеств открытых хеджей
+	Actual          float64 // Общий баланс актива на бирже (включая заблокированный ордерами)
+	Hedges          int     // Количество открытых хеджей по активу
+	ShortagePercent float64 // Нехватка относительно ожидаемого количества, в процентах
+}
+
+// String возвращает описание расхождения для оповещения
+func (d *BalanceDivergence) String() string {
+	return fmt.Sprintf("%s: в %d хеджах %.6f, на бирже %.6f (нехватка %.2f%%)",
+		d.Asset, d.Hedges, d.Expected, d.Actual, d.ShortagePercent)
+}
+
+// BalanceDivergenceUseCase сверяет количество активов в открытых хеджах с балансами биржи.
+// Нехватка означает, что монеты проданы вручную или исполнение ордера пропущено, и хеджи
+// в БД больше не соответствуют позициям; излишек не считается расхождением - на аккаунте могут быть свои монеты
+type BalanceDivergenceUseCase struct {
+	hedgeRepo        repositories.HedgeRepository
+	exchangeService  services.ExchangeService
+	tolerancePercent float64
+}
+
+// NewBalanceDivergenceUseCase создает use case сверки балансов.
+// tolerancePercent - допустимая нехватка в процентах (покрывает комиссии и округление количества)
+func NewBalanceDivergenceUseCase(
+	hedgeRepo repositories.HedgeRepository,
+	exchangeService services.ExchangeService,
+	tolerancePercent float64,
+) *BalanceDivergenceUseCase {
+	return &BalanceDivergenceUseCase{
+		hedgeRepo:        hedgeRepo,
+		exchangeService:  exchangeService,
+		tolerancePercent: tolerancePercent,
+	}
+}
+
+// Check возвращает активы, баланс которых меньше суммы открытых хеджей больше чем на допуск (по имени актива)
+func (b *BalanceDivergenceUseCase) Check(ctx context.Context) ([]*BalanceDivergence, error) {
+	pendingStatus := entities.OrderStatusPending.String()
+	activeTrades, err := b.hedgeRepo.GetHedgedTrades(ctx, &pendingStatus)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения активных хеджей: %w", err)
+	}
+
+	expected := make(map[string]*BalanceDivergence)
+	for _, trade := range activeTrades {
+		asset := valueobjects.NewTradingPair(trade.Pair).BaseCurrency()
+		divergence, ok := expected[asset]
+		if !ok {
+			divergence = &BalanceDivergence{Asset: asset}
+			expected[asset] = divergence
+		}
+		divergence.Expected += trade.HedgeAmount
+		divergence.Hedges++
+	}
+
+	assets := make([]string, 0, len(expected))
+	for asset := range expected {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	var divergences []*BalanceDivergence
+	for _, asset := range assets {
+		divergence := expected[asset]
+		if divergence.Expected <= 0 {
+			continue
+		}
+
+		balance, err := b.exchangeService.GetBalance(ctx, asset)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения баланса %s: %w", asset, err)
+		}
+		divergence.Actual = balance.Total
+
+		shortage := divergence.Expected - divergence.Actual
+		if shortage <= 0 {
+			continue
+		}
+		divergence.ShortagePercent = shortage / divergence.Expected * 100
+		if divergence.ShortagePercent > b.tolerancePercent {
+			logger.LogWithTime("⚠️ Расхождение баланса %s", divergence.String())
+			divergences = append(divergences, divergence)
+		}
+	}
+
+	return divergences, nil
+}
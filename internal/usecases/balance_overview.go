@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"math"
+	"sort"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// AssetBalance баланс одной валюты кошелька биржи
+type AssetBalance struct {
+	Asset     string   `json:"asset"`
+	Available float64  `json:"available"` // Доступно для торговли
+	Total     float64  `json:"total"`     // Всего на кошельке
+	Locked    float64  `json:"locked"`    // Заблокировано в ордерах (всего минус доступно)
+	USDValue  *float64 `json:"usd_value"` // Оценка в USD (null - биржа не отдала)
+	InHedges  float64  `json:"in_hedges"` // Куплено открытыми хеджами (монеты хеджей хранятся на этом же кошельке)
+	Precision int32    `json:"precision"` // Знаков после запятой для отображения
+}
+
+// BalanceOverview балансы всех валют кошелька и запас на новые хеджи
+type BalanceOverview struct {
+	BaseCurrency   string         `json:"base_currency"`
+	BaseAvailable  float64        `json:"base_available"`  // Доступно в базовой валюте
+	PositionAmount float64        `json:"position_amount"` // Сумма одного хеджа (strategy.position_amount)
+	HedgeCapacity  int            `json:"hedge_capacity"`  // Сколько хеджей на position_amount помещается в доступный баланс
+	TotalUSD       *float64       `json:"total_usd"`       // Сумма оценок в USD (null - ни одна оценка не известна)
+	Assets         []AssetBalance `json:"assets"`          // Сначала самые крупные по оценке в USD
+}
+
+// BuildBalanceOverview строит обзор балансов: каждая валюта с доступной, общей суммой и оценкой в USD,
+// количество монет в открытых хеджах и сколько хеджей еще можно открыть на доступный баланс базовой валюты
+func BuildBalanceOverview(balances []*entities.Balance, hedges []*entities.HedgedTrade, baseCurrency string, positionAmount float64) *BalanceOverview {
+	overview := &BalanceOverview{
+		BaseCurrency:   baseCurrency,
+		PositionAmount: positionAmount,
+		Assets:         []AssetBalance{},
+	}
+
+	inHedges := make(map[string]float64)
+	for _, hedge := range hedges {
+		if isOpenHedge(hedge) && hedge.OrderStatus != entities.OrderStatusBuyPending {
+			inHedges[valueobjects.NewTradingPair(hedge.Pair).BaseCurrency()] += hedge.HedgeAmount
+		}
+	}
+
+	var totalUSD float64
+	var knownUSD bool
+	for _, balance := range balances {
+		asset := AssetBalance{
+			Asset:     balance.Asset,
+			Available: balance.Available,
+			Total:     balance.Total,
+			Locked:    math.Max(balance.Total-balance.Available, 0),
+			USDValue:  balance.USDValue,
+			InHedges:  inHedges[balance.Asset],
+			Precision: valueobjects.CurrencyPrecision(balance.Asset),
+		}
+		if balance.USDValue != nil {
+			totalUSD += *balance.USDValue
+			knownUSD = true
+		}
+		if balance.Asset == baseCurrency {
+			overview.BaseAvailable = balance.Available
+		}
+		overview.Assets = append(overview.Assets, asset)
+	}
+
+	if knownUSD {
+		overview.TotalUSD = &totalUSD
+	}
+	if positionAmount > 0 {
+		overview.HedgeCapacity = int(overview.BaseAvailable / positionAmount)
+	}
+
+	sort.SliceStable(overview.Assets, func(i, j int) bool {
+		a, b := overview.Assets[i], overview.Assets[j]
+		if (a.Asset == baseCurrency) != (b.Asset == baseCurrency) {
+			return a.Asset == baseCurrency
+		}
+		if usdA, usdB := usdOrZero(a.USDValue), usdOrZero(b.USDValue); usdA != usdB {
+			return usdA > usdB
+		}
+		return a.Asset < b.Asset
+	})
+	return overview
+}
+
+// usdOrZero возвращает оценку в USD или 0, если она неизвестна
+func usdOrZero(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
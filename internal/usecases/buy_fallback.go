@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// Значения strategy.buy_fallback
+const (
+	BuyFallbackNone   = "none"   // Неисполненный остаток лимитной покупки отменяется
+	BuyFallbackMarket = "market" // Неисполненный остаток докупается рыночным ордером
+)
+
+// completeBuyAtMarket докупает по рынку остаток отмененной лимитной покупки и возвращает
+// объединенный статус: суммарное количество и средневзвешенную цену обеих частей.
+// При ошибке возвращает статус лимитной части без изменений вместе с ошибкой.
+// rules - лимиты и шаг количества инструмента, referencePrice - цена для оценки стоимости остатка
+func (h *HedgeStrategyUseCase) completeBuyAtMarket(
+	ctx context.Context,
+	pair, symbol string,
+	requestedQty float64,
+	limitStatus *services.OrderStatusInfo,
+	rules valueobjects.InstrumentRules,
+	referencePrice float64,
+) (*services.OrderStatusInfo, error) {
+	var limitFilled float64
+	if limitStatus != nil {
+		limitFilled = limitStatus.FilledQty
+	}
+
+	unfilled := valueobjects.NewDecimalFromFloat(requestedQty).Sub(valueobjects.NewDecimalFromFloat(limitFilled)).Float64()
+	remaining := valueobjects.NewQuantity(unfilled, rules, h.rounding.quantity.Mode())
+	if remaining.Decimal().Sign() <= 0 {
+		return limitStatus, nil
+	}
+	notional := remaining.Notional(valueobjects.NewPrice(referencePrice, rules, valueobjects.RoundHalfUp))
+	if err := remaining.Validate(); err != nil {
+		logger.LogWithTime("💡 Остаток вне лимитов биржи (%v) - рыночная докупка не выполняется", err)
+		return limitStatus, nil
+	}
+	if err := rules.ValidateAmount(notional); err != nil {
+		logger.LogWithTime("💡 Остаток %s вне лимитов биржи (%v) - рыночная докупка не выполняется", remaining, err)
+		return limitStatus, nil
+	}
+
+	logger.LogWithTime("⚡ Лимитная покупка исполнилась не полностью - докупаем остаток %s по рынку", remaining)
+
+	marketOrder := entities.NewMarketOrder(symbol, entities.OrderSideBuy, remaining.Float64()).WithInstrumentSteps(0, rules.StepSize)
+	marketResult, err := h.exchangeService.PlaceOrder(ctx, marketOrder)
+	if err != nil {
+		return limitStatus, fmt.Errorf("ошибка размещения рыночной покупки: %w", err)
+	}
+	if !marketResult.Success {
+		return limitStatus, fmt.Errorf("рыночная покупка отклонена: %s", marketResult.Error)
+	}
+
+	marketStatus, err := h.fillWaiter.WaitForFill(ctx, marketResult.OrderID, symbol)
+	if marketStatus == nil || marketStatus.FilledQty <= 0 {
+		if err == nil {
+			err = fmt.Errorf("рыночная покупка %s не исполнена", marketResult.OrderID)
+		}
+		return limitStatus, err
+	}
+	logger.LogWithTime("✅ Рыночная покупка %s: исполнено %.6f", marketResult.OrderID, marketStatus.FilledQty)
+	h.events.RecordPlaced(ctx, pair, marketOrder, marketResult)
+	h.events.RecordStatus(ctx, pair, entities.OrderStatusPending, marketStatus)
+
+	return mergeBuyFills(limitStatus, marketStatus), nil
+}
+
+// mergeBuyFills объединяет исполнение лимитной и рыночной частей покупки
+func mergeBuyFills(limitStatus, marketStatus *services.OrderStatusInfo) *services.OrderStatusInfo {
+	if limitStatus == nil || limitStatus.FilledQty <= 0 {
+		return marketStatus
+	}
+
+	merged := *limitStatus
+	merged.Status = entities.OrderStatusFilled
+	merged.FilledQty = limitStatus.FilledQty + marketStatus.FilledQty
+	merged.RemainingQty = 0
+	merged.Fee = limitStatus.Fee + marketStatus.Fee
+	if merged.FeeCurrency == "" {
+		merged.FeeCurrency = marketStatus.FeeCurrency
+	}
+	if marketStatus.FilledTime != nil {
+		merged.FilledTime = marketStatus.FilledTime
+	}
+
+	if limitStatus.FilledPrice != nil && marketStatus.FilledPrice != nil {
+		average := (limitStatus.FilledQty**limitStatus.FilledPrice + marketStatus.FilledQty**marketStatus.FilledPrice) / merged.FilledQty
+		merged.FilledPrice = &average
+	} else {
+		merged.FilledPrice = nil
+	}
+
+	return &merged
+}
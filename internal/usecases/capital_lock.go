@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"sort"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// CapitalLock капитал, занятый одним открытым хеджем
+type CapitalLock struct {
+	HedgeID          int64     `json:"hedge_id"`
+	FreqtradeTradeID int       `json:"freqtrade_trade_id"`
+	Pair             string    `json:"pair"`
+	Status           string    `json:"status"`
+	Notional         float64   `json:"notional"` // Стоимость входа в котируемой валюте пары
+	QuoteCurrency    string    `json:"quote_currency"`
+	OpenedAt         time.Time `json:"opened_at"`
+	AgeHours         float64   `json:"age_hours"`
+	// BankrollPercent доля капитала в валюте баланса (nil - котируемая валюта пары другая или капитал неизвестен)
+	BankrollPercent *float64 `json:"bankroll_percent"`
+}
+
+// CapitalLockReport распределение капитала валюты баланса между открытыми хеджами
+type CapitalLockReport struct {
+	Currency      string        `json:"currency"`
+	Bankroll      float64       `json:"bankroll"`       // Баланс валюты на бирже и стоимость входа купленных хеджей
+	Locked        float64       `json:"locked"`         // Занято хеджами в валюте баланса
+	LockedPercent float64       `json:"locked_percent"` // Доля занятого капитала, %
+	Free          float64       `json:"free"`           // Не занято хеджами
+	Hedges        []CapitalLock `json:"hedges"`         // Открытые хеджи, самые крупные первыми
+}
+
+// BuildCapitalLockReport строит распределение капитала по открытым хеджам. balance - баланс валюты
+// currency на бирже (nil - неизвестен): монеты купленных хеджей в него не входят, поэтому их стоимость
+// входа добавляется к капиталу, а средства покупок, ожидающих исполнения, уже учтены в балансе как заблокированные
+func BuildCapitalLockReport(hedges []*entities.HedgedTrade, balance *entities.Balance, currency string, now time.Time) *CapitalLockReport {
+	report := &CapitalLockReport{Currency: currency, Hedges: []CapitalLock{}}
+
+	var held float64
+	for _, hedge := range hedges {
+		if !isOpenHedge(hedge) {
+			continue
+		}
+		quote := valueobjects.NewTradingPair(hedge.Pair).QuoteCurrency()
+		lock := CapitalLock{
+			HedgeID:          hedge.HedgeID,
+			FreqtradeTradeID: hedge.FreqtradeTradeID,
+			Pair:             hedge.Pair,
+			Status:           hedge.OrderStatus.String(),
+			Notional:         hedge.HedgeAmount * hedge.HedgeOpenPrice,
+			QuoteCurrency:    quote,
+			OpenedAt:         hedge.HedgeTime,
+			AgeHours:         now.Sub(hedge.HedgeTime).Hours(),
+		}
+		if quote == currency {
+			report.Locked += lock.Notional
+			if hedge.OrderStatus != entities.OrderStatusBuyPending {
+				held += lock.Notional
+			}
+		}
+		report.Hedges = append(report.Hedges, lock)
+	}
+
+	if balance != nil {
+		report.Bankroll = balance.Total + held
+	}
+	if report.Bankroll > 0 {
+		report.LockedPercent = report.Locked / report.Bankroll * 100
+		report.Free = report.Bankroll - report.Locked
+		if report.Free < 0 {
+			report.Free = 0
+		}
+		for i := range report.Hedges {
+			if report.Hedges[i].QuoteCurrency == currency {
+				percent := report.Hedges[i].Notional / report.Bankroll * 100
+				report.Hedges[i].BankrollPercent = &percent
+			}
+		}
+	}
+
+	sort.SliceStable(report.Hedges, func(i, j int) bool {
+		return report.Hedges[i].Notional > report.Hedges[j].Notional
+	})
+	return report
+}
@@ -0,0 +1,247 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// closePollInterval интервал опроса статуса лимитной продажи при закрытии
+const closePollInterval = time.Second
+
+// CloseExecutionConfig защита цены закрытия хеджа по рынку (стоп-лосс, закрытие по времени и вручную)
+type CloseExecutionConfig struct {
+	MaxSlippagePercent float64       // Насколько цена лимитной продажи может быть ниже цены отметки, % (0 - рыночный ордер)
+	RepriceInterval    time.Duration // Сколько ждать исполнения лимитной продажи перед перевыставлением
+	RepriceAttempts    int           // Лимитных попыток до продажи остатка по рынку
+}
+
+// CloseFill итог продажи позиции при закрытии хеджа
+type CloseFill struct {
+	OrderID       string  // Последний ордер продажи
+	FilledQty     float64 // Продано всего
+	Price         float64 // Средняя цена исполнения
+	IntendedPrice float64 // Цена отметки в момент решения о закрытии
+	Fee           float64 // Комиссия продажи в котируемой валюте
+	MarketQty     float64 // Продано рыночным ордером после лимитных попыток
+}
+
+// SlippagePercent возвращает отклонение средней цены продажи от цены отметки в процентах:
+// положительное значение - продано дешевле, отрицательное - дороже
+func (f *CloseFill) SlippagePercent() float64 {
+	if f.IntendedPrice <= 0 {
+		return 0
+	}
+	return (f.IntendedPrice - f.Price) / f.IntendedPrice * 100
+}
+
+// add учитывает исполнение ордера продажи; price - оценка цены, если биржа не сообщила цену исполнения
+func (f *CloseFill) add(status *services.OrderStatusInfo, pair *valueobjects.TradingPair, price float64) {
+	if status == nil || status.FilledQty <= 0 {
+		return
+	}
+	if status.FilledPrice != nil && *status.FilledPrice > 0 {
+		price = *status.FilledPrice
+	}
+	total := f.FilledQty + status.FilledQty
+	f.Price = (f.FilledQty*f.Price + status.FilledQty*price) / total
+	f.FilledQty = total
+	f.Fee += feeInQuote(status, pair, price)
+}
+
+// CloseExecutor продает позицию хеджа при закрытии по рынку. С защитой цены вместо рыночного ордера
+// выставляется лимитная продажа по лучшей цене покупки, но не ниже цены отметки минус MaxSlippagePercent;
+// неисполненный остаток перевыставляется по свежей цене, а после RepriceAttempts попыток продается по рынку,
+// чтобы позиция не осталась без выхода
+type CloseExecutor struct {
+	exchangeService services.ExchangeService
+	config          *CloseExecutionConfig // nil - рыночный ордер
+	waiter          *OrderFillWaiter
+}
+
+// NewCloseExecutor создает исполнитель закрытия; config nil или с нулевым MaxSlippagePercent - продажа по рынку
+func NewCloseExecutor(exchangeService services.ExchangeService, config *CloseExecutionConfig) *CloseExecutor {
+	executor := &CloseExecutor{exchangeService: exchangeService}
+	if config != nil && config.MaxSlippagePercent > 0 {
+		executor.config = config
+		executor.waiter = NewOrderFillWaiter(exchangeService, config.RepriceInterval, closePollInterval)
+	}
+	return executor
+}
+
+// Sell продает количество пары; intendedPrice - цена отметки, по которой принято решение о закрытии.
+// events - история событий ордеров (nil - не сохраняется). Ошибка возвращается, только если не продано ничего;
+// при частичной продаже возвращается итог проданной части вместе с ошибкой
+func (c *CloseExecutor) Sell(ctx context.Context, pairName string, quantity, intendedPrice float64, events *OrderEventRecorder) (*CloseFill, error) {
+	pair := valueobjects.NewTradingPair(pairName)
+	symbol := pair.ToBybitFormat()
+	fill := &CloseFill{IntendedPrice: intendedPrice}
+
+	remaining := quantity
+	if c.config != nil && intendedPrice > 0 {
+		var err error
+		if remaining, err = c.sellWithLimits(ctx, pair, quantity, fill, events); err != nil {
+			if fill.FilledQty > 0 {
+				return fill, err
+			}
+			return nil, err
+		}
+	}
+	if remaining <= 0 {
+		return fill, nil
+	}
+
+	switch {
+	case fill.FilledQty > 0:
+		logger.LogWithTime("⚡ Лимитная продажа %s исполнилась не полностью - продаем остаток %.8f по рынку", symbol, remaining)
+	case c.config != nil:
+		logger.LogWithTime("⚡ Лимитная продажа %s не исполнилась - продаем %.8f по рынку", symbol, remaining)
+	}
+	sellOrder := entities.NewMarketOrder(symbol, entities.OrderSideSell, remaining)
+	sellResult, err := c.exchangeService.PlaceOrder(ctx, sellOrder)
+	if err == nil && !sellResult.Success {
+		err = fmt.Errorf("продажа по рынку отклонена: %s", sellResult.Error)
+	}
+	if err != nil {
+		if fill.FilledQty > 0 {
+			return fill, fmt.Errorf("остаток %.8f не продан: %w", remaining, err)
+		}
+		return nil, err
+	}
+	events.RecordPlaced(ctx, pairName, sellOrder, sellResult)
+	fill.OrderID = sellResult.OrderID
+
+	// Цена рыночного исполнения неизвестна до ответа биржи - оцениваем ценой отметки
+	status, err := c.exchangeService.GetOrderStatus(ctx, sellResult.OrderID, symbol)
+	if err != nil {
+		status = &services.OrderStatusInfo{OrderID: sellResult.OrderID, FilledQty: remaining}
+	} else {
+		events.RecordStatus(ctx, pairName, entities.OrderStatusPending, status)
+	}
+	if status.FilledQty <= 0 {
+		status.FilledQty = remaining
+	}
+	fill.MarketQty = status.FilledQty
+	fill.add(status, pair, intendedPrice)
+
+	return fill, nil
+}
+
+// sellWithLimits продает лимитными ордерами не ниже допустимой цены и возвращает непроданный остаток.
+// Ошибка - лимитный ордер не удалось отменить: продажа остатка по рынку продала бы позицию дважды
+func (c *CloseExecutor) sellWithLimits(
+	ctx context.Context,
+	pair *valueobjects.TradingPair,
+	quantity float64,
+	fill *CloseFill,
+	events *OrderEventRecorder,
+) (float64, error) {
+	symbol := pair.ToBybitFormat()
+	var rules valueobjects.InstrumentRules
+	if instrument, err := c.exchangeService.GetInstrumentInfo(ctx, symbol); err == nil {
+		rules = instrument.Rules()
+	}
+	floor := valueobjects.NewPrice(fill.IntendedPrice*(1-c.config.MaxSlippagePercent/100), rules, valueobjects.RoundCeil).Float64()
+
+	remaining := quantity
+	for attempt := 1; attempt <= c.config.RepriceAttempts; attempt++ {
+		qty := valueobjects.NewQuantity(remaining, rules, valueobjects.RoundFloor)
+		if qty.Decimal().Sign() <= 0 || qty.Validate() != nil {
+			break // Остаток меньше шага или минимального количества - продается по рынку
+		}
+
+		price := valueobjects.NewPrice(c.limitPrice(ctx, symbol, floor), rules, valueobjects.RoundCeil)
+		logger.LogWithTime("🎯 Попытка %d/%d: лимитная продажа %s %s по %s (цена отметки %s, не ниже %s)",
+			attempt, c.config.RepriceAttempts, qty, symbol, price, pair.FormatPrice(fill.IntendedPrice), pair.FormatPrice(floor))
+
+		sellOrder := entities.NewLimitOrder(symbol, entities.OrderSideSell, qty.Float64(), price.Float64()).
+			WithInstrumentSteps(rules.TickSize, rules.StepSize)
+		sellResult, err := c.exchangeService.PlaceOrder(ctx, sellOrder)
+		if err == nil && !sellResult.Success {
+			err = fmt.Errorf("%s", sellResult.Error)
+		}
+		if err != nil {
+			logger.LogWithTime("⚠️ Лимитная продажа %s не размещена: %v", symbol, err)
+			break
+		}
+		events.RecordPlaced(ctx, pair.String(), sellOrder, sellResult)
+		fill.OrderID = sellResult.OrderID
+
+		status, _ := c.waiter.WaitForFill(ctx, sellResult.OrderID, symbol)
+		if status == nil || status.Status != entities.OrderStatusFilled {
+			status = c.cancelLimit(ctx, pair, sellResult.OrderID, status, events)
+		}
+		if status == nil || !status.Status.IsCompleted() {
+			return remaining, fmt.Errorf("лимитная продажа %s не отменена - остаток не продается по рынку", sellResult.OrderID)
+		}
+
+		fill.add(status, pair, price.Float64())
+		remaining -= status.FilledQty
+		if status.Status == entities.OrderStatusFilled {
+			remaining = 0
+		}
+		if remaining <= 0 {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return remaining, err
+		}
+	}
+	return remaining, nil
+}
+
+// limitPrice возвращает цену лимитной продажи: лучшая цена покупки стакана (без стакана - последняя цена),
+// но не ниже floor
+func (c *CloseExecutor) limitPrice(ctx context.Context, symbol string, floor float64) float64 {
+	var price float64
+	if bookService, ok := c.exchangeService.(services.BookTickerExchangeService); ok {
+		if book, err := bookService.GetBookTicker(ctx, symbol); err == nil {
+			price = book.Bid
+		}
+	}
+	if price <= 0 {
+		if ticker, err := c.exchangeService.GetTickerPrice(ctx, symbol); err == nil {
+			price = ticker
+		}
+	}
+	if price < floor {
+		return floor
+	}
+	return price
+}
+
+// cancelLimit отменяет неисполненную лимитную продажу и возвращает ее итоговый статус:
+// ордер мог исполниться между последней проверкой и отменой
+func (c *CloseExecutor) cancelLimit(
+	ctx context.Context,
+	pair *valueobjects.TradingPair,
+	orderID string,
+	lastStatus *services.OrderStatusInfo,
+	events *OrderEventRecorder,
+) *services.OrderStatusInfo {
+	symbol := pair.ToBybitFormat()
+	if result, err := c.exchangeService.CancelOrder(ctx, orderID, symbol); err != nil {
+		logger.LogWithTime("⚠️ Не удалось отменить лимитную продажу %s: %v", orderID, err)
+	} else if result.Success {
+		events.Record(ctx, orderID, pair.String(), entities.OrderStatusPending, entities.OrderStatusCancelled, 0, 0, result)
+	}
+
+	status, err := c.exchangeService.GetOrderStatus(ctx, orderID, symbol)
+	if err != nil {
+		return lastStatus
+	}
+	events.RecordStatus(ctx, pair.String(), entities.OrderStatusPending, status)
+	return status
+}
+
+// logCloseFill выводит цену закрытия относительно цены отметки
+func logCloseFill(pair *valueobjects.TradingPair, fill *CloseFill) {
+	logger.LogWithTime("🎯 Закрытие %s: продано %.8f по %s при цене отметки %s (проскальзывание %.3f%%, по рынку %.8f)",
+		pair.String(), fill.FilledQty, pair.FormatPrice(fill.Price), pair.FormatPrice(fill.IntendedPrice),
+		fill.SlippagePercent(), fill.MarketQty)
+}
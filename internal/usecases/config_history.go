@@ -0,0 +1,178 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// configDiffContext количество неизмененных строк вокруг изменения в diff
+const configDiffContext = 2
+
+// ConfigHistoryUseCase ведет историю примененных конфигураций: каждая отличающаяся версия сохраняется
+// с автором и diff относительно предыдущей, к любой версии можно откатиться
+type ConfigHistoryUseCase struct {
+	repo repositories.ConfigHistoryRepository
+}
+
+// NewConfigHistoryUseCase создает новый use case истории конфигурации
+func NewConfigHistoryUseCase(repo repositories.ConfigHistoryRepository) *ConfigHistoryUseCase {
+	return &ConfigHistoryUseCase{repo: repo}
+}
+
+// Record сохраняет версию конфигурации, если ее содержимое отличается от последней сохраненной.
+// Возвращает сохраненную версию или nil, если конфигурация не изменилась
+func (u *ConfigHistoryUseCase) Record(ctx context.Context, version *entities.ConfigVersion) (*entities.ConfigVersion, error) {
+	latest, err := u.repo.GetLatestConfigVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Content == version.Content {
+		return nil, nil
+	}
+
+	if latest != nil {
+		version.Diff = diffLines(latest.Content, version.Content)
+	}
+	if version.CreatedAt.IsZero() {
+		version.CreatedAt = time.Now()
+	}
+
+	if err := u.repo.SaveConfigVersion(ctx, version); err != nil {
+		return nil, err
+	}
+
+	logger.LogWithTime("🗂️ Сохранена версия конфигурации #%d (%s, %s)", version.ID, version.Source, version.Author)
+	return version, nil
+}
+
+// Versions возвращает историю конфигурации (новые первыми)
+func (u *ConfigHistoryUseCase) Versions(ctx context.Context) ([]*entities.ConfigVersion, error) {
+	return u.repo.GetConfigVersions(ctx)
+}
+
+// Version возвращает версию конфигурации по ID
+func (u *ConfigHistoryUseCase) Version(ctx context.Context, id int) (*entities.ConfigVersion, error) {
+	version, err := u.repo.GetConfigVersion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		return nil, fmt.Errorf("версия конфигурации %d не найдена", id)
+	}
+	return version, nil
+}
+
+// Rollback откатывает конфигурацию к версии id. apply применяет содержимое версии (записывает файл
+// конфигурации) и возвращает снимок примененной конфигурации, который сохраняется как новая версия
+func (u *ConfigHistoryUseCase) Rollback(ctx context.Context, id int, author string, apply func(content string) (string, error)) (*entities.ConfigVersion, error) {
+	target, err := u.Version(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := apply(target.Content)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка применения версии конфигурации %d: %w", id, err)
+	}
+
+	logger.LogWithTime("⏪ Конфигурация откачена к версии #%d (%s)", id, author)
+	return u.Record(ctx, &entities.ConfigVersion{
+		Author:         author,
+		Source:         entities.ConfigSourceRollback,
+		Content:        applied,
+		RolledBackFrom: id,
+	})
+}
+
+// DefaultConfigAuthor возвращает автора конфигурации, примененной при запуске: пользователь@хост
+func DefaultConfigAuthor() string {
+	name := "unknown"
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		name = current.Username
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return name
+	}
+	return name + "@" + hostname
+}
+
+// diffLines строит построчный diff в стиле git: удаленные строки с "-", добавленные с "+",
+// вокруг изменений configDiffContext строк контекста, группы изменений разделены заголовками @@
+func diffLines(previous, current string) string {
+	oldLines := strings.Split(strings.TrimRight(previous, "\n"), "\n")
+	newLines := strings.Split(strings.TrimRight(current, "\n"), "\n")
+
+	// Длины наибольших общих подпоследовательностей суффиксов
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	type diffLine struct {
+		op         byte // ' ', '-', '+'
+		text       string
+		oldN, newN int // Номера строк (с 1) в старой и новой версии
+	}
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			lines = append(lines, diffLine{' ', oldLines[i], i + 1, j + 1})
+			i++
+			j++
+		case i < len(oldLines) && (j == len(newLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', oldLines[i], i + 1, j + 1})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', newLines[j], i + 1, j + 1})
+			j++
+		}
+	}
+
+	// Показываем изменения и configDiffContext строк вокруг них
+	visible := make([]bool, len(lines))
+	for k, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+		for c := k - configDiffContext; c <= k+configDiffContext; c++ {
+			if c >= 0 && c < len(lines) {
+				visible[c] = true
+			}
+		}
+	}
+
+	var b strings.Builder
+	for k, line := range lines {
+		if !visible[k] {
+			continue
+		}
+		if k == 0 || !visible[k-1] {
+			fmt.Fprintf(&b, "@@ -%d +%d @@\n", line.oldN, line.newN)
+		}
+		fmt.Fprintf(&b, "%c %s\n", line.op, line.text)
+	}
+
+	return b.String()
+}
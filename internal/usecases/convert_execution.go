@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// Способы исполнения хеджирующей покупки
+const (
+	ExecutionMethodSpot    = "spot"    // Лимитный ордер в стакане
+	ExecutionMethodConvert = "convert" // Конвертация по твердой котировке биржи (strategy.convert_pairs)
+)
+
+// executionMethod возвращает способ покупки пары: конвертация для пар из ConvertPairs, иначе спот
+func (h *HedgeStrategyUseCase) executionMethod(pair string) string {
+	if !h.flags.Enabled(entities.FlagConvertExecution) {
+		return ExecutionMethodSpot
+	}
+	for _, convertPair := range h.config.ConvertPairs {
+		if strings.EqualFold(strings.TrimSpace(convertPair), pair) {
+			return ExecutionMethodConvert
+		}
+	}
+	return ExecutionMethodSpot
+}
+
+// hedgeViaConvert покупает актив конвертацией по твердой котировке вместо лимитного ордера.
+// Цена котировки записывается как цена входа хеджа, тейк-профит выставляется обычным лимитным ордером.
+// Обмен нельзя найти по клиентскому ID, поэтому при неподтвержденном исполнении намерение закрывается
+// с предупреждением - купленный актив нужно сверить по истории конвертаций вручную
+func (h *HedgeStrategyUseCase) hedgeViaConvert(
+	ctx context.Context,
+	trade *entities.Trade,
+	tranche int,
+	positionAmount float64,
+	instrument *services.InstrumentInfo,
+) error {
+	pair := valueobjects.NewTradingPair(trade.Pair)
+
+	convert, ok := h.exchangeService.(services.ConvertExchangeService)
+	if !ok {
+		return errors.NewExchangeError(fmt.Sprintf("пара %s: биржа не поддерживает конвертацию", pair.String()))
+	}
+
+	logger.LogWithTime("🔄 Запрос котировки конвертации %.2f %s → %s", positionAmount, h.config.BaseCurrency, pair.BaseCurrency())
+	quote, err := convert.RequestConvertQuote(ctx, h.config.BaseCurrency, pair.BaseCurrency(), positionAmount)
+	if err != nil {
+		return fmt.Errorf("ошибка запроса котировки конвертации %s: %w", pair.String(), err)
+	}
+	quotePrice := quote.Price()
+
+	// Котировка включает спред биржи: сверяем ее с ценой Freqtrade так же, как тикер
+	if h.config.MaxPriceDeviationPercent > 0 && trade.CurrentRate > 0 {
+		deviationPercent := math.Abs(quotePrice-trade.CurrentRate) / trade.CurrentRate * 100
+		if deviationPercent > h.config.MaxPriceDeviationPercent {
+			logger.LogWithTime("⚠️ Цена котировки %s (%.8f) отличается от цены Freqtrade (%.8f) на %.2f%%",
+				pair.String(), quotePrice, trade.CurrentRate, deviationPercent)
+			return errors.NewPriceDeviationError(trade.Pair, trade.CurrentRate, quotePrice,
+				deviationPercent, h.config.MaxPriceDeviationPercent)
+		}
+	}
+
+	// Купленное количество должно проходить лимиты инструмента, иначе тейк-профит не выставить
+	sellable := valueobjects.NewQuantity(quote.ToAmount, instrument.Rules(), valueobjects.RoundFloor)
+	if err := sellable.Validate(); err != nil {
+		logger.LogWithTime("💡 Пропускаем пару %s - количество по котировке вне лимитов инструмента", pair.String())
+		return errors.NewExchangeError(fmt.Sprintf("пара %s: %v", pair.String(), err))
+	}
+
+	logger.LogWithTime("🧾 Котировка %s: %.6f %s за %.2f %s (цена %.8f, действует до %s)",
+		quote.QuoteID, quote.ToAmount, pair.BaseCurrency(), quote.FromAmount, h.config.BaseCurrency,
+		quotePrice, quote.ExpiresAt.Format("15:04:05"))
+
+	intent, err := h.createHedgeIntent(ctx, trade, tranche, quote.ToAmount, quotePrice)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения намерения хеджирования: %w", err)
+	}
+
+	result, err := convert.ExecuteConvert(ctx, quote.QuoteID)
+	if err != nil {
+		logger.LogWithTime("⚠️ Конвертация по котировке %s не подтверждена - проверьте историю конвертаций Bybit", quote.QuoteID)
+		h.advanceHedgeIntent(ctx, intent, entities.HedgeStateClosed)
+		return fmt.Errorf("ошибка конвертации %s → %s: %w", h.config.BaseCurrency, pair.BaseCurrency(), err)
+	}
+
+	buyOrderID := result.ExchangeID
+	if buyOrderID == "" {
+		buyOrderID = result.QuoteID
+	}
+	filledQty := result.ToAmount
+	if filledQty <= 0 {
+		filledQty = quote.ToAmount
+	}
+	logger.LogWithTime("✅ Конвертация %s исполнена: получено %.6f %s", buyOrderID, filledQty, pair.BaseCurrency())
+
+	// Обмен уже завершен: статус ордера по нему не запросить, поэтому намерение сразу переводится в BUY_FILLED
+	intent.BuyOrderID = buyOrderID
+	intent.FilledQty = filledQty
+	h.advanceHedgeIntent(ctx, intent, entities.HedgeStateBuyPlaced)
+	h.advanceHedgeIntent(ctx, intent, entities.HedgeStateBuyFilled)
+
+	now := time.Now()
+	buyOrderStatus := &services.OrderStatusInfo{
+		OrderID:     buyOrderID,
+		Status:      entities.OrderStatusFilled,
+		FilledPrice: &quotePrice,
+		FilledTime:  &now,
+		FilledQty:   filledQty,
+	}
+	h.events.RecordStatus(ctx, trade.Pair, entities.OrderStatusPending, buyOrderStatus)
+
+	hedgedTrade, events, err := h.placeTakeProfit(ctx, trade, buyOrderID, quote.ToAmount, buyOrderStatus, instrument)
+	if err != nil {
+		// Покупка исполнена, но не защищена - тейк-профит будет выставлен восстановлением
+		return err
+	}
+	hedgedTrade.HedgeOpenPrice = quotePrice
+	hedgedTrade.BuyPlacedAt = &now
+	h.thresholds.Claim(hedgedTrade)
+	hedgedTrade.FeatureFlags = withFeatureFlag(hedgedTrade.FeatureFlags, "execution", ExecutionMethodConvert)
+
+	intent.TakeProfitOrderID = hedgedTrade.BybitOrderID
+	h.advanceHedgeIntent(ctx, intent, entities.HedgeStateTPPlaced)
+
+	if err := h.saveHedge(ctx, "", hedgedTrade, events); err != nil {
+		return fmt.Errorf("ошибка сохранения хеджированной сделки: %w", err)
+	}
+
+	h.scheduleEarlyChecks(ctx, hedgedTrade)
+
+	return nil
+}
+
+// withFeatureFlag добавляет флаг к строке флагов "ключ=значение,...", сохраняя сортировку
+func withFeatureFlag(flags, key, value string) string {
+	pairs := []string{key + "=" + value}
+	if flags != "" {
+		pairs = append(pairs, strings.Split(flags, ",")...)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+)
+
+// exchangeAuthCodes коды ошибок Bybit, означающие, что биржа не принимает ключ API: повтор запроса
+// не поможет, нужен оператор (ключ удален или просрочен, нет прав, запрос с неразрешенного IP)
+var exchangeAuthCodes = []string{"10003", "10004", "10005", "10007", "10009", "10010", "33004"}
+
+// IsExchangeAuthError проверяет, что ошибка цикла вызвана отказом биржи в аутентификации.
+// Клиент биржи возвращает ошибки текстом "ошибка Bybit: <сообщение> (код: <код>)", поэтому код ищется в тексте
+func IsExchangeAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+	text := err.Error()
+	for _, code := range exchangeAuthCodes {
+		if strings.Contains(text, "(код: "+code+")") {
+			return true
+		}
+	}
+	return strings.Contains(text, "401 Unauthorized")
+}
+
+// FindStuckHedges возвращает намерения, застрявшие в BUY_FILLED дольше threshold: позиция куплена,
+// но не защищена тейк-профитом (восстановление не может его выставить)
+func FindStuckHedges(ctx context.Context, intentRepo repositories.HedgeIntentRepository, threshold time.Duration, now time.Time) ([]*entities.HedgeIntent, error) {
+	intents, err := intentRepo.GetInFlightHedgeIntents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения незавершенных хеджей: %w", err)
+	}
+
+	var stuck []*entities.HedgeIntent
+	for _, intent := range intents {
+		if intent.State == entities.HedgeStateBuyFilled && now.Sub(intent.UpdatedAt) >= threshold {
+			stuck = append(stuck, intent)
+		}
+	}
+	return stuck, nil
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// CustomMetricsUseCase вычисляет пользовательские метрики оператора (read-only SQL запросы из metrics.custom).
+// Значения кэшируются на interval: частый опрос /metrics и дашборда не нагружает базу
+type CustomMetricsUseCase struct {
+	repo     repositories.CustomMetricRepository
+	metrics  []entities.CustomMetric
+	interval time.Duration
+	timeout  time.Duration
+
+	mu        sync.Mutex
+	values    []*entities.CustomMetricValue
+	updatedAt time.Time
+}
+
+// NewCustomMetricsUseCase создает use case пользовательских метрик; запросы должны пройти entities.CustomMetric.Validate
+func NewCustomMetricsUseCase(repo repositories.CustomMetricRepository, metrics []entities.CustomMetric, interval, timeout time.Duration) *CustomMetricsUseCase {
+	return &CustomMetricsUseCase{
+		repo:     repo,
+		metrics:  metrics,
+		interval: interval,
+		timeout:  timeout,
+	}
+}
+
+// Metrics возвращает настроенные метрики
+func (u *CustomMetricsUseCase) Metrics() []entities.CustomMetric {
+	return u.metrics
+}
+
+// Values возвращает значения метрик в порядке конфигурации, выполняя запросы заново,
+// если кэш старше interval. Ошибка отдельного запроса не мешает остальным метрикам
+func (u *CustomMetricsUseCase) Values(ctx context.Context) []*entities.CustomMetricValue {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.values != nil && time.Since(u.updatedAt) < u.interval {
+		return u.values
+	}
+
+	values := make([]*entities.CustomMetricValue, 0, len(u.metrics))
+	for _, metric := range u.metrics {
+		value := &entities.CustomMetricValue{Metric: metric}
+		value.Value, value.Err = u.repo.QueryScalar(ctx, metric.Query, u.timeout)
+		value.UpdatedAt = time.Now()
+		if value.Err != nil {
+			logger.LogWithTime("⚠️ Ошибка вычисления метрики %s: %v", metric.Name, value.Err)
+		}
+		values = append(values, value)
+	}
+
+	u.values = values
+	u.updatedAt = time.Now()
+	return values
+}
@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/pkg/cron"
+)
+
+// CycleSchedule расписание циклов стратегии: каждые interval или по расписанию cron, и тихие часы,
+// в которые циклы продолжают проверять статусы ордеров, но новые хеджи не открываются
+// (например, в часы низкой ликвидности)
+type CycleSchedule struct {
+	interval time.Duration
+	cron     *cron.Schedule // nil - циклы каждые interval
+	quiet    []cron.Window
+	location *time.Location // Часовой пояс расписания и тихих часов
+}
+
+// NewCycleSchedule создает расписание циклов каждые interval
+func NewCycleSchedule(interval time.Duration) *CycleSchedule {
+	return &CycleSchedule{
+		interval: interval,
+		location: time.UTC,
+	}
+}
+
+// WithCron запускает циклы по расписанию cron вместо интервала (nil - остается интервал)
+func (c *CycleSchedule) WithCron(schedule *cron.Schedule) *CycleSchedule {
+	c.cron = schedule
+	return c
+}
+
+// WithQuietHours задает тихие часы без новых хеджей
+func (c *CycleSchedule) WithQuietHours(windows []cron.Window) *CycleSchedule {
+	c.quiet = windows
+	return c
+}
+
+// In задает часовой пояс расписания cron и тихих часов (по умолчанию UTC)
+func (c *CycleSchedule) In(location *time.Location) *CycleSchedule {
+	c.location = location
+	return c
+}
+
+// Next возвращает время цикла, следующего за циклом в момент prev. Циклы, время которых уже прошло к now
+// (предыдущий цикл выполнялся дольше), пропускаются. Нулевое время - расписание больше не наступает
+func (c *CycleSchedule) Next(prev, now time.Time) time.Time {
+	next := c.after(prev)
+	for !next.IsZero() && !next.After(now) {
+		next = c.after(next)
+	}
+	return next
+}
+
+// Overrun возвращает время цикла после now и количество циклов с next по now, время которых прошло,
+// пока выполнялся долгий цикл. Такие циклы пропускаются, а не запускаются подряд
+func (c *CycleSchedule) Overrun(next, now time.Time) (time.Time, int) {
+	missed := 0
+	for !next.IsZero() && !next.After(now) {
+		missed++
+		next = c.after(next)
+	}
+	return next, missed
+}
+
+// after возвращает время цикла строго после t
+func (c *CycleSchedule) after(t time.Time) time.Time {
+	if c.cron != nil {
+		return c.cron.Next(t.In(c.location))
+	}
+	return t.Add(c.interval)
+}
+
+// QuietWindow возвращает тихое окно, в которое попадает t
+func (c *CycleSchedule) QuietWindow(t time.Time) (cron.Window, bool) {
+	local := t.In(c.location)
+	for _, window := range c.quiet {
+		if window.Contains(local) {
+			return window, true
+		}
+	}
+	return cron.Window{}, false
+}
+
+// Interval возвращает интервал циклов (0 - циклы по расписанию cron)
+func (c *CycleSchedule) Interval() time.Duration {
+	if c.cron != nil {
+		return 0
+	}
+	return c.interval
+}
+
+// Cron возвращает расписание cron ("" - циклы каждые интервал)
+func (c *CycleSchedule) Cron() string {
+	if c.cron == nil {
+		return ""
+	}
+	return c.cron.String()
+}
+
+// QuietHours возвращает тихие часы в формате "ЧЧ:ММ-ЧЧ:ММ"
+func (c *CycleSchedule) QuietHours() []string {
+	hours := make([]string, len(c.quiet))
+	for i, window := range c.quiet {
+		hours[i] = window.String()
+	}
+	return hours
+}
+
+// Location возвращает часовой пояс расписания и тихих часов
+func (c *CycleSchedule) Location() *time.Location {
+	return c.location
+}
+
+// String описывает расписание для лога
+func (c *CycleSchedule) String() string {
+	description := fmt.Sprintf("каждые %v", c.interval)
+	if c.cron != nil {
+		description = fmt.Sprintf("по расписанию «%s» (%s)", c.cron, c.location)
+	}
+	if len(c.quiet) > 0 {
+		description += fmt.Sprintf(", тихие часы %s (%s)", strings.Join(c.QuietHours(), ", "), c.location)
+	}
+	return description
+}
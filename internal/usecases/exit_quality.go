@@ -0,0 +1,217 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// HedgeExitQuality качество выхода из одного закрытого хеджа
+type HedgeExitQuality struct {
+	HedgeID           int64     `json:"hedge_id"`
+	Pair              string    `json:"pair"`
+	Exit              string    `json:"exit"` // Способ закрытия (entities.HedgeExit*)
+	OpenedAt          time.Time `json:"opened_at"`
+	ClosedAt          time.Time `json:"closed_at"`
+	OpenPrice         float64   `json:"open_price"`
+	ClosePrice        float64   `json:"close_price"`
+	PeakPrice         float64   `json:"peak_price"`         // Максимум цены за время удержания по часовым свечам
+	EfficiencyPercent *float64  `json:"efficiency_percent"` // Доля роста до максимума, полученная при выходе (nil - роста не было)
+	MissedPercent     float64   `json:"missed_percent"`     // Насколько максимум выше цены выхода, %
+}
+
+// ExitQualityGroup эффективность выхода группы хеджей (пара или способ закрытия)
+type ExitQualityGroup struct {
+	Key              string       `json:"key"`
+	Hedges           int          `json:"hedges"`            // Закрытых хеджей в группе
+	Efficiency       Distribution `json:"efficiency"`        // Эффективность выхода, % (хеджи с ростом за время удержания)
+	MissedPercent    Distribution `json:"missed_percent"`    // Упущенный рост от цены выхода до максимума, %
+	PeakAboveTarget  int          `json:"peak_above_target"` // Хеджей, цена которых после тейк-профита росла дальше
+	WithoutKlines    int          `json:"without_klines"`    // Хеджей без свечей за время удержания
+	efficiencyValues []float64
+	missedValues     []float64
+}
+
+// ExitQualityReport отчет о качестве выхода из хеджей: насколько цена выхода близка к максимуму
+// за время удержания. Помогает оценить, дал бы скользящий тейк-профит или лестница выходов больше
+type ExitQualityReport struct {
+	Since   time.Time          `json:"since"`
+	Overall ExitQualityGroup   `json:"overall"`
+	Pairs   []ExitQualityGroup `json:"pairs"`
+	Exits   []ExitQualityGroup `json:"exits"` // По способу закрытия
+	Hedges  []HedgeExitQuality `json:"hedges"`
+}
+
+// ExitQualityUseCase строит отчет о качестве выхода по закрытым хеджам и свечам биржи
+type ExitQualityUseCase struct {
+	hedgeRepo       repositories.HedgeRepository
+	exchangeService services.ExchangeService
+}
+
+// NewExitQualityUseCase создает новый use case качества выхода
+func NewExitQualityUseCase(hedgeRepo repositories.HedgeRepository, exchangeService services.ExchangeService) *ExitQualityUseCase {
+	return &ExitQualityUseCase{
+		hedgeRepo:       hedgeRepo,
+		exchangeService: exchangeService,
+	}
+}
+
+// BuildReport строит отчет по хеджам, открытым за последние days дней и уже закрытым продажей
+func (u *ExitQualityUseCase) BuildReport(ctx context.Context, days int) (*ExitQualityReport, error) {
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	page, err := u.hedgeRepo.QueryHedgedTrades(ctx, &entities.HedgeTradeQuery{From: &since, Ascending: true})
+	if err != nil {
+		return nil, err
+	}
+
+	byPair := make(map[string][]*entities.HedgedTrade)
+	for _, hedge := range page.Trades {
+		if hedge.ExitKind() == "" || hedge.CloseTime == nil || hedge.HedgeOpenPrice <= 0 {
+			continue
+		}
+		byPair[hedge.Pair] = append(byPair[hedge.Pair], hedge)
+	}
+
+	report := &ExitQualityReport{Since: since, Overall: ExitQualityGroup{Key: "all"}}
+	pairGroups := make(map[string]*ExitQualityGroup)
+	exitGroups := make(map[string]*ExitQualityGroup)
+	group := func(groups map[string]*ExitQualityGroup, key string) *ExitQualityGroup {
+		if groups[key] == nil {
+			groups[key] = &ExitQualityGroup{Key: key}
+		}
+		return groups[key]
+	}
+
+	for pair, hedges := range byPair {
+		// Одна выборка свечей на пару: от открытия первого хеджа до закрытия последнего
+		start, end := holdingWindow(hedges[0])
+		for _, hedge := range hedges[1:] {
+			from, to := holdingWindow(hedge)
+			if from.Before(start) {
+				start = from
+			}
+			if to.After(end) {
+				end = to
+			}
+		}
+
+		symbol := valueobjects.NewTradingPair(pair).ToBybitFormat()
+		klines, err := u.exchangeService.GetKlines(ctx, symbol, start.Truncate(time.Hour), end)
+		if err != nil {
+			// Без свечей максимум неизвестен: хеджи пары учитываются только в счетчиках
+			logger.LogWithTime("⚠️ Не удалось получить свечи %s для отчета о качестве выхода: %v", symbol, err)
+		}
+
+		for _, hedge := range hedges {
+			quality, ok := exitQuality(hedge, klines)
+			for _, g := range []*ExitQualityGroup{&report.Overall, group(pairGroups, pair), group(exitGroups, quality.Exit)} {
+				g.add(quality, ok)
+			}
+			if ok {
+				report.Hedges = append(report.Hedges, quality)
+			}
+		}
+	}
+
+	report.Overall.finish()
+	report.Pairs = sortedExitGroups(pairGroups)
+	report.Exits = sortedExitGroups(exitGroups)
+	// Первыми - хеджи с наибольшим упущенным ростом
+	sort.SliceStable(report.Hedges, func(i, j int) bool {
+		return report.Hedges[i].MissedPercent > report.Hedges[j].MissedPercent
+	})
+
+	return report, nil
+}
+
+// holdingWindow возвращает время удержания хеджа: от исполнения покупки (или хеджирования) до закрытия
+func holdingWindow(hedge *entities.HedgedTrade) (time.Time, time.Time) {
+	start := hedge.HedgeTime
+	if hedge.BuyFilledAt != nil {
+		start = *hedge.BuyFilledAt
+	}
+	return start, *hedge.CloseTime
+}
+
+// exitQuality рассчитывает качество выхода хеджа по часовым свечам за время удержания.
+// Свечи на границах учитываются целиком, поэтому максимум известен с точностью до часа.
+// Возвращает false, если свечей за время удержания нет
+func exitQuality(hedge *entities.HedgedTrade, klines []*entities.Kline) (HedgeExitQuality, bool) {
+	start, end := holdingWindow(hedge)
+	closePrice := *hedge.ClosePrice
+	quality := HedgeExitQuality{
+		HedgeID:    hedge.HedgeID,
+		Pair:       hedge.Pair,
+		Exit:       hedge.ExitKind(),
+		OpenedAt:   start,
+		ClosedAt:   end,
+		OpenPrice:  hedge.HedgeOpenPrice,
+		ClosePrice: closePrice,
+		PeakPrice:  closePrice, // Цена выхода - тоже сделка за время удержания
+	}
+
+	found := false
+	for _, kline := range klines {
+		if kline.StartTime.Before(start.Truncate(time.Hour)) {
+			continue
+		}
+		if kline.StartTime.After(end) {
+			break
+		}
+		found = true
+		if kline.High > quality.PeakPrice {
+			quality.PeakPrice = kline.High
+		}
+	}
+	if !found {
+		return quality, false
+	}
+
+	quality.MissedPercent = (quality.PeakPrice/closePrice - 1) * 100
+	if upside := quality.PeakPrice - quality.OpenPrice; upside > 0 {
+		efficiency := (closePrice - quality.OpenPrice) / upside * 100
+		quality.EfficiencyPercent = &efficiency
+	}
+	return quality, true
+}
+
+// add учитывает хедж в группе
+func (g *ExitQualityGroup) add(quality HedgeExitQuality, measured bool) {
+	g.Hedges++
+	if !measured {
+		g.WithoutKlines++
+		return
+	}
+	if quality.EfficiencyPercent != nil {
+		g.efficiencyValues = append(g.efficiencyValues, *quality.EfficiencyPercent)
+	}
+	g.missedValues = append(g.missedValues, quality.MissedPercent)
+	if quality.Exit == entities.HedgeExitTakeProfit && quality.MissedPercent > 0 {
+		g.PeakAboveTarget++
+	}
+}
+
+// finish строит распределения группы
+func (g *ExitQualityGroup) finish() {
+	g.Efficiency = newDistribution(g.efficiencyValues)
+	g.MissedPercent = newDistribution(g.missedValues)
+}
+
+// sortedExitGroups возвращает группы с распределениями в порядке ключей
+func sortedExitGroups(groups map[string]*ExitQualityGroup) []ExitQualityGroup {
+	result := make([]ExitQualityGroup, 0, len(groups))
+	for _, g := range groups {
+		g.finish()
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
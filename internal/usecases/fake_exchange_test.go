@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+)
+
+// fakeExchange биржа для тестов: ордера активны, пока их не отменят, рыночная продажа исполняется сразу
+// по цене тикера. Каждый запрос ордеров и цены выполняется с задержкой latency, чтобы параллельные проверки
+// пересекались
+type fakeExchange struct {
+	mu      sync.Mutex
+	price   float64
+	latency time.Duration
+	orders  map[string]*services.OrderStatusInfo
+	sells   []*entities.Order
+	nextID  int
+
+	available  float64                  // Доступный баланс любой валюты
+	instrument *services.InstrumentInfo // Информация об инструменте (nil - шаги по умолчанию)
+	klines     []*entities.Kline        // Свечи за любое окно
+	err        error                    // Ошибка запросов рыночных данных (цена, баланс, свечи)
+}
+
+// newFakeExchange создает биржу с ценой тикера price
+func newFakeExchange(price float64) *fakeExchange {
+	return &fakeExchange{price: price, orders: make(map[string]*services.OrderStatusInfo)}
+}
+
+// addOrder добавляет активный ордер
+func (e *fakeExchange) addOrder(orderID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.orders[orderID] = &services.OrderStatusInfo{OrderID: orderID, Status: entities.OrderStatusPending}
+}
+
+// sellCount возвращает количество размещенных продаж
+func (e *fakeExchange) sellCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.sells)
+}
+
+func (e *fakeExchange) wait() {
+	if e.latency > 0 {
+		time.Sleep(e.latency)
+	}
+}
+
+func (e *fakeExchange) PlaceOrder(ctx context.Context, order *entities.Order) (*entities.OrderResult, error) {
+	e.wait()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.nextID++
+	orderID := fmt.Sprintf("order-%d", e.nextID)
+	status := &services.OrderStatusInfo{OrderID: orderID, Status: entities.OrderStatusPending}
+	if order.Side == entities.OrderSideSell {
+		e.sells = append(e.sells, order)
+	}
+	if order.Type == entities.OrderTypeMarket {
+		price := e.price
+		status.Status = entities.OrderStatusFilled
+		status.FilledPrice = &price
+		status.FilledQty = order.Quantity
+	}
+	e.orders[orderID] = status
+	return &entities.OrderResult{OrderID: orderID, Success: true}, nil
+}
+
+func (e *fakeExchange) CancelOrder(ctx context.Context, orderID, symbol string) (*entities.OrderResult, error) {
+	e.wait()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status, ok := e.orders[orderID]
+	if !ok || status.Status.IsCompleted() {
+		return &entities.OrderResult{OrderID: orderID, Error: "order not exists or too late to cancel"}, nil
+	}
+	status.Status = entities.OrderStatusCancelled
+	return &entities.OrderResult{OrderID: orderID, Success: true}, nil
+}
+
+func (e *fakeExchange) GetBalance(ctx context.Context, asset string) (*entities.Balance, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return &entities.Balance{Asset: asset, Available: e.available, Total: e.available}, nil
+}
+
+func (e *fakeExchange) GetOrderStatus(ctx context.Context, orderID, symbol string) (*services.OrderStatusInfo, error) {
+	e.wait()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status, ok := e.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("ордер %s не найден", orderID)
+	}
+	copied := *status
+	return &copied, nil
+}
+
+func (e *fakeExchange) GetOrderStatusByClientID(ctx context.Context, clientOrderID, symbol string) (*services.OrderStatusInfo, error) {
+	return nil, nil
+}
+
+func (e *fakeExchange) GetInstrumentInfo(ctx context.Context, symbol string) (*services.InstrumentInfo, error) {
+	if e.instrument != nil {
+		instrument := *e.instrument
+		return &instrument, nil
+	}
+	return &services.InstrumentInfo{Symbol: symbol, TickSize: 0.01, StepSize: 0.001, Status: "Trading"}, nil
+}
+
+func (e *fakeExchange) GetTickerPrice(ctx context.Context, symbol string) (float64, error) {
+	e.wait()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return 0, e.err
+	}
+	return e.price, nil
+}
+
+func (e *fakeExchange) GetKlines(ctx context.Context, symbol string, start, end time.Time) ([]*entities.Kline, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return e.klines, nil
+}
+
+// fakeBookExchange биржа для тестов с ценами стакана
+type fakeBookExchange struct {
+	*fakeExchange
+	bid, ask float64
+}
+
+func (e *fakeBookExchange) GetBookTicker(ctx context.Context, symbol string) (*services.BookTicker, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return &services.BookTicker{Bid: e.bid, Ask: e.ask}, nil
+}
@@ -0,0 +1,161 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// FeatureFlagView состояние флага возможности для веб-интерфейса
+type FeatureFlagView struct {
+	Key         string     `json:"key"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Default     bool       `json:"default"`
+	Enabled     bool       `json:"enabled"`
+	Source      string     `json:"source"` // default, config, database
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	UpdatedBy   string     `json:"updated_by,omitempty"`
+}
+
+// FeatureFlagsUseCase определяет, включены ли рискованные возможности. Приоритет: переключение
+// в веб-интерфейсе (БД), затем раздел features конфигурации, затем значение по умолчанию флага
+type FeatureFlagsUseCase struct {
+	repo       repositories.FeatureFlagRepository
+	configured map[string]bool // Значения из конфигурации
+
+	mu       sync.RWMutex
+	switched map[string]*entities.FeatureFlag
+}
+
+// NewFeatureFlagsUseCase создает use case флагов; configured - раздел features конфигурации
+func NewFeatureFlagsUseCase(repo repositories.FeatureFlagRepository, configured map[string]bool) *FeatureFlagsUseCase {
+	return &FeatureFlagsUseCase{
+		repo:       repo,
+		configured: configured,
+		switched:   make(map[string]*entities.FeatureFlag),
+	}
+}
+
+// Load загружает переключения из БД (при запуске). Неизвестные флаги пропускаются
+func (u *FeatureFlagsUseCase) Load(ctx context.Context) error {
+	flags, err := u.repo.GetFeatureFlags(ctx)
+	if err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.switched = make(map[string]*entities.FeatureFlag, len(flags))
+	for _, flag := range flags {
+		if _, ok := entities.FindFeatureFlag(flag.Key); !ok {
+			continue
+		}
+		u.switched[flag.Key] = flag
+		logger.LogWithTime("🚩 Флаг %s = %v из БД (переключен %s, %s)",
+			flag.Key, flag.Enabled, flag.UpdatedBy, flag.UpdatedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Enabled проверяет, включена ли возможность. Без use case (nil) действует значение по умолчанию флага
+func (u *FeatureFlagsUseCase) Enabled(key string) bool {
+	definition, ok := entities.FindFeatureFlag(key)
+	if !ok {
+		return false
+	}
+	if u == nil {
+		return definition.Default
+	}
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	enabled, _ := u.resolve(definition)
+	return enabled
+}
+
+// Flags возвращает состояние всех зарегистрированных флагов
+func (u *FeatureFlagsUseCase) Flags() []FeatureFlagView {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	views := make([]FeatureFlagView, 0, len(entities.FeatureFlagDefinitions))
+	for _, definition := range entities.FeatureFlagDefinitions {
+		enabled, source := u.resolve(definition)
+		view := FeatureFlagView{
+			Key:         definition.Key,
+			Title:       definition.Title,
+			Description: definition.Description,
+			Default:     definition.Default,
+			Enabled:     enabled,
+			Source:      source,
+		}
+		if flag, ok := u.switched[definition.Key]; ok {
+			updatedAt := flag.UpdatedAt
+			view.UpdatedAt = &updatedAt
+			view.UpdatedBy = flag.UpdatedBy
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// Set переключает флаг; состояние действует сразу и сохраняется после перезапуска
+func (u *FeatureFlagsUseCase) Set(ctx context.Context, key string, enabled bool, author string) (*entities.FeatureFlagChange, error) {
+	if _, ok := entities.FindFeatureFlag(key); !ok {
+		return nil, fmt.Errorf("неизвестный флаг: %s", key)
+	}
+
+	change, err := u.repo.SaveFeatureFlag(ctx, key, &enabled, author)
+	if err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	u.switched[key] = &entities.FeatureFlag{Key: key, Enabled: enabled, UpdatedAt: change.ChangedAt, UpdatedBy: author}
+	u.mu.Unlock()
+
+	logger.LogWithTime("🚩 Флаг %s переключен: %v (%s)", key, enabled, author)
+	return change, nil
+}
+
+// Reset удаляет переключение: снова действует конфигурация или значение по умолчанию
+func (u *FeatureFlagsUseCase) Reset(ctx context.Context, key string, author string) (*entities.FeatureFlagChange, error) {
+	if _, ok := entities.FindFeatureFlag(key); !ok {
+		return nil, fmt.Errorf("неизвестный флаг: %s", key)
+	}
+
+	change, err := u.repo.SaveFeatureFlag(ctx, key, nil, author)
+	if err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	delete(u.switched, key)
+	u.mu.Unlock()
+
+	logger.LogWithTime("🚩 Флаг %s сброшен к конфигурации (%s)", key, author)
+	return change, nil
+}
+
+// History возвращает журнал переключений флагов (новые первыми)
+func (u *FeatureFlagsUseCase) History(ctx context.Context, limit int) ([]*entities.FeatureFlagChange, error) {
+	return u.repo.GetFeatureFlagChanges(ctx, limit)
+}
+
+// resolve возвращает состояние флага и его источник (вызывается под блокировкой)
+func (u *FeatureFlagsUseCase) resolve(definition entities.FeatureFlagDefinition) (bool, string) {
+	if flag, ok := u.switched[definition.Key]; ok {
+		return flag.Enabled, entities.FeatureFlagSourceDatabase
+	}
+	if enabled, ok := u.configured[definition.Key]; ok {
+		return enabled, entities.FeatureFlagSourceConfig
+	}
+	return definition.Default, entities.FeatureFlagSourceDefault
+}
@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// feeInQuote переводит комиссию исполнения ордера в котируемую валюту пары по цене исполнения.
+// Комиссия в сторонней валюте (например, скидочном токене биржи) не пересчитывается и не учитывается
+func feeInQuote(status *services.OrderStatusInfo, pair *valueobjects.TradingPair, price float64) float64 {
+	if status == nil || status.Fee <= 0 {
+		return 0
+	}
+
+	switch status.FeeCurrency {
+	case "", pair.QuoteCurrency():
+		return status.Fee
+	case pair.BaseCurrency():
+		if status.FilledPrice != nil && *status.FilledPrice > 0 {
+			price = *status.FilledPrice
+		}
+		return status.Fee * price
+	default:
+		logger.LogWithTime("⚠️ Комиссия ордера %s списана в %s и не учитывается в прибыли %s",
+			status.OrderID, status.FeeCurrency, pair.String())
+		return 0
+	}
+}
@@ -0,0 +1,363 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// Результаты закрытия хеджа по времени
+const (
+	FlatActionClosed    = "closed"    // Позиция продана по рынку
+	FlatActionCancelled = "cancelled" // Неисполненная покупка отменена, позиции нет
+	FlatActionSkipped   = "skipped"   // Хедж оставлен (например, не в прибыли)
+	FlatActionFailed    = "failed"    // Закрыть не удалось
+)
+
+// flatCloseMode способ закрытия хеджа: по времени или вручную
+type flatCloseMode struct {
+	onlyProfitable bool                 // Оставить хедж, если он не в прибыли по текущей цене
+	sell           bool                 // Продать позицию (false - монеты остаются на балансе)
+	closed         entities.OrderStatus // Статус хеджа, закрытого продажей или без продажи
+	cancelled      entities.OrderStatus // Статус хеджа, покупка которого отменена без исполнения
+}
+
+// FlatCloserConfig конфигурация закрытия хеджей по времени
+type FlatCloserConfig struct {
+	OnlyProfitable bool // Закрывать только хеджи, находящиеся в прибыли по текущей цене
+}
+
+// FlatCloseResult результат закрытия одного хеджа
+type FlatCloseResult struct {
+	TradeID    int
+	Pair       string
+	OrderID    string   // Ордер хеджа до закрытия (тейк-профит или покупка)
+	Action     string   // closed, cancelled, skipped, failed
+	ClosePrice *float64 // Цена продажи по рынку
+	Profit     *float64 // Результат закрытия в котируемой валюте за вычетом комиссий
+	Reason     string   // Причина пропуска или ошибки
+}
+
+// FlatCloserUseCase закрывает открытые хеджи в заданное время суток, чтобы не держать позиции ночью
+type FlatCloserUseCase struct {
+	hedgeRepo       repositories.HedgeRepository
+	intentRepo      repositories.HedgeIntentRepository
+	exchangeService services.ExchangeService
+	config          *FlatCloserConfig
+	flags           *FeatureFlagsUseCase // Флаги возможностей (nil - значения по умолчанию)
+	closer          *CloseExecutor       // Продажа позиции
+}
+
+// NewFlatCloserUseCase создает новый use case закрытия хеджей по времени
+func NewFlatCloserUseCase(
+	hedgeRepo repositories.HedgeRepository,
+	intentRepo repositories.HedgeIntentRepository,
+	exchangeService services.ExchangeService,
+	config *FlatCloserConfig,
+) *FlatCloserUseCase {
+	return &FlatCloserUseCase{
+		hedgeRepo:       hedgeRepo,
+		intentRepo:      intentRepo,
+		exchangeService: exchangeService,
+		config:          config,
+		closer:          NewCloseExecutor(exchangeService, nil),
+	}
+}
+
+// WithCloseProtection продает позиции лимитными ордерами с ограниченным отклонением от цены отметки
+// вместо рыночного ордера (см. CloseExecutor)
+func (f *FlatCloserUseCase) WithCloseProtection(config *CloseExecutionConfig) *FlatCloserUseCase {
+	f.closer = NewCloseExecutor(f.exchangeService, config)
+	return f
+}
+
+// WithFeatureFlags подключает флаги возможностей: при отключенном auto_close хеджи не закрываются
+func (f *FlatCloserUseCase) WithFeatureFlags(flags *FeatureFlagsUseCase) *FlatCloserUseCase {
+	f.flags = flags
+	return f
+}
+
+// CloseAll закрывает все открытые хеджи (или только прибыльные) и возвращает результат по каждому
+func (f *FlatCloserUseCase) CloseAll(ctx context.Context) ([]*FlatCloseResult, error) {
+	if !f.flags.Enabled(entities.FlagAutoClose) {
+		logger.LogWithTime("🚩 Закрытие хеджей по времени отключено флагом %s", entities.FlagAutoClose)
+		return nil, nil
+	}
+
+	logger.LogWithTime("🌙 Закрытие хеджей по времени (только прибыльные: %v)...", f.config.OnlyProfitable)
+
+	trades, err := f.hedgeRepo.GetHedgedTrades(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения хеджей для закрытия: %w", err)
+	}
+
+	mode := flatCloseMode{
+		onlyProfitable: f.config.OnlyProfitable,
+		sell:           true,
+		closed:         entities.OrderStatusFilled,
+		cancelled:      entities.OrderStatusCancelled,
+	}
+	var results []*FlatCloseResult
+	for _, trade := range trades {
+		var result *FlatCloseResult
+		switch trade.OrderStatus {
+		case entities.OrderStatusPending:
+			result = f.closeTakeProfit(ctx, trade, mode)
+		case entities.OrderStatusBuyPending:
+			result = f.closeBuyPending(ctx, trade, mode)
+		default:
+			continue
+		}
+		results = append(results, result)
+		logFlatCloseResult(result)
+	}
+
+	closed, failed := 0, 0
+	for _, result := range results {
+		switch result.Action {
+		case FlatActionClosed, FlatActionCancelled:
+			closed++
+		case FlatActionFailed:
+			failed++
+		}
+	}
+	logger.LogWithTime("🌙 Закрытие по времени завершено: закрыто %d, пропущено %d, ошибок %d из %d",
+		closed, len(results)-closed-failed, failed, len(results))
+
+	return results, nil
+}
+
+// CloseHedge закрывает один открытый хедж вручную по ID ордера (тейк-профита или покупки): отменяет
+// тейк-профит и стоп-лосс или неисполненную покупку, с sell продает позицию (по рынку или с защитой цены),
+// без sell оставляет монеты на балансе. Хедж переводится в статус CLOSED_MANUAL; флаг auto_close
+// и only_profitable не учитываются
+func (f *FlatCloserUseCase) CloseHedge(ctx context.Context, orderID string, sell bool) (*FlatCloseResult, error) {
+	trade, err := f.findOpenHedge(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.LogWithTime("🖐️ Ручное закрытие хеджа %s (%s, сделка %d, продажа позиции: %v)...",
+		orderID, trade.Pair, trade.FreqtradeTradeID, sell)
+
+	mode := flatCloseMode{
+		sell:      sell,
+		closed:    entities.OrderStatusClosedManual,
+		cancelled: entities.OrderStatusClosedManual,
+	}
+	var result *FlatCloseResult
+	if trade.OrderStatus == entities.OrderStatusBuyPending {
+		result = f.closeBuyPending(ctx, trade, mode)
+	} else {
+		result = f.closeTakeProfit(ctx, trade, mode)
+	}
+	logFlatCloseResult(result)
+	return result, nil
+}
+
+// findOpenHedge находит хедж с тейк-профитом или покупкой в ожидании по ID ордера
+func (f *FlatCloserUseCase) findOpenHedge(ctx context.Context, orderID string) (*entities.HedgedTrade, error) {
+	for _, status := range []entities.OrderStatus{entities.OrderStatusPending, entities.OrderStatusBuyPending} {
+		statusFilter := status.String()
+		trades, err := f.hedgeRepo.GetHedgedTrades(ctx, &statusFilter)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения хеджей: %w", err)
+		}
+		for _, trade := range trades {
+			if trade.BybitOrderID == orderID || (trade.BuyOrderID == orderID && status == entities.OrderStatusBuyPending) {
+				return trade, nil
+			}
+		}
+	}
+	return nil, errors.NewHedgeNotFoundError(orderID)
+}
+
+// closeTakeProfit отменяет тейк-профит и продает позицию по рынку
+func (f *FlatCloserUseCase) closeTakeProfit(ctx context.Context, trade *entities.HedgedTrade, mode flatCloseMode) *FlatCloseResult {
+	result := &FlatCloseResult{TradeID: trade.FreqtradeTradeID, Pair: trade.Pair, OrderID: trade.BybitOrderID}
+	symbol := valueobjects.NewTradingPair(trade.Pair).ToBybitFormat()
+
+	price, err := f.exchangeService.GetTickerPrice(ctx, symbol)
+	if err != nil {
+		return failFlatClose(result, fmt.Errorf("ошибка получения цены: %w", err))
+	}
+	if mode.onlyProfitable && price <= trade.HedgeOpenPrice {
+		result.Action = FlatActionSkipped
+		result.Reason = fmt.Sprintf("не в прибыли: цена %.8f, покупка %.8f", price, trade.HedgeOpenPrice)
+		return result
+	}
+
+	if _, err := f.exchangeService.CancelOrder(ctx, trade.BybitOrderID, symbol); err != nil {
+		return failFlatClose(result, fmt.Errorf("ошибка отмены тейк-профита: %w", err))
+	}
+	if trade.StopLossOrderID != "" {
+		if _, err := f.exchangeService.CancelOrder(ctx, trade.StopLossOrderID, symbol); err != nil {
+			logger.LogWithTime("⚠️ Не удалось отменить стоп-лосс %s: %v", trade.StopLossOrderID, err)
+		}
+	}
+
+	// Тейк-профит мог частично исполниться до отмены - продаем только остаток
+	quantity := trade.HedgeAmount
+	if status, err := f.exchangeService.GetOrderStatus(ctx, trade.BybitOrderID, symbol); err == nil {
+		if status.Status == entities.OrderStatusFilled {
+			result.Action = FlatActionSkipped
+			result.Reason = "тейк-профит исполнен до отмены"
+			return result
+		}
+		quantity -= status.FilledQty
+	}
+
+	if !mode.sell {
+		return f.keepPosition(ctx, trade, result, quantity, mode)
+	}
+	return f.sellAtMarket(ctx, trade, result, symbol, quantity, price, mode)
+}
+
+// closeBuyPending отменяет неисполненную покупку; исполненная часть продается по рынку
+func (f *FlatCloserUseCase) closeBuyPending(ctx context.Context, trade *entities.HedgedTrade, mode flatCloseMode) *FlatCloseResult {
+	result := &FlatCloseResult{TradeID: trade.FreqtradeTradeID, Pair: trade.Pair, OrderID: trade.BybitOrderID}
+	symbol := valueobjects.NewTradingPair(trade.Pair).ToBybitFormat()
+
+	if _, err := f.exchangeService.CancelOrder(ctx, trade.BuyOrderID, symbol); err != nil {
+		return failFlatClose(result, fmt.Errorf("ошибка отмены покупки: %w", err))
+	}
+
+	status, err := f.exchangeService.GetOrderStatus(ctx, trade.BuyOrderID, symbol)
+	if err != nil {
+		return failFlatClose(result, fmt.Errorf("ошибка получения статуса покупки: %w", err))
+	}
+
+	if status.FilledQty <= 0 {
+		now := time.Now()
+		if err := f.hedgeRepo.UpdateHedgedTradeStatus(ctx, trade.BybitOrderID, mode.cancelled, nil, &now); err != nil {
+			return failFlatClose(result, err)
+		}
+		closeHedgeIntentByOrderID(ctx, f.intentRepo, trade.BuyOrderID)
+		result.Action = FlatActionCancelled
+		return result
+	}
+
+	// Частично исполненная покупка - продаем купленное количество
+	closing := *trade
+	closing.HedgeAmount = status.FilledQty
+	closing.BuyFilledQty = status.FilledQty
+	if status.FilledPrice != nil {
+		closing.HedgeOpenPrice = *status.FilledPrice
+	}
+	closing.EntryFee = feeInQuote(status, valueobjects.NewTradingPair(trade.Pair), closing.HedgeOpenPrice)
+	if !mode.sell {
+		return f.keepPosition(ctx, &closing, result, status.FilledQty, mode)
+	}
+
+	price, err := f.exchangeService.GetTickerPrice(ctx, symbol)
+	if err != nil {
+		return failFlatClose(result, fmt.Errorf("ошибка получения цены: %w", err))
+	}
+
+	return f.sellAtMarket(ctx, &closing, result, symbol, status.FilledQty, price, mode)
+}
+
+// keepPosition отмечает хедж закрытым без продажи: ордера отменены, купленные монеты (quantity) остаются на балансе
+func (f *FlatCloserUseCase) keepPosition(
+	ctx context.Context,
+	trade *entities.HedgedTrade,
+	result *FlatCloseResult,
+	quantity float64,
+	mode flatCloseMode,
+) *FlatCloseResult {
+	now := time.Now()
+	closed := *trade
+	closed.OrderStatus = mode.closed
+	closed.LastStatusCheck = &now
+	closed.CloseTime = &now
+	if err := f.hedgeRepo.UpdateHedgedTrade(ctx, trade.BybitOrderID, &closed); err != nil {
+		return failFlatClose(result, fmt.Errorf("ордера отменены, но хедж не обновлен: %w", err))
+	}
+	closeHedgeIntentByOrderID(ctx, f.intentRepo, trade.BybitOrderID)
+
+	result.Action = FlatActionCancelled
+	result.Reason = fmt.Sprintf("ордера отменены, %.8f %s оставлены на балансе",
+		quantity, valueobjects.NewTradingPair(trade.Pair).BaseCurrency())
+	return result
+}
+
+// sellAtMarket продает количество (по рынку или с защитой цены) и отмечает хедж закрытым по цене исполнения.
+// referencePrice - цена отметки, по которой принято решение о закрытии
+func (f *FlatCloserUseCase) sellAtMarket(
+	ctx context.Context,
+	trade *entities.HedgedTrade,
+	result *FlatCloseResult,
+	symbol string,
+	quantity, referencePrice float64,
+	mode flatCloseMode,
+) *FlatCloseResult {
+	if quantity <= 0 {
+		return failFlatClose(result, fmt.Errorf("нечего продавать: количество %.8f", quantity))
+	}
+
+	fill, err := f.closer.Sell(ctx, trade.Pair, quantity, referencePrice, nil)
+	if fill == nil {
+		return failFlatClose(result, fmt.Errorf("ошибка продажи по рынку: %w", err))
+	}
+	if err != nil {
+		logger.LogWithTime("⚠️ %s: продано %.8f из %.8f: %v", symbol, fill.FilledQty, quantity, err)
+	}
+	logCloseFill(valueobjects.NewTradingPair(trade.Pair), fill)
+	closePrice := fill.Price
+	now := time.Now()
+
+	previousOrderID := trade.BybitOrderID
+	closed := *trade
+	closed.BybitOrderID = fill.OrderID
+	closed.OrderStatus = mode.closed
+	closed.LastStatusCheck = &now
+	closed.ClosePrice = &closePrice
+	closed.CloseIntendedPrice = fill.IntendedPrice
+	closed.CloseTime = &now
+	closed.ExitFee = fill.Fee
+	if err := f.hedgeRepo.UpdateHedgedTrade(ctx, previousOrderID, &closed); err != nil {
+		return failFlatClose(result, fmt.Errorf("позиция продана ордером %s, но хедж не обновлен: %w", fill.OrderID, err))
+	}
+	closeHedgeIntentByOrderID(ctx, f.intentRepo, previousOrderID)
+
+	result.Action = FlatActionClosed
+	result.ClosePrice = &closePrice
+	if profit := closed.CalculateProfit(); profit != nil {
+		result.Profit = &profit.Net
+	}
+	return result
+}
+
+// failFlatClose отмечает результат закрытия ошибкой
+func failFlatClose(result *FlatCloseResult, err error) *FlatCloseResult {
+	result.Action = FlatActionFailed
+	result.Reason = err.Error()
+	return result
+}
+
+// logFlatCloseResult выводит результат закрытия одного хеджа
+func logFlatCloseResult(result *FlatCloseResult) {
+	switch result.Action {
+	case FlatActionClosed:
+		profit := 0.0
+		if result.Profit != nil {
+			profit = *result.Profit
+		}
+		pair := valueobjects.NewTradingPair(result.Pair)
+		logger.LogWithTime("   ✅ %s (сделка %d): продано по %s, результат %s %s", result.Pair, result.TradeID,
+			pair.FormatPrice(*result.ClosePrice), valueobjects.FormatAmount(profit, pair.QuoteCurrency()), pair.QuoteCurrency())
+	case FlatActionCancelled:
+		logger.LogWithTime("   🚫 %s (сделка %d): покупка %s отменена", result.Pair, result.TradeID, result.OrderID)
+	case FlatActionSkipped:
+		logger.LogWithTime("   ⏭️ %s (сделка %d): оставлен - %s", result.Pair, result.TradeID, result.Reason)
+	default:
+		logger.LogWithTime("   ❌ %s (сделка %d): %s", result.Pair, result.TradeID, result.Reason)
+	}
+}
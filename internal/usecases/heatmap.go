@@ -0,0 +1,195 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// HeatmapCell статистика просадок пары в один час суток (UTC)
+type HeatmapCell struct {
+	Pair              string  `json:"pair"`
+	Hour              int     `json:"hour"`
+	Observations      int     `json:"observations"`        // Количество наблюдений за сделками пары в этот час
+	Crossings         int     `json:"crossings"`           // Сколько раз просадка впервые превысила порог
+	AvgDrawdown       float64 `json:"avg_drawdown"`        // Средняя просадка в момент пересечения порога, %
+	AvgReboundPct     float64 `json:"avg_rebound_pct"`     // Средний максимальный рост цены за горизонт после пересечения, %
+	AvgNetMovePct     float64 `json:"avg_net_move_pct"`    // Среднее изменение цены к концу горизонта, %
+	ReachedTakeProfit int     `json:"reached_take_profit"` // Сколько раз рост покрыл бы тейк-профит хеджа
+}
+
+// Heatmap тепловая карта возможностей хеджирования по парам и часам суток
+type Heatmap struct {
+	Since          time.Time     `json:"since"`
+	HorizonHours   int           `json:"horizon_hours"`
+	MaxLossPercent float64       `json:"max_loss_percent"`
+	Pairs          []string      `json:"pairs"`
+	Cells          []HeatmapCell `json:"cells"`
+}
+
+// HeatmapUseCase строит тепловую карту по истории наблюдений и свечам биржи,
+// помогая подобрать порог MaxLossPercent для каждой пары
+type HeatmapUseCase struct {
+	evaluationRepo  repositories.EvaluationRepository
+	exchangeService services.ExchangeService
+	maxLossPercent  float64
+	profitRatio     float64
+}
+
+// NewHeatmapUseCase создает новый use case тепловой карты
+func NewHeatmapUseCase(
+	evaluationRepo repositories.EvaluationRepository,
+	exchangeService services.ExchangeService,
+	maxLossPercent float64,
+	profitRatio float64,
+) *HeatmapUseCase {
+	return &HeatmapUseCase{
+		evaluationRepo:  evaluationRepo,
+		exchangeService: exchangeService,
+		maxLossPercent:  maxLossPercent,
+		profitRatio:     profitRatio,
+	}
+}
+
+// heatmapKey ячейка тепловой карты
+type heatmapKey struct {
+	pair string
+	hour int
+}
+
+// BuildHeatmap строит тепловую карту за последние days дней с горизонтом оценки движения цены horizonHours
+func (u *HeatmapUseCase) BuildHeatmap(ctx context.Context, days, horizonHours int) (*Heatmap, error) {
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	evaluations, err := u.evaluationRepo.GetEvaluations(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	cells := make(map[heatmapKey]*HeatmapCell)
+	cell := func(pair string, hour int) *HeatmapCell {
+		key := heatmapKey{pair: pair, hour: hour}
+		if cells[key] == nil {
+			cells[key] = &HeatmapCell{Pair: pair, Hour: hour}
+		}
+		return cells[key]
+	}
+
+	// Первое пересечение порога по каждой сделке
+	crossed := make(map[int]bool)
+	crossings := make(map[string][]*entities.TradeEvaluation)
+	for _, evaluation := range evaluations {
+		cell(evaluation.Pair, evaluation.EvaluatedAt.UTC().Hour()).Observations++
+		if evaluation.ThresholdCrossed && !crossed[evaluation.TradeID] {
+			crossed[evaluation.TradeID] = true
+			crossings[evaluation.Pair] = append(crossings[evaluation.Pair], evaluation)
+		}
+	}
+
+	horizon := time.Duration(horizonHours) * time.Hour
+	for pair, pairCrossings := range crossings {
+		symbol := valueobjects.NewTradingPair(pair).ToBybitFormat()
+		klines, err := u.exchangeService.GetKlines(ctx, symbol, pairCrossings[0].EvaluatedAt, time.Now())
+		if err != nil {
+			// Без свечей учитываем только частоту пересечений
+			logger.LogWithTime("⚠️ Не удалось получить свечи %s для тепловой карты: %v", symbol, err)
+		}
+
+		for _, evaluation := range pairCrossings {
+			c := cell(pair, evaluation.EvaluatedAt.UTC().Hour())
+			c.Crossings++
+			c.AvgDrawdown += evaluation.ProfitRatio * -100
+
+			rebound, netMove := priceMoveAfter(klines, evaluation.EvaluatedAt, horizon, evaluation.CurrentRate)
+			c.AvgReboundPct += rebound
+			c.AvgNetMovePct += netMove
+			takeProfitPercent := evaluation.ProfitRatio * -100 * u.profitRatio
+			if rebound >= takeProfitPercent {
+				c.ReachedTakeProfit++
+			}
+		}
+	}
+
+	heatmap := &Heatmap{
+		Since:          since,
+		HorizonHours:   horizonHours,
+		MaxLossPercent: u.maxLossPercent,
+	}
+	pairs := make(map[string]bool)
+	for _, c := range cells {
+		if c.Crossings > 0 {
+			c.AvgDrawdown /= float64(c.Crossings)
+			c.AvgReboundPct /= float64(c.Crossings)
+			c.AvgNetMovePct /= float64(c.Crossings)
+		}
+		if !pairs[c.Pair] {
+			pairs[c.Pair] = true
+			heatmap.Pairs = append(heatmap.Pairs, c.Pair)
+		}
+		heatmap.Cells = append(heatmap.Cells, *c)
+	}
+
+	sort.Strings(heatmap.Pairs)
+	sort.Slice(heatmap.Cells, func(i, j int) bool {
+		if heatmap.Cells[i].Pair != heatmap.Cells[j].Pair {
+			return heatmap.Cells[i].Pair < heatmap.Cells[j].Pair
+		}
+		return heatmap.Cells[i].Hour < heatmap.Cells[j].Hour
+	})
+
+	return heatmap, nil
+}
+
+// priceMoveAfter возвращает максимальный рост и итоговое изменение цены в процентах
+// за горизонт после момента from относительно цены price
+func priceMoveAfter(klines []*entities.Kline, from time.Time, horizon time.Duration, price float64) (float64, float64) {
+	if price <= 0 {
+		return 0, 0
+	}
+
+	end := from.Add(horizon)
+	maxHigh, lastClose := price, price
+	for _, kline := range klines {
+		if kline.StartTime.Before(from.Truncate(time.Hour)) {
+			continue
+		}
+		if !kline.StartTime.Before(end) {
+			break
+		}
+		if kline.High > maxHigh {
+			maxHigh = kline.High
+		}
+		lastClose = kline.Close
+	}
+
+	return (maxHigh/price - 1) * 100, (lastClose/price - 1) * 100
+}
+
+// recordEvaluations сохраняет просадки активных сделок текущего цикла; ошибки не прерывают стратегию
+func (h *HedgeStrategyUseCase) recordEvaluations(ctx context.Context, trades []*entities.Trade) {
+	if h.evaluationRepo == nil || len(trades) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	evaluations := make([]*entities.TradeEvaluation, 0, len(trades))
+	for _, trade := range trades {
+		evaluations = append(evaluations, &entities.TradeEvaluation{
+			TradeID:          trade.ID,
+			Pair:             trade.Pair,
+			EvaluatedAt:      now,
+			ProfitRatio:      trade.ProfitRatio,
+			CurrentRate:      trade.CurrentRate,
+			ThresholdCrossed: trade.ShouldBeHedged(h.config.MaxLossPercent),
+		})
+	}
+
+	if err := h.evaluationRepo.SaveEvaluations(ctx, evaluations); err != nil {
+		logger.LogWithTime("⚠️ Не удалось сохранить историю наблюдений: %v", err)
+	}
+}
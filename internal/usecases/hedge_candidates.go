@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+)
+
+// HedgeCandidate кандидат на хеджирование с расчетными параметрами следующего цикла
+type HedgeCandidate struct {
+	Rank                int             `json:"rank"` // Порядок обработки согласно политике приоритизации
+	TradeID             int             `json:"trade_id"`
+	Pair                string          `json:"pair"`
+	DrawdownPercent     float64         `json:"drawdown_percent"`
+	CurrentRate         float64         `json:"current_rate"`
+	ProposedAmount      float64         `json:"proposed_amount"`   // Сумма позиции в базовой валюте
+	ProposedQuantity    float64         `json:"proposed_quantity"` // Количество до округления по шагу инструмента
+	ExpectedTakeProfit  float64         `json:"expected_take_profit"`
+	ExpectedProfit      float64         `json:"expected_profit"` // Ожидаемая прибыль при исполнении тейк-профита
+	Filters             map[string]bool `json:"filters"`         // Результаты фильтров (true - пройден)
+	Eligible            bool            `json:"eligible"`        // Все фильтры пройдены
+	PrioritizationLabel string          `json:"prioritization"`
+}
+
+// Названия фильтров кандидатов
+const (
+	CandidateFilterNoActiveHedge    = "no_active_hedge"
+	CandidateFilterStrategySelected = "strategy_selected"
+	CandidateFilterPositionSize     = "position_size"
+	CandidateFilterPairNotLocked    = "pair_not_locked"
+	CandidateFilterPortfolioStress  = "portfolio_stress"
+)
+
+// GetHedgeCandidates возвращает активные сделки, упорядоченные политикой приоритизации,
+// с расчетными полями и результатами фильтров. Ордера не размещаются
+func (h *HedgeStrategyUseCase) GetHedgeCandidates(ctx context.Context) ([]*HedgeCandidate, error) {
+	trades, err := h.tradeService.GetActiveTrades(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения активных сделок: %w", err)
+	}
+
+	// Сначала сделки, отобранные стратегией, в ее порядке; затем остальные
+	selected := h.strategy.SelectTrades(trades)
+	isSelected := make(map[int]bool, len(selected))
+	ordered := make([]*entities.Trade, 0, len(trades))
+	for _, trade := range selected {
+		isSelected[trade.ID] = true
+		ordered = append(ordered, trade)
+	}
+	for _, trade := range trades {
+		if !isSelected[trade.ID] {
+			ordered = append(ordered, trade)
+		}
+	}
+
+	prioritization := h.strategy.Name() + "/" + NewTradePrioritizer(h.config).Name()
+	locks := h.activePairLocks(ctx)
+	portfolioStressed := h.checkPortfolioStress(trades) == nil
+	now := time.Now()
+
+	candidates := make([]*HedgeCandidate, 0, len(ordered))
+	for i, trade := range ordered {
+		hasActiveHedge, previousHedges, err := h.hedgeHistoryState(ctx, trade)
+		if err != nil {
+			return nil, err
+		}
+
+		positionAmount := h.strategy.SizePosition(trade, previousHedges)
+		quantity := entities.CalculateQuantityFromAmount(positionAmount, trade.CurrentRate)
+		takeProfit := applyTakeProfitFloor(h.strategy.PriceExit(trade), h.strategy.PriceEntry(trade), 0,
+			h.config.MinTakeProfitTicks, h.config.MinTakeProfitPercent, h.rounding.sellPrice)
+
+		candidate := &HedgeCandidate{
+			Rank:               i + 1,
+			TradeID:            trade.ID,
+			Pair:               trade.Pair,
+			DrawdownPercent:    trade.ProfitRatio * -100,
+			CurrentRate:        trade.CurrentRate,
+			ProposedAmount:     positionAmount,
+			ProposedQuantity:   quantity,
+			ExpectedTakeProfit: takeProfit,
+			ExpectedProfit:     (takeProfit - trade.CurrentRate) * quantity,
+			Filters: map[string]bool{
+				CandidateFilterNoActiveHedge:    !hasActiveHedge,
+				CandidateFilterStrategySelected: isSelected[trade.ID],
+				CandidateFilterPositionSize:     positionAmount > 0,
+				CandidateFilterPairNotLocked:    entities.FindActivePairLock(locks, trade.Pair, now) == nil,
+				CandidateFilterPortfolioStress:  portfolioStressed,
+			},
+			PrioritizationLabel: prioritization,
+		}
+
+		candidate.Eligible = true
+		for _, passed := range candidate.Filters {
+			candidate.Eligible = candidate.Eligible && passed
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// hedgeDecisionRecorder накапливает решения не хеджировать сделки за цикл и сохраняет их одним пакетом
+type hedgeDecisionRecorder struct {
+	repo      repositories.HedgeDecisionRepository
+	mu        sync.Mutex
+	decisions []*entities.HedgeDecision
+}
+
+// newHedgeDecisionRecorder создает накопитель решений (repo nil - решения не сохраняются)
+func newHedgeDecisionRecorder(repo repositories.HedgeDecisionRepository) *hedgeDecisionRecorder {
+	if repo == nil {
+		return nil
+	}
+	return &hedgeDecisionRecorder{repo: repo}
+}
+
+// Record добавляет решение по сделке
+func (r *hedgeDecisionRecorder) Record(trade *entities.Trade, reason, details string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decisions = append(r.decisions, entities.NewHedgeDecision(trade, reason, details, time.Now().UTC()))
+}
+
+// RecordError добавляет решение по ошибке хеджирования сделки
+func (r *hedgeDecisionRecorder) RecordError(trade *entities.Trade, err error) {
+	r.Record(trade, decisionReason(err), err.Error())
+}
+
+// Flush сохраняет накопленные решения; ошибка сохранения не прерывает цикл стратегии
+func (r *hedgeDecisionRecorder) Flush(ctx context.Context) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	decisions := r.decisions
+	r.decisions = nil
+	r.mu.Unlock()
+
+	if err := r.repo.SaveHedgeDecisions(ctx, decisions); err != nil {
+		logger.LogWithTime("⚠️ Не удалось сохранить решения по сделкам: %v", err)
+	}
+}
+
+// decisionReason определяет причину отказа по ошибке хеджирования
+func decisionReason(err error) string {
+	strategyErr, ok := err.(*errors.StrategyError)
+	if !ok {
+		return entities.DecisionError
+	}
+
+	switch strategyErr.Type {
+	case errors.ErrorTypeStrategySkipped:
+		return entities.DecisionStrategySkipped
+	case errors.ErrorTypeInsufficientBalance:
+		return entities.DecisionInsufficientBalance
+	case errors.ErrorTypeInsufficientBalanceForMinLimit:
+		return entities.DecisionMinLimit
+	case errors.ErrorTypeRiskLimitExceeded, errors.ErrorTypePairRiskLimitExceeded, errors.ErrorTypeQuoteRiskLimitExceeded:
+		return entities.DecisionRiskLimit
+	case errors.ErrorTypePriceDeviation:
+		return entities.DecisionPriceDeviation
+	case errors.ErrorTypeQuoteConversion:
+		return entities.DecisionQuoteConversion
+	case errors.ErrorTypePreTradeFilter:
+		return entities.DecisionPreTradeFilter
+	case errors.ErrorTypePortfolioCalm:
+		return entities.DecisionPortfolioCalm
+	}
+	return entities.DecisionError
+}
+
+// recordSelectionDecisions записывает сделки, не отобранные стратегией: ниже порога или отклоненные стратегией
+func (h *HedgeStrategyUseCase) recordSelectionDecisions(candidates, selected []*entities.Trade) {
+	if h.decisions == nil {
+		return
+	}
+
+	chosen := make(map[int]bool, len(selected))
+	for _, trade := range selected {
+		chosen[trade.ID] = true
+	}
+	for _, trade := range candidates {
+		if chosen[trade.ID] {
+			continue
+		}
+		if !trade.ShouldBeHedged(h.config.MaxLossPercent) {
+			h.decisions.Record(trade, entities.DecisionBelowThreshold,
+				fmt.Sprintf("Просадка %.2f%% не превышает порог %.2f%%", trade.ProfitRatio*-100, h.config.MaxLossPercent))
+			continue
+		}
+		h.decisions.Record(trade, entities.DecisionStrategySkipped,
+			fmt.Sprintf("Стратегия %s не отобрала сделку", h.strategy.Name()))
+	}
+}
+
+// HedgeDecisionReasonCount количество решений по причине
+type HedgeDecisionReasonCount struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// SummarizeHedgeDecisions считает решения по причинам (по убыванию количества)
+func SummarizeHedgeDecisions(decisions []*entities.HedgeDecision) []HedgeDecisionReasonCount {
+	counts := make(map[string]int)
+	for _, decision := range decisions {
+		counts[decision.Reason]++
+	}
+
+	summary := make([]HedgeDecisionReasonCount, 0, len(counts))
+	for reason, count := range counts {
+		summary = append(summary, HedgeDecisionReasonCount{Reason: reason, Count: count})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Count != summary[j].Count {
+			return summary[i].Count > summary[j].Count
+		}
+		return summary[i].Reason < summary[j].Reason
+	})
+	return summary
+}
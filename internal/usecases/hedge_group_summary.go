@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"trade-hedge/internal/domain/entities"
+)
+
+// HedgeGroupSummary сводка хедж-группы по ее ногам: сколько куплено, по какой средней цене и с каким итогом
+type HedgeGroupSummary struct {
+	Legs          int     `json:"legs"`            // Ног в хранилище (включая архив)
+	OpenLegs      int     `json:"open_legs"`       // Ног с незакрытым хеджем
+	FilledQty     float64 `json:"filled_qty"`      // Куплено базовой валюты всеми ногами
+	Invested      float64 `json:"invested"`        // Стоимость покупок в котируемой валюте
+	AvgEntryPrice float64 `json:"avg_entry_price"` // Средняя цена входа, взвешенная по количеству (0 - покупок нет)
+	RealizedNet   float64 `json:"realized_net"`    // Прибыль закрытых ног за вычетом комиссий
+}
+
+// SummarizeHedgeGroup считает сводку хедж-группы по ее ногам. Ноги, покупка которых еще не исполнена,
+// учитываются только в количестве ног
+func SummarizeHedgeGroup(legs []*entities.HedgedTrade) *HedgeGroupSummary {
+	summary := &HedgeGroupSummary{Legs: len(legs)}
+
+	for _, leg := range legs {
+		if leg.IsActive() {
+			summary.OpenLegs++
+		}
+		if leg.OrderStatus == entities.OrderStatusBuyPending {
+			continue
+		}
+		summary.FilledQty += leg.HedgeAmount
+		summary.Invested += leg.HedgeAmount * leg.HedgeOpenPrice
+		if profit := leg.CalculateProfit(); profit != nil {
+			summary.RealizedNet += profit.Net
+		}
+	}
+
+	if summary.FilledQty > 0 {
+		summary.AvgEntryPrice = summary.Invested / summary.FilledQty
+	}
+	return summary
+}
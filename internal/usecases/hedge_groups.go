@@ -0,0 +1,352 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// hedgeGroupTracker активные хедж-группы по ID сделки Freqtrade. Ноги, размещенные по сделке с активной
+// группой (в том числе отложенные покупки и восстановленные после сбоя), получают общий тейк-профит группы
+type hedgeGroupTracker struct {
+	repo repositories.HedgeGroupRepository
+
+	mu     sync.Mutex
+	active map[int]*entities.HedgeGroup
+}
+
+// newHedgeGroupTracker создает учет хедж-групп с хранилищем repo
+func newHedgeGroupTracker(repo repositories.HedgeGroupRepository) *hedgeGroupTracker {
+	return &hedgeGroupTracker{
+		repo:   repo,
+		active: make(map[int]*entities.HedgeGroup),
+	}
+}
+
+// load перечитывает активные группы из хранилища в начале цикла, до восстановления прерванных хеджей
+// и отложенных покупок. При ошибке остаются группы, известные с прошлого цикла
+func (t *hedgeGroupTracker) load(ctx context.Context) {
+	if t == nil {
+		return
+	}
+	groups, err := t.repo.GetActiveHedgeGroups(ctx)
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось получить активные хедж-группы: %v", err)
+		return
+	}
+
+	active := make(map[int]*entities.HedgeGroup, len(groups))
+	for _, group := range groups {
+		active[group.FreqtradeTradeID] = group
+	}
+	t.mu.Lock()
+	t.active = active
+	t.mu.Unlock()
+}
+
+// snapshot возвращает активные группы (старые первыми)
+func (t *hedgeGroupTracker) snapshot() []*entities.HedgeGroup {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	groups := make([]*entities.HedgeGroup, 0, len(t.active))
+	for _, group := range t.active {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ID < groups[j].ID
+	})
+	return groups
+}
+
+// forTrade возвращает активную группу сделки (nil - сделка хеджируется без группы)
+func (t *hedgeGroupTracker) forTrade(tradeID int) *entities.HedgeGroup {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.active[tradeID]
+}
+
+// start сохраняет новую группу и делает ее активной
+func (t *hedgeGroupTracker) start(ctx context.Context, group *entities.HedgeGroup) error {
+	if err := t.repo.SaveHedgeGroup(ctx, group); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	t.active[group.FreqtradeTradeID] = group
+	t.mu.Unlock()
+	return nil
+}
+
+// update сохраняет прогресс группы; завершенная или остановленная группа перестает быть активной
+func (t *hedgeGroupTracker) update(ctx context.Context, group *entities.HedgeGroup) {
+	if !group.IsActive() {
+		t.mu.Lock()
+		if t.active[group.FreqtradeTradeID] == group {
+			delete(t.active, group.FreqtradeTradeID)
+		}
+		t.mu.Unlock()
+	}
+	if err := t.repo.UpdateHedgeGroup(ctx, group); err != nil {
+		logger.LogWithTime("❌ Ошибка сохранения хедж-группы %d: %v", group.ID, err)
+	}
+}
+
+// takeProfitPrice возвращает общую цену тейк-профита группы (0 - еще не выставлен тейк-профит первой ноги)
+func (t *hedgeGroupTracker) takeProfitPrice(group *entities.HedgeGroup) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return group.TakeProfitPrice
+}
+
+// fixTakeProfit запоминает тейк-профит первой ноги как общий тейк-профит группы
+func (t *hedgeGroupTracker) fixTakeProfit(ctx context.Context, group *entities.HedgeGroup, price float64) {
+	t.mu.Lock()
+	if group.TakeProfitPrice > 0 {
+		t.mu.Unlock()
+		return
+	}
+	group.TakeProfitPrice = price
+	group.UpdatedAt = time.Now()
+	t.mu.Unlock()
+
+	logger.LogWithTime("🎯 Общий тейк-профит хедж-группы %d (%s): %.8f", group.ID, group.Pair, price)
+	t.update(ctx, group)
+}
+
+// WithHedgeGroupRepository включает хеджирование крупных сделок ногами: если сумма позиции больше
+// максимального ордера инструмента или доли глубины стакана, хедж покупается несколькими ордерами с интервалом
+func (h *HedgeStrategyUseCase) WithHedgeGroupRepository(repo repositories.HedgeGroupRepository) *HedgeStrategyUseCase {
+	h.groups = newHedgeGroupTracker(repo)
+	return h
+}
+
+// planHedgeGroup разбивает сумму позиции на равные ноги, если она больше допустимой суммы одного ордера.
+// Если нужно больше strategy.split_max_legs ног, хеджируется только сумма max_legs ног.
+// nil - хедж размещается одним ордером
+func (h *HedgeStrategyUseCase) planHedgeGroup(ctx context.Context, trade *entities.Trade, positionAmount float64) *entities.HedgeGroup {
+	if h.groups == nil || h.config.SplitMaxLegs <= 1 {
+		return nil
+	}
+
+	legCap, source := h.hedgeLegCap(ctx, trade)
+	if legCap <= 0 || positionAmount <= legCap {
+		return nil
+	}
+
+	legs := int(math.Ceil(positionAmount / legCap))
+	totalAmount := positionAmount
+	if legs > h.config.SplitMaxLegs {
+		legs = h.config.SplitMaxLegs
+		totalAmount = legCap * float64(legs)
+		logger.LogWithTime("✂️ Сумма позиции %.2f %s больше %d ног по %.2f %s - хеджируется только %.2f %s",
+			positionAmount, h.config.BaseCurrency, legs, legCap, h.config.BaseCurrency, totalAmount, h.config.BaseCurrency)
+	}
+
+	group := entities.NewHedgeGroup(trade.ID, trade.Pair, totalAmount, legs, time.Now())
+	logger.LogWithTime("🧩 Сумма позиции %.2f %s больше допустимой суммы ордера %.2f (%s): хедж из %d ног по %.2f %s с интервалом %v",
+		positionAmount, h.config.BaseCurrency, legCap, source, legs, group.LegAmount, h.config.BaseCurrency, h.config.SplitLegInterval)
+	return group
+}
+
+// hedgeLegCap возвращает допустимую сумму одного ордера на покупку и ее источник: наименьшее из
+// strategy.split_max_leg_amount, лимитов инструмента и доли глубины стакана (0 - ограничения нет)
+func (h *HedgeStrategyUseCase) hedgeLegCap(ctx context.Context, trade *entities.Trade) (float64, string) {
+	symbol := valueobjects.NewTradingPair(trade.Pair).ToBybitFormat()
+
+	var legCap float64
+	var source string
+	limit := func(value float64, name string) {
+		if value > 0 && (legCap == 0 || value < legCap) {
+			legCap, source = value, name
+		}
+	}
+
+	limit(h.config.SplitMaxLegAmount, "strategy.split_max_leg_amount")
+
+	instrument, err := h.exchangeService.GetInstrumentInfo(ctx, symbol)
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось получить лимиты инструмента %s для разбиения хеджа: %v", symbol, err)
+	} else {
+		limit(instrument.MaxOrderAmt, "максимальная сумма ордера "+symbol)
+		limit(instrument.MaxOrderQty*trade.CurrentRate, "максимальное количество ордера "+symbol)
+	}
+
+	if h.config.SplitDepthPercent > 0 {
+		if book, ok := h.exchangeService.(services.OrderBookExchangeService); ok {
+			depth, err := book.GetAskDepth(ctx, symbol, h.config.SplitDepthPercent)
+			if err != nil {
+				logger.LogWithTime("⚠️ Не удалось получить глубину стакана %s: %v", symbol, err)
+			} else {
+				limit(depth*h.config.SplitDepthSharePercent/100,
+					fmt.Sprintf("%.0f%% стакана %s в пределах %.2f%% от лучшей цены", h.config.SplitDepthSharePercent, symbol, h.config.SplitDepthPercent))
+			}
+		}
+	}
+
+	return legCap, source
+}
+
+// startHedgeGroup сохраняет хедж-группу и размещает ее первую ногу
+func (h *HedgeStrategyUseCase) startHedgeGroup(ctx context.Context, group *entities.HedgeGroup, trade *entities.Trade, previousHedges int) error {
+	if err := h.groups.start(ctx, group); err != nil {
+		return fmt.Errorf("ошибка сохранения хедж-группы: %w", err)
+	}
+	return h.placeHedgeLeg(ctx, group, trade, previousHedges)
+}
+
+// placeHedgeLeg размещает очередную ногу группы обычным хеджем на сумму ноги. Нога считается размещенной,
+// если покупка дошла до биржи (исполнена, оставлена до следующего цикла или ожидает восстановления).
+// Отказ фильтров и лимитов риска останавливает группу; сбой биржи переносит ногу на следующий интервал
+func (h *HedgeStrategyUseCase) placeHedgeLeg(ctx context.Context, group *entities.HedgeGroup, trade *entities.Trade, previousHedges int) error {
+	leg := group.LegsPlaced + 1
+	amount := group.NextLegAmount()
+	logger.LogWithTime("🧩 Хедж-группа %d (%s): нога %d/%d на %.2f %s",
+		group.ID, group.Pair, leg, group.LegsPlanned, amount, h.config.BaseCurrency)
+
+	err := h.hedgeTradeAmount(ctx, trade, previousHedges, amount)
+	now := time.Now()
+	if err != nil && !h.legReachedExchange(ctx, trade.ID) {
+		if strategyErr, ok := err.(*errors.StrategyError); ok && strategyErr.IsExpected() {
+			group.Stop(fmt.Sprintf("Нога %d/%d не размещена: %v", leg, group.LegsPlanned, err), now)
+			logger.LogWithTime("⏹️ Хедж-группа %d (%s) остановлена: %s", group.ID, group.Pair, group.StatusReason)
+		} else {
+			group.NextLegAt = now.Add(h.config.SplitLegInterval)
+			group.UpdatedAt = now
+			logger.LogWithTime("⚠️ Нога %d/%d хедж-группы %d не размещена, повтор не раньше %s: %v",
+				leg, group.LegsPlanned, group.ID, group.NextLegAt.Format("15:04:05"), err)
+		}
+		h.groups.update(ctx, group)
+		return err
+	}
+
+	group.LegPlaced(now, h.config.SplitLegInterval)
+	h.groups.update(ctx, group)
+	return err
+}
+
+// legReachedExchange проверяет, что у сделки есть незавершенный хедж: покупка могла дойти до биржи
+// и будет продолжена восстановлением, поэтому ногу нельзя размещать повторно
+func (h *HedgeStrategyUseCase) legReachedExchange(ctx context.Context, tradeID int) bool {
+	inFlight, err := h.inFlightTradeIDs(ctx)
+	if err != nil {
+		// Не знаем, дошла ли покупка до биржи - безопаснее не покупать ногу повторно
+		logger.LogWithTime("⚠️ Не удалось проверить незавершенные хеджи сделки %d: %v", tradeID, err)
+		return true
+	}
+	return inFlight[tradeID]
+}
+
+// advanceHedgeGroups сопровождает активные хедж-группы: останавливает группы закрытых сделок и групп
+// с закрытыми ногами, завершает группы, все ноги которых размещены, и размещает ноги, время которых подошло
+func (h *HedgeStrategyUseCase) advanceHedgeGroups(ctx context.Context, trades []*entities.Trade) {
+	if h.groups == nil {
+		return
+	}
+	groups := h.groups.snapshot()
+	if len(groups) == 0 {
+		return
+	}
+
+	openTrades := make(map[int]*entities.Trade, len(trades))
+	for _, trade := range trades {
+		openTrades[trade.ID] = trade
+	}
+
+	for _, group := range groups {
+		now := time.Now()
+		legs, err := h.groups.repo.GetHedgeGroupLegs(ctx, group.ID)
+		if err != nil {
+			logger.LogWithTime("⚠️ Не удалось получить ноги хедж-группы %d: %v", group.ID, err)
+			continue
+		}
+
+		trade, err := h.hedgeGroupTrade(ctx, openTrades[group.FreqtradeTradeID])
+		if err != nil {
+			logger.LogWithTime("⚠️ Хедж-группа %d (%s): %v", group.ID, group.Pair, err)
+			continue
+		}
+		if reason := hedgeGroupStopReason(group, legs, trade); reason != "" {
+			group.Stop(reason, now)
+			logger.LogWithTime("⏹️ Хедж-группа %d (%s) остановлена после %d из %d ног: %s",
+				group.ID, group.Pair, group.LegsPlaced, group.LegsPlanned, reason)
+			h.groups.update(ctx, group)
+			continue
+		}
+
+		if group.AllLegsPlaced() {
+			if !hasBuyPendingLeg(legs) {
+				group.Complete(now)
+				logger.LogWithTime("✅ Хедж-группа %d (%s): все %d ног размещены", group.ID, group.Pair, group.LegsPlanned)
+				h.groups.update(ctx, group)
+			}
+			continue
+		}
+		if !group.LegDue(now) {
+			continue
+		}
+
+		_, previousHedges, err := h.hedgeHistoryState(ctx, trade)
+		if err != nil {
+			logger.LogWithTime("⚠️ Хедж-группа %d (%s): %v", group.ID, group.Pair, err)
+			continue
+		}
+		if err := h.placeHedgeLeg(ctx, group, trade, previousHedges); err != nil {
+			logger.LogWithTime("⚠️ Ошибка размещения ноги хедж-группы %d (%s): %v", group.ID, group.Pair, err)
+		}
+	}
+}
+
+// hedgeGroupTrade переводит открытую сделку группы на рынок хеджа (nil - сделка закрыта в Freqtrade)
+func (h *HedgeStrategyUseCase) hedgeGroupTrade(ctx context.Context, trade *entities.Trade) (*entities.Trade, error) {
+	if trade == nil {
+		return nil, nil
+	}
+	return h.convertTradeQuote(ctx, trade)
+}
+
+// hedgeGroupStopReason возвращает причину прекратить размещение ног группы (пусто - продолжать).
+// trade - открытая сделка группы на рынке хеджа (nil - сделка закрыта в Freqtrade)
+func hedgeGroupStopReason(group *entities.HedgeGroup, legs []*entities.HedgedTrade, trade *entities.Trade) string {
+	if trade == nil {
+		return "сделка Freqtrade закрыта"
+	}
+
+	for i, leg := range legs {
+		switch leg.OrderStatus {
+		case entities.OrderStatusFilled:
+			if leg.ClosePrice != nil && *leg.ClosePrice < leg.HedgeOpenPrice {
+				return fmt.Sprintf("нога %d закрыта в убыток по %.8f", i+1, *leg.ClosePrice)
+			}
+			return fmt.Sprintf("тейк-профит ноги %d исполнен - цель группы достигнута", i+1)
+		case entities.OrderStatusClosedManual:
+			return fmt.Sprintf("нога %d закрыта вручную", i+1)
+		}
+	}
+
+	if group.TakeProfitPrice > 0 && trade.CurrentRate >= group.TakeProfitPrice {
+		return fmt.Sprintf("цена %.8f достигла тейк-профита группы %.8f", trade.CurrentRate, group.TakeProfitPrice)
+	}
+	return ""
+}
+
+// hasBuyPendingLeg проверяет, что покупка какой-то ноги еще ожидает исполнения (тейк-профит не выставлен)
+func hasBuyPendingLeg(legs []*entities.HedgedTrade) bool {
+	for _, leg := range legs {
+		if leg.OrderStatus == entities.OrderStatusBuyPending {
+			return true
+		}
+	}
+	return false
+}
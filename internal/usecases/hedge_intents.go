@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// createHedgeIntent сохраняет намерение хеджирования (состояние INTENT) до размещения ордера на покупку.
+// Клиентский ID детерминирован: сделка, номер хеджа и номер попытки
+func (h *HedgeStrategyUseCase) createHedgeIntent(
+	ctx context.Context,
+	trade *entities.Trade,
+	tranche int,
+	quantity, price float64,
+) (*entities.HedgeIntent, error) {
+	attempt, err := h.intentRepo.CountHedgeIntents(ctx, trade.ID, tranche)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	intent := &entities.HedgeIntent{
+		ClientOrderID:    entities.BuildClientOrderID(trade.ID, tranche, attempt),
+		FreqtradeTradeID: trade.ID,
+		Pair:             trade.Pair,
+		Tranche:          tranche,
+		Attempt:          attempt,
+		Quantity:         quantity,
+		Price:            price,
+		State:            entities.HedgeStateIntent,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+
+		FreqtradeOpenPrice:   trade.OpenRate,
+		FreqtradeAmount:      trade.Amount,
+		FreqtradeProfitRatio: trade.ProfitRatio,
+		CurrentRate:          trade.CurrentRate,
+	}
+
+	if err := h.intentRepo.SaveHedgeIntent(ctx, intent); err != nil {
+		return nil, err
+	}
+
+	return intent, nil
+}
+
+// advanceHedgeIntent переводит хедж в следующее состояние и сохраняет его; ошибка только логируется,
+// т.к. хедж в незавершенном состоянии будет продолжен восстановлением в следующем цикле
+func (h *HedgeStrategyUseCase) advanceHedgeIntent(ctx context.Context, intent *entities.HedgeIntent, next entities.HedgeState) {
+	advanceHedgeIntent(ctx, h.intentRepo, intent, next)
+}
+
+// closeHedgeIntentByOrderID закрывает хедж, связанный с ордером, если он еще не закрыт
+func (h *HedgeStrategyUseCase) closeHedgeIntentByOrderID(ctx context.Context, orderID string) {
+	closeHedgeIntentByOrderID(ctx, h.intentRepo, orderID)
+}
+
+// inFlightTradeIDs возвращает ID сделок, хеджи которых еще не защищены тейк-профитом.
+// Такие сделки нельзя хеджировать повторно, пока восстановление не завершит текущий хедж
+func (h *HedgeStrategyUseCase) inFlightTradeIDs(ctx context.Context) (map[int]bool, error) {
+	intents, err := h.intentRepo.GetInFlightHedgeIntents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[int]bool, len(intents))
+	for _, intent := range intents {
+		ids[intent.FreqtradeTradeID] = true
+	}
+	return ids, nil
+}
+
+// advanceHedgeIntent переводит хедж в следующее состояние и сохраняет его
+func advanceHedgeIntent(ctx context.Context, intentRepo repositories.HedgeIntentRepository, intent *entities.HedgeIntent, next entities.HedgeState) {
+	previous := intent.State
+	if err := intent.TransitionTo(next); err != nil {
+		logger.LogWithTime("⚠️ %v", err)
+		return
+	}
+	if err := intentRepo.UpdateHedgeIntent(ctx, intent); err != nil {
+		logger.LogWithTime("⚠️ Не удалось сохранить состояние хеджа %s (%s → %s): %v",
+			intent.ClientOrderID, previous, next, err)
+	}
+}
+
+// closeHedgeIntentByOrderID закрывает хедж, связанный с ордером на покупку или тейк-профитом
+func closeHedgeIntentByOrderID(ctx context.Context, intentRepo repositories.HedgeIntentRepository, orderID string) {
+	intent, err := intentRepo.GetHedgeIntentByOrderID(ctx, orderID)
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось найти хедж по ордеру %s: %v", orderID, err)
+		return
+	}
+	if intent == nil || intent.State == entities.HedgeStateClosed {
+		return
+	}
+	advanceHedgeIntent(ctx, intentRepo, intent, entities.HedgeStateClosed)
+}
@@ -0,0 +1,213 @@
+package usecases
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+)
+
+// thresholdCrossing первое наблюдение сделки за порогом просадки
+type thresholdCrossing struct {
+	pair    string    // Пара сделки в момент наблюдения (до перевода на рынок с базовой валютой)
+	at      time.Time // Начало цикла, в котором пересечение замечено впервые
+	price   float64   // Цена сделки в этом цикле
+	claimed bool      // Пересечение уже записано в хедж: следующие ступени его не получают
+}
+
+// thresholdTracker запоминает, когда сделки впервые оказались за порогом просадки, для метрик задержки.
+// Момент пересечения известен с точностью до интервала планировщика: это начало первого цикла,
+// в котором просадка превысила порог
+type thresholdTracker struct {
+	mu        sync.Mutex
+	crossings map[int]*thresholdCrossing // По ID сделки Freqtrade
+}
+
+// newThresholdTracker создает пустой трекер пересечений
+func newThresholdTracker() *thresholdTracker {
+	return &thresholdTracker{crossings: make(map[int]*thresholdCrossing)}
+}
+
+// Observe обновляет пересечения по активным сделкам цикла: новые пересечения запоминаются,
+// сделки, вернувшиеся выше порога или закрытые, забываются (повторное пересечение считается заново)
+func (t *thresholdTracker) Observe(trades []*entities.Trade, maxLossPercent float64, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	crossed := make(map[int]bool, len(trades))
+	for _, trade := range trades {
+		if !trade.ShouldBeHedged(maxLossPercent) {
+			continue
+		}
+		crossed[trade.ID] = true
+		if _, ok := t.crossings[trade.ID]; !ok {
+			t.crossings[trade.ID] = &thresholdCrossing{pair: trade.Pair, at: now, price: trade.CurrentRate}
+		}
+	}
+
+	for tradeID := range t.crossings {
+		if !crossed[tradeID] {
+			delete(t.crossings, tradeID)
+		}
+	}
+}
+
+// Claim записывает пересечение порога в первый хедж сделки. Для следующих ступеней лестницы
+// пересечение не записывается: их порог другой, и задержка от первого пересечения ничего не говорит.
+// Цена не записывается, если хедж открыт на другом рынке (перевод котируемой валюты)
+func (t *thresholdTracker) Claim(hedgedTrade *entities.HedgedTrade) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	crossing, ok := t.crossings[hedgedTrade.FreqtradeTradeID]
+	if !ok || crossing.claimed {
+		return
+	}
+	crossing.claimed = true
+
+	at := crossing.at
+	hedgedTrade.ThresholdCrossedAt = &at
+	if crossing.pair == hedgedTrade.Pair {
+		hedgedTrade.ThresholdCrossedPrice = crossing.price
+	}
+}
+
+// copyHedgeTiming переносит моменты этапов, известные до исполнения покупки, из ожидающего хеджа
+func copyHedgeTiming(from, to *entities.HedgedTrade) {
+	to.ThresholdCrossedAt = from.ThresholdCrossedAt
+	to.ThresholdCrossedPrice = from.ThresholdCrossedPrice
+	to.BuyPlacedAt = from.BuyPlacedAt
+}
+
+// buyFillTime возвращает время исполнения покупки по данным биржи, иначе момент обнаружения исполнения
+func buyFillTime(status *services.OrderStatusInfo, detectedAt time.Time) *time.Time {
+	if status != nil && status.FilledTime != nil {
+		filled := *status.FilledTime
+		return &filled
+	}
+	return &detectedAt
+}
+
+// Distribution распределение значений метрики
+type Distribution struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min"`
+	Avg   float64 `json:"avg"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P95   float64 `json:"p95"`
+	Max   float64 `json:"max"`
+}
+
+// newDistribution строит распределение значений (перцентили - по ближайшему рангу)
+func newDistribution(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, value := range sorted {
+		sum += value
+	}
+	rank := func(p float64) float64 {
+		index := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if index < 0 {
+			index = 0
+		}
+		return sorted[index]
+	}
+
+	return Distribution{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Avg:   sum / float64(len(sorted)),
+		P50:   rank(0.50),
+		P90:   rank(0.90),
+		P95:   rank(0.95),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// HedgeLatency распределения задержек хеджирования
+type HedgeLatency struct {
+	EntrySeconds      Distribution `json:"entry_seconds"`       // Пересечение порога -> ордер на покупку, с
+	ProtectionSeconds Distribution `json:"protection_seconds"`  // Исполнение покупки -> тейк-профит, с
+	EntryDriftPercent Distribution `json:"entry_drift_percent"` // Изменение цены от пересечения порога до открытия хеджа, %
+}
+
+// PairHedgeLatency задержки хеджирования по паре
+type PairHedgeLatency struct {
+	Pair string `json:"pair"`
+	HedgeLatency
+}
+
+// HedgeLatencyReport отчет о задержках хеджирования: сколько стоят интервал планировщика
+// и ожидание исполнения покупки в цене входа
+type HedgeLatencyReport struct {
+	Since  time.Time `json:"since"`
+	Hedges int       `json:"hedges"` // Хеджей за период (включая хеджи без известных моментов этапов)
+	HedgeLatency
+	Pairs []PairHedgeLatency `json:"pairs"`
+}
+
+// latencySamples значения метрик задержки группы хеджей
+type latencySamples struct {
+	entry, protection, drift []float64
+}
+
+// add добавляет известные метрики хеджа
+func (s *latencySamples) add(hedge *entities.HedgedTrade) {
+	if latency, ok := hedge.EntryLatency(); ok {
+		s.entry = append(s.entry, latency.Seconds())
+	}
+	if latency, ok := hedge.ProtectionLatency(); ok {
+		s.protection = append(s.protection, latency.Seconds())
+	}
+	if drift, ok := hedge.EntryPriceDrift(); ok {
+		s.drift = append(s.drift, drift)
+	}
+}
+
+// latency строит распределения группы
+func (s *latencySamples) latency() HedgeLatency {
+	return HedgeLatency{
+		EntrySeconds:      newDistribution(s.entry),
+		ProtectionSeconds: newDistribution(s.protection),
+		EntryDriftPercent: newDistribution(s.drift),
+	}
+}
+
+// BuildHedgeLatencyReport строит распределения задержек по хеджам периода, в целом и по парам
+func BuildHedgeLatencyReport(hedges []*entities.HedgedTrade, since time.Time) *HedgeLatencyReport {
+	total := &latencySamples{}
+	byPair := make(map[string]*latencySamples)
+	for _, hedge := range hedges {
+		total.add(hedge)
+		samples, ok := byPair[hedge.Pair]
+		if !ok {
+			samples = &latencySamples{}
+			byPair[hedge.Pair] = samples
+		}
+		samples.add(hedge)
+	}
+
+	report := &HedgeLatencyReport{
+		Since:        since,
+		Hedges:       len(hedges),
+		HedgeLatency: total.latency(),
+		Pairs:        make([]PairHedgeLatency, 0, len(byPair)),
+	}
+	for pair, samples := range byPair {
+		report.Pairs = append(report.Pairs, PairHedgeLatency{Pair: pair, HedgeLatency: samples.latency()})
+	}
+	sort.Slice(report.Pairs, func(i, j int) bool {
+		return report.Pairs[i].Pair < report.Pairs[j].Pair
+	})
+	return report
+}
@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// HedgeOutcomeUseCase сопоставляет хеджи с итогами сделок Freqtrade,
+// чтобы оценить, улучшило ли хеджирование общий результат
+type HedgeOutcomeUseCase struct {
+	hedgeRepo    repositories.HedgeRepository
+	outcomeRepo  repositories.HedgeOutcomeRepository
+	tradeService services.TradeService
+}
+
+// NewHedgeOutcomeUseCase создает новый use case расчета эффективности хеджирования
+func NewHedgeOutcomeUseCase(
+	hedgeRepo repositories.HedgeRepository,
+	outcomeRepo repositories.HedgeOutcomeRepository,
+	tradeService services.TradeService,
+) *HedgeOutcomeUseCase {
+	return &HedgeOutcomeUseCase{
+		hedgeRepo:    hedgeRepo,
+		outcomeRepo:  outcomeRepo,
+		tradeService: tradeService,
+	}
+}
+
+// ReconcileOutcomes рассчитывает итоги для закрытых в Freqtrade сделок, все хеджи которых завершены.
+// Возвращает количество новых итогов
+func (h *HedgeOutcomeUseCase) ReconcileOutcomes(ctx context.Context) (int, error) {
+	hedges, err := h.hedgeRepo.GetHedgedTrades(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения хеджированных сделок: %w", err)
+	}
+
+	outcomes, err := h.outcomeRepo.GetHedgeOutcomes(ctx)
+	if err != nil {
+		return 0, err
+	}
+	calculated := make(map[int]bool, len(outcomes))
+	for _, outcome := range outcomes {
+		calculated[outcome.FreqtradeTradeID] = true
+	}
+
+	// Группируем хеджи по сделкам без итога; сделки с активными хеджами откладываем
+	pending := make(map[int][]*entities.HedgedTrade)
+	hasActive := make(map[int]bool)
+	for _, hedge := range hedges {
+		if calculated[hedge.FreqtradeTradeID] {
+			continue
+		}
+		pending[hedge.FreqtradeTradeID] = append(pending[hedge.FreqtradeTradeID], hedge)
+		if hedge.IsActive() {
+			hasActive[hedge.FreqtradeTradeID] = true
+		}
+	}
+	if len(pending) == len(hasActive) {
+		return 0, nil
+	}
+
+	closedTrades, err := h.tradeService.GetClosedTrades(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения закрытых сделок Freqtrade: %w", err)
+	}
+
+	saved := 0
+	for _, trade := range closedTrades {
+		if _, ok := pending[trade.ID]; !ok || hasActive[trade.ID] {
+			continue
+		}
+
+		// История сделки включает хеджи, уже перенесенные в архив
+		tradeHedges, err := h.hedgeRepo.GetHedgeHistory(ctx, trade.ID)
+		if err != nil {
+			logger.LogWithTime("❌ Ошибка получения хеджей сделки %d: %v", trade.ID, err)
+			continue
+		}
+
+		outcome := entities.NewHedgeOutcome(trade, tradeHedges)
+		if err := h.outcomeRepo.SaveHedgeOutcome(ctx, outcome); err != nil {
+			logger.LogWithTime("❌ Ошибка сохранения итога сделки %d: %v", trade.ID, err)
+			continue
+		}
+		saved++
+
+		logger.LogWithTime("📐 Итог сделки %d (%s): Freqtrade %.4f, хеджи %.4f, итого %.4f",
+			outcome.FreqtradeTradeID, outcome.Pair, outcome.FreqtradeProfit, outcome.HedgeProfit, outcome.NetOutcome)
+	}
+
+	return saved, nil
+}
+
+// GetOutcomes возвращает рассчитанные итоги по сделкам и их агрегат
+func (h *HedgeOutcomeUseCase) GetOutcomes(ctx context.Context) ([]*entities.HedgeOutcome, entities.OutcomeSummary, error) {
+	outcomes, err := h.outcomeRepo.GetHedgeOutcomes(ctx)
+	if err != nil {
+		return nil, entities.OutcomeSummary{}, err
+	}
+	return outcomes, entities.SummarizeOutcomes(outcomes), nil
+}
@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// BalanceReservation учитывает средства, занятые хеджами в процессе размещения,
+// чтобы параллельные хеджи не потратили один и тот же баланс
+type BalanceReservation struct {
+	mu       sync.Mutex
+	reserved float64
+}
+
+// NewBalanceReservation создает пустой учет резервов
+func NewBalanceReservation() *BalanceReservation {
+	return &BalanceReservation{}
+}
+
+// TryReserve резервирует amount из доступного баланса за вычетом уже зарезервированных средств.
+// Возвращает функцию освобождения резерва, остаток с учетом резервов и признак успеха
+func (b *BalanceReservation) TryReserve(available, amount float64) (func(), float64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	free := available - b.reserved
+	if free < amount {
+		return nil, free, false
+	}
+
+	b.reserved += amount
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.reserved -= amount
+		})
+	}, free, true
+}
+
+// hedgeTradesParallel хеджирует до MaxParallelHedges сделок за цикл пулом воркеров.
+// Сделки выдаются воркерам в порядке стратегии; ошибки по отдельной паре не останавливают пул,
+// общий лимит риска и прочие ошибки прекращают выдачу новых сделок
+func (h *HedgeStrategyUseCase) hedgeTradesParallel(ctx context.Context, trades []*entities.Trade) error {
+	workers := h.config.MaxParallelHedges
+	if workers > len(trades) {
+		workers = len(trades)
+	}
+
+	logger.LogWithTime("🎯 Параллельное хеджирование: до %d сделок из %d (порядок стратегии %s)",
+		h.config.MaxParallelHedges, len(trades), h.strategy.Name())
+
+	jobs := make(chan *entities.Trade)
+	var (
+		mu        sync.Mutex
+		hedged    int
+		inFlight  int // Сделки, выданные воркерам и еще не завершенные
+		stopped   bool
+		lastError error
+		fatalErr  error
+	)
+	slots := sync.NewCond(&mu)
+
+	// acquire ждет места под лимитом MaxParallelHedges и занимает его до выдачи сделки: сделки в работе
+	// учитываются вместе с успешными, чтобы за цикл не было размещено больше хеджей, чем разрешено.
+	// Место освобождается, если сделку не удалось хеджировать. false - выдача прекращена
+	acquire := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for !stopped && hedged+inFlight >= h.config.MaxParallelHedges {
+			slots.Wait()
+		}
+		if stopped {
+			return false
+		}
+		inFlight++
+		return true
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for trade := range jobs {
+				// Выдача могла быть остановлена ошибкой другого воркера после того, как сделка была выдана
+				mu.Lock()
+				skip := stopped || hedged >= h.config.MaxParallelHedges
+				if skip {
+					inFlight--
+					slots.Broadcast()
+				}
+				mu.Unlock()
+				if skip {
+					continue
+				}
+
+				err := h.hedgeTrade(ctx, trade)
+				if err != nil {
+					h.decisions.RecordError(trade, err)
+				}
+
+				mu.Lock()
+				inFlight--
+				switch {
+				case err == nil:
+					logger.LogWithTime("✅ Успешно хеджировали пару %s", trade.Pair)
+					hedged++
+					if hedged >= h.config.MaxParallelHedges {
+						stopped = true
+					}
+				case isPairLevelHedgeError(err, trade.Pair):
+					lastError = err
+				default:
+					logger.LogWithTime("❌ Ошибка хеджирования пары %s: %v", trade.Pair, err)
+					h.notifyHedgeFailed(ctx, trade, err)
+					if fatalErr == nil {
+						fatalErr = err
+					}
+					stopped = true
+				}
+				slots.Broadcast()
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, trade := range trades {
+		if ctx.Err() != nil || !acquire() {
+			break
+		}
+		jobs <- trade
+	}
+	close(jobs)
+	wg.Wait()
+
+	if hedged > 0 {
+		if fatalErr != nil {
+			logger.LogWithTime("⚠️ Хеджировано %d сделок, остальные остановлены ошибкой: %v", hedged, fatalErr)
+		}
+		return nil
+	}
+	if fatalErr != nil {
+		return fatalErr
+	}
+	if lastError != nil {
+		logger.LogWithTime("⚠️ Все подходящие пары не удалось хеджировать")
+		return lastError
+	}
+
+	logger.LogWithTime("ℹ️ Обработано %d сделок, подходящих для хеджирования не найдено", len(trades))
+	return errors.NewNoLossyTradesError(h.config.MaxLossPercent)
+}
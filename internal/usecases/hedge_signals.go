@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// signalHistorySize количество последних сигналов, повтор которых отклоняется
+const signalHistorySize = 1000
+
+// signalIntake источники внешних сигналов хеджирования и ключи уже принятых сигналов
+type signalIntake struct {
+	sources   []services.SignalSource
+	maxAmount float64 // Максимальная сумма сигнала (0 - без ограничения)
+
+	mu   sync.Mutex
+	seen map[string]bool
+	keys []string // Ключи в порядке приема: старые вытесняются после signalHistorySize
+}
+
+// accept запоминает ключ сигнала и проверяет, что сигнал с таким ключом еще не принимался
+func (s *signalIntake) accept(key string) bool {
+	if key == "" {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen[key] {
+		return false
+	}
+	s.seen[key] = true
+	s.keys = append(s.keys, key)
+	if len(s.keys) > signalHistorySize {
+		delete(s.seen, s.keys[0])
+		s.keys = s.keys[1:]
+	}
+	return true
+}
+
+// WithSignalSources включает внешние сигналы хеджирования: в начале каждого цикла сигналы забираются
+// из источников и хеджируются на указанную в них сумму через те же фильтры, лимиты риска и сохранение,
+// что и сделки Freqtrade. maxAmount ограничивает сумму одного сигнала (0 - без ограничения)
+func (h *HedgeStrategyUseCase) WithSignalSources(maxAmount float64, sources ...services.SignalSource) *HedgeStrategyUseCase {
+	if len(sources) == 0 {
+		return h
+	}
+	h.signals = &signalIntake{
+		sources:   sources,
+		maxAmount: maxAmount,
+		seen:      make(map[string]bool),
+	}
+	return h
+}
+
+// processSignals забирает и исполняет сигналы всех источников. Ошибка одного сигнала или источника
+// не прерывает цикл: сделки Freqtrade обрабатываются как обычно
+func (h *HedgeStrategyUseCase) processSignals(ctx context.Context) {
+	if h.signals == nil {
+		return
+	}
+	defer h.decisions.Flush(ctx)
+
+	for _, source := range h.signals.sources {
+		signals, err := source.Receive(ctx)
+		if err != nil {
+			logger.LogWithTime("⚠️ Ошибка получения сигналов (%s): %v", source.Name(), err)
+		}
+		if len(signals) > 0 {
+			logger.LogWithTime("📨 Получено сигналов хеджирования (%s): %d", source.Name(), len(signals))
+		}
+
+		for _, signal := range signals {
+			if err := h.processSignal(ctx, signal); err != nil {
+				logger.LogWithTime("❌ Сигнал %s %q (%s на %.2f) не исполнен: %v",
+					signal.Source, signal.ID, signal.Pair, signal.Amount, err)
+			}
+		}
+	}
+}
+
+// processSignal проверяет сигнал и хеджирует пару на сумму сигнала
+func (h *HedgeStrategyUseCase) processSignal(ctx context.Context, signal *entities.HedgeSignal) error {
+	if err := signal.Normalize(); err != nil {
+		return err
+	}
+	if h.signals.maxAmount > 0 && signal.Amount > h.signals.maxAmount {
+		return fmt.Errorf("сумма %.2f больше signals.max_amount %.2f", signal.Amount, h.signals.maxAmount)
+	}
+	if !h.signals.accept(signal.Key()) {
+		return fmt.Errorf("сигнал с таким ID уже принят")
+	}
+
+	trade, err := h.signalTrade(ctx, signal)
+	if err != nil {
+		return err
+	}
+	logger.LogWithTime("📨 Сигнал %s %q: хеджирование %s на %.2f по цене %.8f (%s)",
+		signal.Source, signal.ID, trade.Pair, signal.Amount, trade.CurrentRate, signal.Reason)
+
+	if h.config.DryRun {
+		logger.LogWithTime("🧪 [dry-run] Сигнал хеджировал бы %s на %.2f", trade.Pair, signal.Amount)
+		return nil
+	}
+
+	// Переводим пару на рынок с базовой валютой кошелька, если котируемая валюта отличается
+	converted, err := h.convertTradeQuote(ctx, trade)
+	if err != nil {
+		h.decisions.RecordError(trade, err)
+		return err
+	}
+	_, previousHedges, err := h.hedgeHistoryState(ctx, converted)
+	if err != nil {
+		return err
+	}
+	if err := h.hedgeTradeAmount(ctx, converted, previousHedges, signal.Amount); err != nil {
+		h.decisions.RecordError(trade, err)
+		return err
+	}
+
+	logger.LogWithTime("✅ Сигнал %s %q исполнен: хедж %s на %.2f", signal.Source, signal.ID, converted.Pair, signal.Amount)
+	return nil
+}
+
+// signalTrade представляет сигнал виртуальной сделкой пары по текущей цене: просадка сигнала
+// (по умолчанию порог max_loss_percent) определяет тейк-профит так же, как у сделки Freqtrade
+func (h *HedgeStrategyUseCase) signalTrade(ctx context.Context, signal *entities.HedgeSignal) (*entities.Trade, error) {
+	symbol := valueobjects.NewTradingPair(signal.Pair).ToBybitFormat()
+	price, err := h.exchangeService.GetTickerPrice(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения цены %s: %w", symbol, err)
+	}
+	if price <= 0 {
+		return nil, fmt.Errorf("нет цены %s", symbol)
+	}
+
+	drawdown := signal.Drawdown
+	if drawdown == 0 {
+		drawdown = h.config.MaxLossPercent
+	}
+
+	openTime := signal.ReceivedAt
+	if openTime.IsZero() {
+		openTime = time.Now()
+	}
+	return &entities.Trade{
+		ID:          entities.SignalTradeID(signal.Pair),
+		Pair:        signal.Pair,
+		IsOpen:      true,
+		ProfitRatio: -drawdown / 100,
+		CurrentRate: price,
+		OpenRate:    price,
+		Amount:      signal.Amount / price,
+		OpenTime:    openTime,
+	}, nil
+}
@@ -3,7 +3,6 @@ package usecases
 import (
 	"context"
 	"fmt"
-	"math"
 	"time"
 	"trade-hedge/internal/domain/entities"
 	"trade-hedge/internal/domain/errors"
@@ -11,6 +10,7 @@ import (
 	"trade-hedge/internal/domain/services"
 	"trade-hedge/internal/domain/valueobjects"
 	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/pkg/tracing"
 )
 
 // HedgeStrategyConfig конфигурация стратегии хеджирования
@@ -21,30 +21,191 @@ type HedgeStrategyConfig struct {
 	BaseCurrency   string // Базовая валюта для покупки (например, USDT)
 	RetryAttempts  int    // Количество попыток размещения ордера
 	RetryDelay     int    // Задержка между попытками в секундах
+
+	// EarlyStatusChecks задержки внеочередных проверок статуса тейк-профита после размещения
+	EarlyStatusChecks []time.Duration
+
+	BuyFillTimeout      time.Duration // Максимальное время ожидания исполнения ордера на покупку
+	BuyFillPollInterval time.Duration // Интервал опроса статуса ордера на покупку
+	LeaveBuyPending     bool          // Не ждать исполнения дольше таймаута, а подхватить покупку в следующем цикле
+
+	StrategyName         string  // Название стратегии хеджирования (classic, martingale-ladder)
+	MartingaleMultiplier float64 // Множитель суммы для каждой следующей ступени (martingale-ladder)
+	MartingaleMaxSteps   int     // Максимальное количество ступеней (martingale-ladder)
+
+	MinTakeProfitTicks   int     // Минимальное расстояние тейк-профита от цены покупки в шагах цены
+	MinTakeProfitPercent float64 // Минимальное расстояние тейк-профита от цены покупки в процентах
+
+	MaxPriceDeviationPercent float64 // Максимальное отклонение цены Freqtrade от цены биржи перед покупкой (0 - без проверки)
+	BuyPriceOffsetPercent    float64 // Надбавка к текущей цене для лимитного ордера на покупку в процентах
+	SlippageBufferPercent    float64 // Запас баланса на проскальзывание при проверке средств в процентах
+
+	RespectPairLocks bool // Не хеджировать пары, заблокированные Freqtrade
+	QuoteConversion  bool // Хеджировать пары с другой котируемой валютой через рынок к BaseCurrency
+
+	MaxParallelHedges int // Сколько сделок хеджировать за цикл параллельно (1 - одна сделка за цикл)
+
+	MinLosingTrades  int     // Хеджировать только при количестве открытых убыточных сделок больше N (0 - без условия)
+	MinPortfolioLoss float64 // Хеджировать только при суммарном нереализованном убытке больше суммы (0 - без условия)
+
+	BuyFallback string // Действие при неисполнении лимитной покупки за BuyFillTimeout (none, market)
+
+	// PassiveEntryTimeout сколько ждать исполнения мейкерской покупки по лучшей цене покупки стакана,
+	// прежде чем докупить остаток по рынку (0 - покупка обычным лимитным ордером)
+	PassiveEntryTimeout time.Duration
+
+	ConvertPairs []string // Пары, покупаемые конвертацией по твердой котировке вместо лимитного ордера
+
+	Priority            string   // Политика приоритизации отобранных сделок (drawdown, notional, loss, age, pairs)
+	PriorityPairs       []string // Порядок пар для политики pairs
+	PriorityTiebreakers []string // Дополнительные ключи сортировки при равенстве основного (drawdown, notional, loss, age)
+
+	StopLossPercent float64 // Стоп-лосс ниже цены покупки в процентах, связанный с тейк-профитом как OCO (0 - без стоп-лосса)
+
+	BuyPriceRounding  string // Политика округления цены покупки (floor, ceil, nearest, bankers)
+	SellPriceRounding string // Политика округления цены тейк-профита
+	QuantityRounding  string // Политика округления количества в ордере на покупку
+
+	DryRun bool // Не размещать ордера (режимы monitor-only и dry-run): только показывать, что было бы сделано
+
+	Risk RiskLimits // Лимиты риска, проверяемые перед каждым хеджированием
+
+	Filters              []string            // Порядок фильтров перед хеджированием (пусто - DefaultPreTradeFilters); не указанные фильтры отключены
+	FiltersByStrategy    map[string][]string // Порядок фильтров для отдельных стратегий (по StrategyName), переопределяет Filters
+	BlacklistPairs       []string            // Пары, которые не хеджируются (фильтр blacklist)
+	MaxSpreadPercent     float64             // Максимальный спред стакана в процентах (фильтр spread, 0 - без проверки)
+	MaxVolatilityPercent float64             // Максимальный размах цены за окно в процентах (фильтр volatility, 0 - без проверки)
+	VolatilityWindow     time.Duration       // Окно расчета размаха цены (фильтр volatility)
+
+	// Хедж крупной сделки ногами (нужен WithHedgeGroupRepository)
+	SplitMaxLegs           int           // Максимум ног; сумма сверх этого не хеджируется (1 - не разбивать)
+	SplitMaxLegAmount      float64       // Максимальная сумма одной ноги (0 - только лимит инструмента)
+	SplitLegInterval       time.Duration // Интервал между ногами
+	SplitDepthPercent      float64       // Глубина стакана в процентах от лучшей цены продажи (0 - не учитывается)
+	SplitDepthSharePercent float64       // Доля глубины стакана на одну ногу, %
 }
 
 // HedgeStrategyUseCase реализует сценарий хеджирования убытков
 type HedgeStrategyUseCase struct {
 	tradeService    services.TradeService
 	hedgeRepo       repositories.HedgeRepository
+	intentRepo      repositories.HedgeIntentRepository
 	exchangeService services.ExchangeService
-	config          *HedgeStrategyConfig
+	statusChecker   *StatusCheckerUseCase
+	fillWaiter      *OrderFillWaiter
+	passiveWaiter   *OrderFillWaiter     // Ожидание мейкерской покупки (PassiveEntryTimeout)
+	passiveEntries  *passiveEntryTracker // Итоги мейкерских попыток покупки
+	strategy        HedgeStrategy
+	riskManager     *RiskManager
+	recovery        *RecoveryUseCase
+	evaluationRepo  repositories.EvaluationRepository // История наблюдений за сделками для аналитики (nil - не сохраняется)
+	events          *OrderEventRecorder               // История событий ордеров (nil - не сохраняется)
+	featureFlags    string                            // Флаги поведения, которыми помечаются новые хеджи
+	rounding        roundingPolicies                  // Политики округления цен и количества до шагов биржи
+	thresholds      *thresholdTracker                 // Первые пересечения порога просадки для метрик задержки
+	preTrade        *PreTradePipeline                 // Фильтры перед размещением ордера на покупку
+	snapshots       *MarketSnapshotUseCase            // Снимок балансов и цен для веб-интерфейса (nil - не ведется)
+	decisions       *hedgeDecisionRecorder            // Решения не хеджировать сделки (nil - не сохраняются)
+	settings        *SettingsUseCase                  // Параметры, измененные во время работы (nil - только файл конфигурации)
+	flags           *FeatureFlagsUseCase              // Флаги рискованных возможностей (nil - значения по умолчанию)
+	transactions    repositories.UnitOfWork           // Транзакции для атомарного сохранения хеджа (nil - записи по отдельности)
+	signals         *signalIntake                     // Внешние сигналы хеджирования (nil - только сделки Freqtrade)
+	notifier        services.Notifier                 // Оповещения об открытых хеджах и ошибках хеджирования (nil - не отправляются)
+	groups          *hedgeGroupTracker                // Хедж-группы крупных сделок (nil - хедж всегда одним ордером)
+
+	balanceReservation *BalanceReservation // Средства, занятые хеджами в процессе размещения
+	config             *HedgeStrategyConfig
 }
 
 // NewHedgeStrategyUseCase создает новый экземпляр use case
 func NewHedgeStrategyUseCase(
 	tradeService services.TradeService,
 	hedgeRepo repositories.HedgeRepository,
+	intentRepo repositories.HedgeIntentRepository,
 	exchangeService services.ExchangeService,
+	statusChecker *StatusCheckerUseCase,
 	config *HedgeStrategyConfig,
 ) *HedgeStrategyUseCase {
 
-	return &HedgeStrategyUseCase{
+	h := &HedgeStrategyUseCase{
 		tradeService:    tradeService,
 		hedgeRepo:       hedgeRepo,
+		intentRepo:      intentRepo,
 		exchangeService: exchangeService,
-		config:          config,
+		statusChecker:   statusChecker,
+		fillWaiter:      NewOrderFillWaiter(exchangeService, config.BuyFillTimeout, config.BuyFillPollInterval),
+		passiveWaiter:   NewOrderFillWaiter(exchangeService, config.PassiveEntryTimeout, config.BuyFillPollInterval),
+		passiveEntries:  &passiveEntryTracker{},
+		strategy:        NewHedgeStrategy(config),
+		riskManager:     NewRiskManager(hedgeRepo, config.Risk),
+		featureFlags:    FeatureFlags(config),
+		rounding:        newRoundingPolicies(config),
+		thresholds:      newThresholdTracker(),
+
+		balanceReservation: NewBalanceReservation(),
+		config:             config,
 	}
+	h.recovery = NewRecoveryUseCase(h)
+	h.preTrade = newPreTradePipeline(h)
+
+	return h
+}
+
+// WithEvaluationRepository включает сохранение просадок активных сделок в каждом цикле (для тепловой карты)
+func (h *HedgeStrategyUseCase) WithEvaluationRepository(repo repositories.EvaluationRepository) *HedgeStrategyUseCase {
+	h.evaluationRepo = repo
+	return h
+}
+
+// WithOrderEventRepository включает запись истории событий ордеров хеджей
+func (h *HedgeStrategyUseCase) WithOrderEventRepository(repo repositories.OrderEventRepository) *HedgeStrategyUseCase {
+	h.events = NewOrderEventRecorder(repo)
+	return h
+}
+
+// WithMarketSnapshots включает обновление снимка балансов и цен в каждом успешном цикле
+func (h *HedgeStrategyUseCase) WithMarketSnapshots(snapshots *MarketSnapshotUseCase) *HedgeStrategyUseCase {
+	h.snapshots = snapshots
+	return h
+}
+
+// WithHedgeDecisionRepository включает сохранение причин, по которым сделки не хеджировались в цикле
+func (h *HedgeStrategyUseCase) WithHedgeDecisionRepository(repo repositories.HedgeDecisionRepository) *HedgeStrategyUseCase {
+	h.decisions = newHedgeDecisionRecorder(repo)
+	return h
+}
+
+// WithSettings подключает параметры, измененные во время работы: они применяются в начале каждого цикла
+func (h *HedgeStrategyUseCase) WithSettings(settings *SettingsUseCase) *HedgeStrategyUseCase {
+	h.settings = settings
+	if settings != nil {
+		settings.ApplyTo(h.config)
+	}
+	return h
+}
+
+// WithFeatureFlags подключает флаги возможностей: отключенная возможность не используется с ближайшей операции
+func (h *HedgeStrategyUseCase) WithFeatureFlags(flags *FeatureFlagsUseCase) *HedgeStrategyUseCase {
+	h.flags = flags
+	return h
+}
+
+// WithUnitOfWork включает сохранение хеджа вместе с событиями его ордеров в одной транзакции
+func (h *HedgeStrategyUseCase) WithUnitOfWork(transactions repositories.UnitOfWork) *HedgeStrategyUseCase {
+	h.transactions = transactions
+	return h
+}
+
+// WithNotifier включает оповещения об открытых хеджах с расчетом риска и прибыли (hedge_opened)
+// и об ошибках хеджирования сделок (hedge_failed)
+func (h *HedgeStrategyUseCase) WithNotifier(notifier services.Notifier) *HedgeStrategyUseCase {
+	h.notifier = notifier
+	return h
+}
+
+// Recovery возвращает use case восстановления прерванных хеджей (для запуска при старте приложения)
+func (h *HedgeStrategyUseCase) Recovery() *RecoveryUseCase {
+	return h.recovery
 }
 
 // GetExchangeService возвращает сервис для работы с биржей
@@ -54,11 +215,68 @@ func (h *HedgeStrategyUseCase) GetExchangeService() services.ExchangeService {
 
 // ExecuteHedgeStrategy выполняет стратегию хеджирования
 func (h *HedgeStrategyUseCase) ExecuteHedgeStrategy(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "hedge.execute_strategy", tracing.String("strategy", h.strategy.Name()))
+	defer span.End()
+
+	err := h.executeHedgeStrategy(ctx)
+	if strategyErr, ok := err.(*errors.StrategyError); ok && strategyErr.IsExpected() {
+		// Нет сделок для хеджирования - штатный исход цикла, а не ошибка спана
+		span.SetAttributes(tracing.String("outcome", strategyErr.Message))
+		return err
+	}
+	span.RecordError(err)
+	return err
+}
+
+// executeHedgeStrategy выполняет стратегию хеджирования в спане ExecuteHedgeStrategy
+func (h *HedgeStrategyUseCase) executeHedgeStrategy(ctx context.Context) error {
+	if h.settings != nil {
+		h.settings.Expire(ctx)
+		h.settings.ApplyTo(h.config)
+	}
+
+	// 0. Продолжаем прерванные хеджи и подхватываем ордера на покупку, оставленные в предыдущих циклах
+	if !h.config.DryRun {
+		// Ноги хедж-групп, восстановленные и исполненные здесь, получают общий тейк-профит группы
+		h.groups.load(ctx)
+		// Без сверки с биржей нельзя размещать новые ордера: возможна повторная покупка
+		if err := h.recovery.RecoverInFlightHedges(ctx); err != nil {
+			return err
+		}
+		if err := h.resumePendingBuys(ctx); err != nil {
+			logger.LogWithTime("⚠️ Ошибка обработки отложенных покупок: %v", err)
+		}
+	}
+
+	// Внешние сигналы исполняются независимо от доступности Freqtrade
+	h.processSignals(ctx)
+
 	// 1. Получаем все активные сделки
+	cycleStart := time.Now()
 	trades, err := h.tradeService.GetActiveTrades(ctx)
 	if err != nil {
 		return fmt.Errorf("ошибка получения активных сделок: %w", err)
 	}
+	tracing.FromContext(ctx).SetAttributes(tracing.Int("trades", len(trades)))
+	h.recordEvaluations(ctx, trades)
+	h.refreshMarketSnapshot(ctx, trades)
+	h.thresholds.Observe(trades, h.config.MaxLossPercent, cycleStart)
+	defer h.decisions.Flush(ctx)
+
+	// Очередные ноги хедж-групп размещаются по расписанию независимо от отбора новых сделок
+	if !h.config.DryRun {
+		h.advanceHedgeGroups(ctx, trades)
+	}
+
+	// Хеджируем только при нагрузке на портфель, если условие задано
+	if err := h.checkPortfolioStress(trades); err != nil {
+		for _, trade := range trades {
+			if trade.ShouldBeHedged(h.config.MaxLossPercent) {
+				h.decisions.RecordError(trade, err)
+			}
+		}
+		return err
+	}
 
 	// 2. Фильтруем сделки, исключая только те, что имеют активные ордера в ожидании
 	unhedgedTrades, err := h.filterUnhedgedTrades(ctx, trades)
@@ -70,19 +288,50 @@ func (h *HedgeStrategyUseCase) ExecuteHedgeStrategy(ctx context.Context) error {
 		return errors.NewNoTradesError()
 	}
 
-	// 3. Сортируем сделки по максимальной просадке (от большей к меньшей)
-	entities.SortTradesByDrawdown(unhedgedTrades)
-	logger.LogWithTime("📊 Отсортировали %d сделок по просадке (от большей к меньшей)", len(unhedgedTrades))
+	// 3. Отбираем и упорядочиваем сделки согласно стратегии
+	selectedTrades := h.strategy.SelectTrades(unhedgedTrades)
+	h.recordSelectionDecisions(unhedgedTrades, selectedTrades)
+	logger.LogWithTime("📊 Стратегия %s отобрала %d из %d сделок", h.strategy.Name(), len(selectedTrades), len(unhedgedTrades))
 
-	// Логируем детали сортировки для всех сделок
-	logger.LogWithTime("📋 Детали сортировки сделок:")
-	for i, trade := range unhedgedTrades {
+	// Логируем детали отбора для всех сделок
+	logger.LogWithTime("📋 Порядок обработки сделок:")
+	for i, trade := range selectedTrades {
 		drawdownPercent := trade.ProfitRatio * -100
 		logger.LogWithTime("   %d. %s: просадка %.2f%%", i+1, trade.Pair, drawdownPercent)
 	}
 
+	if len(selectedTrades) == 0 {
+		logger.LogWithTime("ℹ️ Обработано %d сделок, подходящих для хеджирования не найдено", len(unhedgedTrades))
+		return errors.NewNoLossyTradesError(h.config.MaxLossPercent)
+	}
+
+	// В режимах без торговли только показываем, что было бы сделано
+	if h.config.DryRun {
+		return h.reportDryRun(ctx, selectedTrades)
+	}
+
 	// 4. Находим и пытаемся хеджировать подходящие сделки
-	return h.findAndHedgeTrade(ctx, unhedgedTrades)
+	return h.findAndHedgeTrade(ctx, selectedTrades)
+}
+
+// reportDryRun логирует сделки, которые были бы хеджированы, без размещения ордеров
+func (h *HedgeStrategyUseCase) reportDryRun(ctx context.Context, trades []*entities.Trade) error {
+	for _, trade := range trades {
+		_, previousHedges, err := h.hedgeHistoryState(ctx, trade)
+		if err != nil {
+			return err
+		}
+
+		positionAmount := h.strategy.SizePosition(trade, previousHedges)
+		if positionAmount <= 0 {
+			continue
+		}
+
+		logger.LogWithTime("🧪 [без торговли] Хеджировали бы %s: %.2f %s по цене %.8f, тейк-профит %.8f",
+			trade.Pair, positionAmount, h.config.BaseCurrency, h.strategy.PriceEntry(trade), h.strategy.PriceExit(trade))
+	}
+
+	return errors.NewDryRunError(len(trades))
 }
 
 // filterUnhedgedTrades фильтрует сделки, исключая только те, что имеют активные ордера в ожидании (PENDING)
@@ -90,61 +339,111 @@ func (h *HedgeStrategyUseCase) ExecuteHedgeStrategy(ctx context.Context) error {
 func (h *HedgeStrategyUseCase) filterUnhedgedTrades(ctx context.Context, trades []*entities.Trade) ([]*entities.Trade, error) {
 	var unhedged []*entities.Trade
 
+	inFlight, err := h.inFlightTradeIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения незавершенных хеджей: %w", err)
+	}
+	locks := h.activePairLocks(ctx)
+	now := time.Now()
+
 	for _, trade := range trades {
-		// Получаем историю хеджирования для сделки
-		hedgeHistory, err := h.hedgeRepo.GetHedgeHistory(ctx, trade.ID)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка получения истории хеджирования для сделки %d: %w", trade.ID, err)
+		// Хедж в процессе открытия (ожидает восстановления) - не покупаем повторно
+		if inFlight[trade.ID] {
+			logger.LogWithTime("♻️ Сделка %d (%s) имеет незавершенный хедж - пропускаем до его восстановления",
+				trade.ID, trade.Pair)
+			h.decisions.Record(trade, entities.DecisionInFlight, "Хедж в процессе открытия ожидает восстановления")
+			continue
 		}
 
-		// Если нет истории хеджирования - сделка подходит для хеджирования
-		if len(hedgeHistory) == 0 {
-			unhedged = append(unhedged, trade)
+		// Пара заблокирована Freqtrade - сделка скорее всего скоро разрешится, хедж удвоит риск
+		if lock := entities.FindActivePairLock(locks, trade.Pair, now); lock != nil {
+			logger.LogWithTime("🔒 Пара %s заблокирована Freqtrade до %s (%s) - пропускаем",
+				trade.Pair, lock.LockEnd.Format("15:04:05"), lock.Reason)
+			h.decisions.Record(trade, entities.DecisionPairLocked,
+				fmt.Sprintf("Пара заблокирована Freqtrade до %s: %s", lock.LockEnd.Format(time.RFC3339), lock.Reason))
 			continue
 		}
 
-		// Проверяем, есть ли активные ордера в ожидании
-		hasActiveOrders := false
-		for _, hedge := range hedgeHistory {
-			if hedge.OrderStatus == entities.OrderStatusPending {
-				hasActiveOrders = true
-				break
-			}
+		// Сделку хеджирует группа ног: следующая нога будет размещена по расписанию группы
+		if group := h.groups.forTrade(trade.ID); group != nil {
+			logger.LogWithTime("🧩 Сделка %d (%s) хеджируется группой %d (размещено ног: %d из %d) - пропускаем",
+				trade.ID, trade.Pair, group.ID, group.LegsPlaced, group.LegsPlanned)
+			h.decisions.Record(trade, entities.DecisionHedgeGroup,
+				fmt.Sprintf("Хедж-группа %d: размещено ног %d из %d", group.ID, group.LegsPlaced, group.LegsPlanned))
+			continue
+		}
+
+		hasActiveOrders, historyLen, err := h.hedgeHistoryState(ctx, trade)
+		if err != nil {
+			return nil, err
 		}
 
 		// Если есть активные ордера - пропускаем (ждем исполнения)
 		if hasActiveOrders {
 			logger.LogWithTime("⏳ Сделка %d (%s) имеет активный ордер в ожидании - пропускаем",
 				trade.ID, trade.Pair)
+			h.decisions.Record(trade, entities.DecisionActiveOrder, "Хедж-ордер в ожидании исполнения")
 			continue
 		}
 
-		// Если нет активных ордеров - сделка подходит для повторного хеджирования
-		logger.LogWithTime("🔄 Сделка %d (%s) имеет %d завершенных ордеров - можно хеджировать повторно",
-			trade.ID, trade.Pair, len(hedgeHistory))
+		// Если нет активных ордеров - сделка подходит для (повторного) хеджирования
+		if historyLen > 0 {
+			logger.LogWithTime("🔄 Сделка %d (%s) имеет %d завершенных ордеров - можно хеджировать повторно",
+				trade.ID, trade.Pair, historyLen)
+		}
 		unhedged = append(unhedged, trade)
 	}
 
 	return unhedged, nil
 }
 
+// activePairLocks получает блокировки пар из Freqtrade.
+// Ошибка получения не останавливает цикл: старые версии Freqtrade могут не поддерживать /locks
+func (h *HedgeStrategyUseCase) activePairLocks(ctx context.Context) []*entities.PairLock {
+	if !h.config.RespectPairLocks {
+		return nil
+	}
+
+	locks, err := h.tradeService.GetPairLocks(ctx)
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось получить блокировки пар Freqtrade: %v", err)
+		return nil
+	}
+	return locks
+}
+
+// hedgeHistoryState проверяет, есть ли у сделки незавершенный хедж (тейк-профит в ожидании, частично исполненный
+// или с нераспознанным статусом, отложенная покупка), и возвращает размер истории хеджирования
+func (h *HedgeStrategyUseCase) hedgeHistoryState(ctx context.Context, trade *entities.Trade) (bool, int, error) {
+	hedgeHistory, err := h.hedgeRepo.GetHedgeHistory(ctx, trade.ID)
+	if err != nil {
+		return false, 0, fmt.Errorf("ошибка получения истории хеджирования для сделки %d: %w", trade.ID, err)
+	}
+
+	for _, hedge := range hedgeHistory {
+		if hedge.IsActive() {
+			return true, len(hedgeHistory), nil
+		}
+	}
+
+	return false, len(hedgeHistory), nil
+}
+
 // findAndHedgeTrade находит и пытается хеджировать подходящую сделку
 func (h *HedgeStrategyUseCase) findAndHedgeTrade(ctx context.Context, trades []*entities.Trade) error {
+	if h.config.MaxParallelHedges > 1 {
+		return h.hedgeTradesParallel(ctx, trades)
+	}
+
 	var lastError error
 	var triedPairs []string
 
-	logger.LogWithTime("🎯 Начинаем поиск сделок для хеджирования (отсортированы по просадке)")
+	logger.LogWithTime("🎯 Начинаем поиск сделок для хеджирования (порядок стратегии %s)", h.strategy.Name())
 
 	// Пытаемся найти подходящую сделку для хеджирования
 	for i, trade := range trades {
 		drawdownPercent := trade.ProfitRatio * -100 // Конвертируем в проценты
 
-		if !trade.ShouldBeHedged(h.config.MaxLossPercent) {
-			logger.LogWithTime("⏭️ [%d/%d] Пропускаем пару %s (просадка: %.2f%% < порог %.2f%%)",
-				i+1, len(trades), trade.Pair, drawdownPercent, h.config.MaxLossPercent)
-			continue
-		}
-
 		pair := valueobjects.NewTradingPair(trade.Pair)
 		triedPairs = append(triedPairs, pair.String())
 
@@ -160,18 +459,15 @@ func (h *HedgeStrategyUseCase) findAndHedgeTrade(ctx context.Context, trades []*
 			return nil
 		}
 
-		// Проверяем тип ошибки
-		if strategyErr, ok := err.(*errors.StrategyError); ok {
-			if strategyErr.Type == errors.ErrorTypeInsufficientBalanceForMinLimit {
-				// Это ожидаемая ошибка - пара не подходит по минимальному лимиту
-				logger.LogWithTime("⚠️ Пара %s не подходит по минимальному лимиту, пробуем следующую...", pair.String())
-				lastError = err
-				continue // Продолжаем искать другие пары
-			}
+		h.decisions.RecordError(trade, err)
+		if isPairLevelHedgeError(err, pair.String()) {
+			lastError = err
+			continue // Продолжаем искать другие пары
 		}
 
-		// Другие ошибки - возвращаем их
+		// Общий лимит риска и другие ошибки - возвращаем их
 		logger.LogWithTime("❌ Ошибка хеджирования пары %s: %v", pair.String(), err)
+		h.notifyHedgeFailed(ctx, trade, err)
 		return err
 	}
 
@@ -181,123 +477,125 @@ func (h *HedgeStrategyUseCase) findAndHedgeTrade(ctx context.Context, trades []*
 		return lastError
 	}
 
-	// Нет подходящих сделок для хеджирования
+	// Стратегия отказалась от всех отобранных сделок (например, исчерпаны ступени)
 	logger.LogWithTime("ℹ️ Обработано %d сделок, подходящих для хеджирования не найдено", len(trades))
 	return errors.NewNoLossyTradesError(h.config.MaxLossPercent)
 }
 
-// hedgeTrade выполняет хеджирование конкретной сделки
-func (h *HedgeStrategyUseCase) hedgeTrade(ctx context.Context, trade *entities.Trade) error {
-	pair := valueobjects.NewTradingPair(trade.Pair)
-	symbol := pair.ToBybitFormat()
-
-	// 1. Проверяем баланс базовой валюты
-	balance, err := h.exchangeService.GetBalance(ctx, h.config.BaseCurrency)
-	if err != nil {
-		return fmt.Errorf("ошибка получения баланса %s: %w", h.config.BaseCurrency, err)
+// isPairLevelHedgeError проверяет, касается ли ошибка только конкретной пары (можно пробовать следующую)
+func isPairLevelHedgeError(err error, pair string) bool {
+	strategyErr, ok := err.(*errors.StrategyError)
+	if !ok {
+		return false
 	}
 
-	// Рассчитываем необходимую сумму для покупки с запасом на проскальзывание
-	requiredAmount := h.config.PositionAmount * 1.01 // +1% запас на проскальзывание
-
-	// Проверяем, достаточно ли баланса для указанной в настройках суммы позиции
-	// Если баланса недостаточно - пропускаем пару, НЕ корректируем размер позиции
-	if !balance.HasSufficientBalance(requiredAmount) {
-		logger.LogWithTime("⚠️ ВНИМАНИЕ: Недостаточно баланса для запрошенной позиции")
-		logger.LogWithTime("💡 Требуется: %.2f %s, доступно: %.2f %s",
-			requiredAmount, h.config.BaseCurrency, balance.Available, h.config.BaseCurrency)
-		logger.LogWithTime("💡 Пропускаем пару %s - недостаточно баланса для указанной суммы позиции", pair.String())
-		return errors.NewInsufficientBalanceError(requiredAmount, balance.Available, h.config.BaseCurrency)
+	switch strategyErr.Type {
+	case errors.ErrorTypeInsufficientBalanceForMinLimit:
+		// Это ожидаемая ошибка - пара не подходит по минимальному лимиту
+		logger.LogWithTime("⚠️ Пара %s не подходит по минимальному лимиту, пробуем следующую...", pair)
+		return true
+	case errors.ErrorTypeStrategySkipped,
+		errors.ErrorTypePairRiskLimitExceeded,
+		errors.ErrorTypeQuoteRiskLimitExceeded,
+		errors.ErrorTypePriceDeviation,
+		errors.ErrorTypeQuoteConversion,
+		errors.ErrorTypePreTradeFilter:
+		logger.LogWithTime("⚠️ %s, пробуем следующую...", strategyErr.Message)
+		return true
+	case errors.ErrorTypeRiskLimitExceeded:
+		// Общий лимит риска блокирует все пары - дальнейший перебор бессмысленен
+		logger.LogWithTime("🛑 %s", strategyErr.Message)
 	}
+	return false
+}
 
-	// Используем фиксированный размер позиции из настроек (без автоматической корректировки)
-	adjustedPositionAmount := h.config.PositionAmount
-
-	// Рассчитываем количество валюты для покупки на фиксированную сумму
-	orderQuantity := entities.CalculateQuantityFromAmount(adjustedPositionAmount, trade.CurrentRate)
-
-	// Получаем минимальный лимит ордера для конкретной пары от Bybit API
-	instrumentInfo, err := h.exchangeService.GetInstrumentInfo(ctx, symbol)
+// hedgeTrade выполняет хеджирование конкретной сделки в отдельном спане трассы цикла
+func (h *HedgeStrategyUseCase) hedgeTrade(ctx context.Context, trade *entities.Trade) (err error) {
+	ctx, span := tracing.Start(ctx, "hedge.trade",
+		tracing.String("pair", trade.Pair),
+		tracing.Int("freqtrade.trade_id", trade.ID),
+		tracing.Float64("profit_ratio", trade.ProfitRatio))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	// Переводим пару на рынок с базовой валютой кошелька, если котируемая валюта отличается
+	trade, err = h.convertTradeQuote(ctx, trade)
 	if err != nil {
-		logger.LogWithTime("⚠️ Не удалось получить информацию об инструменте %s: %v", symbol, err)
-		logger.LogWithTime("💡 Используем безопасное значение по умолчанию: 100 USDT")
-		// Используем безопасное значение по умолчанию
-		instrumentInfo = &services.InstrumentInfo{
-			MinOrderAmt: 100.0,
-		}
+		return err
 	}
 
-	// Проверяем корректность полученного минимального лимита
-	minOrderValue := instrumentInfo.MinOrderAmt
-	if minOrderValue <= 0 {
-		logger.LogWithTime("⚠️ ВНИМАНИЕ: Bybit вернул некорректный минимальный лимит: %.2f USDT", minOrderValue)
-		logger.LogWithTime("💡 Используем безопасное значение по умолчанию: 100 USDT")
-		minOrderValue = 100.0
-	}
+	pair := valueobjects.NewTradingPair(trade.Pair)
 
-	// Проверяем минимальное количество валюты
-	minOrderQty := instrumentInfo.MinOrderQty
-	if minOrderQty <= 0 {
-		logger.LogWithTime("⚠️ ВНИМАНИЕ: Bybit вернул некорректное минимальное количество: %.6f", minOrderQty)
-		logger.LogWithTime("💡 Используем безопасное значение по умолчанию: 0.001")
-		minOrderQty = 0.001
+	// Определяем сумму позиции согласно стратегии
+	_, previousHedges, err := h.hedgeHistoryState(ctx, trade)
+	if err != nil {
+		return err
 	}
-
-	// Округляем количество до правильной точности согласно basePrecision от Bybit
-	stepSize := instrumentInfo.StepSize
-	if stepSize > 0 {
-		// Округляем до ближайшего кратного stepSize
-		orderQuantity = math.Round(orderQuantity/stepSize) * stepSize
-		logger.LogWithTime("🔧 Количество скорректировано до шага %.6f: %.6f → %.6f", stepSize, entities.CalculateQuantityFromAmount(adjustedPositionAmount, trade.CurrentRate), orderQuantity)
+	positionAmount := h.strategy.SizePosition(trade, previousHedges)
+	if positionAmount <= 0 {
+		logger.LogWithTime("⏭️ Стратегия %s не выделила сумму для пары %s (предыдущих хеджей: %d)",
+			h.strategy.Name(), pair.String(), previousHedges)
+		return errors.NewStrategySkippedError(trade.Pair, h.strategy.Name())
 	}
 
-	orderValue := adjustedPositionAmount
-
-	// Проверяем минимальную сумму ордера
-	if orderValue < minOrderValue {
-		logger.LogWithTime("⚠️ ВНИМАНИЕ: Стоимость ордера %.2f %s меньше минимального лимита %.2f %s для пары %s",
-			orderValue, h.config.BaseCurrency, minOrderValue, h.config.BaseCurrency, pair.String())
-		logger.LogWithTime("💡 Минимальный лимит получен от Bybit API: %s", symbol)
-
-		logger.LogWithTime("💡 Пропускаем пару %s - размер позиции меньше минимального лимита", pair.String())
-		return errors.NewInsufficientBalanceForMinLimitError(minOrderValue, adjustedPositionAmount, h.config.BaseCurrency)
+	// Сумма больше допустимого ордера - хедж покупается несколькими ногами с интервалом
+	if group := h.planHedgeGroup(ctx, trade, positionAmount); group != nil {
+		return h.startHedgeGroup(ctx, group, trade, previousHedges)
 	}
 
-	// Проверяем минимальное количество валюты
-	if orderQuantity < minOrderQty {
-		logger.LogWithTime("⚠️ ВНИМАНИЕ: Количество валюты %.6f %s меньше минимального лимита %.6f для пары %s",
-			orderQuantity, pair.ToBybitFormat(), minOrderQty, pair.String())
-		logger.LogWithTime("💡 Минимальное количество получено от Bybit API: %s", symbol)
+	return h.hedgeTradeAmount(ctx, trade, previousHedges, positionAmount)
+}
 
-		logger.LogWithTime("💡 Пропускаем пару %s - количество меньше минимального лимита", pair.String())
-		return errors.NewInsufficientBalanceForMinLimitError(minOrderValue, adjustedPositionAmount, h.config.BaseCurrency)
-	}
+// hedgeTradeAmount хеджирует сделку на сумму позиции positionAmount (в пару уже переведена котируемая валюта)
+func (h *HedgeStrategyUseCase) hedgeTradeAmount(ctx context.Context, trade *entities.Trade, previousHedges int, positionAmount float64) error {
+	pair := valueobjects.NewTradingPair(trade.Pair)
+	symbol := pair.ToBybitFormat()
 
-	logger.LogWithTime("✅ Стоимость ордера %.2f %s соответствует минимальному лимиту %.2f %s",
-		orderValue, h.config.BaseCurrency, minOrderValue, h.config.BaseCurrency)
-	logger.LogWithTime("✅ Количество валюты %.6f %s соответствует минимальному лимиту %.6f",
-		orderQuantity, pair.ToBybitFormat(), minOrderQty)
+	// Проверяем сделку цепочкой фильтров: лимиты риска, баланс, лимиты инструмента и т.д.
+	check := NewPreTradeCheck(trade, positionAmount, h.config.BaseCurrency, h.exchangeService, h.rounding.quantity)
+	if _, err := h.preTrade.Run(ctx, check); err != nil {
+		return err
+	}
+	defer check.Release()
+
+	// Количество рассчитано на сумму позиции, определенную стратегией (без автоматической корректировки)
+	sizing := check.Sizing(ctx)
+	instrumentInfo := check.Instrument
+	rules := sizing.Rules
+	orderQuantity := sizing.Quantity.Float64()
+	stepSize := rules.StepSize
+	minOrderValue, minOrderQty := sizing.MinOrderValue, sizing.MinOrderQty
 	logger.LogWithTime("💡 Минимальные лимиты получены от Bybit API: %s", symbol)
 
-	logger.LogPlain("💰 Баланс %s: доступно %.4f, требуется %.4f\n",
-		h.config.BaseCurrency, balance.Available, requiredAmount)
+	if check.Balance != nil {
+		logger.LogPlain("💰 Баланс %s: доступно %.4f, требуется %.4f\n",
+			h.config.BaseCurrency, check.Balance.Available, check.RequiredAmount)
+	}
 	logger.LogPlain("📊 Исходная сделка Freqtrade: %.6f %s по цене %.4f (убыток %.2f%%)\n",
 		trade.Amount, pair.String(), trade.OpenRate, trade.ProfitRatio*100)
 	logger.LogPlain("🛒 Хеджирующая покупка: %.6f %s на сумму %.2f %s по цене %.4f\n",
-		orderQuantity, pair.ToBybitFormat(), adjustedPositionAmount, h.config.BaseCurrency, trade.CurrentRate)
+		orderQuantity, pair.ToBybitFormat(), positionAmount, h.config.BaseCurrency, trade.CurrentRate)
+
+	// Пары с тонким стаканом покупаются конвертацией по твердой котировке биржи
+	if h.executionMethod(trade.Pair) == ExecutionMethodConvert {
+		return h.hedgeViaConvert(ctx, trade, previousHedges, positionAmount, instrumentInfo)
+	}
 
 	// 2. Размещаем лимитный ордер на покупку с небольшим запасом по цене
 	// Используем лимитный ордер вместо рыночного для лучшего контроля над минимальными лимитами
-	limitPrice := trade.CurrentRate * 1.001 // +0.1% запас для гарантированного исполнения
+	entryPrice := h.strategy.PriceEntry(trade)
+	limitPrice := entryPrice
 
 	// Расчет цены для лимитного ордера
 
 	// Округляем цену до правильного шага согласно tickSize от Bybit
-	tickSize := instrumentInfo.TickSize
+	tickSize := rules.TickSize
 	if tickSize > 0 {
-		// Округляем до ближайшего кратного tickSize
-		limitPrice = math.Round(limitPrice/tickSize) * tickSize
-		logger.LogWithTime("🔧 Цена скорректирована до шага %.8f: %.8f → %.8f", tickSize, trade.CurrentRate*1.001, limitPrice)
+		price := valueobjects.NewPrice(limitPrice, rules, h.rounding.buyPrice.Mode())
+		limitPrice = price.Float64()
+		logger.LogWithTime("🔧 Цена скорректирована до шага %.8f (%s): %.8f → %s", tickSize, h.rounding.buyPrice.Name(), entryPrice, price)
 	}
 
 	// Объявляем переменную для ордера
@@ -308,15 +606,25 @@ func (h *HedgeStrategyUseCase) hedgeTrade(ctx context.Context, trade *entities.T
 	if limitPrice <= 0 || limitPrice < 0.0001 {
 		logger.LogWithTime("⚠️ ВНИМАНИЕ: Цена слишком маленькая (%.8f), используем лимитный ордер с текущей рыночной ценой", limitPrice)
 		// Для очень дешевых активов используем текущую рыночную цену с небольшим запасом
-		marketPrice := trade.CurrentRate * 1.001 // +0.1% запас для гарантированного исполнения
-		buyOrder = entities.NewLimitOrder(symbol, entities.OrderSideBuy, orderQuantity, marketPrice)
-		logger.LogWithTime("🎯 Лимитный ордер на покупку: %.6f %s по цене %.8f (текущая рыночная +0.1%%)", orderQuantity, pair.ToBybitFormat(), marketPrice)
+		buyOrder = entities.NewLimitOrder(symbol, entities.OrderSideBuy, orderQuantity, entryPrice).
+			WithInstrumentSteps(0, stepSize)
+		logger.LogWithTime("🎯 Лимитный ордер на покупку: %.6f %s по цене %.8f (без округления)", orderQuantity, pair.ToBybitFormat(), entryPrice)
 	} else {
-		buyOrder = entities.NewLimitOrder(symbol, entities.OrderSideBuy, orderQuantity, limitPrice)
-		logger.LogWithTime("🎯 Лимитный ордер на покупку: %.6f %s по цене %.8f (с запасом +0.1%%)",
+		buyOrder = entities.NewLimitOrder(symbol, entities.OrderSideBuy, orderQuantity, limitPrice).
+			WithInstrumentSteps(tickSize, stepSize)
+		logger.LogWithTime("🎯 Лимитный ордер на покупку: %.6f %s по цене %.8f",
 			orderQuantity, pair.ToBybitFormat(), limitPrice)
 	}
 
+	// Сначала пробуем купить мейкером по лучшей цене покупки: экономия на спреде и комиссии
+	passive := h.preparePassiveEntry(ctx, symbol, rules)
+	if passive != nil {
+		buyOrder.Price = passive.bid
+		buyOrder.WithTimeInForce(entities.TimeInForcePostOnly)
+		logger.LogWithTime("🪤 Мейкерская покупка: %.6f %s по лучшей цене покупки %.8f (продажа %.8f), ожидание до %v",
+			buyOrder.Quantity, pair.ToBybitFormat(), passive.bid, passive.ask, h.config.PassiveEntryTimeout)
+	}
+
 	// Проверка параметров ордера на покупку
 
 	// Проверка на пустые или некорректные значения
@@ -331,53 +639,386 @@ func (h *HedgeStrategyUseCase) hedgeTrade(ctx context.Context, trade *entities.T
 		return fmt.Errorf("цена лимитного ордера должна быть больше 0: %.4f", buyOrder.Price)
 	}
 
+	// Сохраняем намерение до размещения: при падении процесса ордер будет найден по клиентскому ID
+	intent, err := h.createHedgeIntent(ctx, trade, previousHedges, buyOrder.Quantity, buyOrder.Price)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения намерения хеджирования: %w", err)
+	}
+	buyOrder.WithClientOrderID(intent.ClientOrderID)
+
 	// Размещение ордера на покупку
 
 	buyResult, err := h.exchangeService.PlaceOrder(ctx, buyOrder)
 	if err != nil {
+		// Ордер мог дойти до биржи - намерение остается незавершенным и будет сверено
 		return fmt.Errorf("ошибка размещения ордера на покупку: %w", err)
 	}
 
+	h.events.RecordPlaced(ctx, trade.Pair, buyOrder, buyResult)
 	if !buyResult.Success {
+		h.advanceHedgeIntent(ctx, intent, entities.HedgeStateClosed)
 		return fmt.Errorf("неудачное размещение ордера на покупку: %s", buyResult.Error)
 	}
 
-	// 3. Ожидаем полного исполнения ордера на покупку с повторными попытками
+	buyPlacedAt := time.Now()
+	intent.BuyOrderID = buyResult.OrderID
+	h.advanceHedgeIntent(ctx, intent, entities.HedgeStateBuyPlaced)
+
+	// 3. Ожидаем полного исполнения ордера на покупку
 	logger.LogWithTime("⏳ Ожидание исполнения ордера на покупку...")
 
-	var buyOrderStatus *services.OrderStatusInfo
-	maxWaitAttempts := 30 // Максимум 30 попыток (30 секунд)
-	waitDelay := time.Second
+	waiter := h.fillWaiter
+	if passive != nil {
+		waiter = h.passiveWaiter
+	}
+	buyOrderStatus, err := waiter.WaitForFill(ctx, buyResult.OrderID, symbol)
+	var passiveStatus *services.OrderStatusInfo
+	if err != nil {
+		strategyErr, ok := err.(*errors.StrategyError)
+		timedOut := ok && strategyErr.Type == errors.ErrorTypeOrderFillTimeout
+		// Мейкерский ордер, отклоненный биржей (цена ушла, и он исполнился бы сразу), докупается по рынку
+		passiveRejected := passive != nil && buyOrderStatus != nil && buyOrderStatus.Status.IsCompleted()
+		if !timedOut && !passiveRejected {
+			return fmt.Errorf("ошибка ожидания исполнения ордера на покупку: %w", err)
+		}
+
+		hasPartialFill := buyOrderStatus != nil && buyOrderStatus.FilledQty > 0
+		// После мейкерской попытки остаток всегда покупается по рынку
+		marketFallback := passive != nil ||
+			h.config.BuyFallback == BuyFallbackMarket && h.flags.Enabled(entities.FlagMarketBuyFallback)
+		if !hasPartialFill && h.config.LeaveBuyPending && !marketFallback {
+			// Не блокируем цикл: ордер на покупку будет подхвачен в следующем цикле
+			if err := h.saveBuyPending(ctx, trade, buyResult.OrderID, orderQuantity, buyPlacedAt); err != nil {
+				return err
+			}
+			return nil
+		}
+
+		// Отменяем неисполненный остаток, чтобы он не оставался в стакане
+		if !passiveRejected {
+			buyOrderStatus, err = h.cancelBuyRemainder(ctx, buyResult.OrderID, symbol, buyOrderStatus)
+			if err != nil {
+				return err
+			}
+		}
+		passiveStatus = buyOrderStatus
+
+		// Гарантируем вход при резком движении: отмененный остаток докупаем по рынку
+		if marketFallback {
+			buyOrderStatus, err = h.completeBuyAtMarket(ctx, trade.Pair, symbol, orderQuantity, buyOrderStatus, rules, buyOrder.Price)
+			if err != nil {
+				logger.LogWithTime("⚠️ Рыночная докупка не выполнена: %v", err)
+			}
+		}
+		if buyOrderStatus == nil || buyOrderStatus.FilledQty <= 0 {
+			h.advanceHedgeIntent(ctx, intent, entities.HedgeStateClosed)
+			return fmt.Errorf("ордер на покупку не исполнен за %v и отменен", waiter.timeout)
+		}
+
+		// Продолжаем с исполненной частью только если она проходит минимальные лимиты биржи
+		filledValue := buyOrderStatus.FilledQty * buyOrder.Price
+		if buyOrderStatus.FilledQty < minOrderQty || filledValue < minOrderValue {
+			// Тейк-профит на такое количество выставить невозможно - восстановление не поможет
+			h.advanceHedgeIntent(ctx, intent, entities.HedgeStateClosed)
+			return fmt.Errorf("частично исполненное количество %.6f %s (%.2f %s) меньше минимальных лимитов биржи (%.6f / %.2f %s) - тейк-профит не выставлен",
+				buyOrderStatus.FilledQty, pair.BaseCurrency(), filledValue, h.config.BaseCurrency,
+				minOrderQty, minOrderValue, h.config.BaseCurrency)
+		}
+
+		if buyOrderStatus.Status != entities.OrderStatusFilled || !marketFallback {
+			logger.LogWithTime("✂️ Остаток ордера на покупку отменен, продолжаем с исполненным количеством %.6f из %.6f",
+				buyOrderStatus.FilledQty, orderQuantity)
+		}
+	}
+
+	h.events.RecordStatus(ctx, trade.Pair, entities.OrderStatusPending, buyOrderStatus)
+	intent.FilledQty = buyOrderStatus.FilledQty
+	h.advanceHedgeIntent(ctx, intent, entities.HedgeStateBuyFilled)
+
+	var passiveOutcome string
+	if passive != nil {
+		if passiveStatus == nil {
+			passiveStatus = buyOrderStatus
+		}
+		passiveOutcome = h.passiveEntries.record(passive, pair, passiveStatus, buyOrderStatus)
+		logger.LogWithTime("🪤 Итог мейкерской покупки: %s", passiveOutcome)
+	}
+
+	hedgedTrade, events, err := h.placeTakeProfit(ctx, trade, buyResult.OrderID, orderQuantity, buyOrderStatus, instrumentInfo)
+	if err != nil {
+		// Покупка исполнена, но не защищена - тейк-профит будет выставлен восстановлением
+		return err
+	}
+	if passiveOutcome != "" {
+		hedgedTrade.FeatureFlags = withFeatureFlag(hedgedTrade.FeatureFlags, "entry", passiveOutcome)
+	}
+	hedgedTrade.BuyPlacedAt = &buyPlacedAt
+	h.thresholds.Claim(hedgedTrade)
+
+	// Фиксируем тейк-профит до сохранения хеджа, чтобы восстановление не выставило его повторно
+	intent.TakeProfitOrderID = hedgedTrade.BybitOrderID
+	h.advanceHedgeIntent(ctx, intent, entities.HedgeStateTPPlaced)
+
+	if err := h.saveHedge(ctx, "", hedgedTrade, events); err != nil {
+		return fmt.Errorf("ошибка сохранения хеджированной сделки: %w", err)
+	}
+
+	h.scheduleEarlyChecks(ctx, hedgedTrade)
+	h.notifyHedgeOpened(ctx, hedgedTrade)
+
+	return nil
+}
+
+// cancelBuyRemainder отменяет неисполненный остаток ордера на покупку и возвращает итоговый статус
+func (h *HedgeStrategyUseCase) cancelBuyRemainder(ctx context.Context, orderID, symbol string, lastStatus *services.OrderStatusInfo) (*services.OrderStatusInfo, error) {
+	logger.LogWithTime("🚫 Отмена неисполненного остатка ордера на покупку %s...", orderID)
+
+	cancelResult, err := h.exchangeService.CancelOrder(ctx, orderID, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка отмены остатка ордера на покупку %s: %w", orderID, err)
+	}
+	if !cancelResult.Success {
+		// Ордер мог исполниться между последней проверкой и отменой - проверяем итоговый статус
+		logger.LogWithTime("⚠️ Отмена ордера %s не выполнена: %s", orderID, cancelResult.Error)
+	}
+
+	// Между последней проверкой и отменой могло исполниться дополнительное количество
+	finalStatus, err := h.exchangeService.GetOrderStatus(ctx, orderID, symbol)
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось получить итоговый статус ордера %s: %v", orderID, err)
+		if !cancelResult.Success {
+			return nil, fmt.Errorf("не удалось отменить остаток ордера на покупку %s: %s", orderID, cancelResult.Error)
+		}
+		return lastStatus, nil
+	}
+
+	return finalStatus, nil
+}
+
+// saveBuyPending сохраняет хеджирование с неисполненным ордером на покупку для обработки в следующем цикле
+func (h *HedgeStrategyUseCase) saveBuyPending(ctx context.Context, trade *entities.Trade, buyOrderID string, orderQuantity float64, buyPlacedAt time.Time) error {
+	logger.LogWithTime("⏭️ Ордер на покупку %s не исполнен за %v - оставляем его на бирже до следующего цикла",
+		buyOrderID, h.config.BuyFillTimeout)
+
+	now := time.Now()
+	hedgedTrade := &entities.HedgedTrade{
+		FreqtradeTradeID: trade.ID,
+		Pair:             trade.Pair,
+		HedgeTime:        now,
+		BybitOrderID:     buyOrderID,
+		BuyOrderID:       buyOrderID,
+
+		FreqtradeOpenPrice:   trade.OpenRate,
+		FreqtradeAmount:      trade.Amount,
+		FreqtradeProfitRatio: trade.ProfitRatio,
+
+		// До исполнения покупки храним запрошенное количество, тейк-профит еще не выставлен
+		HedgeOpenPrice:  trade.CurrentRate,
+		HedgeAmount:     orderQuantity,
+		BuyRequestedQty: orderQuantity,
+
+		OrderStatus:     entities.OrderStatusBuyPending,
+		LastStatusCheck: &now,
+		BuyPlacedAt:     &buyPlacedAt,
+	}
+	if group := h.groups.forTrade(trade.ID); group != nil {
+		hedgedTrade.HedgeGroupID = group.ID
+	}
+	h.tagHedge(hedgedTrade)
+	h.thresholds.Claim(hedgedTrade)
+
+	if err := h.hedgeRepo.SaveHedgedTrade(ctx, hedgedTrade); err != nil {
+		return fmt.Errorf("ошибка сохранения ожидающей покупки: %w", err)
+	}
+
+	return nil
+}
 
-	for attempt := 1; attempt <= maxWaitAttempts; attempt++ {
-		time.Sleep(waitDelay)
+// resumePendingBuys проверяет ордера на покупку, оставленные в предыдущих циклах,
+// и выставляет тейк-профит для исполненных
+func (h *HedgeStrategyUseCase) resumePendingBuys(ctx context.Context) error {
+	buyPendingStatus := entities.OrderStatusBuyPending.String()
+	pendingBuys, err := h.hedgeRepo.GetHedgedTrades(ctx, &buyPendingStatus)
+	if err != nil {
+		return fmt.Errorf("ошибка получения ожидающих покупок: %w", err)
+	}
+
+	for _, pending := range pendingBuys {
+		pair := valueobjects.NewTradingPair(pending.Pair)
+		symbol := pair.ToBybitFormat()
 
-		buyOrderStatus, err = h.exchangeService.GetOrderStatus(ctx, buyResult.OrderID, symbol)
+		statusInfo, err := h.exchangeService.GetOrderStatus(ctx, pending.BuyOrderID, symbol)
 		if err != nil {
-			logger.LogWithTime("⚠️ Попытка %d/%d получения статуса ордера: %v", attempt, maxWaitAttempts, err)
+			logger.LogWithTime("⚠️ Не удалось получить статус покупки %s (пара %s): %v", pending.BuyOrderID, pending.Pair, err)
 			continue
 		}
 
-		// Проверяем, исполнен ли ордер полностью
-		if buyOrderStatus.Status == entities.OrderStatusFilled {
-			logger.LogWithTime("✅ Ордер на покупку полностью исполнен!")
-			break
-		} else if buyOrderStatus.Status == entities.OrderStatusPartiallyFilled {
-			logger.LogWithTime("⏳ Частичное исполнение: %v из %v", buyOrderStatus.FilledQty, orderQuantity)
-			// Продолжаем ждать полного исполнения
-		} else if buyOrderStatus.Status.IsCompleted() && buyOrderStatus.Status != entities.OrderStatusFilled {
-			return fmt.Errorf("ордер на покупку завершен неуспешно: %s", buyOrderStatus.Status)
+		switch {
+		case statusInfo.Status == entities.OrderStatusFilled:
+			logger.LogWithTime("✅ Отложенная покупка %s (пара %s) исполнена - выставляем тейк-профит", pending.BuyOrderID, pending.Pair)
+			if err := h.completePendingBuy(ctx, pending, statusInfo); err != nil {
+				logger.LogWithTime("❌ Ошибка завершения отложенной покупки %s: %v", pending.BuyOrderID, err)
+			}
+		case statusInfo.Status.IsCompleted():
+			logger.LogWithTime("❌ Отложенная покупка %s (пара %s) завершена неуспешно: %s", pending.BuyOrderID, pending.Pair, statusInfo.Status)
+			now := time.Now()
+			if err := h.hedgeRepo.UpdateHedgedTradeStatus(ctx, pending.BybitOrderID, statusInfo.Status, nil, &now); err != nil {
+				logger.LogWithTime("❌ Ошибка обновления статуса отложенной покупки %s: %v", pending.BuyOrderID, err)
+			}
+			h.closeHedgeIntentByOrderID(ctx, pending.BuyOrderID)
+		default:
+			logger.LogWithTime("⏳ Отложенная покупка %s (пара %s) еще не исполнена: %s", pending.BuyOrderID, pending.Pair, statusInfo.Status)
 		}
+	}
+
+	return nil
+}
+
+// completePendingBuy выставляет тейк-профит для исполненной отложенной покупки
+func (h *HedgeStrategyUseCase) completePendingBuy(ctx context.Context, pending *entities.HedgedTrade, buyOrderStatus *services.OrderStatusInfo) error {
+	// Восстанавливаем состояние сделки Freqtrade на момент размещения покупки
+	trade := &entities.Trade{
+		ID:          pending.FreqtradeTradeID,
+		Pair:        pending.Pair,
+		IsOpen:      true,
+		ProfitRatio: pending.FreqtradeProfitRatio,
+		CurrentRate: pending.HedgeOpenPrice,
+		OpenRate:    pending.FreqtradeOpenPrice,
+		Amount:      pending.FreqtradeAmount,
+	}
+
+	symbol := valueobjects.NewTradingPair(pending.Pair).ToBybitFormat()
+	instrument := &services.InstrumentInfo{}
+	if instrumentInfo, err := h.exchangeService.GetInstrumentInfo(ctx, symbol); err == nil {
+		instrument = instrumentInfo
+	}
+
+	// Покупка исполнена - продвигаем хедж, если он был размещен через намерение
+	intent, err := h.intentRepo.GetHedgeIntentByOrderID(ctx, pending.BuyOrderID)
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось найти хедж по покупке %s: %v", pending.BuyOrderID, err)
+	}
+	if intent != nil && intent.State == entities.HedgeStateBuyPlaced {
+		intent.FilledQty = buyOrderStatus.FilledQty
+		h.advanceHedgeIntent(ctx, intent, entities.HedgeStateBuyFilled)
+	}
+
+	hedgedTrade, events, err := h.placeTakeProfit(ctx, trade, pending.BuyOrderID, pending.HedgeAmount, buyOrderStatus, instrument)
+	if err != nil {
+		return err
+	}
+	hedgedTrade.HedgeTime = pending.HedgeTime
+	copyHedgeTiming(pending, hedgedTrade)
 
-		if attempt == maxWaitAttempts {
-			return fmt.Errorf("превышено время ожидания исполнения ордера на покупку (30 секунд)")
+	if intent != nil && intent.State == entities.HedgeStateBuyFilled {
+		intent.TakeProfitOrderID = hedgedTrade.BybitOrderID
+		h.advanceHedgeIntent(ctx, intent, entities.HedgeStateTPPlaced)
+	}
+
+	if err := h.saveHedge(ctx, pending.BybitOrderID, hedgedTrade, events); err != nil {
+		return fmt.Errorf("ошибка сохранения хеджированной сделки: %w", err)
+	}
+
+	h.scheduleEarlyChecks(ctx, hedgedTrade)
+	h.notifyHedgeOpened(ctx, hedgedTrade)
+
+	return nil
+}
+
+// saveHedge сохраняет хедж с выставленным тейк-профитом вместе с событиями размещения его ордеров в одной
+// транзакции: сбой между записями не оставляет хедж без событий или события без хеджа.
+// Если replaceOrderID не пуст, обновляется ранее сохраненный хедж с этим ордером (ожидающая покупка)
+func (h *HedgeStrategyUseCase) saveHedge(ctx context.Context, replaceOrderID string, hedgedTrade *entities.HedgedTrade, events []*entities.OrderEvent) error {
+	save := func(ctx context.Context) error {
+		var err error
+		if replaceOrderID != "" {
+			err = h.hedgeRepo.UpdateHedgedTrade(ctx, replaceOrderID, hedgedTrade)
+		} else {
+			err = h.hedgeRepo.SaveHedgedTrade(ctx, hedgedTrade)
+		}
+		if err != nil {
+			return err
 		}
+		return h.events.Save(ctx, events)
 	}
 
+	if h.transactions == nil {
+		return save(ctx)
+	}
+	return h.transactions.WithinTransaction(ctx, save)
+}
+
+// scheduleEarlyChecks планирует внеочередные проверки статуса, чтобы быстрые исполнения не ждали следующего интервала
+func (h *HedgeStrategyUseCase) scheduleEarlyChecks(ctx context.Context, hedgedTrade *entities.HedgedTrade) {
+	if h.statusChecker == nil {
+		return
+	}
+	h.statusChecker.ScheduleEarlyChecks(ctx, hedgedTrade, h.config.EarlyStatusChecks)
+}
+
+// notifyHedgeOpened оповещает об открытом хедже: вход, тейк-профит, стоп-лосс, прибыль и убыток с комиссиями.
+// Ошибка отправки только логируется - хедж уже сохранен
+func (h *HedgeStrategyUseCase) notifyHedgeOpened(ctx context.Context, hedgedTrade *entities.HedgedTrade) {
+	if h.notifier == nil {
+		return
+	}
+
+	pair := valueobjects.NewTradingPair(hedgedTrade.Pair)
+	message := fmt.Sprintf("Сделка Freqtrade %d: куплено %s %s по %s, тейк-профит %s",
+		hedgedTrade.FreqtradeTradeID, valueobjects.FormatAmount(hedgedTrade.HedgeAmount, pair.BaseCurrency()), pair.BaseCurrency(),
+		pair.FormatPrice(hedgedTrade.HedgeOpenPrice), pair.FormatPrice(hedgedTrade.HedgeTakeProfitPrice))
+	notification := entities.NewNotification(entities.NotificationPriorityNormal, "Хедж открыт: "+hedgedTrade.Pair, message).
+		WithRiskReward(entities.NewHedgeRiskReward(hedgedTrade)).
+		WithEvent(entities.NotificationEventHedgeOpened, entities.HedgeEventData(hedgedTrade))
+	if err := h.notifier.Notify(ctx, notification); err != nil {
+		logger.LogWithTime("❌ Ошибка отправки оповещения об открытии хеджа %s: %v", hedgedTrade.Pair, err)
+	}
+}
+
+// notifyHedgeFailed оповещает об ошибке хеджирования сделки. Общий лимит риска - ожидаемый отказ,
+// а не сбой: о нем не оповещаем, иначе оповещение приходило бы каждый цикл
+func (h *HedgeStrategyUseCase) notifyHedgeFailed(ctx context.Context, trade *entities.Trade, hedgeErr error) {
+	if h.notifier == nil {
+		return
+	}
+	if strategyErr, ok := hedgeErr.(*errors.StrategyError); ok && strategyErr.Type == errors.ErrorTypeRiskLimitExceeded {
+		return
+	}
+
+	notification := entities.NewNotification(entities.NotificationPriorityHigh, "Ошибка хеджирования: "+trade.Pair,
+		fmt.Sprintf("Сделка Freqtrade %d: %v", trade.ID, hedgeErr)).
+		WithEvent(entities.NotificationEventHedgeFailed, map[string]interface{}{
+			"freqtrade_trade_id": trade.ID,
+			"pair":               trade.Pair,
+			"profit_ratio":       trade.ProfitRatio,
+			"error":              hedgeErr.Error(),
+		})
+	if err := h.notifier.Notify(ctx, notification); err != nil {
+		logger.LogWithTime("❌ Ошибка отправки оповещения об ошибке хеджирования %s: %v", trade.Pair, err)
+	}
+}
+
+// placeTakeProfit выставляет тейк-профит на фактически купленное количество
+// и возвращает заполненную хеджированную сделку и события размещения ее ордеров для сохранения (saveHedge)
+func (h *HedgeStrategyUseCase) placeTakeProfit(
+	ctx context.Context,
+	trade *entities.Trade,
+	buyOrderID string,
+	orderQuantity float64,
+	buyOrderStatus *services.OrderStatusInfo,
+	instrument *services.InstrumentInfo,
+) (*entities.HedgedTrade, []*entities.OrderEvent, error) {
+	// Исполнение обнаружено к началу выставления тейк-профита (если биржа не сообщила время исполнения)
+	fillDetectedAt := time.Now()
+	rules := instrument.Rules()
+	tickSize := rules.TickSize
+	pair := valueobjects.NewTradingPair(trade.Pair)
+	symbol := pair.ToBybitFormat()
+
 	// Используем фактически купленное количество для ордера на продажу
 	actualQuantity := buyOrderStatus.FilledQty
 	if actualQuantity <= 0 {
-		return fmt.Errorf("ордер на покупку не был исполнен или исполнен на 0")
+		return nil, nil, fmt.Errorf("ордер на покупку не был исполнен или исполнен на 0")
 	}
 
 	// Проверяем на частичное исполнение
@@ -408,7 +1049,7 @@ func (h *HedgeStrategyUseCase) hedgeTrade(ctx context.Context, trade *entities.T
 			actualQuantity = baseCurrencyBalance.Available
 
 			if actualQuantity <= 0 {
-				return fmt.Errorf("недостаточно %s для размещения ордера на продажу", pair.BaseCurrency())
+				return nil, nil, fmt.Errorf("недостаточно %s для размещения ордера на продажу", pair.BaseCurrency())
 			}
 		} else {
 			logger.LogWithTime("✅ Баланс %s достаточен: доступно %.4f, требуется %.4f",
@@ -417,18 +1058,36 @@ func (h *HedgeStrategyUseCase) hedgeTrade(ctx context.Context, trade *entities.T
 	}
 
 	// 5. Рассчитываем цену тейк-профита
-	takeProfitPrice := trade.CalculateTakeProfitPrice(h.config.ProfitRatio)
+	rawTakeProfitPrice := h.strategy.PriceExit(trade)
+
+	// Ноги хедж-группы закрываются по общему тейк-профиту, заданному первой ногой
+	group := h.groups.forTrade(trade.ID)
+	if group != nil {
+		if groupPrice := h.groups.takeProfitPrice(group); groupPrice > 0 {
+			logger.LogWithTime("🧩 Тейк-профит ноги хедж-группы %d: общая цена группы %.8f (стратегия: %.8f)",
+				group.ID, groupPrice, rawTakeProfitPrice)
+			rawTakeProfitPrice = groupPrice
+		}
+	}
+	takeProfitPrice := rawTakeProfitPrice
 
 	logger.LogWithTime("🔍 Расчет цены тейк-профита:")
 	logger.LogWithTime("   Исходная цена: %.8f", trade.CurrentRate)
 	logger.LogWithTime("   Коэффициент прибыли: %.4f", h.config.ProfitRatio)
 	logger.LogWithTime("   Рассчитанная цена тейк-профита: %.8f", takeProfitPrice)
 
-	// Округляем цену тейк-профита до правильного шага согласно tickSize от Bybit
-	if tickSize > 0 {
-		// Округляем до ближайшего кратного tickSize
-		takeProfitPrice = math.Round(takeProfitPrice/tickSize) * tickSize
-		logger.LogWithTime("🔧 Цена тейк-профита скорректирована до шага %.8f: %.8f → %.8f", tickSize, trade.CalculateTakeProfitPrice(h.config.ProfitRatio), takeProfitPrice)
+	// Цена покупки: фактическая средняя цена исполнения, иначе лимитная цена стратегии
+	entryPrice := h.strategy.PriceEntry(trade)
+	if buyOrderStatus.FilledPrice != nil && *buyOrderStatus.FilledPrice > 0 {
+		entryPrice = *buyOrderStatus.FilledPrice
+	}
+
+	// Округляем до шага tickSize от Bybit с минимальным расстоянием от цены покупки
+	takeProfitPrice = applyTakeProfitFloor(rawTakeProfitPrice, entryPrice, tickSize,
+		h.config.MinTakeProfitTicks, h.config.MinTakeProfitPercent, h.rounding.sellPrice)
+	if takeProfitPrice != rawTakeProfitPrice {
+		logger.LogWithTime("🔧 Цена тейк-профита скорректирована (шаг %.8f, минимум %d шаг. / %.2f%% от покупки %.8f): %.8f → %.8f",
+			tickSize, h.config.MinTakeProfitTicks, h.config.MinTakeProfitPercent, entryPrice, rawTakeProfitPrice, takeProfitPrice)
 	}
 
 	// Проверяем, что цена тейк-профита не стала нулевой
@@ -442,58 +1101,80 @@ func (h *HedgeStrategyUseCase) hedgeTrade(ctx context.Context, trade *entities.T
 	logger.LogWithTime("🎯 Лимитный ордер на продажу: %.4f %s по цене %.8f (тейк-профит)",
 		actualQuantity, pair.ToBybitFormat(), takeProfitPrice)
 
+	// Стоп-лосс округляем вниз, чтобы не сработать раньше заданного процента
+	var stopLossPrice float64
+	if h.config.StopLossPercent > 0 && h.flags.Enabled(entities.FlagStopLoss) {
+		stopLossPrice = valueobjects.NewPrice(applyPercent(entryPrice, -h.config.StopLossPercent), rules, valueobjects.RoundFloor).Float64()
+		logger.LogWithTime("🛡️ Стоп-лосс (OCO с тейк-профитом): %.8f (-%.2f%% от покупки %.8f)",
+			stopLossPrice, h.config.StopLossPercent, entryPrice)
+	}
+
 	// 6. Размещаем лимитный ордер на продажу с ретраями
-	sellOrder := entities.NewLimitOrder(symbol, entities.OrderSideSell, actualQuantity, takeProfitPrice)
+	// Количество округляем вниз до шага: продать больше купленного нельзя
+	sellQuantity := valueobjects.NewQuantity(actualQuantity, rules, valueobjects.RoundFloor)
+	sellOrder := entities.NewLimitOrder(symbol, entities.OrderSideSell, sellQuantity.Float64(), takeProfitPrice).
+		WithInstrumentSteps(tickSize, rules.StepSize)
 
 	// Проверка параметров ордера на продажу
 
 	// Проверка на пустые или некорректные значения для ордера на продажу
 	if sellOrder.Quantity <= 0 {
-		return fmt.Errorf("количество ордера на продажу должно быть больше 0: %.6f", sellOrder.Quantity)
+		return nil, nil, fmt.Errorf("количество ордера на продажу должно быть больше 0: %.6f", sellOrder.Quantity)
 	}
 	if sellOrder.Price <= 0 {
-		return fmt.Errorf("цена ордера на продажу должна быть больше 0: %.8f", sellOrder.Price)
+		return nil, nil, fmt.Errorf("цена ордера на продажу должна быть больше 0: %.8f", sellOrder.Price)
 	}
 
 	var sellResult *entities.OrderResult
+	var stopLossOrderID string
+	var events []*entities.OrderEvent
 	maxRetries := h.config.RetryAttempts
-	retryDelay := 2 * time.Second
+	retryDelay := time.Duration(h.config.RetryDelay) * time.Second
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		logger.LogWithTime("📤 Попытка %d/%d размещения ордера на продажу", attempt, maxRetries)
 
-		sellResult, err = h.exchangeService.PlaceOrder(ctx, sellOrder)
+		sellResult, stopLossOrderID, err = h.placeExitOrders(ctx, sellOrder, stopLossPrice)
 		if err != nil {
 			logger.LogWithTime("⚠️ Попытка %d неудачна: %v", attempt, err)
 			if attempt < maxRetries {
 				logger.LogWithTime("⏳ Ждем %v перед повтором...", retryDelay)
-				time.Sleep(retryDelay)
+				if err := sleepWithContext(ctx, retryDelay); err != nil {
+					return nil, nil, fmt.Errorf("размещение ордера на продажу прервано: %w", err)
+				}
 				continue
 			}
-			return fmt.Errorf("неудачное размещение ордера на продажу после %d попыток: %w", maxRetries, err)
+			return nil, nil, fmt.Errorf("неудачное размещение ордера на продажу после %d попыток: %w", maxRetries, err)
 		}
 
 		if sellResult.Success {
 			logger.LogWithTime("✅ Ордер на продажу успешно размещен с попытки %d", attempt)
+			events = append(events, placedOrderEvent(trade.Pair, sellOrder, sellResult))
+			if stopLossOrderID != "" {
+				events = append(events, newOrderEvent(stopLossOrderID, trade.Pair, "", entities.OrderStatusPending, 0, stopLossPrice, sellOrder))
+			}
 			break
 		} else {
 			logger.LogWithTime("⚠️ Попытка %d неудачна: %s", attempt, sellResult.Error)
 			if attempt < maxRetries {
 				logger.LogWithTime("⏳ Ждем %v перед повтором...", retryDelay)
-				time.Sleep(retryDelay)
+				if err := sleepWithContext(ctx, retryDelay); err != nil {
+					return nil, nil, fmt.Errorf("размещение ордера на продажу прервано: %w", err)
+				}
 				continue
 			}
-			return fmt.Errorf("неудачное размещение ордера на продажу после %d попыток: %s", maxRetries, sellResult.Error)
+			return nil, nil, fmt.Errorf("неудачное размещение ордера на продажу после %d попыток: %s", maxRetries, sellResult.Error)
 		}
 	}
 
-	// 7. Сохраняем полную информацию о хеджировании
+	// 7. Формируем полную информацию о хеджировании
 	now := time.Now()
 	hedgedTrade := &entities.HedgedTrade{
 		FreqtradeTradeID: trade.ID,
 		Pair:             trade.Pair,
 		HedgeTime:        now,
 		BybitOrderID:     sellResult.OrderID,
+		BuyOrderID:       buyOrderID,
 
 		// Информация об исходной сделке Freqtrade
 		FreqtradeOpenPrice:   trade.OpenRate,
@@ -504,17 +1185,29 @@ func (h *HedgeStrategyUseCase) hedgeTrade(ctx context.Context, trade *entities.T
 		HedgeOpenPrice:       trade.CurrentRate,
 		HedgeAmount:          actualQuantity,
 		HedgeTakeProfitPrice: takeProfitPrice,
+		StopLossPrice:        stopLossPrice,
+		StopLossOrderID:      stopLossOrderID,
+
+		// Информация об исполнении покупки
+		BuyRequestedQty: orderQuantity,
+		BuyFilledQty:    buyOrderStatus.FilledQty,
+		EntryFee:        feeInQuote(buyOrderStatus, pair, entryPrice),
 
 		// Статус ордера
 		OrderStatus:     entities.OrderStatusPending,
 		LastStatusCheck: &now,
 		ClosePrice:      nil,
 		CloseTime:       nil,
-	}
 
-	if err := h.hedgeRepo.SaveHedgedTrade(ctx, hedgedTrade); err != nil {
-		return fmt.Errorf("ошибка сохранения хеджированной сделки: %w", err)
+		// Моменты этапов для метрик задержки
+		BuyFilledAt:        buyFillTime(buyOrderStatus, fillDetectedAt),
+		TakeProfitPlacedAt: &now,
 	}
+	if group != nil {
+		hedgedTrade.HedgeGroupID = group.ID
+		h.groups.fixTakeProfit(ctx, group, takeProfitPrice)
+	}
+	h.tagHedge(hedgedTrade)
 
-	return nil
+	return hedgedTrade, events, nil
 }
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"math"
+
+	"trade-hedge/internal/domain/entities"
+)
+
+// Названия встроенных стратегий хеджирования
+const (
+	StrategyClassic          = "classic"
+	StrategyMartingaleLadder = "martingale-ladder"
+)
+
+// HedgeStrategy определяет правила выбора сделок, размера позиции и цен входа/выхода
+type HedgeStrategy interface {
+	// Name возвращает название стратегии
+	Name() string
+
+	// SelectTrades отбирает сделки для хеджирования и упорядочивает их по приоритету
+	SelectTrades(trades []*entities.Trade) []*entities.Trade
+
+	// SizePosition возвращает сумму позиции в базовой валюте (0 - не хеджировать)
+	// previousHedges - количество предыдущих хеджей по сделке
+	SizePosition(trade *entities.Trade, previousHedges int) float64
+
+	// PriceEntry возвращает лимитную цену покупки хеджирующей позиции
+	PriceEntry(trade *entities.Trade) float64
+
+	// PriceExit возвращает цену тейк-профита (до округления по шагу цены)
+	PriceExit(trade *entities.Trade) float64
+}
+
+// NewHedgeStrategy создает стратегию по названию из конфигурации (по умолчанию - classic)
+func NewHedgeStrategy(config *HedgeStrategyConfig) HedgeStrategy {
+	classic := &ClassicStrategy{config: config, prioritizer: NewTradePrioritizer(config)}
+
+	switch config.StrategyName {
+	case StrategyMartingaleLadder:
+		return &MartingaleLadderStrategy{
+			ClassicStrategy: classic,
+			multiplier:      config.MartingaleMultiplier,
+			maxSteps:        config.MartingaleMaxSteps,
+		}
+	default:
+		return classic
+	}
+}
+
+// ClassicStrategy хеджирует отобранные сделки фиксированной суммой в порядке политики приоритизации
+type ClassicStrategy struct {
+	config      *HedgeStrategyConfig
+	prioritizer TradePrioritizer
+}
+
+// Name возвращает название стратегии
+func (s *ClassicStrategy) Name() string {
+	return StrategyClassic
+}
+
+// SelectTrades отбирает сделки с убытком больше порога и упорядочивает их политикой приоритизации
+func (s *ClassicStrategy) SelectTrades(trades []*entities.Trade) []*entities.Trade {
+	selected := make([]*entities.Trade, 0, len(trades))
+	for _, trade := range trades {
+		if trade.ShouldBeHedged(s.config.MaxLossPercent) {
+			selected = append(selected, trade)
+		}
+	}
+
+	s.prioritizer.Prioritize(selected)
+	return selected
+}
+
+// SizePosition возвращает фиксированную сумму позиции из настроек
+func (s *ClassicStrategy) SizePosition(trade *entities.Trade, previousHedges int) float64 {
+	return s.config.PositionAmount
+}
+
+// PriceEntry возвращает текущую цену с надбавкой BuyPriceOffsetPercent для гарантированного исполнения
+func (s *ClassicStrategy) PriceEntry(trade *entities.Trade) float64 {
+	return applyPercent(trade.CurrentRate, s.config.BuyPriceOffsetPercent)
+}
+
+// PriceExit возвращает цену тейк-профита пропорционально убытку сделки
+func (s *ClassicStrategy) PriceExit(trade *entities.Trade) float64 {
+	return trade.CalculateTakeProfitPrice(s.config.ProfitRatio)
+}
+
+// MartingaleLadderStrategy увеличивает сумму каждого следующего хеджа по сделке в multiplier раз
+// и прекращает хеджирование после maxSteps ступеней
+type MartingaleLadderStrategy struct {
+	*ClassicStrategy
+	multiplier float64
+	maxSteps   int
+}
+
+// Name возвращает название стратегии
+func (s *MartingaleLadderStrategy) Name() string {
+	return StrategyMartingaleLadder
+}
+
+// SizePosition возвращает сумму позиции для очередной ступени лестницы
+func (s *MartingaleLadderStrategy) SizePosition(trade *entities.Trade, previousHedges int) float64 {
+	if previousHedges >= s.maxSteps {
+		return 0
+	}
+	return s.config.PositionAmount * math.Pow(s.multiplier, float64(previousHedges))
+}
@@ -0,0 +1,250 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// SchedulerLeaseName название аренды, дающей право размещать ордера
+const SchedulerLeaseName = "scheduler"
+
+// ReporterLeaseName название аренды роли reporter: итоги хеджирования и сверку балансов выполняет один экземпляр
+const ReporterLeaseName = "reporter"
+
+// LeaseState состояние экземпляра относительно аренды
+type LeaseState string
+
+const (
+	LeaseStateStandby  LeaseState = "standby"  // Аренда у другого экземпляра - ждем передачи или истечения
+	LeaseStateActive   LeaseState = "active"   // Аренда получена - экземпляр хеджирует
+	LeaseStateDraining LeaseState = "draining" // Новые хеджи не открываются, завершаются начатые
+	LeaseStateDrained  LeaseState = "drained"  // Аренда передана - экземпляр можно останавливать
+)
+
+// InstanceLease управляет арендой роли ведущего экземпляра для развертывания без простоя:
+// новый экземпляр запрашивает передачу аренды, текущий перестает открывать хеджи, завершает
+// начатые и освобождает аренду, после чего новый экземпляр получает ее при следующем продлении
+type InstanceLease struct {
+	leaseRepo      repositories.LeaseRepository
+	intentRepo     repositories.HedgeIntentRepository
+	instanceID     string
+	ttl            time.Duration
+	requestHandoff bool // Запрашивать передачу аренды у текущего держателя
+	cyclesInFlight int32
+	handoffAsked   bool
+	mu             sync.Mutex
+	state          LeaseState
+}
+
+// NewInstanceLease создает менеджер аренды экземпляра
+func NewInstanceLease(
+	leaseRepo repositories.LeaseRepository,
+	intentRepo repositories.HedgeIntentRepository,
+	instanceID string,
+	ttl time.Duration,
+	requestHandoff bool,
+) *InstanceLease {
+	return &InstanceLease{
+		leaseRepo:      leaseRepo,
+		intentRepo:     intentRepo,
+		instanceID:     instanceID,
+		ttl:            ttl,
+		requestHandoff: requestHandoff,
+		state:          LeaseStateStandby,
+	}
+}
+
+// InstanceID возвращает ID этого экземпляра
+func (l *InstanceLease) InstanceID() string {
+	return l.instanceID
+}
+
+// State возвращает текущее состояние экземпляра
+func (l *InstanceLease) State() LeaseState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.state
+}
+
+// CanHedge проверяет, может ли экземпляр открывать новые хеджи
+func (l *InstanceLease) CanHedge() bool {
+	return l.State() == LeaseStateActive
+}
+
+// CanManageOrders проверяет, может ли экземпляр сопровождать уже размещенные ордера
+func (l *InstanceLease) CanManageOrders() bool {
+	state := l.State()
+	return state == LeaseStateActive || state == LeaseStateDraining
+}
+
+// CycleStarted отмечает начало цикла планировщика; аренда не освобождается, пока цикл не завершен
+func (l *InstanceLease) CycleStarted() {
+	atomic.AddInt32(&l.cyclesInFlight, 1)
+}
+
+// CycleFinished отмечает завершение цикла планировщика
+func (l *InstanceLease) CycleFinished() {
+	atomic.AddInt32(&l.cyclesInFlight, -1)
+}
+
+// Drain переводит экземпляр в режим завершения: новые хеджи не открываются,
+// после завершения начатых аренда освобождается
+func (l *InstanceLease) Drain() LeaseState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch l.state {
+	case LeaseStateActive:
+		logger.LogWithTime("🚰 Экземпляр %s переходит в режим завершения: новые хеджи не открываются", l.instanceID)
+		l.state = LeaseStateDraining
+	case LeaseStateStandby:
+		// Аренды нет - достаточно больше не пытаться ее получить
+		l.state = LeaseStateDrained
+	}
+	return l.state
+}
+
+// Renew продлевает или получает аренду и продвигает передачу. Вызывается периодически (чаще ttl)
+func (l *InstanceLease) Renew(ctx context.Context) LeaseState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch l.state {
+	case LeaseStateDrained:
+		return l.state
+
+	case LeaseStateDraining:
+		// Пока аренда не освобождена, продлеваем ее, чтобы другой экземпляр не начал раньше времени
+		if _, err := l.leaseRepo.AcquireLease(ctx, SchedulerLeaseName, l.instanceID, l.ttl); err != nil {
+			logger.LogWithTime("⚠️ Ошибка продления аренды: %v", err)
+		}
+		if l.drained(ctx) {
+			if err := l.leaseRepo.ReleaseLease(ctx, SchedulerLeaseName, l.instanceID); err != nil {
+				logger.LogWithTime("⚠️ Ошибка освобождения аренды: %v", err)
+				return l.state
+			}
+			logger.LogWithTime("🤝 Начатые хеджи завершены, аренда освобождена - экземпляр %s можно останавливать", l.instanceID)
+			l.state = LeaseStateDrained
+		}
+		return l.state
+	}
+
+	lease, err := l.leaseRepo.AcquireLease(ctx, SchedulerLeaseName, l.instanceID, l.ttl)
+	if err != nil {
+		// Без подтверждения аренды ведущий экземпляр прекращает хеджировать: аренда могла истечь
+		logger.LogWithTime("⚠️ Ошибка продления аренды: %v", err)
+		if l.state == LeaseStateActive {
+			l.state = LeaseStateStandby
+		}
+		return l.state
+	}
+
+	if lease == nil {
+		if l.state == LeaseStateActive {
+			logger.LogWithTime("⚠️ Аренда потеряна экземпляром %s - хеджирование приостановлено", l.instanceID)
+		}
+		l.state = LeaseStateStandby
+		l.askHandoff(ctx)
+		return l.state
+	}
+
+	if l.state == LeaseStateStandby {
+		logger.LogWithTime("🔑 Экземпляр %s получил аренду и начинает хеджирование", l.instanceID)
+		l.handoffAsked = false
+	}
+	l.state = LeaseStateActive
+
+	if lease.HandoffRequested() {
+		logger.LogWithTime("🤝 Экземпляр %s запросил передачу аренды - завершаем начатые хеджи", lease.HandoffRequestedBy)
+		l.state = LeaseStateDraining
+	}
+
+	return l.state
+}
+
+// Lease возвращает состояние аренды в БД
+func (l *InstanceLease) Lease(ctx context.Context) (*entities.Lease, error) {
+	return l.leaseRepo.GetLease(ctx, SchedulerLeaseName)
+}
+
+// askHandoff однократно запрашивает передачу аренды у текущего держателя
+func (l *InstanceLease) askHandoff(ctx context.Context) {
+	if !l.requestHandoff || l.handoffAsked {
+		return
+	}
+	if err := l.leaseRepo.RequestHandoff(ctx, SchedulerLeaseName, l.instanceID); err != nil {
+		logger.LogWithTime("⚠️ %v", err)
+		return
+	}
+	l.handoffAsked = true
+	logger.LogWithTime("🤝 Экземпляр %s запросил передачу аренды у текущего держателя", l.instanceID)
+}
+
+// drained проверяет, что циклов в работе нет и все начатые хеджи дошли до тейк-профита или закрыты
+func (l *InstanceLease) drained(ctx context.Context) bool {
+	if atomic.LoadInt32(&l.cyclesInFlight) > 0 {
+		return false
+	}
+
+	intents, err := l.intentRepo.GetInFlightHedgeIntents(ctx)
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось проверить незавершенные хеджи: %v", err)
+		return false
+	}
+	if len(intents) > 0 {
+		logger.LogWithTime("⏳ Ожидаем завершения %d начатых хеджей перед передачей аренды", len(intents))
+		return false
+	}
+	return true
+}
+
+// RoleLease аренда роли, которую в каждый момент выполняет один экземпляр. В отличие от InstanceLease
+// передача и завершение не нужны: роль не размещает ордера, и повтор цикла другим экземпляром безопасен
+type RoleLease struct {
+	leaseRepo  repositories.LeaseRepository
+	name       string
+	instanceID string
+	ttl        time.Duration
+	held       bool
+	mu         sync.Mutex
+}
+
+// NewRoleLease создает аренду роли. ttl должен быть больше периодичности вызова Acquire,
+// иначе аренда истекает между циклами и роль переходит от экземпляра к экземпляру
+func NewRoleLease(leaseRepo repositories.LeaseRepository, name, instanceID string, ttl time.Duration) *RoleLease {
+	return &RoleLease{
+		leaseRepo:  leaseRepo,
+		name:       name,
+		instanceID: instanceID,
+		ttl:        ttl,
+	}
+}
+
+// Acquire получает или продлевает аренду перед циклом роли; false - роль выполняет другой экземпляр
+func (l *RoleLease) Acquire(ctx context.Context) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lease, err := l.leaseRepo.AcquireLease(ctx, l.name, l.instanceID, l.ttl)
+	if err != nil {
+		logger.LogWithTime("⚠️ Ошибка продления аренды %s: %v", l.name, err)
+		l.held = false
+		return false
+	}
+
+	held := lease != nil
+	if held && !l.held {
+		logger.LogWithTime("🔑 Экземпляр %s получил аренду роли %s", l.instanceID, l.name)
+	}
+	if !held && l.held {
+		logger.LogWithTime("⚠️ Аренда роли %s перешла к другому экземпляру", l.name)
+	}
+	l.held = held
+	return held
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// HedgeTradeManually хеджирует одну выбранную сделку Freqtrade вне очереди стратегии (ручной запуск из веб-интерфейса).
+// Сделка проходит те же фильтры, лимиты риска и расчет суммы, что и в цикле стратегии; отбор стратегии
+// не применяется. Сделка с просадкой не больше max_loss_percent хеджируется только с confirm
+func (h *HedgeStrategyUseCase) HedgeTradeManually(ctx context.Context, tradeID int, confirm bool) error {
+	if h.settings != nil {
+		h.settings.Expire(ctx)
+		h.settings.ApplyTo(h.config)
+	}
+
+	if !h.config.DryRun {
+		// Без сверки с биржей нельзя размещать новые ордера: возможна повторная покупка
+		if err := h.recovery.RecoverInFlightHedges(ctx); err != nil {
+			return err
+		}
+	}
+
+	trades, err := h.tradeService.GetActiveTrades(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка получения активных сделок: %w", err)
+	}
+	var trade *entities.Trade
+	for _, candidate := range trades {
+		if candidate.ID == tradeID {
+			trade = candidate
+			break
+		}
+	}
+	if trade == nil {
+		return errors.NewTradeNotFoundError(tradeID)
+	}
+	defer h.decisions.Flush(ctx)
+
+	inFlight, err := h.inFlightTradeIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка получения незавершенных хеджей: %w", err)
+	}
+	if inFlight[trade.ID] {
+		return errors.NewHedgeInProgressError(trade.ID, trade.Pair, "хедж в процессе открытия ожидает восстановления")
+	}
+	hasActiveOrders, _, err := h.hedgeHistoryState(ctx, trade)
+	if err != nil {
+		return err
+	}
+	if hasActiveOrders {
+		return errors.NewHedgeInProgressError(trade.ID, trade.Pair, "хедж-ордер в ожидании исполнения")
+	}
+
+	drawdownPercent := trade.ProfitRatio * -100
+	if !trade.ShouldBeHedged(h.config.MaxLossPercent) && !confirm {
+		return errors.NewDrawdownConfirmationError(trade.Pair, drawdownPercent, h.config.MaxLossPercent)
+	}
+	if lock := entities.FindActivePairLock(h.activePairLocks(ctx), trade.Pair, time.Now()); lock != nil {
+		logger.LogWithTime("⚠️ Пара %s заблокирована Freqtrade до %s (%s) - хеджируем по ручному запросу",
+			trade.Pair, lock.LockEnd.Format("15:04:05"), lock.Reason)
+	}
+
+	logger.LogWithTime("🖐️ Ручное хеджирование сделки %d (%s, просадка %.2f%%, порог %.2f%%)",
+		trade.ID, trade.Pair, drawdownPercent, h.config.MaxLossPercent)
+
+	if h.config.DryRun {
+		return h.reportDryRun(ctx, []*entities.Trade{trade})
+	}
+
+	if err := h.hedgeTrade(ctx, trade); err != nil {
+		h.decisions.RecordError(trade, err)
+		logger.LogWithTime("❌ Ручное хеджирование сделки %d (%s) не выполнено: %v", trade.ID, trade.Pair, err)
+		return err
+	}
+
+	logger.LogWithTime("✅ Сделка %d (%s) хеджирована по ручному запросу", trade.ID, trade.Pair)
+	return nil
+}
@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// DashboardCurrencies криптовалюты, балансы которых показывает дашборд помимо базовой валюты
+var DashboardCurrencies = []string{"BTC", "ETH", "SOL", "XRP", "DOGE", "PEPE", "TON", "ONDO"}
+
+// snapshotRefreshTimeout ограничивает обновление снимка в цикле стратегии
+const snapshotRefreshTimeout = 30 * time.Second
+
+// MarketSnapshotUseCase ведет снимок последних успешно полученных балансов и цен.
+// Снимок обновляется в каждом цикле и при каждом успешном запросе веб-интерфейса,
+// а при недоступности Bybit или Freqtrade веб-интерфейс показывает его с отметкой времени
+type MarketSnapshotUseCase struct {
+	repo         repositories.MarketSnapshotRepository
+	exchange     services.ExchangeService // nil - балансы не запрашиваются (ключи Bybit не заданы)
+	baseCurrency string
+
+	mu     sync.Mutex
+	latest *entities.MarketSnapshot // Кэш сохраненного снимка (nil - еще не загружен)
+}
+
+// NewMarketSnapshotUseCase создает use case снимка; exchange может быть nil
+func NewMarketSnapshotUseCase(repo repositories.MarketSnapshotRepository, exchange services.ExchangeService, baseCurrency string) *MarketSnapshotUseCase {
+	return &MarketSnapshotUseCase{
+		repo:         repo,
+		exchange:     exchange,
+		baseCurrency: baseCurrency,
+	}
+}
+
+// BaseCurrency возвращает базовую валюту, баланс которой обязателен в снимке
+func (u *MarketSnapshotUseCase) BaseCurrency() string {
+	return u.baseCurrency
+}
+
+// FetchBalances запрашивает балансы базовой валюты и DashboardCurrencies и сохраняет их в снимок.
+// Ошибка возвращается, только если недоступен баланс базовой валюты; остальные валюты необязательны
+func (u *MarketSnapshotUseCase) FetchBalances(ctx context.Context) (map[string]*entities.Balance, error) {
+	if u.exchange == nil {
+		return nil, fmt.Errorf("биржа не настроена")
+	}
+
+	base, err := u.exchange.GetBalance(ctx, u.baseCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения баланса %s: %w", u.baseCurrency, err)
+	}
+
+	balances := map[string]*entities.Balance{u.baseCurrency: base}
+	for _, currency := range DashboardCurrencies {
+		if balance, err := u.exchange.GetBalance(ctx, currency); err == nil {
+			balances[currency] = balance
+		}
+	}
+
+	u.update(ctx, func(snapshot *entities.MarketSnapshot) {
+		snapshot.SetBalances(balances, time.Now())
+	})
+	return balances, nil
+}
+
+// RecordPrices сохраняет в снимок успешно полученные цены пар
+func (u *MarketSnapshotUseCase) RecordPrices(ctx context.Context, prices map[string]float64) {
+	if len(prices) == 0 {
+		return
+	}
+	u.update(ctx, func(snapshot *entities.MarketSnapshot) {
+		snapshot.SetPrices(prices, time.Now())
+	})
+}
+
+// Refresh обновляет снимок в цикле стратегии: текущие цены сделок Freqtrade и балансы биржи.
+// Ошибки только логируются - снимок не должен мешать хеджированию
+func (u *MarketSnapshotUseCase) Refresh(ctx context.Context, trades []*entities.Trade) {
+	ctx, cancel := context.WithTimeout(ctx, snapshotRefreshTimeout)
+	defer cancel()
+
+	prices := make(map[string]float64, len(trades))
+	for _, trade := range trades {
+		if trade.CurrentRate > 0 {
+			prices[trade.Pair] = trade.CurrentRate
+		}
+	}
+	u.RecordPrices(ctx, prices)
+
+	if u.exchange == nil {
+		return
+	}
+	if _, err := u.FetchBalances(ctx); err != nil {
+		logger.LogWithTime("⚠️ Снимок балансов не обновлен: %v", err)
+	}
+}
+
+// Latest возвращает последний снимок (nil, если снимка еще нет)
+func (u *MarketSnapshotUseCase) Latest(ctx context.Context) (*entities.MarketSnapshot, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if err := u.load(ctx); err != nil {
+		return nil, err
+	}
+	if u.latest == nil {
+		return nil, nil
+	}
+	return u.latest.Clone(), nil
+}
+
+// update изменяет копию снимка и сохраняет ее; при ошибке сохранения кэш все равно обновляется
+func (u *MarketSnapshotUseCase) update(ctx context.Context, change func(snapshot *entities.MarketSnapshot)) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if err := u.load(ctx); err != nil {
+		logger.LogWithTime("⚠️ Не удалось загрузить снимок балансов и цен: %v", err)
+	}
+
+	snapshot := entities.NewMarketSnapshot()
+	if u.latest != nil {
+		snapshot = u.latest.Clone()
+	}
+	change(snapshot)
+	u.latest = snapshot
+
+	if err := u.repo.SaveMarketSnapshot(ctx, snapshot.Clone()); err != nil {
+		logger.LogWithTime("⚠️ Не удалось сохранить снимок балансов и цен: %v", err)
+	}
+}
+
+// load загружает сохраненный снимок в кэш при первом обращении (вызывается под блокировкой)
+func (u *MarketSnapshotUseCase) load(ctx context.Context) error {
+	if u.latest != nil {
+		return nil
+	}
+	snapshot, err := u.repo.GetMarketSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	u.latest = snapshot
+	return nil
+}
+
+// refreshMarketSnapshot обновляет снимок по данным цикла в фоне, не задерживая хеджирование
+func (h *HedgeStrategyUseCase) refreshMarketSnapshot(ctx context.Context, trades []*entities.Trade) {
+	if h.snapshots == nil {
+		return
+	}
+	go h.snapshots.Refresh(context.WithoutCancel(ctx), trades)
+}
@@ -0,0 +1,183 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// placeExitOrders размещает ордер тейк-профита. При заданном стоп-лоссе и поддержке биржей
+// тейк-профит и стоп-лосс размещаются нативной OCO-парой; иначе стоп-лосс эмулируется
+// проверкой статусов (см. StatusCheckerUseCase.checkStopLoss) и ID ордера стоп-лосса пуст
+func (h *HedgeStrategyUseCase) placeExitOrders(ctx context.Context, takeProfit *entities.Order, stopLossPrice float64) (*entities.OrderResult, string, error) {
+	if stopLossPrice > 0 {
+		if oco, ok := h.exchangeService.(services.OCOExchangeService); ok {
+			result, err := oco.PlaceOCOOrder(ctx, takeProfit.Symbol, takeProfit.Quantity, takeProfit.Price, stopLossPrice)
+			if err != nil {
+				return nil, "", err
+			}
+			logger.LogWithTime("🔗 Размещена OCO-пара: тейк-профит %s, стоп-лосс %s",
+				result.TakeProfitOrderID, result.StopLossOrderID)
+			return &entities.OrderResult{OrderID: result.TakeProfitOrderID, Success: true}, result.StopLossOrderID, nil
+		}
+	}
+
+	result, err := h.exchangeService.PlaceOrder(ctx, takeProfit)
+	return result, "", err
+}
+
+// checkStopLoss проверяет стоп-лосс хеджа с активным тейк-профитом.
+// Нативный OCO: если стоп-лосс исполнен, отменяется тейк-профит (если биржа не сделала этого сама).
+// Эмуляция: при цене не выше стоп-лосса тейк-профит отменяется, а остаток продается по рынку
+// (с защитой цены закрытия - лимитными ордерами, см. CloseExecutor). Если исполненное количество
+// тейк-профита прочитать не удалось, продажа откладывается до следующего цикла.
+// Возвращает true, если хедж закрыт по стоп-лоссу
+func (s *StatusCheckerUseCase) checkStopLoss(ctx context.Context, trade *entities.HedgedTrade) (bool, error) {
+	pair := valueobjects.NewTradingPair(trade.Pair)
+	symbol := pair.ToBybitFormat()
+
+	if trade.StopLossOrderID != "" {
+		status, err := s.exchangeService.GetOrderStatus(ctx, trade.StopLossOrderID, symbol)
+		if err != nil {
+			return false, fmt.Errorf("ошибка получения статуса стоп-лосса %s: %w", trade.StopLossOrderID, err)
+		}
+		if status.Status != entities.OrderStatusFilled {
+			return false, nil
+		}
+
+		logger.LogWithTime("🛡️ Стоп-лосс %s (пара %s) исполнен - отменяем тейк-профит %s",
+			trade.StopLossOrderID, trade.Pair, trade.BybitOrderID)
+		s.events.RecordStatus(ctx, trade.Pair, entities.OrderStatusPending, status)
+		s.cancelSurvivor(ctx, trade.BybitOrderID, trade.Pair)
+
+		closePrice := trade.StopLossPrice
+		if status.FilledPrice != nil {
+			closePrice = *status.FilledPrice
+		}
+		exitFee := feeInQuote(status, pair, closePrice)
+		return true, s.closeByStopLoss(ctx, trade, trade.StopLossOrderID, closePrice, exitFee, status.FilledTime)
+	}
+
+	price, err := s.exchangeService.GetTickerPrice(ctx, symbol)
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения цены для стоп-лосса: %w", err)
+	}
+	if price > trade.StopLossPrice {
+		return false, nil
+	}
+
+	// Без статуса тейк-профита неизвестно, сколько уже продано: ничего не отменяем и повторяем в следующем цикле
+	status, err := s.exchangeService.GetOrderStatus(ctx, trade.BybitOrderID, symbol)
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения статуса тейк-профита %s: %w", trade.BybitOrderID, err)
+	}
+	if status.Status == entities.OrderStatusFilled {
+		// Тейк-профит исполнился раньше - обычная проверка статуса закроет хедж
+		return false, nil
+	}
+
+	// Тейк-профит уже отменен (предыдущая проверка не дочитала его статус после отмены) - продаем остаток
+	if !status.Status.IsCompleted() {
+		logger.LogWithTime("🛡️ Цена %s %.8f достигла стоп-лосса %.8f - отменяем тейк-профит %s и закрываем позицию",
+			trade.Pair, price, trade.StopLossPrice, trade.BybitOrderID)
+
+		cancelResult, err := s.exchangeService.CancelOrder(ctx, trade.BybitOrderID, symbol)
+		if err != nil {
+			return false, fmt.Errorf("ошибка отмены тейк-профита: %w", err)
+		}
+		if cancelResult.Success {
+			s.events.Record(ctx, trade.BybitOrderID, trade.Pair, trade.OrderStatus, entities.OrderStatusCancelled, 0, trade.HedgeTakeProfitPrice, cancelResult)
+		}
+
+		// Тейк-профит мог частично исполниться до отмены - перечитываем исполненное количество.
+		// При ошибке не продаем весь объем: следующий цикл увидит отмененный тейк-профит и продаст остаток
+		status, err = s.exchangeService.GetOrderStatus(ctx, trade.BybitOrderID, symbol)
+		if err != nil {
+			return false, fmt.Errorf("ошибка получения статуса отмененного тейк-профита %s, продажа по стоп-лоссу повторится в следующем цикле: %w",
+				trade.BybitOrderID, err)
+		}
+		if status.Status == entities.OrderStatusFilled {
+			return false, nil
+		}
+	}
+
+	// Тейк-профит мог частично исполниться - продаем только остаток
+	takeProfitFilled := status.FilledQty
+	quantity := trade.HedgeAmount - takeProfitFilled
+	exitFee := feeInQuote(status, pair, trade.HedgeTakeProfitPrice)
+	if quantity <= 0 {
+		return false, fmt.Errorf("нечего продавать по стоп-лоссу: количество %.8f", quantity)
+	}
+
+	fill, err := s.closer.Sell(ctx, trade.Pair, quantity, price, s.events)
+	if fill == nil {
+		return false, fmt.Errorf("ошибка продажи по стоп-лоссу: %w", err)
+	}
+	if err != nil {
+		logger.LogWithTime("⚠️ Стоп-лосс %s: продано %.8f из %.8f: %v", trade.Pair, fill.FilledQty, quantity, err)
+	}
+	exitFee += fill.Fee
+	logCloseFill(pair, fill)
+
+	// Средние цены закрытия (фактическая и по цене отметки) с учетом частично исполненного тейк-профита
+	closed := *trade
+	closed.CloseIntendedPrice = (takeProfitFilled*trade.HedgeTakeProfitPrice + quantity*fill.IntendedPrice) / trade.HedgeAmount
+	closePrice := (takeProfitFilled*trade.HedgeTakeProfitPrice + quantity*fill.Price) / trade.HedgeAmount
+
+	return true, s.closeByStopLoss(ctx, &closed, fill.OrderID, closePrice, exitFee, nil)
+}
+
+// cancelSurvivor отменяет оставшийся ордер OCO-пары после исполнения другого
+func (s *StatusCheckerUseCase) cancelSurvivor(ctx context.Context, orderID, pair string) {
+	symbol := valueobjects.NewTradingPair(pair).ToBybitFormat()
+	status, err := s.exchangeService.GetOrderStatus(ctx, orderID, symbol)
+	if err == nil && status.Status.IsCompleted() {
+		return
+	}
+
+	result, err := s.exchangeService.CancelOrder(ctx, orderID, symbol)
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось отменить ордер %s OCO-пары: %v", orderID, err)
+		return
+	}
+	if !result.Success {
+		logger.LogWithTime("⚠️ Не удалось отменить ордер %s OCO-пары: %s", orderID, result.Error)
+		return
+	}
+	logger.LogWithTime("🔗 Ордер %s OCO-пары отменен", orderID)
+	s.events.Record(ctx, orderID, pair, entities.OrderStatusPending, entities.OrderStatusCancelled, 0, 0, result)
+}
+
+// closeByStopLoss отмечает хедж закрытым по стоп-лоссу; exitFee - комиссия продажи в котируемой валюте
+func (s *StatusCheckerUseCase) closeByStopLoss(ctx context.Context, trade *entities.HedgedTrade, stopLossOrderID string, closePrice, exitFee float64, closeTime *time.Time) error {
+	now := time.Now()
+	if closeTime == nil {
+		closeTime = &now
+	}
+
+	closed := *trade
+	closed.StopLossOrderID = stopLossOrderID
+	closed.OrderStatus = entities.OrderStatusFilled
+	closed.LastStatusCheck = &now
+	closed.ClosePrice = &closePrice
+	closed.CloseTime = closeTime
+	closed.ExitFee = exitFee
+	if err := s.hedgeRepo.UpdateHedgedTrade(ctx, trade.BybitOrderID, &closed); err != nil {
+		return fmt.Errorf("ошибка сохранения закрытия по стоп-лоссу: %w", err)
+	}
+	closeHedgeIntentByOrderID(ctx, s.intentRepo, trade.BybitOrderID)
+
+	if profit := closed.CalculateProfit(); profit != nil {
+		pair := valueobjects.NewTradingPair(trade.Pair)
+		logger.LogWithTime("🛡️ Хедж закрыт по стоп-лоссу по цене %s, результат: %s %s (после комиссий %s %s)", pair.FormatPrice(closePrice),
+			valueobjects.FormatAmount(profit.Gross, pair.QuoteCurrency()), pair.QuoteCurrency(),
+			valueobjects.FormatAmount(profit.Net, pair.QuoteCurrency()), pair.QuoteCurrency())
+	}
+	s.notifyHedgeClosed(ctx, &closed, "stop_loss")
+	return nil
+}
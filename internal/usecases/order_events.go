@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// OrderEventRecorder записывает историю событий ордеров хеджей для аудита.
+// Нулевой рекордер (nil или без репозитория) события не записывает
+type OrderEventRecorder struct {
+	repo repositories.OrderEventRepository
+}
+
+// NewOrderEventRecorder создает рекордер событий ордеров
+func NewOrderEventRecorder(repo repositories.OrderEventRepository) *OrderEventRecorder {
+	return &OrderEventRecorder{repo: repo}
+}
+
+// Record сохраняет событие ордера; payload сериализуется в JSON, необработанный ответ биржи
+// берется из payload (результат размещения или статус ордера). Ошибки записи не прерывают торговый поток
+func (r *OrderEventRecorder) Record(ctx context.Context, orderID, pair string, oldStatus, newStatus entities.OrderStatus, filledQty, price float64, payload interface{}) {
+	r.save(ctx, newOrderEvent(orderID, pair, oldStatus, newStatus, filledQty, price, payload))
+}
+
+// save сохраняет событие, ошибка записи только логируется
+func (r *OrderEventRecorder) save(ctx context.Context, event *entities.OrderEvent) {
+	if r == nil || r.repo == nil || event.OrderID == "" {
+		return
+	}
+	if err := r.repo.SaveOrderEvent(ctx, event); err != nil {
+		logger.LogWithTime("⚠️ Не удалось сохранить событие ордера %s: %v", event.OrderID, err)
+	}
+}
+
+// Save сохраняет подготовленные события и возвращает первую ошибку записи.
+// Используется в транзакции с сохранением хеджа: ошибка откатывает и хедж
+func (r *OrderEventRecorder) Save(ctx context.Context, events []*entities.OrderEvent) error {
+	if r == nil || r.repo == nil {
+		return nil
+	}
+	for _, event := range events {
+		if err := r.repo.SaveOrderEvent(ctx, event); err != nil {
+			return fmt.Errorf("ошибка сохранения события ордера %s: %w", event.OrderID, err)
+		}
+	}
+	return nil
+}
+
+// newOrderEvent создает событие ордера; payload сериализуется в JSON
+func newOrderEvent(orderID, pair string, oldStatus, newStatus entities.OrderStatus, filledQty, price float64, payload interface{}) *entities.OrderEvent {
+	var raw string
+	if payload != nil {
+		if data, err := json.Marshal(payload); err == nil {
+			raw = string(data)
+		}
+	}
+	event := entities.NewOrderEvent(orderID, pair, oldStatus, newStatus, filledQty, price, raw)
+	event.RawPayload = rawExchangeResponse(payload)
+	return event
+}
+
+// rawExchangeResponse возвращает необработанный ответ биржи из данных события, если он есть
+func rawExchangeResponse(payload interface{}) string {
+	switch value := payload.(type) {
+	case *entities.OrderResult:
+		if value != nil {
+			return value.RawResponse
+		}
+	case *services.OrderStatusInfo:
+		if value != nil {
+			return value.RawResponse
+		}
+	}
+	return ""
+}
+
+// RecordStatus сохраняет смену статуса ордера по ответу биржи; цена - средняя цена исполнения
+func (r *OrderEventRecorder) RecordStatus(ctx context.Context, pair string, oldStatus entities.OrderStatus, status *services.OrderStatusInfo) {
+	if status == nil {
+		return
+	}
+
+	var price float64
+	if status.FilledPrice != nil {
+		price = *status.FilledPrice
+	}
+	r.Record(ctx, status.OrderID, pair, oldStatus, status.Status, status.FilledQty, price, status)
+}
+
+// RecordPlaced сохраняет размещение ордера с ответом биржи. Отклоненное размещение сохраняется
+// как событие REJECTED под клиентским ID ордера (ID биржи у него нет); без клиентского ID - не сохраняется
+func (r *OrderEventRecorder) RecordPlaced(ctx context.Context, pair string, order *entities.Order, result *entities.OrderResult) {
+	if result == nil {
+		return
+	}
+	r.save(ctx, placedOrderEvent(pair, order, result))
+}
+
+// placedOrderEvent создает событие размещения ордера: PENDING при успехе, REJECTED при отказе биржи
+func placedOrderEvent(pair string, order *entities.Order, result *entities.OrderResult) *entities.OrderEvent {
+	orderID, status := result.OrderID, entities.OrderStatusPending
+	if !result.Success {
+		orderID, status = order.ClientOrderID, entities.OrderStatusRejected
+	}
+	event := newOrderEvent(orderID, pair, "", status, 0, order.Price, order)
+	event.RawPayload = result.RawResponse
+	return event
+}
@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// OrderFillWaiter ожидает исполнения ордера, периодически опрашивая биржу
+type OrderFillWaiter struct {
+	exchangeService services.ExchangeService
+	timeout         time.Duration
+	pollInterval    time.Duration
+}
+
+// NewOrderFillWaiter создает новый ожидатель исполнения ордера
+func NewOrderFillWaiter(exchangeService services.ExchangeService, timeout, pollInterval time.Duration) *OrderFillWaiter {
+	return &OrderFillWaiter{
+		exchangeService: exchangeService,
+		timeout:         timeout,
+		pollInterval:    pollInterval,
+	}
+}
+
+// WaitForFill ожидает полного исполнения ордера.
+// При превышении времени ожидания возвращает последний полученный статус вместе с ошибкой
+// ErrorTypeOrderFillTimeout, чтобы вызывающий код мог принять решение о частичном исполнении
+func (w *OrderFillWaiter) WaitForFill(ctx context.Context, orderID, symbol string) (*services.OrderStatusInfo, error) {
+	deadline := time.Now().Add(w.timeout)
+	var lastStatus *services.OrderStatusInfo
+
+	for attempt := 1; ; attempt++ {
+		if err := sleepWithContext(ctx, w.pollInterval); err != nil {
+			return lastStatus, fmt.Errorf("ожидание исполнения ордера %s прервано: %w", orderID, err)
+		}
+
+		status, err := w.exchangeService.GetOrderStatus(ctx, orderID, symbol)
+		if err != nil {
+			logger.LogWithTime("⚠️ Попытка %d получения статуса ордера: %v", attempt, err)
+		} else {
+			lastStatus = status
+
+			switch {
+			case status.Status == entities.OrderStatusFilled:
+				logger.LogWithTime("✅ Ордер %s полностью исполнен!", orderID)
+				return status, nil
+			case status.Status == entities.OrderStatusPartiallyFilled:
+				logger.LogWithTime("⏳ Частичное исполнение: %v, остаток %v", status.FilledQty, status.RemainingQty)
+			case status.Status.IsCompleted():
+				return status, fmt.Errorf("ордер %s завершен неуспешно: %s", orderID, status.Status)
+			}
+		}
+
+		if !time.Now().Before(deadline) {
+			return lastStatus, errors.NewOrderFillTimeoutError(orderID, w.timeout)
+		}
+	}
+}
+
+// sleepWithContext приостанавливает выполнение с учетом отмены контекста
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
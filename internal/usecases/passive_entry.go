@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// Итоги мейкерской попытки покупки (флаг entry хеджа)
+const (
+	PassiveEntryFilled  = "passive" // Покупка полностью исполнена мейкером
+	PassiveEntryPartial = "partial" // Часть исполнена мейкером, остаток докуплен по рынку
+	PassiveEntryMissed  = "crossed" // Мейкерский ордер не исполнился, покупка по рынку
+)
+
+// passiveEntry мейкерская попытка покупки: ордер PostOnly по лучшей цене покупки стакана
+type passiveEntry struct {
+	bid float64 // Цена мейкерского ордера (лучшая цена покупки, округленная вниз до шага цены)
+	ask float64 // Лучшая цена продажи в момент размещения - цена немедленной покупки
+}
+
+// preparePassiveEntry возвращает мейкерскую попытку для покупки, если она включена (strategy.passive_entry_timeout)
+// и биржа сообщает лучшие цены стакана. nil - покупка обычным лимитным ордером
+func (h *HedgeStrategyUseCase) preparePassiveEntry(ctx context.Context, symbol string, rules valueobjects.InstrumentRules) *passiveEntry {
+	if h.config.PassiveEntryTimeout <= 0 {
+		return nil
+	}
+	bookService, ok := h.exchangeService.(services.BookTickerExchangeService)
+	if !ok {
+		return nil
+	}
+
+	book, err := bookService.GetBookTicker(ctx, symbol)
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось получить стакан %s для мейкерской покупки: %v", symbol, err)
+		return nil
+	}
+	bid := valueobjects.NewPrice(book.Bid, rules, valueobjects.RoundFloor).Float64()
+	if bid <= 0 || book.Ask <= 0 {
+		return nil
+	}
+	return &passiveEntry{bid: bid, ask: book.Ask}
+}
+
+// PassiveEntryStats статистика мейкерских попыток покупки с запуска процесса
+type PassiveEntryStats struct {
+	Attempts    int     `json:"attempts"`
+	Filled      int     `json:"filled"`       // Полностью исполнены мейкером
+	Partial     int     `json:"partial"`      // Частично исполнены мейкером, остаток - по рынку
+	Missed      int     `json:"missed"`       // Не исполнены мейкером (в том числе отклонены как PostOnly)
+	SuccessRate float64 `json:"success_rate"` // Доля попыток с мейкерским исполнением (полным или частичным), %
+
+	PassiveNotional float64 `json:"passive_notional"` // Куплено мейкером, в котируемой валюте
+	PassiveFees     float64 `json:"passive_fees"`     // Комиссии мейкерских исполнений
+	CrossedNotional float64 `json:"crossed_notional"` // Докуплено по рынку
+	CrossedFees     float64 `json:"crossed_fees"`     // Комиссии рыночных исполнений
+
+	// PriceSaving экономия в цене: мейкерское количество, купленное дешевле лучшей цены продажи в момент размещения
+	PriceSaving float64 `json:"price_saving"`
+	// FeeSaving оценка экономии на комиссии: мейкерский объем по разнице ставок комиссии рыночных и мейкерских
+	// исполнений (0, пока нет исполнений обоих видов)
+	FeeSaving float64 `json:"fee_saving"`
+}
+
+// passiveEntryTracker накапливает итоги мейкерских попыток покупки
+type passiveEntryTracker struct {
+	mu    sync.Mutex
+	stats PassiveEntryStats
+}
+
+// record учитывает итог попытки: passiveStatus - мейкерская часть после отмены остатка,
+// finalStatus - покупка целиком с рыночной докупкой. Возвращает итог попытки (PassiveEntry*)
+func (t *passiveEntryTracker) record(entry *passiveEntry, pair *valueobjects.TradingPair, passiveStatus, finalStatus *services.OrderStatusInfo) string {
+	var passiveQty, passiveNotional, passiveFee float64
+	if passiveStatus != nil && passiveStatus.FilledQty > 0 {
+		price := entry.bid
+		if passiveStatus.FilledPrice != nil && *passiveStatus.FilledPrice > 0 {
+			price = *passiveStatus.FilledPrice
+		}
+		passiveQty = passiveStatus.FilledQty
+		passiveNotional = passiveQty * price
+		passiveFee = feeInQuote(passiveStatus, pair, price)
+	}
+
+	var crossedNotional, crossedFee float64
+	if finalStatus != nil && finalStatus.FilledQty > passiveQty && finalStatus.FilledPrice != nil {
+		crossedNotional = finalStatus.FilledQty**finalStatus.FilledPrice - passiveNotional
+		crossedFee = feeInQuote(finalStatus, pair, *finalStatus.FilledPrice) - passiveFee
+	}
+
+	outcome := PassiveEntryMissed
+	switch {
+	case passiveQty > 0 && passiveStatus.Status == entities.OrderStatusFilled:
+		outcome = PassiveEntryFilled
+	case passiveQty > 0:
+		outcome = PassiveEntryPartial
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := &t.stats
+	s.Attempts++
+	switch outcome {
+	case PassiveEntryFilled:
+		s.Filled++
+	case PassiveEntryPartial:
+		s.Partial++
+	default:
+		s.Missed++
+	}
+	s.SuccessRate = float64(s.Filled+s.Partial) / float64(s.Attempts) * 100
+
+	s.PassiveNotional += passiveNotional
+	s.PassiveFees += passiveFee
+	if crossedNotional > 0 {
+		s.CrossedNotional += crossedNotional
+		s.CrossedFees += crossedFee
+	}
+	if passiveQty > 0 {
+		s.PriceSaving += passiveQty*entry.ask - passiveNotional
+	}
+	if s.PassiveNotional > 0 && s.CrossedNotional > 0 {
+		s.FeeSaving = s.PassiveNotional * (s.CrossedFees/s.CrossedNotional - s.PassiveFees/s.PassiveNotional)
+	}
+	return outcome
+}
+
+// snapshot возвращает копию накопленной статистики
+func (t *passiveEntryTracker) snapshot() PassiveEntryStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stats
+}
+
+// PassiveEntryStats возвращает статистику мейкерских попыток покупки (strategy.passive_entry_timeout)
+func (h *HedgeStrategyUseCase) PassiveEntryStats() PassiveEntryStats {
+	return h.passiveEntries.snapshot()
+}
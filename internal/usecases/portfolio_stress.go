@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// PortfolioStress показатели нагрузки на портфель Freqtrade
+type PortfolioStress struct {
+	LosingTrades int     // Количество открытых убыточных сделок
+	Loss         float64 // Суммарный нереализованный убыток убыточных сделок (положительное число)
+}
+
+// MeasurePortfolioStress считает убыточные сделки и их суммарный нереализованный убыток
+func MeasurePortfolioStress(trades []*entities.Trade) PortfolioStress {
+	var stress PortfolioStress
+	for _, trade := range trades {
+		if trade.ProfitRatio >= 0 {
+			continue
+		}
+		stress.LosingTrades++
+		if profit := trade.UnrealizedProfit(); profit < 0 {
+			stress.Loss -= profit
+		}
+	}
+	return stress
+}
+
+// checkPortfolioStress разрешает хеджирование, только если портфель под нагрузкой: убыточных сделок
+// больше MinLosingTrades или их суммарный убыток больше MinPortfolioLoss. Без заданных условий - всегда разрешает
+func (h *HedgeStrategyUseCase) checkPortfolioStress(trades []*entities.Trade) error {
+	if h.config.MinLosingTrades <= 0 && h.config.MinPortfolioLoss <= 0 {
+		return nil
+	}
+
+	stress := MeasurePortfolioStress(trades)
+	byCount := h.config.MinLosingTrades > 0 && stress.LosingTrades > h.config.MinLosingTrades
+	byLoss := h.config.MinPortfolioLoss > 0 && stress.Loss > h.config.MinPortfolioLoss
+	if byCount || byLoss {
+		logger.LogWithTime("🌡️ Портфель под нагрузкой: убыточных сделок %d, суммарный убыток %.2f %s",
+			stress.LosingTrades, stress.Loss, h.config.BaseCurrency)
+		return nil
+	}
+
+	return errors.NewPortfolioCalmError(stress.LosingTrades, h.config.MinLosingTrades,
+		stress.Loss, h.config.MinPortfolioLoss, h.config.BaseCurrency)
+}
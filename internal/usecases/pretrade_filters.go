@@ -0,0 +1,560 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// Фильтры перед хеджированием (strategy.filters)
+const (
+	FilterBlacklist        = "blacklist"         // Пара в черном списке strategy.blacklist_pairs
+	FilterBudget           = "budget"            // Лимиты риска (общий, по паре, по котируемой валюте)
+	FilterPriceDeviation   = "price_deviation"   // Цена Freqtrade расходится с ценой биржи
+	FilterSpread           = "spread"            // Спред стакана шире strategy.max_spread_percent
+	FilterVolatility       = "volatility"        // Размах цены за окно выше strategy.max_volatility_percent
+	FilterBalance          = "balance"           // Недостаточно свободного баланса базовой валюты
+	FilterInstrumentStatus = "instrument_status" // Инструмент не торгуется
+	FilterMinLimit         = "min_limit"         // Сумма или количество вне лимитов инструмента
+)
+
+// DefaultPreTradeFilters порядок фильтров по умолчанию: сначала проверки без обращений к бирже,
+// баланс резервируется после проверок рынка, лимиты инструмента проверяются последними
+var DefaultPreTradeFilters = []string{
+	FilterBlacklist,
+	FilterBudget,
+	FilterPriceDeviation,
+	FilterSpread,
+	FilterVolatility,
+	FilterBalance,
+	FilterInstrumentStatus,
+	FilterMinLimit,
+}
+
+// IsPreTradeFilter проверяет, что фильтр с таким названием существует
+func IsPreTradeFilter(name string) bool {
+	for _, filter := range DefaultPreTradeFilters {
+		if filter == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PreTradeFilterNames возвращает порядок фильтров для стратегии: список из strategy.filters_by_strategy,
+// иначе strategy.filters, иначе порядок по умолчанию
+func PreTradeFilterNames(config *HedgeStrategyConfig) []string {
+	if names := config.FiltersByStrategy[config.StrategyName]; len(names) > 0 {
+		return names
+	}
+	if len(config.Filters) > 0 {
+		return config.Filters
+	}
+	return DefaultPreTradeFilters
+}
+
+// FilterVerdict решение фильтра по сделке
+type FilterVerdict struct {
+	Filter string `json:"filter"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"` // Причина отказа или пропуска проверки
+	Err    error  `json:"-"`                // Ошибка отказа (StrategyError) или ошибка получения данных
+}
+
+// passVerdict сделка прошла фильтр; reason поясняет, почему проверка не выполнялась
+func passVerdict(filter, reason string) FilterVerdict {
+	return FilterVerdict{Filter: filter, Passed: true, Reason: reason}
+}
+
+// rejectVerdict фильтр отклонил сделку с ошибкой err
+func rejectVerdict(filter string, err error) FilterVerdict {
+	return FilterVerdict{Filter: filter, Reason: err.Error(), Err: err}
+}
+
+// OrderSizing размер ордера на покупку с ограничениями инструмента
+type OrderSizing struct {
+	Rules         valueobjects.InstrumentRules // Ограничения инструмента с безопасными минимумами
+	RawQuantity   float64                      // Количество до округления
+	Quantity      valueobjects.Quantity        // Количество, округленное до шага
+	MinOrderValue float64                      // Минимальная сумма ордера
+	MinOrderQty   float64                      // Минимальное количество
+}
+
+// PreTradeCheck данные сделки, проверяемой фильтрами. Фильтры дополняют ее результатами
+// (баланс, информация об инструменте), которые затем используются при размещении ордера
+type PreTradeCheck struct {
+	Trade          *entities.Trade
+	Pair           *valueobjects.TradingPair
+	Symbol         string
+	PositionAmount float64 // Сумма позиции, выделенная стратегией
+	BaseCurrency   string
+
+	Balance        *entities.Balance        // Баланс базовой валюты (заполняет фильтр balance)
+	RequiredAmount float64                  // Сумма с запасом на проскальзывание (заполняет фильтр balance)
+	Instrument     *services.InstrumentInfo // Информация об инструменте (загружается при первом обращении)
+
+	exchange         services.ExchangeService
+	quantityRounding RoundingPolicy
+	sizing           *OrderSizing
+	releases         []func()
+}
+
+// NewPreTradeCheck создает проверку сделки на сумму positionAmount
+func NewPreTradeCheck(trade *entities.Trade, positionAmount float64, baseCurrency string,
+	exchange services.ExchangeService, quantityRounding RoundingPolicy) *PreTradeCheck {
+	pair := valueobjects.NewTradingPair(trade.Pair)
+	return &PreTradeCheck{
+		Trade:            trade,
+		Pair:             pair,
+		Symbol:           pair.ToBybitFormat(),
+		PositionAmount:   positionAmount,
+		BaseCurrency:     baseCurrency,
+		exchange:         exchange,
+		quantityRounding: quantityRounding,
+	}
+}
+
+// Hold запоминает функцию освобождения ресурса, занятого фильтром (резерв баланса, место в лимите риска)
+func (c *PreTradeCheck) Hold(release func()) {
+	c.releases = append(c.releases, release)
+}
+
+// Release освобождает занятые фильтрами ресурсы в обратном порядке; повторный вызов ничего не делает
+func (c *PreTradeCheck) Release() {
+	for i := len(c.releases) - 1; i >= 0; i-- {
+		c.releases[i]()
+	}
+	c.releases = nil
+}
+
+// loadInstrument загружает информацию об инструменте; при ошибке биржи - безопасные значения по умолчанию
+func (c *PreTradeCheck) loadInstrument(ctx context.Context) *services.InstrumentInfo {
+	if c.Instrument != nil {
+		return c.Instrument
+	}
+
+	instrumentInfo, err := c.exchange.GetInstrumentInfo(ctx, c.Symbol)
+	if err != nil {
+		logger.LogWithTime("⚠️ Не удалось получить информацию об инструменте %s: %v", c.Symbol, err)
+		logger.LogWithTime("💡 Используем безопасное значение по умолчанию: 100 USDT")
+		instrumentInfo = &services.InstrumentInfo{
+			MinOrderAmt: 100.0,
+		}
+	}
+	c.Instrument = instrumentInfo
+	return instrumentInfo
+}
+
+// Sizing рассчитывает количество для покупки на сумму позиции с ограничениями инструмента
+func (c *PreTradeCheck) Sizing(ctx context.Context) *OrderSizing {
+	if c.sizing != nil {
+		return c.sizing
+	}
+	instrumentInfo := c.loadInstrument(ctx)
+
+	// Проверяем корректность полученного минимального лимита
+	minOrderValue := instrumentInfo.MinOrderAmt
+	if minOrderValue <= 0 {
+		logger.LogWithTime("⚠️ ВНИМАНИЕ: Bybit вернул некорректный минимальный лимит: %.2f USDT", minOrderValue)
+		logger.LogWithTime("💡 Используем безопасное значение по умолчанию: 100 USDT")
+		minOrderValue = 100.0
+	}
+
+	// Проверяем минимальное количество валюты
+	minOrderQty := instrumentInfo.MinOrderQty
+	if minOrderQty <= 0 {
+		logger.LogWithTime("⚠️ ВНИМАНИЕ: Bybit вернул некорректное минимальное количество: %.6f", minOrderQty)
+		logger.LogWithTime("💡 Используем безопасное значение по умолчанию: 0.001")
+		minOrderQty = 0.001
+	}
+
+	// Ограничения инструмента с безопасными значениями по умолчанию
+	rules := instrumentInfo.Rules()
+	rules.MinAmount = minOrderValue
+	rules.MinQty = minOrderQty
+
+	// Рассчитываем количество валюты для покупки на фиксированную сумму и округляем до шага инструмента
+	rawQuantity := entities.CalculateQuantityFromAmount(c.PositionAmount, c.Trade.CurrentRate)
+	quantity := valueobjects.NewQuantity(rawQuantity, rules, c.quantityRounding.Mode())
+	if rules.StepSize > 0 {
+		logger.LogWithTime("🔧 Количество скорректировано до шага %.6f (%s): %.6f → %s",
+			rules.StepSize, c.quantityRounding.Name(), rawQuantity, quantity)
+	}
+
+	c.sizing = &OrderSizing{
+		Rules:         rules,
+		RawQuantity:   rawQuantity,
+		Quantity:      quantity,
+		MinOrderValue: minOrderValue,
+		MinOrderQty:   minOrderQty,
+	}
+	return c.sizing
+}
+
+// PreTradeFilter проверка сделки перед размещением ордера на покупку
+type PreTradeFilter interface {
+	// Name возвращает название фильтра (значение strategy.filters)
+	Name() string
+
+	// Check проверяет сделку. Отказ с ошибкой StrategyError касается только пары,
+	// прочие ошибки (недоступна биржа) прерывают цикл
+	Check(ctx context.Context, check *PreTradeCheck) FilterVerdict
+}
+
+// PreTradePipeline цепочка фильтров перед хеджированием
+type PreTradePipeline struct {
+	filters []PreTradeFilter
+}
+
+// NewPreTradePipeline создает цепочку фильтров, проверяемых по порядку
+func NewPreTradePipeline(filters ...PreTradeFilter) *PreTradePipeline {
+	return &PreTradePipeline{filters: filters}
+}
+
+// Names возвращает названия фильтров цепочки по порядку
+func (p *PreTradePipeline) Names() []string {
+	names := make([]string, 0, len(p.filters))
+	for _, filter := range p.filters {
+		names = append(names, filter.Name())
+	}
+	return names
+}
+
+// Run проверяет сделку фильтрами по порядку до первого отказа и возвращает решения проверенных фильтров.
+// При отказе ресурсы, занятые предыдущими фильтрами, освобождаются; при успехе их освобождает вызывающий (check.Release)
+func (p *PreTradePipeline) Run(ctx context.Context, check *PreTradeCheck) ([]FilterVerdict, error) {
+	verdicts := make([]FilterVerdict, 0, len(p.filters))
+	for _, filter := range p.filters {
+		verdict := filter.Check(ctx, check)
+		verdicts = append(verdicts, verdict)
+		if verdict.Passed {
+			continue
+		}
+
+		check.Release()
+		logger.LogWithTime("🚫 Фильтр %s отклонил пару %s: %s", verdict.Filter, check.Pair.String(), verdict.Reason)
+		return verdicts, verdict.Err
+	}
+	return verdicts, nil
+}
+
+// newPreTradePipeline собирает цепочку фильтров из конфигурации стратегии; неизвестные названия пропускаются
+func newPreTradePipeline(h *HedgeStrategyUseCase) *PreTradePipeline {
+	config := h.config
+	var filters []PreTradeFilter
+	for _, name := range PreTradeFilterNames(config) {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case FilterBlacklist:
+			filters = append(filters, NewBlacklistFilter(config.BlacklistPairs))
+		case FilterBudget:
+			filters = append(filters, NewBudgetFilter(h.riskManager))
+		case FilterPriceDeviation:
+			filters = append(filters, NewPriceDeviationFilter(h.exchangeService, config.MaxPriceDeviationPercent))
+		case FilterSpread:
+			filters = append(filters, NewSpreadFilter(h.exchangeService, config.MaxSpreadPercent))
+		case FilterVolatility:
+			filters = append(filters, NewVolatilityFilter(h.exchangeService, config.MaxVolatilityPercent, config.VolatilityWindow))
+		case FilterBalance:
+			filters = append(filters, NewBalanceFilter(h.exchangeService, h.balanceReservation, config.SlippageBufferPercent))
+		case FilterInstrumentStatus:
+			filters = append(filters, NewInstrumentStatusFilter())
+		case FilterMinLimit:
+			filters = append(filters, NewMinLimitFilter())
+		}
+	}
+	return NewPreTradePipeline(filters...)
+}
+
+// BlacklistFilter отклоняет пары из черного списка
+type BlacklistFilter struct {
+	pairs map[string]bool // Пары в верхнем регистре
+}
+
+// NewBlacklistFilter создает фильтр по списку пар (BTC/USDT)
+func NewBlacklistFilter(pairs []string) *BlacklistFilter {
+	blacklist := make(map[string]bool, len(pairs))
+	for _, pair := range pairs {
+		blacklist[strings.ToUpper(strings.TrimSpace(pair))] = true
+	}
+	return &BlacklistFilter{pairs: blacklist}
+}
+
+// Name возвращает название фильтра
+func (f *BlacklistFilter) Name() string { return FilterBlacklist }
+
+// Check отклоняет пару из черного списка (пара проверяется на рынке хеджа, после перевода котируемой валюты)
+func (f *BlacklistFilter) Check(ctx context.Context, check *PreTradeCheck) FilterVerdict {
+	if !f.pairs[strings.ToUpper(check.Trade.Pair)] {
+		return passVerdict(f.Name(), "")
+	}
+	return rejectVerdict(f.Name(), errors.NewPreTradeFilterError(check.Trade.Pair, f.Name(), "пара в черном списке"))
+}
+
+// BudgetFilter проверяет лимиты риска и занимает место под хедж до его сохранения
+type BudgetFilter struct {
+	riskManager *RiskManager
+}
+
+// NewBudgetFilter создает фильтр лимитов риска
+func NewBudgetFilter(riskManager *RiskManager) *BudgetFilter {
+	return &BudgetFilter{riskManager: riskManager}
+}
+
+// Name возвращает название фильтра
+func (f *BudgetFilter) Name() string { return FilterBudget }
+
+// Check занимает сумму позиции в лимитах риска; место освобождается вместе с проверкой
+func (f *BudgetFilter) Check(ctx context.Context, check *PreTradeCheck) FilterVerdict {
+	release, err := f.riskManager.Admit(ctx, check.Trade.Pair, check.PositionAmount)
+	if err != nil {
+		return rejectVerdict(f.Name(), err)
+	}
+	check.Hold(release)
+	return passVerdict(f.Name(), "")
+}
+
+// PriceDeviationFilter защищает от устаревших данных Freqtrade: сверяет цену сделки с текущим тикером биржи
+type PriceDeviationFilter struct {
+	exchange   services.ExchangeService
+	maxPercent float64
+}
+
+// NewPriceDeviationFilter создает фильтр отклонения цены (maxPercent <= 0 - без проверки)
+func NewPriceDeviationFilter(exchange services.ExchangeService, maxPercent float64) *PriceDeviationFilter {
+	return &PriceDeviationFilter{exchange: exchange, maxPercent: maxPercent}
+}
+
+// Name возвращает название фильтра
+func (f *PriceDeviationFilter) Name() string { return FilterPriceDeviation }
+
+// Check отказывает в покупке, если отклонение цены Freqtrade от последней цены биржи превышает лимит
+func (f *PriceDeviationFilter) Check(ctx context.Context, check *PreTradeCheck) FilterVerdict {
+	trade := check.Trade
+	if f.maxPercent <= 0 || trade.CurrentRate <= 0 {
+		return passVerdict(f.Name(), "проверка отключена")
+	}
+
+	tickerPrice, err := f.exchange.GetTickerPrice(ctx, check.Symbol)
+	if err != nil {
+		return rejectVerdict(f.Name(), fmt.Errorf("ошибка получения текущей цены %s: %w", check.Symbol, err))
+	}
+
+	deviationPercent := math.Abs(tickerPrice-trade.CurrentRate) / tickerPrice * 100
+	if deviationPercent > f.maxPercent {
+		logger.LogWithTime("⚠️ Цена %s во Freqtrade (%.8f) отличается от биржевой (%.8f) на %.2f%%",
+			trade.Pair, trade.CurrentRate, tickerPrice, deviationPercent)
+		return rejectVerdict(f.Name(), errors.NewPriceDeviationError(trade.Pair, trade.CurrentRate, tickerPrice,
+			deviationPercent, f.maxPercent))
+	}
+	return passVerdict(f.Name(), "")
+}
+
+// SpreadFilter отклоняет пары с широким спредом: лимитная покупка в тонком стакане исполняется плохо
+type SpreadFilter struct {
+	exchange   services.ExchangeService
+	maxPercent float64
+}
+
+// NewSpreadFilter создает фильтр спреда (maxPercent <= 0 - без проверки)
+func NewSpreadFilter(exchange services.ExchangeService, maxPercent float64) *SpreadFilter {
+	return &SpreadFilter{exchange: exchange, maxPercent: maxPercent}
+}
+
+// Name возвращает название фильтра
+func (f *SpreadFilter) Name() string { return FilterSpread }
+
+// Check сравнивает спред лучших цен стакана с лимитом; без стакана у биржи проверка пропускается
+func (f *SpreadFilter) Check(ctx context.Context, check *PreTradeCheck) FilterVerdict {
+	if f.maxPercent <= 0 {
+		return passVerdict(f.Name(), "проверка отключена")
+	}
+	bookTickers, ok := f.exchange.(services.BookTickerExchangeService)
+	if !ok {
+		return passVerdict(f.Name(), "биржа не предоставляет цены стакана")
+	}
+
+	ticker, err := bookTickers.GetBookTicker(ctx, check.Symbol)
+	if err != nil {
+		return rejectVerdict(f.Name(), fmt.Errorf("ошибка получения стакана %s: %w", check.Symbol, err))
+	}
+
+	spreadPercent := ticker.SpreadPercent()
+	if spreadPercent > f.maxPercent {
+		reason := fmt.Sprintf("спред %.3f%% (bid %.8f, ask %.8f) при лимите %.3f%%",
+			spreadPercent, ticker.Bid, ticker.Ask, f.maxPercent)
+		return rejectVerdict(f.Name(), errors.NewPreTradeFilterError(check.Trade.Pair, f.Name(), reason))
+	}
+	return passVerdict(f.Name(), "")
+}
+
+// VolatilityFilter отклоняет пары с большим размахом цены за окно: тейк-профит по такой цене ненадежен
+type VolatilityFilter struct {
+	exchange   services.ExchangeService
+	maxPercent float64
+	window     time.Duration
+}
+
+// NewVolatilityFilter создает фильтр волатильности по часовым свечам за окно (maxPercent <= 0 - без проверки)
+func NewVolatilityFilter(exchange services.ExchangeService, maxPercent float64, window time.Duration) *VolatilityFilter {
+	if window <= 0 {
+		window = 24 * time.Hour
+	}
+	return &VolatilityFilter{exchange: exchange, maxPercent: maxPercent, window: window}
+}
+
+// Name возвращает название фильтра
+func (f *VolatilityFilter) Name() string { return FilterVolatility }
+
+// Check сравнивает размах цены (максимум к минимуму свечей окна) с лимитом
+func (f *VolatilityFilter) Check(ctx context.Context, check *PreTradeCheck) FilterVerdict {
+	if f.maxPercent <= 0 {
+		return passVerdict(f.Name(), "проверка отключена")
+	}
+
+	now := time.Now()
+	klines, err := f.exchange.GetKlines(ctx, check.Symbol, now.Add(-f.window), now)
+	if err != nil {
+		return rejectVerdict(f.Name(), fmt.Errorf("ошибка получения свечей %s: %w", check.Symbol, err))
+	}
+
+	rangePercent, ok := klineRangePercent(klines)
+	if !ok {
+		return passVerdict(f.Name(), "нет свечей за окно")
+	}
+	if rangePercent > f.maxPercent {
+		reason := fmt.Sprintf("размах цены %.2f%% за %v при лимите %.2f%%", rangePercent, f.window, f.maxPercent)
+		return rejectVerdict(f.Name(), errors.NewPreTradeFilterError(check.Trade.Pair, f.Name(), reason))
+	}
+	return passVerdict(f.Name(), "")
+}
+
+// klineRangePercent возвращает размах цены свечей: (максимум - минимум) / минимум в процентах
+func klineRangePercent(klines []*entities.Kline) (float64, bool) {
+	low, high := math.Inf(1), 0.0
+	for _, kline := range klines {
+		if kline.Low > 0 {
+			low = math.Min(low, kline.Low)
+		}
+		high = math.Max(high, kline.High)
+	}
+	if math.IsInf(low, 1) || high <= 0 {
+		return 0, false
+	}
+	return (high - low) / low * 100, true
+}
+
+// BalanceFilter проверяет свободный баланс базовой валюты и резервирует сумму позиции
+type BalanceFilter struct {
+	exchange              services.ExchangeService
+	reservation           *BalanceReservation
+	slippageBufferPercent float64
+}
+
+// NewBalanceFilter создает фильтр баланса с запасом на проскальзывание в процентах
+func NewBalanceFilter(exchange services.ExchangeService, reservation *BalanceReservation, slippageBufferPercent float64) *BalanceFilter {
+	return &BalanceFilter{exchange: exchange, reservation: reservation, slippageBufferPercent: slippageBufferPercent}
+}
+
+// Name возвращает название фильтра
+func (f *BalanceFilter) Name() string { return FilterBalance }
+
+// Check проверяет, достаточно ли баланса для суммы позиции с учетом средств, зарезервированных
+// параллельными хеджами. Размер позиции не корректируется: при нехватке пара пропускается
+func (f *BalanceFilter) Check(ctx context.Context, check *PreTradeCheck) FilterVerdict {
+	balance, err := f.exchange.GetBalance(ctx, check.BaseCurrency)
+	if err != nil {
+		return rejectVerdict(f.Name(), fmt.Errorf("ошибка получения баланса %s: %w", check.BaseCurrency, err))
+	}
+
+	// Рассчитываем необходимую сумму для покупки с запасом на проскальзывание
+	requiredAmount := check.PositionAmount * (1 + f.slippageBufferPercent/100)
+	release, available, ok := f.reservation.TryReserve(balance.Available, requiredAmount)
+	if !ok {
+		logger.LogWithTime("⚠️ ВНИМАНИЕ: Недостаточно баланса для запрошенной позиции")
+		logger.LogWithTime("💡 Требуется: %.2f %s, доступно: %.2f %s",
+			requiredAmount, check.BaseCurrency, available, check.BaseCurrency)
+		return rejectVerdict(f.Name(), errors.NewInsufficientBalanceError(requiredAmount, available, check.BaseCurrency))
+	}
+
+	check.Hold(release)
+	check.Balance = balance
+	check.RequiredAmount = requiredAmount
+	return passVerdict(f.Name(), "")
+}
+
+// InstrumentStatusFilter отклоняет инструменты, которые сейчас не торгуются (Break, PreLaunch и т.д.)
+type InstrumentStatusFilter struct{}
+
+// NewInstrumentStatusFilter создает фильтр статуса инструмента
+func NewInstrumentStatusFilter() *InstrumentStatusFilter {
+	return &InstrumentStatusFilter{}
+}
+
+// Name возвращает название фильтра
+func (f *InstrumentStatusFilter) Name() string { return FilterInstrumentStatus }
+
+// Check проверяет статус инструмента; неизвестный статус (биржа его не вернула) не блокирует покупку
+func (f *InstrumentStatusFilter) Check(ctx context.Context, check *PreTradeCheck) FilterVerdict {
+	status := check.loadInstrument(ctx).Status
+	if status == "" {
+		return passVerdict(f.Name(), "статус инструмента неизвестен")
+	}
+	if !strings.EqualFold(status, "Trading") {
+		reason := fmt.Sprintf("инструмент %s в статусе %s", check.Symbol, status)
+		return rejectVerdict(f.Name(), errors.NewPreTradeFilterError(check.Trade.Pair, f.Name(), reason))
+	}
+	return passVerdict(f.Name(), "")
+}
+
+// MinLimitFilter проверяет сумму и количество ордера на лимиты инструмента
+type MinLimitFilter struct{}
+
+// NewMinLimitFilter создает фильтр лимитов инструмента
+func NewMinLimitFilter() *MinLimitFilter {
+	return &MinLimitFilter{}
+}
+
+// Name возвращает название фильтра
+func (f *MinLimitFilter) Name() string { return FilterMinLimit }
+
+// Check отклоняет пару, если сумма позиции или количество после округления вне лимитов инструмента
+func (f *MinLimitFilter) Check(ctx context.Context, check *PreTradeCheck) FilterVerdict {
+	sizing := check.Sizing(ctx)
+	pair := check.Pair.String()
+	orderValue := check.PositionAmount
+
+	// Проверяем сумму ордера на лимиты инструмента
+	if err := sizing.Rules.ValidateAmount(orderValue); err != nil {
+		logger.LogWithTime("⚠️ ВНИМАНИЕ: Стоимость ордера вне лимитов инструмента для пары %s: %v %s",
+			pair, err, check.BaseCurrency)
+		logger.LogWithTime("💡 Лимиты получены от Bybit API: %s", check.Symbol)
+		if orderValue < sizing.MinOrderValue {
+			return rejectVerdict(f.Name(), errors.NewInsufficientBalanceForMinLimitError(sizing.MinOrderValue, orderValue, check.BaseCurrency))
+		}
+		return rejectVerdict(f.Name(), errors.NewExchangeError(fmt.Sprintf("пара %s: %v", pair, err)))
+	}
+
+	// Проверяем количество валюты на лимиты инструмента
+	if err := sizing.Quantity.Validate(); err != nil {
+		logger.LogWithTime("⚠️ ВНИМАНИЕ: Количество валюты вне лимитов инструмента для пары %s: %v", pair, err)
+		logger.LogWithTime("💡 Лимиты количества получены от Bybit API: %s", check.Symbol)
+		if sizing.Quantity.Float64() < sizing.MinOrderQty {
+			return rejectVerdict(f.Name(), errors.NewInsufficientBalanceForMinLimitError(sizing.MinOrderValue, orderValue, check.BaseCurrency))
+		}
+		return rejectVerdict(f.Name(), errors.NewExchangeError(fmt.Sprintf("пара %s: %v", pair, err)))
+	}
+
+	logger.LogWithTime("✅ Стоимость ордера %.2f %s соответствует минимальному лимиту %.2f %s",
+		orderValue, check.BaseCurrency, sizing.MinOrderValue, check.BaseCurrency)
+	logger.LogWithTime("✅ Количество валюты %.6f %s соответствует минимальному лимиту %.6f",
+		sizing.Quantity.Float64(), check.Symbol, sizing.MinOrderQty)
+	return passVerdict(f.Name(), "")
+}
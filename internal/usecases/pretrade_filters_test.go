@@ -0,0 +1,321 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"trade-hedge/internal/adapters/repositories"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/domain/services"
+)
+
+// newTestPreTradeCheck создает проверку сделки SOL/USDT по цене rate на сумму amount
+func newTestPreTradeCheck(exchange services.ExchangeService, rate, amount float64) *PreTradeCheck {
+	trade := &entities.Trade{ID: 1, Pair: "SOL/USDT", CurrentRate: rate}
+	return NewPreTradeCheck(trade, amount, "USDT", exchange, NewRoundingPolicy(RoundingFloor, nil))
+}
+
+// checkVerdict сверяет решение фильтра с ожидаемым: пропуск или отказ с ошибкой стратегии нужного типа
+// (wantType < 0 - отказ с ошибкой получения данных)
+func checkVerdict(t *testing.T, verdict FilterVerdict, wantPass bool, wantType errors.ErrorType) {
+	t.Helper()
+	if verdict.Passed != wantPass {
+		t.Fatalf("фильтр %s: пропуск = %v, ожидалось %v (%s, %v)", verdict.Filter, verdict.Passed, wantPass, verdict.Reason, verdict.Err)
+	}
+	if wantPass {
+		return
+	}
+	strategyErr, ok := verdict.Err.(*errors.StrategyError)
+	if wantType < 0 {
+		if ok || verdict.Err == nil {
+			t.Fatalf("фильтр %s: ошибка %v, ожидалась ошибка получения данных", verdict.Filter, verdict.Err)
+		}
+		return
+	}
+	if !ok || strategyErr.Type != wantType {
+		t.Fatalf("фильтр %s: ошибка %v, ожидался тип %d", verdict.Filter, verdict.Err, wantType)
+	}
+}
+
+// TestBlacklistFilter проверяет отказ парам из черного списка без учета регистра и пробелов
+func TestBlacklistFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		pairs    []string
+		wantPass bool
+	}{
+		{"пустой список", nil, true},
+		{"другая пара", []string{"BTC/USDT"}, true},
+		{"пара в списке", []string{"SOL/USDT"}, false},
+		{"другой регистр и пробелы", []string{" sol/usdt "}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := NewBlacklistFilter(tt.pairs).Check(context.Background(), newTestPreTradeCheck(newFakeExchange(100), 100, 50))
+			checkVerdict(t, verdict, tt.wantPass, errors.ErrorTypePreTradeFilter)
+		})
+	}
+}
+
+// TestBudgetFilter проверяет, что фильтр занимает место в лимите риска до освобождения проверки
+func TestBudgetFilter(t *testing.T) {
+	ctx := context.Background()
+	riskManager := NewRiskManager(repositories.NewMemoryHedgeRepository(), RiskLimits{MaxConcurrentHedges: 1})
+	filter := NewBudgetFilter(riskManager)
+	exchange := newFakeExchange(100)
+
+	first := newTestPreTradeCheck(exchange, 100, 50)
+	checkVerdict(t, filter.Check(ctx, first), true, 0)
+
+	second := newTestPreTradeCheck(exchange, 100, 50)
+	checkVerdict(t, filter.Check(ctx, second), false, errors.ErrorTypeRiskLimitExceeded)
+
+	first.Release()
+	first.Release()
+	checkVerdict(t, filter.Check(ctx, second), true, 0)
+}
+
+// TestPriceDeviationFilter проверяет сравнение цены Freqtrade с тикером биржи
+func TestPriceDeviationFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		rate       float64
+		ticker     float64
+		maxPercent float64
+		err        error
+		wantPass   bool
+		wantType   errors.ErrorType
+	}{
+		{"проверка отключена", 100, 150, 0, nil, true, 0},
+		{"цена сделки неизвестна", 0, 150, 1, nil, true, 0},
+		{"в пределах лимита", 100, 103, 5, nil, true, 0},
+		{"цена ниже биржевой", 100, 103, 2, nil, false, errors.ErrorTypePriceDeviation},
+		{"цена выше биржевой", 106, 103, 2, nil, false, errors.ErrorTypePriceDeviation},
+		{"ошибка биржи", 100, 100, 2, fmt.Errorf("timeout"), false, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exchange := newFakeExchange(tt.ticker)
+			exchange.err = tt.err
+			verdict := NewPriceDeviationFilter(exchange, tt.maxPercent).Check(context.Background(),
+				newTestPreTradeCheck(exchange, tt.rate, 50))
+			checkVerdict(t, verdict, tt.wantPass, tt.wantType)
+		})
+	}
+}
+
+// TestSpreadFilter проверяет сравнение спреда стакана с лимитом и пропуск проверки без стакана
+func TestSpreadFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		book       bool
+		bid, ask   float64
+		maxPercent float64
+		err        error
+		wantPass   bool
+		wantType   errors.ErrorType
+	}{
+		{"проверка отключена", true, 90, 100, 0, nil, true, 0},
+		{"биржа без стакана", false, 0, 0, 0.5, nil, true, 0},
+		{"узкий спред", true, 99.9, 100, 0.5, nil, true, 0},
+		{"широкий спред", true, 99, 100, 0.5, nil, false, errors.ErrorTypePreTradeFilter},
+		{"цены стакана неизвестны", true, 0, 0, 0.5, nil, true, 0},
+		{"ошибка биржи", true, 99.9, 100, 0.5, fmt.Errorf("timeout"), false, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newFakeExchange(100)
+			base.err = tt.err
+			var exchange services.ExchangeService = base
+			if tt.book {
+				exchange = &fakeBookExchange{fakeExchange: base, bid: tt.bid, ask: tt.ask}
+			}
+			verdict := NewSpreadFilter(exchange, tt.maxPercent).Check(context.Background(), newTestPreTradeCheck(exchange, 100, 50))
+			checkVerdict(t, verdict, tt.wantPass, tt.wantType)
+		})
+	}
+}
+
+// TestVolatilityFilter проверяет размах цены свечей окна относительно лимита
+func TestVolatilityFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		klines     []*entities.Kline
+		maxPercent float64
+		err        error
+		wantPass   bool
+		wantType   errors.ErrorType
+	}{
+		{"проверка отключена", []*entities.Kline{{Low: 50, High: 100}}, 0, nil, true, 0},
+		{"нет свечей", nil, 5, nil, true, 0},
+		{"размах в пределах лимита", []*entities.Kline{{Low: 100, High: 102}, {Low: 101, High: 104}}, 5, nil, true, 0},
+		{"размах по нескольким свечам", []*entities.Kline{{Low: 100, High: 103}, {Low: 103, High: 106}}, 5, nil, false, errors.ErrorTypePreTradeFilter},
+		{"свеча без минимума", []*entities.Kline{{Low: 0, High: 104}, {Low: 100, High: 102}}, 5, nil, true, 0},
+		{"ошибка биржи", nil, 5, fmt.Errorf("timeout"), false, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exchange := newFakeExchange(100)
+			exchange.klines = tt.klines
+			exchange.err = tt.err
+			verdict := NewVolatilityFilter(exchange, tt.maxPercent, 0).Check(context.Background(), newTestPreTradeCheck(exchange, 100, 50))
+			checkVerdict(t, verdict, tt.wantPass, tt.wantType)
+		})
+	}
+}
+
+// TestBalanceFilter проверяет баланс с запасом на проскальзывание и резерв суммы до освобождения проверки
+func TestBalanceFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		available float64
+		reserved  float64
+		err       error
+		wantPass  bool
+		wantType  errors.ErrorType
+	}{
+		{"баланса достаточно", 102, 0, nil, true, 0},
+		{"не хватает запаса на проскальзывание", 101, 0, nil, false, errors.ErrorTypeInsufficientBalance},
+		{"баланс занят параллельным хеджем", 150, 50, nil, false, errors.ErrorTypeInsufficientBalance},
+		{"ошибка биржи", 1000, 0, fmt.Errorf("timeout"), false, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exchange := newFakeExchange(100)
+			exchange.available = tt.available
+			exchange.err = tt.err
+			reservation := NewBalanceReservation()
+			if tt.reserved > 0 {
+				reservation.TryReserve(tt.available, tt.reserved)
+			}
+
+			check := newTestPreTradeCheck(exchange, 100, 100)
+			verdict := NewBalanceFilter(exchange, reservation, 2).Check(context.Background(), check)
+			checkVerdict(t, verdict, tt.wantPass, tt.wantType)
+			if !tt.wantPass {
+				return
+			}
+
+			if check.Balance == nil || check.Balance.Available != tt.available || check.RequiredAmount != 102 {
+				t.Fatalf("проверка не дополнена балансом: %+v, требуется %v", check.Balance, check.RequiredAmount)
+			}
+			if _, _, ok := reservation.TryReserve(tt.available, 1); ok {
+				t.Fatalf("сумма позиции не зарезервирована")
+			}
+			check.Release()
+			if _, _, ok := reservation.TryReserve(tt.available, tt.available); !ok {
+				t.Fatalf("резерв не освобожден проверкой")
+			}
+		})
+	}
+}
+
+// TestInstrumentStatusFilter проверяет отказ инструментам, которые не торгуются
+func TestInstrumentStatusFilter(t *testing.T) {
+	tests := []struct {
+		status   string
+		wantPass bool
+	}{
+		{"", true},
+		{"Trading", true},
+		{"TRADING", true},
+		{"Break", false},
+		{"PreLaunch", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			exchange := newFakeExchange(100)
+			exchange.instrument = &services.InstrumentInfo{Symbol: "SOLUSDT", Status: tt.status}
+			verdict := NewInstrumentStatusFilter().Check(context.Background(), newTestPreTradeCheck(exchange, 100, 50))
+			checkVerdict(t, verdict, tt.wantPass, errors.ErrorTypePreTradeFilter)
+		})
+	}
+}
+
+// TestMinLimitFilter проверяет сумму и количество ордера на лимиты инструмента
+func TestMinLimitFilter(t *testing.T) {
+	solInstrument := &services.InstrumentInfo{Symbol: "SOLUSDT", MinOrderAmt: 5, MaxOrderAmt: 1000,
+		MinOrderQty: 0.01, MaxOrderQty: 10, TickSize: 0.01, StepSize: 0.001, Status: "Trading"}
+
+	tests := []struct {
+		name       string
+		instrument *services.InstrumentInfo
+		rate       float64
+		amount     float64
+		wantPass   bool
+		wantType   errors.ErrorType
+	}{
+		{"в пределах лимитов", solInstrument, 100, 50, true, 0},
+		{"сумма меньше минимальной", solInstrument, 100, 3, false, errors.ErrorTypeInsufficientBalanceForMinLimit},
+		{"сумма больше максимальной", solInstrument, 100, 2000, false, errors.ErrorTypeExchangeError},
+		{"количество меньше минимального", solInstrument, 1000, 6, false, errors.ErrorTypeInsufficientBalanceForMinLimit},
+		{"количество больше максимального", solInstrument, 50, 900, false, errors.ErrorTypeExchangeError},
+		{"минимум по умолчанию", &services.InstrumentInfo{Symbol: "SOLUSDT", StepSize: 0.001}, 100, 50, false, errors.ErrorTypeInsufficientBalanceForMinLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exchange := newFakeExchange(tt.rate)
+			exchange.instrument = tt.instrument
+			verdict := NewMinLimitFilter().Check(context.Background(), newTestPreTradeCheck(exchange, tt.rate, tt.amount))
+			checkVerdict(t, verdict, tt.wantPass, tt.wantType)
+		})
+	}
+}
+
+// TestPreTradePipelineRejectReleasesHolds проверяет, что отказ фильтра в конце цепочки освобождает резерв баланса
+// и место в лимите риска, занятые предыдущими фильтрами, а при успехе они держатся до check.Release
+func TestPreTradePipelineRejectReleasesHolds(t *testing.T) {
+	ctx := context.Background()
+	exchange := newFakeExchange(100)
+	exchange.available = 100
+	exchange.instrument = &services.InstrumentInfo{Symbol: "SOLUSDT", Status: "Break"}
+
+	reservation := NewBalanceReservation()
+	riskManager := NewRiskManager(repositories.NewMemoryHedgeRepository(), RiskLimits{MaxConcurrentHedges: 1})
+	pipeline := NewPreTradePipeline(NewBudgetFilter(riskManager), NewBalanceFilter(exchange, reservation, 0),
+		NewInstrumentStatusFilter())
+
+	verdicts, err := pipeline.Run(ctx, newTestPreTradeCheck(exchange, 100, 100))
+	if strategyErr, ok := err.(*errors.StrategyError); !ok || strategyErr.Type != errors.ErrorTypePreTradeFilter {
+		t.Fatalf("Run: ошибка %v, ожидался отказ фильтра %s", err, FilterInstrumentStatus)
+	}
+	if len(verdicts) != 3 || !verdicts[0].Passed || !verdicts[1].Passed || verdicts[2].Passed {
+		t.Fatalf("решения фильтров: %+v", verdicts)
+	}
+	if release, _, ok := reservation.TryReserve(exchange.available, exchange.available); !ok {
+		t.Fatalf("резерв баланса не освобожден после отказа фильтра %s", FilterInstrumentStatus)
+	} else {
+		release()
+	}
+	if release, err := riskManager.Admit(ctx, "SOL/USDT", 100); err != nil {
+		t.Fatalf("место в лимите риска не освобождено после отказа: %v", err)
+	} else {
+		release()
+	}
+
+	// Успешная проверка держит резерв и место в лимите до освобождения вызывающим
+	exchange.instrument.Status = "Trading"
+	check := newTestPreTradeCheck(exchange, 100, 100)
+	if _, err := pipeline.Run(ctx, check); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if _, _, ok := reservation.TryReserve(exchange.available, 1); ok {
+		t.Fatalf("резерв баланса освобожден до check.Release")
+	}
+	if _, err := riskManager.Admit(ctx, "SOL/USDT", 100); err == nil {
+		t.Fatalf("место в лимите риска освобождено до check.Release")
+	}
+	check.Release()
+	if _, _, ok := reservation.TryReserve(exchange.available, exchange.available); !ok {
+		t.Fatalf("резерв баланса не освобожден check.Release")
+	}
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// convertTradeQuote переводит сделку с котируемой валютой, отличной от BaseCurrency, на рынок BASE/BaseCurrency.
+// Цены сделки пересчитываются по курсу котируемой валюты (через промежуточную пару, например EURUSDT),
+// поэтому размер позиции и тейк-профит считаются в валюте кошелька.
+// Сделки в BaseCurrency возвращаются без изменений
+func (h *HedgeStrategyUseCase) convertTradeQuote(ctx context.Context, trade *entities.Trade) (*entities.Trade, error) {
+	pair := valueobjects.NewTradingPair(trade.Pair)
+	quote := pair.QuoteCurrency()
+	if quote == "" || strings.EqualFold(quote, h.config.BaseCurrency) {
+		return trade, nil
+	}
+
+	if !h.config.QuoteConversion {
+		return nil, errors.NewQuoteConversionError(trade.Pair, h.config.BaseCurrency, "перевод котируемой валюты отключен")
+	}
+
+	convertedPair := valueobjects.NewTradingPair(pair.BaseCurrency() + "/" + h.config.BaseCurrency)
+	if _, err := h.exchangeService.GetInstrumentInfo(ctx, convertedPair.ToBybitFormat()); err != nil {
+		return nil, errors.NewQuoteConversionError(trade.Pair, h.config.BaseCurrency,
+			fmt.Sprintf("рынок %s недоступен", convertedPair.ToBybitFormat()))
+	}
+
+	rate, err := h.quoteRate(ctx, quote)
+	if err != nil {
+		return nil, errors.NewQuoteConversionError(trade.Pair, h.config.BaseCurrency, err.Error())
+	}
+
+	converted := *trade
+	converted.Pair = convertedPair.String()
+	converted.CurrentRate = trade.CurrentRate * rate
+	converted.OpenRate = trade.OpenRate * rate
+
+	logger.LogWithTime("💱 Пара %s хеджируется на рынке %s: курс %s/%s %.8f, цена %.8f → %.8f",
+		trade.Pair, convertedPair.String(), quote, h.config.BaseCurrency, rate, trade.CurrentRate, converted.CurrentRate)
+
+	return &converted, nil
+}
+
+// quoteRate возвращает стоимость единицы котируемой валюты в BaseCurrency
+// по прямой (EURUSDT) или обратной (USDTEUR) промежуточной паре
+func (h *HedgeStrategyUseCase) quoteRate(ctx context.Context, quote string) (float64, error) {
+	direct := quote + h.config.BaseCurrency
+	if price, err := h.exchangeService.GetTickerPrice(ctx, direct); err == nil {
+		return price, nil
+	}
+
+	inverse := h.config.BaseCurrency + quote
+	price, err := h.exchangeService.GetTickerPrice(ctx, inverse)
+	if err != nil {
+		return 0, fmt.Errorf("нет курса %s/%s (пары %s и %s недоступны)", quote, h.config.BaseCurrency, direct, inverse)
+	}
+	return 1 / price, nil
+}
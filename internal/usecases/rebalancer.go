@@ -0,0 +1,191 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// RebalancerConfig конфигурация ребалансировки прибыли от хеджирования
+type RebalancerConfig struct {
+	QuoteCurrency string             // Котируемая валюта, в которой накапливается прибыль (например, USDT)
+	Allocations   map[string]float64 // Целевые доли активов в процентах; остаток остается в котируемой валюте
+	MinProfit     float64            // Минимальная накопленная прибыль для запуска ребалансировки
+}
+
+// RebalancerUseCase конвертирует накопленную прибыль от тейк-профитов в целевое распределение активов
+type RebalancerUseCase struct {
+	hedgeRepo       repositories.HedgeRepository
+	rebalanceRepo   repositories.RebalanceRepository
+	exchangeService services.ExchangeService
+	config          *RebalancerConfig
+}
+
+// NewRebalancerUseCase создает новый use case ребалансировки
+func NewRebalancerUseCase(
+	hedgeRepo repositories.HedgeRepository,
+	rebalanceRepo repositories.RebalanceRepository,
+	exchangeService services.ExchangeService,
+	config *RebalancerConfig,
+) *RebalancerUseCase {
+	return &RebalancerUseCase{
+		hedgeRepo:       hedgeRepo,
+		rebalanceRepo:   rebalanceRepo,
+		exchangeService: exchangeService,
+		config:          config,
+	}
+}
+
+// Rebalance распределяет прибыль, накопленную с момента предыдущей завершенной ребалансировки.
+// Если какая-либо конвертация не удалась, период не завершается: следующая ребалансировка снова
+// учитывает его прибыль и конвертирует только то, что еще не конвертировано
+func (r *RebalancerUseCase) Rebalance(ctx context.Context) error {
+	logger.LogWithTime("⚖️ Запуск ребалансировки прибыли от хеджирования...")
+
+	periodEnd := time.Now()
+	var periodStart time.Time
+	last, err := r.rebalanceRepo.GetLastRebalanceTime(ctx)
+	if err != nil {
+		return err
+	}
+	if last != nil {
+		periodStart = *last
+	}
+
+	profit, err := r.accumulatedProfit(ctx, periodStart, periodEnd)
+	if err != nil {
+		return err
+	}
+
+	if profit < r.config.MinProfit || profit <= 0 {
+		logger.LogWithTime("ℹ️ Накопленная прибыль %s %s меньше порога %s %s - ребалансировка не требуется",
+			valueobjects.FormatAmount(profit, r.config.QuoteCurrency), r.config.QuoteCurrency,
+			valueobjects.FormatAmount(r.config.MinProfit, r.config.QuoteCurrency), r.config.QuoteCurrency)
+		return nil
+	}
+
+	logger.LogWithTime("💰 Накопленная прибыль с %s: %s %s",
+		periodStart.Format("2006-01-02 15:04:05"), valueobjects.FormatAmount(profit, r.config.QuoteCurrency), r.config.QuoteCurrency)
+
+	// Успешные конвертации предыдущих попыток этого же периода не повторяются
+	converted, err := r.rebalanceRepo.GetConvertedAmounts(ctx, periodStart)
+	if err != nil {
+		return err
+	}
+
+	// Обрабатываем активы в детерминированном порядке для воспроизводимого аудита
+	assets := make([]string, 0, len(r.config.Allocations))
+	for asset := range r.config.Allocations {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	retainedPercent := 100.0
+	var failed []string
+	for _, asset := range assets {
+		percent := r.config.Allocations[asset]
+		retainedPercent -= percent
+
+		amount := profit*percent/100 - converted[asset]
+		if amount <= 0 {
+			logger.LogWithTime("ℹ️ Доля %s за период уже конвертирована", asset)
+			continue
+		}
+
+		record := &entities.RebalanceRecord{
+			CreatedAt:     time.Now(),
+			PeriodStart:   periodStart,
+			PeriodEnd:     periodEnd,
+			TotalProfit:   profit,
+			Asset:         asset,
+			TargetPercent: percent,
+			QuoteAmount:   amount,
+		}
+
+		r.convert(ctx, record)
+		if !record.Success {
+			failed = append(failed, asset)
+		}
+
+		if err := r.rebalanceRepo.SaveRebalanceRecord(ctx, record); err != nil {
+			return err
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("ребалансировка не завершена: не удалось конвертировать прибыль в %s, период будет повторен",
+			strings.Join(failed, ", "))
+	}
+
+	// Фиксируем долю, оставшуюся в котируемой валюте, чтобы период считался обработанным
+	retained := &entities.RebalanceRecord{
+		CreatedAt:     time.Now(),
+		PeriodStart:   periodStart,
+		PeriodEnd:     periodEnd,
+		TotalProfit:   profit,
+		Asset:         r.config.QuoteCurrency,
+		TargetPercent: retainedPercent,
+		QuoteAmount:   profit * retainedPercent / 100,
+		Success:       true,
+	}
+	if err := r.rebalanceRepo.SaveRebalanceRecord(ctx, retained); err != nil {
+		return err
+	}
+
+	logger.LogWithTime("✅ Ребалансировка завершена: %.1f%% оставлено в %s", retainedPercent, r.config.QuoteCurrency)
+	return nil
+}
+
+// convert покупает целевой актив на указанную сумму и заполняет результат в записи аудита
+func (r *RebalancerUseCase) convert(ctx context.Context, record *entities.RebalanceRecord) {
+	symbol := record.Asset + r.config.QuoteCurrency
+
+	// Для рыночной покупки на споте Bybit количество указывается в котируемой валюте
+	order := entities.NewMarketOrder(symbol, entities.OrderSideBuy, record.QuoteAmount).WithQuoteQuantity()
+
+	result, err := r.exchangeService.PlaceOrder(ctx, order)
+	if err != nil {
+		record.Error = err.Error()
+		logger.LogWithTime("❌ Ошибка конвертации %.4f %s в %s: %v", record.QuoteAmount, r.config.QuoteCurrency, record.Asset, err)
+		return
+	}
+	if !result.Success {
+		record.Error = result.Error
+		logger.LogWithTime("❌ Конвертация %.4f %s в %s отклонена: %s", record.QuoteAmount, r.config.QuoteCurrency, record.Asset, result.Error)
+		return
+	}
+
+	record.OrderID = result.OrderID
+	record.Success = true
+	logger.LogWithTime("🔁 Конвертировано %.4f %s в %s (%.1f%%), ордер %s",
+		record.QuoteAmount, r.config.QuoteCurrency, record.Asset, record.TargetPercent, result.OrderID)
+}
+
+// accumulatedProfit рассчитывает прибыль исполненных тейк-профитов (за вычетом комиссий), закрытых в указанном периоде
+func (r *RebalancerUseCase) accumulatedProfit(ctx context.Context, from, to time.Time) (float64, error) {
+	filledStatus := entities.OrderStatusFilled.String()
+	trades, err := r.hedgeRepo.GetHedgedTrades(ctx, &filledStatus)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения исполненных сделок: %w", err)
+	}
+
+	var total float64
+	for _, trade := range trades {
+		if trade.CloseTime == nil || !trade.CloseTime.After(from) || trade.CloseTime.After(to) {
+			continue
+		}
+		if profit := trade.CalculateProfit(); profit != nil {
+			total += profit.Net
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,208 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// RecoveryUseCase продолжает хеджи, прерванные на середине (например, покупка исполнена,
+// а тейк-профит не выставлен из-за перезапуска), вместо того чтобы оставлять позиции без защиты.
+// Запускается при старте приложения и перед каждым циклом хеджирования
+type RecoveryUseCase struct {
+	hedge *HedgeStrategyUseCase
+}
+
+// NewRecoveryUseCase создает use case восстановления для сценария хеджирования
+func NewRecoveryUseCase(hedge *HedgeStrategyUseCase) *RecoveryUseCase {
+	return &RecoveryUseCase{
+		hedge: hedge,
+	}
+}
+
+// RecoverInFlightHedges продвигает незавершенные хеджи по машине состояний.
+// Ошибка возвращается, только если нельзя установить, был ли ордер размещен на бирже:
+// в этом случае новые ордера размещать небезопасно
+func (r *RecoveryUseCase) RecoverInFlightHedges(ctx context.Context) error {
+	intents, err := r.hedge.intentRepo.GetInFlightHedgeIntents(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка получения незавершенных хеджей: %w", err)
+	}
+
+	if len(intents) > 0 {
+		logger.LogWithTime("♻️ Найдено незавершенных хеджей: %d", len(intents))
+	}
+
+	for _, intent := range intents {
+		if err := r.recoverIntent(ctx, intent); err != nil {
+			if intent.State == entities.HedgeStateIntent {
+				return err
+			}
+			logger.LogWithTime("❌ Ошибка восстановления хеджа %s (пара %s, состояние %s): %v",
+				intent.ClientOrderID, intent.Pair, intent.State, err)
+		}
+	}
+
+	return nil
+}
+
+// recoverIntent продвигает один хедж из текущего состояния насколько возможно
+func (r *RecoveryUseCase) recoverIntent(ctx context.Context, intent *entities.HedgeIntent) error {
+	symbol := valueobjects.NewTradingPair(intent.Pair).ToBybitFormat()
+
+	var buyStatus *services.OrderStatusInfo
+
+	// INTENT: ордер мог дойти до биржи - ищем его по клиентскому ID
+	if intent.State == entities.HedgeStateIntent {
+		statusInfo, err := r.hedge.exchangeService.GetOrderStatusByClientID(ctx, intent.ClientOrderID, symbol)
+		if err != nil {
+			return fmt.Errorf("ошибка сверки хеджа %s с биржей: %w", intent.ClientOrderID, err)
+		}
+		if statusInfo == nil {
+			logger.LogWithTime("🧹 Ордер %s (пара %s) не найден на бирже - хедж закрыт без покупки",
+				intent.ClientOrderID, intent.Pair)
+			r.hedge.advanceHedgeIntent(ctx, intent, entities.HedgeStateClosed)
+			return nil
+		}
+
+		logger.LogWithTime("♻️ Найден ордер %s (пара %s), размещенный до перезапуска", intent.ClientOrderID, intent.Pair)
+		intent.BuyOrderID = statusInfo.OrderID
+		r.hedge.advanceHedgeIntent(ctx, intent, entities.HedgeStateBuyPlaced)
+		buyStatus = statusInfo
+	}
+
+	// BUY_PLACED: проверяем исполнение покупки
+	if intent.State == entities.HedgeStateBuyPlaced {
+		pending, err := r.findPendingBuy(ctx, intent)
+		if err != nil {
+			return err
+		}
+		if pending != nil {
+			// Покупка уже отслеживается как ожидающая и будет завершена resumePendingBuys
+			return nil
+		}
+
+		if buyStatus == nil {
+			buyStatus, err = r.hedge.exchangeService.GetOrderStatus(ctx, intent.BuyOrderID, symbol)
+			if err != nil {
+				return fmt.Errorf("ошибка получения статуса покупки %s: %w", intent.BuyOrderID, err)
+			}
+		}
+
+		switch {
+		case !buyStatus.Status.IsCompleted():
+			// Покупка еще в стакане - передаем ее в обработку ожидающих покупок
+			logger.LogWithTime("⏳ Покупка %s (пара %s) еще не исполнена - отслеживаем как ожидающую",
+				intent.BuyOrderID, intent.Pair)
+			return r.saveRecoveredBuyPending(ctx, intent)
+		case buyStatus.FilledQty <= 0:
+			logger.LogWithTime("🧹 Покупка %s (пара %s) завершена без исполнения: %s",
+				intent.BuyOrderID, intent.Pair, buyStatus.Status)
+			r.hedge.advanceHedgeIntent(ctx, intent, entities.HedgeStateClosed)
+			return nil
+		default:
+			intent.FilledQty = buyStatus.FilledQty
+			r.hedge.advanceHedgeIntent(ctx, intent, entities.HedgeStateBuyFilled)
+		}
+	}
+
+	// BUY_FILLED: позиция куплена, но не защищена - выставляем тейк-профит
+	if intent.State == entities.HedgeStateBuyFilled {
+		return r.placeRecoveredTakeProfit(ctx, intent)
+	}
+
+	return nil
+}
+
+// placeRecoveredTakeProfit выставляет тейк-профит для купленной позиции и сохраняет хедж
+func (r *RecoveryUseCase) placeRecoveredTakeProfit(ctx context.Context, intent *entities.HedgeIntent) error {
+	logger.LogWithTime("🛠️ Покупка %s (пара %s) исполнена без тейк-профита - выставляем его",
+		intent.BuyOrderID, intent.Pair)
+
+	symbol := valueobjects.NewTradingPair(intent.Pair).ToBybitFormat()
+	instrument := &services.InstrumentInfo{}
+	if instrumentInfo, err := r.hedge.exchangeService.GetInstrumentInfo(ctx, symbol); err == nil {
+		instrument = instrumentInfo
+	}
+
+	buyStatus := &services.OrderStatusInfo{
+		OrderID:   intent.BuyOrderID,
+		Status:    entities.OrderStatusFilled,
+		FilledQty: intent.FilledQty,
+	}
+
+	hedgedTrade, events, err := r.hedge.placeTakeProfit(ctx, intent.ToTrade(), intent.BuyOrderID, intent.Quantity, buyStatus, instrument)
+	if err != nil {
+		return err
+	}
+	hedgedTrade.HedgeTime = intent.CreatedAt
+	// Покупка исполнилась, пока процесс не работал: момент исполнения неизвестен
+	hedgedTrade.BuyFilledAt = nil
+
+	pending, err := r.findPendingBuy(ctx, intent)
+	if err != nil {
+		return err
+	}
+	replaceOrderID := ""
+	if pending != nil {
+		copyHedgeTiming(pending, hedgedTrade)
+		replaceOrderID = pending.BybitOrderID
+	}
+	if err := r.hedge.saveHedge(ctx, replaceOrderID, hedgedTrade, events); err != nil {
+		return fmt.Errorf("ошибка сохранения восстановленного хеджа: %w", err)
+	}
+
+	intent.TakeProfitOrderID = hedgedTrade.BybitOrderID
+	r.hedge.advanceHedgeIntent(ctx, intent, entities.HedgeStateTPPlaced)
+	r.hedge.scheduleEarlyChecks(ctx, hedgedTrade)
+
+	return nil
+}
+
+// saveRecoveredBuyPending сохраняет найденную неисполненную покупку как ожидающую
+func (r *RecoveryUseCase) saveRecoveredBuyPending(ctx context.Context, intent *entities.HedgeIntent) error {
+	now := time.Now()
+	hedgedTrade := &entities.HedgedTrade{
+		FreqtradeTradeID: intent.FreqtradeTradeID,
+		Pair:             intent.Pair,
+		HedgeTime:        intent.CreatedAt,
+		BybitOrderID:     intent.BuyOrderID,
+		BuyOrderID:       intent.BuyOrderID,
+
+		FreqtradeOpenPrice:   intent.FreqtradeOpenPrice,
+		FreqtradeAmount:      intent.FreqtradeAmount,
+		FreqtradeProfitRatio: intent.FreqtradeProfitRatio,
+
+		HedgeOpenPrice:  intent.CurrentRate,
+		HedgeAmount:     intent.Quantity,
+		BuyRequestedQty: intent.Quantity,
+
+		OrderStatus:     entities.OrderStatusBuyPending,
+		LastStatusCheck: &now,
+	}
+	r.hedge.tagHedge(hedgedTrade)
+
+	if err := r.hedge.hedgeRepo.SaveHedgedTrade(ctx, hedgedTrade); err != nil {
+		return fmt.Errorf("ошибка сохранения ожидающей покупки %s: %w", intent.BuyOrderID, err)
+	}
+	return nil
+}
+
+// findPendingBuy находит ожидающую покупку, уже сохраненную для ордера хеджа
+func (r *RecoveryUseCase) findPendingBuy(ctx context.Context, intent *entities.HedgeIntent) (*entities.HedgedTrade, error) {
+	history, err := r.hedge.hedgeRepo.GetHedgeHistory(ctx, intent.FreqtradeTradeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории хеджирования: %w", err)
+	}
+
+	for _, hedge := range history {
+		if hedge.BuyOrderID == intent.BuyOrderID && hedge.OrderStatus == entities.OrderStatusBuyPending {
+			return hedge, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/services"
+)
+
+// ReportUseCase составляет сводку хеджирования за день или неделю и отправляет ее оповещением report
+type ReportUseCase struct {
+	hedgeRepo repositories.HedgeRepository
+	notifier  services.Notifier // nil - сводка только рассчитывается (API)
+}
+
+// NewReportUseCase создает use case сводок хеджирования
+func NewReportUseCase(hedgeRepo repositories.HedgeRepository) *ReportUseCase {
+	return &ReportUseCase{hedgeRepo: hedgeRepo}
+}
+
+// WithNotifier подключает шину оповещений для отправки сводок
+func (u *ReportUseCase) WithNotifier(notifier services.Notifier) *ReportUseCase {
+	u.notifier = notifier
+	return u
+}
+
+// BuildReport рассчитывает сводку за период, заканчивающийся в now. Прибыль и доля прибыльных
+// считаются по хеджам, закрытым за период, независимо от того, когда они открыты
+func (u *ReportUseCase) BuildReport(ctx context.Context, period string, now time.Time) (*entities.HedgeReport, error) {
+	duration, ok := entities.ReportPeriodDuration(period)
+	if !ok {
+		return nil, fmt.Errorf("неизвестный период сводки: %s", period)
+	}
+
+	hedges, err := u.hedgeRepo.GetHedgedTrades(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения хеджей для сводки: %w", err)
+	}
+
+	report := &entities.HedgeReport{Period: period, From: now.Add(-duration), To: now}
+	for _, hedge := range hedges {
+		if !hedge.HedgeTime.Before(report.From) && hedge.HedgeTime.Before(report.To) {
+			report.Opened++
+		}
+		if hedge.IsActive() {
+			report.Active++
+			continue
+		}
+		if hedge.CloseTime == nil || hedge.CloseTime.Before(report.From) || !hedge.CloseTime.Before(report.To) {
+			continue
+		}
+		report.Closed++
+
+		profit := hedge.CalculateProfit()
+		if profit == nil {
+			continue
+		}
+		report.RealizedProfit += profit.Gross
+		report.NetProfit += profit.Net
+		report.Fees += hedge.TotalFees()
+		switch {
+		case profit.Net > 0:
+			report.Wins++
+		case profit.Net < 0:
+			report.Losses++
+		}
+
+		item := &entities.HedgeReportItem{
+			HedgeID:          hedge.HedgeID,
+			FreqtradeTradeID: hedge.FreqtradeTradeID,
+			Pair:             hedge.Pair,
+			NetProfit:        profit.Net,
+		}
+		if report.BestHedge == nil || item.NetProfit > report.BestHedge.NetProfit {
+			report.BestHedge = item
+		}
+		if report.WorstHedge == nil || item.NetProfit < report.WorstHedge.NetProfit {
+			report.WorstHedge = item
+		}
+	}
+	if closed := report.Wins + report.Losses; closed > 0 {
+		report.WinRate = float64(report.Wins) / float64(closed) * 100
+	}
+	return report, nil
+}
+
+// SendReport рассчитывает сводку и отправляет ее в шину оповещений
+func (u *ReportUseCase) SendReport(ctx context.Context, period string, now time.Time) error {
+	report, err := u.BuildReport(ctx, period, now)
+	if err != nil {
+		return err
+	}
+	if u.notifier == nil {
+		return nil
+	}
+	return u.notifier.Notify(ctx, newReportNotification(report))
+}
+
+// newReportNotification оформляет сводку оповещением report: текст для людей и поля сводки в данных
+func newReportNotification(report *entities.HedgeReport) *entities.Notification {
+	title := "Сводка хеджирования за день"
+	if report.Period == entities.ReportPeriodWeekly {
+		title = "Сводка хеджирования за неделю"
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s - %s", report.From.Format("2006-01-02 15:04"), report.To.Format("2006-01-02 15:04")),
+		fmt.Sprintf("Открыто хеджей: %d, закрыто: %d, открытых сейчас: %d", report.Opened, report.Closed, report.Active),
+		fmt.Sprintf("Прибыль после комиссий: %.4f (до комиссий %.4f, комиссии %.4f)", report.NetProfit, report.RealizedProfit, report.Fees),
+		fmt.Sprintf("Прибыльных: %d, убыточных: %d, доля прибыльных: %.1f%%", report.Wins, report.Losses, report.WinRate),
+	}
+	if report.BestHedge != nil {
+		lines = append(lines, fmt.Sprintf("Лучший хедж: %s (сделка %d) %+.4f",
+			report.BestHedge.Pair, report.BestHedge.FreqtradeTradeID, report.BestHedge.NetProfit))
+	}
+	if report.WorstHedge != nil && report.WorstHedge != report.BestHedge {
+		lines = append(lines, fmt.Sprintf("Худший хедж: %s (сделка %d) %+.4f",
+			report.WorstHedge.Pair, report.WorstHedge.FreqtradeTradeID, report.WorstHedge.NetProfit))
+	}
+
+	data := map[string]interface{}{
+		"period":          report.Period,
+		"from":            report.From,
+		"to":              report.To,
+		"opened":          report.Opened,
+		"closed":          report.Closed,
+		"active":          report.Active,
+		"realized_profit": report.RealizedProfit,
+		"net_profit":      report.NetProfit,
+		"fees":            report.Fees,
+		"wins":            report.Wins,
+		"losses":          report.Losses,
+		"win_rate":        report.WinRate,
+	}
+	if report.BestHedge != nil {
+		data["best_hedge"] = report.BestHedge
+	}
+	if report.WorstHedge != nil {
+		data["worst_hedge"] = report.WorstHedge
+	}
+
+	return entities.NewNotification(entities.NotificationPriorityLow, title, strings.Join(lines, "\n")).
+		WithEvent(entities.NotificationEventReport, data)
+}
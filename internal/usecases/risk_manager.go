@@ -0,0 +1,183 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/domain/valueobjects"
+)
+
+// RiskLimits лимиты риска, проверяемые перед каждым хеджированием (0 - без ограничения)
+type RiskLimits struct {
+	MaxOpenNotional     float64 // Максимальный суммарный объем открытых хеджей в базовой валюте
+	MaxConcurrentHedges int     // Максимальное количество одновременно активных хеджей
+	DailyBudget         float64 // Максимальная сумма новых хеджей за календарные сутки
+	MaxHedgesPerPair    int     // Максимальное количество активных хеджей по одной паре
+
+	PerQuote map[string]QuoteRiskLimits // Независимые лимиты по котируемым валютам
+}
+
+// QuoteRiskLimits лимиты риска по одной котируемой валюте (0 - без ограничения, суммы - в этой валюте)
+type QuoteRiskLimits struct {
+	MaxConcurrentHedges int
+	MaxOpenNotional     float64
+	DailyBudget         float64
+}
+
+// RiskManager проверяет лимиты риска перед размещением хеджа
+type RiskManager struct {
+	hedgeRepo repositories.HedgeRepository
+	limits    RiskLimits
+
+	// Хеджи, допущенные к размещению, но еще не сохраненные в репозитории (параллельное хеджирование)
+	mu      sync.Mutex
+	pending map[int]pendingHedge
+	nextID  int
+}
+
+// pendingHedge хедж в процессе размещения
+type pendingHedge struct {
+	pair   string
+	amount float64
+}
+
+// NewRiskManager создает новый риск-менеджер
+func NewRiskManager(hedgeRepo repositories.HedgeRepository, limits RiskLimits) *RiskManager {
+	return &RiskManager{
+		hedgeRepo: hedgeRepo,
+		limits:    limits,
+		pending:   make(map[int]pendingHedge),
+	}
+}
+
+// Admit атомарно проверяет лимиты с учетом хеджей в процессе размещения и резервирует место под хедж.
+// Возвращаемую функцию release нужно вызвать после сохранения хеджа или отказа от него
+func (r *RiskManager) Admit(ctx context.Context, pair string, amount float64) (func(), error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.check(ctx, pair, amount); err != nil {
+		return nil, err
+	}
+
+	id := r.nextID
+	r.nextID++
+	r.pending[id] = pendingHedge{pair: pair, amount: amount}
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.pending, id)
+	}, nil
+}
+
+// CheckHedge проверяет, можно ли открыть хедж по паре на указанную сумму.
+// Возвращает типизированную ошибку с указанием сработавшего лимита
+func (r *RiskManager) CheckHedge(ctx context.Context, pair string, amount float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.check(ctx, pair, amount)
+}
+
+// check проверяет лимиты; вызывается под r.mu
+func (r *RiskManager) check(ctx context.Context, pair string, amount float64) error {
+	trades, err := r.hedgeRepo.GetHedgedTrades(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка получения хеджей для проверки лимитов риска: %w", err)
+	}
+
+	now := time.Now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var openNotional, dailySpent float64
+	var activeHedges, activePairHedges int
+	for _, trade := range trades {
+		notional := trade.HedgeAmount * trade.HedgeOpenPrice
+
+		if isOpenHedge(trade) {
+			activeHedges++
+			openNotional += notional
+			if trade.Pair == pair {
+				activePairHedges++
+			}
+		}
+
+		if !trade.HedgeTime.Before(dayStart) {
+			dailySpent += notional
+		}
+	}
+
+	// Хеджи в процессе размещения уже занимают лимиты
+	for _, hedge := range r.pending {
+		activeHedges++
+		openNotional += hedge.amount
+		dailySpent += hedge.amount
+		if hedge.pair == pair {
+			activePairHedges++
+		}
+	}
+
+	if r.limits.MaxHedgesPerPair > 0 && activePairHedges >= r.limits.MaxHedgesPerPair {
+		return errors.NewPairRiskLimitError(pair, "max_hedges_per_pair",
+			float64(activePairHedges), float64(r.limits.MaxHedgesPerPair))
+	}
+	if r.limits.MaxConcurrentHedges > 0 && activeHedges >= r.limits.MaxConcurrentHedges {
+		return errors.NewRiskLimitError("max_concurrent_hedges",
+			float64(activeHedges), float64(r.limits.MaxConcurrentHedges))
+	}
+	if r.limits.MaxOpenNotional > 0 && openNotional+amount > r.limits.MaxOpenNotional {
+		return errors.NewRiskLimitError("max_open_notional", openNotional+amount, r.limits.MaxOpenNotional)
+	}
+	if r.limits.DailyBudget > 0 && dailySpent+amount > r.limits.DailyBudget {
+		return errors.NewRiskLimitError("daily_budget", dailySpent+amount, r.limits.DailyBudget)
+	}
+
+	return r.checkQuote(ctx, pair, amount, dayStart)
+}
+
+// checkQuote проверяет лимиты котируемой валюты пары по агрегатам из репозитория
+func (r *RiskManager) checkQuote(ctx context.Context, pair string, amount float64, dayStart time.Time) error {
+	quote := valueobjects.NewTradingPair(pair).QuoteCurrency()
+	limits, ok := r.limits.PerQuote[quote]
+	if !ok {
+		return nil
+	}
+
+	exposure, err := r.hedgeRepo.GetQuoteExposure(ctx, quote, dayStart)
+	if err != nil {
+		return fmt.Errorf("ошибка получения агрегатов для проверки лимитов %s: %w", quote, err)
+	}
+	for _, hedge := range r.pending {
+		if valueobjects.NewTradingPair(hedge.pair).QuoteCurrency() == quote {
+			exposure.OpenHedges++
+			exposure.OpenNotional += hedge.amount
+			exposure.DailyNotional += hedge.amount
+		}
+	}
+
+	if limits.MaxConcurrentHedges > 0 && exposure.OpenHedges >= limits.MaxConcurrentHedges {
+		return errors.NewQuoteRiskLimitError(quote, "max_concurrent_hedges",
+			float64(exposure.OpenHedges), float64(limits.MaxConcurrentHedges))
+	}
+	if limits.MaxOpenNotional > 0 && exposure.OpenNotional+amount > limits.MaxOpenNotional {
+		return errors.NewQuoteRiskLimitError(quote, "max_open_notional",
+			exposure.OpenNotional+amount, limits.MaxOpenNotional)
+	}
+	if limits.DailyBudget > 0 && exposure.DailyNotional+amount > limits.DailyBudget {
+		return errors.NewQuoteRiskLimitError(quote, "daily_budget",
+			exposure.DailyNotional+amount, limits.DailyBudget)
+	}
+
+	return nil
+}
+
+// isOpenHedge проверяет, держит ли хедж капитал (покупка ожидает исполнения или тейк-профит не исполнен)
+func isOpenHedge(trade *entities.HedgedTrade) bool {
+	return trade.IsActive() && trade.OrderStatus != entities.OrderStatusUnknown
+}
@@ -0,0 +1,116 @@
+package usecases
+
+import "trade-hedge/internal/domain/valueobjects"
+
+// Названия политик округления (значения strategy.*_rounding)
+const (
+	RoundingFloor   = "floor"
+	RoundingCeil    = "ceil"
+	RoundingNearest = "nearest"
+	RoundingBankers = "bankers"
+)
+
+// RoundingPolicy округляет значение до кратного шага биржи (шага цены или количества)
+type RoundingPolicy interface {
+	// Round округляет value до кратного step; при step <= 0 значение не меняется
+	Round(value, step float64) float64
+
+	// Mode возвращает режим округления для цен и количеств (valueobjects.Price, valueobjects.Quantity)
+	Mode() valueobjects.RoundingMode
+
+	// Name возвращает название политики
+	Name() string
+}
+
+// floorRounding округление вниз
+type floorRounding struct{}
+
+func (floorRounding) Name() string { return RoundingFloor }
+
+func (floorRounding) Mode() valueobjects.RoundingMode { return valueobjects.RoundFloor }
+
+func (r floorRounding) Round(value, step float64) float64 {
+	return roundToStep(value, step, r.Mode())
+}
+
+// ceilRounding округление вверх
+type ceilRounding struct{}
+
+func (ceilRounding) Name() string { return RoundingCeil }
+
+func (ceilRounding) Mode() valueobjects.RoundingMode { return valueobjects.RoundCeil }
+
+func (r ceilRounding) Round(value, step float64) float64 {
+	return roundToStep(value, step, r.Mode())
+}
+
+// nearestRounding округление до ближайшего (половина - от нуля)
+type nearestRounding struct{}
+
+func (nearestRounding) Name() string { return RoundingNearest }
+
+func (nearestRounding) Mode() valueobjects.RoundingMode { return valueobjects.RoundHalfUp }
+
+func (r nearestRounding) Round(value, step float64) float64 {
+	return roundToStep(value, step, r.Mode())
+}
+
+// bankersRounding банковское округление (половина - к четному кратному)
+type bankersRounding struct{}
+
+func (bankersRounding) Name() string { return RoundingBankers }
+
+func (bankersRounding) Mode() valueobjects.RoundingMode { return valueobjects.RoundHalfEven }
+
+func (r bankersRounding) Round(value, step float64) float64 {
+	return roundToStep(value, step, r.Mode())
+}
+
+// roundToStep округляет value до кратного step в десятичной арифметике: результат - ровно n шагов
+// (0.3, а не 0.30000000000000004), поэтому его десятичная запись не содержит лишних знаков. Значение,
+// лежащее на шаге с точностью до шума float64, не сдвигается направленным округлением на шаг (см. Decimal.RoundToStep)
+func roundToStep(value, step float64, mode valueobjects.RoundingMode) float64 {
+	if step <= 0 {
+		return value
+	}
+	return valueobjects.NewDecimalFromFloat(value).RoundToStep(valueobjects.NewDecimalFromFloat(step), mode).Float64()
+}
+
+// NewRoundingPolicy возвращает политику округления по названию; для неизвестного названия - fallback
+func NewRoundingPolicy(name string, fallback RoundingPolicy) RoundingPolicy {
+	switch name {
+	case RoundingFloor:
+		return floorRounding{}
+	case RoundingCeil:
+		return ceilRounding{}
+	case RoundingNearest:
+		return nearestRounding{}
+	case RoundingBankers:
+		return bankersRounding{}
+	default:
+		return fallback
+	}
+}
+
+// roundingPolicies политики округления для каждой операции
+type roundingPolicies struct {
+	buyPrice  RoundingPolicy // Цена лимитной покупки
+	sellPrice RoundingPolicy // Цена тейк-профита
+	quantity  RoundingPolicy // Количество в ордере на покупку
+}
+
+// newRoundingPolicies собирает политики из конфигурации. По умолчанию цены округляются вверх,
+// чтобы покупка не оказалась ниже рынка, а тейк-профит - ниже запланированной прибыли;
+// количество - вниз, чтобы ордер не превысил выделенную сумму
+func newRoundingPolicies(config *HedgeStrategyConfig) roundingPolicies {
+	return roundingPolicies{
+		buyPrice:  NewRoundingPolicy(config.BuyPriceRounding, ceilRounding{}),
+		sellPrice: NewRoundingPolicy(config.SellPriceRounding, ceilRounding{}),
+		quantity:  NewRoundingPolicy(config.QuantityRounding, floorRounding{}),
+	}
+}
+
+// String возвращает политики в виде "цена покупки/цена продажи/количество" (для флагов поведения)
+func (r roundingPolicies) String() string {
+	return r.buyPrice.Name() + "/" + r.sellPrice.Name() + "/" + r.quantity.Name()
+}
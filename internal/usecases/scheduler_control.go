@@ -0,0 +1,261 @@
+package usecases
+
+import (
+	"sync"
+	"time"
+
+	"trade-hedge/internal/pkg/logger"
+)
+
+// SchedulerState состояние планировщика циклов стратегии
+type SchedulerState string
+
+const (
+	SchedulerStateStarting SchedulerState = "starting" // Планировщик еще не запущен
+	SchedulerStateWaiting  SchedulerState = "waiting"  // Ждет следующего цикла
+	SchedulerStateRunning  SchedulerState = "running"  // Выполняет цикл
+	SchedulerStatePaused   SchedulerState = "paused"   // Автоматическое хеджирование приостановлено оператором
+	SchedulerStateQuiet    SchedulerState = "quiet"    // Тихие часы: новые хеджи не открываются до конца окна
+	SchedulerStateStopped  SchedulerState = "stopped"  // Планировщик остановлен (завершение процесса)
+)
+
+// SchedulerStatus состояние планировщика для веб-интерфейса
+type SchedulerStatus struct {
+	State        SchedulerState
+	Paused       bool
+	PausedAt     *time.Time
+	PausedBy     string
+	PauseReason  string
+	CycleRunning bool       // Цикл выполняется (на паузе - проверка статусов без открытия хеджей)
+	LastCycleAt  *time.Time // Начало последнего цикла
+	NextCycleAt  *time.Time // Ожидаемое начало следующего цикла
+
+	Interval   time.Duration // Интервал циклов (0 - циклы по расписанию cron)
+	Schedule   string        // Расписание циклов cron ("" - каждые Interval)
+	QuietHours []string      // Тихие часы "ЧЧ:ММ-ЧЧ:ММ"
+	Timezone   string        // Часовой пояс расписания и тихих часов
+	QuietUntil *time.Time    // Конец текущего тихого окна (nil - сейчас не тихие часы)
+
+	CycleTimeout      time.Duration // Ограничение длительности цикла (0 - без ограничения)
+	LastCycleDuration time.Duration // Длительность последнего завершенного цикла
+	CyclesSkipped     int           // Циклы, пропущенные из-за выполняющегося или слишком долгого цикла
+	CyclesOverrun     int           // Циклы, не уложившиеся в период расписания
+	CyclesTimedOut    int           // Циклы, прерванные по таймауту
+}
+
+// SchedulerControl управляемое состояние планировщика: пауза автоматического хеджирования без остановки процесса.
+// На паузе циклы продолжают проверять статусы ордеров (тейк-профиты и стоп-лоссы открытых хеджей сопровождаются),
+// но новые хеджи не открываются. После снятия паузы цикл запускается сразу, не дожидаясь расписания.
+// Одновременно выполняется не больше одного цикла: цикл, начатый во время другого, пропускается
+type SchedulerControl struct {
+	schedule *CycleSchedule
+
+	mu           sync.Mutex
+	started      bool
+	stopped      bool
+	paused       bool
+	pausedAt     time.Time
+	pausedBy     string
+	pauseReason  string
+	cycleRunning bool
+	lastCycleAt  time.Time
+	nextCycleAt  time.Time
+
+	cycleTimeout      time.Duration
+	lastCycleDuration time.Duration
+	cyclesSkipped     int
+	cyclesOverrun     int
+	cyclesTimedOut    int
+
+	resumed chan struct{} // Сигнал планировщику о снятии паузы (буфер 1: сигналы не копятся)
+}
+
+// NewSchedulerControl создает состояние планировщика с расписанием циклов schedule
+func NewSchedulerControl(schedule *CycleSchedule) *SchedulerControl {
+	return &SchedulerControl{
+		schedule: schedule,
+		resumed:  make(chan struct{}, 1),
+	}
+}
+
+// WithSchedule заменяет расписание циклов (до запуска планировщика)
+func (c *SchedulerControl) WithSchedule(schedule *CycleSchedule) *SchedulerControl {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schedule = schedule
+	return c
+}
+
+// WithCycleTimeout задает ограничение длительности цикла для состояния планировщика
+func (c *SchedulerControl) WithCycleTimeout(timeout time.Duration) *SchedulerControl {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cycleTimeout = timeout
+	return c
+}
+
+// Pause приостанавливает автоматическое хеджирование. false - пауза уже действует
+func (c *SchedulerControl) Pause(author, reason string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.paused {
+		return false
+	}
+	c.paused = true
+	c.pausedAt = time.Now()
+	c.pausedBy = author
+	c.pauseReason = reason
+	logger.LogWithTime("⏸️ Автоматическое хеджирование приостановлено (%s): %s", author, reason)
+	return true
+}
+
+// Resume снимает паузу и запускает цикл, не дожидаясь интервала. false - пауза не действовала
+func (c *SchedulerControl) Resume(author string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.paused {
+		return false
+	}
+	c.paused = false
+	c.pausedAt = time.Time{}
+	c.pausedBy = ""
+	c.pauseReason = ""
+	logger.LogWithTime("▶️ Автоматическое хеджирование возобновлено (%s)", author)
+
+	select {
+	case c.resumed <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// Paused проверяет, приостановлено ли автоматическое хеджирование
+func (c *SchedulerControl) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// Resumed возвращает канал сигнала о снятии паузы (читает планировщик)
+func (c *SchedulerControl) Resumed() <-chan struct{} {
+	return c.resumed
+}
+
+// Started отмечает запуск планировщика
+func (c *SchedulerControl) Started() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.started = true
+}
+
+// Stopped отмечает остановку планировщика
+func (c *SchedulerControl) Stopped() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+}
+
+// CycleStarted отмечает начало цикла. false - предыдущий цикл еще выполняется: новый не начинается
+// и учитывается как пропущенный
+func (c *SchedulerControl) CycleStarted() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cycleRunning {
+		c.cyclesSkipped++
+		return false
+	}
+	c.cycleRunning = true
+	c.lastCycleAt = time.Now()
+	return true
+}
+
+// ScheduleNext отмечает ожидаемое начало следующего цикла по расписанию
+func (c *SchedulerControl) ScheduleNext(at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextCycleAt = at
+}
+
+// CycleFinished отмечает завершение цикла
+func (c *SchedulerControl) CycleFinished() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cycleRunning = false
+	c.lastCycleDuration = time.Since(c.lastCycleAt)
+}
+
+// CycleOverrun отмечает цикл, который не уложился в период расписания, и missed циклов, пропущенных за ним
+func (c *SchedulerControl) CycleOverrun(missed int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cyclesOverrun++
+	c.cyclesSkipped += missed
+}
+
+// CycleTimedOut отмечает цикл, прерванный по таймауту
+func (c *SchedulerControl) CycleTimedOut() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cyclesTimedOut++
+}
+
+// Status возвращает состояние планировщика
+func (c *SchedulerControl) Status() SchedulerStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	status := SchedulerStatus{
+		State:        c.state(now),
+		Paused:       c.paused,
+		PausedBy:     c.pausedBy,
+		PauseReason:  c.pauseReason,
+		CycleRunning: c.cycleRunning,
+		Interval:     c.schedule.Interval(),
+		Schedule:     c.schedule.Cron(),
+		QuietHours:   c.schedule.QuietHours(),
+		Timezone:     c.schedule.Location().String(),
+
+		CycleTimeout:      c.cycleTimeout,
+		LastCycleDuration: c.lastCycleDuration,
+		CyclesSkipped:     c.cyclesSkipped,
+		CyclesOverrun:     c.cyclesOverrun,
+		CyclesTimedOut:    c.cyclesTimedOut,
+	}
+	if window, quiet := c.schedule.QuietWindow(now); quiet {
+		quietUntil := window.End(now.In(c.schedule.Location()))
+		status.QuietUntil = &quietUntil
+	}
+	if c.paused {
+		pausedAt := c.pausedAt
+		status.PausedAt = &pausedAt
+	}
+	if !c.lastCycleAt.IsZero() {
+		lastCycleAt, nextCycleAt := c.lastCycleAt, c.nextCycleAt
+		status.LastCycleAt = &lastCycleAt
+		if !c.stopped && !nextCycleAt.IsZero() {
+			status.NextCycleAt = &nextCycleAt
+		}
+	}
+	return status
+}
+
+// state вычисляет состояние по флагам и тихим часам в момент now. Вызывается под mu
+func (c *SchedulerControl) state(now time.Time) SchedulerState {
+	switch {
+	case c.stopped:
+		return SchedulerStateStopped
+	case !c.started:
+		return SchedulerStateStarting
+	case c.paused:
+		return SchedulerStatePaused
+	case c.cycleRunning:
+		return SchedulerStateRunning
+	}
+	if _, quiet := c.schedule.QuietWindow(now); quiet {
+		return SchedulerStateQuiet
+	}
+	return SchedulerStateWaiting
+}
@@ -0,0 +1,377 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/repositories"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// runtimeSetting параметр стратегии, изменяемый во время работы
+type runtimeSetting struct {
+	key      string
+	title    string
+	get      func(config *HedgeStrategyConfig) float64
+	set      func(config *HedgeStrategyConfig, value float64)
+	validate func(value float64) error
+}
+
+// runtimeSettings параметры стратегии, которые можно изменить из веб-интерфейса без перезапуска
+var runtimeSettings = []runtimeSetting{
+	{
+		key:   entities.SettingPositionAmount,
+		title: "Сумма позиции",
+		get:   func(config *HedgeStrategyConfig) float64 { return config.PositionAmount },
+		set:   func(config *HedgeStrategyConfig, value float64) { config.PositionAmount = value },
+		validate: func(value float64) error {
+			if value <= 0 {
+				return fmt.Errorf("%s должен быть положительным, получен: %.2f", entities.SettingPositionAmount, value)
+			}
+			return nil
+		},
+	},
+	{
+		key:   entities.SettingMaxLossPercent,
+		title: "Максимальный убыток, %",
+		get:   func(config *HedgeStrategyConfig) float64 { return config.MaxLossPercent },
+		set:   func(config *HedgeStrategyConfig, value float64) { config.MaxLossPercent = value },
+		validate: func(value float64) error {
+			if value <= 0 || value >= 100 {
+				return fmt.Errorf("%s должен быть в диапазоне (0, 100), получен: %.2f", entities.SettingMaxLossPercent, value)
+			}
+			return nil
+		},
+	},
+	{
+		key:   entities.SettingProfitRatio,
+		title: "Коэффициент прибыли",
+		get:   func(config *HedgeStrategyConfig) float64 { return config.ProfitRatio },
+		set:   func(config *HedgeStrategyConfig, value float64) { config.ProfitRatio = value },
+		validate: func(value float64) error {
+			if value <= 0 {
+				return fmt.Errorf("%s должен быть положительным, получен: %.2f", entities.SettingProfitRatio, value)
+			}
+			return nil
+		},
+	},
+	{
+		key:   entities.SettingMinTakeProfitPercent,
+		title: "Минимальный тейк-профит, %",
+		get:   func(config *HedgeStrategyConfig) float64 { return config.MinTakeProfitPercent },
+		set:   func(config *HedgeStrategyConfig, value float64) { config.MinTakeProfitPercent = value },
+		validate: func(value float64) error {
+			if value < 0 {
+				return fmt.Errorf("%s не может быть отрицательным, получен: %.2f", entities.SettingMinTakeProfitPercent, value)
+			}
+			return nil
+		},
+	},
+	{
+		key:   entities.SettingBuyPriceOffsetPercent,
+		title: "Надбавка к цене покупки, %",
+		get:   func(config *HedgeStrategyConfig) float64 { return config.BuyPriceOffsetPercent },
+		set:   func(config *HedgeStrategyConfig, value float64) { config.BuyPriceOffsetPercent = value },
+		validate: func(value float64) error {
+			if value < 0 {
+				return fmt.Errorf("%s не может быть отрицательным, получен: %.2f", entities.SettingBuyPriceOffsetPercent, value)
+			}
+			return nil
+		},
+	},
+	{
+		key:   entities.SettingStopLossPercent,
+		title: "Стоп-лосс хеджа, %",
+		get:   func(config *HedgeStrategyConfig) float64 { return config.StopLossPercent },
+		set:   func(config *HedgeStrategyConfig, value float64) { config.StopLossPercent = value },
+		validate: func(value float64) error {
+			if value < 0 || value >= 100 {
+				return fmt.Errorf("%s должен быть в диапазоне [0, 100), получен: %.2f", entities.SettingStopLossPercent, value)
+			}
+			return nil
+		},
+	},
+	{
+		key:   entities.SettingMinPortfolioLoss,
+		title: "Минимальный убыток портфеля",
+		get:   func(config *HedgeStrategyConfig) float64 { return config.MinPortfolioLoss },
+		set:   func(config *HedgeStrategyConfig, value float64) { config.MinPortfolioLoss = value },
+		validate: func(value float64) error {
+			if value < 0 {
+				return fmt.Errorf("%s не может быть отрицательным, получен: %.2f", entities.SettingMinPortfolioLoss, value)
+			}
+			return nil
+		},
+	},
+}
+
+// IsRuntimeSetting проверяет, что параметр можно изменить во время работы (ключ - путь в YAML, Setting*)
+func IsRuntimeSetting(key string) bool {
+	_, ok := findRuntimeSetting(key)
+	return ok
+}
+
+// findRuntimeSetting возвращает описание параметра по ключу
+func findRuntimeSetting(key string) (runtimeSetting, bool) {
+	for _, setting := range runtimeSettings {
+		if setting.key == key {
+			return setting, true
+		}
+	}
+	return runtimeSetting{}, false
+}
+
+// MaxSettingOverride наибольшая длительность временного значения параметра
+const MaxSettingOverride = 7 * 24 * time.Hour
+
+// settingRevertAuthor автор записей журнала о возврате значений после окончания временных
+const settingRevertAuthor = "auto-revert"
+
+// SettingView состояние параметра: значение из файла конфигурации, сохраненное в БД и действующее
+type SettingView struct {
+	Key         string     `json:"key"`
+	Title       string     `json:"title"`
+	FileValue   float64    `json:"file_value"`
+	Value       float64    `json:"value"`
+	Overridden  bool       `json:"overridden"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	UpdatedBy   string     `json:"updated_by,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // Окончание временного значения
+	RevertValue *float64   `json:"revert_value"`         // Значение после окончания временного (null - из файла)
+}
+
+// SettingsUseCase хранит параметры стратегии, измененные во время работы: сохраненные в БД значения
+// переопределяют значения из файла конфигурации и применяются с начала следующего цикла стратегии
+type SettingsUseCase struct {
+	repo       repositories.SettingsRepository
+	fileValues map[string]float64 // Значения из файла конфигурации
+
+	mu        sync.RWMutex
+	overrides map[string]*entities.Setting
+}
+
+// NewSettingsUseCase создает use case параметров; значения из файла берутся из конфигурации стратегии
+func NewSettingsUseCase(repo repositories.SettingsRepository, config *HedgeStrategyConfig) *SettingsUseCase {
+	fileValues := make(map[string]float64, len(runtimeSettings))
+	for _, setting := range runtimeSettings {
+		fileValues[setting.key] = setting.get(config)
+	}
+	return &SettingsUseCase{
+		repo:       repo,
+		fileValues: fileValues,
+		overrides:  make(map[string]*entities.Setting),
+	}
+}
+
+// Load загружает сохраненные значения (при запуске). Неизвестные и некорректные значения пропускаются
+func (u *SettingsUseCase) Load(ctx context.Context) error {
+	settings, err := u.repo.GetSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.overrides = make(map[string]*entities.Setting, len(settings))
+	for _, stored := range settings {
+		setting, ok := findRuntimeSetting(stored.Key)
+		if !ok {
+			continue
+		}
+		if err := setting.validate(stored.Value); err != nil {
+			logger.LogWithTime("⚠️ Сохраненное значение параметра пропущено: %v", err)
+			continue
+		}
+		u.overrides[stored.Key] = stored
+		logger.LogWithTime("⚙️ Параметр %s = %g из БД (изменен %s, %s)",
+			stored.Key, stored.Value, stored.UpdatedBy, stored.UpdatedAt.Format(time.RFC3339))
+		if stored.IsTemporary() {
+			logger.LogWithTime("⏳ Значение %s временное: до %s", stored.Key, stored.ExpiresAt.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// Settings возвращает состояние всех изменяемых параметров
+func (u *SettingsUseCase) Settings() []SettingView {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	views := make([]SettingView, 0, len(runtimeSettings))
+	for _, setting := range runtimeSettings {
+		view := SettingView{
+			Key:       setting.key,
+			Title:     setting.title,
+			FileValue: u.fileValues[setting.key],
+			Value:     u.fileValues[setting.key],
+		}
+		if stored, ok := u.overrides[setting.key]; ok {
+			updatedAt := stored.UpdatedAt
+			view.Value = stored.Value
+			view.Overridden = true
+			view.UpdatedAt = &updatedAt
+			view.UpdatedBy = stored.UpdatedBy
+			view.ExpiresAt = stored.ExpiresAt
+			view.RevertValue = stored.RevertValue
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// Update сохраняет новое значение параметра; оно действует с начала следующего цикла и после перезапуска
+func (u *SettingsUseCase) Update(ctx context.Context, key string, value float64, author string) (*entities.SettingChange, error) {
+	setting, ok := findRuntimeSetting(key)
+	if !ok {
+		return nil, fmt.Errorf("параметр %s нельзя изменить во время работы", key)
+	}
+	if err := setting.validate(value); err != nil {
+		return nil, err
+	}
+
+	change, err := u.repo.SaveSetting(ctx, key, &value, 0, author)
+	if err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	u.overrides[key] = &entities.Setting{Key: key, Value: value, UpdatedAt: change.ChangedAt, UpdatedBy: author}
+	u.mu.Unlock()
+
+	logger.LogWithTime("⚙️ Параметр %s изменен на %g (%s)", key, value, author)
+	return change, nil
+}
+
+// Override сохраняет временное значение параметра на duration: по окончании автоматически возвращается
+// постоянное значение (сохраненное ранее или из файла), возврат записывается в журнал
+func (u *SettingsUseCase) Override(ctx context.Context, key string, value float64, duration time.Duration, author string) (*entities.SettingChange, error) {
+	setting, ok := findRuntimeSetting(key)
+	if !ok {
+		return nil, fmt.Errorf("параметр %s нельзя изменить во время работы", key)
+	}
+	if err := setting.validate(value); err != nil {
+		return nil, err
+	}
+	if duration <= 0 || duration > MaxSettingOverride {
+		return nil, fmt.Errorf("длительность временного значения должна быть от 0 до %v, получена: %v", MaxSettingOverride, duration)
+	}
+
+	change, err := u.repo.SaveSetting(ctx, key, &value, duration, author)
+	if err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	revertValue := u.overrides[key].BaseValue()
+	u.overrides[key] = &entities.Setting{
+		Key:         key,
+		Value:       value,
+		UpdatedAt:   change.ChangedAt,
+		UpdatedBy:   author,
+		ExpiresAt:   change.ExpiresAt,
+		RevertValue: revertValue,
+	}
+	u.mu.Unlock()
+
+	logger.LogWithTime("⏳ Параметр %s временно изменен на %g на %v, затем вернется к %s (%s)",
+		key, value, duration, u.formatRevert(key, revertValue), author)
+	return change, nil
+}
+
+// Expire возвращает постоянные значения параметров, временные значения которых закончились.
+// Вызывается в начале цикла стратегии; без закончившихся значений к БД не обращается
+func (u *SettingsUseCase) Expire(ctx context.Context) {
+	now := time.Now()
+
+	u.mu.RLock()
+	expired := false
+	for _, stored := range u.overrides {
+		expired = expired || stored.Expired(now)
+	}
+	u.mu.RUnlock()
+	if !expired {
+		return
+	}
+
+	changes, err := u.repo.RevertExpiredSettings(ctx, settingRevertAuthor)
+	if err != nil {
+		// Значение в памяти все равно возвращается: ApplyTo не применяет закончившиеся временные значения
+		logger.LogWithTime("⚠️ Не удалось записать возврат временных значений параметров: %v", err)
+	}
+	for _, change := range changes {
+		logger.LogWithTime("⌛ Временное значение %s = %g закончилось, действует %s",
+			change.Key, *change.OldValue, u.formatRevert(change.Key, change.NewValue))
+	}
+
+	// Возврат мог записать другой экземпляр: значения в памяти возвращаются в любом случае
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for key, stored := range u.overrides {
+		if !stored.Expired(now) {
+			continue
+		}
+		if stored.RevertValue == nil {
+			delete(u.overrides, key)
+			continue
+		}
+		u.overrides[key] = &entities.Setting{Key: key, Value: *stored.RevertValue, UpdatedAt: now, UpdatedBy: settingRevertAuthor}
+	}
+}
+
+// formatRevert описывает значение, которое действует после окончания временного
+func (u *SettingsUseCase) formatRevert(key string, revertValue *float64) string {
+	if revertValue == nil {
+		return fmt.Sprintf("значению из файла %g", u.fileValues[key])
+	}
+	return fmt.Sprintf("%g", *revertValue)
+}
+
+// Reset удаляет сохраненное значение: снова действует значение из файла конфигурации
+func (u *SettingsUseCase) Reset(ctx context.Context, key string, author string) (*entities.SettingChange, error) {
+	if _, ok := findRuntimeSetting(key); !ok {
+		return nil, fmt.Errorf("параметр %s нельзя изменить во время работы", key)
+	}
+
+	change, err := u.repo.SaveSetting(ctx, key, nil, 0, author)
+	if err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	delete(u.overrides, key)
+	u.mu.Unlock()
+
+	logger.LogWithTime("⚙️ Параметр %s сброшен к значению из файла %g (%s)", key, u.fileValues[key], author)
+	return change, nil
+}
+
+// History возвращает журнал изменений параметров (новые первыми)
+func (u *SettingsUseCase) History(ctx context.Context, limit int) ([]*entities.SettingChange, error) {
+	return u.repo.GetSettingChanges(ctx, limit)
+}
+
+// ApplyTo записывает действующие значения параметров в конфигурацию стратегии.
+// Закончившееся временное значение не применяется, даже если его возврат еще не записан
+func (u *SettingsUseCase) ApplyTo(config *HedgeStrategyConfig) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	now := time.Now()
+	for _, setting := range runtimeSettings {
+		value := u.fileValues[setting.key]
+		if stored, ok := u.overrides[setting.key]; ok {
+			value = stored.Value
+			if stored.Expired(now) {
+				value = u.fileValues[setting.key]
+				if stored.RevertValue != nil {
+					value = *stored.RevertValue
+				}
+			}
+		}
+		setting.set(config, value)
+	}
+}
@@ -3,40 +3,109 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"trade-hedge/internal/domain/entities"
 	"trade-hedge/internal/domain/repositories"
 	"trade-hedge/internal/domain/services"
+	"trade-hedge/internal/domain/valueobjects"
 	"trade-hedge/internal/pkg/logger"
+	"trade-hedge/internal/pkg/tracing"
 )
 
 // StatusCheckerUseCase отвечает за проверку статусов всех активных хеджированных ордеров
 type StatusCheckerUseCase struct {
 	hedgeRepo       repositories.HedgeRepository
+	intentRepo      repositories.HedgeIntentRepository
 	exchangeService services.ExchangeService
+	events          *OrderEventRecorder // История событий ордеров (nil - не сохраняется)
+	closer          *CloseExecutor      // Продажа позиции при эмуляции стоп-лосса
+	claims          *statusClaims       // Распределение хеджей между экземплярами (nil - проверяются все)
+	notifier        services.Notifier   // Оповещения о закрытых хеджах (nil - не отправляются)
+
+	mu       sync.Mutex
+	checking map[string]bool // Хеджи (по ID тейк-профита), статус которых проверяется сейчас
+	lifetime context.Context // Контекст приложения: внеочередные проверки прекращаются при его отмене
 }
 
 // NewStatusCheckerUseCase создает новый use case для проверки статусов
 func NewStatusCheckerUseCase(
 	hedgeRepo repositories.HedgeRepository,
+	intentRepo repositories.HedgeIntentRepository,
 	exchangeService services.ExchangeService,
 ) *StatusCheckerUseCase {
 	return &StatusCheckerUseCase{
 		hedgeRepo:       hedgeRepo,
+		intentRepo:      intentRepo,
 		exchangeService: exchangeService,
+		closer:          NewCloseExecutor(exchangeService, nil),
 	}
 }
 
+// WithCloseProtection включает защиту цены продажи при эмуляции стоп-лосса (см. CloseExecutor)
+func (s *StatusCheckerUseCase) WithCloseProtection(config *CloseExecutionConfig) *StatusCheckerUseCase {
+	s.closer = NewCloseExecutor(s.exchangeService, config)
+	return s
+}
+
+// WithOrderEventRepository включает запись смен статусов ордеров в историю событий
+func (s *StatusCheckerUseCase) WithOrderEventRepository(repo repositories.OrderEventRepository) *StatusCheckerUseCase {
+	s.events = NewOrderEventRecorder(repo)
+	return s
+}
+
+// WithNotifier включает оповещения о хеджах, закрытых тейк-профитом или стоп-лоссом (hedge_closed)
+func (s *StatusCheckerUseCase) WithNotifier(notifier services.Notifier) *StatusCheckerUseCase {
+	s.notifier = notifier
+	return s
+}
+
+// WithLifetime задает контекст приложения: внеочередные проверки, запланированные после размещения ордеров,
+// прекращаются при его отмене (завершение процесса), а не вместе с циклом или веб-запросом, который их запланировал
+func (s *StatusCheckerUseCase) WithLifetime(ctx context.Context) *StatusCheckerUseCase {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lifetime = ctx
+	return s
+}
+
+// statusClaims параметры захвата хеджей экземпляром с ролью status-checker
+type statusClaims struct {
+	repo       repositories.StatusClaimRepository
+	instanceID string
+	batch      int
+	ttl        time.Duration
+}
+
+// WithStatusClaims распределяет проверку статусов между несколькими экземплярами: каждый проверяет только
+// захваченные им хеджи (не более batch за цикл, 0 - без ограничения). Захват продлевается каждым циклом,
+// поэтому хедж остается за экземпляром; после его остановки захваты истекают через ttl и переходят к другим
+func (s *StatusCheckerUseCase) WithStatusClaims(repo repositories.StatusClaimRepository, instanceID string, batch int, ttl time.Duration) *StatusCheckerUseCase {
+	s.claims = &statusClaims{repo: repo, instanceID: instanceID, batch: batch, ttl: ttl}
+	return s
+}
+
 // CheckAllActiveOrders проверяет статусы всех активных хеджированных ордеров
-func (s *StatusCheckerUseCase) CheckAllActiveOrders(ctx context.Context) error {
+func (s *StatusCheckerUseCase) CheckAllActiveOrders(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "status.check_orders")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	logger.LogWithTime("🔍 Начинаем проверку статусов активных хеджированных ордеров...")
 
 	// 1. Получаем все активные хеджированные сделки
-	pendingStatus := "PENDING"
-	activeTrades, err := s.hedgeRepo.GetHedgedTrades(ctx, &pendingStatus)
+	activeTrades, err := s.ActiveTrades(ctx)
 	if err != nil {
-		return fmt.Errorf("ошибка получения активных хеджированных сделок: %w", err)
+		return err
+	}
+
+	if s.claims != nil {
+		if activeTrades, err = s.claimTrades(ctx, activeTrades); err != nil {
+			return err
+		}
 	}
 
 	if len(activeTrades) == 0 {
@@ -62,19 +131,127 @@ func (s *StatusCheckerUseCase) CheckAllActiveOrders(ctx context.Context) error {
 	}
 
 	logger.LogWithTime("✅ Проверка завершена. Обновлено статусов: %d из %d", updatedCount, len(activeTrades))
+	span.SetAttributes(tracing.Int("orders", len(activeTrades)), tracing.Int("updated", updatedCount))
 	return nil
 }
 
-// checkSingleOrderStatus проверяет статус одного ордера
+// claimTrades оставляет хеджи, захваченные этим экземпляром
+func (s *StatusCheckerUseCase) claimTrades(ctx context.Context, trades []*entities.HedgedTrade) ([]*entities.HedgedTrade, error) {
+	hedgeIDs := make([]int64, 0, len(trades))
+	for _, trade := range trades {
+		hedgeIDs = append(hedgeIDs, trade.HedgeID)
+	}
+
+	claimedIDs, err := s.claims.repo.ClaimHedges(ctx, s.claims.instanceID, hedgeIDs, s.claims.batch, s.claims.ttl)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка захвата хеджей для проверки статусов: %w", err)
+	}
+
+	claimed := make(map[int64]bool, len(claimedIDs))
+	for _, hedgeID := range claimedIDs {
+		claimed[hedgeID] = true
+	}
+	var owned []*entities.HedgedTrade
+	for _, trade := range trades {
+		if claimed[trade.HedgeID] {
+			owned = append(owned, trade)
+		}
+	}
+
+	if len(owned) < len(trades) {
+		logger.LogWithTime("🧩 Экземпляр %s захватил %d из %d активных хеджей, остальные проверяют другие экземпляры",
+			s.claims.instanceID, len(owned), len(trades))
+	}
+	return owned, nil
+}
+
+// ActiveTrades возвращает хеджи с активным тейк-профитом: все незавершенные статусы (PENDING, PARTIALLY_FILLED,
+// UNKNOWN), а не только PENDING, чтобы частично исполненный или нераспознанный ордер проверялся каждый цикл.
+// BUY_PENDING не входит: ожидающие покупки сопровождает стратегия (resumePendingBuys)
+func (s *StatusCheckerUseCase) ActiveTrades(ctx context.Context) ([]*entities.HedgedTrade, error) {
+	var trades []*entities.HedgedTrade
+	for _, status := range entities.ActiveOrderStatuses() {
+		if status == entities.OrderStatusBuyPending {
+			continue
+		}
+		value := status.String()
+		batch, err := s.hedgeRepo.GetHedgedTrades(ctx, &value)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения активных хеджированных сделок (%s): %w", status, err)
+		}
+		trades = append(trades, batch...)
+	}
+	return trades, nil
+}
+
+// checkSingleOrderStatus проверяет статус одного ордера. На время проверки хедж захватывается и перечитывается:
+// снимок trade мог устареть, пока хедж проверяла другая проверка (например, тейк-профит уже отменен
+// и остаток продан по стоп-лоссу)
 func (s *StatusCheckerUseCase) checkSingleOrderStatus(ctx context.Context, trade *entities.HedgedTrade) (bool, error) {
+	release, ok := s.claimCheck(trade.BybitOrderID)
+	if !ok {
+		logger.LogWithTime("⏭️ Ордер %s (пара %s) уже проверяется - проверка пропущена", trade.BybitOrderID, trade.Pair)
+		return false, nil
+	}
+	defer release()
+
+	current, err := s.findHedgedTrade(ctx, trade.FreqtradeTradeID, trade.BybitOrderID)
+	if err != nil {
+		return false, err
+	}
+	if current == nil || current.OrderStatus != trade.OrderStatus {
+		// Хедж уже обработан другой проверкой
+		return false, nil
+	}
+	return s.checkOrderStatus(ctx, current)
+}
+
+// claimCheck захватывает проверку хеджа с тейк-профитом orderID. Проверка цикла, ручная из веб-интерфейса
+// и внеочередная после размещения выполняются в разных горутинах: без захвата две из них могли бы одновременно
+// отменить тейк-профит и продать остаток по стоп-лоссу. false - хедж уже проверяется
+func (s *StatusCheckerUseCase) claimCheck(orderID string) (func(), bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.checking[orderID] {
+		return nil, false
+	}
+	if s.checking == nil {
+		s.checking = make(map[string]bool)
+	}
+	s.checking[orderID] = true
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.checking, orderID)
+	}, true
+}
+
+// checkOrderStatus проверяет статус ордера захваченного хеджа
+func (s *StatusCheckerUseCase) checkOrderStatus(ctx context.Context, trade *entities.HedgedTrade) (bool, error) {
 	// Получаем актуальный статус с биржи
 	statusInfo, err := s.exchangeService.GetOrderStatus(ctx, trade.BybitOrderID, trade.Pair)
 	if err != nil {
 		return false, fmt.Errorf("ошибка получения статуса ордера: %w", err)
 	}
+	if statusInfo == nil {
+		return false, fmt.Errorf("ордер не найден на бирже")
+	}
 
 	// Проверяем, изменился ли статус
 	if statusInfo.Status == trade.OrderStatus {
+		// Тейк-профит еще активен - проверяем связанный с ним стоп-лосс
+		if trade.HasStopLoss() && !statusInfo.Status.IsCompleted() {
+			closed, err := s.checkStopLoss(ctx, trade)
+			if err != nil {
+				logger.LogWithTime("⚠️ Ошибка проверки стоп-лосса хеджа %s (пара %s): %v", trade.BybitOrderID, trade.Pair, err)
+			}
+			if closed {
+				return true, nil
+			}
+		}
+
 		// Статус не изменился, обновляем только время последней проверки
 		err := s.hedgeRepo.UpdateHedgedTradeStatus(ctx, trade.BybitOrderID, trade.OrderStatus, trade.ClosePrice, trade.CloseTime)
 		if err != nil {
@@ -86,35 +263,178 @@ func (s *StatusCheckerUseCase) checkSingleOrderStatus(ctx context.Context, trade
 	// Статус изменился
 	logger.LogWithTime("🔄 Ордер %s (пара %s): %s → %s",
 		trade.BybitOrderID, trade.Pair, trade.OrderStatus, statusInfo.Status)
+	s.events.RecordStatus(ctx, trade.Pair, trade.OrderStatus, statusInfo)
 
-	// Подготавливаем данные для обновления
-	var closePrice *float64
-	var closeTime *time.Time
-
-	// Если ордер исполнен, сохраняем цену и время исполнения
+	// Если ордер исполнен, сохраняем цену, время исполнения и комиссию продажи
 	if statusInfo.Status == entities.OrderStatusFilled {
-		closePrice = statusInfo.FilledPrice
-		closeTime = statusInfo.FilledTime
+		// Тейк-профит исполнен - отменяем стоп-лосс OCO-пары
+		if trade.StopLossOrderID != "" {
+			s.cancelSurvivor(ctx, trade.StopLossOrderID, trade.Pair)
+		}
+
+		pair := valueobjects.NewTradingPair(trade.Pair)
+		now := time.Now()
+		closed := *trade
+		closed.OrderStatus = statusInfo.Status
+		closed.LastStatusCheck = &now
+		closed.ClosePrice = statusInfo.FilledPrice
+		closed.CloseTime = statusInfo.FilledTime
+		closed.ExitFee = feeInQuote(statusInfo, pair, trade.HedgeTakeProfitPrice)
+		if err := s.hedgeRepo.UpdateHedgedTrade(ctx, trade.BybitOrderID, &closed); err != nil {
+			return false, fmt.Errorf("ошибка обновления статуса в БД: %w", err)
+		}
+		closeHedgeIntentByOrderID(ctx, s.intentRepo, trade.BybitOrderID)
 
 		// Рассчитываем и выводим прибыль
-		if closePrice != nil {
-			profit := (*closePrice - trade.HedgeOpenPrice) * trade.HedgeAmount
-			logger.LogWithTime("💰 Хеджирование завершено! Прибыль: %.4f USDT", profit)
-			logger.LogWithTime("   📈 Открытие: %.4f, Закрытие: %.4f, Количество: %.4f",
-				trade.HedgeOpenPrice, *closePrice, trade.HedgeAmount)
+		if profit := closed.CalculateProfit(); profit != nil {
+			logger.LogWithTime("💰 Хеджирование завершено! Прибыль: %s %s (после комиссий %s %s)",
+				valueobjects.FormatAmount(profit.Gross, pair.QuoteCurrency()), pair.QuoteCurrency(),
+				valueobjects.FormatAmount(profit.Net, pair.QuoteCurrency()), pair.QuoteCurrency())
+			logger.LogWithTime("   📈 Открытие: %s, Закрытие: %s, Количество: %s",
+				pair.FormatPrice(trade.HedgeOpenPrice), pair.FormatPrice(*closed.ClosePrice),
+				valueobjects.FormatAmount(trade.HedgeAmount, pair.BaseCurrency()))
 		}
-	} else if statusInfo.Status.IsCompleted() {
+		s.notifyHedgeClosed(ctx, &closed, "take_profit")
+		return true, nil
+	}
+
+	// Тейк-профит отменен эмулированным стоп-лоссом, но остаток не продан (не удалось прочитать статус
+	// после отмены) - повторяем закрытие по стоп-лоссу, а не отмечаем хедж отмененным
+	if statusInfo.Status == entities.OrderStatusCancelled && trade.HasStopLoss() && trade.StopLossOrderID == "" {
+		closed, err := s.checkStopLoss(ctx, trade)
+		if err != nil {
+			return false, fmt.Errorf("ошибка закрытия по стоп-лоссу после отмены тейк-профита: %w", err)
+		}
+		if closed {
+			return true, nil
+		}
+	}
+
+	var closeTime *time.Time
+	if statusInfo.Status.IsCompleted() {
 		// Ордер завершен неуспешно (отменен или отклонен)
 		now := time.Now()
 		closeTime = &now
 		logger.LogWithTime("❌ Ордер %s завершен неуспешно: %s", trade.BybitOrderID, statusInfo.Status)
+		if statusInfo.FilledQty > 0 {
+			logger.LogWithTime("⚠️ До отмены исполнено %.6f из %.6f - проверьте остаток хеджа на бирже",
+				statusInfo.FilledQty, trade.HedgeAmount)
+		}
 	}
 
 	// Обновляем статус в базе данных
-	err = s.hedgeRepo.UpdateHedgedTradeStatus(ctx, trade.BybitOrderID, statusInfo.Status, closePrice, closeTime)
+	err = s.hedgeRepo.UpdateHedgedTradeStatus(ctx, trade.BybitOrderID, statusInfo.Status, nil, closeTime)
 	if err != nil {
 		return false, fmt.Errorf("ошибка обновления статуса в БД: %w", err)
 	}
 
+	// Тейк-профит завершен - хедж переходит в состояние CLOSED
+	if statusInfo.Status.IsCompleted() {
+		closeHedgeIntentByOrderID(ctx, s.intentRepo, trade.BybitOrderID)
+	}
+
 	return true, nil
 }
+
+// ScheduleEarlyChecks планирует внеочередные проверки статуса только что размещенного ордера.
+// Задержки отсчитываются от момента вызова; проверки прекращаются, как только ордер завершен.
+// Отмена ctx (завершение цикла или веб-запроса) проверки не прерывает - они прекращаются
+// с контекстом приложения (см. WithLifetime)
+func (s *StatusCheckerUseCase) ScheduleEarlyChecks(ctx context.Context, trade *entities.HedgedTrade, delays []time.Duration) {
+	if len(delays) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(s.lifetimeContext(), cancel)
+
+	go func() {
+		defer cancel()
+		defer stop()
+
+		start := time.Now()
+		for _, delay := range delays {
+			timer := time.NewTimer(time.Until(start.Add(delay)))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			current, err := s.findHedgedTrade(ctx, trade.FreqtradeTradeID, trade.BybitOrderID)
+			if err != nil {
+				logger.LogWithTime("⚠️ Внеочередная проверка ордера %s: %v", trade.BybitOrderID, err)
+				continue
+			}
+			if current == nil || !current.IsActive() {
+				// Ордер уже обработан обычной проверкой
+				return
+			}
+
+			logger.LogWithTime("⚡ Внеочередная проверка ордера %s (пара %s) через %v после размещения",
+				current.BybitOrderID, current.Pair, delay)
+			if _, err := s.checkSingleOrderStatus(ctx, current); err != nil {
+				logger.LogWithTime("❌ Ошибка внеочередной проверки ордера %s (пара %s): %v",
+					current.BybitOrderID, current.Pair, err)
+			}
+		}
+	}()
+}
+
+// lifetimeContext возвращает контекст приложения (без WithLifetime - не отменяется)
+func (s *StatusCheckerUseCase) lifetimeContext() context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lifetime == nil {
+		return context.Background()
+	}
+	return s.lifetime
+}
+
+// findHedgedTrade находит актуальное состояние хеджированной сделки по ID ордера
+func (s *StatusCheckerUseCase) findHedgedTrade(ctx context.Context, tradeID int, orderID string) (*entities.HedgedTrade, error) {
+	history, err := s.hedgeRepo.GetHedgeHistory(ctx, tradeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории хеджирования: %w", err)
+	}
+
+	for _, hedge := range history {
+		if hedge.BybitOrderID == orderID {
+			return hedge, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// notifyHedgeClosed оповещает о закрытом хедже с результатом после комиссий; reason - take_profit или stop_loss.
+// Ошибка отправки только логируется - закрытие уже сохранено
+func (s *StatusCheckerUseCase) notifyHedgeClosed(ctx context.Context, closed *entities.HedgedTrade, reason string) {
+	if s.notifier == nil {
+		return
+	}
+
+	pair := valueobjects.NewTradingPair(closed.Pair)
+	title := "Хедж закрыт по тейк-профиту: " + closed.Pair
+	if reason == "stop_loss" {
+		title = "Хедж закрыт по стоп-лоссу: " + closed.Pair
+	}
+	message := fmt.Sprintf("Сделка Freqtrade %d: продано %s %s", closed.FreqtradeTradeID,
+		valueobjects.FormatAmount(closed.HedgeAmount, pair.BaseCurrency()), pair.BaseCurrency())
+	if closed.ClosePrice != nil {
+		message += " по " + pair.FormatPrice(*closed.ClosePrice)
+	}
+	if profit := closed.CalculateProfit(); profit != nil {
+		message += fmt.Sprintf(", результат после комиссий %s %s",
+			valueobjects.FormatAmount(profit.Net, pair.QuoteCurrency()), pair.QuoteCurrency())
+	}
+
+	data := entities.HedgeEventData(closed)
+	data["reason"] = reason
+	notification := entities.NewNotification(entities.NotificationPriorityNormal, title, message).
+		WithEvent(entities.NotificationEventHedgeClosed, data)
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		logger.LogWithTime("❌ Ошибка отправки оповещения о закрытии хеджа %s: %v", closed.Pair, err)
+	}
+}
@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"trade-hedge/internal/adapters/repositories"
+	"trade-hedge/internal/domain/entities"
+)
+
+// newStopLossFixture создает хедж с эмулированным стоп-лоссом, цена которого уже достигнута
+func newStopLossFixture(t *testing.T) (*StatusCheckerUseCase, *fakeExchange, *repositories.MemoryHedgeRepository, *entities.HedgedTrade) {
+	t.Helper()
+
+	exchange := newFakeExchange(90)
+	exchange.latency = 5 * time.Millisecond
+	exchange.addOrder("tp-1")
+
+	hedgeRepo := repositories.NewMemoryHedgeRepository()
+	trade := &entities.HedgedTrade{
+		FreqtradeTradeID:     1,
+		Pair:                 "SOL/USDT",
+		HedgeTime:            time.Now(),
+		HedgeAmount:          2,
+		HedgeOpenPrice:       100,
+		HedgeTakeProfitPrice: 110,
+		StopLossPrice:        95,
+		BybitOrderID:         "tp-1",
+		OrderStatus:          entities.OrderStatusPending,
+	}
+	if err := hedgeRepo.SaveHedgedTrade(context.Background(), trade); err != nil {
+		t.Fatalf("SaveHedgedTrade: %v", err)
+	}
+
+	checker := NewStatusCheckerUseCase(hedgeRepo, repositories.NewMemoryHedgeIntentRepository(), exchange)
+	return checker, exchange, hedgeRepo, trade
+}
+
+// TestStatusCheckConcurrentStopLossSellsOnce проверяет, что проверка цикла и внеочередная проверка одного хеджа,
+// запущенные одновременно, продают позицию по стоп-лоссу один раз
+func TestStatusCheckConcurrentStopLossSellsOnce(t *testing.T) {
+	checker, exchange, hedgeRepo, trade := newStopLossFixture(t)
+	ctx := context.Background()
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		snapshot := *trade
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if _, err := checker.checkSingleOrderStatus(ctx, &snapshot); err != nil {
+				t.Errorf("checkSingleOrderStatus: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if got := exchange.sellCount(); got != 1 {
+		t.Fatalf("продаж по стоп-лоссу: %d, ожидалась 1", got)
+	}
+
+	history, err := hedgeRepo.GetHedgeHistory(ctx, trade.FreqtradeTradeID)
+	if err != nil {
+		t.Fatalf("GetHedgeHistory: %v", err)
+	}
+	if len(history) != 1 || history[0].OrderStatus != entities.OrderStatusFilled {
+		t.Fatalf("хедж не закрыт по стоп-лоссу: %+v", history)
+	}
+}
+
+// TestStatusCheckStaleSnapshotDoesNotSellAgain проверяет, что проверка с устаревшим снимком хеджа
+// (загруженным до закрытия по стоп-лоссу) не продает остаток повторно
+func TestStatusCheckStaleSnapshotDoesNotSellAgain(t *testing.T) {
+	checker, exchange, _, trade := newStopLossFixture(t)
+	ctx := context.Background()
+
+	stale := *trade
+	if _, err := checker.checkSingleOrderStatus(ctx, trade); err != nil {
+		t.Fatalf("checkSingleOrderStatus: %v", err)
+	}
+	if _, err := checker.checkSingleOrderStatus(ctx, &stale); err != nil {
+		t.Fatalf("checkSingleOrderStatus (устаревший снимок): %v", err)
+	}
+
+	if got := exchange.sellCount(); got != 1 {
+		t.Fatalf("продаж по стоп-лоссу: %d, ожидалась 1", got)
+	}
+}
+
+// TestCheckAllActiveOrdersPollsNonPendingStatuses проверяет, что частично исполненный тейк-профит и хедж
+// со статусом UNKNOWN проверяются каждый цикл, а не только PENDING
+func TestCheckAllActiveOrdersPollsNonPendingStatuses(t *testing.T) {
+	ctx := context.Background()
+	exchange := newFakeExchange(110)
+	hedgeRepo := repositories.NewMemoryHedgeRepository()
+
+	for i, status := range []entities.OrderStatus{entities.OrderStatusPartiallyFilled, entities.OrderStatusUnknown} {
+		orderID := fmt.Sprintf("tp-%d", i+1)
+		exchange.addOrder(orderID)
+		exchange.orders[orderID].Status = entities.OrderStatusFilled
+		trade := &entities.HedgedTrade{
+			FreqtradeTradeID:     i + 1,
+			Pair:                 "SOL/USDT",
+			HedgeTime:            time.Now(),
+			HedgeAmount:          1,
+			HedgeOpenPrice:       100,
+			HedgeTakeProfitPrice: 110,
+			BybitOrderID:         orderID,
+			OrderStatus:          status,
+		}
+		if err := hedgeRepo.SaveHedgedTrade(ctx, trade); err != nil {
+			t.Fatalf("SaveHedgedTrade: %v", err)
+		}
+	}
+
+	checker := NewStatusCheckerUseCase(hedgeRepo, repositories.NewMemoryHedgeIntentRepository(), exchange)
+	for cycle := 0; cycle < 2; cycle++ {
+		if err := checker.CheckAllActiveOrders(ctx); err != nil {
+			t.Fatalf("CheckAllActiveOrders: %v", err)
+		}
+	}
+
+	active, err := checker.ActiveTrades(ctx)
+	if err != nil {
+		t.Fatalf("ActiveTrades: %v", err)
+	}
+	if len(active) != 0 {
+		t.Fatalf("после проверки остались активные хеджи: %d", len(active))
+	}
+}
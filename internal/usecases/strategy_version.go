@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"trade-hedge/internal/domain/entities"
+)
+
+// StrategyVersion версия логики хеджирования, сохраняемая с каждым хеджем.
+// Увеличивается при каждом изменении поведения стратегии, чтобы аналитика могла отличить
+// влияние изменений кода от изменений рынка. Может быть переопределена при сборке:
+// go build -ldflags "-X trade-hedge/internal/usecases.StrategyVersion=..."
+var StrategyVersion = "1.14.0"
+
+// FeatureFlags возвращает активные флаги поведения стратегии в виде отсортированной строки "ключ=значение,..."
+func FeatureFlags(config *HedgeStrategyConfig) string {
+	flags := map[string]string{
+		"strategy":          config.StrategyName,
+		"leave_buy_pending": strconv.FormatBool(config.LeaveBuyPending),
+		"buy_offset_pct":    formatFlagFloat(config.BuyPriceOffsetPercent),
+		"slippage_pct":      formatFlagFloat(config.SlippageBufferPercent),
+		"price_guard_pct":   formatFlagFloat(config.MaxPriceDeviationPercent),
+		"tp_floor_ticks":    strconv.Itoa(config.MinTakeProfitTicks),
+		"tp_floor_pct":      formatFlagFloat(config.MinTakeProfitPercent),
+		"parallel_hedges":   strconv.Itoa(config.MaxParallelHedges),
+		"rounding":          newRoundingPolicies(config).String(),
+		"stop_loss_pct":     formatFlagFloat(config.StopLossPercent),
+		"buy_fallback":      config.BuyFallback,
+		"passive_entry_s":   strconv.Itoa(int(config.PassiveEntryTimeout.Seconds())),
+		"portfolio_gate":    fmt.Sprintf("%d/%s", config.MinLosingTrades, formatFlagFloat(config.MinPortfolioLoss)),
+		"convert_pairs":     strconv.Itoa(len(config.ConvertPairs)),
+		"priority":          NewTradePrioritizer(config).Name(),
+	}
+	if config.StrategyName == StrategyMartingaleLadder {
+		flags["martingale"] = fmt.Sprintf("%sx%d", formatFlagFloat(config.MartingaleMultiplier), config.MartingaleMaxSteps)
+	}
+
+	pairs := make([]string, 0, len(flags))
+	for key, value := range flags {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// formatFlagFloat форматирует число без лишних нулей
+func formatFlagFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// tagHedge помечает хедж версией стратегии и флагами, активными на момент его создания.
+// Хеджи по внешним сигналам дополнительно помечаются флагом signal=true
+func (h *HedgeStrategyUseCase) tagHedge(hedgedTrade *entities.HedgedTrade) {
+	hedgedTrade.StrategyVersion = StrategyVersion
+	hedgedTrade.FeatureFlags = h.featureFlags
+	if entities.IsSignalTradeID(hedgedTrade.FreqtradeTradeID) {
+		hedgedTrade.FeatureFlags = withFeatureFlag(hedgedTrade.FeatureFlags, "signal", "true")
+	}
+}
@@ -0,0 +1,30 @@
+package usecases
+
+import "trade-hedge/internal/domain/valueobjects"
+
+// applyPercent возвращает value * (1 + percent/100) в десятичной арифметике, чтобы цена, кратная шагу,
+// не сдвигалась на шаг при последующем округлении из-за погрешности float
+func applyPercent(value, percent float64) float64 {
+	return valueobjects.NewDecimalFromFloat(value).ApplyPercent(valueobjects.NewDecimalFromFloat(percent)).Float64()
+}
+
+// applyTakeProfitFloor поднимает цену тейк-профита до минимального расстояния от цены покупки
+// (в шагах цены и в процентах) и округляет результат до шага цены политикой rounding.
+// При округлении вверх (по умолчанию) прибыль после округления не меньше запланированной
+func applyTakeProfitFloor(rawPrice, entryPrice, tickSize float64, minTicks int, minPercent float64, rounding RoundingPolicy) float64 {
+	price := rawPrice
+
+	if floor := applyPercent(entryPrice, minPercent); price < floor {
+		price = floor
+	}
+	if tickSize > 0 {
+		floor := valueobjects.NewDecimalFromFloat(entryPrice).
+			Add(valueobjects.NewDecimal(int64(minTicks), 0).Mul(valueobjects.NewDecimalFromFloat(tickSize))).
+			Float64()
+		if price < floor {
+			price = floor
+		}
+	}
+
+	return rounding.Round(price, tickSize)
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"trade-hedge/internal/domain/entities"
+	"trade-hedge/internal/domain/errors"
+	"trade-hedge/internal/pkg/logger"
+)
+
+// TradeDetails сделка Freqtrade со всеми ее хеджами для страницы сделки
+type TradeDetails struct {
+	Trade  *entities.Trade         // Сделка Freqtrade (nil - не найдена ни среди открытых, ни в истории Freqtrade)
+	Hedges []*entities.HedgedTrade // Хеджи сделки, включая архив (старые первыми)
+}
+
+// GetTradeDetails собирает сделку Freqtrade и историю ее хеджей. Сделка ищется среди открытых, затем
+// в истории Freqtrade; если Freqtrade недоступен, страница строится по данным, сохраненным с хеджами.
+// Ошибка ErrorTypeTradeNotFound - нет ни сделки, ни хеджей
+func (h *HedgeStrategyUseCase) GetTradeDetails(ctx context.Context, tradeID int) (*TradeDetails, error) {
+	hedges, err := h.hedgeRepo.GetHedgeHistory(ctx, tradeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории хеджей сделки %d: %w", tradeID, err)
+	}
+	sort.SliceStable(hedges, func(i, j int) bool {
+		return hedges[i].HedgeTime.Before(hedges[j].HedgeTime)
+	})
+
+	trade, err := h.findFreqtradeTrade(ctx, tradeID)
+	if err != nil {
+		if len(hedges) == 0 {
+			return nil, err
+		}
+		logger.LogWithTime("⚠️ Сделка %d: %v - показываются данные хеджей", tradeID, err)
+	}
+	if trade == nil && len(hedges) == 0 {
+		return nil, errors.NewTradeNotFoundError(tradeID)
+	}
+
+	return &TradeDetails{Trade: trade, Hedges: hedges}, nil
+}
+
+// findFreqtradeTrade ищет сделку среди открытых сделок Freqtrade, затем в истории (nil - не найдена)
+func (h *HedgeStrategyUseCase) findFreqtradeTrade(ctx context.Context, tradeID int) (*entities.Trade, error) {
+	active, err := h.tradeService.GetActiveTrades(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения активных сделок: %w", err)
+	}
+	for _, trade := range active {
+		if trade.ID == tradeID {
+			return trade, nil
+		}
+	}
+
+	closed, err := h.tradeService.GetClosedTrades(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения закрытых сделок Freqtrade: %w", err)
+	}
+	for _, trade := range closed {
+		if trade.ID == tradeID {
+			return trade, nil
+		}
+	}
+	return nil, nil
+}
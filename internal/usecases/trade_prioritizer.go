@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"sort"
+	"strings"
+
+	"trade-hedge/internal/domain/entities"
+)
+
+// Политики приоритизации сделок (strategy.priority) и ключи сортировки (strategy.priority_tiebreakers)
+const (
+	PriorityDrawdown = "drawdown" // Сначала сделки с наибольшей просадкой
+	PriorityNotional = "notional" // Сначала сделки с наибольшей стоимостью позиции
+	PriorityLoss     = "loss"     // Сначала сделки с наибольшим убытком в котируемой валюте
+	PriorityAge      = "age"      // Сначала самые старые сделки
+	PriorityPairs    = "pairs"    // В порядке списка strategy.priority_pairs, остальные - по дополнительным ключам
+)
+
+// tradeCompare сравнивает сделки по одному ключу: отрицательное значение - a хеджируется раньше,
+// положительное - позже, 0 - ключ не различает сделки
+type tradeCompare func(a, b *entities.Trade) int
+
+// tradeSortKeys ключи сортировки, доступные для основной политики и дополнительных ключей
+var tradeSortKeys = map[string]tradeCompare{
+	PriorityDrawdown: func(a, b *entities.Trade) int {
+		// ProfitRatio отрицательный при убытке: большая просадка - меньшее значение
+		return compareFloats(a.ProfitRatio, b.ProfitRatio)
+	},
+	PriorityNotional: func(a, b *entities.Trade) int {
+		return compareFloats(b.NotionalAtRisk(), a.NotionalAtRisk())
+	},
+	PriorityLoss: func(a, b *entities.Trade) int {
+		return compareFloats(a.UnrealizedProfit(), b.UnrealizedProfit())
+	},
+	PriorityAge: func(a, b *entities.Trade) int {
+		// Сделки без времени открытия идут последними
+		switch {
+		case a.OpenTime.IsZero() && b.OpenTime.IsZero():
+			return 0
+		case a.OpenTime.IsZero():
+			return 1
+		case b.OpenTime.IsZero():
+			return -1
+		case a.OpenTime.Before(b.OpenTime):
+			return -1
+		case b.OpenTime.Before(a.OpenTime):
+			return 1
+		}
+		return 0
+	},
+}
+
+// IsTradeSortKey проверяет, что ключ сортировки поддерживается (для валидации дополнительных ключей)
+func IsTradeSortKey(key string) bool {
+	_, ok := tradeSortKeys[key]
+	return ok
+}
+
+// TradePrioritizer упорядочивает отобранные для хеджирования сделки
+type TradePrioritizer interface {
+	// Name возвращает название политики
+	Name() string
+
+	// Prioritize сортирует сделки на месте: первыми идут сделки, которые хеджируются раньше
+	Prioritize(trades []*entities.Trade)
+}
+
+// NewTradePrioritizer создает политику приоритизации по названию из конфигурации (по умолчанию - drawdown)
+// с дополнительными ключами из PriorityTiebreakers
+func NewTradePrioritizer(config *HedgeStrategyConfig) TradePrioritizer {
+	switch config.Priority {
+	case PriorityPairs:
+		return NewPairListPrioritizer(config.PriorityPairs, config.PriorityTiebreakers)
+	case PriorityNotional, PriorityLoss, PriorityAge:
+		return NewSortKeyPrioritizer(config.Priority, config.PriorityTiebreakers)
+	default:
+		return NewSortKeyPrioritizer(PriorityDrawdown, config.PriorityTiebreakers)
+	}
+}
+
+// SortKeyPrioritizer упорядочивает сделки по основному ключу, при равенстве - по дополнительным ключам
+// в заданном порядке, затем по просадке. Сортировка устойчивая: полностью равные сделки сохраняют порядок Freqtrade
+type SortKeyPrioritizer struct {
+	name    string
+	compare []tradeCompare
+}
+
+// NewSortKeyPrioritizer создает политику по основному ключу и дополнительным ключам; неизвестные ключи пропускаются
+func NewSortKeyPrioritizer(primary string, tiebreakers []string) *SortKeyPrioritizer {
+	keys := sortKeyChain(append([]string{primary}, tiebreakers...))
+	if len(keys) == 0 {
+		keys = []string{PriorityDrawdown}
+	}
+	return &SortKeyPrioritizer{name: strings.Join(keys, "+"), compare: sortKeyComparators(keys)}
+}
+
+// Name возвращает название политики: основной ключ и дополнительные ключи через "+"
+func (p *SortKeyPrioritizer) Name() string {
+	return p.name
+}
+
+// Prioritize сортирует сделки по цепочке ключей
+func (p *SortKeyPrioritizer) Prioritize(trades []*entities.Trade) {
+	sort.SliceStable(trades, func(i, j int) bool {
+		return compareChain(p.compare, trades[i], trades[j]) < 0
+	})
+}
+
+// PairListPrioritizer ставит первыми пары из списка в порядке списка
+type PairListPrioritizer struct {
+	rank    map[string]int // Позиция пары в списке (в верхнем регистре)
+	name    string
+	compare []tradeCompare // Ключи для пар вне списка и сделок одной пары
+}
+
+// NewPairListPrioritizer создает политику приоритизации по списку пар;
+// пары вне списка и сделки одной пары упорядочиваются по дополнительным ключам, затем по просадке
+func NewPairListPrioritizer(pairs []string, tiebreakers []string) *PairListPrioritizer {
+	rank := make(map[string]int, len(pairs))
+	for i, pair := range pairs {
+		key := strings.ToUpper(strings.TrimSpace(pair))
+		if _, ok := rank[key]; !ok {
+			rank[key] = i
+		}
+	}
+
+	keys := sortKeyChain(tiebreakers)
+	name := strings.Join(append([]string{PriorityPairs}, keys...), "+")
+	return &PairListPrioritizer{rank: rank, name: name, compare: sortKeyComparators(keys)}
+}
+
+// Name возвращает название политики
+func (p *PairListPrioritizer) Name() string {
+	return p.name
+}
+
+// Prioritize сортирует сделки по позиции пары в списке, затем по дополнительным ключам
+func (p *PairListPrioritizer) Prioritize(trades []*entities.Trade) {
+	sort.SliceStable(trades, func(i, j int) bool {
+		left, right := p.pairRank(trades[i]), p.pairRank(trades[j])
+		if left != right {
+			return left < right
+		}
+		return compareChain(p.compare, trades[i], trades[j]) < 0
+	})
+}
+
+// pairRank возвращает позицию пары сделки в списке (пары вне списка - после всех)
+func (p *PairListPrioritizer) pairRank(trade *entities.Trade) int {
+	if rank, ok := p.rank[strings.ToUpper(trade.Pair)]; ok {
+		return rank
+	}
+	return len(p.rank)
+}
+
+// sortKeyChain оставляет известные ключи без повторов
+func sortKeyChain(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	chain := make([]string, 0, len(keys))
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if seen[key] || !IsTradeSortKey(key) {
+			continue
+		}
+		seen[key] = true
+		chain = append(chain, key)
+	}
+	return chain
+}
+
+// sortKeyComparators возвращает функции сравнения для цепочки ключей, завершенной просадкой
+func sortKeyComparators(keys []string) []tradeCompare {
+	compare := make([]tradeCompare, 0, len(keys)+1)
+	hasDrawdown := false
+	for _, key := range keys {
+		compare = append(compare, tradeSortKeys[key])
+		hasDrawdown = hasDrawdown || key == PriorityDrawdown
+	}
+	if !hasDrawdown {
+		compare = append(compare, tradeSortKeys[PriorityDrawdown])
+	}
+	return compare
+}
+
+// compareChain сравнивает сделки по ключам по очереди до первого различия
+func compareChain(compare []tradeCompare, a, b *entities.Trade) int {
+	for _, cmp := range compare {
+		if result := cmp(a, b); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// compareFloats сравнивает числа: -1 если a < b, 1 если a > b, 0 при равенстве
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
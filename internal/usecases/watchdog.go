@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Компоненты, за которыми следит сторожевой таймер
+const (
+	WatchdogStrategy    = "стратегия хеджирования"
+	WatchdogStatusCheck = "проверка статусов"
+)
+
+// StalledComponent компонент, цикл которого не завершался дольше порога
+type StalledComponent struct {
+	Name          string
+	LastCompleted time.Time // Время последнего завершенного цикла (или начала наблюдения)
+	Idle          time.Duration
+}
+
+// Watchdog отслеживает время последнего успешного завершения периодических циклов
+type Watchdog struct {
+	mu        sync.Mutex
+	threshold time.Duration
+	last      map[string]time.Time
+}
+
+// NewWatchdog создает сторожевой таймер с порогом зависания
+func NewWatchdog(threshold time.Duration) *Watchdog {
+	return &Watchdog{
+		threshold: threshold,
+		last:      make(map[string]time.Time),
+	}
+}
+
+// Threshold возвращает порог зависания
+func (w *Watchdog) Threshold() time.Duration {
+	return w.threshold
+}
+
+// Track начинает наблюдение за компонентом; отсчет идет от момента вызова
+func (w *Watchdog) Track(name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.last[name]; !ok {
+		w.last[name] = time.Now()
+	}
+}
+
+// MarkCompleted отмечает успешное завершение цикла компонента
+func (w *Watchdog) MarkCompleted(name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.last[name] = time.Now()
+}
+
+// Stalled возвращает компоненты, не завершавшие цикл дольше порога (по имени)
+func (w *Watchdog) Stalled(now time.Time) []StalledComponent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var stalled []StalledComponent
+	for name, last := range w.last {
+		if idle := now.Sub(last); idle > w.threshold {
+			stalled = append(stalled, StalledComponent{Name: name, LastCompleted: last, Idle: idle})
+		}
+	}
+
+	sort.Slice(stalled, func(i, j int) bool {
+		return stalled[i].Name < stalled[j].Name
+	})
+	return stalled
+}